@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/prompt"
+)
+
+// ConfirmCommands warns that the specified source (e.g. a project file)
+// specifies commands (e.g. SSH proxy commands or hooks) that will be run with
+// the user's credentials and asks the user to confirm that they should be
+// allowed. It returns an error if the user doesn't
+// confirm or if confirmation isn't possible. Duplicate commands are only shown
+// once. If no commands are specified, then no confirmation is requested.
+func ConfirmCommands(source string, commands []string) error {
+	// If there are no commands, then there's nothing to confirm.
+	if len(commands) == 0 {
+		return nil
+	}
+
+	// Warn about the commands.
+	Warning(fmt.Sprintf("%s specifies commands that will be run with your credentials:", source))
+	shown := make(map[string]bool, len(commands))
+	for _, command := range commands {
+		if !shown[command] {
+			fmt.Fprintln(os.Stderr, "    "+command)
+			shown[command] = true
+		}
+	}
+
+	// Request confirmation.
+	response, err := prompt.PromptCommandLine("Allow these commands to run (yes/no)? ")
+	if err != nil {
+		return errors.Wrap(err, "unable to confirm commands")
+	} else if strings.ToLower(strings.TrimSpace(response)) != "yes" {
+		return errors.New("commands not allowed")
+	}
+
+	// Success.
+	return nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"net"
 	"os"
 	"os/signal"
 	"time"
@@ -14,7 +15,6 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/agent"
 	"github.com/mutagen-io/mutagen/pkg/housekeeping"
 	"github.com/mutagen-io/mutagen/pkg/logging"
-	"github.com/mutagen-io/mutagen/pkg/mutagen"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/endpoint/remote"
 )
 
@@ -59,17 +59,15 @@ func endpointMain(command *cobra.Command, arguments []string) error {
 	go housekeepRegularly(housekeepingContext, logging.RootLogger.Sublogger("housekeeping"))
 
 	// Create a connection on standard input/output.
-	connection := newStdioConnection()
+	var connection net.Conn = newStdioConnection()
 
-	// Perform an agent handshake.
-	if err := agent.ServerHandshake(connection); err != nil {
+	// Perform an agent handshake and associate the negotiated capabilities
+	// with the connection.
+	negotiation, err := agent.ServerHandshake(connection)
+	if err != nil {
 		return errors.Wrap(err, "server handshake failed")
 	}
-
-	// Perform a version handshake.
-	if err := mutagen.ServerVersionHandshake(connection); err != nil {
-		return errors.Wrap(err, "version handshake error")
-	}
+	connection = agent.WithNegotiation(connection, negotiation)
 
 	// Serve an endpoint on standard input/output and monitor for its
 	// termination.
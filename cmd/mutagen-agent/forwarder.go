@@ -1,6 +1,7 @@
 package main
 
 import (
+	"net"
 	"os"
 	"os/signal"
 
@@ -12,7 +13,6 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/agent"
 	"github.com/mutagen-io/mutagen/pkg/forwarding/endpoint/remote"
 	"github.com/mutagen-io/mutagen/pkg/logging"
-	"github.com/mutagen-io/mutagen/pkg/mutagen"
 )
 
 func forwarderMain(command *cobra.Command, arguments []string) error {
@@ -23,17 +23,15 @@ func forwarderMain(command *cobra.Command, arguments []string) error {
 	signal.Notify(signalTermination, cmd.TerminationSignals...)
 
 	// Create a connection on standard input/output.
-	connection := newStdioConnection()
+	var connection net.Conn = newStdioConnection()
 
-	// Perform an agent handshake.
-	if err := agent.ServerHandshake(connection); err != nil {
+	// Perform an agent handshake and associate the negotiated capabilities
+	// with the connection.
+	negotiation, err := agent.ServerHandshake(connection)
+	if err != nil {
 		return errors.Wrap(err, "server handshake failed")
 	}
-
-	// Perform a version handshake.
-	if err := mutagen.ServerVersionHandshake(connection); err != nil {
-		return errors.Wrap(err, "version handshake error")
-	}
+	connection = agent.WithNegotiation(connection, negotiation)
 
 	// Serve a forwarder on standard input/output and monitor for its
 	// termination.
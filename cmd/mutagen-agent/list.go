@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+)
+
+func listMain(command *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) > 0 {
+		return errors.New("unexpected arguments provided")
+	}
+
+	// Query installed agents.
+	agents, err := agent.InstalledAgents()
+	if err != nil {
+		return errors.Wrap(err, "unable to list installed agents")
+	}
+
+	// Print installed agents.
+	return agent.WriteInstalledAgents(os.Stdout, agents)
+}
+
+var listCommand = &cobra.Command{
+	Use:          agent.ModeList,
+	Short:        "List installed agent versions",
+	RunE:         listMain,
+	SilenceUsage: true,
+}
+
+var listConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := listCommand.Flags()
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&listConfiguration.help, "help", "h", false, "Show help information")
+}
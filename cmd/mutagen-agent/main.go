@@ -61,6 +61,11 @@ func init() {
 		installCommand,
 		endpointCommand,
 		forwarderCommand,
+		multiplexerCommand,
+		serveCommand,
+		listCommand,
+		uninstallCommand,
+		pruneCommand,
 		versionCommand,
 		legalCommand,
 	)
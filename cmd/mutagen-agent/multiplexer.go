@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/signal"
+
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	forwardingremote "github.com/mutagen-io/mutagen/pkg/forwarding/endpoint/remote"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	synchronizationremote "github.com/mutagen-io/mutagen/pkg/synchronization/endpoint/remote"
+)
+
+// modeHandlers returns the handlers used to serve modes requested via mode
+// requests (e.g. over multiplexed streams or direct connections).
+func modeHandlers() map[string]agent.ModeHandler {
+	return map[string]agent.ModeHandler{
+		agent.ModeEndpoint: func(connection net.Conn) error {
+			return synchronizationremote.ServeEndpoint(logging.RootLogger.Sublogger("endpoint"), connection)
+		},
+		agent.ModeForwarder: func(connection net.Conn) error {
+			return forwardingremote.ServeEndpoint(logging.RootLogger.Sublogger("forwarder"), connection)
+		},
+	}
+}
+
+func multiplexerMain(command *cobra.Command, arguments []string) error {
+	// Create a channel to track termination signals. We do this before creating
+	// and starting other infrastructure so that we can ensure things terminate
+	// smoothly, not mid-initialization.
+	signalTermination := make(chan os.Signal, 1)
+	signal.Notify(signalTermination, cmd.TerminationSignals...)
+
+	// Set up regular housekeeping and defer its shutdown. Since we may serve
+	// synchronization endpoints, we perform the same housekeeping as endpoint
+	// mode.
+	housekeepingContext, housekeepingCancel := context.WithCancel(context.Background())
+	defer housekeepingCancel()
+	go housekeepRegularly(housekeepingContext, logging.RootLogger.Sublogger("housekeeping"))
+
+	// Create a connection on standard input/output.
+	var connection net.Conn = newStdioConnection()
+
+	// Perform an agent handshake and associate the negotiated capabilities
+	// with the connection.
+	negotiation, err := agent.ServerHandshake(connection)
+	if err != nil {
+		return errors.Wrap(err, "server handshake failed")
+	}
+	connection = agent.WithNegotiation(connection, negotiation)
+
+	// Serve multiplexed streams on standard input/output and monitor for
+	// termination.
+	multiplexerTermination := make(chan error, 1)
+	go func() {
+		multiplexerTermination <- agent.ServeMultiplexer(logging.RootLogger, connection, modeHandlers())
+	}()
+
+	// Wait for termination from a signal or the multiplexer.
+	select {
+	case sig := <-signalTermination:
+		return errors.Errorf("terminated by signal: %s", sig)
+	case err := <-multiplexerTermination:
+		return errors.Wrap(err, "multiplexer terminated")
+	}
+}
+
+var multiplexerCommand = &cobra.Command{
+	Use:          agent.ModeMultiplexer,
+	Short:        "Run the agent in multiplexer mode",
+	RunE:         multiplexerMain,
+	SilenceUsage: true,
+}
+
+var multiplexerConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := multiplexerCommand.Flags()
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&multiplexerConfiguration.help, "help", "h", false, "Show help information")
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+)
+
+func pruneMain(command *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) > 0 {
+		return errors.New("unexpected arguments provided")
+	}
+
+	// Query installed agents.
+	agents, err := agent.InstalledAgents()
+	if err != nil {
+		return errors.Wrap(err, "unable to list installed agents")
+	}
+
+	// Remove all versions other than our own, printing each removed version.
+	for _, a := range agents {
+		if a.Version == agent.InstallationVersion {
+			continue
+		}
+		if err := agent.UninstallVersion(a.Version); err != nil {
+			return errors.Wrapf(err, "unable to uninstall version %s", a.Version)
+		}
+		fmt.Println(a.Version)
+	}
+
+	// Success.
+	return nil
+}
+
+var pruneCommand = &cobra.Command{
+	Use:          agent.ModePrune,
+	Short:        "Remove installed agent versions other than this one",
+	RunE:         pruneMain,
+	SilenceUsage: true,
+}
+
+var pruneConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := pruneCommand.Flags()
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&pruneConfiguration.help, "help", "h", false, "Show help information")
+}
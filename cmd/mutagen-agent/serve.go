@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"os"
+	"os/signal"
+
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/pkg/agent/direct"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	forwardingurlpkg "github.com/mutagen-io/mutagen/pkg/url/forwarding"
+)
+
+// tokenEnvironmentVariable is the environment variable from which the
+// authentication token is read if no token file is specified.
+const tokenEnvironmentVariable = "MUTAGEN_TCP_TOKEN"
+
+// loadToken loads the authentication token from the specified file, or from
+// the environment if no file is specified.
+func loadToken(path string) (string, error) {
+	if path == "" {
+		return os.Getenv(tokenEnvironmentVariable), nil
+	}
+	return direct.LoadToken(path)
+}
+
+func serveMain(command *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) != 0 {
+		return errors.New("unexpected arguments provided")
+	} else if serveConfiguration.listen == "" {
+		return errors.New("listen address must be specified")
+	}
+
+	// Parse the listening endpoint.
+	protocol, address, err := forwardingurlpkg.Parse(serveConfiguration.listen)
+	if err != nil {
+		return errors.Wrap(err, "unable to parse listen address")
+	} else if !(protocol == "tcp" || protocol == "tcp4" || protocol == "tcp6") {
+		return errors.New("listen address must use a TCP protocol")
+	}
+
+	// Load the authentication token.
+	token, err := loadToken(serveConfiguration.tokenFile)
+	if err != nil {
+		return errors.Wrap(err, "unable to load authentication token")
+	}
+
+	// Create the TLS configuration. TLS is required since the authentication
+	// token would otherwise be sent in plaintext.
+	tlsConfiguration, err := direct.NewServerTLSConfiguration(
+		serveConfiguration.tlsCertificate,
+		serveConfiguration.tlsKey,
+		serveConfiguration.tlsClientCA,
+	)
+	if err != nil {
+		return errors.Wrap(err, "unable to create TLS configuration")
+	}
+
+	// Ensure that some form of authentication is configured. We refuse to
+	// serve unauthenticated connections since that would give anyone who can
+	// reach the listener access to the filesystem.
+	if token == "" && serveConfiguration.tlsClientCA == "" {
+		return errors.New("an authentication token or TLS client certificate authority is required")
+	}
+
+	// Create a channel to track termination signals. We do this before creating
+	// and starting other infrastructure so that we can ensure things terminate
+	// smoothly, not mid-initialization.
+	signalTermination := make(chan os.Signal, 1)
+	signal.Notify(signalTermination, cmd.TerminationSignals...)
+
+	// Set up regular housekeeping and defer its shutdown.
+	housekeepingContext, housekeepingCancel := context.WithCancel(context.Background())
+	defer housekeepingCancel()
+	go housekeepRegularly(housekeepingContext, logging.RootLogger.Sublogger("housekeeping"))
+
+	// Create the listener and defer its closure.
+	listener, err := net.Listen(protocol, address)
+	if err != nil {
+		return errors.Wrap(err, "unable to create listener")
+	}
+	listener = tls.NewListener(listener, tlsConfiguration)
+	defer listener.Close()
+	logging.RootLogger.Printf("Listening on %s", listener.Addr())
+
+	// Serve connections and monitor for termination.
+	serverTermination := make(chan error, 1)
+	go func() {
+		serverTermination <- direct.Serve(logging.RootLogger, listener, token, modeHandlers())
+	}()
+
+	// Wait for termination from a signal or the server.
+	select {
+	case sig := <-signalTermination:
+		return errors.Errorf("terminated by signal: %s", sig)
+	case err := <-serverTermination:
+		return errors.Wrap(err, "server terminated")
+	}
+}
+
+var serveCommand = &cobra.Command{
+	Use:          "serve",
+	Short:        "Run the agent as a long-lived listener for direct TCP connections",
+	RunE:         serveMain,
+	SilenceUsage: true,
+}
+
+var serveConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+	// listen is the endpoint on which to listen, in forwarding endpoint format
+	// (e.g. tcp::9000).
+	listen string
+	// tokenFile is the path to a file containing the authentication token.
+	tokenFile string
+	// tlsCertificate is the path to the TLS certificate.
+	tlsCertificate string
+	// tlsKey is the path to the TLS key.
+	tlsKey string
+	// tlsClientCA is the path to the TLS certificate authority bundle used to
+	// verify client certificates.
+	tlsClientCA string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := serveCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&serveConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up serve flags.
+	flags.StringVarP(&serveConfiguration.listen, "listen", "l", "", "Specify the listening endpoint (e.g. tcp::9000)")
+	flags.StringVar(&serveConfiguration.tokenFile, "token-file", "", "Specify a file containing the authentication token (defaults to "+tokenEnvironmentVariable+")")
+	flags.StringVar(&serveConfiguration.tlsCertificate, "tls-certificate", "", "Specify the TLS certificate (required)")
+	flags.StringVar(&serveConfiguration.tlsKey, "tls-key", "", "Specify the TLS key (required)")
+	flags.StringVar(&serveConfiguration.tlsClientCA, "tls-client-ca", "", "Require client certificates signed by the specified certificate authority bundle")
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+)
+
+func uninstallMain(command *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) == 0 {
+		return errors.New("no versions specified")
+	}
+
+	// Remove each version.
+	for _, version := range arguments {
+		if err := agent.UninstallVersion(version); err != nil {
+			return errors.Wrapf(err, "unable to uninstall version %s", version)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+var uninstallCommand = &cobra.Command{
+	Use:          agent.ModeUninstall + " <version>...",
+	Short:        "Remove installed agent versions",
+	RunE:         uninstallMain,
+	SilenceUsage: true,
+}
+
+var uninstallConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := uninstallCommand.Flags()
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&uninstallConfiguration.help, "help", "h", false, "Show help information")
+}
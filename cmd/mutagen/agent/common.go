@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/agent/transports"
+	"github.com/mutagen-io/mutagen/pkg/prompt"
+	urlpkg "github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// commandLinePrompter is a prompt.Prompter implementation that displays
+// messages on a status line and performs prompting on the command line.
+type commandLinePrompter struct {
+	// printer is the status line printer.
+	printer cmd.StatusLinePrinter
+}
+
+// Message implements prompt.Prompter.Message.
+func (p *commandLinePrompter) Message(message string) error {
+	p.printer.Print(message)
+	return nil
+}
+
+// Prompt implements prompt.Prompter.Prompt.
+func (p *commandLinePrompter) Prompt(message string) (string, error) {
+	p.printer.BreakIfNonEmpty()
+	return prompt.PromptCommandLine(message)
+}
+
+// transportForURL parses a raw synchronization URL and creates an agent
+// transport for the remote that it targets. The path portion of the URL is
+// ignored. It also registers a command line prompter for use with the transport
+// and returns its identifier along with a function that unregisters it and
+// clears its status line. The unregistration function is idempotent.
+func transportForURL(raw string) (agent.Transport, string, func(), error) {
+	// Parse the URL.
+	url, err := urlpkg.Parse(raw, urlpkg.Kind_Synchronization, true)
+	if err != nil {
+		return nil, "", nil, errors.Wrap(err, "unable to parse URL")
+	}
+
+	// Register a command line prompter.
+	commandLinePrompter := &commandLinePrompter{}
+	prompter, err := prompt.RegisterPrompter(commandLinePrompter)
+	if err != nil {
+		return nil, "", nil, errors.Wrap(err, "unable to register prompter")
+	}
+	var unregistered bool
+	unregister := func() {
+		if !unregistered {
+			prompt.UnregisterPrompter(prompter)
+			commandLinePrompter.printer.Clear()
+			unregistered = true
+		}
+	}
+
+	// Create the transport.
+	transport, err := transports.New(url, prompter)
+	if err != nil {
+		unregister()
+		return nil, "", nil, errors.Wrap(err, "unable to create agent transport")
+	}
+
+	// Success.
+	return transport, prompter, unregister, nil
+}
@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+)
+
+func extractMain(command *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) != 2 {
+		return errors.New("invalid number of arguments")
+	}
+	platform := strings.Split(arguments[0], "/")
+	if len(platform) != 2 || platform[0] == "" || platform[1] == "" {
+		return errors.New("invalid platform specification")
+	}
+	output := arguments[1]
+
+	// Extract the agent.
+	if _, err := agent.ExecutableForPlatform(platform[0], platform[1], output); err != nil {
+		return errors.Wrap(err, "unable to extract agent")
+	}
+
+	// Explain how to install the agent.
+	fmt.Printf("Agent extracted. Copy it to the target system and run \"%s %s\" there to install it.\n",
+		output, agent.ModeInstall,
+	)
+
+	// Success.
+	return nil
+}
+
+var extractCommand = &cobra.Command{
+	Use:   "extract <os>/<arch> <output-path>",
+	Short: "Extract an agent executable for manual installation",
+	Long: `Extract an agent executable for manual installation.
+
+This is useful for pre-installing agents on systems that Mutagen can't copy
+agents to, such as air-gapped hosts. Once copied to the target system, running
+the extracted executable with the "install" argument will install it for the
+current user.`,
+	RunE:         extractMain,
+	SilenceUsage: true,
+}
+
+var extractConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := extractCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&extractConfiguration.help, "help", "h", false, "Show help information")
+}
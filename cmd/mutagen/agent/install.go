@@ -0,0 +1,57 @@
+package agent
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+func installMain(command *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) != 1 {
+		return errors.New("invalid number of arguments")
+	}
+
+	// Create the transport.
+	transport, prompter, unregister, err := transportForURL(arguments[0])
+	if err != nil {
+		return err
+	}
+	defer unregister()
+
+	// Perform installation.
+	if err := agent.InstallRemote(logging.RootLogger, transport, prompter); err != nil {
+		return errors.Wrap(err, "unable to install agent")
+	}
+
+	// Success.
+	return nil
+}
+
+var installCommand = &cobra.Command{
+	Use:          "install <url>",
+	Short:        "Install (or reinstall) the current agent version on a remote",
+	RunE:         installMain,
+	SilenceUsage: true,
+}
+
+var installConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := installCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&installConfiguration.help, "help", "h", false, "Show help information")
+}
@@ -0,0 +1,73 @@
+package agent
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+func listMain(command *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) != 1 {
+		return errors.New("invalid number of arguments")
+	}
+
+	// Create the transport.
+	transport, prompter, unregister, err := transportForURL(arguments[0])
+	if err != nil {
+		return err
+	}
+	defer unregister()
+
+	// List installed agents.
+	agents, err := agent.ListRemote(logging.RootLogger, transport, prompter)
+	if err != nil {
+		return errors.Wrap(err, "unable to list agents")
+	}
+
+	// Unregister the prompter to clear its status line before printing.
+	unregister()
+
+	// Print installed agents.
+	for _, a := range agents {
+		current := ""
+		if a.Version == agent.InstallationVersion {
+			current = " (current)"
+		}
+		fmt.Printf("%s%s\tLast used: %s\n", a.Version, current, a.LastUsed.Format(time.RFC3339))
+	}
+
+	// Success.
+	return nil
+}
+
+var listCommand = &cobra.Command{
+	Use:          "list <url>",
+	Short:        "List the agent versions installed on a remote",
+	RunE:         listMain,
+	SilenceUsage: true,
+}
+
+var listConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := listCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&listConfiguration.help, "help", "h", false, "Show help information")
+}
@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+func pruneMain(command *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) != 1 {
+		return errors.New("invalid number of arguments")
+	}
+
+	// Create the transport.
+	transport, prompter, unregister, err := transportForURL(arguments[0])
+	if err != nil {
+		return err
+	}
+	defer unregister()
+
+	// Perform pruning.
+	removed, err := agent.PruneRemote(logging.RootLogger, transport, prompter)
+	if err != nil {
+		return errors.Wrap(err, "unable to prune agents")
+	}
+
+	// Unregister the prompter to clear its status line before printing.
+	unregister()
+
+	// Print removed versions.
+	for _, version := range removed {
+		fmt.Println("Removed", version)
+	}
+
+	// Success.
+	return nil
+}
+
+var pruneCommand = &cobra.Command{
+	Use:          "prune <url>",
+	Short:        "Remove all agent versions other than the current version from a remote",
+	RunE:         pruneMain,
+	SilenceUsage: true,
+}
+
+var pruneConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := pruneCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&pruneConfiguration.help, "help", "h", false, "Show help information")
+}
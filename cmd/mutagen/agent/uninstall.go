@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+func uninstallMain(command *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) != 1 {
+		return errors.New("invalid number of arguments")
+	}
+
+	// Determine which versions to remove.
+	versions := uninstallConfiguration.versions
+	if len(versions) == 0 {
+		versions = []string{agent.InstallationVersion}
+	}
+
+	// Create the transport.
+	transport, prompter, unregister, err := transportForURL(arguments[0])
+	if err != nil {
+		return err
+	}
+	defer unregister()
+
+	// Perform removal.
+	if err := agent.UninstallRemote(logging.RootLogger, transport, prompter, versions); err != nil {
+		return errors.Wrap(err, "unable to uninstall agents")
+	}
+
+	// Success.
+	return nil
+}
+
+var uninstallCommand = &cobra.Command{
+	Use:          "uninstall <url>",
+	Short:        "Remove agent versions from a remote",
+	RunE:         uninstallMain,
+	SilenceUsage: true,
+}
+
+var uninstallConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+	// versions are the versions to remove.
+	versions []string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := uninstallCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&uninstallConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up removal flags.
+	flags.StringSliceVar(&uninstallConfiguration.versions, "version", nil, "Specify an agent version to remove (defaults to the current version)")
+}
@@ -1,128 +1,52 @@
 package daemon
 
 import (
-	"context"
-	"time"
+	"os"
 
 	"github.com/pkg/errors"
 
 	"google.golang.org/grpc"
 
 	"github.com/mutagen-io/mutagen/cmd"
-	"github.com/mutagen-io/mutagen/pkg/daemon"
-	"github.com/mutagen-io/mutagen/pkg/grpcutil"
-	"github.com/mutagen-io/mutagen/pkg/ipc"
-	"github.com/mutagen-io/mutagen/pkg/mutagen"
-	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
+	"github.com/mutagen-io/mutagen/pkg/client"
 )
 
-const (
-	// dialTimeout is the timeout to use when attempting to connect to the
-	// daemon IPC endpoint.
-	dialTimeout = 100 * time.Millisecond
-	// autostartWaitInterval is the wait period between reconnect attempts after
-	// autostarting the daemon.
-	autostartWaitInterval = 100 * time.Millisecond
-	// autostartRetryCount is the number of times to try reconnecting after
-	// autostarting the daemon.
-	autostartRetryCount = 10
-)
-
-// CreateClientConnection creates a new daemon client connection and optionally
-// verifies that the daemon version matches the current process' version.
-func CreateClientConnection(autostart, enforceVersionMatch bool) (*grpc.ClientConn, error) {
-	// Compute the path to the daemon IPC endpoint.
-	endpoint, err := daemon.EndpointPath()
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to compute endpoint path")
-	}
-
-	// Check if autostart has been globally disabled.
-	if daemon.AutostartDisabled {
-		autostart = false
+// Connect creates a new daemon client and optionally verifies that the daemon
+// version matches the current process' version. If autostart is requested,
+// then the daemon will be started using the current executable and autostart
+// progress will be displayed on a status line.
+func Connect(autostart, enforceVersionMatch bool) (*client.Client, error) {
+	// Compute the path to the current executable, which we'll use if the
+	// daemon needs to be autostarted.
+	var executable string
+	if autostart {
+		var err error
+		if executable, err = os.Executable(); err != nil {
+			return nil, errors.Wrap(err, "unable to determine executable path")
+		}
 	}
 
-	// Create a status line printer and defer a clear.
+	// Create a status line printer and defer a break.
 	statusLinePrinter := &cmd.StatusLinePrinter{}
 	defer statusLinePrinter.BreakIfNonEmpty()
 
-	// Perform dialing in a loop until failure or success.
-	remainingPostAutostatAttempts := autostartRetryCount
-	invokedStart := false
-	var connection *grpc.ClientConn
-	for {
-		// Create a context to timeout the dial.
-		dialContext, dialCancel := context.WithTimeout(context.Background(), dialTimeout)
-
-		// Attempt to dial.
-		connection, err = grpc.DialContext(
-			dialContext, endpoint,
-			grpc.WithInsecure(),
-			grpc.WithContextDialer(ipc.DialContext),
-			grpc.WithBlock(),
-			grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(grpcutil.MaximumMessageSize)),
-			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutil.MaximumMessageSize)),
-		)
-
-		// Cancel the dialing context. If the dialing operation has already
-		// succeeded, this has no effect, but it is necessary to clean up the
-		// Goroutine that backs the context.
-		dialCancel()
-
-		// Check for errors.
-		if err != nil {
-			// Handle failure due to timeouts.
-			if err == context.DeadlineExceeded {
-				// If autostart is enabled, and we have attempts remaining, then
-				// try autostarting, waiting, and retrying.
-				if autostart && remainingPostAutostatAttempts > 0 {
-					if !invokedStart {
-						statusLinePrinter.Print("Attempting to start Mutagen daemon...")
-						startMain(nil, nil)
-						invokedStart = true
-					}
-					time.Sleep(autostartWaitInterval)
-					remainingPostAutostatAttempts--
-					continue
-				}
-
-				// Otherwise just fail due to the timeout.
-				return nil, errors.New("connection timed out (is the daemon running?)")
-			}
-
-			// If we failed for any other reason, then bail.
-			return nil, err
-		}
-
-		// Print a notice if we started the daemon.
-		if invokedStart {
-			statusLinePrinter.Clear()
-			statusLinePrinter.Print("Started Mutagen daemon in background (terminate with \"mutagen daemon stop\")")
-		}
-
-		// We've successfully dialed, so break out of the dialing loop.
-		break
-	}
+	// Connect to the daemon.
+	return client.Connect(&client.Options{
+		Autostart:           autostart,
+		Executable:          executable,
+		EnforceVersionMatch: enforceVersionMatch,
+		Prompter:            &cmd.StatusLinePrompter{Printer: statusLinePrinter},
+	})
+}
 
-	// If requested, verify that the daemon version matches the current process'
-	// version.
-	if enforceVersionMatch {
-		daemonService := daemonsvc.NewDaemonClient(connection)
-		version, err := daemonService.Version(context.Background(), &daemonsvc.VersionRequest{})
-		if err != nil {
-			connection.Close()
-			return nil, errors.Wrap(err, "unable to query daemon version")
-		}
-		versionMatch := version.Major == mutagen.VersionMajor &&
-			version.Minor == mutagen.VersionMinor &&
-			version.Patch == mutagen.VersionPatch &&
-			version.Tag == mutagen.VersionTag
-		if !versionMatch {
-			connection.Close()
-			return nil, errors.New("client/daemon version mismatch (daemon restart recommended)")
-		}
+// CreateClientConnection creates a new daemon client connection and optionally
+// verifies that the daemon version matches the current process' version. It's
+// intended for commands that use daemon services that aren't wrapped by the
+// client package, with other commands using Connect directly.
+func CreateClientConnection(autostart, enforceVersionMatch bool) (*grpc.ClientConn, error) {
+	daemonClient, err := Connect(autostart, enforceVersionMatch)
+	if err != nil {
+		return nil, err
 	}
-
-	// Success.
-	return connection, nil
+	return daemonClient.Connection(), nil
 }
@@ -0,0 +1,170 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fatih/color"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/mutagen-io/mutagen/pkg/client"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
+)
+
+const (
+	// logsTimeFormat is the time format used when printing log records.
+	logsTimeFormat = "2006-01-02 15:04:05.000"
+)
+
+// resolveSessionIdentifiers resolves session specifications (names,
+// identifiers, or identifier prefixes) to the identifiers of the matching
+// synchronization and forwarding sessions.
+func resolveSessionIdentifiers(daemonClient *client.Client, specifications []string) ([]string, error) {
+	// Resolve each specification, first against synchronization sessions and
+	// then against forwarding sessions.
+	var identifiers []string
+	for _, specification := range specifications {
+		// Create a selection for the specification.
+		selection := &selection.Selection{Specifications: []string{specification}}
+
+		// Check synchronization sessions.
+		_, synchronizationStates, err := daemonClient.Synchronization().List(context.Background(), selection, 0)
+		if err == nil {
+			for _, state := range synchronizationStates {
+				identifiers = append(identifiers, state.Session.Identifier)
+			}
+			continue
+		}
+
+		// Check forwarding sessions.
+		_, forwardingStates, err := daemonClient.Forwarding().List(context.Background(), selection, 0)
+		if err == nil {
+			for _, state := range forwardingStates {
+				identifiers = append(identifiers, state.Session.Identifier)
+			}
+			continue
+		}
+
+		// If neither session type matched, then the specification is invalid.
+		return nil, errors.Errorf("unable to locate session: %s", specification)
+	}
+
+	// Success.
+	return identifiers, nil
+}
+
+// printLogRecord prints a log record in human-readable form.
+func printLogRecord(record *daemonsvc.LogRecord) {
+	// Format the record time in the local time zone.
+	var timestamp string
+	if t, err := ptypes.Timestamp(record.Time); err == nil {
+		timestamp = t.Local().Format(logsTimeFormat)
+	}
+
+	// Format the level, highlighting warnings and errors.
+	level := fmt.Sprintf("%-5s", strings.ToUpper(record.Level))
+	if record.Level == logging.LevelWarn.String() {
+		level = color.YellowString(level)
+	} else if record.Level == logging.LevelError.String() {
+		level = color.RedString(level)
+	}
+
+	// Print the record.
+	if record.Logger != "" {
+		fmt.Printf("%s %s [%s] %s\n", timestamp, level, record.Logger, record.Message)
+	} else {
+		fmt.Printf("%s %s %s\n", timestamp, level, record.Message)
+	}
+}
+
+func logsMain(command *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) != 0 {
+		return errors.New("unexpected arguments provided")
+	}
+
+	// Validate the log level, if specified.
+	if logsConfiguration.level != "" {
+		if _, ok := logging.NameToLevel(logsConfiguration.level); !ok {
+			return errors.Errorf("invalid log level: %s", logsConfiguration.level)
+		}
+	}
+
+	// Connect to the daemon and defer closure of the client. We don't
+	// autostart the daemon since a newly started daemon would have nothing
+	// interesting to report.
+	daemonClient, err := Connect(false, true)
+	if err != nil {
+		return errors.Wrap(err, "unable to connect to daemon")
+	}
+	defer daemonClient.Close()
+
+	// Resolve session specifications to identifiers, since log records only
+	// reference sessions by identifier.
+	var sessions []string
+	if len(logsConfiguration.sessions) > 0 {
+		sessions, err = resolveSessionIdentifiers(daemonClient, logsConfiguration.sessions)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Print records until the stream ends.
+	return daemonClient.Logs(
+		context.Background(),
+		sessions,
+		logsConfiguration.level,
+		logsConfiguration.follow,
+		func(records []*daemonsvc.LogRecord) error {
+			for _, record := range records {
+				printLogRecord(record)
+			}
+			return nil
+		},
+	)
+}
+
+var logsCommand = &cobra.Command{
+	Use:          "logs",
+	Short:        "Show Mutagen daemon logs",
+	RunE:         logsMain,
+	SilenceUsage: true,
+}
+
+var logsConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+	// follow indicates whether or not newly logged records should be shown as
+	// they're logged.
+	follow bool
+	// sessions are the session specifications whose records should be shown.
+	sessions []string
+	// level is the most verbose log level to show.
+	level string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := logsCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&logsConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up logs flags.
+	flags.BoolVarP(&logsConfiguration.follow, "follow", "f", false, "Continue showing records as they're logged")
+	flags.StringSliceVar(&logsConfiguration.sessions, "session", nil, "Only show records for the specified session (may be repeated)")
+	flags.StringVar(&logsConfiguration.level, "level", "", "Only show records at or below the specified level (error|warn|info|debug|trace)")
+}
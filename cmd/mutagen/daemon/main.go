@@ -47,6 +47,7 @@ func init() {
 		runCommand,
 		startCommand,
 		stopCommand,
+		logsCommand,
 	}
 	if daemon.RegistrationSupported {
 		supportedCommands = append(supportedCommands,
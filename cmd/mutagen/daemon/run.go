@@ -1,8 +1,10 @@
 package daemon
 
 import (
+	"net/http"
 	"os"
 	"os/signal"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -11,11 +13,17 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/pkg/client"
+	"github.com/mutagen-io/mutagen/pkg/configuration/global"
 	"github.com/mutagen-io/mutagen/pkg/daemon"
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/gateway"
 	"github.com/mutagen-io/mutagen/pkg/grpcutil"
 	"github.com/mutagen-io/mutagen/pkg/ipc"
 	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/metrics"
+	"github.com/mutagen-io/mutagen/pkg/notification"
 	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
 	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
 	promptsvc "github.com/mutagen-io/mutagen/pkg/service/prompt"
@@ -23,6 +31,188 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/synchronization"
 )
 
+const (
+	// globalConfigurationPollInterval is the interval at which the daemon
+	// checks the global configuration file for changes to settings that can be
+	// applied without restarting.
+	globalConfigurationPollInterval = 5 * time.Second
+)
+
+// globalConfigurationModificationTime returns the modification time of the
+// global configuration file, or the zero time if it can't be determined.
+func globalConfigurationModificationTime() time.Time {
+	if path, err := global.ConfigurationPath(); err != nil {
+		return time.Time{}
+	} else if metadata, err := os.Stat(path); err != nil {
+		return time.Time{}
+	} else {
+		return metadata.ModTime()
+	}
+}
+
+// loadGlobalConfiguration loads the global configuration file for use by the
+// daemon. Failures are logged rather than returned so that an invalid global
+// configuration doesn't prevent the daemon from starting, in which case nil is
+// returned and the features that depend on the global configuration are
+// disabled.
+func loadGlobalConfiguration() *global.Configuration {
+	// Compute the path to the global configuration file.
+	path, err := global.ConfigurationPath()
+	if err != nil {
+		logging.RootLogger.Warn(errors.Wrap(err, "unable to compute path to global configuration file"))
+		return nil
+	}
+
+	// Attempt to load the file. We allow it to not exist.
+	configuration, err := global.LoadConfiguration(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logging.RootLogger.Warn(errors.Wrap(err, "unable to load global configuration"))
+		}
+		return nil
+	}
+
+	// Success.
+	return configuration
+}
+
+// notificationWebhooks extracts and validates the notification webhooks from
+// the global configuration. Invalid webhook configurations are logged and
+// cause notifications to be disabled.
+func notificationWebhooks(configuration *global.Configuration) []*notification.Webhook {
+	// If there's no configuration, then there are no webhooks.
+	if configuration == nil {
+		return nil
+	}
+
+	// Extract and validate the webhooks.
+	webhooks := configuration.Notification.NotificationWebhooks()
+	for _, webhook := range webhooks {
+		if err := webhook.EnsureValid(); err != nil {
+			logging.RootLogger.Warn(errors.Wrap(err, "invalid notification webhook configuration"))
+			return nil
+		}
+	}
+
+	// Success.
+	return webhooks
+}
+
+// reloadNotificationWebhooks reloads the notification webhooks from the global
+// configuration file. If the file can't be loaded or specifies invalid webhooks,
+// then the failure is logged and the existing webhooks are retained. If the file
+// no longer exists, then all webhooks are removed.
+func reloadNotificationWebhooks(notifier *notification.Notifier) {
+	// Compute the path to the global configuration file.
+	path, err := global.ConfigurationPath()
+	if err != nil {
+		logging.RootLogger.Warn(errors.Wrap(err, "unable to compute path to global configuration file"))
+		return
+	}
+
+	// Attempt to load the file and extract the webhooks.
+	var webhooks []*notification.Webhook
+	if configuration, err := global.LoadConfiguration(path); err == nil {
+		webhooks = configuration.Notification.NotificationWebhooks()
+	} else if !os.IsNotExist(err) {
+		logging.RootLogger.Warn(errors.Wrap(err, "unable to reload global configuration"))
+		return
+	}
+
+	// Update the notifier.
+	if err := notifier.SetWebhooks(webhooks); err != nil {
+		logging.RootLogger.Warn(errors.Wrap(err, "unable to update notification webhooks"))
+	} else {
+		logging.RootLogger.Println("Reloaded notification configuration")
+	}
+}
+
+// configureLogging applies the logging settings from the global configuration.
+// Invalid settings are logged and ignored.
+func configureLogging(configuration *global.Configuration) {
+	// If there's no configuration, then there's nothing to apply.
+	if configuration == nil {
+		return
+	}
+
+	// Apply the log level.
+	if configuration.Logging.Level != "" {
+		if level, ok := logging.NameToLevel(configuration.Logging.Level); ok {
+			logging.SetLevel(level)
+		} else {
+			logging.RootLogger.Warn(errors.Errorf("invalid log level: %s", configuration.Logging.Level))
+		}
+	}
+
+	// Apply the log format.
+	if configuration.Logging.Format != "" {
+		if format, ok := logging.NameToFormat(configuration.Logging.Format); ok {
+			logging.SetFormat(format)
+		} else {
+			logging.RootLogger.Warn(errors.Errorf("invalid log format: %s", configuration.Logging.Format))
+		}
+	}
+}
+
+// serveAPI serves the HTTP/JSON API gateway on the specified address in a
+// background Goroutine, proxying requests to the daemon's gRPC services via the
+// specified IPC endpoint. Session creation requests may only specify locally
+// executed commands that are included in allowlist. It writes a newly generated
+// authentication token to the API token file. The returned function stops the
+// gateway and removes the token file.
+func serveAPI(address, endpoint string, allowlist gateway.CommandAllowlist) (func(), error) {
+	// Generate an authentication token and write it to the token file.
+	token, err := gateway.GenerateToken()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to generate API token")
+	}
+	tokenPath, err := daemon.APITokenPath()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to compute API token path")
+	}
+	if err := filesystem.WriteFileAtomic(tokenPath, []byte(token), 0600); err != nil {
+		return nil, errors.Wrap(err, "unable to write API token")
+	}
+
+	// Create the gateway listener.
+	listener, err := gateway.Listen(address)
+	if err != nil {
+		os.Remove(tokenPath)
+		return nil, errors.Wrap(err, "unable to create API listener")
+	}
+
+	// Create a client connection to the daemon's gRPC services. We don't block
+	// on dialing since the gRPC server may not have started serving yet.
+	connection, err := grpc.Dial(
+		endpoint,
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(ipc.DialContext),
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(grpcutil.MaximumMessageSize)),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutil.MaximumMessageSize)),
+	)
+	if err != nil {
+		listener.Close()
+		os.Remove(tokenPath)
+		return nil, errors.Wrap(err, "unable to connect to daemon services")
+	}
+	apiClient := client.New(connection)
+
+	// Serve the gateway.
+	server := &http.Server{Handler: gateway.NewHandler(apiClient, token, allowlist)}
+	go func() {
+		if err := server.Serve(listener); err != http.ErrServerClosed {
+			logging.RootLogger.Warn(errors.Wrap(err, "API server failed"))
+		}
+	}()
+
+	// Success.
+	return func() {
+		server.Close()
+		apiClient.Close()
+		os.Remove(tokenPath)
+	}, nil
+}
+
 func runMain(command *cobra.Command, arguments []string) error {
 	// Validate arguments.
 	if len(arguments) != 0 {
@@ -42,6 +232,24 @@ func runMain(command *cobra.Command, arguments []string) error {
 	signalTermination := make(chan os.Signal, 1)
 	signal.Notify(signalTermination, cmd.TerminationSignals...)
 
+	// Start logging to the daemon log file and defer its closure. Since the
+	// daemon is usually detached from any terminal, this is the only place
+	// where its logs will persist. Failure to log to a file isn't terminal.
+	if logPath, err := daemon.LogPath(); err != nil {
+		logging.RootLogger.Warn(errors.Wrap(err, "unable to compute log path"))
+	} else if logFile, err := logging.LogToFile(logPath); err != nil {
+		logging.RootLogger.Warn(errors.Wrap(err, "unable to start logging to file"))
+	} else {
+		defer logFile.Close()
+	}
+
+	// Load the global configuration and apply its logging settings. We record
+	// the configuration file's modification time beforehand so that changes
+	// made while loading are detected.
+	globalConfigurationModified := globalConfigurationModificationTime()
+	globalConfiguration := loadGlobalConfiguration()
+	configureLogging(globalConfiguration)
+
 	// Create a forwarding session manager and defer its shutdown.
 	forwardingManager, err := forwarding.NewManager(logging.RootLogger.Sublogger("forwarding"))
 	if err != nil {
@@ -56,11 +264,27 @@ func runMain(command *cobra.Command, arguments []string) error {
 	}
 	defer synchronizationManager.Shutdown()
 
+	// Create a notifier, start it watching the session managers, and defer its
+	// shutdown. Since the notifier is deferred after the managers, it will be
+	// shut down before them.
+	notifier, err := notification.NewNotifier(
+		logging.RootLogger.Sublogger("notify"),
+		notificationWebhooks(globalConfiguration),
+	)
+	if err != nil {
+		return errors.Wrap(err, "unable to create notifier")
+	}
+	notifier.WatchForwarding(forwardingManager)
+	notifier.WatchSynchronization(synchronizationManager)
+	defer notifier.Shutdown()
+
 	// Create the gRPC server and defer its stoppage. We use a hard stop rather
 	// than a graceful stop so that it doesn't hang on open requests.
 	server := grpc.NewServer(
 		grpc.MaxSendMsgSize(grpcutil.MaximumMessageSize),
 		grpc.MaxRecvMsgSize(grpcutil.MaximumMessageSize),
+		grpc.UnaryInterceptor(grpcutil.UnaryServerInterceptor),
+		grpc.StreamInterceptor(grpcutil.StreamServerInterceptor),
 	)
 	defer server.Stop()
 
@@ -80,6 +304,25 @@ func runMain(command *cobra.Command, arguments []string) error {
 	synchronizationServer := synchronizationsvc.NewServer(synchronizationManager)
 	synchronizationsvc.RegisterSynchronizationServer(server, synchronizationServer)
 
+	// If requested, expose metrics in a separate Goroutine and defer the
+	// metrics server's closure. Since metrics are optional, failure to create
+	// or serve the metrics endpoint is logged rather than treated as terminal.
+	if globalConfiguration != nil && globalConfiguration.Metrics.Listen != "" {
+		if metricsListener, err := ipc.ListenAddress(globalConfiguration.Metrics.Listen); err != nil {
+			logging.RootLogger.Warn(errors.Wrap(err, "unable to create metrics listener"))
+		} else {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", metrics.NewHandler(synchronizationManager, forwardingManager))
+			metricsServer := &http.Server{Handler: mux}
+			defer metricsServer.Close()
+			go func() {
+				if err := metricsServer.Serve(metricsListener); err != http.ErrServerClosed {
+					logging.RootLogger.Warn(errors.Wrap(err, "metrics server failed"))
+				}
+			}()
+		}
+	}
+
 	// Compute the path to the daemon IPC endpoint.
 	endpoint, err := daemon.EndpointPath()
 	if err != nil {
@@ -103,15 +346,45 @@ func runMain(command *cobra.Command, arguments []string) error {
 		serverErrors <- server.Serve(listener)
 	}()
 
+	// If requested, serve the HTTP/JSON API gateway and defer its shutdown.
+	// Since the gateway is optional, failure to serve it is logged rather than
+	// treated as terminal.
+	if globalConfiguration != nil && globalConfiguration.API.Listen != "" {
+		allowlist := gateway.CommandAllowlist{
+			ProxyCommands:    globalConfiguration.API.AllowedProxyCommands,
+			HookCommands:     globalConfiguration.API.AllowedHookCommands,
+			EndpointCommands: globalConfiguration.API.AllowedEndpointCommands,
+		}
+		if stopAPI, err := serveAPI(globalConfiguration.API.Listen, endpoint, allowlist); err != nil {
+			logging.RootLogger.Warn(errors.Wrap(err, "unable to serve API"))
+		} else {
+			defer stopAPI()
+		}
+	}
+
+	// Create a ticker to poll for global configuration changes and defer its
+	// shutdown.
+	globalConfigurationPoller := time.NewTicker(globalConfigurationPollInterval)
+	defer globalConfigurationPoller.Stop()
+
 	// Wait for termination from a signal, the daemon service, or the gRPC
-	// server. We treat termination via the daemon service as a non-error.
-	select {
-	case sig := <-signalTermination:
-		return errors.Errorf("terminated by signal: %s", sig)
-	case <-daemonServer.Termination:
-		return nil
-	case err = <-serverErrors:
-		return errors.Wrap(err, "daemon server termination")
+	// server. We treat termination via the daemon service as a non-error. While
+	// waiting, reload notification settings if the global configuration file
+	// changes.
+	for {
+		select {
+		case sig := <-signalTermination:
+			return errors.Errorf("terminated by signal: %s", sig)
+		case <-daemonServer.Termination:
+			return nil
+		case err = <-serverErrors:
+			return errors.Wrap(err, "daemon server termination")
+		case <-globalConfigurationPoller.C:
+			if modified := globalConfigurationModificationTime(); !modified.Equal(globalConfigurationModified) {
+				globalConfigurationModified = modified
+				reloadNotificationWebhooks(notifier)
+			}
+		}
 	}
 }
 
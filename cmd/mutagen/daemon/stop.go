@@ -8,7 +8,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/mutagen-io/mutagen/pkg/daemon"
-	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
 )
 
 func stopMain(command *cobra.Command, arguments []string) error {
@@ -25,22 +24,19 @@ func stopMain(command *cobra.Command, arguments []string) error {
 		return nil
 	}
 
-	// Connect to the daemon and defer closure of the connection. We avoid
+	// Connect to the daemon and defer closure of the client. We avoid
 	// version compatibility checks since they would remove the ability to
 	// terminate an incompatible daemon. This is fine since the daemon service
 	// portion of the daemon API is stable.
-	daemonConnection, err := CreateClientConnection(false, false)
+	daemonClient, err := Connect(false, false)
 	if err != nil {
 		return errors.Wrap(err, "unable to connect to daemon")
 	}
-	defer daemonConnection.Close()
+	defer daemonClient.Close()
 
-	// Create a daemon service client.
-	daemonService := daemonsvc.NewDaemonClient(daemonConnection)
-
-	// Invoke shutdown. We don't check the response or error, because the daemon
-	// may terminate before it has a chance to send the response.
-	daemonService.Terminate(context.Background(), &daemonsvc.TerminateRequest{})
+	// Invoke shutdown. We don't check the error, because the daemon may
+	// terminate before it has a chance to send the response.
+	daemonClient.TerminateDaemon(context.Background())
 
 	// Success.
 	return nil
@@ -0,0 +1,155 @@
+package forward
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+)
+
+func captureMain(command *cobra.Command, arguments []string) error {
+	// Validate arguments and create the session selection specification.
+	if len(arguments) != 1 {
+		return errors.New("a single session must be specified")
+	}
+	selection := &selection.Selection{
+		Specifications: arguments,
+	}
+	if err := selection.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid session selection specification")
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.CreateClientConnection(true, true)
+	if err != nil {
+		return errors.Wrap(err, "unable to connect to daemon")
+	}
+	defer daemonConnection.Close()
+
+	// Create a session service client.
+	sessionService := forwardingsvc.NewForwardingClient(daemonConnection)
+
+	// If we're just stopping an existing capture, then do so and bail.
+	if captureConfiguration.stop {
+		return stopCapture(sessionService, selection)
+	}
+
+	// Validate and convert the output path. The daemon will interpret the path,
+	// so it needs to be absolute.
+	if captureConfiguration.output == "" {
+		return errors.New("an output path must be specified")
+	}
+	path, err := filepath.Abs(captureConfiguration.output)
+	if err != nil {
+		return errors.Wrap(err, "unable to compute absolute output path")
+	}
+
+	// Validate and convert the capture format.
+	var format forwarding.CaptureFormat
+	if captureConfiguration.format != "" {
+		if err := format.UnmarshalText([]byte(captureConfiguration.format)); err != nil {
+			return errors.Wrap(err, "unable to parse capture format")
+		}
+	}
+
+	// Create a channel to track termination signals. We do this before starting
+	// the capture so that we can be sure to stop the capture.
+	signalTermination := make(chan os.Signal, 1)
+	if !captureConfiguration.detach {
+		signal.Notify(signalTermination, cmd.TerminationSignals...)
+	}
+
+	// Start the capture.
+	request := &forwardingsvc.StartCaptureRequest{
+		Selection:       selection,
+		Format:          format,
+		Path:            path,
+		ConnectionLimit: captureConfiguration.connections,
+	}
+	if response, err := sessionService.StartCapture(context.Background(), request); err != nil {
+		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "capture start failed")
+	} else if err = response.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid start capture response received")
+	}
+
+	// If we're detaching, then we're done. The capture can be stopped later
+	// using the --stop flag.
+	if captureConfiguration.detach {
+		fmt.Println("Capturing to", path)
+		return nil
+	}
+
+	// Wait for termination and then stop the capture.
+	fmt.Println("Capturing to", path, "(press Ctrl-C to stop)")
+	<-signalTermination
+	return stopCapture(sessionService, selection)
+}
+
+// stopCapture stops the capture for the specified session.
+func stopCapture(sessionService forwardingsvc.ForwardingClient, selection *selection.Selection) error {
+	request := &forwardingsvc.StopCaptureRequest{
+		Selection: selection,
+	}
+	if response, err := sessionService.StopCapture(context.Background(), request); err != nil {
+		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "capture stop failed")
+	} else if err = response.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid stop capture response received")
+	}
+	return nil
+}
+
+var captureCommand = &cobra.Command{
+	Use:          "capture <session>",
+	Short:        "Capture traffic flowing through a forwarding session",
+	RunE:         captureMain,
+	SilenceUsage: true,
+}
+
+var captureConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+	// output is the output path for the capture. For pcapng captures, this is
+	// a file. For raw captures, this is a directory.
+	output string
+	// format is the capture format specification.
+	format string
+	// connections is the maximum number of connections to capture.
+	connections uint64
+	// detach indicates whether or not the command should exit after starting
+	// the capture rather than waiting for termination to stop it.
+	detach bool
+	// stop indicates whether or not an existing capture should be stopped.
+	stop bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := captureCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&captureConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up capture flags.
+	flags.StringVarP(&captureConfiguration.output, "output", "o", "", "Specify the output file (or directory for raw captures)")
+	flags.StringVar(&captureConfiguration.format, "format", "", "Specify the capture format (pcapng|raw)")
+	flags.Uint64Var(&captureConfiguration.connections, "connections", 0, "Specify the maximum number of connections to capture")
+	flags.BoolVarP(&captureConfiguration.detach, "detach", "d", false, "Leave the capture running in the daemon and exit")
+	flags.BoolVar(&captureConfiguration.stop, "stop", false, "Stop an existing capture")
+}
@@ -12,11 +12,10 @@ import (
 
 	"github.com/mutagen-io/mutagen/cmd"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+	"github.com/mutagen-io/mutagen/pkg/client"
 	"github.com/mutagen-io/mutagen/pkg/configuration/global"
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/forwarding"
-	"github.com/mutagen-io/mutagen/pkg/grpcutil"
-	"github.com/mutagen-io/mutagen/pkg/prompt"
 	"github.com/mutagen-io/mutagen/pkg/selection"
 	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
 	"github.com/mutagen-io/mutagen/pkg/url"
@@ -48,51 +47,23 @@ func loadAndValidateGlobalForwardingConfiguration(path string) (*forwarding.Conf
 // methods, it requires provision of a client to avoid creating one for each
 // request.
 func CreateWithSpecification(
-	service forwardingsvc.ForwardingClient,
+	sessions *client.ForwardingClient,
 	specification *forwardingsvc.CreationSpecification,
 ) error {
-	// Invoke the session create method. The stream will close when the
-	// associated context is cancelled.
-	createContext, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	stream, err := service.Create(createContext)
-	if err != nil {
-		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to invoke create")
-	}
-
-	// Send the initial request.
-	request := &forwardingsvc.CreateRequest{Specification: specification}
-	if err := stream.Send(request); err != nil {
-		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send create request")
-	}
-
 	// Create a status line printer and defer a break.
 	statusLinePrinter := &cmd.StatusLinePrinter{}
 	defer statusLinePrinter.BreakIfNonEmpty()
 
-	// Receive and process responses until we're done.
-	for {
-		if response, err := stream.Recv(); err != nil {
-			return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "create failed")
-		} else if err = response.EnsureValid(); err != nil {
-			return errors.Wrap(err, "invalid create response received")
-		} else if response.Session != "" {
-			statusLinePrinter.Print(fmt.Sprintf("Created session %s", response.Session))
-			return nil
-		} else if response.Message != "" {
-			statusLinePrinter.Print(response.Message)
-			if err := stream.Send(&forwardingsvc.CreateRequest{}); err != nil {
-				return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send message response")
-			}
-		} else if response.Prompt != "" {
-			statusLinePrinter.BreakIfNonEmpty()
-			if response, err := prompt.PromptCommandLine(response.Prompt); err != nil {
-				return errors.Wrap(err, "unable to perform prompting")
-			} else if err = stream.Send(&forwardingsvc.CreateRequest{Response: response}); err != nil {
-				return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send prompt response")
-			}
-		}
+	// Perform creation, relaying messages and prompts to the console.
+	prompter := &cmd.StatusLinePrompter{Printer: statusLinePrinter}
+	session, err := sessions.Create(context.Background(), specification, prompter)
+	if err != nil {
+		return err
 	}
+
+	// Report the new session.
+	statusLinePrinter.Print(fmt.Sprintf("Created session %s", session))
+	return nil
 }
 
 func createMain(command *cobra.Command, arguments []string) error {
@@ -134,6 +105,20 @@ func createMain(command *cobra.Command, arguments []string) error {
 		labels[key] = value
 	}
 
+	// Parse HTTP route specifications. Route targets are validated as part of
+	// configuration validation.
+	var httpRoutes map[string]string
+	if len(createConfiguration.httpRoutes) > 0 {
+		httpRoutes = make(map[string]string, len(createConfiguration.httpRoutes))
+	}
+	for _, route := range createConfiguration.httpRoutes {
+		components := strings.SplitN(route, "=", 2)
+		if len(components) != 2 {
+			return errors.Errorf("invalid HTTP route specification: %s", route)
+		}
+		httpRoutes[components[0]] = components[1]
+	}
+
 	// Create a default session configuration which will form the basis of our
 	// cumulative configuration.
 	configuration := &forwarding.Configuration{}
@@ -168,6 +153,24 @@ func createMain(command *cobra.Command, arguments []string) error {
 		}
 	}
 
+	// Validate and convert TLS mode specifications.
+	var tlsMode, tlsModeSource, tlsModeDestination forwarding.TLSMode
+	if createConfiguration.tlsMode != "" {
+		if err := tlsMode.UnmarshalText([]byte(createConfiguration.tlsMode)); err != nil {
+			return errors.Wrap(err, "unable to parse TLS mode")
+		}
+	}
+	if createConfiguration.tlsModeSource != "" {
+		if err := tlsModeSource.UnmarshalText([]byte(createConfiguration.tlsModeSource)); err != nil {
+			return errors.Wrap(err, "unable to parse TLS mode for source")
+		}
+	}
+	if createConfiguration.tlsModeDestination != "" {
+		if err := tlsModeDestination.UnmarshalText([]byte(createConfiguration.tlsModeDestination)); err != nil {
+			return errors.Wrap(err, "unable to parse TLS mode for destination")
+		}
+	}
+
 	// Validate and convert socket overwrite mode specifications.
 	var socketOverwriteMode, socketOverwriteModeSource, socketOverwriteModeDestination forwarding.SocketOverwriteMode
 	if createConfiguration.socketOverwriteMode != "" {
@@ -253,6 +256,9 @@ func createMain(command *cobra.Command, arguments []string) error {
 	// Create the command line configuration and merge it into our cumulative
 	// configuration.
 	configuration = forwarding.MergeConfigurations(configuration, &forwarding.Configuration{
+		DialRetryCount:       createConfiguration.dialRetryCount,
+		DialRetryInterval:    createConfiguration.dialRetryInterval,
+		Tls:                  tlsMode,
 		SocketOverwriteMode:  socketOverwriteMode,
 		SocketOwner:          createConfiguration.socketOwner,
 		SocketGroup:          createConfiguration.socketGroup,
@@ -265,34 +271,41 @@ func createMain(command *cobra.Command, arguments []string) error {
 		Destination:   destination,
 		Configuration: configuration,
 		ConfigurationSource: &forwarding.Configuration{
-			SocketOverwriteMode:  socketOverwriteModeSource,
-			SocketOwner:          createConfiguration.socketOwnerSource,
-			SocketGroup:          createConfiguration.socketGroupSource,
-			SocketPermissionMode: uint32(socketPermissionModeSource),
+			Tls:                     tlsModeSource,
+			TlsCertificate:          createConfiguration.tlsCertificateSource,
+			TlsKey:                  createConfiguration.tlsKeySource,
+			TlsCertificateAuthority: createConfiguration.tlsCertificateAuthoritySource,
+			SocketOverwriteMode:     socketOverwriteModeSource,
+			SocketOwner:             createConfiguration.socketOwnerSource,
+			SocketGroup:             createConfiguration.socketGroupSource,
+			SocketPermissionMode:    uint32(socketPermissionModeSource),
 		},
 		ConfigurationDestination: &forwarding.Configuration{
-			SocketOverwriteMode:  socketOverwriteModeDestination,
-			SocketOwner:          createConfiguration.socketOwnerDestination,
-			SocketGroup:          createConfiguration.socketGroupDestination,
-			SocketPermissionMode: uint32(socketPermissionModeDestination),
+			Tls:                     tlsModeDestination,
+			TlsCertificate:          createConfiguration.tlsCertificateDestination,
+			TlsKey:                  createConfiguration.tlsKeyDestination,
+			TlsCertificateAuthority: createConfiguration.tlsCertificateAuthorityDestination,
+			TlsServerName:           createConfiguration.tlsServerNameDestination,
+			HttpRoutes:              httpRoutes,
+			SocketOverwriteMode:     socketOverwriteModeDestination,
+			SocketOwner:             createConfiguration.socketOwnerDestination,
+			SocketGroup:             createConfiguration.socketGroupDestination,
+			SocketPermissionMode:    uint32(socketPermissionModeDestination),
 		},
 		Name:   createConfiguration.name,
 		Labels: labels,
 		Paused: createConfiguration.paused,
 	}
 
-	// Connect to the daemon and defer closure of the connection.
-	daemonConnection, err := daemon.CreateClientConnection(true, true)
+	// Connect to the daemon and defer closure of the client.
+	daemonClient, err := daemon.Connect(true, true)
 	if err != nil {
 		return errors.Wrap(err, "unable to connect to daemon")
 	}
-	defer daemonConnection.Close()
-
-	// Create a forwarding service client.
-	service := forwardingsvc.NewForwardingClient(daemonConnection)
+	defer daemonClient.Close()
 
 	// Perform creation.
-	return CreateWithSpecification(service, specification)
+	return CreateWithSpecification(daemonClient.Forwarding(), specification)
 }
 
 var createCommand = &cobra.Command{
@@ -319,6 +332,48 @@ var createConfiguration struct {
 	// configurationFile specifies a file from which to load configuration. It
 	// should be a path relative to the working directory.
 	configurationFile string
+	// dialRetryCount specifies the number of times that a failed dial of the
+	// destination should be retried.
+	dialRetryCount uint32
+	// dialRetryInterval specifies the initial interval (in milliseconds) to
+	// wait before retrying a failed dial of the destination.
+	dialRetryInterval uint32
+	// tlsMode specifies the TLS mode to use for the session, with
+	// endpoint-specific specifications taking priority.
+	tlsMode string
+	// tlsModeSource specifies the TLS mode to use for the session, taking
+	// priority over tlsMode on source if specified. Enabling TLS on source
+	// terminates TLS for incoming connections.
+	tlsModeSource string
+	// tlsCertificateSource specifies the path to the certificate to use for
+	// terminating TLS on source.
+	tlsCertificateSource string
+	// tlsKeySource specifies the path to the private key to use for
+	// terminating TLS on source.
+	tlsKeySource string
+	// tlsCertificateAuthoritySource specifies the path to a certificate
+	// authority bundle to use for authenticating client certificates on
+	// source.
+	tlsCertificateAuthoritySource string
+	// tlsModeDestination specifies the TLS mode to use for the session,
+	// taking priority over tlsMode on destination if specified. Enabling TLS
+	// on destination originates TLS for outgoing connections.
+	tlsModeDestination string
+	// tlsCertificateDestination specifies the path to the client certificate
+	// to use for originating TLS on destination.
+	tlsCertificateDestination string
+	// tlsKeyDestination specifies the path to the client private key to use
+	// for originating TLS on destination.
+	tlsKeyDestination string
+	// tlsCertificateAuthorityDestination specifies the path to a certificate
+	// authority bundle to use for verifying the target on destination.
+	tlsCertificateAuthorityDestination string
+	// tlsServerNameDestination specifies the server name to use for verifying
+	// the target on destination.
+	tlsServerNameDestination string
+	// httpRoutes are the HTTP route specifications (in host=target format) to
+	// use for the destination of HTTP-aware sessions.
+	httpRoutes []string
 	// socketOverwriteMode specifies the socket overwrite mode to use for the
 	// session.
 	socketOverwriteMode string
@@ -388,6 +443,25 @@ func init() {
 	flags.BoolVar(&createConfiguration.noGlobalConfiguration, "no-global-configuration", false, "Ignore the global configuration file")
 	flags.StringVarP(&createConfiguration.configurationFile, "configuration-file", "c", "", "Specify a file from which to load session configuration")
 
+	// Wire up dial flags.
+	flags.Uint32Var(&createConfiguration.dialRetryCount, "dial-retry-count", 0, "Specify the number of times to retry a failed dial of the destination")
+	flags.Uint32Var(&createConfiguration.dialRetryInterval, "dial-retry-interval", 0, "Specify the initial dial retry interval in milliseconds")
+
+	// Wire up TLS flags.
+	flags.StringVar(&createConfiguration.tlsMode, "tls-mode", "", "Specify TLS mode (enabled|disabled)")
+	flags.StringVar(&createConfiguration.tlsModeSource, "tls-mode-source", "", "Specify TLS mode for source (enabled terminates TLS for incoming connections)")
+	flags.StringVar(&createConfiguration.tlsCertificateSource, "tls-certificate-source", "", "Specify TLS certificate path for source")
+	flags.StringVar(&createConfiguration.tlsKeySource, "tls-key-source", "", "Specify TLS private key path for source")
+	flags.StringVar(&createConfiguration.tlsCertificateAuthoritySource, "tls-ca-source", "", "Specify TLS certificate authority bundle path for client authentication on source")
+	flags.StringVar(&createConfiguration.tlsModeDestination, "tls-mode-destination", "", "Specify TLS mode for destination (enabled originates TLS for outgoing connections)")
+	flags.StringVar(&createConfiguration.tlsCertificateDestination, "tls-certificate-destination", "", "Specify TLS client certificate path for destination")
+	flags.StringVar(&createConfiguration.tlsKeyDestination, "tls-key-destination", "", "Specify TLS client private key path for destination")
+	flags.StringVar(&createConfiguration.tlsCertificateAuthorityDestination, "tls-ca-destination", "", "Specify TLS certificate authority bundle path for destination")
+	flags.StringVar(&createConfiguration.tlsServerNameDestination, "tls-server-name-destination", "", "Specify TLS server name for destination")
+
+	// Wire up HTTP flags.
+	flags.StringSliceVar(&createConfiguration.httpRoutes, "http-route", nil, "Specify an HTTP route (host=target) for destination")
+
 	// Wire up socket flags.
 	flags.StringVar(&createConfiguration.socketOverwriteMode, "socket-overwrite-mode", "", "Specify socket overwrite mode (leave|overwrite)")
 	flags.StringVar(&createConfiguration.socketOverwriteModeSource, "socket-overwrite-mode-source", "", "Specify socket overwrite mode for source (leave|overwrite)")
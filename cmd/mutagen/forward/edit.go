@@ -0,0 +1,254 @@
+package forward
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+)
+
+// tlsModeDefaultSpecification is the TLS mode specification that removes an
+// explicitly configured TLS mode, reverting to the lower-priority setting.
+const tlsModeDefaultSpecification = "default"
+
+// editTLSMode applies a TLS mode edit specification to a configuration. An
+// empty specification leaves the configuration unmodified.
+func editTLSMode(configuration *forwarding.Configuration, specification string) error {
+	if specification == "" {
+		return nil
+	} else if specification == tlsModeDefaultSpecification {
+		configuration.Tls = forwarding.TLSMode_TLSModeDefault
+		return nil
+	}
+	return configuration.Tls.UnmarshalText([]byte(specification))
+}
+
+func editMain(command *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) != 1 {
+		return errors.New("a single session must be specified")
+	}
+
+	// Connect to the daemon and defer closure of the client.
+	daemonClient, err := daemon.Connect(true, true)
+	if err != nil {
+		return errors.Wrap(err, "unable to connect to daemon")
+	}
+	defer daemonClient.Close()
+	sessionClient := daemonClient.Forwarding()
+
+	// Look up the current session.
+	_, states, err := sessionClient.List(context.Background(), &selection.Selection{Specifications: arguments}, 0)
+	if err != nil {
+		return err
+	} else if len(states) != 1 {
+		return errors.New("specification matched multiple sessions")
+	}
+	session := states[0].Session
+
+	// Start with the existing session parameters.
+	configuration := session.Configuration
+	configurationSource := session.ConfigurationSource
+	configurationDestination := session.ConfigurationDestination
+	name := session.Name
+	labels := make(map[string]string, len(session.Labels))
+	for key, value := range session.Labels {
+		labels[key] = value
+	}
+
+	// Update the name, if requested.
+	if command.Flags().Changed("name") {
+		if err := selection.EnsureNameValid(editConfiguration.name); err != nil {
+			return errors.Wrap(err, "invalid session name")
+		}
+		name = editConfiguration.name
+	}
+
+	// Parse, validate, and apply label additions.
+	for _, label := range editConfiguration.labels {
+		components := strings.SplitN(label, "=", 2)
+		var key, value string
+		key = components[0]
+		if len(components) == 2 {
+			value = components[1]
+		}
+		if err := selection.EnsureLabelKeyValid(key); err != nil {
+			return errors.Wrap(err, "invalid label key")
+		} else if err := selection.EnsureLabelValueValid(value); err != nil {
+			return errors.Wrap(err, "invalid label value")
+		}
+		labels[key] = value
+	}
+
+	// Apply label removals.
+	for _, key := range editConfiguration.removeLabels {
+		delete(labels, key)
+	}
+
+	// Parse HTTP route specifications. Route targets are validated as part of
+	// configuration validation. If specified, they replace any existing routes.
+	var httpRoutes map[string]string
+	if len(editConfiguration.httpRoutes) > 0 {
+		httpRoutes = make(map[string]string, len(editConfiguration.httpRoutes))
+	}
+	for _, route := range editConfiguration.httpRoutes {
+		components := strings.SplitN(route, "=", 2)
+		if len(components) != 2 {
+			return errors.Errorf("invalid HTTP route specification: %s", route)
+		}
+		httpRoutes[components[0]] = components[1]
+	}
+
+	// If a configuration file has been specified, then load it and use it to
+	// replace the existing session-wide configuration. Replacement (rather than
+	// merging) ensures that settings can be removed by editing the file.
+	if editConfiguration.configurationFile != "" {
+		if c, err := loadAndValidateGlobalForwardingConfiguration(editConfiguration.configurationFile); err != nil {
+			return errors.Wrap(err, "unable to load configuration file")
+		} else {
+			configuration = c
+		}
+	}
+
+	// Create the command line configurations and merge them into the existing
+	// configurations.
+	configuration = forwarding.MergeConfigurations(configuration, &forwarding.Configuration{
+		DialRetryCount:    editConfiguration.dialRetryCount,
+		DialRetryInterval: editConfiguration.dialRetryInterval,
+	})
+	configurationSource = forwarding.MergeConfigurations(configurationSource, &forwarding.Configuration{
+		TlsCertificate: editConfiguration.tlsCertificateSource,
+		TlsKey:         editConfiguration.tlsKeySource,
+	})
+	configurationDestination = forwarding.MergeConfigurations(configurationDestination, &forwarding.Configuration{
+		TlsCertificate: editConfiguration.tlsCertificateDestination,
+		TlsKey:         editConfiguration.tlsKeyDestination,
+		HttpRoutes:     httpRoutes,
+	})
+
+	// Apply TLS mode edits. These are applied directly rather than merged so
+	// that TLS can be disabled and endpoint-specific overrides can be removed.
+	if err := editTLSMode(configuration, editConfiguration.tlsMode); err != nil {
+		return errors.Wrap(err, "unable to parse TLS mode")
+	} else if err := editTLSMode(configurationSource, editConfiguration.tlsModeSource); err != nil {
+		return errors.Wrap(err, "unable to parse TLS mode for source")
+	} else if err := editTLSMode(configurationDestination, editConfiguration.tlsModeDestination); err != nil {
+		return errors.Wrap(err, "unable to parse TLS mode for destination")
+	}
+
+	// Perform the update, relaying messages and prompts to the console. We
+	// identify the session by its identifier to avoid any ambiguity introduced
+	// by concurrent renames.
+	statusLinePrinter := &cmd.StatusLinePrinter{}
+	prompter := &cmd.StatusLinePrompter{Printer: statusLinePrinter}
+	specification := &forwardingsvc.UpdateSpecification{
+		Session:                  session.Identifier,
+		Configuration:            configuration,
+		ConfigurationSource:      configurationSource,
+		ConfigurationDestination: configurationDestination,
+		Name:                     name,
+		Labels:                   labels,
+	}
+	if err := sessionClient.Update(context.Background(), specification, prompter); err != nil {
+		statusLinePrinter.BreakIfNonEmpty()
+		return err
+	}
+
+	// Success.
+	statusLinePrinter.Clear()
+	return nil
+}
+
+var editCommand = &cobra.Command{
+	Use:          "edit <session>",
+	Short:        "Update the configuration of an existing forwarding session",
+	RunE:         editMain,
+	SilenceUsage: true,
+}
+
+var editConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+	// name is the new name specification for the session.
+	name string
+	// labels are the label specifications to add to (or update on) the
+	// session.
+	labels []string
+	// removeLabels are the label keys to remove from the session.
+	removeLabels []string
+	// configurationFile specifies a file from which to load configuration that
+	// will replace the existing session configuration.
+	configurationFile string
+	// dialRetryCount specifies the number of times to retry a failed dial of
+	// the destination.
+	dialRetryCount uint32
+	// dialRetryInterval specifies the initial dial retry interval in
+	// milliseconds.
+	dialRetryInterval uint32
+	// tlsMode specifies the TLS mode for the session.
+	tlsMode string
+	// tlsModeSource specifies the TLS mode for the source, taking priority
+	// over tlsMode if specified.
+	tlsModeSource string
+	// tlsCertificateSource specifies the TLS certificate path for the source.
+	tlsCertificateSource string
+	// tlsKeySource specifies the TLS private key path for the source.
+	tlsKeySource string
+	// tlsModeDestination specifies the TLS mode for the destination, taking
+	// priority over tlsMode if specified.
+	tlsModeDestination string
+	// tlsCertificateDestination specifies the TLS client certificate path for
+	// the destination.
+	tlsCertificateDestination string
+	// tlsKeyDestination specifies the TLS client private key path for the
+	// destination.
+	tlsKeyDestination string
+	// httpRoutes are the HTTP route specifications for the destination. If
+	// specified, they replace any existing routes.
+	httpRoutes []string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := editCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&editConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up name and label flags.
+	flags.StringVarP(&editConfiguration.name, "name", "n", "", "Specify a new name for the session")
+	flags.StringSliceVarP(&editConfiguration.labels, "label", "l", nil, "Add or update labels")
+	flags.StringSliceVar(&editConfiguration.removeLabels, "remove-label", nil, "Remove labels by key")
+
+	// Wire up general configuration flags.
+	flags.StringVarP(&editConfiguration.configurationFile, "configuration-file", "c", "", "Specify a file from which to load replacement session configuration")
+
+	// Wire up dial flags.
+	flags.Uint32Var(&editConfiguration.dialRetryCount, "dial-retry-count", 0, "Specify the number of times to retry a failed dial of the destination")
+	flags.Uint32Var(&editConfiguration.dialRetryInterval, "dial-retry-interval", 0, "Specify the initial dial retry interval in milliseconds")
+
+	// Wire up TLS flags.
+	flags.StringVar(&editConfiguration.tlsMode, "tls-mode", "", "Specify TLS mode (enabled|disabled|default)")
+	flags.StringVar(&editConfiguration.tlsModeSource, "tls-mode-source", "", "Specify TLS mode for source (enabled|disabled|default)")
+	flags.StringVar(&editConfiguration.tlsCertificateSource, "tls-certificate-source", "", "Specify TLS certificate path for source")
+	flags.StringVar(&editConfiguration.tlsKeySource, "tls-key-source", "", "Specify TLS private key path for source")
+	flags.StringVar(&editConfiguration.tlsModeDestination, "tls-mode-destination", "", "Specify TLS mode for destination (enabled|disabled|default)")
+	flags.StringVar(&editConfiguration.tlsCertificateDestination, "tls-certificate-destination", "", "Specify TLS client certificate path for destination")
+	flags.StringVar(&editConfiguration.tlsKeyDestination, "tls-key-destination", "", "Specify TLS client private key path for destination")
+
+	// Wire up HTTP flags.
+	flags.StringSliceVar(&editConfiguration.httpRoutes, "http-route", nil, "Specify HTTP routes (host=target) for destination, replacing existing routes")
+}
@@ -0,0 +1,111 @@
+package forward
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+	"github.com/mutagen-io/mutagen/pkg/export"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+)
+
+func exportMain(command *cobra.Command, arguments []string) error {
+	// Validate the output path.
+	if exportConfiguration.output == "" {
+		return errors.New("output path must be specified")
+	} else if _, err := export.FormatForPath(exportConfiguration.output); err != nil {
+		return err
+	}
+
+	// Create session selection specification.
+	selection := &selection.Selection{
+		All:            exportConfiguration.all,
+		Specifications: arguments,
+		LabelSelector:  exportConfiguration.labelSelector,
+	}
+	if err := selection.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid session selection specification")
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.CreateClientConnection(true, true)
+	if err != nil {
+		return errors.Wrap(err, "unable to connect to daemon")
+	}
+	defer daemonConnection.Close()
+
+	// Create a forwarding service client.
+	service := forwardingsvc.NewForwardingClient(daemonConnection)
+
+	// Invoke export.
+	request := &forwardingsvc.ExportRequest{
+		Selection: selection,
+	}
+	response, err := service.Export(context.Background(), request)
+	if err != nil {
+		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "export failed")
+	} else if err = response.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid export response received")
+	}
+
+	// Save the export document.
+	sessions := make([]proto.Message, len(response.Specifications))
+	for i, specification := range response.Specifications {
+		sessions[i] = specification
+	}
+	if err := export.Save(exportConfiguration.output, export.KindForwarding, sessions); err != nil {
+		return errors.Wrap(err, "unable to save export document")
+	}
+
+	// Print a summary.
+	fmt.Printf("Exported %d session(s) to %s\n", len(sessions), exportConfiguration.output)
+
+	// Success.
+	return nil
+}
+
+var exportCommand = &cobra.Command{
+	Use:          "export [<session>...]",
+	Short:        "Export forwarding sessions to a file for later import",
+	RunE:         exportMain,
+	SilenceUsage: true,
+}
+
+var exportConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+	// all indicates whether or not all sessions should be exported.
+	all bool
+	// labelSelector encodes a label selector to be used in identifying which
+	// sessions should be exported.
+	labelSelector string
+	// output is the path to which the export document should be written. Its
+	// extension determines the document format.
+	output string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := exportCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&exportConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up export flags.
+	flags.BoolVarP(&exportConfiguration.all, "all", "a", false, "Export all sessions")
+	flags.StringVar(&exportConfiguration.labelSelector, "label-selector", "", "Export sessions matching the specified label selector")
+	flags.StringVarP(&exportConfiguration.output, "output", "o", "", "Specify the output path (.yaml, .yml, or .json)")
+}
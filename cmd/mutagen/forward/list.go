@@ -8,14 +8,14 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/golang/protobuf/proto"
+
 	"github.com/fatih/color"
 
 	"github.com/mutagen-io/mutagen/cmd"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
 	"github.com/mutagen-io/mutagen/pkg/forwarding"
-	"github.com/mutagen-io/mutagen/pkg/grpcutil"
 	"github.com/mutagen-io/mutagen/pkg/selection"
-	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
 	"github.com/mutagen-io/mutagen/pkg/url"
 )
 
@@ -48,6 +48,16 @@ func printSessionStatus(state *forwarding.State) {
 	}
 	fmt.Fprintln(color.Output, "Status:", statusString)
 
+	// Print connection statistics if we're forwarding.
+	if state.Status == forwarding.Status_ForwardingConnections {
+		fmt.Printf(
+			"Connections: %d active, %d total, %d failed\n",
+			state.OpenConnections,
+			state.TotalConnections,
+			state.FailedConnections,
+		)
+	}
+
 	// Print the last error, if any.
 	if state.LastError != "" {
 		color.Red("Last error: %s\n", state.LastError)
@@ -62,41 +72,68 @@ func ListWithLabelSelector(labelSelector string, long bool) error {
 	return listMain(nil, nil)
 }
 
-func listMain(command *cobra.Command, arguments []string) error {
-	// Create session selection specification.
-	selection := &selection.Selection{
-		All:            len(arguments) == 0 && listConfiguration.labelSelector == "",
-		Specifications: arguments,
-		LabelSelector:  listConfiguration.labelSelector,
-	}
+// ListStatesWithLabelSelector is an orchestration convenience method that
+// returns the states of sessions matching the specified label selector.
+func ListStatesWithLabelSelector(labelSelector string) ([]*forwarding.State, error) {
+	return listStates(&selection.Selection{LabelSelector: labelSelector})
+}
+
+// listStates queries the daemon for the states of sessions matching the
+// specified selection.
+func listStates(selection *selection.Selection) ([]*forwarding.State, error) {
+	// Validate the selection.
 	if err := selection.EnsureValid(); err != nil {
-		return errors.Wrap(err, "invalid session selection specification")
+		return nil, errors.Wrap(err, "invalid session selection specification")
 	}
 
-	// Connect to the daemon and defer closure of the connection.
-	daemonConnection, err := daemon.CreateClientConnection(true, true)
+	// Connect to the daemon and defer closure of the client.
+	daemonClient, err := daemon.Connect(true, true)
 	if err != nil {
-		return errors.Wrap(err, "unable to connect to daemon")
+		return nil, errors.Wrap(err, "unable to connect to daemon")
 	}
-	defer daemonConnection.Close()
-
-	// Create a session service client.
-	sessionService := forwardingsvc.NewForwardingClient(daemonConnection)
+	defer daemonClient.Close()
 
 	// Invoke list.
-	request := &forwardingsvc.ListRequest{
-		Selection: selection,
+	_, states, err := daemonClient.Forwarding().List(context.Background(), selection, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return states, nil
+}
+
+func listMain(command *cobra.Command, arguments []string) error {
+	// Create the output renderer (if any).
+	renderer, err := cmd.NewStateRenderer(listConfiguration.output, listConfiguration.template)
+	if err != nil {
+		return errors.Wrap(err, "invalid output specification")
+	} else if renderer != nil && listConfiguration.long {
+		return errors.New("long listing format not supported with machine-readable output")
 	}
-	response, err := sessionService.List(context.Background(), request)
+
+	// Query session states.
+	states, err := listStates(&selection.Selection{
+		All:            len(arguments) == 0 && listConfiguration.labelSelector == "",
+		Specifications: arguments,
+		LabelSelector:  listConfiguration.labelSelector,
+	})
 	if err != nil {
-		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "list failed")
-	} else if err = response.EnsureValid(); err != nil {
-		return errors.Wrap(err, "invalid list response received")
+		return err
+	}
+
+	// Handle machine-readable output.
+	if renderer != nil {
+		messages := make([]proto.Message, len(states))
+		for s, state := range states {
+			messages[s] = state
+		}
+		return renderer.RenderList(messages)
 	}
 
 	// Handle output based on whether or not any sessions were returned.
-	if len(response.SessionStates) > 0 {
-		for _, state := range response.SessionStates {
+	if len(states) > 0 {
+		for _, state := range states {
 			fmt.Println(cmd.DelimiterLine)
 			printSession(state, listConfiguration.long)
 			printEndpointStatus("Source", state.Session.Source, state.SourceConnected)
@@ -130,6 +167,10 @@ var listConfiguration struct {
 	// labelSelector encodes a label selector to be used in identifying which
 	// sessions should be paused.
 	labelSelector string
+	// output specifies the machine-readable output format, if any.
+	output string
+	// template specifies the output template, if any.
+	template string
 }
 
 func init() {
@@ -146,4 +187,6 @@ func init() {
 	// Wire up list flags.
 	flags.BoolVarP(&listConfiguration.long, "long", "l", false, "Show detailed session information")
 	flags.StringVar(&listConfiguration.labelSelector, "label-selector", "", "List sessions matching the specified label selector")
+	flags.StringVarP(&listConfiguration.output, "output", "o", "", "Specify a machine-readable output format (json|yaml)")
+	flags.StringVar(&listConfiguration.template, "template", "", "Specify a Go template with which to format each session state")
 }
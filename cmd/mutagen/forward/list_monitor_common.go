@@ -2,6 +2,7 @@ package forward
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/mutagen-io/mutagen/pkg/forwarding"
 	"github.com/mutagen-io/mutagen/pkg/selection"
@@ -15,13 +16,62 @@ const (
 	emptyLabelValueDescription = "<empty>"
 )
 
-func printEndpoint(name string, url *url.URL, configuration *forwarding.Configuration, version forwarding.Version) {
+func printEndpoint(name string, url *url.URL, configuration *forwarding.Configuration, version forwarding.Version, source bool) {
 	// Print the endpoint header.
 	fmt.Println(name, "configuration:")
 
 	// Print the URL.
 	fmt.Println("\tURL:", url.Format("\n\t\t"))
 
+	// Print dialing parameters, so long as this isn't the source (which never
+	// dials).
+	if !source {
+		// Print the dial retry count.
+		fmt.Println("\tDial retry count:", configuration.DialRetryCount)
+
+		// Compute and print the dial retry interval, so long as retries are
+		// enabled.
+		if configuration.DialRetryCount > 0 {
+			var dialRetryIntervalDescription string
+			if configuration.DialRetryInterval == 0 {
+				dialRetryIntervalDescription = fmt.Sprintf("Default (%s)", version.DefaultDialRetryInterval())
+			} else {
+				dialRetryIntervalDescription = fmt.Sprintf("%d milliseconds", configuration.DialRetryInterval)
+			}
+			fmt.Println("\tDial retry interval:", dialRetryIntervalDescription)
+		}
+	}
+
+	// Print TLS parameters.
+	if configuration.Tls.Enabled() {
+		fmt.Println("\tTLS: Enabled")
+		if configuration.TlsCertificate != "" {
+			fmt.Println("\tTLS certificate:", configuration.TlsCertificate)
+			fmt.Println("\tTLS key:", configuration.TlsKey)
+		}
+		if configuration.TlsCertificateAuthority != "" {
+			fmt.Println("\tTLS certificate authority:", configuration.TlsCertificateAuthority)
+		}
+		if !source && configuration.TlsServerName != "" {
+			fmt.Println("\tTLS server name:", configuration.TlsServerName)
+		}
+	} else {
+		fmt.Println("\tTLS: Disabled")
+	}
+
+	// Print HTTP routes, if any.
+	if !source && len(configuration.HttpRoutes) > 0 {
+		hosts := make([]string, 0, len(configuration.HttpRoutes))
+		for host := range configuration.HttpRoutes {
+			hosts = append(hosts, host)
+		}
+		sort.Strings(hosts)
+		fmt.Println("\tHTTP routes:")
+		for _, host := range hosts {
+			fmt.Printf("\t\t%s -> %s\n", host, configuration.HttpRoutes[host])
+		}
+	}
+
 	// Compute and print the socket overwrite mode.
 	socketOverwriteModeDescription := configuration.SocketOverwriteMode.Description()
 	if configuration.SocketOverwriteMode.IsDefault() {
@@ -87,13 +137,13 @@ func printSession(state *forwarding.State, long bool) {
 			state.Session.Configuration,
 			state.Session.ConfigurationSource,
 		)
-		printEndpoint("Source", state.Session.Source, sourceConfigurationMerged, state.Session.Version)
+		printEndpoint("Source", state.Session.Source, sourceConfigurationMerged, state.Session.Version, true)
 
 		// Compute and print beta-specific configuration.
 		destinationConfigurationMerged := forwarding.MergeConfigurations(
 			state.Session.Configuration,
 			state.Session.ConfigurationDestination,
 		)
-		printEndpoint("Destination", state.Session.Destination, destinationConfigurationMerged, state.Session.Version)
+		printEndpoint("Destination", state.Session.Destination, destinationConfigurationMerged, state.Session.Version, false)
 	}
 }
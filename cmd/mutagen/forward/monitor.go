@@ -13,9 +13,7 @@ import (
 	"github.com/mutagen-io/mutagen/cmd"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
 	"github.com/mutagen-io/mutagen/pkg/forwarding"
-	"github.com/mutagen-io/mutagen/pkg/grpcutil"
 	selectionpkg "github.com/mutagen-io/mutagen/pkg/selection"
-	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
 )
 
 func computeMonitorStatusLine(state *forwarding.State) string {
@@ -39,6 +37,9 @@ func computeMonitorStatusLine(state *forwarding.State) string {
 				state.OpenConnections,
 				state.TotalConnections,
 			)
+			if state.FailedConnections > 0 {
+				status += fmt.Sprintf(", %d failed", state.FailedConnections)
+			}
 		}
 	}
 
@@ -64,15 +65,21 @@ func monitorMain(command *cobra.Command, arguments []string) error {
 		return errors.Wrap(err, "invalid session selection specification")
 	}
 
-	// Connect to the daemon and defer closure of the connection.
-	daemonConnection, err := daemon.CreateClientConnection(true, true)
+	// Create the output renderer (if any).
+	renderer, err := cmd.NewStateRenderer(monitorConfiguration.output, monitorConfiguration.template)
 	if err != nil {
-		return errors.Wrap(err, "unable to connect to daemon")
+		return errors.Wrap(err, "invalid output specification")
+	} else if renderer != nil && monitorConfiguration.long {
+		return errors.New("long monitoring format not supported with machine-readable output")
 	}
-	defer daemonConnection.Close()
 
-	// Create a session service client.
-	sessionService := forwardingsvc.NewForwardingClient(daemonConnection)
+	// Connect to the daemon and defer closure of the client.
+	daemonClient, err := daemon.Connect(true, true)
+	if err != nil {
+		return errors.Wrap(err, "unable to connect to daemon")
+	}
+	defer daemonClient.Close()
+	sessionClient := daemonClient.Forwarding()
 
 	// Create a status line printer and defer a break.
 	statusLinePrinter := &cmd.StatusLinePrinter{}
@@ -83,20 +90,12 @@ func monitorMain(command *cobra.Command, arguments []string) error {
 	var previousStateIndex uint64
 	sessionInformationPrinted := false
 	for {
-		// Create the list request. If there's no session specified, then we
-		// need to grab all sessions and identify the most recently created one
-		// for future queries.
-		request := &forwardingsvc.ListRequest{
-			Selection:          selection,
-			PreviousStateIndex: previousStateIndex,
-		}
-
-		// Invoke list.
-		response, err := sessionService.List(context.Background(), request)
+		// Invoke list. If there's no session specified, then we need to grab
+		// all sessions and identify the most recently created one for future
+		// queries.
+		stateIndex, states, err := sessionClient.List(context.Background(), selection, previousStateIndex)
 		if err != nil {
-			return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "list failed")
-		} else if err = response.EnsureValid(); err != nil {
-			return errors.Wrap(err, "invalid list response received")
+			return err
 		}
 
 		// Validate the response and extract the relevant session state. If we
@@ -104,26 +103,35 @@ func monitorMain(command *cobra.Command, arguments []string) error {
 		// choose the last session in the batch (which will be the one with the
 		// most recent creation date).
 		var state *forwarding.State
-		previousStateIndex = response.StateIndex
+		previousStateIndex = stateIndex
 		if identifier == "" {
-			if len(response.SessionStates) == 0 {
+			if len(states) == 0 {
 				err = errors.New("no matching sessions exist")
 			} else {
-				state = response.SessionStates[len(response.SessionStates)-1]
+				state = states[len(states)-1]
 				identifier = state.Session.Identifier
 				selection = &selectionpkg.Selection{
 					Specifications: []string{identifier},
 				}
 			}
-		} else if len(response.SessionStates) != 1 {
+		} else if len(states) != 1 {
 			err = errors.New("invalid list response")
 		} else {
-			state = response.SessionStates[0]
+			state = states[0]
 		}
 		if err != nil {
 			return err
 		}
 
+		// If machine-readable output has been requested, then render the state
+		// as a stream entry and wait for the next update.
+		if renderer != nil {
+			if err := renderer.RenderStreamEntry(state); err != nil {
+				return errors.Wrap(err, "unable to render session state")
+			}
+			continue
+		}
+
 		// Print session information the first time through the loop.
 		if !sessionInformationPrinted {
 			// Print session information.
@@ -164,6 +172,10 @@ var monitorConfiguration struct {
 	// labelSelector encodes a label selector to be used in identifying which
 	// sessions should be paused.
 	labelSelector string
+	// output specifies the machine-readable output format, if any.
+	output string
+	// template specifies the output template, if any.
+	template string
 }
 
 func init() {
@@ -180,4 +192,6 @@ func init() {
 	// Wire up monitor flags.
 	flags.BoolVarP(&monitorConfiguration.long, "long", "l", false, "Show detailed session information")
 	flags.StringVar(&monitorConfiguration.labelSelector, "label-selector", "", "Monitor the most recently created session matching the specified label selector")
+	flags.StringVarP(&monitorConfiguration.output, "output", "o", "", "Specify a machine-readable output format (json|yaml), rendering one state per update")
+	flags.StringVar(&monitorConfiguration.template, "template", "", "Specify a Go template with which to format each session state update")
 }
@@ -9,9 +9,7 @@ import (
 
 	"github.com/mutagen-io/mutagen/cmd"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
-	"github.com/mutagen-io/mutagen/pkg/grpcutil"
 	"github.com/mutagen-io/mutagen/pkg/selection"
-	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
 )
 
 // PauseWithLabelSelector is an orchestration convenience method invokes the
@@ -32,54 +30,24 @@ func pauseMain(command *cobra.Command, arguments []string) error {
 		return errors.Wrap(err, "invalid session selection specification")
 	}
 
-	// Connect to the daemon and defer closure of the connection.
-	daemonConnection, err := daemon.CreateClientConnection(true, true)
+	// Connect to the daemon and defer closure of the client.
+	daemonClient, err := daemon.Connect(true, true)
 	if err != nil {
 		return errors.Wrap(err, "unable to connect to daemon")
 	}
-	defer daemonConnection.Close()
+	defer daemonClient.Close()
 
-	// Create a session service client.
-	sessionService := forwardingsvc.NewForwardingClient(daemonConnection)
-
-	// Invoke the session pause method. The stream will close when the
-	// associated context is cancelled.
-	pauseContext, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	stream, err := sessionService.Pause(pauseContext)
-	if err != nil {
-		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to invoke pause")
-	}
-
-	// Send the initial request.
-	request := &forwardingsvc.PauseRequest{
-		Selection: selection,
-	}
-	if err := stream.Send(request); err != nil {
-		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send pause request")
-	}
-
-	// Create a status line printer.
+	// Perform the pause operation, relaying messages to a status line.
 	statusLinePrinter := &cmd.StatusLinePrinter{}
-
-	// Receive and process responses until we're done.
-	for {
-		if response, err := stream.Recv(); err != nil {
-			statusLinePrinter.BreakIfNonEmpty()
-			return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "pause failed")
-		} else if err = response.EnsureValid(); err != nil {
-			return errors.Wrap(err, "invalid pause response received")
-		} else if response.Message == "" {
-			statusLinePrinter.Clear()
-			return nil
-		} else if response.Message != "" {
-			statusLinePrinter.Print(response.Message)
-			if err := stream.Send(&forwardingsvc.PauseRequest{}); err != nil {
-				statusLinePrinter.BreakIfNonEmpty()
-				return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send message response")
-			}
-		}
+	prompter := &cmd.StatusLinePrompter{Printer: statusLinePrinter}
+	if err := daemonClient.Forwarding().Pause(context.Background(), selection, prompter); err != nil {
+		statusLinePrinter.BreakIfNonEmpty()
+		return err
 	}
+
+	// Success.
+	statusLinePrinter.Clear()
+	return nil
 }
 
 var pauseCommand = &cobra.Command{
@@ -0,0 +1,138 @@
+package forward
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fatih/color"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+)
+
+// printRequestLogEntry prints a single HTTP request log entry.
+func printRequestLogEntry(entry *forwarding.HTTPRequestLogEntry) {
+	// Format the timestamp.
+	timestamp := "<unknown time>"
+	if t, err := ptypes.Timestamp(entry.Time); err == nil {
+		timestamp = t.Local().Format(time.RFC3339)
+	}
+
+	// Format the latency.
+	var latency time.Duration
+	if d, err := ptypes.Duration(entry.Latency); err == nil {
+		latency = d.Round(time.Microsecond)
+	}
+
+	// Format the route.
+	route := "default"
+	if entry.Route != "" {
+		route = entry.Route
+	}
+
+	// Print the entry.
+	if entry.Error != "" {
+		fmt.Fprintln(color.Output, timestamp, entry.Method, entry.Host+entry.Path,
+			color.RedString("error: %s", entry.Error), "via", route)
+	} else {
+		fmt.Println(timestamp, entry.Method, entry.Host+entry.Path,
+			entry.Status, latency, "via", route)
+	}
+}
+
+func requestsMain(command *cobra.Command, arguments []string) error {
+	// Create session selection specification.
+	selection := &selection.Selection{
+		All:            len(arguments) == 0 && requestsConfiguration.labelSelector == "",
+		Specifications: arguments,
+		LabelSelector:  requestsConfiguration.labelSelector,
+	}
+	if err := selection.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid session selection specification")
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.CreateClientConnection(true, true)
+	if err != nil {
+		return errors.Wrap(err, "unable to connect to daemon")
+	}
+	defer daemonConnection.Close()
+
+	// Create a session service client.
+	sessionService := forwardingsvc.NewForwardingClient(daemonConnection)
+
+	// Invoke request log retrieval.
+	request := &forwardingsvc.RequestLogRequest{
+		Selection: selection,
+	}
+	response, err := sessionService.RequestLog(context.Background(), request)
+	if err != nil {
+		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "request log retrieval failed")
+	} else if err = response.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid request log response received")
+	}
+
+	// Handle output based on whether or not any logs were returned.
+	if len(response.Logs) > 0 {
+		for _, log := range response.Logs {
+			fmt.Println(cmd.DelimiterLine)
+			fmt.Println("Session:", log.Session)
+			if len(log.Entries) == 0 {
+				fmt.Println("No requests recorded")
+			}
+			for _, entry := range log.Entries {
+				printRequestLogEntry(entry)
+			}
+		}
+		fmt.Println(cmd.DelimiterLine)
+	} else {
+		fmt.Println(cmd.DelimiterLine)
+		fmt.Println("No HTTP sessions found")
+		fmt.Println(cmd.DelimiterLine)
+	}
+
+	// Success.
+	return nil
+}
+
+var requestsCommand = &cobra.Command{
+	Use:          "requests [<session>...]",
+	Short:        "Show recent requests for HTTP forwarding sessions",
+	RunE:         requestsMain,
+	SilenceUsage: true,
+}
+
+var requestsConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+	// labelSelector encodes a label selector to be used in identifying which
+	// sessions should be queried.
+	labelSelector string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := requestsCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&requestsConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up requests flags.
+	flags.StringVar(&requestsConfiguration.labelSelector, "label-selector", "", "Show requests for sessions matching the specified label selector")
+}
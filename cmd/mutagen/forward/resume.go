@@ -9,10 +9,7 @@ import (
 
 	"github.com/mutagen-io/mutagen/cmd"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
-	"github.com/mutagen-io/mutagen/pkg/grpcutil"
-	"github.com/mutagen-io/mutagen/pkg/prompt"
 	"github.com/mutagen-io/mutagen/pkg/selection"
-	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
 )
 
 // ResumeWithLabelSelector is an orchestration convenience method invokes the
@@ -33,62 +30,24 @@ func resumeMain(command *cobra.Command, arguments []string) error {
 		return errors.Wrap(err, "invalid session selection specification")
 	}
 
-	// Connect to the daemon and defer closure of the connection.
-	daemonConnection, err := daemon.CreateClientConnection(true, true)
+	// Connect to the daemon and defer closure of the client.
+	daemonClient, err := daemon.Connect(true, true)
 	if err != nil {
 		return errors.Wrap(err, "unable to connect to daemon")
 	}
-	defer daemonConnection.Close()
+	defer daemonClient.Close()
 
-	// Create a session service client.
-	sessionService := forwardingsvc.NewForwardingClient(daemonConnection)
-
-	// Invoke the session resume method. The stream will close when the
-	// associated context is cancelled.
-	resumeContext, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	stream, err := sessionService.Resume(resumeContext)
-	if err != nil {
-		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to invoke resume")
-	}
-
-	// Send the initial request.
-	request := &forwardingsvc.ResumeRequest{
-		Selection: selection,
-	}
-	if err := stream.Send(request); err != nil {
-		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send resume request")
-	}
-
-	// Create a status line printer.
+	// Perform the resume operation, relaying messages to a status line.
 	statusLinePrinter := &cmd.StatusLinePrinter{}
-
-	// Receive and process responses until we're done.
-	for {
-		if response, err := stream.Recv(); err != nil {
-			statusLinePrinter.BreakIfNonEmpty()
-			return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "resume failed")
-		} else if err = response.EnsureValid(); err != nil {
-			statusLinePrinter.BreakIfNonEmpty()
-			return errors.Wrap(err, "invalid resume response received")
-		} else if response.Message == "" && response.Prompt == "" {
-			statusLinePrinter.Clear()
-			return nil
-		} else if response.Message != "" {
-			statusLinePrinter.Print(response.Message)
-			if err := stream.Send(&forwardingsvc.ResumeRequest{}); err != nil {
-				statusLinePrinter.BreakIfNonEmpty()
-				return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send message response")
-			}
-		} else if response.Prompt != "" {
-			statusLinePrinter.BreakIfNonEmpty()
-			if response, err := prompt.PromptCommandLine(response.Prompt); err != nil {
-				return errors.Wrap(err, "unable to perform prompting")
-			} else if err = stream.Send(&forwardingsvc.ResumeRequest{Response: response}); err != nil {
-				return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send prompt response")
-			}
-		}
+	prompter := &cmd.StatusLinePrompter{Printer: statusLinePrinter}
+	if err := daemonClient.Forwarding().Resume(context.Background(), selection, prompter); err != nil {
+		statusLinePrinter.BreakIfNonEmpty()
+		return err
 	}
+
+	// Success.
+	statusLinePrinter.Clear()
+	return nil
 }
 
 var resumeCommand = &cobra.Command{
@@ -9,9 +9,7 @@ import (
 
 	"github.com/mutagen-io/mutagen/cmd"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
-	"github.com/mutagen-io/mutagen/pkg/grpcutil"
 	"github.com/mutagen-io/mutagen/pkg/selection"
-	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
 )
 
 // TerminateWithLabelSelector is an orchestration convenience method invokes the
@@ -32,54 +30,24 @@ func terminateMain(command *cobra.Command, arguments []string) error {
 		return errors.Wrap(err, "invalid session selection specification")
 	}
 
-	// Connect to the daemon and defer closure of the connection.
-	daemonConnection, err := daemon.CreateClientConnection(true, true)
+	// Connect to the daemon and defer closure of the client.
+	daemonClient, err := daemon.Connect(true, true)
 	if err != nil {
 		return errors.Wrap(err, "unable to connect to daemon")
 	}
-	defer daemonConnection.Close()
+	defer daemonClient.Close()
 
-	// Create a session service client.
-	sessionService := forwardingsvc.NewForwardingClient(daemonConnection)
-
-	// Invoke the session terminate method. The stream will close when the
-	// associated context is cancelled.
-	terminateContext, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	stream, err := sessionService.Terminate(terminateContext)
-	if err != nil {
-		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to invoke terminate")
-	}
-
-	// Send the initial request.
-	request := &forwardingsvc.TerminateRequest{
-		Selection: selection,
-	}
-	if err := stream.Send(request); err != nil {
-		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send terminate request")
-	}
-
-	// Create a status line printer.
+	// Perform the terminate operation, relaying messages to a status line.
 	statusLinePrinter := &cmd.StatusLinePrinter{}
-
-	// Receive and process responses until we're done.
-	for {
-		if response, err := stream.Recv(); err != nil {
-			statusLinePrinter.BreakIfNonEmpty()
-			return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "terminate failed")
-		} else if err = response.EnsureValid(); err != nil {
-			return errors.Wrap(err, "invalid terminate response received")
-		} else if response.Message == "" {
-			statusLinePrinter.Clear()
-			return nil
-		} else if response.Message != "" {
-			statusLinePrinter.Print(response.Message)
-			if err := stream.Send(&forwardingsvc.TerminateRequest{}); err != nil {
-				statusLinePrinter.BreakIfNonEmpty()
-				return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send message response")
-			}
-		}
+	prompter := &cmd.StatusLinePrompter{Printer: statusLinePrinter}
+	if err := daemonClient.Forwarding().Terminate(context.Background(), selection, prompter); err != nil {
+		statusLinePrinter.BreakIfNonEmpty()
+		return err
 	}
+
+	// Success.
+	statusLinePrinter.Clear()
+	return nil
 }
 
 var terminateCommand = &cobra.Command{
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/agent"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/forward"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/project"
@@ -65,6 +66,7 @@ func init() {
 		forward.RootCommand,
 		project.RootCommand,
 		daemon.RootCommand,
+		agent.RootCommand,
 		versionCommand,
 		legalCommand,
 		generateCommand,
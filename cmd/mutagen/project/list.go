@@ -10,6 +10,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/golang/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/cmd"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/forward"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/sync"
 	"github.com/mutagen-io/mutagen/pkg/filesystem/locking"
@@ -17,6 +20,14 @@ import (
 )
 
 func listMain(command *cobra.Command, arguments []string) error {
+	// Create the output renderer (if any).
+	renderer, err := cmd.NewStateRenderer(listConfiguration.output, listConfiguration.template)
+	if err != nil {
+		return errors.Wrap(err, "invalid output specification")
+	} else if renderer != nil && listConfiguration.long {
+		return errors.New("long listing format not supported with machine-readable output")
+	}
+
 	// Compute the name of the configuration file and change our working
 	// directory to the path in which the file resides.
 	var configurationFileName string
@@ -70,6 +81,29 @@ func listMain(command *cobra.Command, arguments []string) error {
 	// Compute the label selector that we're going to use to list sessions.
 	labelSelector := fmt.Sprintf("%s=%s", project.LabelKey, identifier)
 
+	// Handle machine-readable output.
+	if renderer != nil {
+		forwardingStates, err := forward.ListStatesWithLabelSelector(labelSelector)
+		if err != nil {
+			return errors.Wrap(err, "unable to list forwarding session(s)")
+		}
+		synchronizationStates, err := sync.ListStatesWithLabelSelector(labelSelector)
+		if err != nil {
+			return errors.Wrap(err, "unable to list synchronization session(s)")
+		}
+		groups := [][]proto.Message{
+			make([]proto.Message, len(forwardingStates)),
+			make([]proto.Message, len(synchronizationStates)),
+		}
+		for s, state := range forwardingStates {
+			groups[0][s] = state
+		}
+		for s, state := range synchronizationStates {
+			groups[1][s] = state
+		}
+		return renderer.RenderGroups([]string{"forwarding", "synchronization"}, groups)
+	}
+
 	// List forwarding sessions.
 	fmt.Println("Forwarding sessions:")
 	if err := forward.ListWithLabelSelector(labelSelector, listConfiguration.long); err != nil {
@@ -102,6 +136,10 @@ var listConfiguration struct {
 	help bool
 	// long indicates whether or not to use long-format listing.
 	long bool
+	// output specifies the machine-readable output format, if any.
+	output string
+	// template specifies the output template, if any.
+	template string
 }
 
 func init() {
@@ -117,4 +155,6 @@ func init() {
 
 	// Wire up list flags.
 	flags.BoolVarP(&listConfiguration.long, "long", "l", false, "Show detailed session information")
+	flags.StringVarP(&listConfiguration.output, "output", "o", "", "Specify a machine-readable output format (json|yaml)")
+	flags.StringVar(&listConfiguration.template, "template", "", "Specify a Go template with which to format each session state")
 }
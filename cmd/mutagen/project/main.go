@@ -7,8 +7,11 @@ import (
 
 	// Explicitly import packages that need to register protocol handlers.
 	_ "github.com/mutagen-io/mutagen/pkg/forwarding/protocols/docker"
+	_ "github.com/mutagen-io/mutagen/pkg/forwarding/protocols/kubernetes"
 	_ "github.com/mutagen-io/mutagen/pkg/forwarding/protocols/local"
+	_ "github.com/mutagen-io/mutagen/pkg/forwarding/protocols/podman"
 	_ "github.com/mutagen-io/mutagen/pkg/forwarding/protocols/ssh"
+	_ "github.com/mutagen-io/mutagen/pkg/forwarding/protocols/tcp"
 )
 
 func rootMain(command *cobra.Command, arguments []string) error {
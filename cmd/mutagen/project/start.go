@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/mutagen-io/mutagen/cmd"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/forward"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/sync"
@@ -329,25 +330,61 @@ func startMain(command *cobra.Command, arguments []string) error {
 		})
 	}
 
-	// Connect to the daemon and defer closure of the connection.
-	daemonConnection, err := daemon.CreateClientConnection(true, true)
+	// Unless they're trusted, confirm any SSH proxy commands, forwarding
+	// endpoint commands, and hook commands specified in the configuration file,
+	// since they'll be run with the user's credentials.
+	if !startConfiguration.trustCommands {
+		var commands []string
+		for _, specification := range forwardingSpecifications {
+			for _, u := range []*url.URL{specification.Source, specification.Destination} {
+				if command := u.ProxyCommand(); command != "" {
+					commands = append(commands, command)
+				}
+				if command := u.EndpointCommand(); command != "" {
+					commands = append(commands, command)
+				}
+			}
+		}
+		for _, specification := range synchronizationSpecifications {
+			for _, u := range []*url.URL{specification.Alpha, specification.Beta} {
+				if command := u.ProxyCommand(); command != "" {
+					commands = append(commands, command)
+				}
+			}
+			configurations := []*synchronization.Configuration{
+				specification.Configuration,
+				specification.ConfigurationAlpha,
+				specification.ConfigurationBeta,
+			}
+			for _, configuration := range configurations {
+				for _, hook := range configuration.GetHooks() {
+					commands = append(commands, hook.Command)
+				}
+			}
+		}
+		if err := cmd.ConfirmCommands("Project configuration", commands); err != nil {
+			os.Remove(lockPath)
+			return err
+		}
+	}
+
+	// Connect to the daemon and defer closure of the client.
+	daemonClient, err := daemon.Connect(true, true)
 	if err != nil {
 		return errors.Wrap(err, "unable to connect to daemon")
 	}
-	defer daemonConnection.Close()
+	defer daemonClient.Close()
 
 	// Create forwarding sessions.
-	forwardingService := forwardingsvc.NewForwardingClient(daemonConnection)
 	for _, specification := range forwardingSpecifications {
-		if err := forward.CreateWithSpecification(forwardingService, specification); err != nil {
+		if err := forward.CreateWithSpecification(daemonClient.Forwarding(), specification); err != nil {
 			return errors.Errorf("unable to create forwarding session (%s): %v", specification.Name, err)
 		}
 	}
 
 	// Create synchronization sessions.
-	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
 	for _, specification := range synchronizationSpecifications {
-		if err := sync.CreateWithSpecification(synchronizationService, specification); err != nil {
+		if err := sync.CreateWithSpecification(daemonClient.Synchronization(), specification); err != nil {
 			return errors.Errorf("unable to create synchronization session (%s): %v", specification.Name, err)
 		}
 	}
@@ -372,6 +409,9 @@ var startConfiguration struct {
 	// noGlobalConfiguration specifies whether or not the global configuration
 	// file should be ignored.
 	noGlobalConfiguration bool
+	// trustCommands indicates whether or not commands specified in the
+	// configuration file should be allowed to run without confirmation.
+	trustCommands bool
 }
 
 func init() {
@@ -390,4 +430,7 @@ func init() {
 
 	// Wire up general configuration flags.
 	flags.BoolVar(&startConfiguration.noGlobalConfiguration, "no-global-configuration", false, "Ignore the global configuration file")
+
+	// Wire up trust flags.
+	flags.BoolVar(&startConfiguration.trustCommands, "trust-commands", false, "Allow commands specified in the configuration file to run without confirmation")
 }
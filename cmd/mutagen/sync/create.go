@@ -15,12 +15,11 @@ import (
 
 	"github.com/mutagen-io/mutagen/cmd"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+	"github.com/mutagen-io/mutagen/pkg/client"
 	"github.com/mutagen-io/mutagen/pkg/configuration/global"
 	"github.com/mutagen-io/mutagen/pkg/configuration/legacy"
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior"
-	"github.com/mutagen-io/mutagen/pkg/grpcutil"
-	"github.com/mutagen-io/mutagen/pkg/prompt"
 	"github.com/mutagen-io/mutagen/pkg/selection"
 	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
 	"github.com/mutagen-io/mutagen/pkg/synchronization"
@@ -75,51 +74,23 @@ func loadAndValidateLegacyTOMLConfiguration(path string) (*synchronization.Confi
 // methods, it requires provision of a client to avoid creating one for each
 // request.
 func CreateWithSpecification(
-	service synchronizationsvc.SynchronizationClient,
+	sessions *client.SynchronizationClient,
 	specification *synchronizationsvc.CreationSpecification,
 ) error {
-	// Invoke the session create method. The stream will close when the
-	// associated context is cancelled.
-	createContext, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	stream, err := service.Create(createContext)
-	if err != nil {
-		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to invoke create")
-	}
-
-	// Send the initial request.
-	request := &synchronizationsvc.CreateRequest{Specification: specification}
-	if err := stream.Send(request); err != nil {
-		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send create request")
-	}
-
 	// Create a status line printer and defer a break.
 	statusLinePrinter := &cmd.StatusLinePrinter{}
 	defer statusLinePrinter.BreakIfNonEmpty()
 
-	// Receive and process responses until we're done.
-	for {
-		if response, err := stream.Recv(); err != nil {
-			return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "create failed")
-		} else if err = response.EnsureValid(); err != nil {
-			return errors.Wrap(err, "invalid create response received")
-		} else if response.Session != "" {
-			statusLinePrinter.Print(fmt.Sprintf("Created session %s", response.Session))
-			return nil
-		} else if response.Message != "" {
-			statusLinePrinter.Print(response.Message)
-			if err := stream.Send(&synchronizationsvc.CreateRequest{}); err != nil {
-				return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send message response")
-			}
-		} else if response.Prompt != "" {
-			statusLinePrinter.BreakIfNonEmpty()
-			if response, err := prompt.PromptCommandLine(response.Prompt); err != nil {
-				return errors.Wrap(err, "unable to perform prompting")
-			} else if err = stream.Send(&synchronizationsvc.CreateRequest{Response: response}); err != nil {
-				return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send prompt response")
-			}
-		}
+	// Perform creation, relaying messages and prompts to the console.
+	prompter := &cmd.StatusLinePrompter{Printer: statusLinePrinter}
+	session, err := sessions.Create(context.Background(), specification, prompter)
+	if err != nil {
+		return err
 	}
+
+	// Report the new session.
+	statusLinePrinter.Print(fmt.Sprintf("Created session %s", session))
+	return nil
 }
 
 func createMain(command *cobra.Command, arguments []string) error {
@@ -498,18 +469,15 @@ func createMain(command *cobra.Command, arguments []string) error {
 		Paused: createConfiguration.paused,
 	}
 
-	// Connect to the daemon and defer closure of the connection.
-	daemonConnection, err := daemon.CreateClientConnection(true, true)
+	// Connect to the daemon and defer closure of the client.
+	daemonClient, err := daemon.Connect(true, true)
 	if err != nil {
 		return errors.Wrap(err, "unable to connect to daemon")
 	}
-	defer daemonConnection.Close()
-
-	// Create a synchronization service client.
-	service := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	defer daemonClient.Close()
 
 	// Perform creation.
-	return CreateWithSpecification(service, specification)
+	return CreateWithSpecification(daemonClient.Synchronization(), specification)
 }
 
 var createCommand = &cobra.Command{
@@ -0,0 +1,341 @@
+package sync
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+func editMain(command *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) != 1 {
+		return errors.New("a single session must be specified")
+	}
+
+	// Connect to the daemon and defer closure of the client.
+	daemonClient, err := daemon.Connect(true, true)
+	if err != nil {
+		return errors.Wrap(err, "unable to connect to daemon")
+	}
+	defer daemonClient.Close()
+	sessionClient := daemonClient.Synchronization()
+
+	// Look up the current session.
+	_, states, err := sessionClient.List(context.Background(), &selection.Selection{Specifications: arguments}, 0)
+	if err != nil {
+		return err
+	} else if len(states) != 1 {
+		return errors.New("specification matched multiple sessions")
+	}
+	session := states[0].Session
+
+	// Start with the existing session parameters.
+	configuration := session.Configuration
+	configurationAlpha := session.ConfigurationAlpha
+	configurationBeta := session.ConfigurationBeta
+	name := session.Name
+	labels := make(map[string]string, len(session.Labels))
+	for key, value := range session.Labels {
+		labels[key] = value
+	}
+
+	// Update the name, if requested.
+	if command.Flags().Changed("name") {
+		if err := selection.EnsureNameValid(editConfiguration.name); err != nil {
+			return errors.Wrap(err, "invalid session name")
+		}
+		name = editConfiguration.name
+	}
+
+	// Parse, validate, and apply label additions.
+	for _, label := range editConfiguration.labels {
+		components := strings.SplitN(label, "=", 2)
+		var key, value string
+		key = components[0]
+		if len(components) == 2 {
+			value = components[1]
+		}
+		if err := selection.EnsureLabelKeyValid(key); err != nil {
+			return errors.Wrap(err, "invalid label key")
+		} else if err := selection.EnsureLabelValueValid(value); err != nil {
+			return errors.Wrap(err, "invalid label value")
+		}
+		labels[key] = value
+	}
+
+	// Apply label removals.
+	for _, key := range editConfiguration.removeLabels {
+		delete(labels, key)
+	}
+
+	// If a configuration file has been specified, then load it and use it to
+	// replace the existing session-wide configuration. Replacement (rather than
+	// merging) ensures that repeated edits don't accumulate ignores or hooks
+	// and that settings can be removed by editing the file.
+	if editConfiguration.configurationFile != "" {
+		if filepath.Ext(editConfiguration.configurationFile) == ".toml" {
+			cmd.Warning("TOML-based configuration files are deprecated, please migrate to YAML")
+			if c, err := loadAndValidateLegacyTOMLConfiguration(editConfiguration.configurationFile); err != nil {
+				return errors.Wrap(err, "unable to load legacy configuration file")
+			} else {
+				configuration = c
+			}
+		} else {
+			if c, err := loadAndValidateGlobalSynchronizationConfiguration(editConfiguration.configurationFile); err != nil {
+				return errors.Wrap(err, "unable to load configuration file")
+			} else {
+				configuration = c
+			}
+		}
+	}
+
+	// If requested, clear existing ignores and hooks. We do this before merging
+	// in command line configuration so that new ignores can be specified. Since
+	// the configuration was either received from the daemon or loaded from a
+	// file, we're free to modify it.
+	if editConfiguration.clearIgnores {
+		configuration.Ignores = nil
+		configuration.DefaultIgnores = nil
+	}
+	if editConfiguration.clearHooks {
+		configuration.Hooks = nil
+	}
+
+	// Validate and convert the synchronization mode specification.
+	var synchronizationMode core.SynchronizationMode
+	if editConfiguration.synchronizationMode != "" {
+		if err := synchronizationMode.UnmarshalText([]byte(editConfiguration.synchronizationMode)); err != nil {
+			return errors.Wrap(err, "unable to parse synchronization mode")
+		}
+	}
+
+	// Validate and convert the maximum staging file size.
+	var maximumStagingFileSize uint64
+	if editConfiguration.maximumStagingFileSize != "" {
+		if s, err := humanize.ParseBytes(editConfiguration.maximumStagingFileSize); err != nil {
+			return errors.Wrap(err, "unable to parse maximum staging file size")
+		} else {
+			maximumStagingFileSize = s
+		}
+	}
+
+	// Validate and convert the symbolic link mode specification.
+	var symbolicLinkMode core.SymlinkMode
+	if editConfiguration.symbolicLinkMode != "" {
+		if err := symbolicLinkMode.UnmarshalText([]byte(editConfiguration.symbolicLinkMode)); err != nil {
+			return errors.Wrap(err, "unable to parse symbolic link mode")
+		}
+	}
+
+	// Validate and convert watch mode specifications.
+	var watchMode, watchModeAlpha, watchModeBeta synchronization.WatchMode
+	if editConfiguration.watchMode != "" {
+		if err := watchMode.UnmarshalText([]byte(editConfiguration.watchMode)); err != nil {
+			return errors.Wrap(err, "unable to parse watch mode")
+		}
+	}
+	if editConfiguration.watchModeAlpha != "" {
+		if err := watchModeAlpha.UnmarshalText([]byte(editConfiguration.watchModeAlpha)); err != nil {
+			return errors.Wrap(err, "unable to parse watch mode for alpha")
+		}
+	}
+	if editConfiguration.watchModeBeta != "" {
+		if err := watchModeBeta.UnmarshalText([]byte(editConfiguration.watchModeBeta)); err != nil {
+			return errors.Wrap(err, "unable to parse watch mode for beta")
+		}
+	}
+
+	// There's no need to validate the watch polling intervals - any uint32
+	// values are valid.
+
+	// Validate ignore specifications.
+	for _, ignore := range editConfiguration.ignores {
+		if !core.ValidIgnorePattern(ignore) {
+			return errors.Errorf("invalid ignore pattern: %s", ignore)
+		}
+	}
+
+	// Validate and convert the VCS ignore mode specification.
+	var ignoreVCSMode core.IgnoreVCSMode
+	if editConfiguration.ignoreVCS && editConfiguration.noIgnoreVCS {
+		return errors.New("conflicting VCS ignore behavior specified")
+	} else if editConfiguration.ignoreVCS {
+		ignoreVCSMode = core.IgnoreVCSMode_IgnoreVCSModeIgnore
+	} else if editConfiguration.noIgnoreVCS {
+		ignoreVCSMode = core.IgnoreVCSMode_IgnoreVCSModePropagate
+	}
+
+	// Create the command line configurations and merge them into the existing
+	// configurations.
+	configuration = synchronization.MergeConfigurations(configuration, &synchronization.Configuration{
+		SynchronizationMode:    synchronizationMode,
+		MaximumStagingFileSize: maximumStagingFileSize,
+		SymlinkMode:            symbolicLinkMode,
+		WatchMode:              watchMode,
+		WatchPollingInterval:   editConfiguration.watchPollingInterval,
+		Ignores:                editConfiguration.ignores,
+		IgnoreVCSMode:          ignoreVCSMode,
+	})
+	configurationAlpha = synchronization.MergeConfigurations(configurationAlpha, &synchronization.Configuration{
+		WatchMode:            watchModeAlpha,
+		WatchPollingInterval: editConfiguration.watchPollingIntervalAlpha,
+	})
+	configurationBeta = synchronization.MergeConfigurations(configurationBeta, &synchronization.Configuration{
+		WatchMode:            watchModeBeta,
+		WatchPollingInterval: editConfiguration.watchPollingIntervalBeta,
+	})
+
+	// Warn about archive resets, since they'll cause any differences between
+	// the endpoints to be treated as conflicts.
+	oldSymlinkMode := session.Configuration.SymlinkMode
+	if oldSymlinkMode.IsDefault() {
+		oldSymlinkMode = session.Version.DefaultSymlinkMode()
+	}
+	newSymlinkMode := configuration.SymlinkMode
+	if newSymlinkMode.IsDefault() {
+		newSymlinkMode = session.Version.DefaultSymlinkMode()
+	}
+	if newSymlinkMode != oldSymlinkMode {
+		cmd.Warning("Changing the symbolic link mode will reset synchronization history")
+	}
+
+	// Perform the update, relaying messages and prompts to the console. We
+	// identify the session by its identifier to avoid any ambiguity introduced
+	// by concurrent renames.
+	statusLinePrinter := &cmd.StatusLinePrinter{}
+	prompter := &cmd.StatusLinePrompter{Printer: statusLinePrinter}
+	specification := &synchronizationsvc.UpdateSpecification{
+		Session:            session.Identifier,
+		Configuration:      configuration,
+		ConfigurationAlpha: configurationAlpha,
+		ConfigurationBeta:  configurationBeta,
+		Name:               name,
+		Labels:             labels,
+	}
+	if err := sessionClient.Update(context.Background(), specification, prompter); err != nil {
+		statusLinePrinter.BreakIfNonEmpty()
+		return err
+	}
+
+	// Success.
+	statusLinePrinter.Clear()
+	return nil
+}
+
+var editCommand = &cobra.Command{
+	Use:          "edit <session>",
+	Short:        "Update the configuration of an existing synchronization session",
+	RunE:         editMain,
+	SilenceUsage: true,
+}
+
+var editConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+	// name is the new name specification for the session.
+	name string
+	// labels are the label specifications to add to (or update on) the
+	// session.
+	labels []string
+	// removeLabels are the label keys to remove from the session.
+	removeLabels []string
+	// configurationFile specifies a file from which to load configuration that
+	// will replace the existing session configuration.
+	configurationFile string
+	// synchronizationMode specifies the synchronization mode for the session.
+	synchronizationMode string
+	// maximumStagingFileSize is the maximum file size that endpoints will
+	// stage. It can be specified in human-friendly units.
+	maximumStagingFileSize string
+	// symbolicLinkMode specifies the symbolic link handling mode to use for
+	// the session.
+	symbolicLinkMode string
+	// watchMode specifies the filesystem watching mode to use for the session.
+	watchMode string
+	// watchModeAlpha specifies the filesystem watching mode to use for the
+	// session, taking priority over watchMode on alpha if specified.
+	watchModeAlpha string
+	// watchModeBeta specifies the filesystem watching mode to use for the
+	// session, taking priority over watchMode on beta if specified.
+	watchModeBeta string
+	// watchPollingInterval specifies the polling interval to use if using
+	// poll-based or hybrid watching.
+	watchPollingInterval uint32
+	// watchPollingIntervalAlpha specifies the polling interval to use if using
+	// poll-based or hybrid watching, taking priority over watchPollingInterval
+	// on alpha if specified.
+	watchPollingIntervalAlpha uint32
+	// watchPollingIntervalBeta specifies the polling interval to use if using
+	// poll-based or hybrid watching, taking priority over watchPollingInterval
+	// on beta if specified.
+	watchPollingIntervalBeta uint32
+	// ignores is the list of ignore specifications to add to the session.
+	ignores []string
+	// clearIgnores specifies whether or not to remove existing ignore
+	// specifications from the session.
+	clearIgnores bool
+	// clearHooks specifies whether or not to remove existing hooks from the
+	// session.
+	clearHooks bool
+	// ignoreVCS specifies whether or not to enable VCS ignores for the session.
+	ignoreVCS bool
+	// noIgnoreVCS specifies whether or not to disable VCS ignores for the
+	// session.
+	noIgnoreVCS bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := editCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&editConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up name and label flags.
+	flags.StringVarP(&editConfiguration.name, "name", "n", "", "Specify a new name for the session")
+	flags.StringSliceVarP(&editConfiguration.labels, "label", "l", nil, "Add or update labels")
+	flags.StringSliceVar(&editConfiguration.removeLabels, "remove-label", nil, "Remove labels by key")
+
+	// Wire up general configuration flags.
+	flags.StringVarP(&editConfiguration.configurationFile, "configuration-file", "c", "", "Specify a file from which to load replacement session configuration")
+
+	// Wire up synchronization flags.
+	flags.StringVarP(&editConfiguration.synchronizationMode, "sync-mode", "m", "", "Specify synchronization mode (two-way-safe|two-way-resolved|one-way-safe|one-way-replica)")
+	flags.StringVar(&editConfiguration.maximumStagingFileSize, "max-staging-file-size", "", "Specify the maximum (individual) file size that endpoints will stage")
+
+	// Wire up symbolic link flags.
+	flags.StringVar(&editConfiguration.symbolicLinkMode, "symlink-mode", "", "Specify symlink mode (ignore|portable|posix-raw)")
+
+	// Wire up watch flags.
+	flags.StringVar(&editConfiguration.watchMode, "watch-mode", "", "Specify watch mode (portable|force-poll|no-watch)")
+	flags.StringVar(&editConfiguration.watchModeAlpha, "watch-mode-alpha", "", "Specify watch mode for alpha (portable|force-poll|no-watch)")
+	flags.StringVar(&editConfiguration.watchModeBeta, "watch-mode-beta", "", "Specify watch mode for beta (portable|force-poll|no-watch)")
+	flags.Uint32Var(&editConfiguration.watchPollingInterval, "watch-polling-interval", 0, "Specify watch polling interval in seconds")
+	flags.Uint32Var(&editConfiguration.watchPollingIntervalAlpha, "watch-polling-interval-alpha", 0, "Specify watch polling interval in seconds for alpha")
+	flags.Uint32Var(&editConfiguration.watchPollingIntervalBeta, "watch-polling-interval-beta", 0, "Specify watch polling interval in seconds for beta")
+
+	// Wire up ignore flags.
+	flags.StringSliceVarP(&editConfiguration.ignores, "ignore", "i", nil, "Add ignore paths")
+	flags.BoolVar(&editConfiguration.clearIgnores, "clear-ignores", false, "Remove existing ignore paths")
+	flags.BoolVar(&editConfiguration.clearHooks, "clear-hooks", false, "Remove existing hooks")
+	flags.BoolVar(&editConfiguration.ignoreVCS, "ignore-vcs", false, "Ignore VCS directories")
+	flags.BoolVar(&editConfiguration.noIgnoreVCS, "no-ignore-vcs", false, "Propagate VCS directories")
+}
@@ -0,0 +1,118 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+	"github.com/mutagen-io/mutagen/pkg/export"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+)
+
+func exportMain(command *cobra.Command, arguments []string) error {
+	// Validate the output path.
+	if exportConfiguration.output == "" {
+		return errors.New("output path must be specified")
+	} else if _, err := export.FormatForPath(exportConfiguration.output); err != nil {
+		return err
+	}
+
+	// Create session selection specification.
+	selection := &selection.Selection{
+		All:            exportConfiguration.all,
+		Specifications: arguments,
+		LabelSelector:  exportConfiguration.labelSelector,
+	}
+	if err := selection.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid session selection specification")
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.CreateClientConnection(true, true)
+	if err != nil {
+		return errors.Wrap(err, "unable to connect to daemon")
+	}
+	defer daemonConnection.Close()
+
+	// Create a session service client.
+	sessionService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+
+	// Invoke export.
+	request := &synchronizationsvc.ExportRequest{
+		Selection:       selection,
+		IncludeArchives: exportConfiguration.includeArchives,
+	}
+	stream, err := sessionService.Export(context.Background(), request)
+	if err != nil {
+		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to invoke export")
+	}
+	specifications, err := synchronizationsvc.ReceiveExport(stream)
+	if err != nil {
+		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "export failed")
+	}
+
+	// Save the export document.
+	sessions := make([]proto.Message, len(specifications))
+	for i, specification := range specifications {
+		sessions[i] = specification
+	}
+	if err := export.Save(exportConfiguration.output, export.KindSynchronization, sessions); err != nil {
+		return errors.Wrap(err, "unable to save export document")
+	}
+
+	// Print a summary.
+	fmt.Printf("Exported %d session(s) to %s\n", len(sessions), exportConfiguration.output)
+
+	// Success.
+	return nil
+}
+
+var exportCommand = &cobra.Command{
+	Use:          "export [<session>...]",
+	Short:        "Export synchronization sessions to a file for later import",
+	RunE:         exportMain,
+	SilenceUsage: true,
+}
+
+var exportConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+	// all indicates whether or not all sessions should be exported.
+	all bool
+	// labelSelector encodes a label selector to be used in identifying which
+	// sessions should be exported.
+	labelSelector string
+	// output is the path to which the export document should be written. Its
+	// extension determines the document format.
+	output string
+	// includeArchives indicates whether or not session archives should be
+	// included in the export.
+	includeArchives bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := exportCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&exportConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up export flags.
+	flags.BoolVarP(&exportConfiguration.all, "all", "a", false, "Export all sessions")
+	flags.StringVar(&exportConfiguration.labelSelector, "label-selector", "", "Export sessions matching the specified label selector")
+	flags.StringVarP(&exportConfiguration.output, "output", "o", "", "Specify the output path (.yaml, .yml, or .json)")
+	flags.BoolVar(&exportConfiguration.includeArchives, "include-archives", false, "Include synchronization history (only useful if endpoint contents are also preserved)")
+}
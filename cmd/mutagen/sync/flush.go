@@ -9,9 +9,7 @@ import (
 
 	"github.com/mutagen-io/mutagen/cmd"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
-	"github.com/mutagen-io/mutagen/pkg/grpcutil"
 	"github.com/mutagen-io/mutagen/pkg/selection"
-	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
 )
 
 // FlushWithLabelSelector is an orchestration convenience method invokes the
@@ -33,55 +31,24 @@ func flushMain(command *cobra.Command, arguments []string) error {
 		return errors.Wrap(err, "invalid session selection specification")
 	}
 
-	// Connect to the daemon and defer closure of the connection.
-	daemonConnection, err := daemon.CreateClientConnection(true, true)
+	// Connect to the daemon and defer closure of the client.
+	daemonClient, err := daemon.Connect(true, true)
 	if err != nil {
 		return errors.Wrap(err, "unable to connect to daemon")
 	}
-	defer daemonConnection.Close()
+	defer daemonClient.Close()
 
-	// Create a session service client.
-	sessionService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
-
-	// Invoke the session flush method. The stream will close when the
-	// associated context is cancelled.
-	flushContext, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	stream, err := sessionService.Flush(flushContext)
-	if err != nil {
-		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to invoke flush")
-	}
-
-	// Send the initial request.
-	request := &synchronizationsvc.FlushRequest{
-		Selection: selection,
-		SkipWait:  flushConfiguration.skipWait,
-	}
-	if err := stream.Send(request); err != nil {
-		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send flush request")
-	}
-
-	// Create a status line printer.
+	// Perform the flush operation, relaying messages to a status line.
 	statusLinePrinter := &cmd.StatusLinePrinter{}
-
-	// Receive and process responses until we're done.
-	for {
-		if response, err := stream.Recv(); err != nil {
-			statusLinePrinter.BreakIfNonEmpty()
-			return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "flush failed")
-		} else if err = response.EnsureValid(); err != nil {
-			return errors.Wrap(err, "invalid flush response received")
-		} else if response.Message == "" {
-			statusLinePrinter.Clear()
-			return nil
-		} else if response.Message != "" {
-			statusLinePrinter.Print(response.Message)
-			if err := stream.Send(&synchronizationsvc.FlushRequest{}); err != nil {
-				statusLinePrinter.BreakIfNonEmpty()
-				return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send message response")
-			}
-		}
+	prompter := &cmd.StatusLinePrompter{Printer: statusLinePrinter}
+	if err := daemonClient.Synchronization().Flush(context.Background(), selection, flushConfiguration.skipWait, prompter); err != nil {
+		statusLinePrinter.BreakIfNonEmpty()
+		return err
 	}
+
+	// Success.
+	statusLinePrinter.Clear()
+	return nil
 }
 
 var flushCommand = &cobra.Command{
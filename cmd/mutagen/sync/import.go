@@ -0,0 +1,220 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+	"github.com/mutagen-io/mutagen/pkg/export"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+func importMain(command *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) != 1 {
+		return errors.New("a single export document path must be specified")
+	}
+
+	// Validate the duplicate handling mode.
+	var rename bool
+	switch importConfiguration.duplicates {
+	case "skip":
+	case "rename":
+		rename = true
+	default:
+		return errors.Errorf("invalid duplicate handling mode: %s", importConfiguration.duplicates)
+	}
+
+	// Load and validate the export document.
+	sessions, err := export.Load(arguments[0], export.KindSynchronization, func() proto.Message {
+		return &synchronizationsvc.CreationSpecification{}
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to load export document")
+	}
+	specifications := make([]*synchronizationsvc.CreationSpecification, len(sessions))
+	for i, session := range sessions {
+		specification := session.(*synchronizationsvc.CreationSpecification)
+		if err := specification.EnsureValid(); err != nil {
+			return errors.Wrapf(err, "invalid session at index %d", i)
+		}
+		specifications[i] = specification
+	}
+
+	// Replace any environment variables in the endpoint URLs with values from
+	// the local environment, since exported URLs don't carry them and any that
+	// are present may have originated on another system.
+	for _, specification := range specifications {
+		specification.Alpha = specification.Alpha.WithLocalEnvironment(true)
+		specification.Beta = specification.Beta.WithLocalEnvironment(false)
+	}
+
+	// Unless they're trusted, confirm any SSH proxy commands and hook commands
+	// specified in the export document, since they'll be run with the user's
+	// credentials.
+	if !importConfiguration.trustCommands {
+		var commands []string
+		for _, specification := range specifications {
+			for _, u := range []*url.URL{specification.Alpha, specification.Beta} {
+				if command := u.ProxyCommand(); command != "" {
+					commands = append(commands, command)
+				}
+			}
+			configurations := []*synchronization.Configuration{
+				specification.Configuration,
+				specification.ConfigurationAlpha,
+				specification.ConfigurationBeta,
+			}
+			for _, configuration := range configurations {
+				for _, hook := range configuration.GetHooks() {
+					commands = append(commands, hook.Command)
+				}
+			}
+		}
+		if err := cmd.ConfirmCommands("Export document", commands); err != nil {
+			return err
+		}
+	}
+
+	// Connect to the daemon and defer closure of the client.
+	daemonClient, err := daemon.Connect(true, true)
+	if err != nil {
+		return errors.Wrap(err, "unable to connect to daemon")
+	}
+	defer daemonClient.Close()
+	sessionClient := daemonClient.Synchronization()
+
+	// Retrieve existing sessions for duplicate detection.
+	_, states, err := sessionClient.List(context.Background(), &selection.Selection{All: true}, 0)
+	if err != nil {
+		return err
+	}
+	existing := make([]*synchronizationsvc.CreationSpecification, 0, len(states))
+	names := make(map[string]bool, len(states))
+	for _, state := range states {
+		existing = append(existing, &synchronizationsvc.CreationSpecification{
+			Alpha: state.Session.Alpha,
+			Beta:  state.Session.Beta,
+		})
+		if state.Session.Name != "" {
+			names[state.Session.Name] = true
+		}
+	}
+
+	// Create sessions, handling duplicates. Named sessions are considered
+	// duplicates if their name is in use, while unnamed sessions are considered
+	// duplicates if a session already exists between the same endpoints. Only
+	// named duplicates can be renamed.
+	var created, skipped, archived int
+	for _, specification := range specifications {
+		// Handle duplicates.
+		if specification.Name != "" {
+			if names[specification.Name] {
+				if !rename {
+					fmt.Printf("Skipping session %s (name in use)\n", specification.Name)
+					skipped++
+					continue
+				}
+				renamed := export.UniqueName(specification.Name, names)
+				fmt.Printf("Renaming session %s to %s (name in use)\n", specification.Name, renamed)
+				specification.Name = renamed
+			}
+		} else {
+			var duplicate bool
+			for _, e := range existing {
+				if proto.Equal(e.Alpha, specification.Alpha) && proto.Equal(e.Beta, specification.Beta) {
+					duplicate = true
+					break
+				}
+			}
+			if duplicate {
+				fmt.Println("Skipping unnamed session (endpoints already synchronized)")
+				skipped++
+				continue
+			}
+		}
+
+		// Apply paused override.
+		if importConfiguration.paused {
+			specification.Paused = true
+		}
+
+		// Perform creation. Sessions with archives are always created paused,
+		// since an archive that doesn't match the current endpoint contents
+		// could cause deletions to propagate.
+		if err := CreateWithSpecification(sessionClient, specification); err != nil {
+			return errors.Wrap(err, "unable to create session")
+		}
+		created++
+		if specification.Archive != nil && specification.Archive.Root != nil && !specification.Paused {
+			archived++
+		}
+
+		// Record the session for subsequent duplicate detection.
+		existing = append(existing, specification)
+		if specification.Name != "" {
+			names[specification.Name] = true
+		}
+	}
+
+	// Print a summary.
+	fmt.Printf("Imported %d session(s), skipped %d\n", created, skipped)
+	if archived > 0 {
+		cmd.Warning(fmt.Sprintf(
+			"%d session(s) with archives were created paused; verify their endpoints before resuming",
+			archived,
+		))
+	}
+
+	// Success.
+	return nil
+}
+
+var importCommand = &cobra.Command{
+	Use:          "import <path>",
+	Short:        "Recreate synchronization sessions from an export document",
+	RunE:         importMain,
+	SilenceUsage: true,
+}
+
+var importConfiguration struct {
+	// help indicates whether or not help information should be shown for the
+	// command.
+	help bool
+	// duplicates specifies how sessions that duplicate existing sessions
+	// should be handled.
+	duplicates string
+	// paused indicates whether or not to create all imported sessions in a
+	// pre-paused state.
+	paused bool
+	// trustCommands indicates whether or not commands specified in the export
+	// document should be allowed to run without confirmation.
+	trustCommands bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := importCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&importConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up import flags.
+	flags.StringVar(&importConfiguration.duplicates, "duplicates", "skip", "Specify duplicate session handling (skip|rename)")
+	flags.BoolVarP(&importConfiguration.paused, "paused", "p", false, "Create imported sessions pre-paused")
+	flags.BoolVar(&importConfiguration.trustCommands, "trust-commands", false, "Allow commands specified in the export document to run without confirmation")
+}
@@ -206,6 +206,18 @@ func printSession(state *synchronization.State, long bool) {
 			fmt.Println("\tIgnores: None")
 		}
 
+		// Print hooks.
+		if len(configuration.Hooks) > 0 {
+			fmt.Println("\tHooks:")
+			for _, h := range configuration.Hooks {
+				location := h.Location
+				if location.IsDefault() {
+					location = synchronization.HookLocation_HookLocationLocal
+				}
+				fmt.Printf("\t\t%s (%s): %s\n", h.Trigger.Description(), location.Description(), h.Command)
+			}
+		}
+
 		// Compute and print alpha-specific configuration.
 		alphaConfigurationMerged := synchronization.MergeConfigurations(
 			state.Session.Configuration,
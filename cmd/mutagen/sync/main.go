@@ -5,8 +5,11 @@ import (
 
 	// Explicitly import packages that need to register protocol handlers.
 	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/docker"
+	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/kubernetes"
 	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/local"
+	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/podman"
 	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/ssh"
+	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/tcp"
 )
 
 func rootMain(command *cobra.Command, arguments []string) error {
@@ -46,5 +49,7 @@ func init() {
 
 	// HACK: In order for the sync commands to have the correct parent, we have
 	// to add them to the sync command after we add them to the root command.
-	// Thus, we add them in the top-level init function.
+	// Thus, we add them in the top-level init function. Commands that don't
+	// exist at the root of the command structure can be registered directly.
+	RootCommand.AddCommand(editCommand, exportCommand, importCommand)
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/fatih/color"
+
+	"github.com/mutagen-io/mutagen/pkg/prompt"
 )
 
 // StatusLinePrinter provides printing facilities for dynamically updating
@@ -58,3 +60,23 @@ func (p *StatusLinePrinter) BreakIfNonEmpty() {
 		p.nonEmpty = false
 	}
 }
+
+// StatusLinePrompter is a prompt.Prompter implementation that displays
+// messages on a status line and performs prompting on the command line. It's
+// used to relay messages and prompts from daemon operations to the console.
+type StatusLinePrompter struct {
+	// Printer is the status line printer used to display messages.
+	Printer *StatusLinePrinter
+}
+
+// Message implements prompt.Prompter.Message.
+func (p *StatusLinePrompter) Message(message string) error {
+	p.Printer.Print(message)
+	return nil
+}
+
+// Prompt implements prompt.Prompter.Prompt.
+func (p *StatusLinePrompter) Prompt(message string) (string, error) {
+	p.Printer.BreakIfNonEmpty()
+	return prompt.PromptCommandLine(message)
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+const (
+	// OutputFormatJSON is the output format specification for JSON output.
+	OutputFormatJSON = "json"
+	// OutputFormatYAML is the output format specification for YAML output.
+	OutputFormatYAML = "yaml"
+)
+
+// StateRenderer renders session states in a machine-readable format to
+// standard output. States are rendered using their Protocol Buffers JSON
+// representation (for JSON and YAML output) or using a Go template executed
+// against the state message (for template output). Endpoint URL environment
+// variables are omitted from rendered states, since they may reference
+// credentials.
+type StateRenderer struct {
+	// output is the destination for rendered output.
+	output io.Writer
+	// format is the output format, if any.
+	format string
+	// template is the output template, if any.
+	template *template.Template
+	// marshaler is the Protocol Buffers JSON marshaler.
+	marshaler *jsonpb.Marshaler
+}
+
+// NewStateRenderer creates a new state renderer using the specified output
+// format and template. At most one of the two may be specified. If neither is
+// specified, then a nil renderer is returned, indicating that human-readable
+// output should be used.
+func NewStateRenderer(format, templateText string) (*StateRenderer, error) {
+	// Handle the case of human-readable output.
+	if format == "" && templateText == "" {
+		return nil, nil
+	} else if format != "" && templateText != "" {
+		return nil, errors.New("output format and template are mutually exclusive")
+	}
+
+	// Validate the format.
+	if format != "" && format != OutputFormatJSON && format != OutputFormatYAML {
+		return nil, errors.Errorf("unknown output format: %s", format)
+	}
+
+	// Parse the template, if any.
+	var parsed *template.Template
+	if templateText != "" {
+		var err error
+		if parsed, err = template.New("output").Funcs(templateFunctions).Parse(templateText); err != nil {
+			return nil, errors.Wrap(err, "unable to parse template")
+		}
+	}
+
+	// Create the renderer.
+	return &StateRenderer{
+		output:    os.Stdout,
+		format:    format,
+		template:  parsed,
+		marshaler: &jsonpb.Marshaler{EmitDefaults: true},
+	}, nil
+}
+
+// templateFunctions are additional functions available to output templates.
+var templateFunctions = template.FuncMap{
+	"json": func(message proto.Message) (string, error) {
+		return (&jsonpb.Marshaler{}).MarshalToString(message)
+	},
+}
+
+// redact returns a copy of a state with endpoint URL environment variables
+// removed. States of unknown types are returned unmodified.
+func redact(state proto.Message) proto.Message {
+	switch s := state.(type) {
+	case *synchronization.State:
+		if s.Session != nil {
+			s = proto.Clone(s).(*synchronization.State)
+			s.Session.Alpha = s.Session.Alpha.WithoutEnvironment()
+			s.Session.Beta = s.Session.Beta.WithoutEnvironment()
+		}
+		return s
+	case *forwarding.State:
+		if s.Session != nil {
+			s = proto.Clone(s).(*forwarding.State)
+			s.Session.Source = s.Session.Source.WithoutEnvironment()
+			s.Session.Destination = s.Session.Destination.WithoutEnvironment()
+		}
+		return s
+	default:
+		return state
+	}
+}
+
+// marshalList encodes a list of states as a JSON array.
+func (r *StateRenderer) marshalList(states []proto.Message) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	buffer.WriteByte('[')
+	for i, state := range states {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		if err := r.marshaler.Marshal(buffer, redact(state)); err != nil {
+			return nil, errors.Wrap(err, "unable to encode state")
+		}
+	}
+	buffer.WriteByte(']')
+	return buffer.Bytes(), nil
+}
+
+// executeTemplate executes the output template against each of the specified
+// states, terminating each result with a newline.
+func (r *StateRenderer) executeTemplate(states []proto.Message) error {
+	for _, state := range states {
+		if err := r.template.Execute(r.output, redact(state)); err != nil {
+			return errors.Wrap(err, "unable to execute template")
+		}
+		fmt.Fprintln(r.output)
+	}
+	return nil
+}
+
+// RenderList renders a complete list of states. JSON and YAML output encode the
+// list as an array. Template output executes the template once per state.
+func (r *StateRenderer) RenderList(states []proto.Message) error {
+	// Handle template output.
+	if r.template != nil {
+		return r.executeTemplate(states)
+	}
+
+	// Encode the list.
+	data, err := r.marshalList(states)
+	if err != nil {
+		return err
+	}
+
+	// Format and print the list.
+	if r.format == OutputFormatYAML {
+		if data, err = encoding.JSONToYAML(data); err != nil {
+			return errors.Wrap(err, "unable to convert states to YAML")
+		}
+		_, err = r.output.Write(data)
+		return err
+	}
+	indented := &bytes.Buffer{}
+	if err := json.Indent(indented, data, "", "  "); err != nil {
+		return errors.Wrap(err, "unable to format JSON")
+	}
+	indented.WriteByte('\n')
+	_, err = indented.WriteTo(r.output)
+	return err
+}
+
+// RenderGroups renders named groups of states. JSON and YAML output encode the
+// groups as an object whose keys are the group names (in the order specified)
+// and whose values are arrays of states. Template output executes the template
+// once per state, in group order.
+func (r *StateRenderer) RenderGroups(names []string, groups [][]proto.Message) error {
+	// Validate arguments.
+	if len(names) != len(groups) {
+		return errors.New("group name and group count mismatch")
+	}
+
+	// Handle template output.
+	if r.template != nil {
+		for _, group := range groups {
+			if err := r.executeTemplate(group); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Encode the groups as an object.
+	buffer := &bytes.Buffer{}
+	buffer.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return errors.Wrap(err, "unable to encode group name")
+		}
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		list, err := r.marshalList(groups[i])
+		if err != nil {
+			return err
+		}
+		buffer.Write(list)
+	}
+	buffer.WriteByte('}')
+
+	// Format and print the groups.
+	if r.format == OutputFormatYAML {
+		data, err := encoding.JSONToYAML(buffer.Bytes())
+		if err != nil {
+			return errors.Wrap(err, "unable to convert states to YAML")
+		}
+		_, err = r.output.Write(data)
+		return err
+	}
+	indented := &bytes.Buffer{}
+	if err := json.Indent(indented, buffer.Bytes(), "", "  "); err != nil {
+		return errors.Wrap(err, "unable to format JSON")
+	}
+	indented.WriteByte('\n')
+	_, err := indented.WriteTo(r.output)
+	return err
+}
+
+// RenderStreamEntry renders a single state as an entry in a stream of states.
+// JSON output encodes each entry as a single line (i.e. newline-delimited
+// JSON). YAML output encodes each entry as a separate YAML document. Template
+// output executes the template against the state.
+func (r *StateRenderer) RenderStreamEntry(state proto.Message) error {
+	// Handle template output.
+	if r.template != nil {
+		return r.executeTemplate([]proto.Message{state})
+	}
+
+	// Encode the state.
+	encoded, err := r.marshaler.MarshalToString(redact(state))
+	if err != nil {
+		return errors.Wrap(err, "unable to encode state")
+	}
+
+	// Format and print the state.
+	if r.format == OutputFormatYAML {
+		data, err := encoding.JSONToYAML([]byte(encoded))
+		if err != nil {
+			return errors.Wrap(err, "unable to convert state to YAML")
+		}
+		_, err = fmt.Fprintf(r.output, "---\n%s", data)
+		return err
+	}
+	_, err = fmt.Fprintln(r.output, encoded)
+	return err
+}
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+const (
+	// testSecret is an environment variable value that should never appear in
+	// rendered output.
+	testSecret = "render-test-secret"
+)
+
+// testSynchronizationState creates a synchronization session state whose
+// endpoint URLs carry environment variables.
+func testSynchronizationState(name string) *synchronization.State {
+	return &synchronization.State{
+		Session: &synchronization.Session{
+			Identifier: "sync_" + name,
+			Name:       name,
+			Alpha: &url.URL{
+				Kind:        url.Kind_Synchronization,
+				Protocol:    url.Protocol_Docker,
+				Host:        "container",
+				Path:        "/alpha",
+				Environment: map[string]string{"DOCKER_HOST": testSecret},
+			},
+			Beta: &url.URL{
+				Kind: url.Kind_Synchronization,
+				Path: "/beta",
+			},
+		},
+	}
+}
+
+// testForwardingState creates a forwarding session state whose endpoint URLs
+// carry environment variables.
+func testForwardingState(name string) *forwarding.State {
+	return &forwarding.State{
+		Session: &forwarding.Session{
+			Identifier: "fwrd_" + name,
+			Name:       name,
+			Source: &url.URL{
+				Kind: url.Kind_Forwarding,
+				Path: "tcp:localhost:8080",
+			},
+			Destination: &url.URL{
+				Kind:        url.Kind_Forwarding,
+				Protocol:    url.Protocol_Docker,
+				Host:        "container",
+				Path:        "tcp:localhost:80",
+				Environment: map[string]string{"DOCKER_HOST": testSecret},
+			},
+		},
+	}
+}
+
+// testRenderer creates a renderer that writes to a buffer.
+func testRenderer(t *testing.T, format, templateText string) (*StateRenderer, *bytes.Buffer) {
+	renderer, err := NewStateRenderer(format, templateText)
+	if err != nil {
+		t.Fatal("unable to create renderer:", err)
+	} else if renderer == nil {
+		t.Fatal("nil renderer returned")
+	}
+	output := &bytes.Buffer{}
+	renderer.output = output
+	return renderer, output
+}
+
+// TestNewStateRendererHumanReadable tests that a nil renderer is returned when
+// neither a format nor a template is specified.
+func TestNewStateRendererHumanReadable(t *testing.T) {
+	if renderer, err := NewStateRenderer("", ""); err != nil {
+		t.Fatal("unable to create renderer:", err)
+	} else if renderer != nil {
+		t.Error("non-nil renderer returned for human-readable output")
+	}
+}
+
+// TestNewStateRendererInvalid tests that invalid renderer specifications are
+// rejected.
+func TestNewStateRendererInvalid(t *testing.T) {
+	if _, err := NewStateRenderer("xml", ""); err == nil {
+		t.Error("unknown output format accepted")
+	}
+	if _, err := NewStateRenderer(OutputFormatJSON, "{{.}}"); err == nil {
+		t.Error("output format and template accepted together")
+	}
+	if _, err := NewStateRenderer("", "{{"); err == nil {
+		t.Error("invalid template accepted")
+	}
+}
+
+// TestRedact tests that redaction removes URL environment variables without
+// modifying the original state.
+func TestRedact(t *testing.T) {
+	// Test synchronization states.
+	synchronizationState := testSynchronizationState("sync")
+	redactedSynchronization := redact(synchronizationState).(*synchronization.State)
+	if len(redactedSynchronization.Session.Alpha.Environment) != 0 {
+		t.Error("alpha URL environment not redacted")
+	} else if len(synchronizationState.Session.Alpha.Environment) == 0 {
+		t.Error("original synchronization state modified")
+	}
+
+	// Test forwarding states.
+	forwardingState := testForwardingState("forward")
+	redactedForwarding := redact(forwardingState).(*forwarding.State)
+	if len(redactedForwarding.Session.Destination.Environment) != 0 {
+		t.Error("destination URL environment not redacted")
+	} else if len(forwardingState.Session.Destination.Environment) == 0 {
+		t.Error("original forwarding state modified")
+	}
+
+	// Test states without sessions.
+	empty := &synchronization.State{}
+	if redact(empty) != empty {
+		t.Error("state without session not returned unmodified")
+	}
+}
+
+// TestRenderListJSON tests JSON list rendering.
+func TestRenderListJSON(t *testing.T) {
+	renderer, output := testRenderer(t, OutputFormatJSON, "")
+	states := []proto.Message{testSynchronizationState("first"), testSynchronizationState("second")}
+	if err := renderer.RenderList(states); err != nil {
+		t.Fatal("unable to render list:", err)
+	}
+	rendered := output.String()
+	if !strings.HasPrefix(rendered, "[\n") || !strings.HasSuffix(rendered, "]\n") {
+		t.Error("JSON output is not an indented array:", rendered)
+	} else if strings.Index(rendered, `"sync_first"`) > strings.Index(rendered, `"sync_second"`) {
+		t.Error("JSON output does not preserve state order")
+	} else if strings.Contains(rendered, testSecret) {
+		t.Error("JSON output contains URL environment variables")
+	}
+}
+
+// TestRenderListYAML tests YAML list rendering.
+func TestRenderListYAML(t *testing.T) {
+	renderer, output := testRenderer(t, OutputFormatYAML, "")
+	if err := renderer.RenderList([]proto.Message{testForwardingState("forward")}); err != nil {
+		t.Fatal("unable to render list:", err)
+	}
+	rendered := output.String()
+	if !strings.HasPrefix(rendered, "- session:\n") {
+		t.Error("YAML output is not a sequence of states:", rendered)
+	} else if strings.Contains(rendered, testSecret) {
+		t.Error("YAML output contains URL environment variables")
+	}
+}
+
+// TestRenderListYAMLEmpty tests YAML rendering of an empty list.
+func TestRenderListYAMLEmpty(t *testing.T) {
+	renderer, output := testRenderer(t, OutputFormatYAML, "")
+	if err := renderer.RenderList(nil); err != nil {
+		t.Fatal("unable to render list:", err)
+	} else if output.String() != "[]\n" {
+		t.Errorf("unexpected output for empty list: %q", output.String())
+	}
+}
+
+// TestRenderListTemplate tests template list rendering.
+func TestRenderListTemplate(t *testing.T) {
+	renderer, output := testRenderer(t, "", `{{.Session.Name}} {{json .Session.Alpha}}`)
+	states := []proto.Message{testSynchronizationState("first"), testSynchronizationState("second")}
+	if err := renderer.RenderList(states); err != nil {
+		t.Fatal("unable to render list:", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatal("unexpected number of output lines:", len(lines))
+	} else if !strings.HasPrefix(lines[0], "first ") || !strings.HasPrefix(lines[1], "second ") {
+		t.Error("template output does not match states:", lines)
+	} else if strings.Contains(output.String(), testSecret) {
+		t.Error("template output contains URL environment variables")
+	}
+}
+
+// TestRenderGroups tests group rendering.
+func TestRenderGroups(t *testing.T) {
+	// Create test groups.
+	names := []string{"forwarding", "synchronization"}
+	groups := [][]proto.Message{
+		{testForwardingState("forward")},
+		{testSynchronizationState("sync")},
+	}
+
+	// Test JSON output.
+	renderer, output := testRenderer(t, OutputFormatJSON, "")
+	if err := renderer.RenderGroups(names, groups); err != nil {
+		t.Fatal("unable to render groups:", err)
+	}
+	rendered := output.String()
+	if !strings.HasPrefix(rendered, "{\n  \"forwarding\": [") {
+		t.Error("JSON output does not start with first group:", rendered)
+	} else if strings.Index(rendered, `"forwarding"`) > strings.Index(rendered, `"synchronization"`) {
+		t.Error("JSON output does not preserve group order")
+	} else if strings.Contains(rendered, testSecret) {
+		t.Error("JSON output contains URL environment variables")
+	}
+
+	// Test YAML output.
+	renderer, output = testRenderer(t, OutputFormatYAML, "")
+	if err := renderer.RenderGroups(names, groups); err != nil {
+		t.Fatal("unable to render groups:", err)
+	}
+	rendered = output.String()
+	if !strings.HasPrefix(rendered, "forwarding:\n") || !strings.Contains(rendered, "\nsynchronization:\n") {
+		t.Error("YAML output does not contain groups in order:", rendered)
+	} else if strings.Contains(rendered, testSecret) {
+		t.Error("YAML output contains URL environment variables")
+	}
+
+	// Test mismatched arguments.
+	if renderer.RenderGroups(names[:1], groups) == nil {
+		t.Error("group name and group count mismatch not detected")
+	}
+}
+
+// TestRenderStreamEntry tests stream entry rendering.
+func TestRenderStreamEntry(t *testing.T) {
+	// Test JSON output.
+	renderer, output := testRenderer(t, OutputFormatJSON, "")
+	for _, name := range []string{"first", "second"} {
+		if err := renderer.RenderStreamEntry(testSynchronizationState(name)); err != nil {
+			t.Fatal("unable to render stream entry:", err)
+		}
+	}
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Error("JSON stream entries not rendered one per line:", lines)
+	} else if strings.Contains(output.String(), testSecret) {
+		t.Error("JSON output contains URL environment variables")
+	}
+
+	// Test YAML output.
+	renderer, output = testRenderer(t, OutputFormatYAML, "")
+	for _, name := range []string{"first", "second"} {
+		if err := renderer.RenderStreamEntry(testSynchronizationState(name)); err != nil {
+			t.Fatal("unable to render stream entry:", err)
+		}
+	}
+	if strings.Count(output.String(), "---\n") != 2 {
+		t.Error("YAML stream entries not rendered as separate documents:", output.String())
+	} else if strings.Contains(output.String(), testSecret) {
+		t.Error("YAML output contains URL environment variables")
+	}
+}
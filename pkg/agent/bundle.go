@@ -9,6 +9,8 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 
@@ -18,6 +20,14 @@ import (
 const (
 	// BundleName is the base name of the agent bundle.
 	BundleName = "mutagen-agents.tar.gz"
+
+	// BundleSearchPathEnvironmentVariable is the name of the environment
+	// variable that can be used to specify additional locations in which to
+	// search for the agent bundle. It is a list of paths separated by the
+	// platform's path list separator, each of which may be either a bundle file
+	// or a directory containing a bundle named BundleName. These locations are
+	// searched (in order) before the expected bundle location.
+	BundleSearchPathEnvironmentVariable = "MUTAGEN_AGENT_BUNDLE_PATH"
 )
 
 // BundleLocation encodes an expected location for the agent bundle.
@@ -41,13 +51,9 @@ const (
 // package.
 var ExpectedBundleLocation BundleLocation
 
-// ExecutableForPlatform attempts to locate the agent bundle and extract an
-// agent executable for the specified target platform. If no output path is
-// specified, then the extracted file will be in a temporary location accessible
-// to only the user, and will have the executability bit set if it makes sense.
-// The path to the extracted file will be returned, and the caller is
-// responsible for cleaning up the file if this function returns a nil error.
-func ExecutableForPlatform(goos, goarch, outputPath string) (string, error) {
+// defaultBundlePath computes the path to the agent bundle in the expected
+// bundle location.
+func defaultBundlePath() (string, error) {
 	// Compute the path to the location in which we expect to find the agent
 	// bundle.
 	var bundleLocationPath string
@@ -68,42 +74,324 @@ func ExecutableForPlatform(goos, goarch, outputPath string) (string, error) {
 	}
 
 	// Compute the path to the agent bundle.
-	bundlePath := filepath.Join(bundleLocationPath, BundleName)
+	return filepath.Join(bundleLocationPath, BundleName), nil
+}
+
+// bundleSearchPaths computes the list of paths at which to look for the agent
+// bundle, in order of preference.
+func bundleSearchPaths() ([]string, error) {
+	// Add any paths specified in the environment.
+	var results []string
+	for _, path := range filepath.SplitList(os.Getenv(BundleSearchPathEnvironmentVariable)) {
+		if path == "" {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			path = filepath.Join(path, BundleName)
+		}
+		results = append(results, path)
+	}
+
+	// Add the default path.
+	if path, err := defaultBundlePath(); err != nil {
+		return nil, err
+	} else {
+		results = append(results, path)
+	}
+
+	// Done.
+	return results, nil
+}
 
-	// Open the bundle path and ensure its closure.
-	bundle, err := os.Open(bundlePath)
+// bundleReader provides sequential access to the entries in the agent bundle.
+type bundleReader struct {
+	// file is the underlying bundle file.
+	file *os.File
+	// decompressor is the bundle decompressor.
+	decompressor *gzip.Reader
+	// *tar.Reader is the bundle archive reader.
+	*tar.Reader
+}
+
+// openBundle opens the agent bundle at the specified path for reading. If the
+// bundle can't be opened, then the error from os.Open is returned unwrapped.
+func openBundle(path string) (*bundleReader, error) {
+	// Open the bundle.
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a decompressor.
+	decompressor, err := gzip.NewReader(file)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to open agent bundle")
+		file.Close()
+		return nil, errors.Wrap(err, "unable to decompress agent bundle")
+	}
+
+	// Success.
+	return &bundleReader{
+		file:         file,
+		decompressor: decompressor,
+		Reader:       tar.NewReader(decompressor),
+	}, nil
+}
+
+// Close closes the bundle reader.
+func (r *bundleReader) Close() error {
+	r.decompressor.Close()
+	return r.file.Close()
+}
+
+// readBundleManifest reads the manifest from the agent bundle at the specified
+// path. If the bundle can't be opened, then the error from os.Open is returned
+// unwrapped.
+func readBundleManifest(path string) (BundleManifest, error) {
+	// Open the bundle and ensure its closure.
+	bundle, err := openBundle(path)
+	if err != nil {
+		return nil, err
 	}
 	defer bundle.Close()
 
-	// Create a decompressor and ensure its closure.
-	bundleDecompressor, err := gzip.NewReader(bundle)
+	// Read the manifest, which should be the first entry in the bundle.
+	if header, err := bundle.Next(); err != nil {
+		return nil, errors.Wrap(err, "unable to read archive header")
+	} else if header.Name != BundleManifestName {
+		return nil, errors.New("agent bundle has no manifest")
+	} else if manifest, err := ReadBundleManifest(bundle); err != nil {
+		return nil, errors.Wrap(err, "unable to read agent bundle manifest")
+	} else {
+		return manifest, nil
+	}
+}
+
+// locatedBundleManifest is a bundle manifest along with the path to the agent
+// bundle from which it was read.
+type locatedBundleManifest struct {
+	// path is the path to the agent bundle.
+	path string
+	// manifest is the agent bundle manifest.
+	manifest BundleManifest
+}
+
+// readBundleManifests reads the manifests for all agent bundles found in the
+// bundle search paths, in order of preference. It also returns the paths that
+// were searched.
+func readBundleManifests() ([]locatedBundleManifest, []string, error) {
+	// Compute the paths to search.
+	paths, err := bundleSearchPaths()
 	if err != nil {
-		return "", errors.Wrap(err, "unable to decompress agent bundle")
+		return nil, nil, err
+	}
+
+	// Read the manifest for each bundle that exists.
+	var results []locatedBundleManifest
+	for _, path := range paths {
+		if manifest, err := readBundleManifest(path); err == nil {
+			results = append(results, locatedBundleManifest{path, manifest})
+		} else if !os.IsNotExist(err) {
+			return nil, nil, errors.Wrapf(err, "unable to read agent bundle (%s)", path)
+		}
 	}
-	defer bundleDecompressor.Close()
 
-	// Create an archive reader.
-	bundleArchive := tar.NewReader(bundleDecompressor)
+	// Success.
+	return results, paths, nil
+}
+
+// manifests are the manifests describing the agents available for
+// installation.
+type manifests struct {
+	// bundles are the manifests for the agent bundles found in the bundle
+	// search paths, in order of preference. It is empty if no bundle could be
+	// found.
+	bundles []locatedBundleManifest
+	// source is the manifest for the agent source. It is only loaded if an
+	// agent source is configured and none of the agent bundles contains an
+	// agent for the platform of interest.
+	source BundleManifest
+}
+
+// bundleFor returns the path to the preferred agent bundle containing an agent
+// for the specified platform (of the form GOOS_GOARCH), along with the digest
+// for that agent.
+func (m *manifests) bundleFor(platform string) (string, ExecutableDigest, bool) {
+	for _, b := range m.bundles {
+		if digest, ok := b.manifest[platform]; ok {
+			return b.path, digest, true
+		}
+	}
+	return "", ExecutableDigest{}, false
+}
+
+// Contains returns whether or not the specified digest corresponds to the
+// available agent for the specified platform (of the form GOOS_GOARCH).
+func (m *manifests) Contains(platform string, digest ExecutableDigest) bool {
+	for _, b := range m.bundles {
+		if b.manifest.Contains(platform, digest) {
+			return true
+		}
+	}
+	return m.source.Contains(platform, digest)
+}
+
+// missingManifestsError indicates that neither an agent bundle nor an agent
+// source is available. This is typically the case for development builds.
+type missingManifestsError struct {
+	// searched are the paths at which the agent bundle was searched for.
+	searched []string
+}
+
+// Error implements error.Error.
+func (e *missingManifestsError) Error() string {
+	return fmt.Sprintf(
+		"agent bundle not found (searched %s) and no agent source configured",
+		strings.Join(e.searched, ", "),
+	)
+}
+
+// manifestsLock serializes access to the manifest caches.
+var manifestsLock sync.Mutex
+
+// cachedBundleManifests are the cached agent bundle manifests.
+var cachedBundleManifests []locatedBundleManifest
+
+// cachedBundleSearchPaths are the bundle search paths for which
+// cachedBundleManifests was loaded.
+var cachedBundleSearchPaths []string
+
+// cachedBundleManifestsConfiguration is the bundle search path configuration
+// for which cachedBundleManifests was loaded. It is nil if the bundle manifests
+// haven't been loaded.
+var cachedBundleManifestsConfiguration *string
+
+// cachedSourceManifest is the cached agent source manifest.
+var cachedSourceManifest BundleManifest
+
+// cachedSourceManifestConfiguration is the agent source configuration for which
+// cachedSourceManifest was loaded.
+var cachedSourceManifestConfiguration string
+
+// loadManifests loads the manifests needed to locate and verify the agent for
+// the specified platform (of the form GOOS_GOARCH). The agent bundle manifests
+// are always loaded, but the agent source manifest is only loaded if none of
+// the agent bundles contains an agent for the platform. If no bundle is found
+// and no agent source is configured, then a *missingManifestsError is returned.
+// Manifests are cached after they're successfully loaded, though they'll be
+// reloaded if the environment-based configuration changes.
+func loadManifests(platform string) (*manifests, error) {
+	// Lock the manifest caches.
+	manifestsLock.Lock()
+	defer manifestsLock.Unlock()
+
+	// Load the bundle manifests if they aren't cached for the current
+	// configuration.
+	bundleConfiguration := os.Getenv(BundleSearchPathEnvironmentVariable)
+	if cachedBundleManifestsConfiguration == nil || *cachedBundleManifestsConfiguration != bundleConfiguration {
+		bundles, searched, err := readBundleManifests()
+		if err != nil {
+			return nil, err
+		}
+		cachedBundleManifests = bundles
+		cachedBundleSearchPaths = searched
+		cachedBundleManifestsConfiguration = &bundleConfiguration
+	}
+	result := &manifests{bundles: cachedBundleManifests}
+
+	// If a bundle contains an agent for the platform, then there's no need to
+	// consult the agent source.
+	if _, _, ok := result.bundleFor(platform); ok {
+		return result, nil
+	}
+
+	// Check whether or not an agent source is configured.
+	source := currentSource()
+	if source == "" {
+		if len(result.bundles) == 0 {
+			return nil, &missingManifestsError{cachedBundleSearchPaths}
+		}
+		return result, nil
+	}
+
+	// Load the source manifest if it isn't cached for the current
+	// configuration.
+	sourceConfiguration := source + "\x00" + os.Getenv(SourceManifestDigestEnvironmentVariable)
+	if cachedSourceManifest == nil || cachedSourceManifestConfiguration != sourceConfiguration {
+		sourceManifest, err := readSourceManifest(source)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read agent source manifest")
+		}
+		cachedSourceManifest = sourceManifest
+		cachedSourceManifestConfiguration = sourceConfiguration
+	}
+	result.source = cachedSourceManifest
+
+	// Success.
+	return result, nil
+}
+
+// extractFromBundle extracts the specified entry from the agent bundle at the
+// specified path.
+func extractFromBundle(path, name string, writer io.Writer) error {
+	// Open the bundle and ensure its closure.
+	bundle, err := openBundle(path)
+	if err != nil {
+		return errors.Wrap(err, "unable to open agent bundle")
+	}
+	defer bundle.Close()
 
 	// Scan until we find a matching header.
-	var header *tar.Header
 	for {
-		if h, err := bundleArchive.Next(); err != nil {
+		if header, err := bundle.Next(); err != nil {
 			if err == io.EOF {
-				break
+				return errors.New("entry not found in agent bundle")
 			}
-			return "", errors.Wrap(err, "unable to read archive header")
-		} else if h.Name == fmt.Sprintf("%s_%s", goos, goarch) {
-			header = h
-			break
+			return errors.Wrap(err, "unable to read archive header")
+		} else if header.Name == name {
+			if _, err := io.CopyN(writer, bundle, header.Size); err != nil {
+				return errors.Wrap(err, "unable to copy agent data")
+			}
+			return nil
 		}
 	}
+}
+
+// ExecutableForPlatform attempts to locate an agent executable for the
+// specified target platform, first in the agent bundle and then (if necessary)
+// in the agent source. If no output path is specified, then the extracted file
+// will be in a temporary location accessible to only the user, and will have
+// the executability bit set if it makes sense. The extracted executable is
+// verified against the corresponding manifest. The path to the extracted file
+// will be returned, and the caller is responsible for cleaning up the file if
+// this function returns a nil error.
+func ExecutableForPlatform(goos, goarch, outputPath string) (string, error) {
+	// Load manifests.
+	platform := fmt.Sprintf("%s_%s", goos, goarch)
+	manifests, err := loadManifests(platform)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to load agent manifests")
+	}
 
-	// Check if we have a valid header. If not, there was no match.
-	if header == nil {
-		return "", errors.New("unsupported platform")
+	// Determine where to retrieve the agent from and what digest to expect.
+	var expectedDigest ExecutableDigest
+	var retrieve func(io.Writer) error
+	if path, digest, ok := manifests.bundleFor(platform); ok {
+		expectedDigest = digest
+		retrieve = func(writer io.Writer) error {
+			return extractFromBundle(path, platform, writer)
+		}
+	} else if digest, ok := manifests.source[platform]; ok {
+		expectedDigest = digest
+		retrieve = func(writer io.Writer) error {
+			return fetchFromSource(currentSource(), platform, writer)
+		}
+	} else if currentSource() == "" {
+		return "", errors.Errorf(
+			"no agent available for %s/%s in agent bundle (set %s to specify an agent source)",
+			goos, goarch, SourceEnvironmentVariable,
+		)
+	} else {
+		return "", errors.Errorf("no agent available for %s/%s in agent bundle or agent source", goos, goarch)
 	}
 
 	// If an output path has been specified, then open the path for writing,
@@ -119,10 +407,10 @@ func ExecutableForPlatform(goos, goarch, outputPath string) (string, error) {
 	}
 
 	// Copy data into the file.
-	if _, err := io.CopyN(file, bundleArchive, header.Size); err != nil {
+	if err := retrieve(file); err != nil {
 		file.Close()
 		os.Remove(file.Name())
-		return "", errors.Wrap(err, "unable to copy agent data")
+		return "", err
 	}
 
 	// If we're not on Windows and our target system is not Windows, mark the
@@ -145,6 +433,15 @@ func ExecutableForPlatform(goos, goarch, outputPath string) (string, error) {
 		return "", errors.Wrap(err, "unable to close temporary file")
 	}
 
+	// Verify the extracted executable against the manifest.
+	if digest, err := DigestFile(file.Name()); err != nil {
+		os.Remove(file.Name())
+		return "", errors.Wrap(err, "unable to compute agent digest")
+	} else if digest != expectedDigest {
+		os.Remove(file.Name())
+		return "", errors.New("extracted agent does not match manifest")
+	}
+
 	// Success.
 	return file.Name(), nil
 }
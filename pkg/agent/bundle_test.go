@@ -1,6 +1,10 @@
 package agent
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -71,3 +75,131 @@ func TestExecutableForPlatformWithOutputPath(t *testing.T) {
 		t.Error("unable to remove agent executable:", err)
 	}
 }
+
+// createTestBundle creates an agent bundle named BundleName in the specified
+// directory containing a fake agent for the specified platform.
+func createTestBundle(t *testing.T, directory, platform string) {
+	// Mark this as a helper function.
+	t.Helper()
+
+	// Create the bundle.
+	file, err := os.Create(filepath.Join(directory, BundleName))
+	if err != nil {
+		t.Fatal("unable to create bundle:", err)
+	}
+	defer file.Close()
+
+	// Write a manifest and the fake agent.
+	compressor := gzip.NewWriter(file)
+	archiver := tar.NewWriter(compressor)
+	manifest := &bytes.Buffer{}
+	BundleManifest{
+		platform: sha256.Sum256([]byte(testAgentContent)),
+	}.Write(manifest)
+	for _, entry := range []struct {
+		name    string
+		content []byte
+	}{
+		{BundleManifestName, manifest.Bytes()},
+		{platform, []byte(testAgentContent)},
+	} {
+		header := &tar.Header{Name: entry.name, Mode: 0600, Size: int64(len(entry.content))}
+		if err := archiver.WriteHeader(header); err != nil {
+			t.Fatal("unable to write bundle header:", err)
+		} else if _, err := archiver.Write(entry.content); err != nil {
+			t.Fatal("unable to write bundle entry:", err)
+		}
+	}
+	archiver.Close()
+	compressor.Close()
+}
+
+// testExecutableFromBundle tests extraction of the fake agent for the
+// fakeos/fakearch platform using the current configuration.
+func testExecutableFromBundle(t *testing.T) {
+	// Mark this as a helper function.
+	t.Helper()
+
+	// Perform extraction and verify the result.
+	executable, err := ExecutableForPlatform("fakeos", "fakearch", "")
+	if err != nil {
+		t.Fatal("unable to extract agent from bundle search path:", err)
+	}
+	defer os.Remove(executable)
+	if content, err := ioutil.ReadFile(executable); err != nil {
+		t.Fatal("unable to read extracted agent:", err)
+	} else if string(content) != testAgentContent {
+		t.Error("extracted agent content does not match expected")
+	}
+}
+
+// TestExecutableFromBundleSearchPath tests that ExecutableForPlatform finds
+// bundles specified via the bundle search path.
+func TestExecutableFromBundleSearchPath(t *testing.T) {
+	// Create a temporary directory and defer its removal.
+	directory, err := ioutil.TempDir("", "mutagen_bundle_search")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Create a bundle containing a manifest and a fake agent.
+	createTestBundle(t, directory, "fakeos_fakearch")
+
+	// Point the bundle search path at the directory and test extraction.
+	defer setTestEnvironmentVariable(BundleSearchPathEnvironmentVariable, directory)()
+	testExecutableFromBundle(t)
+}
+
+// TestExecutableFromSecondBundle tests that ExecutableForPlatform considers
+// all bundles in the bundle search path, not just the first bundle found.
+func TestExecutableFromSecondBundle(t *testing.T) {
+	// Create temporary directories and defer their removal.
+	first, err := ioutil.TempDir("", "mutagen_bundle_search")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(first)
+	second, err := ioutil.TempDir("", "mutagen_bundle_search")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(second)
+
+	// Create a bundle for a different platform in the first directory and a
+	// bundle for the target platform in the second directory.
+	createTestBundle(t, first, "otheros_otherarch")
+	createTestBundle(t, second, "fakeos_fakearch")
+
+	// Point the bundle search path at both directories and test extraction.
+	searchPath := first + string(filepath.ListSeparator) + second
+	defer setTestEnvironmentVariable(BundleSearchPathEnvironmentVariable, searchPath)()
+	testExecutableFromBundle(t)
+}
+
+// TestLoadManifestsSkipsSourceForBundledPlatform tests that the agent source
+// isn't consulted for platforms available in an agent bundle.
+func TestLoadManifestsSkipsSourceForBundledPlatform(t *testing.T) {
+	// Create a temporary directory and defer its removal.
+	directory, err := ioutil.TempDir("", "mutagen_bundle_search")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Create a bundle and point the bundle search path at it.
+	createTestBundle(t, directory, "fakeos_fakearch")
+	defer setTestEnvironmentVariable(BundleSearchPathEnvironmentVariable, directory)()
+
+	// Configure an agent source that would fail if consulted.
+	defer setTestEnvironmentVariable(SourceEnvironmentVariable, filepath.Join(directory, "missing"))()
+
+	// Verify that manifests can be loaded for the bundled platform, but not
+	// for other platforms.
+	if _, err := loadManifests("fakeos_fakearch"); err != nil {
+		t.Error("unable to load manifests for bundled platform:", err)
+	}
+	if _, err := loadManifests("otheros_otherarch"); err == nil {
+		t.Error("agent source not consulted for unbundled platform")
+	}
+}
@@ -0,0 +1,200 @@
+package agent
+
+import (
+	"net"
+	"runtime"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/mutagen"
+)
+
+const (
+	// CompressionAlgorithmDeflate is the DEFLATE stream compression algorithm.
+	CompressionAlgorithmDeflate = "deflate"
+	// CompressionAlgorithmNone indicates that streams aren't compressed. It's
+	// used as a fallback when no other compression algorithm is supported by
+	// both sides of a connection.
+	CompressionAlgorithmNone = "none"
+
+	// DigestAlgorithmSHA256 is the SHA-256 digest algorithm.
+	DigestAlgorithmSHA256 = "sha256"
+
+	// FeatureMultiplexing indicates support for ModeMultiplexer.
+	FeatureMultiplexing = "multiplexing"
+)
+
+// supportedCompressionAlgorithms are the compression algorithms supported by
+// this build, in order of preference.
+var supportedCompressionAlgorithms = []string{
+	CompressionAlgorithmDeflate,
+	CompressionAlgorithmNone,
+}
+
+// supportedDigestAlgorithms are the digest algorithms supported by this build,
+// in order of preference.
+var supportedDigestAlgorithms = []string{
+	DigestAlgorithmSHA256,
+}
+
+// supportedFeatures are the optional features supported by this build.
+var supportedFeatures = []string{
+	FeatureMultiplexing,
+}
+
+// localCapabilities returns the capabilities of this build.
+func localCapabilities() *Capabilities {
+	return &Capabilities{
+		VersionMajor:          mutagen.VersionMajor,
+		VersionMinor:          mutagen.VersionMinor,
+		VersionPatch:          mutagen.VersionPatch,
+		Os:                    runtime.GOOS,
+		Arch:                  runtime.GOARCH,
+		CompressionAlgorithms: supportedCompressionAlgorithms,
+		DigestAlgorithms:      supportedDigestAlgorithms,
+		Features:              supportedFeatures,
+	}
+}
+
+// Negotiation is the result of negotiating capabilities between a client and
+// an agent.
+type Negotiation struct {
+	// Remote are the capabilities reported by the other side of the
+	// connection.
+	Remote *Capabilities
+	// CompressionAlgorithm is the stream compression algorithm to use.
+	CompressionAlgorithm string
+	// DigestAlgorithm is the digest algorithm to use. It may be empty if no
+	// digest algorithm is supported by both sides.
+	DigestAlgorithm string
+	// Features are the optional features supported by both sides.
+	Features []string
+}
+
+// Supports returns whether or not the specified feature is supported by both
+// sides of the connection.
+func (n *Negotiation) Supports(feature string) bool {
+	for _, f := range n.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// firstCommon returns the first value in preferred that is also present in
+// other, or an empty string if there are no common values.
+func firstCommon(preferred, other []string) string {
+	for _, p := range preferred {
+		for _, o := range other {
+			if p == o {
+				return p
+			}
+		}
+	}
+	return ""
+}
+
+// versionsCompatible returns whether or not the versions in the specified
+// capabilities are compatible. Versions are compatible if their major and minor
+// components match. Patch-level skew is allowed, since patch releases don't
+// change the agent protocol.
+func versionsCompatible(client, server *Capabilities) bool {
+	return client.VersionMajor == server.VersionMajor &&
+		client.VersionMinor == server.VersionMinor
+}
+
+// negotiate computes the negotiated capabilities for a connection. Algorithm
+// selection follows the client's order of preference so that both sides of the
+// connection arrive at the same result. Clients and agents are compatible so
+// long as their versions are compatible (see versionsCompatible). Compression
+// falls back to uncompressed streams if there's no common compression
+// algorithm, while optional features and digest algorithms degrade to the
+// subset supported by both sides.
+func negotiate(client, server *Capabilities) (*Negotiation, error) {
+	// Ensure that versions are compatible.
+	if !versionsCompatible(client, server) {
+		return nil, errors.Errorf(
+			"incompatible versions (%d.%d.%d and %d.%d.%d)",
+			client.VersionMajor, client.VersionMinor, client.VersionPatch,
+			server.VersionMajor, server.VersionMinor, server.VersionPatch,
+		)
+	}
+
+	// Select a compression algorithm, falling back to uncompressed streams
+	// (which every build supports, whether or not it advertises them) if
+	// there's no algorithm in common.
+	compressionAlgorithm := firstCommon(client.CompressionAlgorithms, server.CompressionAlgorithms)
+	if compressionAlgorithm == "" {
+		compressionAlgorithm = CompressionAlgorithmNone
+	}
+
+	// Select a digest algorithm.
+	digestAlgorithm := firstCommon(client.DigestAlgorithms, server.DigestAlgorithms)
+
+	// Compute common features.
+	var features []string
+	for _, f := range client.Features {
+		if firstCommon([]string{f}, server.Features) != "" {
+			features = append(features, f)
+		}
+	}
+
+	// Success.
+	return &Negotiation{
+		CompressionAlgorithm: compressionAlgorithm,
+		DigestAlgorithm:      digestAlgorithm,
+		Features:             features,
+	}, nil
+}
+
+// negotiated is the interface implemented by connections that carry the result
+// of a capability negotiation.
+type negotiated interface {
+	// negotiation returns the associated negotiation result.
+	negotiation() *Negotiation
+}
+
+// negotiatedConnection associates a negotiation result with a connection.
+type negotiatedConnection struct {
+	net.Conn
+	// result is the associated negotiation result.
+	result *Negotiation
+}
+
+// negotiation implements negotiated.negotiation.
+func (c *negotiatedConnection) negotiation() *Negotiation {
+	return c.result
+}
+
+// WithNegotiation associates a negotiation result with a connection so that it
+// can later be retrieved using NegotiationForConnection. If negotiation is nil,
+// then the connection is returned unmodified.
+func WithNegotiation(connection net.Conn, negotiation *Negotiation) net.Conn {
+	if negotiation == nil {
+		return connection
+	}
+	return &negotiatedConnection{connection, negotiation}
+}
+
+// NegotiationForConnection returns the negotiation result associated with a
+// connection, or nil if the connection didn't involve a capability
+// negotiation. Connections returned by Dial and DialMultiplexed (and their
+// server-side counterparts) carry the result of their negotiation.
+func NegotiationForConnection(connection net.Conn) *Negotiation {
+	if n, ok := connection.(negotiated); ok {
+		return n.negotiation()
+	}
+	return nil
+}
+
+// UseCompression returns whether or not stream compression should be used on a
+// connection. Compression is used if it was negotiated for the connection or
+// if the connection didn't involve a capability negotiation (e.g. in-memory
+// connections), in which case both sides are assumed to be the same build.
+func UseCompression(connection net.Conn) bool {
+	if negotiation := NegotiationForConnection(connection); negotiation != nil {
+		return negotiation.CompressionAlgorithm == CompressionAlgorithmDeflate
+	}
+	return true
+}
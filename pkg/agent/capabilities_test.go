@@ -0,0 +1,140 @@
+package agent
+
+import (
+	"net"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	// Create client and server capabilities with different preferences.
+	client := &Capabilities{
+		VersionMajor:          1,
+		VersionMinor:          2,
+		VersionPatch:          3,
+		CompressionAlgorithms: []string{"zstd", CompressionAlgorithmDeflate},
+		DigestAlgorithms:      []string{"blake2b", DigestAlgorithmSHA256},
+		Features:              []string{FeatureMultiplexing, "future"},
+	}
+	server := &Capabilities{
+		VersionMajor:          1,
+		VersionMinor:          2,
+		VersionPatch:          0,
+		CompressionAlgorithms: []string{CompressionAlgorithmDeflate, "zstd"},
+		DigestAlgorithms:      []string{DigestAlgorithmSHA256},
+		Features:              []string{FeatureMultiplexing},
+	}
+
+	// Perform negotiation.
+	negotiation, err := negotiate(client, server)
+	if err != nil {
+		t.Fatal("negotiation failed:", err)
+	}
+
+	// Verify results.
+	if negotiation.CompressionAlgorithm != "zstd" {
+		t.Error("unexpected compression algorithm:", negotiation.CompressionAlgorithm)
+	}
+	if negotiation.DigestAlgorithm != DigestAlgorithmSHA256 {
+		t.Error("unexpected digest algorithm:", negotiation.DigestAlgorithm)
+	}
+	if !negotiation.Supports(FeatureMultiplexing) {
+		t.Error("common feature not supported")
+	}
+	if negotiation.Supports("future") {
+		t.Error("client-only feature supported")
+	}
+}
+
+func TestNegotiateNoCommonDigestAlgorithm(t *testing.T) {
+	client := &Capabilities{
+		CompressionAlgorithms: []string{CompressionAlgorithmDeflate},
+		DigestAlgorithms:      []string{DigestAlgorithmSHA256},
+	}
+	server := &Capabilities{
+		CompressionAlgorithms: []string{CompressionAlgorithmDeflate},
+	}
+	if negotiation, err := negotiate(client, server); err != nil {
+		t.Fatal("negotiation failed:", err)
+	} else if negotiation.DigestAlgorithm != "" {
+		t.Error("digest algorithm negotiated without common support")
+	}
+}
+
+func TestNegotiateIncompatibleVersion(t *testing.T) {
+	client := &Capabilities{
+		VersionMinor:          1,
+		CompressionAlgorithms: []string{CompressionAlgorithmDeflate},
+	}
+	server := &Capabilities{
+		VersionMinor:          2,
+		CompressionAlgorithms: []string{CompressionAlgorithmDeflate},
+	}
+	if _, err := negotiate(client, server); err == nil {
+		t.Error("negotiation succeeded with incompatible versions")
+	}
+}
+
+func TestNegotiateNoCommonCompressionAlgorithm(t *testing.T) {
+	client := &Capabilities{
+		CompressionAlgorithms: []string{CompressionAlgorithmDeflate},
+	}
+	server := &Capabilities{
+		CompressionAlgorithms: []string{"zstd"},
+	}
+	if negotiation, err := negotiate(client, server); err != nil {
+		t.Fatal("negotiation failed without common compression algorithm:", err)
+	} else if negotiation.CompressionAlgorithm != CompressionAlgorithmNone {
+		t.Error("unexpected compression algorithm:", negotiation.CompressionAlgorithm)
+	}
+}
+
+func TestNegotiatePatchSkew(t *testing.T) {
+	client := &Capabilities{
+		VersionMajor:          1,
+		VersionMinor:          2,
+		VersionPatch:          3,
+		CompressionAlgorithms: []string{CompressionAlgorithmDeflate},
+	}
+	server := &Capabilities{
+		VersionMajor:          1,
+		VersionMinor:          2,
+		VersionPatch:          7,
+		CompressionAlgorithms: []string{CompressionAlgorithmDeflate},
+	}
+	if _, err := negotiate(client, server); err != nil {
+		t.Error("negotiation failed with patch-level version skew:", err)
+	}
+}
+
+func TestNegotiationForConnection(t *testing.T) {
+	// Create a connection pair.
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	// Verify that connections without a negotiation report none and default
+	// to using compression.
+	if NegotiationForConnection(client) != nil {
+		t.Error("negotiation reported for connection without negotiation")
+	} else if !UseCompression(client) {
+		t.Error("compression disabled for connection without negotiation")
+	}
+
+	// Verify that associating a nil negotiation leaves the connection as-is.
+	if WithNegotiation(client, nil) != client {
+		t.Error("connection wrapped for nil negotiation")
+	}
+
+	// Verify that negotiation results are associated with connections and
+	// control compression.
+	negotiation := &Negotiation{CompressionAlgorithm: CompressionAlgorithmDeflate}
+	negotiated := WithNegotiation(client, negotiation)
+	if NegotiationForConnection(negotiated) != negotiation {
+		t.Error("negotiation not associated with connection")
+	} else if !UseCompression(negotiated) {
+		t.Error("compression disabled despite being negotiated")
+	}
+	if UseCompression(WithNegotiation(client, &Negotiation{CompressionAlgorithm: CompressionAlgorithmNone})) {
+		t.Error("compression enabled despite not being negotiated")
+	}
+}
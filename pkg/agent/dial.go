@@ -28,28 +28,22 @@ const (
 	agentKillDelay = 5 * time.Second
 )
 
-// connect connects to an agent-based endpoint using the specified transport,
-// connection mode, and prompter. It accepts a hint as to whether or not the
-// remote environment is cmd.exe-based and returns hints as to whether or not
-// installation should be attempted and whether or not the remote environment is
-// cmd.exe-based.
-func connect(logger *logging.Logger, transport Transport, mode, prompter string, cmdExe bool) (net.Conn, bool, bool, error) {
-	// Compute the agent invocation command, relative to the user's home
-	// directory on the remote. Unless we have reason to assume that this is a
-	// cmd.exe environment, we construct a path using forward slashes. This will
-	// work for all POSIX systems and POSIX-like environments on Windows. If we
-	// know we're hitting a cmd.exe environment, then we use backslashes,
-	// otherwise the invocation won't work. Watching for cmd.exe to fail on
-	// commands with forward slashes is actually the way that we detect cmd.exe
-	// environments.
-	//
-	// HACK: We're assuming that none of these path components have spaces in
-	// them, but since we control all of them, this is probably okay.
-	//
-	// HACK: When invoking on Windows systems (whether inside a POSIX
-	// environment or cmd.exe), we can leave the "exe" suffix off the target
-	// name. Fortunately this allows us to also avoid having to try the
-	// combination of forward slashes + ".exe" for Windows POSIX environments.
+// invocationPath computes the path to the agent for the current installation
+// version, relative to the user's home directory on the remote. Unless we have reason to assume that
+// this is a cmd.exe environment, we construct a path using forward slashes.
+// This will work for all POSIX systems and POSIX-like environments on Windows.
+// If we know we're hitting a cmd.exe environment, then we use backslashes,
+// otherwise the invocation won't work. Watching for cmd.exe to fail on commands
+// with forward slashes is actually the way that we detect cmd.exe environments.
+//
+// HACK: We're assuming that none of these path components have spaces in them,
+// but since we control all of them, this is probably okay.
+//
+// HACK: When invoking on Windows systems (whether inside a POSIX environment or
+// cmd.exe), we can leave the "exe" suffix off the target name. Fortunately this
+// allows us to also avoid having to try the combination of forward slashes +
+// ".exe" for Windows POSIX environments.
+func invocationPath(cmdExe bool) string {
 	pathSeparator := "/"
 	if cmdExe {
 		pathSeparator = "\\"
@@ -58,12 +52,22 @@ func connect(logger *logging.Logger, transport Transport, mode, prompter string,
 	if mutagen.DevelopmentVersion {
 		dataDirectoryName = filesystem.MutagenDataDirectoryDevelopmentName
 	}
-	agentInvocationPath := strings.Join([]string{
+	return strings.Join([]string{
 		dataDirectoryName,
 		filesystem.MutagenAgentsDirectoryName,
-		mutagen.Version,
+		InstallationVersion,
 		BaseName,
 	}, pathSeparator)
+}
+
+// connect connects to an agent-based endpoint using the specified transport,
+// connection mode, and prompter. It accepts a hint as to whether or not the
+// remote environment is cmd.exe-based and returns hints as to whether or not
+// installation should be attempted and whether or not the remote environment is
+// cmd.exe-based.
+func connect(logger *logging.Logger, transport Transport, mode, prompter string, cmdExe bool) (net.Conn, bool, bool, error) {
+	// Compute the agent invocation path.
+	agentInvocationPath := invocationPath(cmdExe)
 
 	// Compute the command to invoke.
 	command := fmt.Sprintf("%s %s", agentInvocationPath, mode)
@@ -103,7 +107,7 @@ func connect(logger *logging.Logger, transport Transport, mode, prompter string,
 
 	// Redirect the process' standard error output to a tee'd writer that writes
 	// to both our buffer (via the valveWriter) and the logger.
-	agentProcess.Stderr = io.MultiWriter(errorWriter, logger.Sublogger("remote").Writer())
+	agentProcess.SetStderr(io.MultiWriter(errorWriter, logger.Sublogger("remote").Writer()))
 
 	// Start the process.
 	if err = agentProcess.Start(); err != nil {
@@ -112,7 +116,16 @@ func connect(logger *logging.Logger, transport Transport, mode, prompter string,
 
 	// Perform a handshake with the remote to ensure that we're talking with a
 	// Mutagen agent.
-	if err := clientHandshake(connection); err != nil {
+	negotiation, err := ClientHandshake(connection)
+	if _, ok := err.(*negotiationError); ok {
+		// If the handshake failed due to incompatible capabilities, then the
+		// installed agent is from an incompatible build, so recommend
+		// reinstallation. We return the cmd.exe hint that we were given, since
+		// we know that it worked.
+		connection.Close()
+		logger.Println("Agent is incompatible:", err)
+		return nil, true, cmdExe, err
+	} else if err != nil {
 		// Close the connection to ensure that the underlying process and its
 		// I/O-forwarding Goroutines have terminated. The error returned from
 		// Close will be non-nil if the process exits with a non-0 exit code, so
@@ -138,7 +151,7 @@ func connect(logger *logging.Logger, transport Transport, mode, prompter string,
 		// the transport's reason for classification failure. If we don't have
 		// error output, then just tell the user why the transport failed to
 		// classify the failure.
-		tryInstall, cmdExe, err := transport.ClassifyError(agentProcess.ProcessState, errorOutput)
+		tryInstall, cmdExe, err := transport.ClassifyError(agentProcess.ExitCode(), errorOutput)
 		if err != nil {
 			if errorOutput != "" {
 				return nil, false, false, errors.Errorf(
@@ -154,25 +167,51 @@ func connect(logger *logging.Logger, transport Transport, mode, prompter string,
 		return nil, tryInstall, cmdExe, errors.New("unable to handshake with agent process")
 	}
 
+	// Verify that the agent executable matches the one for its platform in our
+	// agent bundle or agent source. If it doesn't (or the agent can't report a
+	// digest that we understand), then the installed agent has been modified
+	// or corrupted (or was installed by a different build with the same
+	// version), so recommend reinstallation. If neither an agent bundle nor an
+	// agent source is available (e.g. in development builds), then there's
+	// nothing to verify against (or to install from), so we skip verification.
+	var digest ExecutableDigest
+	digestOk := negotiation.DigestAlgorithm == DigestAlgorithmSHA256 &&
+		len(negotiation.Remote.ExecutableDigest) == len(digest)
+	copy(digest[:], negotiation.Remote.ExecutableDigest)
+	platform := fmt.Sprintf("%s_%s", negotiation.Remote.Os, negotiation.Remote.Arch)
+	manifests, err := loadManifests(platform)
+	if _, missing := err.(*missingManifestsError); missing {
+		logger.Debug("No agent manifests available, skipping agent executable verification")
+	} else if err != nil {
+		connection.Close()
+		return nil, false, false, errors.Wrap(err, "unable to load agent manifests")
+	} else if !digestOk || !manifests.Contains(platform, digest) {
+		connection.Close()
+		logger.Printf("Agent executable digest (%s) does not match known agent for %s", digest, platform)
+		return nil, true, cmdExe, errors.New("agent executable does not match known agent")
+	}
+
 	// Now that we've successfully connected, disable the kill delay on the
 	// process connection.
 	connection.SetKillDelay(time.Duration(0))
 
-	// Perform a version handshake.
-	if err := mutagen.ClientVersionHandshake(connection); err != nil {
-		connection.Close()
-		return nil, false, false, errors.Wrap(err, "version handshake error")
-	}
+	// Log the negotiated capabilities.
+	logger.Debugf(
+		"Connected to agent %d.%d.%d (%s/%s) with %s compression and features %v",
+		negotiation.Remote.VersionMajor, negotiation.Remote.VersionMinor, negotiation.Remote.VersionPatch,
+		negotiation.Remote.Os, negotiation.Remote.Arch,
+		negotiation.CompressionAlgorithm, negotiation.Features,
+	)
 
 	// Done.
-	return connection, false, false, nil
+	return WithNegotiation(connection, negotiation), false, false, nil
 }
 
 // Dial connects to an agent-based endpoint using the specified transport,
 // connection mode, and prompter.
 func Dial(logger *logging.Logger, transport Transport, mode, prompter string) (net.Conn, error) {
 	// Validate that the mode is sane.
-	if !(mode == ModeEndpoint || mode == ModeForwarder) {
+	if !(mode == ModeEndpoint || mode == ModeForwarder || mode == ModeMultiplexer) {
 		panic("invalid agent dial mode")
 	}
 
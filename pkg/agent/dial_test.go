@@ -1,3 +1,279 @@
 package agent
 
-// TODO: Implement.
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/process"
+)
+
+// dialTestProcess is an in-process Process implementation that runs a function
+// in place of a remote command.
+type dialTestProcess struct {
+	// run is the function to run. It's provided with the process' standard
+	// input and output streams, and its result determines the exit code.
+	run func(io.Reader, io.Writer) error
+	// stdinReader is the read end of the standard input pipe.
+	stdinReader *io.PipeReader
+	// stdinWriter is the write end of the standard input pipe.
+	stdinWriter *io.PipeWriter
+	// stdoutReader is the read end of the standard output pipe.
+	stdoutReader *io.PipeReader
+	// stdoutWriter is the write end of the standard output pipe.
+	stdoutWriter *io.PipeWriter
+	// done is closed when the process exits.
+	done chan struct{}
+	// exitCode is the exit code of the process. It may only be accessed after
+	// done has been closed.
+	exitCode int
+}
+
+// newDialTestProcess creates a new test process that runs the specified
+// function.
+func newDialTestProcess(run func(io.Reader, io.Writer) error) *dialTestProcess {
+	stdinReader, stdinWriter := io.Pipe()
+	stdoutReader, stdoutWriter := io.Pipe()
+	return &dialTestProcess{
+		run:          run,
+		stdinReader:  stdinReader,
+		stdinWriter:  stdinWriter,
+		stdoutReader: stdoutReader,
+		stdoutWriter: stdoutWriter,
+		done:         make(chan struct{}),
+		exitCode:     -1,
+	}
+}
+
+// StdinPipe implements process.Process.StdinPipe.
+func (p *dialTestProcess) StdinPipe() (io.WriteCloser, error) {
+	return p.stdinWriter, nil
+}
+
+// StdoutPipe implements process.Process.StdoutPipe.
+func (p *dialTestProcess) StdoutPipe() (io.Reader, error) {
+	return p.stdoutReader, nil
+}
+
+// SetStderr implements process.Process.SetStderr.
+func (p *dialTestProcess) SetStderr(_ io.Writer) {}
+
+// Start implements process.Process.Start.
+func (p *dialTestProcess) Start() error {
+	go func() {
+		if err := p.run(p.stdinReader, p.stdoutWriter); err != nil {
+			p.exitCode = 1
+		} else {
+			p.exitCode = 0
+		}
+		p.stdoutWriter.Close()
+		close(p.done)
+	}()
+	return nil
+}
+
+// Wait implements process.Process.Wait.
+func (p *dialTestProcess) Wait() error {
+	<-p.done
+	if p.exitCode != 0 {
+		return errors.Errorf("process exited with code %d", p.exitCode)
+	}
+	return nil
+}
+
+// Kill implements process.Process.Kill.
+func (p *dialTestProcess) Kill() error {
+	p.stdinReader.CloseWithError(errors.New("process killed"))
+	p.stdoutWriter.CloseWithError(errors.New("process killed"))
+	return nil
+}
+
+// ExitCode implements process.Process.ExitCode.
+func (p *dialTestProcess) ExitCode() int {
+	select {
+	case <-p.done:
+		return p.exitCode
+	default:
+		return -1
+	}
+}
+
+// dialTestTransport is a Transport implementation that emulates a POSIX remote
+// with an installed agent for the fakeos/fakearch platform. The agent reports
+// a configurable executable digest, which is replaced with the digest of the
+// fake agent in the test source when an installation is performed.
+type dialTestTransport struct {
+	// lock serializes access to the transport's fields.
+	lock sync.Mutex
+	// digest is the executable digest reported by the installed agent.
+	digest ExecutableDigest
+	// commands records the commands that have been run.
+	commands []string
+	// copies records the number of copy operations performed.
+	copies int
+}
+
+// Copy implements Transport.Copy.
+func (t *dialTestTransport) Copy(localPath, _ string) error {
+	t.lock.Lock()
+	t.copies++
+	t.lock.Unlock()
+	if content, err := ioutil.ReadFile(localPath); err != nil {
+		return errors.Wrap(err, "unable to read agent")
+	} else if string(content) != testAgentContent {
+		return errors.New("agent content does not match expected")
+	}
+	return nil
+}
+
+// Command implements Transport.Command.
+func (t *dialTestTransport) Command(command string) (process.Process, error) {
+	// Record the command.
+	t.lock.Lock()
+	t.commands = append(t.commands, command)
+	t.lock.Unlock()
+
+	// Create a process that emulates the command.
+	switch {
+	case command == "uname -s -m":
+		return newDialTestProcess(func(_ io.Reader, stdout io.Writer) error {
+			_, err := fmt.Fprintln(stdout, "FakeOS fakearch")
+			return err
+		}), nil
+	case strings.HasSuffix(command, " "+ModeInstall):
+		return newDialTestProcess(func(_ io.Reader, _ io.Writer) error {
+			t.lock.Lock()
+			t.digest = sha256.Sum256([]byte(testAgentContent))
+			t.lock.Unlock()
+			return nil
+		}), nil
+	case strings.HasSuffix(command, " "+ModeEndpoint):
+		t.lock.Lock()
+		digest := t.digest
+		t.lock.Unlock()
+		return newDialTestProcess(func(stdin io.Reader, stdout io.Writer) error {
+			return fakeAgentHandshake(stdin, stdout, digest)
+		}), nil
+	default:
+		return newDialTestProcess(func(_ io.Reader, _ io.Writer) error {
+			return errors.New("unknown command")
+		}), nil
+	}
+}
+
+// ClassifyError implements Transport.ClassifyError.
+func (t *dialTestTransport) ClassifyError(_ int, _ string) (bool, bool, error) {
+	return false, false, errors.New("unknown error")
+}
+
+// count returns the number of recorded commands with the specified suffix.
+func (t *dialTestTransport) count(suffix string) int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	var result int
+	for _, command := range t.commands {
+		if strings.HasSuffix(command, suffix) {
+			result++
+		}
+	}
+	return result
+}
+
+// fakeAgentHandshake performs the server side of an agent handshake, reporting
+// the fakeos/fakearch platform and the specified executable digest.
+func fakeAgentHandshake(stdin io.Reader, stdout io.Writer, digest ExecutableDigest) error {
+	if err := sendMagicNumber(stdout, serverMagicNumber); err != nil {
+		return err
+	} else if magicOk, err := receiveAndCompareMagicNumber(stdin, clientMagicNumber); err != nil {
+		return err
+	} else if !magicOk {
+		return errors.New("client magic number incorrect")
+	}
+	capabilities := localCapabilities()
+	capabilities.Os = "fakeos"
+	capabilities.Arch = "fakearch"
+	capabilities.ExecutableDigest = digest[:]
+	if err := sendCapabilities(stdout, capabilities); err != nil {
+		return err
+	}
+	_, err := receiveCapabilities(stdin)
+	return err
+}
+
+// setupDialTest configures an agent source containing the fake agent and
+// registers uname values for the fakeos/fakearch platform. It returns a
+// function that restores the previous configuration.
+func setupDialTest(t *testing.T) func() {
+	// Mark this as a helper function.
+	t.Helper()
+
+	// Create and configure the agent source.
+	source := createTestSource(t, false)
+	restoreSource := setTestEnvironmentVariable(SourceEnvironmentVariable, source)
+
+	// Register uname values for the fake platform.
+	unameSToGOOS["FakeOS"] = "fakeos"
+	unameMToGOARCH["fakearch"] = "fakearch"
+
+	// Done.
+	return func() {
+		delete(unameSToGOOS, "FakeOS")
+		delete(unameMToGOARCH, "fakearch")
+		restoreSource()
+		os.RemoveAll(source)
+	}
+}
+
+func TestDialReinstallsAgentWithDigestMismatch(t *testing.T) {
+	// Set up the test environment.
+	defer setupDialTest(t)()
+
+	// Create a transport whose installed agent reports a digest that doesn't
+	// match the manifest.
+	transport := &dialTestTransport{digest: ExecutableDigest{1, 2, 3}}
+
+	// Dial and verify that the agent was reinstalled and dialed again.
+	connection, err := Dial(logging.RootLogger, transport, ModeEndpoint, "")
+	if err != nil {
+		t.Fatal("unable to dial agent:", err)
+	}
+	connection.Close()
+	if transport.copies != 1 {
+		t.Error("unexpected number of agent copies:", transport.copies)
+	}
+	if count := transport.count(" " + ModeInstall); count != 1 {
+		t.Error("unexpected number of installation commands:", count)
+	}
+	if count := transport.count(" " + ModeEndpoint); count != 2 {
+		t.Error("unexpected number of agent dials:", count)
+	}
+}
+
+func TestDialDoesNotReinstallMatchingAgent(t *testing.T) {
+	// Set up the test environment.
+	defer setupDialTest(t)()
+
+	// Create a transport whose installed agent matches the manifest.
+	transport := &dialTestTransport{digest: sha256.Sum256([]byte(testAgentContent))}
+
+	// Dial and verify that no installation was performed.
+	connection, err := Dial(logging.RootLogger, transport, ModeEndpoint, "")
+	if err != nil {
+		t.Fatal("unable to dial agent:", err)
+	}
+	connection.Close()
+	if transport.copies != 0 {
+		t.Error("agent copied to remote")
+	}
+	if len(transport.commands) != 1 {
+		t.Error("unexpected commands run:", transport.commands)
+	}
+}
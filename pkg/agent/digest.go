@@ -0,0 +1,64 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ExecutableDigest is a SHA-256 digest of an agent executable.
+type ExecutableDigest [sha256.Size]byte
+
+// String returns a hexadecimal representation of the digest.
+func (d ExecutableDigest) String() string {
+	return hex.EncodeToString(d[:])
+}
+
+// DigestFile computes the executable digest for the file at the specified path.
+func DigestFile(path string) (ExecutableDigest, error) {
+	// Open the file and defer its closure.
+	file, err := os.Open(path)
+	if err != nil {
+		return ExecutableDigest{}, errors.Wrap(err, "unable to open file")
+	}
+	defer file.Close()
+
+	// Compute the digest.
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return ExecutableDigest{}, errors.Wrap(err, "unable to read file")
+	}
+	var result ExecutableDigest
+	copy(result[:], hasher.Sum(nil))
+
+	// Success.
+	return result, nil
+}
+
+// currentExecutableDigestOnce guards the computation of
+// currentExecutableDigest and currentExecutableDigestError.
+var currentExecutableDigestOnce sync.Once
+
+// currentExecutableDigest is the digest of the current executable.
+var currentExecutableDigest ExecutableDigest
+
+// currentExecutableDigestError is any error that occurred computing the digest
+// of the current executable.
+var currentExecutableDigestError error
+
+// digestCurrentExecutable returns the digest of the current executable,
+// computing it on first use.
+func digestCurrentExecutable() (ExecutableDigest, error) {
+	currentExecutableDigestOnce.Do(func() {
+		if path, err := os.Executable(); err != nil {
+			currentExecutableDigestError = errors.Wrap(err, "unable to determine executable path")
+		} else {
+			currentExecutableDigest, currentExecutableDigestError = DigestFile(path)
+		}
+	})
+	return currentExecutableDigest, currentExecutableDigestError
+}
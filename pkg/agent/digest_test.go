@@ -0,0 +1,42 @@
+package agent
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestDigestFile(t *testing.T) {
+	// Create a file with known contents and defer its removal.
+	file, err := ioutil.TempFile("", "mutagen_agent_digest")
+	if err != nil {
+		t.Fatal("unable to create temporary file:", err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.WriteString("agent"); err != nil {
+		file.Close()
+		t.Fatal("unable to write temporary file:", err)
+	}
+	file.Close()
+
+	// Compute and verify the digest.
+	digest, err := DigestFile(file.Name())
+	if err != nil {
+		t.Fatal("unable to compute digest:", err)
+	}
+	if expected := "d4f0bc5a29de06b510f9aa428f1eedba926012b591fef7a518e776a7c9bd1824"; digest.String() != expected {
+		t.Error("digest does not match expected:", digest, "!=", expected)
+	}
+}
+
+func TestDigestFileNonExistent(t *testing.T) {
+	if _, err := DigestFile("/this/does/not/exist"); err == nil {
+		t.Error("digest of non-existent file succeeded")
+	}
+}
+
+func TestDigestCurrentExecutable(t *testing.T) {
+	if _, err := digestCurrentExecutable(); err != nil {
+		t.Fatal("unable to compute current executable digest:", err)
+	}
+}
@@ -0,0 +1,116 @@
+package direct
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+)
+
+const (
+	// maximumTokenLength is the maximum allowed authentication token length.
+	maximumTokenLength = 1024
+
+	// authenticationSuccess is the response byte sent by the server to
+	// indicate successful authentication.
+	authenticationSuccess = 0
+	// authenticationFailure is the response byte sent by the server to
+	// indicate failed authentication.
+	authenticationFailure = 1
+)
+
+// LoadToken loads an authentication token from the specified file, performing
+// normalization on the path and trimming any surrounding whitespace.
+func LoadToken(path string) (string, error) {
+	// Normalize the path.
+	path, err := filesystem.Normalize(path)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to normalize path")
+	}
+
+	// Read the token.
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to read file")
+	}
+	token := strings.TrimSpace(string(contents))
+	if token == "" {
+		return "", errors.New("empty token")
+	} else if len(token) > maximumTokenLength {
+		return "", errors.New("token too long")
+	}
+
+	// Success.
+	return token, nil
+}
+
+// clientAuthenticate sends the specified token to the server and waits for the
+// server to accept it.
+func clientAuthenticate(connection net.Conn, token string) error {
+	// Validate the token length.
+	if len(token) > maximumTokenLength {
+		return errors.New("authentication token too long")
+	}
+
+	// Send the token, prefixed by its length.
+	message := make([]byte, 2+len(token))
+	binary.BigEndian.PutUint16(message, uint16(len(token)))
+	copy(message[2:], token)
+	if _, err := connection.Write(message); err != nil {
+		return errors.Wrap(err, "unable to send authentication token")
+	}
+
+	// Receive and validate the response.
+	response := make([]byte, 1)
+	if _, err := io.ReadFull(connection, response); err != nil {
+		return errors.Wrap(err, "unable to receive authentication response")
+	} else if response[0] != authenticationSuccess {
+		return errors.New("authentication rejected")
+	}
+
+	// Success.
+	return nil
+}
+
+// serverAuthenticate receives a token from the client and compares it against
+// the expected token. If the expected token is empty, then any token is
+// accepted, since authentication is being handled by mutual TLS. The client is
+// notified of the result.
+func serverAuthenticate(connection net.Conn, expected string) error {
+	// Receive the token length.
+	var lengthBytes [2]byte
+	if _, err := io.ReadFull(connection, lengthBytes[:]); err != nil {
+		return errors.Wrap(err, "unable to receive authentication token length")
+	}
+	length := binary.BigEndian.Uint16(lengthBytes[:])
+	if length > maximumTokenLength {
+		connection.Write([]byte{authenticationFailure})
+		return errors.New("authentication token too long")
+	}
+
+	// Receive the token.
+	token := make([]byte, length)
+	if _, err := io.ReadFull(connection, token); err != nil {
+		return errors.Wrap(err, "unable to receive authentication token")
+	}
+
+	// Compare the token using a constant-time comparison.
+	if expected != "" && subtle.ConstantTimeCompare(token, []byte(expected)) != 1 {
+		connection.Write([]byte{authenticationFailure})
+		return errors.New("invalid authentication token")
+	}
+
+	// Notify the client of success.
+	if _, err := connection.Write([]byte{authenticationSuccess}); err != nil {
+		return errors.Wrap(err, "unable to send authentication response")
+	}
+
+	// Success.
+	return nil
+}
@@ -0,0 +1,147 @@
+package direct
+
+import (
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadToken(t *testing.T) {
+	// Create a temporary directory for token files and defer its removal.
+	directory, err := ioutil.TempDir("", "mutagen_direct_token")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Define test cases. Test cases with nil contents don't have a token file
+	// written.
+	testCases := []struct {
+		contents      []byte
+		expected      string
+		expectFailure bool
+	}{
+		{[]byte("secret"), "secret", false},
+		{[]byte("  secret\n"), "secret", false},
+		{nil, "", true},
+		{[]byte{}, "", true},
+		{[]byte(" \n"), "", true},
+		{[]byte(strings.Repeat("x", maximumTokenLength)), strings.Repeat("x", maximumTokenLength), false},
+		{[]byte(strings.Repeat("x", maximumTokenLength+1)), "", true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Write the token file, if any.
+		path := filepath.Join(directory, "token")
+		os.Remove(path)
+		if testCase.contents != nil {
+			if err := ioutil.WriteFile(path, testCase.contents, 0600); err != nil {
+				t.Fatal("unable to write token file:", err)
+			}
+		}
+
+		// Load the token and verify the result.
+		token, err := LoadToken(path)
+		if err != nil && !testCase.expectFailure {
+			t.Errorf("test index %d: unable to load token: %v", i, err)
+		} else if err == nil && testCase.expectFailure {
+			t.Errorf("test index %d: invalid token loaded", i)
+		} else if token != testCase.expected {
+			t.Errorf("test index %d: token mismatch: %q != %q", i, token, testCase.expected)
+		}
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		expected      string
+		presented     string
+		expectFailure bool
+	}{
+		{"secret", "secret", false},
+		{"secret", "wrong", true},
+		{"secret", "", true},
+		{"secret", "secretsecret", true},
+		{"", "", false},
+		{"", "anything", false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create a connection pair and perform server authentication in the
+		// background.
+		client, server := net.Pipe()
+		serverErrors := make(chan error, 1)
+		go func() {
+			serverErrors <- serverAuthenticate(server, testCase.expected)
+		}()
+
+		// Perform client authentication and verify the results on both ends.
+		clientErr := clientAuthenticate(client, testCase.presented)
+		serverErr := <-serverErrors
+		if testCase.expectFailure {
+			if clientErr == nil {
+				t.Errorf("test index %d: client authentication incorrectly succeeded", i)
+			}
+			if serverErr == nil {
+				t.Errorf("test index %d: server authentication incorrectly succeeded", i)
+			}
+		} else {
+			if clientErr != nil {
+				t.Errorf("test index %d: client authentication failed: %v", i, clientErr)
+			}
+			if serverErr != nil {
+				t.Errorf("test index %d: server authentication failed: %v", i, serverErr)
+			}
+		}
+
+		// Close the connections.
+		client.Close()
+		server.Close()
+	}
+}
+
+func TestClientAuthenticateTokenTooLong(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	if clientAuthenticate(client, strings.Repeat("x", maximumTokenLength+1)) == nil {
+		t.Error("overly long token sent")
+	}
+}
+
+func TestServerAuthenticateTokenTooLong(t *testing.T) {
+	// Create a connection pair and perform server authentication in the
+	// background.
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	serverErrors := make(chan error, 1)
+	go func() {
+		serverErrors <- serverAuthenticate(server, "secret")
+	}()
+
+	// Send an oversized length and verify that it's rejected without the
+	// token being read.
+	var length [2]byte
+	binary.BigEndian.PutUint16(length[:], maximumTokenLength+1)
+	if _, err := client.Write(length[:]); err != nil {
+		t.Fatal("unable to send token length:", err)
+	}
+	response := make([]byte, 1)
+	if _, err := io.ReadFull(client, response); err != nil {
+		t.Fatal("unable to receive authentication response:", err)
+	} else if response[0] != authenticationFailure {
+		t.Error("oversized token not rejected")
+	}
+	if <-serverErrors == nil {
+		t.Error("server authentication succeeded with oversized token")
+	}
+}
@@ -0,0 +1,92 @@
+package direct
+
+import (
+	"crypto/tls"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	urlpkg "github.com/mutagen-io/mutagen/pkg/url"
+)
+
+const (
+	// dialTimeout is the timeout to use when establishing connections.
+	dialTimeout = 5 * time.Second
+	// handshakeTimeout is the timeout to use for authentication and handshake
+	// operations on new connections.
+	handshakeTimeout = 10 * time.Second
+)
+
+// Dial connects to a long-lived agent listening on the specified host and port
+// and requests the specified mode. Authentication and TLS settings are read
+// from the specified environment, which should be the environment recorded in
+// a TCP URL. Connections always use TLS, since the authentication token would
+// otherwise be sent in plaintext. The agent must be authenticated using either
+// a token file or a client certificate (or both).
+func Dial(host string, port uint16, environment map[string]string, mode string) (net.Conn, error) {
+	// Validate that the mode is sane.
+	if !(mode == agent.ModeEndpoint || mode == agent.ModeForwarder) {
+		panic("invalid direct dial mode")
+	}
+
+	// Extract settings from the environment.
+	tokenFile := environment[urlpkg.TCPTokenFileEnvironmentVariable]
+	certificateAuthority := environment[urlpkg.TCPTLSCAEnvironmentVariable]
+	certificate := environment[urlpkg.TCPTLSCertificateEnvironmentVariable]
+	key := environment[urlpkg.TCPTLSKeyEnvironmentVariable]
+
+	// Ensure that some form of client authentication is configured.
+	if tokenFile == "" && certificate == "" {
+		return nil, errors.New("no authentication token file or TLS client certificate specified")
+	}
+
+	// Load the authentication token, if specified. We load it on each dial so
+	// that it isn't retained in memory (or session state) longer than needed.
+	var token string
+	if tokenFile != "" {
+		var err error
+		if token, err = LoadToken(tokenFile); err != nil {
+			return nil, errors.Wrap(err, "unable to load authentication token")
+		}
+	}
+
+	// Create the TLS configuration.
+	tlsConfiguration, err := newClientTLSConfiguration(host, certificateAuthority, certificate, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create TLS configuration")
+	}
+
+	// Establish the network connection.
+	address := net.JoinHostPort(host, strconv.Itoa(int(port)))
+	connection, err := net.DialTimeout("tcp", address, dialTimeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to connect to agent")
+	}
+
+	// Wrap the connection in TLS.
+	connection = tls.Client(connection, tlsConfiguration)
+
+	// Perform authentication and handshakes with a deadline. Agents serving
+	// direct connections are installed and managed outside of Mutagen, so we
+	// don't verify the agent executable digest reported by the handshake.
+	connection.SetDeadline(time.Now().Add(handshakeTimeout))
+	if err := clientAuthenticate(connection, token); err != nil {
+		connection.Close()
+		return nil, errors.Wrap(err, "unable to authenticate with agent")
+	}
+	negotiation, err := agent.ClientHandshake(connection)
+	if err != nil {
+		connection.Close()
+		return nil, errors.Wrap(err, "agent handshake failed")
+	} else if err := agent.RequestMode(connection, mode); err != nil {
+		connection.Close()
+		return nil, err
+	}
+	connection.SetDeadline(time.Time{})
+
+	// Success.
+	return agent.WithNegotiation(connection, negotiation), nil
+}
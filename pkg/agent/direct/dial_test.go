@@ -0,0 +1,244 @@
+package direct
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	urlpkg "github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// testHandlers are mode handlers that echo data back to the client.
+var testHandlers = map[string]agent.ModeHandler{
+	agent.ModeEndpoint: func(connection net.Conn) error {
+		defer connection.Close()
+		_, err := io.Copy(connection, connection)
+		return err
+	},
+}
+
+// startTestServer starts an agent server on a loopback listener and returns the
+// port on which it's listening, as well as the listener so that it can be
+// closed.
+func startTestServer(t *testing.T, token string, tlsConfiguration *tls.Config) (uint16, net.Listener) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("unable to create listener:", err)
+	}
+	if tlsConfiguration != nil {
+		listener = tls.NewListener(listener, tlsConfiguration)
+	}
+	go Serve(logging.RootLogger, listener, token, testHandlers)
+	return uint16(listener.Addr().(*net.TCPAddr).Port), listener
+}
+
+// verifyEcho verifies that a connection echoes data.
+func verifyEcho(t *testing.T, connection net.Conn) {
+	defer connection.Close()
+	if _, err := connection.Write([]byte("echo")); err != nil {
+		t.Fatal("unable to write to connection:", err)
+	}
+	response := make([]byte, 4)
+	if _, err := io.ReadFull(connection, response); err != nil {
+		t.Fatal("unable to read from connection:", err)
+	} else if string(response) != "echo" {
+		t.Error("echoed data mismatch:", string(response))
+	}
+}
+
+// writePEM writes a PEM block to the specified path.
+func writePEM(t *testing.T, path, blockType string, data []byte) {
+	if err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: data}), 0600); err != nil {
+		t.Fatal("unable to write PEM file:", err)
+	}
+}
+
+// createCertificate creates a certificate signed by the specified parent (or
+// self-signed if parent is nil) and writes it and its key to the specified
+// directory using the specified name. It returns the certificate and key.
+func createCertificate(t *testing.T, directory, name string, ca bool, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	// Generate a key.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("unable to generate key:", err)
+	}
+
+	// Create the certificate template.
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  ca,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	// Create and parse the certificate.
+	certificateBytes, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal("unable to create certificate:", err)
+	}
+	certificate, err := x509.ParseCertificate(certificateBytes)
+	if err != nil {
+		t.Fatal("unable to parse certificate:", err)
+	}
+
+	// Write the certificate and key.
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal("unable to marshal key:", err)
+	}
+	writePEM(t, filepath.Join(directory, name+".pem"), "CERTIFICATE", certificateBytes)
+	writePEM(t, filepath.Join(directory, name+"-key.pem"), "EC PRIVATE KEY", keyBytes)
+
+	// Done.
+	return certificate, key
+}
+
+// createTestCertificates creates a certificate authority and uses it to sign
+// server and client certificates, writing everything to a temporary directory.
+// It returns a function that computes paths within the directory, as well as a
+// function to remove the directory.
+func createTestCertificates(t *testing.T) (func(string) string, func()) {
+	// Create a temporary directory for certificates.
+	directory, err := ioutil.TempDir("", "mutagen_direct")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+
+	// Create a certificate authority and use it to sign server and client
+	// certificates.
+	ca, caKey := createCertificate(t, directory, "ca", true, nil, nil)
+	createCertificate(t, directory, "server", false, ca, caKey)
+	createCertificate(t, directory, "client", false, ca, caKey)
+
+	// Done.
+	return func(name string) string {
+		return filepath.Join(directory, name)
+	}, func() { os.RemoveAll(directory) }
+}
+
+func TestDialToken(t *testing.T) {
+	// Create certificates and defer their removal.
+	path, cleanup := createTestCertificates(t)
+	defer cleanup()
+
+	// Write token files.
+	if err := ioutil.WriteFile(path("token"), []byte("secret\n"), 0600); err != nil {
+		t.Fatal("unable to write token file:", err)
+	} else if err = ioutil.WriteFile(path("wrong"), []byte("wrong"), 0600); err != nil {
+		t.Fatal("unable to write token file:", err)
+	}
+
+	// Start a server that requires a token but not a client certificate.
+	tlsConfiguration, err := NewServerTLSConfiguration(path("server.pem"), path("server-key.pem"), "")
+	if err != nil {
+		t.Fatal("unable to create server TLS configuration:", err)
+	}
+	port, listener := startTestServer(t, "secret", tlsConfiguration)
+	defer listener.Close()
+
+	// Verify that dialing with the correct token succeeds.
+	connection, err := Dial("127.0.0.1", port, map[string]string{
+		urlpkg.TCPTokenFileEnvironmentVariable: path("token"),
+		urlpkg.TCPTLSCAEnvironmentVariable:     path("ca.pem"),
+	}, agent.ModeEndpoint)
+	if err != nil {
+		t.Fatal("unable to dial with correct token:", err)
+	}
+	verifyEcho(t, connection)
+
+	// Verify that dialing with an incorrect token fails.
+	if _, err := Dial("127.0.0.1", port, map[string]string{
+		urlpkg.TCPTokenFileEnvironmentVariable: path("wrong"),
+		urlpkg.TCPTLSCAEnvironmentVariable:     path("ca.pem"),
+	}, agent.ModeEndpoint); err == nil {
+		t.Error("dial with incorrect token succeeded")
+	}
+
+	// Verify that dialing with a missing token file fails.
+	if _, err := Dial("127.0.0.1", port, map[string]string{
+		urlpkg.TCPTokenFileEnvironmentVariable: path("missing"),
+		urlpkg.TCPTLSCAEnvironmentVariable:     path("ca.pem"),
+	}, agent.ModeEndpoint); err == nil {
+		t.Error("dial with missing token file succeeded")
+	}
+
+	// Verify that dialing an unsupported mode fails.
+	if _, err := Dial("127.0.0.1", port, map[string]string{
+		urlpkg.TCPTokenFileEnvironmentVariable: path("token"),
+		urlpkg.TCPTLSCAEnvironmentVariable:     path("ca.pem"),
+	}, agent.ModeForwarder); err == nil {
+		t.Error("dial with unsupported mode succeeded")
+	}
+
+	// Verify that dialing a non-TLS server fails, since the token would
+	// otherwise be sent in plaintext.
+	plainPort, plainListener := startTestServer(t, "secret", nil)
+	defer plainListener.Close()
+	if _, err := Dial("127.0.0.1", plainPort, map[string]string{
+		urlpkg.TCPTokenFileEnvironmentVariable: path("token"),
+		urlpkg.TCPTLSCAEnvironmentVariable:     path("ca.pem"),
+	}, agent.ModeEndpoint); err == nil {
+		t.Error("dial to non-TLS server succeeded")
+	}
+}
+
+func TestDialNoCredentials(t *testing.T) {
+	if _, err := Dial("127.0.0.1", 1, nil, agent.ModeEndpoint); err == nil {
+		t.Error("dial without credentials succeeded")
+	}
+}
+
+func TestDialMutualTLS(t *testing.T) {
+	// Create certificates and defer their removal.
+	path, cleanup := createTestCertificates(t)
+	defer cleanup()
+
+	// Start a server requiring client certificates but no token.
+	tlsConfiguration, err := NewServerTLSConfiguration(path("server.pem"), path("server-key.pem"), path("ca.pem"))
+	if err != nil {
+		t.Fatal("unable to create server TLS configuration:", err)
+	}
+	port, listener := startTestServer(t, "", tlsConfiguration)
+	defer listener.Close()
+
+	// Verify that dialing with a client certificate succeeds.
+	connection, err := Dial("127.0.0.1", port, map[string]string{
+		urlpkg.TCPTLSCAEnvironmentVariable:          path("ca.pem"),
+		urlpkg.TCPTLSCertificateEnvironmentVariable: path("client.pem"),
+		urlpkg.TCPTLSKeyEnvironmentVariable:         path("client-key.pem"),
+	}, agent.ModeEndpoint)
+	if err != nil {
+		t.Fatal("unable to dial with client certificate:", err)
+	}
+	verifyEcho(t, connection)
+
+	// Verify that dialing with a server certificate that doesn't verify fails.
+	if _, err := Dial("127.0.0.1", port, map[string]string{
+		urlpkg.TCPTLSCertificateEnvironmentVariable: path("client.pem"),
+		urlpkg.TCPTLSKeyEnvironmentVariable:         path("client-key.pem"),
+	}, agent.ModeEndpoint); err == nil {
+		t.Error("dial without server verification succeeded")
+	}
+}
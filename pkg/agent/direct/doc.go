@@ -0,0 +1,5 @@
+// Package direct provides facilities for serving and connecting to long-lived
+// agents over direct TCP connections, authenticated using a shared token and/or
+// mutual TLS. Unlike agent transports, direct connections skip agent probing
+// and installation, since the agent is expected to already be running.
+package direct
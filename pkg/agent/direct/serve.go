@@ -0,0 +1,50 @@
+package direct
+
+import (
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+// serveConnection authenticates and performs handshakes on an incoming
+// connection before dispatching it to the handler for its requested mode.
+func serveConnection(logger *logging.Logger, connection net.Conn, token string, handlers map[string]agent.ModeHandler) {
+	// Perform authentication and handshakes with a deadline. The deadline
+	// remains in place until the mode request has been served (at which point
+	// agent.ServeMode clears it), so idle clients can't hold connections open.
+	connection.SetDeadline(time.Now().Add(handshakeTimeout))
+	if err := serverAuthenticate(connection, token); err != nil {
+		logger.Warn(errors.Wrap(err, "authentication failed"))
+		connection.Close()
+		return
+	}
+	negotiation, err := agent.ServerHandshake(connection)
+	if err != nil {
+		logger.Warn(errors.Wrap(err, "agent handshake failed"))
+		connection.Close()
+		return
+	}
+
+	// Serve the requested mode.
+	agent.ServeMode(logger, agent.WithNegotiation(connection, negotiation), handlers)
+}
+
+// Serve accepts and serves agent connections on the specified listener until
+// the listener fails or is closed. The listener should already be wrapped with
+// TLS, since tokens would otherwise be received in plaintext. Clients are
+// required to present the specified token. An empty token is only acceptable if
+// the listener uses TLS with client certificate verification, but enforcing
+// this is the caller's responsibility.
+func Serve(logger *logging.Logger, listener net.Listener, token string, handlers map[string]agent.ModeHandler) error {
+	for {
+		connection, err := listener.Accept()
+		if err != nil {
+			return errors.Wrap(err, "unable to accept connection")
+		}
+		go serveConnection(logger, connection, token, handlers)
+	}
+}
@@ -0,0 +1,67 @@
+package direct
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+// deadlineRecordingConnection wraps a connection and records the most recent
+// deadline set on it.
+type deadlineRecordingConnection struct {
+	net.Conn
+	// lock serializes access to deadline.
+	lock sync.Mutex
+	// deadline is the most recently set deadline.
+	deadline time.Time
+}
+
+// SetDeadline implements net.Conn.SetDeadline.
+func (c *deadlineRecordingConnection) SetDeadline(deadline time.Time) error {
+	c.lock.Lock()
+	c.deadline = deadline
+	c.lock.Unlock()
+	return c.Conn.SetDeadline(deadline)
+}
+
+// currentDeadline returns the most recently set deadline.
+func (c *deadlineRecordingConnection) currentDeadline() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.deadline
+}
+
+func TestServeConnectionDeadlineCoversModeRequest(t *testing.T) {
+	// Create a connection pair and serve the server end.
+	client, server := net.Pipe()
+	defer client.Close()
+	recorder := &deadlineRecordingConnection{Conn: server}
+	go serveConnection(logging.RootLogger, recorder, "secret", testHandlers)
+
+	// Authenticate and perform a handshake.
+	if err := clientAuthenticate(client, "secret"); err != nil {
+		t.Fatal("unable to authenticate:", err)
+	} else if _, err := agent.ClientHandshake(client); err != nil {
+		t.Fatal("unable to perform handshake:", err)
+	}
+
+	// Verify that the deadline is still in place while the server is waiting
+	// for the mode request.
+	if recorder.currentDeadline().IsZero() {
+		t.Error("deadline cleared before mode request")
+	}
+
+	// Request a mode and verify that the deadline is cleared once the
+	// connection is being served.
+	if err := agent.RequestMode(client, agent.ModeEndpoint); err != nil {
+		t.Fatal("unable to request mode:", err)
+	}
+	verifyEcho(t, client)
+	if !recorder.currentDeadline().IsZero() {
+		t.Error("deadline not cleared after mode request")
+	}
+}
@@ -0,0 +1,128 @@
+package direct
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+)
+
+// loadCertificate loads a PEM-encoded certificate and private key from the
+// specified paths, performing normalization on the paths.
+func loadCertificate(certificatePath, keyPath string) (tls.Certificate, error) {
+	// Normalize the certificate path.
+	certificatePath, err := filesystem.Normalize(certificatePath)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "unable to normalize certificate path")
+	}
+
+	// Normalize the key path.
+	keyPath, err = filesystem.Normalize(keyPath)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "unable to normalize key path")
+	}
+
+	// Load the certificate and key.
+	return tls.LoadX509KeyPair(certificatePath, keyPath)
+}
+
+// loadCertificateAuthority loads a PEM-encoded bundle of certificate authority
+// certificates from the specified path, performing normalization on the path.
+func loadCertificateAuthority(path string) (*x509.CertPool, error) {
+	// Normalize the path.
+	path, err := filesystem.Normalize(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to normalize path")
+	}
+
+	// Read the bundle.
+	bundle, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read bundle")
+	}
+
+	// Parse the bundle.
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, errors.New("bundle contains no valid certificates")
+	}
+
+	// Success.
+	return pool, nil
+}
+
+// NewServerTLSConfiguration creates a TLS configuration for an agent listener
+// using the specified certificate and key. If a client certificate authority
+// is specified, then clients will be required to present a certificate signed
+// by that authority.
+func NewServerTLSConfiguration(certificatePath, keyPath, clientCertificateAuthorityPath string) (*tls.Config, error) {
+	// Ensure that a certificate and key have been specified.
+	if certificatePath == "" || keyPath == "" {
+		return nil, errors.New("TLS requires a certificate and key")
+	}
+
+	// Load the certificate and key.
+	certificate, err := loadCertificate(certificatePath, keyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load TLS certificate")
+	}
+
+	// Create the configuration.
+	result := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+	}
+
+	// If a client certificate authority has been specified, then require and
+	// verify client certificates.
+	if clientCertificateAuthorityPath != "" {
+		pool, err := loadCertificateAuthority(clientCertificateAuthorityPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load TLS client certificate authority")
+		}
+		result.ClientCAs = pool
+		result.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	// Success.
+	return result, nil
+}
+
+// newClientTLSConfiguration creates a TLS configuration for connecting to an
+// agent listener on the specified host. If no certificate authority is
+// specified, then the system roots are used to verify the server. If a
+// certificate is specified, then it will be presented to the server for mutual
+// TLS authentication.
+func newClientTLSConfiguration(host, certificateAuthorityPath, certificatePath, keyPath string) (*tls.Config, error) {
+	// Create the configuration.
+	result := &tls.Config{
+		ServerName: host,
+	}
+
+	// If a client certificate has been specified, then load it.
+	if certificatePath != "" {
+		if keyPath == "" {
+			return nil, errors.New("TLS client certificate specified without key")
+		}
+		certificate, err := loadCertificate(certificatePath, keyPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load TLS client certificate")
+		}
+		result.Certificates = []tls.Certificate{certificate}
+	}
+
+	// If a certificate authority has been specified, then use it instead of the
+	// system roots.
+	if certificateAuthorityPath != "" {
+		pool, err := loadCertificateAuthority(certificateAuthorityPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load TLS certificate authority")
+		}
+		result.RootCAs = pool
+	}
+
+	// Success.
+	return result, nil
+}
@@ -0,0 +1,161 @@
+package direct
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"testing"
+)
+
+// handshake performs a TLS handshake between the specified client and server
+// configurations over a loopback connection. It returns the errors from both
+// sides. A loopback connection (rather than an in-memory pipe) is used so that
+// alerts sent after a failed handshake don't block.
+func handshake(t *testing.T, clientConfiguration, serverConfiguration *tls.Config) (error, error) {
+	// Create a listener.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("unable to create listener:", err)
+	}
+	defer listener.Close()
+
+	// Accept a connection and perform the server handshake in the background.
+	serverErrors := make(chan error, 1)
+	go func() {
+		connection, err := listener.Accept()
+		if err != nil {
+			serverErrors <- err
+			return
+		}
+		defer connection.Close()
+		serverErrors <- tls.Server(connection, serverConfiguration).Handshake()
+	}()
+
+	// Dial and perform the client handshake.
+	connection, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal("unable to dial listener:", err)
+	}
+	defer connection.Close()
+	clientErr := tls.Client(connection, clientConfiguration).Handshake()
+	if clientErr != nil {
+		connection.Close()
+	}
+
+	// Done.
+	return clientErr, <-serverErrors
+}
+
+func TestNewServerTLSConfigurationInvalid(t *testing.T) {
+	// Create certificates and defer their removal.
+	path, cleanup := createTestCertificates(t)
+	defer cleanup()
+
+	// Write a certificate authority bundle without certificates.
+	if err := ioutil.WriteFile(path("empty.pem"), []byte("not a certificate"), 0600); err != nil {
+		t.Fatal("unable to write bundle:", err)
+	}
+
+	// Define test cases.
+	testCases := []struct {
+		certificate                string
+		key                        string
+		clientCertificateAuthority string
+	}{
+		{"", path("server-key.pem"), ""},
+		{path("server.pem"), "", ""},
+		{path("missing.pem"), path("server-key.pem"), ""},
+		{path("server.pem"), path("client-key.pem"), ""},
+		{path("server.pem"), path("server-key.pem"), path("missing.pem")},
+		{path("server.pem"), path("server-key.pem"), path("empty.pem")},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if _, err := NewServerTLSConfiguration(
+			testCase.certificate, testCase.key, testCase.clientCertificateAuthority,
+		); err == nil {
+			t.Errorf("test index %d: invalid configuration accepted", i)
+		}
+	}
+}
+
+func TestNewClientTLSConfigurationCertificateWithoutKey(t *testing.T) {
+	if _, err := newClientTLSConfiguration("127.0.0.1", "", "client.pem", ""); err == nil {
+		t.Error("client certificate without key accepted")
+	}
+}
+
+func TestMutualTLSClientCertificateVerification(t *testing.T) {
+	// Create certificates and defer their removal. We also create a
+	// self-signed client certificate that isn't signed by the authority.
+	path, cleanup := createTestCertificates(t)
+	defer cleanup()
+	createCertificate(t, path(""), "rogue", false, nil, nil)
+
+	// Create the server configuration.
+	serverConfiguration, err := NewServerTLSConfiguration(path("server.pem"), path("server-key.pem"), path("ca.pem"))
+	if err != nil {
+		t.Fatal("unable to create server TLS configuration:", err)
+	}
+
+	// Define test cases.
+	testCases := []struct {
+		certificate   string
+		key           string
+		expectFailure bool
+	}{
+		{path("client.pem"), path("client-key.pem"), false},
+		{"", "", true},
+		{path("rogue.pem"), path("rogue-key.pem"), true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create the client configuration.
+		clientConfiguration, err := newClientTLSConfiguration(
+			"127.0.0.1", path("ca.pem"), testCase.certificate, testCase.key,
+		)
+		if err != nil {
+			t.Fatalf("test index %d: unable to create client TLS configuration: %v", i, err)
+		}
+
+		// Perform a handshake and verify the result.
+		clientErr, serverErr := handshake(t, clientConfiguration, serverConfiguration)
+		if testCase.expectFailure && serverErr == nil {
+			t.Errorf("test index %d: client certificate incorrectly accepted", i)
+		} else if !testCase.expectFailure && (clientErr != nil || serverErr != nil) {
+			t.Errorf("test index %d: handshake failed: %v, %v", i, clientErr, serverErr)
+		}
+	}
+}
+
+func TestClientTLSServerVerification(t *testing.T) {
+	// Create certificates and defer their removal. We also create a
+	// self-signed certificate to use as an untrusted authority.
+	path, cleanup := createTestCertificates(t)
+	defer cleanup()
+	createCertificate(t, path(""), "rogue", true, nil, nil)
+
+	// Create the server configuration.
+	serverConfiguration, err := NewServerTLSConfiguration(path("server.pem"), path("server-key.pem"), "")
+	if err != nil {
+		t.Fatal("unable to create server TLS configuration:", err)
+	}
+
+	// Verify that a client trusting the signing authority succeeds.
+	trusting, err := newClientTLSConfiguration("127.0.0.1", path("ca.pem"), "", "")
+	if err != nil {
+		t.Fatal("unable to create client TLS configuration:", err)
+	} else if clientErr, serverErr := handshake(t, trusting, serverConfiguration); clientErr != nil || serverErr != nil {
+		t.Error("handshake failed:", clientErr, serverErr)
+	}
+
+	// Verify that a client trusting a different authority fails.
+	untrusting, err := newClientTLSConfiguration("127.0.0.1", path("rogue.pem"), "", "")
+	if err != nil {
+		t.Fatal("unable to create client TLS configuration:", err)
+	} else if clientErr, _ := handshake(t, untrusting, serverConfiguration); clientErr == nil {
+		t.Error("server certificate incorrectly accepted")
+	}
+}
@@ -1,10 +1,13 @@
 package agent
 
 import (
+	"encoding/binary"
 	"io"
 	"net"
 
 	"github.com/pkg/errors"
+
+	"github.com/golang/protobuf/proto"
 )
 
 // magicNumberBytes is a type capable of holding a Mutagen magic byte sequence.
@@ -47,40 +50,155 @@ func receiveAndCompareMagicNumber(reader io.Reader, expected magicNumberBytes) (
 	return received == expected, nil
 }
 
-// clientHandshake performs a client-side handshake on the connection.
-func clientHandshake(connection net.Conn) error {
+// maximumCapabilitiesSize is the maximum encoded size of a capabilities
+// message that we'll accept.
+const maximumCapabilitiesSize = 64 * 1024
+
+// sendCapabilities sends a length-prefixed capabilities message. We avoid the
+// buffered Protocol Buffers stream decoder here since it may read beyond the
+// end of the handshake.
+func sendCapabilities(writer io.Writer, capabilities *Capabilities) error {
+	// Encode the message.
+	data, err := proto.Marshal(capabilities)
+	if err != nil {
+		return errors.Wrap(err, "unable to encode capabilities")
+	}
+
+	// Write the length prefix and message.
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+	if _, err := writer.Write(append(length[:], data...)); err != nil {
+		return err
+	}
+
+	// Success.
+	return nil
+}
+
+// receiveCapabilities receives a length-prefixed capabilities message.
+func receiveCapabilities(reader io.Reader) (*Capabilities, error) {
+	// Read the length prefix.
+	var length [4]byte
+	if _, err := io.ReadFull(reader, length[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(length[:])
+	if size > maximumCapabilitiesSize {
+		return nil, errors.New("capabilities message too large")
+	}
+
+	// Read and decode the message.
+	data := make([]byte, size)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return nil, err
+	}
+	capabilities := &Capabilities{}
+	if err := proto.Unmarshal(data, capabilities); err != nil {
+		return nil, errors.Wrap(err, "unable to decode capabilities")
+	}
+
+	// Success.
+	return capabilities, nil
+}
+
+// negotiationError indicates that a handshake failed due to incompatible
+// capabilities, rather than a transport or protocol failure.
+type negotiationError struct {
+	// err is the underlying negotiation error.
+	err error
+}
+
+// Error implements error.Error.
+func (e *negotiationError) Error() string {
+	return "capability negotiation failed: " + e.err.Error()
+}
+
+// ClientHandshake performs a client-side handshake on the connection. This
+// includes an exchange of capabilities with the server, the negotiated result
+// of which is returned. Callers should use WithNegotiation to associate the
+// result with the connection. The server's capabilities (including the digest of its
+// executable) are available via the Remote field of the result.
+func ClientHandshake(connection net.Conn) (*Negotiation, error) {
 	// Receive the server's magic number.
 	if magicOk, err := receiveAndCompareMagicNumber(connection, serverMagicNumber); err != nil {
-		return errors.Wrap(err, "unable to receive server magic number")
+		return nil, errors.Wrap(err, "unable to receive server magic number")
 	} else if !magicOk {
-		return errors.New("server magic number incorrect")
+		return nil, errors.New("server magic number incorrect")
 	}
 
 	// Send our magic number to the server.
 	if err := sendMagicNumber(connection, clientMagicNumber); err != nil {
-		return errors.Wrap(err, "unable to send client magic number")
+		return nil, errors.Wrap(err, "unable to send client magic number")
+	}
+
+	// Receive the server's capabilities.
+	server, err := receiveCapabilities(connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to receive server capabilities")
+	}
+
+	// Send our capabilities to the server.
+	client := localCapabilities()
+	if err := sendCapabilities(connection, client); err != nil {
+		return nil, errors.Wrap(err, "unable to send client capabilities")
+	}
+
+	// Negotiate capabilities.
+	negotiation, err := negotiate(client, server)
+	if err != nil {
+		return nil, &negotiationError{err}
 	}
+	negotiation.Remote = server
 
 	// Success.
-	return nil
+	return negotiation, nil
 }
 
-// ServerHandshake performs a server-side handshake on the connection.
-func ServerHandshake(connection net.Conn) error {
+// ServerHandshake performs a server-side handshake on the connection. This
+// includes an exchange of capabilities with the client, the negotiated result
+// of which is returned. Callers should use WithNegotiation to associate the
+// result with the connection.
+func ServerHandshake(connection net.Conn) (*Negotiation, error) {
 	// Send our magic number to the client.
 	if err := sendMagicNumber(connection, serverMagicNumber); err != nil {
-		return errors.Wrap(err, "unable to send server magic number")
+		return nil, errors.Wrap(err, "unable to send server magic number")
 	}
 
 	// Receive the client's magic number. We treat a mismatch of the magic
 	// number as a transport error as well, because it indicates that we're not
 	// actually talking to a Mutagen client.
 	if magicOk, err := receiveAndCompareMagicNumber(connection, clientMagicNumber); err != nil {
-		return errors.Wrap(err, "unable to receive client magic number")
+		return nil, errors.Wrap(err, "unable to receive client magic number")
 	} else if !magicOk {
-		return errors.New("client magic number incorrect")
+		return nil, errors.New("client magic number incorrect")
 	}
 
+	// Send our capabilities to the client, including the digest of our
+	// executable so that the client can verify that we match the agent bundle
+	// that it expects.
+	server := localCapabilities()
+	if digest, err := digestCurrentExecutable(); err != nil {
+		return nil, errors.Wrap(err, "unable to compute agent executable digest")
+	} else {
+		server.ExecutableDigest = digest[:]
+	}
+	if err := sendCapabilities(connection, server); err != nil {
+		return nil, errors.Wrap(err, "unable to send server capabilities")
+	}
+
+	// Receive the client's capabilities.
+	client, err := receiveCapabilities(connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to receive client capabilities")
+	}
+
+	// Negotiate capabilities.
+	negotiation, err := negotiate(client, server)
+	if err != nil {
+		return nil, &negotiationError{err}
+	}
+	negotiation.Remote = client
+
 	// Success.
-	return nil
+	return negotiation, nil
 }
@@ -1,3 +1,80 @@
 package agent
 
-// TODO: Implement.
+import (
+	"net"
+	"testing"
+)
+
+func TestHandshake(t *testing.T) {
+	// Create an in-memory connection.
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	// Perform the server side of the handshake in the background.
+	serverResults := make(chan *Negotiation, 1)
+	serverErrors := make(chan error, 1)
+	go func() {
+		negotiation, err := ServerHandshake(server)
+		serverResults <- negotiation
+		serverErrors <- err
+	}()
+
+	// Perform the client side of the handshake.
+	clientNegotiation, err := ClientHandshake(client)
+	if err != nil {
+		t.Fatal("client handshake failed:", err)
+	}
+	serverNegotiation := <-serverResults
+	if err := <-serverErrors; err != nil {
+		t.Fatal("server handshake failed:", err)
+	}
+
+	// Verify that both sides agree on the negotiated capabilities.
+	if clientNegotiation.CompressionAlgorithm != CompressionAlgorithmDeflate {
+		t.Error("unexpected client compression algorithm:", clientNegotiation.CompressionAlgorithm)
+	}
+	if serverNegotiation.CompressionAlgorithm != clientNegotiation.CompressionAlgorithm {
+		t.Error("compression algorithm mismatch")
+	}
+	if serverNegotiation.DigestAlgorithm != clientNegotiation.DigestAlgorithm {
+		t.Error("digest algorithm mismatch")
+	}
+
+	// Verify that the client received the server's executable digest.
+	expected, err := digestCurrentExecutable()
+	if err != nil {
+		t.Fatal("unable to compute executable digest:", err)
+	}
+	if string(clientNegotiation.Remote.ExecutableDigest) != string(expected[:]) {
+		t.Error("executable digest mismatch")
+	}
+	if len(serverNegotiation.Remote.ExecutableDigest) != 0 {
+		t.Error("client reported executable digest")
+	}
+}
+
+func TestHandshakeIncompatibleVersion(t *testing.T) {
+	// Create an in-memory connection.
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	// Emulate a server from an incompatible version.
+	go func() {
+		sendMagicNumber(server, serverMagicNumber)
+		receiveAndCompareMagicNumber(server, clientMagicNumber)
+		capabilities := localCapabilities()
+		capabilities.VersionMajor++
+		sendCapabilities(server, capabilities)
+		receiveCapabilities(server)
+	}()
+
+	// Perform the client side of the handshake and ensure that it fails with
+	// a negotiation error.
+	if _, err := ClientHandshake(client); err == nil {
+		t.Error("handshake succeeded with incompatible version")
+	} else if _, ok := err.(*negotiationError); !ok {
+		t.Error("handshake failure not classified as negotiation error:", err)
+	}
+}
@@ -0,0 +1,148 @@
+package agent
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/shibukawa/extstat"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/process"
+)
+
+// InstalledAgent describes an agent version installed on a system.
+type InstalledAgent struct {
+	// Version is the agent version.
+	Version string
+	// LastUsed is the last time that the agent executable was accessed.
+	LastUsed time.Time
+}
+
+// InstalledAgents returns a list of agent versions installed on the current
+// system for the current user, sorted by version.
+func InstalledAgents() ([]InstalledAgent, error) {
+	// Compute the path to the agents directory. We don't create it if it
+	// doesn't exist, we just treat that case as having no agents installed.
+	agentsDirectoryPath, err := filesystem.Mutagen(false, filesystem.MutagenAgentsDirectoryName)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to compute agents directory")
+	}
+
+	// List the contents of the agents directory.
+	if _, err := os.Lstat(agentsDirectoryPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "unable to probe agents directory")
+	}
+	contents, err := filesystem.DirectoryContentsByPath(agentsDirectoryPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read agents directory")
+	}
+
+	// Compute the name of the agent binary.
+	agentName := process.ExecutableName(BaseName, runtime.GOOS)
+
+	// Record each version that has an agent executable.
+	var results []InstalledAgent
+	for _, c := range contents {
+		version := c.Name()
+		stat, err := extstat.NewFromFileName(filepath.Join(agentsDirectoryPath, version, agentName))
+		if err != nil {
+			continue
+		}
+		results = append(results, InstalledAgent{
+			Version:  version,
+			LastUsed: stat.AccessTime,
+		})
+	}
+
+	// Sort the results.
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Version < results[j].Version
+	})
+
+	// Success.
+	return results, nil
+}
+
+// UninstallVersion removes the specified agent version from the current system
+// for the current user.
+func UninstallVersion(version string) error {
+	// Validate the version to ensure that it won't escape the agents
+	// directory.
+	if version == "" || version == "." || version == ".." ||
+		strings.ContainsAny(version, "/\\") {
+		return errors.New("invalid agent version")
+	}
+
+	// Compute the path to the version directory.
+	path, err := filesystem.Mutagen(false, filesystem.MutagenAgentsDirectoryName, version)
+	if err != nil {
+		return errors.Wrap(err, "unable to compute agent version directory")
+	}
+
+	// Ensure that the version is installed.
+	if _, err := os.Lstat(path); err != nil {
+		if os.IsNotExist(err) {
+			return errors.New("agent version not installed")
+		}
+		return errors.Wrap(err, "unable to probe agent version directory")
+	}
+
+	// Remove the version.
+	if err := os.RemoveAll(path); err != nil {
+		return errors.Wrap(err, "unable to remove agent version directory")
+	}
+
+	// Success.
+	return nil
+}
+
+// WriteInstalledAgents writes a list of installed agents in the format used to
+// report them from an agent to a client.
+func WriteInstalledAgents(writer io.Writer, agents []InstalledAgent) error {
+	for _, a := range agents {
+		if _, err := fmt.Fprintf(writer, "%s\t%d\n", a.Version, a.LastUsed.Unix()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseInstalledAgents parses a list of installed agents in the format written
+// by WriteInstalledAgents.
+func parseInstalledAgents(data string) ([]InstalledAgent, error) {
+	var results []InstalledAgent
+	for _, line := range strings.Split(data, "\n") {
+		// Skip empty lines and trim any carriage returns.
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// Parse the line.
+		fields := strings.Split(line, "\t")
+		if len(fields) != 2 {
+			return nil, errors.New("invalid line format")
+		}
+		lastUsed, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid last use time")
+		}
+		results = append(results, InstalledAgent{
+			Version:  fields[0],
+			LastUsed: time.Unix(lastUsed, 0),
+		})
+	}
+	return results, nil
+}
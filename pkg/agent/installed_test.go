@@ -0,0 +1,114 @@
+package agent
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/process"
+)
+
+func TestInstalledAgentsFormatRoundTrip(t *testing.T) {
+	// Create a list of agents.
+	agents := []InstalledAgent{
+		{Version: "0.10.0", LastUsed: time.Unix(1000, 0)},
+		{Version: "0.11.0-beta1", LastUsed: time.Unix(2000, 0)},
+	}
+
+	// Write and re-parse the list.
+	buffer := &bytes.Buffer{}
+	if err := WriteInstalledAgents(buffer, agents); err != nil {
+		t.Fatal("unable to write installed agents:", err)
+	}
+	parsed, err := parseInstalledAgents(buffer.String())
+	if err != nil {
+		t.Fatal("unable to parse installed agents:", err)
+	}
+
+	// Verify the result.
+	if len(parsed) != len(agents) {
+		t.Fatal("parsed agent count mismatch")
+	}
+	for i, a := range parsed {
+		if a.Version != agents[i].Version || !a.LastUsed.Equal(agents[i].LastUsed) {
+			t.Error("parsed agent mismatch:", a, "!=", agents[i])
+		}
+	}
+}
+
+func TestParseInstalledAgentsInvalid(t *testing.T) {
+	if _, err := parseInstalledAgents("0.10.0\n"); err == nil {
+		t.Error("invalid line parsed successfully")
+	}
+	if _, err := parseInstalledAgents("0.10.0\tyesterday\n"); err == nil {
+		t.Error("invalid time parsed successfully")
+	}
+}
+
+func TestInstalledAgentsAndUninstall(t *testing.T) {
+	// Create a temporary data directory and point Mutagen at it.
+	directory, err := ioutil.TempDir("", "mutagen_agents")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+	previous, previousSet := os.LookupEnv("MUTAGEN_DATA_DIRECTORY")
+	os.Setenv("MUTAGEN_DATA_DIRECTORY", directory)
+	defer func() {
+		if previousSet {
+			os.Setenv("MUTAGEN_DATA_DIRECTORY", previous)
+		} else {
+			os.Unsetenv("MUTAGEN_DATA_DIRECTORY")
+		}
+	}()
+
+	// Verify that there are no agents installed.
+	if agents, err := InstalledAgents(); err != nil {
+		t.Fatal("unable to list installed agents:", err)
+	} else if len(agents) != 0 {
+		t.Fatal("agents found in empty data directory")
+	}
+
+	// Create fake agent installations.
+	agentName := process.ExecutableName(BaseName, runtime.GOOS)
+	for _, version := range []string{"0.2.0", "0.1.0"} {
+		parent := filepath.Join(directory, filesystem.MutagenAgentsDirectoryName, version)
+		if err := os.MkdirAll(parent, 0700); err != nil {
+			t.Fatal("unable to create agent directory:", err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(parent, agentName), nil, 0700); err != nil {
+			t.Fatal("unable to create agent:", err)
+		}
+	}
+
+	// Verify that they're listed in order.
+	agents, err := InstalledAgents()
+	if err != nil {
+		t.Fatal("unable to list installed agents:", err)
+	} else if len(agents) != 2 || agents[0].Version != "0.1.0" || agents[1].Version != "0.2.0" {
+		t.Fatal("unexpected installed agents:", agents)
+	}
+
+	// Verify that invalid versions are rejected.
+	if UninstallVersion("..") == nil {
+		t.Error("uninstall succeeded for invalid version")
+	}
+	if UninstallVersion("0.3.0") == nil {
+		t.Error("uninstall succeeded for version that isn't installed")
+	}
+
+	// Remove a version and verify that it's gone.
+	if err := UninstallVersion("0.1.0"); err != nil {
+		t.Fatal("unable to uninstall version:", err)
+	}
+	if agents, err := InstalledAgents(); err != nil {
+		t.Fatal("unable to list installed agents:", err)
+	} else if len(agents) != 1 || agents[0].Version != "0.2.0" {
+		t.Error("unexpected installed agents after uninstall:", agents)
+	}
+}
@@ -0,0 +1,233 @@
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/process"
+)
+
+// agentNotInstalledError indicates that the current agent version isn't
+// installed on a remote and that installation wasn't permitted.
+type agentNotInstalledError struct{}
+
+// Error implements error.Error.
+func (agentNotInstalledError) Error() string {
+	return "current agent version is not installed on remote"
+}
+
+// invocationFailure describes an unsuccessful agent invocation.
+type invocationFailure struct {
+	// exitCode is the exit code of the invocation process.
+	exitCode int
+	// errorOutput is the error output of the invocation process.
+	errorOutput string
+	// err is the underlying error.
+	err error
+}
+
+// invokeOnce performs a single agent invocation using the specified path
+// conventions. If the invocation process starts but fails, then a non-nil
+// failure describing its exit code and error output is returned.
+func invokeOnce(transport Transport, arguments string, cmdExe bool) (string, *invocationFailure, error) {
+	// Create the agentProcess.
+	command := fmt.Sprintf("%s %s", invocationPath(cmdExe), arguments)
+	agentProcess, err := transport.Command(command)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "unable to create agent command")
+	}
+
+	// Redirect the process' standard output and error output.
+	standardOutput, err := agentProcess.StdoutPipe()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "unable to redirect agent output")
+	}
+	errorBuffer := bytes.NewBuffer(nil)
+	agentProcess.SetStderr(errorBuffer)
+
+	// Start the agentProcess.
+	if err := agentProcess.Start(); err != nil {
+		return "", nil, errors.Wrap(err, "unable to start agent command")
+	}
+
+	// Read the process' output. We have to do this before waiting, since the
+	// wait operation may close the output pipe.
+	result, readErr := ioutil.ReadAll(standardOutput)
+
+	// Wait for the process to complete and handle failure.
+	if err := agentProcess.Wait(); err != nil {
+		return "", &invocationFailure{
+			exitCode:    agentProcess.ExitCode(),
+			errorOutput: errorBuffer.String(),
+			err:         err,
+		}, nil
+	} else if readErr != nil {
+		return "", nil, errors.Wrap(readErr, "unable to read agent output")
+	}
+
+	// Ensure that the output is UTF-8 encoded.
+	if !utf8.Valid(result) {
+		return "", nil, errors.New("agent output is not UTF-8 encoded")
+	}
+
+	// Success.
+	return string(result), nil, nil
+}
+
+// classify determines whether or not an invocation failure indicates that the
+// agent isn't installed and whether or not the remote is a Windows cmd.exe
+// environment. Classification is delegated to the transport, but command not
+// found exit codes are always treated as indicating a missing agent.
+func (f *invocationFailure) classify(transport Transport) (bool, bool, error) {
+	tryInstall, cmdExe, err := transport.ClassifyError(f.exitCode, f.errorOutput)
+	if err != nil {
+		if process.IsPOSIXShellCommandNotFoundExitCode(f.exitCode) {
+			return true, false, nil
+		} else if process.IsWindowsCommandNotFoundExitCode(f.exitCode) {
+			return true, true, nil
+		}
+		return false, false, err
+	}
+	return tryInstall, cmdExe, nil
+}
+
+// error converts an invocation failure to an error, including the agent's
+// error output if available.
+func (f *invocationFailure) error() error {
+	if errorOutput := strings.TrimSpace(f.errorOutput); errorOutput != "" {
+		return errors.Errorf("agent invocation failed with error output:\n%s", errorOutput)
+	}
+	return errors.Wrap(f.err, "agent invocation failed")
+}
+
+// invoke runs the current agent version on a remote with the specified
+// arguments and returns its output. If the invocation fails because the agent
+// isn't installed, then the agent is installed and invocation is re-attempted
+// if installation is allowed, otherwise agentNotInstalledError is returned.
+// Other invocation failures are returned with the agent's error output.
+func invoke(logger *logging.Logger, transport Transport, prompter, arguments string, allowInstall bool) (string, error) {
+	// Attempt invocation using POSIX path conventions and, if the transport
+	// detects a Windows cmd.exe environment, using cmd.exe path conventions.
+	cmdExe := false
+	installed := false
+	for {
+		// Perform the invocation.
+		result, failure, err := invokeOnce(transport, arguments, cmdExe)
+		if err != nil {
+			return "", err
+		} else if failure == nil {
+			return result, nil
+		}
+
+		// Classify the failure. If classification fails, then the failure
+		// wasn't caused by a missing agent, so report it directly.
+		tryInstall, failureCmdExe, err := failure.classify(transport)
+		if err != nil {
+			return "", failure.error()
+		}
+
+		// If we've detected a cmd.exe environment for the first time, then
+		// re-attempt invocation using cmd.exe path conventions.
+		if failureCmdExe && !cmdExe {
+			cmdExe = true
+			continue
+		}
+
+		// If the failure doesn't indicate a missing agent, or if we've already
+		// performed installation, then report the failure.
+		if !tryInstall || installed {
+			return "", failure.error()
+		}
+
+		// Install the agent if allowed.
+		if !allowInstall {
+			return "", agentNotInstalledError{}
+		}
+		logger.Println("Agent not installed, installing")
+		if err := install(logger, transport, prompter); err != nil {
+			return "", errors.Wrap(err, "unable to install agent")
+		}
+		installed = true
+	}
+}
+
+// InstallRemote installs (or re-installs) the current agent version on a
+// remote.
+func InstallRemote(logger *logging.Logger, transport Transport, prompter string) error {
+	return install(logger, transport, prompter)
+}
+
+// ListRemote lists the agent versions installed on a remote. The current agent
+// version is used to perform the listing, but it won't be installed if it's
+// missing (in which case an error is returned).
+func ListRemote(logger *logging.Logger, transport Transport, prompter string) ([]InstalledAgent, error) {
+	// Invoke the agent.
+	result, err := invoke(logger, transport, prompter, ModeList, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the output.
+	agents, err := parseInstalledAgents(result)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse agent list")
+	}
+
+	// Success.
+	return agents, nil
+}
+
+// UninstallRemote removes the specified agent versions from a remote. The
+// current agent version is used to perform removal, so it will be installed if
+// necessary, though it may also be specified for removal (except on Windows,
+// where an executable can't remove itself).
+func UninstallRemote(logger *logging.Logger, transport Transport, prompter string, versions []string) error {
+	// Validate versions. We require that they not contain whitespace since we
+	// pass them as command line arguments.
+	if len(versions) == 0 {
+		return errors.New("no versions specified")
+	}
+	for _, v := range versions {
+		if v == "" || strings.ContainsAny(v, " \t\r\n\"'") {
+			return errors.Errorf("invalid agent version: %q", v)
+		}
+	}
+
+	// Invoke the agent.
+	arguments := ModeUninstall + " " + strings.Join(versions, " ")
+	if _, err := invoke(logger, transport, prompter, arguments, true); err != nil {
+		return err
+	}
+
+	// Success.
+	return nil
+}
+
+// PruneRemote removes all agent versions other than the current version from a
+// remote. It returns the list of removed versions. The current agent version is
+// used to perform pruning, but it won't be installed if it's missing (in which
+// case an error is returned).
+func PruneRemote(logger *logging.Logger, transport Transport, prompter string) ([]string, error) {
+	// Invoke the agent.
+	result, err := invoke(logger, transport, prompter, ModePrune, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the removed versions.
+	var removed []string
+	for _, line := range strings.Split(result, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			removed = append(removed, line)
+		}
+	}
+
+	// Success.
+	return removed, nil
+}
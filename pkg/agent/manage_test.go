@@ -0,0 +1,180 @@
+package agent
+
+import (
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/process"
+)
+
+// manageTestTransport is a Transport implementation that runs local shell
+// scripts in place of remote commands and records the commands that it's asked
+// to run.
+type manageTestTransport struct {
+	// script returns the shell script to run for a command.
+	script func(command string) string
+	// classify implements ClassifyError.
+	classify func(exitCode int, errorOutput string) (bool, bool, error)
+	// commands records the commands that have been run.
+	commands []string
+	// copies records the number of copy operations performed.
+	copies int
+}
+
+// newManageTestTransport creates a new test transport, skipping the test on
+// systems where shell scripts can't be run.
+func newManageTestTransport(
+	t *testing.T,
+	script func(string) string,
+	classify func(int, string) (bool, bool, error),
+) *manageTestTransport {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+	return &manageTestTransport{script: script, classify: classify}
+}
+
+// Copy implements Transport.Copy.
+func (t *manageTestTransport) Copy(_, _ string) error {
+	t.copies++
+	return errors.New("copy not supported")
+}
+
+// Command implements Transport.Command.
+func (t *manageTestTransport) Command(command string) (process.Process, error) {
+	t.commands = append(t.commands, command)
+	return process.NewLocalProcess(exec.Command("/bin/sh", "-c", t.script(command))), nil
+}
+
+// ClassifyError implements Transport.ClassifyError.
+func (t *manageTestTransport) ClassifyError(exitCode int, errorOutput string) (bool, bool, error) {
+	return t.classify(exitCode, errorOutput)
+}
+
+// unclassifiable is a ClassifyError implementation that can't classify any
+// errors.
+func unclassifiable(_ int, _ string) (bool, bool, error) {
+	return false, false, errors.New("unknown error")
+}
+
+func TestListRemote(t *testing.T) {
+	// Create a transport that emulates an installed agent.
+	transport := newManageTestTransport(t, func(_ string) string {
+		return `printf '0.1.0\t100\n0.2.0\t200\n'`
+	}, unclassifiable)
+
+	// List agents and verify the result.
+	agents, err := ListRemote(logging.RootLogger, transport, "")
+	if err != nil {
+		t.Fatal("unable to list agents:", err)
+	}
+	if len(agents) != 2 {
+		t.Fatal("unexpected number of agents:", len(agents))
+	}
+	if agents[0].Version != "0.1.0" || agents[0].LastUsed.Unix() != 100 {
+		t.Error("first agent does not match expected:", agents[0])
+	}
+	if agents[1].Version != "0.2.0" || agents[1].LastUsed.Unix() != 200 {
+		t.Error("second agent does not match expected:", agents[1])
+	}
+}
+
+func TestListAndPruneRemoteDoNotInstall(t *testing.T) {
+	// Create transports that emulate a missing agent, one with classification
+	// support and one without. Command not found exit codes should be treated
+	// as indicating a missing agent in either case.
+	classifiers := []func(int, string) (bool, bool, error){
+		func(_ int, _ string) (bool, bool, error) { return true, false, nil },
+		unclassifiable,
+	}
+	for _, classify := range classifiers {
+		// Create the transport.
+		transport := newManageTestTransport(t, func(_ string) string {
+			return "echo 'agent: not found' >&2; exit 127"
+		}, classify)
+
+		// Verify that listing and pruning fail without installing.
+		if _, err := ListRemote(logging.RootLogger, transport, ""); err == nil {
+			t.Error("listing succeeded with missing agent")
+		} else if _, ok := err.(agentNotInstalledError); !ok {
+			t.Error("unexpected listing error:", err)
+		}
+		if _, err := PruneRemote(logging.RootLogger, transport, ""); err == nil {
+			t.Error("pruning succeeded with missing agent")
+		} else if _, ok := err.(agentNotInstalledError); !ok {
+			t.Error("unexpected pruning error:", err)
+		}
+		if len(transport.commands) != 2 {
+			t.Error("unexpected commands run:", transport.commands)
+		}
+		if transport.copies != 0 {
+			t.Error("agent copied to remote")
+		}
+	}
+}
+
+func TestUninstallRemoteReportsErrorOutput(t *testing.T) {
+	// Create a transport that emulates an agent failure unrelated to
+	// installation.
+	transport := newManageTestTransport(t, func(_ string) string {
+		return "echo 'unable to remove agent: permission denied' >&2; exit 1"
+	}, unclassifiable)
+
+	// Verify that the failure is reported with its error output and that no
+	// installation is attempted.
+	if err := UninstallRemote(logging.RootLogger, transport, "", []string{"0.1.0"}); err == nil {
+		t.Fatal("uninstallation succeeded with failing agent")
+	} else if !strings.Contains(err.Error(), "permission denied") {
+		t.Error("error does not include agent error output:", err)
+	}
+	if len(transport.commands) != 1 {
+		t.Error("unexpected commands run:", transport.commands)
+	}
+}
+
+func TestUninstallRemoteInstallsMissingAgent(t *testing.T) {
+	// Create a transport that emulates a missing agent and a remote that can't
+	// be probed.
+	transport := newManageTestTransport(t, func(_ string) string {
+		return "exit 127"
+	}, func(_ int, _ string) (bool, bool, error) { return true, false, nil })
+
+	// Verify that installation is attempted.
+	if err := UninstallRemote(logging.RootLogger, transport, "", []string{"0.1.0"}); err == nil {
+		t.Fatal("uninstallation succeeded with missing agent")
+	} else if !strings.Contains(err.Error(), "unable to install agent") {
+		t.Error("unexpected uninstallation error:", err)
+	}
+	if len(transport.commands) < 2 || transport.commands[1] == transport.commands[0] {
+		t.Error("installation not attempted:", transport.commands)
+	}
+}
+
+func TestPruneRemoteCmdExe(t *testing.T) {
+	// Create a transport that emulates a cmd.exe environment, where only
+	// invocations using backslashes succeed.
+	transport := newManageTestTransport(t, func(command string) string {
+		if strings.Contains(command, "\\") {
+			return `printf '0.1.0\r\n0.2.0\r\n'`
+		}
+		return "echo 'not recognized' >&2; exit 1"
+	}, func(_ int, _ string) (bool, bool, error) { return false, true, nil })
+
+	// Prune agents and verify the result.
+	removed, err := PruneRemote(logging.RootLogger, transport, "")
+	if err != nil {
+		t.Fatal("unable to prune agents:", err)
+	}
+	if len(removed) != 2 || removed[0] != "0.1.0" || removed[1] != "0.2.0" {
+		t.Error("removed versions do not match expected:", removed)
+	}
+	if len(transport.commands) != 2 {
+		t.Error("unexpected commands run:", transport.commands)
+	}
+}
@@ -0,0 +1,100 @@
+package agent
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// BundleManifestName is the name of the manifest entry in the agent bundle.
+	// It is stored as the first entry in the bundle so that it can be read
+	// without decompressing the agent executables.
+	BundleManifestName = "manifest.sha256"
+)
+
+// BundleManifest maps agent bundle entry names (of the form GOOS_GOARCH) to the
+// digests of their corresponding agent executables. Its serialized format is
+// compatible with the output of sha256sum.
+type BundleManifest map[string]ExecutableDigest
+
+// ReadBundleManifest parses a bundle manifest.
+func ReadBundleManifest(reader io.Reader) (BundleManifest, error) {
+	// Create the result.
+	result := make(BundleManifest)
+
+	// Parse each line.
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		// Skip empty lines.
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		// Split the line into its digest and name. The name may be prefixed
+		// with '*' to indicate binary mode, which we ignore.
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, errors.Errorf("invalid manifest line: %s", line)
+		}
+		name := strings.TrimPrefix(fields[1], "*")
+
+		// Decode the digest.
+		digestBytes, err := hex.DecodeString(fields[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid digest for %s", name)
+		}
+		var digest ExecutableDigest
+		if len(digestBytes) != len(digest) {
+			return nil, errors.Errorf("invalid digest length for %s", name)
+		}
+		copy(digest[:], digestBytes)
+
+		// Record the entry.
+		if _, ok := result[name]; ok {
+			return nil, errors.Errorf("duplicate manifest entry for %s", name)
+		}
+		result[name] = digest
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "unable to read manifest")
+	}
+
+	// Success.
+	return result, nil
+}
+
+// Write serializes the manifest. Entries are written in sorted order.
+func (m BundleManifest) Write(writer io.Writer) error {
+	// Sort names to ensure deterministic output.
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Write entries.
+	for _, name := range names {
+		if _, err := fmt.Fprintf(writer, "%s  %s\n", m[name], name); err != nil {
+			return err
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// Contains returns whether or not the specified digest matches the agent
+// executable for the specified platform (of the form GOOS_GOARCH) in the
+// manifest. Digests for other platforms aren't considered, since an agent
+// executable for another platform can't be valid for the target.
+func (m BundleManifest) Contains(platform string, digest ExecutableDigest) bool {
+	d, ok := m[platform]
+	return ok && d == digest
+}
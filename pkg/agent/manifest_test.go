@@ -0,0 +1,72 @@
+package agent
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// testManifest is a manifest used for testing.
+const testManifest = `d4f0bc5a29de06b510f9aa428f1eedba926012b591fef7a518e776a7c9bd1824  linux_amd64
+e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855 *windows_amd64
+`
+
+func TestBundleManifestRoundTrip(t *testing.T) {
+	// Parse the manifest.
+	manifest, err := ReadBundleManifest(strings.NewReader(testManifest))
+	if err != nil {
+		t.Fatal("unable to parse manifest:", err)
+	} else if len(manifest) != 2 {
+		t.Fatal("manifest has incorrect number of entries:", len(manifest))
+	}
+
+	// Verify that digests are recognized.
+	linux := manifest["linux_amd64"]
+	if !manifest.Contains("linux_amd64", linux) {
+		t.Error("manifest does not contain its own digest")
+	}
+	if manifest.Contains("linux_amd64", ExecutableDigest{}) {
+		t.Error("manifest contains unknown digest")
+	}
+	if manifest.Contains("windows_amd64", linux) {
+		t.Error("manifest contains digest for another platform")
+	}
+	if manifest.Contains("darwin_amd64", linux) {
+		t.Error("manifest contains digest for missing platform")
+	}
+
+	// Serialize the manifest and re-parse it.
+	buffer := &bytes.Buffer{}
+	if err := manifest.Write(buffer); err != nil {
+		t.Fatal("unable to write manifest:", err)
+	}
+	reparsed, err := ReadBundleManifest(buffer)
+	if err != nil {
+		t.Fatal("unable to re-parse manifest:", err)
+	} else if len(reparsed) != len(manifest) {
+		t.Fatal("re-parsed manifest has incorrect number of entries")
+	}
+	for name, digest := range manifest {
+		if reparsed[name] != digest {
+			t.Error("re-parsed manifest digest mismatch for", name)
+		}
+	}
+}
+
+func TestBundleManifestInvalid(t *testing.T) {
+	// Define test cases.
+	testCases := []string{
+		"nodigest",
+		"zz  linux_amd64",
+		"abcd  linux_amd64",
+		"d4f0bc5a29de06b510f9aa428f1eedba926012b591fef7a518e776a7c9bd1824  linux_amd64\n" +
+			"d4f0bc5a29de06b510f9aa428f1eedba926012b591fef7a518e776a7c9bd1824  linux_amd64",
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if _, err := ReadBundleManifest(strings.NewReader(testCase)); err == nil {
+			t.Errorf("invalid manifest parsed successfully: %q", testCase)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package agent
+
+import (
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+const (
+	// modeRequestMaximumLength is the maximum allowed length for a mode
+	// request, including its terminating newline.
+	modeRequestMaximumLength = 64
+
+	// modeResponseSuccess is the response byte sent by the agent to indicate
+	// that a mode request was accepted.
+	modeResponseSuccess = 0
+	// modeResponseFailure is the response byte sent by the agent to indicate
+	// that a mode request was rejected.
+	modeResponseFailure = 1
+)
+
+// ModeHandler is the type for functions that serve a particular mode on a
+// connection whose mode is selected via a mode request (e.g. a multiplexed
+// stream). Handlers take ownership of the connection and should close it when
+// finished.
+type ModeHandler func(net.Conn) error
+
+// RequestMode sends a mode request on the specified connection and waits for
+// the agent to accept it.
+func RequestMode(connection net.Conn, mode string) error {
+	// Send the request.
+	if _, err := connection.Write([]byte(mode + "\n")); err != nil {
+		return errors.Wrap(err, "unable to send mode request")
+	}
+
+	// Receive and validate the response.
+	response := make([]byte, 1)
+	if _, err := io.ReadFull(connection, response); err != nil {
+		return errors.Wrap(err, "unable to receive mode response")
+	} else if response[0] != modeResponseSuccess {
+		return errors.Errorf("agent rejected %s mode request", mode)
+	}
+
+	// Success.
+	return nil
+}
+
+// readModeRequest reads a newline-terminated mode request from a connection.
+// It reads byte-by-byte to avoid consuming any data that follows the request.
+func readModeRequest(connection net.Conn) (string, error) {
+	var builder strings.Builder
+	buffer := make([]byte, 1)
+	for builder.Len() < modeRequestMaximumLength {
+		if _, err := connection.Read(buffer); err != nil {
+			return "", errors.Wrap(err, "unable to read mode request")
+		} else if buffer[0] == '\n' {
+			return builder.String(), nil
+		}
+		builder.WriteByte(buffer[0])
+	}
+	return "", errors.New("mode request too long")
+}
+
+// ServeMode reads a mode request from the specified connection and dispatches
+// the connection to the handler for the requested mode. Any deadline set on the
+// connection (e.g. to bound the time allowed for the request) is cleared before
+// the connection is dispatched. Errors are logged rather than returned since
+// this function is generally invoked in a separate Goroutine for each
+// connection.
+func ServeMode(logger *logging.Logger, connection net.Conn, handlers map[string]ModeHandler) {
+	// Read the requested mode.
+	mode, err := readModeRequest(connection)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "unable to read mode request"))
+		connection.Close()
+		return
+	}
+
+	// Look up the handler and send a response.
+	handler, ok := handlers[mode]
+	if !ok {
+		logger.Error(errors.Errorf("unsupported mode requested: %s", mode))
+		connection.Write([]byte{modeResponseFailure})
+		connection.Close()
+		return
+	} else if _, err := connection.Write([]byte{modeResponseSuccess}); err != nil {
+		logger.Error(errors.Wrap(err, "unable to send mode response"))
+		connection.Close()
+		return
+	} else if err := connection.SetDeadline(time.Time{}); err != nil {
+		logger.Error(errors.Wrap(err, "unable to clear connection deadline"))
+		connection.Close()
+		return
+	}
+
+	// Serve the connection.
+	if err := handler(connection); err != nil {
+		logger.Debug(errors.Wrapf(err, "%s mode connection terminated", mode))
+	}
+}
@@ -0,0 +1,3 @@
+package agent
+
+// TODO: Implement.
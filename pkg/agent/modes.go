@@ -7,6 +7,19 @@ const (
 	ModeEndpoint = "endpoint"
 	// ModeForwarder is the agent command to invoke for running as a forwarder.
 	ModeForwarder = "forwarder"
+	// ModeMultiplexer is the agent command to invoke for running as a
+	// multiplexer that serves endpoint and forwarder streams over a single
+	// connection.
+	ModeMultiplexer = "multiplexer"
+	// ModeList is the agent command to invoke to list installed agent
+	// versions.
+	ModeList = "list"
+	// ModeUninstall is the agent command to invoke to remove installed agent
+	// versions.
+	ModeUninstall = "uninstall"
+	// ModePrune is the agent command to invoke to remove all installed agent
+	// versions other than its own.
+	ModePrune = "prune"
 	// ModeVersion is the agent command to invoke to print version information.
 	ModeVersion = "version"
 	// ModeLegal is the agent command to invoke to print legal information.
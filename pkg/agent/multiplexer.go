@@ -0,0 +1,277 @@
+package agent
+
+import (
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/hashicorp/yamux"
+
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+// errMultiplexingUnsupported indicates that an agent doesn't support
+// multiplexing according to the negotiated capabilities.
+var errMultiplexingUnsupported = errors.New("agent does not support multiplexing")
+
+// ServeMultiplexer serves multiplexed streams over the specified connection,
+// dispatching each stream to the handler registered for the mode that it
+// requests. Any negotiation result associated with the connection is associated
+// with each stream. It returns when the underlying connection fails or is
+// closed by the client, which happens once the client has no further streams
+// open.
+func ServeMultiplexer(logger *logging.Logger, connection net.Conn, handlers map[string]ModeHandler) error {
+	// Grab the negotiation result for the connection.
+	negotiation := NegotiationForConnection(connection)
+
+	// Create the multiplexer and defer its closure.
+	session, err := yamux.Server(connection, nil)
+	if err != nil {
+		return errors.Wrap(err, "unable to create multiplexer")
+	}
+	defer session.Close()
+
+	// Accept and serve streams.
+	for {
+		stream, err := session.Accept()
+		if err != nil {
+			return errors.Wrap(err, "unable to accept stream")
+		}
+		go ServeMode(logger, WithNegotiation(stream, negotiation), handlers)
+	}
+}
+
+// multiplexer tracks a shared multiplexed agent connection for a particular
+// key.
+type multiplexer struct {
+	// lock serializes access to the multiplexer. It is not held while dialing,
+	// so a hung dial won't block stream closures or dials for other keys.
+	lock sync.Mutex
+	// dialing is the in-progress dial, if any. Concurrent dial requests with
+	// the same key wait on it so that only a single agent connection (and thus
+	// a single authentication) is established.
+	dialing *multiplexerDial
+	// session is the multiplexing session, if any.
+	session *yamux.Session
+	// negotiation is the negotiation result for the session's underlying
+	// connection, if any.
+	negotiation *Negotiation
+	// streams is the number of streams currently open on the session.
+	streams int
+}
+
+// multiplexerDial tracks an in-progress multiplexer dial.
+type multiplexerDial struct {
+	// done is closed once the dial completes.
+	done chan struct{}
+	// err is the error (if any) that occurred while dialing. It is only valid
+	// once done has been closed.
+	err error
+}
+
+// multiplexersLock serializes access to multiplexers.
+var multiplexersLock sync.Mutex
+
+// multiplexers maps keys to their corresponding multiplexers.
+var multiplexers = make(map[string]*multiplexer)
+
+// multiplexerForKey returns the multiplexer for the specified key, creating it
+// if necessary.
+func multiplexerForKey(key string) *multiplexer {
+	multiplexersLock.Lock()
+	defer multiplexersLock.Unlock()
+	result, ok := multiplexers[key]
+	if !ok {
+		result = &multiplexer{}
+		multiplexers[key] = result
+	}
+	return result
+}
+
+// multiplexedConnection wraps a multiplexed stream and releases its reference
+// to the underlying session when closed.
+type multiplexedConnection struct {
+	net.Conn
+	// multiplexer is the parent multiplexer.
+	multiplexer *multiplexer
+	// session is the session on which the stream was opened.
+	session *yamux.Session
+	// result is the negotiation result for the session, if any.
+	result *Negotiation
+	// closeOnce ensures that the stream's reference is released only once.
+	closeOnce sync.Once
+}
+
+// negotiation implements negotiated.negotiation.
+func (c *multiplexedConnection) negotiation() *Negotiation {
+	return c.result
+}
+
+// Close implements net.Conn.Close. If this is the last stream open on the
+// underlying session, then the session (and thus the agent connection) is
+// closed as well.
+func (c *multiplexedConnection) Close() error {
+	err := c.Conn.Close()
+	c.closeOnce.Do(func() {
+		c.multiplexer.lock.Lock()
+		defer c.multiplexer.lock.Unlock()
+		if c.multiplexer.session != c.session {
+			return
+		}
+		c.multiplexer.streams--
+		if c.multiplexer.streams == 0 {
+			c.session.Close()
+			c.multiplexer.session = nil
+		}
+	})
+	return err
+}
+
+// dialSession establishes a new agent connection using dial and creates a
+// multiplexing session on top of it. It also returns the negotiation result
+// associated with the connection, if any.
+func dialSession(dial func() (net.Conn, error)) (*yamux.Session, *Negotiation, error) {
+	connection, err := dial()
+	if err != nil {
+		return nil, nil, err
+	}
+	session, err := yamux.Client(connection, nil)
+	if err != nil {
+		connection.Close()
+		return nil, nil, errors.Wrap(err, "unable to create multiplexer")
+	}
+	return session, NegotiationForConnection(connection), nil
+}
+
+// requireMultiplexing verifies that the negotiated capabilities for a connection
+// include multiplexing support. If they don't, then the connection is closed and
+// errMultiplexingUnsupported is returned. It passes through any dial error.
+func requireMultiplexing(connection net.Conn, err error) (net.Conn, error) {
+	if err != nil {
+		return nil, err
+	} else if negotiation := NegotiationForConnection(connection); negotiation == nil || !negotiation.Supports(FeatureMultiplexing) {
+		connection.Close()
+		return nil, errMultiplexingUnsupported
+	}
+	return connection, nil
+}
+
+// dialMultiplexed opens a stream in the specified mode on the multiplexed
+// connection for the specified key, using dial to establish the underlying
+// connection if no live connection exists.
+func dialMultiplexed(key string, dial func() (net.Conn, error), mode string) (net.Conn, error) {
+	// Grab and lock the multiplexer for this key.
+	multiplexer := multiplexerForKey(key)
+	multiplexer.lock.Lock()
+	defer multiplexer.lock.Unlock()
+
+	// Wait for a live session, dialing one if necessary.
+	for {
+		// If there's an existing session that has failed, then discard it. Any
+		// streams still referencing it will fail on their own.
+		if multiplexer.session != nil && multiplexer.session.IsClosed() {
+			multiplexer.session = nil
+			multiplexer.streams = 0
+		}
+
+		// If there's a live session, then we're done.
+		if multiplexer.session != nil {
+			break
+		}
+
+		// If another caller is already dialing, then wait for it to complete
+		// and use its result.
+		if dialing := multiplexer.dialing; dialing != nil {
+			multiplexer.lock.Unlock()
+			<-dialing.done
+			multiplexer.lock.Lock()
+			if dialing.err != nil {
+				return nil, dialing.err
+			}
+			continue
+		}
+
+		// Otherwise perform the dial ourselves without holding the lock.
+		dialing := &multiplexerDial{done: make(chan struct{})}
+		multiplexer.dialing = dialing
+		multiplexer.lock.Unlock()
+		session, negotiation, err := dialSession(dial)
+		multiplexer.lock.Lock()
+		multiplexer.dialing = nil
+		dialing.err = err
+		if err == nil {
+			multiplexer.session = session
+			multiplexer.negotiation = negotiation
+			multiplexer.streams = 0
+		}
+		close(dialing.done)
+		if err != nil {
+			return nil, err
+		}
+	}
+	session := multiplexer.session
+	negotiation := multiplexer.negotiation
+
+	// Define a cleanup function that closes the session if it has no other
+	// streams.
+	cleanup := func() {
+		if multiplexer.streams == 0 {
+			session.Close()
+			multiplexer.session = nil
+		}
+	}
+
+	// Open a stream.
+	stream, err := session.Open()
+	if err != nil {
+		cleanup()
+		return nil, errors.Wrap(err, "unable to open multiplexed stream")
+	}
+
+	// Request the mode.
+	if err := RequestMode(stream, mode); err != nil {
+		stream.Close()
+		cleanup()
+		return nil, err
+	}
+
+	// Record the stream.
+	multiplexer.streams++
+
+	// Success.
+	return &multiplexedConnection{
+		Conn:        stream,
+		multiplexer: multiplexer,
+		session:     session,
+		result:      negotiation,
+	}, nil
+}
+
+// DialMultiplexed connects to an agent-based endpoint using the specified mode,
+// sharing a single agent connection across all callers that specify the same
+// key. The key should uniquely identify the remote environment (e.g. the user,
+// host, and port for SSH-based transports). The transport and prompter are only
+// used if a new agent connection needs to be established. The shared agent
+// connection is closed once all connections returned for the key are closed.
+// If the agent doesn't support multiplexing according to the negotiated
+// capabilities, then a dedicated (non-multiplexed) connection is established.
+func DialMultiplexed(logger *logging.Logger, key string, transport Transport, mode, prompter string) (net.Conn, error) {
+	// Validate that the mode is sane.
+	if !(mode == ModeEndpoint || mode == ModeForwarder) {
+		panic("invalid agent multiplexed dial mode")
+	}
+
+	// Perform the dial.
+	connection, err := dialMultiplexed(key, func() (net.Conn, error) {
+		return requireMultiplexing(Dial(logger, transport, ModeMultiplexer, prompter))
+	}, mode)
+
+	// If multiplexing isn't supported, then fall back to a dedicated
+	// connection.
+	if err == errMultiplexingUnsupported {
+		logger.Debug("Agent does not support multiplexing, using dedicated connection")
+		return Dial(logger, transport, mode, prompter)
+	}
+	return connection, err
+}
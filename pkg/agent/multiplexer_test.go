@@ -0,0 +1,240 @@
+package agent
+
+import (
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+// multiplexerTestHandlers returns handlers for use in multiplexer tests. The
+// endpoint mode handler echoes data and the forwarder mode handler writes its
+// mode name.
+func multiplexerTestHandlers() map[string]ModeHandler {
+	return map[string]ModeHandler{
+		ModeEndpoint: func(stream net.Conn) error {
+			defer stream.Close()
+			_, err := io.Copy(stream, stream)
+			return err
+		},
+		ModeForwarder: func(stream net.Conn) error {
+			defer stream.Close()
+			_, err := stream.Write([]byte(ModeForwarder))
+			return err
+		},
+	}
+}
+
+// multiplexerTestDialer is a dialer that creates in-memory multiplexer servers
+// and tracks how many times it has been invoked.
+type multiplexerTestDialer struct {
+	// lock serializes access to dials.
+	lock sync.Mutex
+	// dials is the number of dial operations performed.
+	dials int
+	// serverTerminations receives the termination results of each server.
+	serverTerminations chan error
+}
+
+// dial creates a new in-memory multiplexer server and returns the client end
+// of its connection.
+func (d *multiplexerTestDialer) dial() (net.Conn, error) {
+	d.lock.Lock()
+	d.dials++
+	d.lock.Unlock()
+	client, server := net.Pipe()
+	go func() {
+		d.serverTerminations <- ServeMultiplexer(logging.RootLogger, server, multiplexerTestHandlers())
+	}()
+	return client, nil
+}
+
+func TestMultiplexerSharesConnection(t *testing.T) {
+	// Create a dialer.
+	dialer := &multiplexerTestDialer{serverTerminations: make(chan error, 2)}
+	const key = "TestMultiplexerSharesConnection"
+
+	// Open an endpoint stream and verify that it echoes data.
+	endpoint, err := dialMultiplexed(key, dialer.dial, ModeEndpoint)
+	if err != nil {
+		t.Fatal("unable to dial endpoint stream:", err)
+	}
+	if _, err := endpoint.Write([]byte("ping")); err != nil {
+		t.Fatal("unable to write to endpoint stream:", err)
+	}
+	echo := make([]byte, 4)
+	if _, err := io.ReadFull(endpoint, echo); err != nil {
+		t.Fatal("unable to read from endpoint stream:", err)
+	} else if string(echo) != "ping" {
+		t.Error("echoed data mismatch:", string(echo))
+	}
+
+	// Open a forwarder stream and verify that it's dispatched correctly.
+	forwarder, err := dialMultiplexed(key, dialer.dial, ModeForwarder)
+	if err != nil {
+		t.Fatal("unable to dial forwarder stream:", err)
+	}
+	mode := make([]byte, len(ModeForwarder))
+	if _, err := io.ReadFull(forwarder, mode); err != nil {
+		t.Fatal("unable to read from forwarder stream:", err)
+	} else if string(mode) != ModeForwarder {
+		t.Error("forwarder stream mode mismatch:", string(mode))
+	}
+
+	// Verify that only a single underlying connection was created.
+	if dialer.dials != 1 {
+		t.Fatal("unexpected number of dials:", dialer.dials)
+	}
+
+	// Close the streams and verify that the server terminates once the last
+	// stream is closed.
+	forwarder.Close()
+	forwarder.Close()
+	select {
+	case <-dialer.serverTerminations:
+		t.Fatal("server terminated with streams still open")
+	default:
+	}
+	endpoint.Close()
+	<-dialer.serverTerminations
+
+	// Verify that a subsequent dial creates a new connection.
+	endpoint, err = dialMultiplexed(key, dialer.dial, ModeEndpoint)
+	if err != nil {
+		t.Fatal("unable to redial endpoint stream:", err)
+	}
+	if dialer.dials != 2 {
+		t.Error("unexpected number of dials:", dialer.dials)
+	}
+	endpoint.Close()
+	<-dialer.serverTerminations
+}
+
+func TestMultiplexerUnsupportedMode(t *testing.T) {
+	// Create a dialer.
+	dialer := &multiplexerTestDialer{serverTerminations: make(chan error, 1)}
+
+	// Attempt to dial an unsupported mode.
+	if _, err := dialMultiplexed("TestMultiplexerUnsupportedMode", dialer.dial, "invalid"); err == nil {
+		t.Fatal("dialing unsupported mode succeeded")
+	}
+
+	// Verify that the server was shut down since no streams remain.
+	<-dialer.serverTerminations
+}
+
+func TestMultiplexerDialDoesNotBlockOtherKeys(t *testing.T) {
+	// Start a dial for one key that blocks until released.
+	release := make(chan struct{})
+	blockedResults := make(chan error, 1)
+	go func() {
+		_, err := dialMultiplexed("TestMultiplexerDialDoesNotBlockOtherKeys-blocked", func() (net.Conn, error) {
+			<-release
+			return nil, errors.New("dial aborted")
+		}, ModeEndpoint)
+		blockedResults <- err
+	}()
+
+	// Start a second dial for the same key, which should wait on the first.
+	waiterResults := make(chan error, 1)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_, err := dialMultiplexed("TestMultiplexerDialDoesNotBlockOtherKeys-blocked", func() (net.Conn, error) {
+			return nil, errors.New("unexpected second dial")
+		}, ModeEndpoint)
+		waiterResults <- err
+	}()
+
+	// Verify that a dial for another key completes while the first is blocked.
+	dialer := &multiplexerTestDialer{serverTerminations: make(chan error, 1)}
+	done := make(chan error, 1)
+	go func() {
+		connection, err := dialMultiplexed("TestMultiplexerDialDoesNotBlockOtherKeys", dialer.dial, ModeEndpoint)
+		if err == nil {
+			connection.Close()
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal("unable to dial unblocked key:", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("dial blocked by dial for another key")
+	}
+	<-dialer.serverTerminations
+
+	// Release the blocked dial and verify that both callers see its error.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	if err := <-blockedResults; err == nil || err.Error() != "dial aborted" {
+		t.Error("unexpected blocked dial result:", err)
+	}
+	if err := <-waiterResults; err == nil || err.Error() != "dial aborted" {
+		t.Error("unexpected waiting dial result:", err)
+	}
+}
+
+func TestMultiplexerNegotiation(t *testing.T) {
+	// Create a dialer that associates a negotiation result with the client end
+	// of each connection and the server end of each connection.
+	negotiation := &Negotiation{Features: []string{FeatureMultiplexing}}
+	serverNegotiations := make(chan *Negotiation, 1)
+	serverTerminations := make(chan error, 1)
+	dial := func() (net.Conn, error) {
+		client, server := net.Pipe()
+		go func() {
+			serverTerminations <- ServeMultiplexer(logging.RootLogger, WithNegotiation(server, negotiation), map[string]ModeHandler{
+				ModeEndpoint: func(stream net.Conn) error {
+					serverNegotiations <- NegotiationForConnection(stream)
+					return stream.Close()
+				},
+			})
+		}()
+		return requireMultiplexing(WithNegotiation(client, negotiation), nil)
+	}
+
+	// Open a stream and verify that both ends carry the negotiation result.
+	stream, err := dialMultiplexed("TestMultiplexerNegotiation", dial, ModeEndpoint)
+	if err != nil {
+		t.Fatal("unable to dial stream:", err)
+	}
+	if NegotiationForConnection(stream) != negotiation {
+		t.Error("client stream does not carry negotiation")
+	}
+	if <-serverNegotiations != negotiation {
+		t.Error("server stream does not carry negotiation")
+	}
+	stream.Close()
+	<-serverTerminations
+}
+
+func TestRequireMultiplexing(t *testing.T) {
+	// Verify that dial errors are passed through.
+	if _, err := requireMultiplexing(nil, errors.New("dial failed")); err == nil || err.Error() != "dial failed" {
+		t.Error("unexpected result for dial error:", err)
+	}
+
+	// Verify that connections without multiplexing support are rejected and
+	// closed.
+	client, server := net.Pipe()
+	defer server.Close()
+	if _, err := requireMultiplexing(WithNegotiation(client, &Negotiation{}), nil); err != errMultiplexingUnsupported {
+		t.Error("unexpected result for connection without multiplexing support:", err)
+	} else if _, err := client.Write([]byte{0}); err == nil {
+		t.Error("connection without multiplexing support not closed")
+	}
+
+	// Verify that connections without any negotiation are rejected.
+	client, server = net.Pipe()
+	defer server.Close()
+	if _, err := requireMultiplexing(client, nil); err != errMultiplexingUnsupported {
+		t.Error("unexpected result for connection without negotiation:", err)
+	}
+}
@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"fmt"
 	"path/filepath"
 	"runtime"
 
@@ -17,12 +18,20 @@ const (
 	BaseName = "mutagen-agent"
 )
 
+// InstallationVersion is the version used to identify installed agents (and
+// name their installation directories). Since agents are compatible across
+// patch releases (see versionsCompatible), it includes only the major and minor
+// components of the Mutagen version, allowing patch releases to share an
+// installed agent. Agents installed by a different build are detected by
+// executable digest verification and reinstalled.
+var InstallationVersion = fmt.Sprintf("%d.%d", mutagen.VersionMajor, mutagen.VersionMinor)
+
 // installPath computes and creates the parent directories of the path where the
 // current executable should be installed if it is an agent binary with the
-// current Mutagen version.
+// current installation version.
 func installPath() (string, error) {
 	// Compute (and create) the path to the agent parent directory.
-	parent, err := filesystem.Mutagen(true, filesystem.MutagenAgentsDirectoryName, mutagen.Version)
+	parent, err := filesystem.Mutagen(true, filesystem.MutagenAgentsDirectoryName, InstallationVersion)
 	if err != nil {
 		return "", errors.Wrap(err, "unable to compute parent directory")
 	}
@@ -0,0 +1,169 @@
+package agent
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/mutagen"
+)
+
+const (
+	// SourceEnvironmentVariable is the name of the environment variable that
+	// can be used to specify an agent source from which agents for platforms
+	// missing from the agent bundle can be retrieved. The source may be either
+	// a local directory or an HTTP(S) URL. In either case, it must contain a
+	// manifest named BundleManifestName and agent executables named using the
+	// same <GOOS>_<GOARCH> format used in the agent bundle. Any occurrence of
+	// "{version}" in the source is replaced with the current Mutagen version.
+	// Plain HTTP sources additionally require that the manifest digest be
+	// pinned using SourceManifestDigestEnvironmentVariable.
+	SourceEnvironmentVariable = "MUTAGEN_AGENT_SOURCE"
+
+	// SourceManifestDigestEnvironmentVariable is the name of the environment
+	// variable that can be used to pin the hex-encoded SHA-256 digest of the
+	// agent source manifest. It's optional for local and HTTPS sources, but
+	// required for plain HTTP sources, since the manifest is what's used to
+	// verify the agents retrieved from the source.
+	SourceManifestDigestEnvironmentVariable = "MUTAGEN_AGENT_SOURCE_MANIFEST_DIGEST"
+
+	// sourceVersionPlaceholder is the placeholder in agent sources that will be
+	// replaced with the current Mutagen version.
+	sourceVersionPlaceholder = "{version}"
+
+	// sourceRequestTimeout is the maximum amount of time allowed for a request
+	// to an HTTP(S) agent source, including reading the response body.
+	sourceRequestTimeout = 5 * time.Minute
+
+	// maximumSourceManifestSize is the maximum agent source manifest size that
+	// we'll accept.
+	maximumSourceManifestSize = 1024 * 1024
+)
+
+// currentSource returns the configured agent source, if any, with version
+// placeholders substituted.
+func currentSource() string {
+	return strings.Replace(
+		os.Getenv(SourceEnvironmentVariable),
+		sourceVersionPlaceholder, mutagen.Version, -1,
+	)
+}
+
+// isHTTPSource returns whether or not an agent source is an HTTP(S) URL.
+func isHTTPSource(source string) bool {
+	return isInsecureHTTPSource(source) || strings.HasPrefix(strings.ToLower(source), "https://")
+}
+
+// isInsecureHTTPSource returns whether or not an agent source is a plain HTTP
+// URL.
+func isInsecureHTTPSource(source string) bool {
+	return strings.HasPrefix(strings.ToLower(source), "http://")
+}
+
+// sourceHTTPClient is the HTTP client used for HTTP(S) agent sources. It won't
+// follow redirects from HTTPS to plain HTTP URLs.
+var sourceHTTPClient = &http.Client{
+	Timeout: sourceRequestTimeout,
+	CheckRedirect: func(request *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("too many redirects")
+		} else if via[0].URL.Scheme == "https" && request.URL.Scheme != "https" {
+			return errors.New("refusing to follow redirect from HTTPS to insecure URL")
+		}
+		return nil
+	},
+}
+
+// openSource opens the named file from an agent source.
+func openSource(source, name string) (io.ReadCloser, error) {
+	// Handle local directory sources.
+	if !isHTTPSource(source) {
+		file, err := os.Open(filepath.Join(source, name))
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to open file in agent source")
+		}
+		return file, nil
+	}
+
+	// Handle HTTP(S) sources.
+	response, err := sourceHTTPClient.Get(strings.TrimSuffix(source, "/") + "/" + name)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to request file from agent source")
+	} else if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, errors.Errorf("agent source returned status %d", response.StatusCode)
+	}
+	return response.Body, nil
+}
+
+// readSourceManifest reads the manifest from an agent source. If a manifest
+// digest is pinned, then the manifest is verified against it. Plain HTTP
+// sources require a pinned manifest digest.
+func readSourceManifest(source string) (BundleManifest, error) {
+	// Determine the pinned manifest digest, if any.
+	var pinned []byte
+	if encoded := os.Getenv(SourceManifestDigestEnvironmentVariable); encoded != "" {
+		if decoded, err := hex.DecodeString(encoded); err != nil || len(decoded) != sha256.Size {
+			return nil, errors.Errorf("invalid agent source manifest digest (%s)", encoded)
+		} else {
+			pinned = decoded
+		}
+	} else if isInsecureHTTPSource(source) {
+		return nil, errors.Errorf(
+			"plain HTTP agent sources require a pinned manifest digest (set %s or use HTTPS)",
+			SourceManifestDigestEnvironmentVariable,
+		)
+	}
+
+	// Open the manifest and ensure its closure.
+	manifest, err := openSource(source, BundleManifestName)
+	if err != nil {
+		return nil, err
+	}
+	defer manifest.Close()
+
+	// Read the manifest.
+	data, err := ioutil.ReadAll(io.LimitReader(manifest, maximumSourceManifestSize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read manifest")
+	} else if len(data) > maximumSourceManifestSize {
+		return nil, errors.New("manifest too large")
+	}
+
+	// Verify the manifest against the pinned digest, if any.
+	if pinned != nil {
+		if digest := sha256.Sum256(data); !bytes.Equal(digest[:], pinned) {
+			return nil, errors.New("manifest does not match pinned digest")
+		}
+	}
+
+	// Parse the manifest.
+	return ReadBundleManifest(bytes.NewReader(data))
+}
+
+// fetchFromSource retrieves the named agent from an agent source.
+func fetchFromSource(source, name string, writer io.Writer) error {
+	// Open the agent and ensure its closure.
+	agent, err := openSource(source, name)
+	if err != nil {
+		return err
+	}
+	defer agent.Close()
+
+	// Copy the agent.
+	if _, err := io.Copy(writer, agent); err != nil {
+		return errors.Wrap(err, "unable to copy agent data from agent source")
+	}
+
+	// Success.
+	return nil
+}
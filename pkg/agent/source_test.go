@@ -0,0 +1,186 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/mutagen"
+)
+
+// setTestEnvironmentVariable sets an environment variable and returns a
+// function that restores its previous value.
+func setTestEnvironmentVariable(name, value string) func() {
+	previous, previousSet := os.LookupEnv(name)
+	os.Setenv(name, value)
+	return func() {
+		if previousSet {
+			os.Setenv(name, previous)
+		} else {
+			os.Unsetenv(name)
+		}
+	}
+}
+
+// testAgentContent is the content used for fake agent executables.
+const testAgentContent = "fake agent"
+
+// createTestSource creates a local agent source containing a fake agent for
+// the fakeos/fakearch platform. If corrupt is true, then the manifest won't
+// match the agent. It returns the path to the source, which the caller is
+// responsible for removing.
+func createTestSource(t *testing.T, corrupt bool) string {
+	// Mark this as a helper function.
+	t.Helper()
+
+	// Create the source directory.
+	directory, err := ioutil.TempDir("", "mutagen_agent_source")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+
+	// Write the agent.
+	if err := ioutil.WriteFile(filepath.Join(directory, "fakeos_fakearch"), []byte(testAgentContent), 0600); err != nil {
+		os.RemoveAll(directory)
+		t.Fatal("unable to write agent:", err)
+	}
+
+	// Write the manifest.
+	digest := ExecutableDigest(sha256.Sum256([]byte(testAgentContent)))
+	if corrupt {
+		digest[0]++
+	}
+	manifest := BundleManifest{"fakeos_fakearch": digest}
+	file, err := os.Create(filepath.Join(directory, BundleManifestName))
+	if err != nil {
+		os.RemoveAll(directory)
+		t.Fatal("unable to create manifest:", err)
+	}
+	if err := manifest.Write(file); err != nil {
+		file.Close()
+		os.RemoveAll(directory)
+		t.Fatal("unable to write manifest:", err)
+	}
+	file.Close()
+
+	// Done.
+	return directory
+}
+
+// testExecutableFromSource tests extraction of the fake agent from the
+// specified source.
+func testExecutableFromSource(t *testing.T, source string, expectSuccess bool) {
+	// Mark this as a helper function.
+	t.Helper()
+
+	// Configure the source.
+	defer setTestEnvironmentVariable(SourceEnvironmentVariable, source)()
+
+	// Perform extraction.
+	executable, err := ExecutableForPlatform("fakeos", "fakearch", "")
+	if !expectSuccess {
+		if err == nil {
+			os.Remove(executable)
+			t.Fatal("extraction succeeded unexpectedly")
+		}
+		return
+	} else if err != nil {
+		t.Fatal("unable to extract agent from source:", err)
+	}
+	defer os.Remove(executable)
+
+	// Verify the content.
+	if content, err := ioutil.ReadFile(executable); err != nil {
+		t.Fatal("unable to read extracted agent:", err)
+	} else if string(content) != testAgentContent {
+		t.Error("extracted agent content does not match expected")
+	}
+}
+
+func TestExecutableFromLocalSource(t *testing.T) {
+	source := createTestSource(t, false)
+	defer os.RemoveAll(source)
+	testExecutableFromSource(t, source, true)
+}
+
+func TestExecutableFromLocalSourceCorrupt(t *testing.T) {
+	source := createTestSource(t, true)
+	defer os.RemoveAll(source)
+	testExecutableFromSource(t, source, false)
+}
+
+// pinTestSourceManifest pins the digest of the manifest in the specified local
+// agent source. It returns a function that restores the previous pin.
+func pinTestSourceManifest(t *testing.T, source string) func() {
+	// Mark this as a helper function.
+	t.Helper()
+
+	// Compute the manifest digest and pin it.
+	manifest, err := ioutil.ReadFile(filepath.Join(source, BundleManifestName))
+	if err != nil {
+		t.Fatal("unable to read manifest:", err)
+	}
+	digest := sha256.Sum256(manifest)
+	return setTestEnvironmentVariable(SourceManifestDigestEnvironmentVariable, hex.EncodeToString(digest[:]))
+}
+
+func TestExecutableFromHTTPSource(t *testing.T) {
+	source := createTestSource(t, false)
+	defer os.RemoveAll(source)
+	defer pinTestSourceManifest(t, source)()
+	server := httptest.NewServer(http.FileServer(http.Dir(source)))
+	defer server.Close()
+	testExecutableFromSource(t, server.URL+"/", true)
+}
+
+func TestExecutableFromHTTPSourceUnpinned(t *testing.T) {
+	source := createTestSource(t, false)
+	defer os.RemoveAll(source)
+	defer setTestEnvironmentVariable(SourceManifestDigestEnvironmentVariable, "")()
+	server := httptest.NewServer(http.FileServer(http.Dir(source)))
+	defer server.Close()
+	testExecutableFromSource(t, server.URL+"/", false)
+}
+
+func TestExecutableFromHTTPSourcePinMismatch(t *testing.T) {
+	source := createTestSource(t, false)
+	defer os.RemoveAll(source)
+	digest := sha256.Sum256([]byte("invalid"))
+	defer setTestEnvironmentVariable(SourceManifestDigestEnvironmentVariable, hex.EncodeToString(digest[:]))()
+	server := httptest.NewServer(http.FileServer(http.Dir(source)))
+	defer server.Close()
+	testExecutableFromSource(t, server.URL+"/", false)
+}
+
+func TestExecutableFromHTTPSSource(t *testing.T) {
+	source := createTestSource(t, false)
+	defer os.RemoveAll(source)
+	defer setTestEnvironmentVariable(SourceManifestDigestEnvironmentVariable, "")()
+	server := httptest.NewTLSServer(http.FileServer(http.Dir(source)))
+	defer server.Close()
+	previousClient := sourceHTTPClient
+	sourceHTTPClient = server.Client()
+	defer func() {
+		sourceHTTPClient = previousClient
+	}()
+	testExecutableFromSource(t, server.URL+"/", true)
+}
+
+func TestExecutableFromHTTPSourceMissing(t *testing.T) {
+	defer setTestEnvironmentVariable(SourceManifestDigestEnvironmentVariable, hex.EncodeToString(make([]byte, sha256.Size)))()
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	testExecutableFromSource(t, server.URL, false)
+}
+
+func TestCurrentSourceVersionSubstitution(t *testing.T) {
+	defer setTestEnvironmentVariable(SourceEnvironmentVariable, "https://example.com/{version}/agents")()
+	if source := currentSource(); source != "https://example.com/"+mutagen.Version+"/agents" {
+		t.Error("version placeholder not substituted:", source)
+	}
+}
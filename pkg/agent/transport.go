@@ -1,10 +1,11 @@
 package agent
 
 import (
-	"os"
-	"os/exec"
+	"io/ioutil"
 
 	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/process"
 )
 
 // Transport is the standard agent transport interface, allowing the agent
@@ -20,15 +21,16 @@ type Transport interface {
 	Copy(localPath, remoteName string) error
 	// Command creates (but does not start) a process that will invoke the
 	// specified command on the specified remote. It should not re-direct any of
-	// the output streams of the process. The command on the remote must be
+	// the streams of the process. The command on the remote must be
 	// invoked with the user's home directory as the working directory. Any
 	// command provided to this interface is guaranteed to be lexable by simply
 	// splitting on spaces.
-	Command(command string) (*exec.Cmd, error)
+	Command(command string) (process.Process, error)
 	// ClassifyError is used to determine how the agent dialing infrastructure
 	// should attempt to handle failure when launching agents. It is provided
-	// with the process exit state as well as a string containing the standard
-	// error output from the command. It should return a bool representing
+	// with the process exit code (which will be -1 if it couldn't be
+	// determined) as well as a string containing the standard error output
+	// from the command. It should return a bool representing
 	// whether or not the error condition represents a failure due to an agent
 	// either not being installed or being installed improperly and a bool
 	// representing whether or not the remote system should be treated as a
@@ -38,14 +40,13 @@ type Transport interface {
 	// attempt to reconnect using the correct command syntax for that platform.
 	// Otherwise, if the first bool indicates that the agent binary simply needs
 	// to be (re-)installed, it will attempt to do so and then reconnect.
-	ClassifyError(processState *os.ProcessState, errorOutput string) (bool, bool, error)
+	ClassifyError(exitCode int, errorOutput string) (bool, bool, error)
 }
 
 // run is a utility method that invokes a command via a transport, waits for it
 // to complete, and returns its exit error. If there is an error creating the
-// command, it will be returned wrapped, but otherwise the result of the run
-// method will be returned un-wrapped, so it can be treated as an
-// os/exec.ExitError.
+// command, it will be returned wrapped, but otherwise the result of the wait
+// operation will be returned un-wrapped.
 func run(transport Transport, command string) error {
 	// Create the process.
 	process, err := transport.Command(command)
@@ -53,15 +54,19 @@ func run(transport Transport, command string) error {
 		return errors.Wrap(err, "unable to create command")
 	}
 
-	// Run the process.
-	return process.Run()
+	// Start the process.
+	if err := process.Start(); err != nil {
+		return err
+	}
+
+	// Wait for the process to complete.
+	return process.Wait()
 }
 
 // output is a utility method that invokes a command via a transport, waits for
 // it to complete, and returns its standard output and exit error. If there is
 // an error creating the command, it will be returned wrapped, but otherwise the
-// result of the run method will be returned un-wrapped, so it can be treated as
-// an os/exec.ExitError.
+// result of the wait operation will be returned un-wrapped.
 func output(transport Transport, command string) ([]byte, error) {
 	// Create the process.
 	process, err := transport.Command(command)
@@ -69,6 +74,28 @@ func output(transport Transport, command string) ([]byte, error) {
 		return nil, errors.Wrap(err, "unable to create command")
 	}
 
-	// Run the process.
-	return process.Output()
+	// Redirect the process' standard output.
+	standardOutput, err := process.StdoutPipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to redirect process output")
+	}
+
+	// Start the process.
+	if err := process.Start(); err != nil {
+		return nil, err
+	}
+
+	// Read the process' output. We have to do this before waiting, since the
+	// wait operation may close the output pipe.
+	result, readErr := ioutil.ReadAll(standardOutput)
+
+	// Wait for the process to complete.
+	if err := process.Wait(); err != nil {
+		return nil, err
+	} else if readErr != nil {
+		return nil, errors.Wrap(readErr, "unable to read process output")
+	}
+
+	// Success.
+	return result, nil
 }
@@ -0,0 +1,48 @@
+package docker
+
+import (
+	"context"
+	"os/exec"
+
+	"github.com/mutagen-io/mutagen/pkg/tools/docker"
+	"github.com/mutagen-io/mutagen/pkg/tools/podman"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// containerCLI describes a Docker-compatible container command line interface.
+type containerCLI struct {
+	// name is the human-readable name of the interface.
+	name string
+	// command prepares (but does not start) a command for the interface. It
+	// receives the environment variables locked in to the URL.
+	command func(context.Context, map[string]string, ...string) (*exec.Cmd, error)
+	// environmentVariables are the environment variables that are locked in to
+	// URLs for the interface and need to be set for its invocations.
+	environmentVariables []string
+	// distinctCommandNotFoundExitCode indicates whether or not the interface
+	// returns a distinct exit code (127) when a command isn't found inside the
+	// container, rather than aliasing it to the invalid command exit code (126).
+	distinctCommandNotFoundExitCode bool
+}
+
+// dockerCLI is the Docker command line interface. The underlying executable
+// may be substituted with another Docker-compatible interface using the
+// MUTAGEN_DOCKER_COMMAND environment variable, which is locked in to the URL at
+// parse time.
+var dockerCLI = &containerCLI{
+	name: "Docker",
+	command: func(context context.Context, environment map[string]string, args ...string) (*exec.Cmd, error) {
+		return docker.Command(context, environment[url.DockerCommandEnvironmentVariable], args...)
+	},
+	environmentVariables: url.DockerEnvironmentVariables,
+}
+
+// podmanCLI is the Podman command line interface.
+var podmanCLI = &containerCLI{
+	name: "Podman",
+	command: func(context context.Context, _ map[string]string, args ...string) (*exec.Cmd, error) {
+		return podman.Command(context, args...)
+	},
+	environmentVariables:            url.PodmanEnvironmentVariables,
+	distinctCommandNotFoundExitCode: true,
+}
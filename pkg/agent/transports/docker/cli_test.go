@@ -0,0 +1,3 @@
+package docker
+
+// TODO: Implement.
@@ -1,2 +1,3 @@
-// Package docker provides the Docker transport implementation.
+// Package docker provides the Docker transport implementation. It also supports
+// Docker-compatible container command line interfaces, such as Podman.
 package docker
@@ -13,7 +13,6 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/agent"
 	"github.com/mutagen-io/mutagen/pkg/process"
 	"github.com/mutagen-io/mutagen/pkg/prompt"
-	"github.com/mutagen-io/mutagen/pkg/tools/docker"
 )
 
 // windowsContainerNotification is a prompt about copying files into Windows
@@ -25,14 +24,17 @@ Hyper-V doesn't support copying files into running containers.
 
 Would you like to continue? (yes/no)? `
 
-// transport implements the agent.Transport interface using Docker.
+// transport implements the agent.Transport interface using Docker or another
+// Docker-compatible container command line interface.
 type transport struct {
+	// cli is the container command line interface to use.
+	cli *containerCLI
 	// container is the target container name.
 	container string
 	// user is the container user under which agents should be invoked.
 	user string
 	// environment is the collection of environment variables that need to be
-	// set for the Docker executable.
+	// set for the container command line interface.
 	environment map[string]string
 	// prompter is the prompter identifier to use for prompting.
 	prompter string
@@ -63,6 +65,19 @@ type transport struct {
 // NewTransport creates a new Docker transport using the specified parameters.
 func NewTransport(container, user string, environment map[string]string, prompter string) (agent.Transport, error) {
 	return &transport{
+		cli:         dockerCLI,
+		container:   container,
+		user:        user,
+		environment: environment,
+		prompter:    prompter,
+	}, nil
+}
+
+// NewPodmanTransport creates a new Podman transport using the specified
+// parameters.
+func NewPodmanTransport(container, user string, environment map[string]string, prompter string) (agent.Transport, error) {
+	return &transport{
+		cli:         podmanCLI,
 		container:   container,
 		user:        user,
 		environment: environment,
@@ -104,7 +119,7 @@ func (t *transport) command(command, workingDirectory, user string) (*exec.Cmd,
 	dockerArguments = append(dockerArguments, strings.Split(command, " ")...)
 
 	// Create the command.
-	dockerCommand, err := docker.Command(context.Background(), dockerArguments...)
+	dockerCommand, err := t.cli.command(context.Background(), t.environment, dockerArguments...)
 	if err != nil {
 		return nil, err
 	}
@@ -115,8 +130,8 @@ func (t *transport) command(command, workingDirectory, user string) (*exec.Cmd,
 	// Create a copy of the current environment.
 	environment := os.Environ()
 
-	// Set Docker environment variables.
-	environment = setDockerVariables(environment, t.environment)
+	// Set container environment variables.
+	environment = setContainerVariables(environment, t.cli.environmentVariables, t.environment)
 
 	// Set the environment for the command.
 	dockerCommand.Env = environment
@@ -125,6 +140,32 @@ func (t *transport) command(command, workingDirectory, user string) (*exec.Cmd,
 	return dockerCommand, nil
 }
 
+// probeIdentity runs the POSIX id command inside the container with the
+// specified flags to determine a user or group name. If the name can't be
+// determined, then it falls back to running id with the specified numeric
+// flags. This is necessary for containers (e.g. rootless Podman containers
+// using --userns=keep-id) where the user or group inside the container is
+// mapped from the host and may not have an entry in /etc/passwd or /etc/group.
+// Numeric identifiers are equally acceptable to chown. It returns the identity
+// and whether or not it's the numeric fallback.
+func (t *transport) probeIdentity(nameFlags, numericFlags string) (string, bool, error) {
+	for _, flags := range []string{nameFlags, numericFlags} {
+		command, err := t.command("id "+flags, "", "")
+		if err != nil {
+			return "", false, errors.Wrapf(err, "unable to set up %s invocation", t.cli.name)
+		}
+		output, err := command.Output()
+		if err != nil {
+			continue
+		} else if !utf8.Valid(output) {
+			return "", false, errors.New("non-UTF-8 output")
+		} else if result := strings.TrimSpace(string(output)); result != "" {
+			return result, flags == numericFlags, nil
+		}
+	}
+	return "", false, errors.New("unable to determine identity")
+}
+
 // probeContainer ensures that the containerIsWindows and containerHomeDirectory
 // fields are populated. It is idempotent. If probing previously failed, probing
 // will simply return an error indicating the previous failure.
@@ -151,12 +192,12 @@ func (t *transport) probeContainer() error {
 	// detect a non-UTF-8 output or detect an empty home directory, we treat
 	// that as an error.
 	if command, err := t.command("env", "", ""); err != nil {
-		return errors.Wrap(err, "unable to set up Docker invocation")
+		return errors.Wrapf(err, "unable to set up %s invocation", t.cli.name)
 	} else if envBytes, err := command.Output(); err == nil {
 		if !utf8.Valid(envBytes) {
 			t.containerProbeError = errors.New("non-UTF-8 POSIX environment")
 			return t.containerProbeError
-		} else if h, ok := findEnviromentVariable(string(envBytes), "HOME"); ok {
+		} else if h, ok := process.FindEnvironmentVariable(string(envBytes), "HOME"); ok {
 			if h == "" {
 				t.containerProbeError = errors.New("empty POSIX home directory")
 				return t.containerProbeError
@@ -171,12 +212,12 @@ func (t *transport) probeContainer() error {
 	// on Windows to identify the USERPROFILE environment variable.
 	if home == "" {
 		if command, err := t.command("cmd /c set", "", ""); err != nil {
-			return errors.Wrap(err, "unable to set up Docker invocation")
+			return errors.Wrapf(err, "unable to set up %s invocation", t.cli.name)
 		} else if envBytes, err := command.Output(); err == nil {
 			if !utf8.Valid(envBytes) {
 				t.containerProbeError = errors.New("non-UTF-8 Windows environment")
 				return t.containerProbeError
-			} else if h, ok := findEnviromentVariable(string(envBytes), "USERPROFILE"); ok {
+			} else if h, ok := process.FindEnvironmentVariable(string(envBytes), "USERPROFILE"); ok {
 				if h == "" {
 					t.containerProbeError = errors.New("empty Windows home directory")
 					return t.containerProbeError
@@ -213,18 +254,15 @@ func (t *transport) probeContainer() error {
 	// need to query it.
 	var username, group string
 	if !windows {
-		// Query username.
-		if command, err := t.command("id -un", "", ""); err != nil {
-			return errors.Wrap(err, "unable to set up Docker invocation")
-		} else if usernameBytes, err := command.Output(); err != nil {
-			t.containerProbeError = errors.New("unable to probe POSIX username")
-			return t.containerProbeError
-		} else if !utf8.Valid(usernameBytes) {
-			t.containerProbeError = errors.New("non-UTF-8 POSIX username")
-			return t.containerProbeError
-		} else if u := strings.TrimSpace(string(usernameBytes)); u == "" {
-			t.containerProbeError = errors.New("empty POSIX username")
+		// Query username. If we had to fall back to a numeric user identifier,
+		// then we can't compare it against a specified username, but since the
+		// container runtime accepted the specified user for our id invocation,
+		// we can simply use the specified user.
+		if u, numeric, err := t.probeIdentity("-un", "-u"); err != nil {
+			t.containerProbeError = errors.Wrap(err, "unable to probe POSIX username")
 			return t.containerProbeError
+		} else if t.user != "" && numeric {
+			username = t.user
 		} else if t.user != "" && u != t.user {
 			t.containerProbeError = errors.New("probed POSIX username does not match specified")
 			return t.containerProbeError
@@ -233,16 +271,8 @@ func (t *transport) probeContainer() error {
 		}
 
 		// Query default group name.
-		if command, err := t.command("id -gn", "", ""); err != nil {
-			return errors.Wrap(err, "unable to set up Docker invocation")
-		} else if groupBytes, err := command.Output(); err != nil {
-			t.containerProbeError = errors.New("unable to probe POSIX group name")
-			return t.containerProbeError
-		} else if !utf8.Valid(groupBytes) {
-			t.containerProbeError = errors.New("non-UTF-8 POSIX group name")
-			return t.containerProbeError
-		} else if g := strings.TrimSpace(string(groupBytes)); g == "" {
-			t.containerProbeError = errors.New("empty POSIX group name")
+		if g, _, err := t.probeIdentity("-gn", "-g"); err != nil {
+			t.containerProbeError = errors.Wrap(err, "unable to probe POSIX group name")
 			return t.containerProbeError
 		} else {
 			group = g
@@ -269,9 +299,9 @@ func (t *transport) changeContainerStatus(stop bool) error {
 	}
 
 	// Create the command.
-	dockerCommand, err := docker.Command(context.Background(), operation, t.container)
+	dockerCommand, err := t.cli.command(context.Background(), t.environment, operation, t.container)
 	if err != nil {
-		return errors.Wrap(err, "unable to set up Docker invocation")
+		return errors.Wrapf(err, "unable to set up %s invocation", t.cli.name)
 	}
 
 	// Force it to run detached.
@@ -280,8 +310,8 @@ func (t *transport) changeContainerStatus(stop bool) error {
 	// Create a copy of the current environment.
 	environment := os.Environ()
 
-	// Set Docker environment variables.
-	environment = setDockerVariables(environment, t.environment)
+	// Set container environment variables.
+	environment = setContainerVariables(environment, t.cli.environmentVariables, t.environment)
 
 	// Set the environment for the command.
 	dockerCommand.Env = environment
@@ -302,11 +332,11 @@ func (t *transport) Copy(localPath, remoteName string) error {
 	// they're okay with this.
 	if t.containerIsWindows {
 		if t.prompter == "" {
-			return errors.New("no prompter for Docker copy behavior confirmation")
+			return errors.Errorf("no prompter for %s copy behavior confirmation", t.cli.name)
 		}
 		for {
 			if response, err := prompt.Prompt(t.prompter, windowsContainerCopyNotification); err != nil {
-				return errors.Wrap(err, "unable to prompt for Docker copy behavior confirmation")
+				return errors.Wrapf(err, "unable to prompt for %s copy behavior confirmation", t.cli.name)
 			} else if response == "no" {
 				return errors.New("user cancelled copy operation")
 			} else if response == "yes" {
@@ -314,7 +344,7 @@ func (t *transport) Copy(localPath, remoteName string) error {
 			}
 		}
 		if err := t.changeContainerStatus(true); err != nil {
-			return errors.Wrap(err, "unable to stop Docker container")
+			return errors.Wrapf(err, "unable to stop %s container", t.cli.name)
 		}
 	}
 
@@ -340,9 +370,9 @@ func (t *transport) Copy(localPath, remoteName string) error {
 	}
 
 	// Create the command.
-	dockerCommand, err := docker.Command(context.Background(), "cp", localPath, containerPath)
+	dockerCommand, err := t.cli.command(context.Background(), t.environment, "cp", localPath, containerPath)
 	if err != nil {
-		return errors.Wrap(err, "unable to set up Docker invocation")
+		return errors.Wrapf(err, "unable to set up %s invocation", t.cli.name)
 	}
 
 	// Force it to run detached.
@@ -351,15 +381,15 @@ func (t *transport) Copy(localPath, remoteName string) error {
 	// Create a copy of the current environment.
 	environment := os.Environ()
 
-	// Set Docker environment variables.
-	environment = setDockerVariables(environment, t.environment)
+	// Set container environment variables.
+	environment = setContainerVariables(environment, t.cli.environmentVariables, t.environment)
 
 	// Set the environment for the command.
 	dockerCommand.Env = environment
 
 	// Run the operation.
 	if err := dockerCommand.Run(); err != nil {
-		return errors.Wrap(err, "unable to run Docker copy command")
+		return errors.Wrapf(err, "unable to run %s copy command", t.cli.name)
 	}
 
 	// The default ownership of files copied into containers is a bit uncertain.
@@ -392,7 +422,7 @@ func (t *transport) Copy(localPath, remoteName string) error {
 			remoteName,
 		)
 		if command, err := t.command(chownCommand, t.containerHomeDirectory, "root"); err != nil {
-			return errors.Wrap(err, "unable to set up Docker invocation")
+			return errors.Wrapf(err, "unable to set up %s invocation", t.cli.name)
 		} else if err := command.Run(); err != nil {
 			return errors.Wrap(err, "unable to set ownership of copied file")
 		}
@@ -402,7 +432,7 @@ func (t *transport) Copy(localPath, remoteName string) error {
 	// while we copy the agent.
 	if t.containerIsWindows {
 		if err := t.changeContainerStatus(false); err != nil {
-			return errors.Wrap(err, "unable to start Docker container")
+			return errors.Wrapf(err, "unable to start %s container", t.cli.name)
 		}
 	}
 
@@ -411,18 +441,24 @@ func (t *transport) Copy(localPath, remoteName string) error {
 }
 
 // Command implements the Command method of agent.Transport.
-func (t *transport) Command(command string) (*exec.Cmd, error) {
+func (t *transport) Command(command string) (process.Process, error) {
 	// Ensure that the container has been probed.
 	if err := t.probeContainer(); err != nil {
 		return nil, errors.Wrap(err, "unable to probe container")
 	}
 
 	// Generate the command.
-	return t.command(command, t.containerHomeDirectory, "")
+	dockerCommand, err := t.command(command, t.containerHomeDirectory, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Wrap the command.
+	return process.NewLocalProcess(dockerCommand), nil
 }
 
 // ClassifyError implements the ClassifyError method of agent.Transport.
-func (t *transport) ClassifyError(processState *os.ProcessState, errorOutput string) (bool, bool, error) {
+func (t *transport) ClassifyError(exitCode int, errorOutput string) (bool, bool, error) {
 	// Ensure that the container has been probed.
 	if err := t.probeContainer(); err != nil {
 		return false, false, errors.Wrap(err, "unable to probe container")
@@ -452,10 +488,14 @@ func (t *transport) ClassifyError(processState *os.ProcessState, errorOutput str
 	// Anyway, the exit code we need to look out for with both POSIX and Windows
 	// containers is 126, and since we know the remote platform already, we can
 	// return that information without needing to resort to the error string.
-	if !process.IsPOSIXShellInvalidCommand(processState) {
-		return false, false, errors.New("unknown process exit error")
+	//
+	// Some Docker-compatible interfaces (notably Podman) don't perform this
+	// aliasing and instead return 127 when a command isn't found, so we also
+	// watch for that code if the interface is known to return it.
+	if process.IsPOSIXShellInvalidCommandExitCode(exitCode) {
+		return true, t.containerIsWindows, nil
+	} else if t.cli.distinctCommandNotFoundExitCode && process.IsPOSIXShellCommandNotFoundExitCode(exitCode) {
+		return true, t.containerIsWindows, nil
 	}
-
-	// Success.
-	return true, t.containerIsWindows, nil
+	return false, false, errors.New("unknown process exit error")
 }
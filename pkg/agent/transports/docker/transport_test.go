@@ -1,3 +1,225 @@
 package docker
 
-// TODO: Implement.
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// stubScript is a stub container command line interface implementation for
+// POSIX systems. It records its arguments to a log file and emulates a rootless
+// POSIX container whose user and group are mapped from the host and thus have
+// no names.
+const stubScript = `#!/bin/sh
+echo "$CONTAINER_CONNECTION|$*" >> "$(dirname "$0")/log"
+case "$*" in
+  *" env") echo "PATH=/bin"; echo "HOME=/home/test" ;;
+  *" id -un"|*" id -gn") echo "id: cannot find name" >&2; exit 1 ;;
+  *" id -u"|*" id -g") echo "1000" ;;
+esac
+exit 0
+`
+
+// installStub installs a stub container command line interface implementation
+// with the specified name into a temporary directory and points the specified
+// search path environment variable at it. It returns the path to the stub's log
+// file and a cleanup function.
+func installStub(t *testing.T, name, searchPathVariable string) (string, func()) {
+	// Mark this as a helper function.
+	t.Helper()
+
+	// Skip on Windows, where we can't run shell scripts.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Create the stub.
+	directory, err := ioutil.TempDir("", "mutagen_container_cli")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	stub := filepath.Join(directory, name)
+	if err := ioutil.WriteFile(stub, []byte(stubScript), 0700); err != nil {
+		os.RemoveAll(directory)
+		t.Fatal("unable to write stub:", err)
+	}
+
+	// Point command lookup at the stub.
+	previous, previousSet := os.LookupEnv(searchPathVariable)
+	os.Setenv(searchPathVariable, directory)
+
+	// Done.
+	return filepath.Join(directory, "log"), func() {
+		if previousSet {
+			os.Setenv(searchPathVariable, previous)
+		} else {
+			os.Unsetenv(searchPathVariable)
+		}
+		os.RemoveAll(directory)
+	}
+}
+
+// installStubPodman installs a stub podman implementation and points
+// MUTAGEN_PODMAN_PATH at it. It returns the path to the stub's log file and a
+// cleanup function.
+func installStubPodman(t *testing.T) (string, func()) {
+	t.Helper()
+	return installStub(t, "podman", "MUTAGEN_PODMAN_PATH")
+}
+
+// readStubLog reads the invocations recorded in a stub log.
+func readStubLog(t *testing.T, log string) []string {
+	t.Helper()
+	contents, err := ioutil.ReadFile(log)
+	if err != nil {
+		t.Fatal("unable to read stub log:", err)
+	}
+	return strings.Split(strings.TrimSpace(string(contents)), "\n")
+}
+
+func TestPodmanTransportCopyWithUnnamedIdentity(t *testing.T) {
+	// Install the stub.
+	log, cleanup := installStubPodman(t)
+	defer cleanup()
+
+	// Create a file to copy.
+	source, err := ioutil.TempFile("", "mutagen_podman_copy")
+	if err != nil {
+		t.Fatal("unable to create source file:", err)
+	}
+	source.Close()
+	defer os.Remove(source.Name())
+
+	// Create the transport and perform the copy.
+	transport, err := NewPodmanTransport("container", "", map[string]string{
+		"CONTAINER_CONNECTION": "remote",
+	}, "")
+	if err != nil {
+		t.Fatal("unable to create transport:", err)
+	}
+	if err := transport.Copy(source.Name(), "mutagen-agent-123"); err != nil {
+		t.Fatal("copy failed:", err)
+	}
+
+	// Verify that the copy and ownership invocations used the frozen
+	// environment and numeric identifiers.
+	lines := readStubLog(t, log)
+	if len(lines) < 2 {
+		t.Fatal("too few invocations recorded")
+	}
+	expectedCopy := "remote|cp " + source.Name() + " container:/home/test/mutagen-agent-123"
+	if copy := lines[len(lines)-2]; copy != expectedCopy {
+		t.Error("copy invocation does not match expected:", copy, "!=", expectedCopy)
+	}
+	expectedChown := "remote|exec --interactive --user root --workdir /home/test container chown 1000:1000 mutagen-agent-123"
+	if chown := lines[len(lines)-1]; chown != expectedChown {
+		t.Error("ownership invocation does not match expected:", chown, "!=", expectedChown)
+	}
+}
+
+func TestPodmanTransportCopyWithSpecifiedUserAndUnnamedIdentity(t *testing.T) {
+	// Install the stub.
+	log, cleanup := installStubPodman(t)
+	defer cleanup()
+
+	// Create a file to copy.
+	source, err := ioutil.TempFile("", "mutagen_podman_copy")
+	if err != nil {
+		t.Fatal("unable to create source file:", err)
+	}
+	source.Close()
+	defer os.Remove(source.Name())
+
+	// Create the transport with a specified user and perform the copy. The
+	// stub can only report a numeric identifier for the user, which shouldn't
+	// be treated as a mismatch.
+	transport, err := NewPodmanTransport("container", "dev", nil, "")
+	if err != nil {
+		t.Fatal("unable to create transport:", err)
+	}
+	if err := transport.Copy(source.Name(), "mutagen-agent-123"); err != nil {
+		t.Fatal("copy failed:", err)
+	}
+
+	// Verify that ownership was set using the specified user.
+	lines := readStubLog(t, log)
+	expectedChown := "|exec --interactive --user root --workdir /home/test container chown dev:1000 mutagen-agent-123"
+	if chown := lines[len(lines)-1]; chown != expectedChown {
+		t.Error("ownership invocation does not match expected:", chown, "!=", expectedChown)
+	}
+}
+
+func TestDockerTransportUsesFrozenCommand(t *testing.T) {
+	// Install a Docker-compatible stub.
+	log, cleanup := installStub(t, "nerdctl", "MUTAGEN_DOCKER_PATH")
+	defer cleanup()
+
+	// Set a different command in the environment to ensure that the value
+	// locked in to the URL takes precedence.
+	previous, previousSet := os.LookupEnv(url.DockerCommandEnvironmentVariable)
+	os.Setenv(url.DockerCommandEnvironmentVariable, "invalid")
+	defer func() {
+		if previousSet {
+			os.Setenv(url.DockerCommandEnvironmentVariable, previous)
+		} else {
+			os.Unsetenv(url.DockerCommandEnvironmentVariable)
+		}
+	}()
+
+	// Create the transport and run a command.
+	transport, err := NewTransport("container", "", map[string]string{
+		url.DockerCommandEnvironmentVariable: "nerdctl",
+	}, "")
+	if err != nil {
+		t.Fatal("unable to create transport:", err)
+	}
+	command, err := transport.Command("true")
+	if err != nil {
+		t.Fatal("unable to create command:", err)
+	} else if err := command.Start(); err != nil {
+		t.Fatal("unable to start command:", err)
+	} else if err := command.Wait(); err != nil {
+		t.Fatal("command failed:", err)
+	}
+
+	// Verify that the stub was invoked.
+	lines := readStubLog(t, log)
+	if invocation := lines[len(lines)-1]; !strings.HasSuffix(invocation, "container true") {
+		t.Error("unexpected invocation:", invocation)
+	}
+}
+
+func TestTransportClassifyError(t *testing.T) {
+	// Install the stub.
+	_, cleanup := installStubPodman(t)
+	defer cleanup()
+
+	// Create a Podman transport and verify that both invalid command and
+	// command not found exit codes are classified.
+	podmanTransport, err := NewPodmanTransport("container", "", nil, "")
+	if err != nil {
+		t.Fatal("unable to create transport:", err)
+	}
+	for _, exitCode := range []int{126, 127} {
+		if tryInstall, _, err := podmanTransport.ClassifyError(exitCode, ""); err != nil {
+			t.Error("unable to classify exit code", exitCode, ":", err)
+		} else if !tryInstall {
+			t.Error("installation not recommended for exit code", exitCode)
+		}
+	}
+	if _, _, err := podmanTransport.ClassifyError(1, ""); err == nil {
+		t.Error("unknown exit code classified")
+	}
+
+	// Verify that Docker transports don't classify the command not found exit
+	// code. We use a probed transport to avoid needing a Docker stub.
+	dockerTransport := &transport{cli: dockerCLI, containerProbed: true}
+	if _, _, err := dockerTransport.ClassifyError(127, ""); err == nil {
+		t.Error("Docker transport classified command not found exit code")
+	}
+}
@@ -0,0 +1,2 @@
+// Package kubernetes provides the Kubernetes transport implementation.
+package kubernetes
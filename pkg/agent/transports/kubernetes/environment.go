@@ -0,0 +1,41 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// setKubernetesVariables sets all Kubernetes environment variables to their
+// values frozen into the URL. Any values for these variables in the base
+// environment are removed, so that variables which were unset (or empty) when
+// the URL was parsed are also unset for kubectl, and only non-empty frozen
+// values are added.
+func setKubernetesVariables(environment []string, variables map[string]string) []string {
+	// Create a filtered copy of the base environment that excludes Kubernetes
+	// environment variables.
+	result := make([]string, 0, len(environment)+len(url.KubernetesEnvironmentVariables))
+	for _, entry := range environment {
+		var kubernetesVariable bool
+		for _, variable := range url.KubernetesEnvironmentVariables {
+			if strings.HasPrefix(entry, variable+"=") {
+				kubernetesVariable = true
+				break
+			}
+		}
+		if !kubernetesVariable {
+			result = append(result, entry)
+		}
+	}
+
+	// Add any non-empty frozen values.
+	for _, variable := range url.KubernetesEnvironmentVariables {
+		if value := variables[variable]; value != "" {
+			result = append(result, fmt.Sprintf("%s=%s", variable, value))
+		}
+	}
+
+	// Done.
+	return result
+}
@@ -0,0 +1,41 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+func TestSetKubernetesVariables(t *testing.T) {
+	// Set variables on top of a base environment that already specifies a
+	// value.
+	environment := setKubernetesVariables(
+		[]string{"PATH=/bin", "KUBECONFIG=/base/config"},
+		map[string]string{url.KubernetesConfigEnvironmentVariable: "/frozen/config"},
+	)
+
+	// Ensure that the base value is replaced by the frozen value.
+	if len(environment) != 2 {
+		t.Fatal("environment has unexpected length:", len(environment))
+	} else if environment[0] != "PATH=/bin" {
+		t.Error("unrelated variable not preserved:", environment[0])
+	} else if environment[1] != "KUBECONFIG=/frozen/config" {
+		t.Error("frozen variable not set as expected:", environment[1])
+	}
+}
+
+func TestSetKubernetesVariablesEmpty(t *testing.T) {
+	// Set variables without any frozen values on top of a base environment
+	// that specifies a value.
+	environment := setKubernetesVariables(
+		[]string{"PATH=/bin", "KUBECONFIG=/base/config"},
+		map[string]string{url.KubernetesConfigEnvironmentVariable: ""},
+	)
+
+	// Ensure that the variable is neither inherited nor set to an empty value.
+	if len(environment) != 1 {
+		t.Fatal("environment has unexpected length:", len(environment))
+	} else if environment[0] != "PATH=/bin" {
+		t.Error("unrelated variable not preserved:", environment[0])
+	}
+}
@@ -0,0 +1,330 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/process"
+	"github.com/mutagen-io/mutagen/pkg/tools/kubectl"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// transport implements the agent.Transport interface using kubectl.
+type transport struct {
+	// namespace is the namespace containing the target pod. If empty, the
+	// default namespace for the current kubectl context is used.
+	namespace string
+	// pod is the target pod name.
+	pod string
+	// container is the target container within the pod. If empty, the pod's
+	// default container is used.
+	container string
+	// environment is the collection of environment variables that need to be
+	// set for the kubectl executable.
+	environment map[string]string
+	// containerProbed indicates whether or not container probing has occurred.
+	// If true, then either containerHomeDirectory will be non-empty or
+	// containerProbeError will be non-nil.
+	containerProbed bool
+	// containerIsWindows indicates whether or not the container is a Windows
+	// container. If not, it should be assumed that it is a POSIX (effectively
+	// Linux) container.
+	containerIsWindows bool
+	// containerHomeDirectory is the path to the default user's home directory
+	// within the container.
+	containerHomeDirectory string
+	// containerProbeError tracks any error that arose when probing the
+	// container.
+	containerProbeError error
+}
+
+// NewTransport creates a new Kubernetes transport using the specified
+// parameters. The pod specification should be of the form
+// [<namespace>/]<pod>[/<container>], as stored in Kubernetes URLs.
+func NewTransport(specification string, environment map[string]string) (agent.Transport, error) {
+	// Parse the pod specification.
+	namespace, pod, container, err := url.ParseKubernetesPodSpecification(specification)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid pod specification")
+	}
+
+	// Create the transport.
+	return &transport{
+		namespace:   namespace,
+		pod:         pod,
+		container:   container,
+		environment: environment,
+	}, nil
+}
+
+// kubectlCommand creates a kubectl command with the specified arguments,
+// configured to run detached and with the environment frozen into the URL.
+func (t *transport) kubectlCommand(arguments ...string) (*exec.Cmd, error) {
+	// Create the command.
+	kubectlCommand, err := kubectl.Command(context.Background(), arguments...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Force it to run detached.
+	kubectlCommand.SysProcAttr = process.DetachedProcessAttributes()
+
+	// Create a copy of the current environment.
+	environment := os.Environ()
+
+	// Set Kubernetes environment variables.
+	environment = setKubernetesVariables(environment, t.environment)
+
+	// Set the environment for the command.
+	kubectlCommand.Env = environment
+
+	// Done.
+	return kubectlCommand, nil
+}
+
+// command is an underlying command generation function that executes the
+// specified command and arguments inside the container. Unlike Docker, kubectl
+// doesn't support specifying a working directory, so any necessary directory
+// changes need to be handled by the command itself.
+func (t *transport) command(command ...string) (*exec.Cmd, error) {
+	// Tell kubectl that we want to execute a command in an interactive (i.e.
+	// with standard input attached) fashion.
+	kubectlArguments := []string{"exec", "--stdin"}
+
+	// If specified, tell kubectl which namespace contains the pod.
+	if t.namespace != "" {
+		kubectlArguments = append(kubectlArguments, "--namespace", t.namespace)
+	}
+
+	// Set the pod name.
+	kubectlArguments = append(kubectlArguments, t.pod)
+
+	// If specified, tell kubectl which container within the pod should be
+	// used.
+	if t.container != "" {
+		kubectlArguments = append(kubectlArguments, "--container", t.container)
+	}
+
+	// Add the command, separating it from kubectl's own arguments.
+	kubectlArguments = append(kubectlArguments, "--")
+	kubectlArguments = append(kubectlArguments, command...)
+
+	// Create the command.
+	return t.kubectlCommand(kubectlArguments...)
+}
+
+// probeContainer ensures that the containerIsWindows and containerHomeDirectory
+// fields are populated. It is idempotent. If probing previously failed, probing
+// will simply return an error indicating the previous failure.
+func (t *transport) probeContainer() error {
+	// Watch for previous errors.
+	if t.containerProbeError != nil {
+		return errors.Wrap(t.containerProbeError, "previous container probing failed")
+	}
+
+	// Check if we've already probed. If not, then we're going to probe, so mark
+	// it as complete (even if it isn't ultimately successful).
+	if t.containerProbed {
+		return nil
+	}
+	t.containerProbed = true
+
+	// Track what we've discovered so far in our probes.
+	var windows bool
+	var home string
+	var posixErr, windowsErr error
+
+	// Attempt to run env in the container to probe the user's environment on
+	// POSIX systems and identify the HOME environment variable value. If we
+	// detect a non-UTF-8 output or detect an empty home directory, we treat
+	// that as an error.
+	if command, err := t.command("env"); err != nil {
+		return errors.Wrap(err, "unable to set up kubectl invocation")
+	} else if envBytes, err := command.Output(); err == nil {
+		if !utf8.Valid(envBytes) {
+			t.containerProbeError = errors.New("non-UTF-8 POSIX environment")
+			return t.containerProbeError
+		} else if h, ok := process.FindEnvironmentVariable(string(envBytes), "HOME"); ok {
+			if h == "" {
+				t.containerProbeError = errors.New("empty POSIX home directory")
+				return t.containerProbeError
+			}
+			home = h
+		}
+	} else {
+		posixErr = err
+	}
+
+	// If we didn't find a POSIX home directory, attempt to a similar procedure
+	// on Windows to identify the USERPROFILE environment variable.
+	if home == "" {
+		if command, err := t.command("cmd", "/c", "set"); err != nil {
+			return errors.Wrap(err, "unable to set up kubectl invocation")
+		} else if envBytes, err := command.Output(); err == nil {
+			if !utf8.Valid(envBytes) {
+				t.containerProbeError = errors.New("non-UTF-8 Windows environment")
+				return t.containerProbeError
+			} else if h, ok := process.FindEnvironmentVariable(string(envBytes), "USERPROFILE"); ok {
+				if h == "" {
+					t.containerProbeError = errors.New("empty Windows home directory")
+					return t.containerProbeError
+				}
+				home = h
+				windows = true
+			}
+		} else {
+			windowsErr = err
+		}
+	}
+
+	// If both probing mechanisms have failed, then create a combined error
+	// message. Either probe may have succeeded without finding a home
+	// directory, so we have to account for nil errors.
+	if home == "" {
+		if posixErr == nil {
+			posixErr = errors.New("home directory not found")
+		}
+		if windowsErr == nil {
+			windowsErr = errors.New("home directory not found")
+		}
+		t.containerProbeError = errors.Errorf(
+			"container probing failed under POSIX hypothesis (%s) and Windows hypothesis (%s)",
+			posixErr.Error(),
+			windowsErr.Error(),
+		)
+		return t.containerProbeError
+	}
+
+	// Store values.
+	t.containerIsWindows = windows
+	t.containerHomeDirectory = home
+
+	// Success.
+	return nil
+}
+
+// Copy implements the Copy method of agent.Transport.
+func (t *transport) Copy(localPath, remoteName string) error {
+	// Ensure that the container has been probed.
+	if err := t.probeContainer(); err != nil {
+		return errors.Wrap(err, "unable to probe container")
+	}
+
+	// Compute the path inside the container. We don't bother trimming trailing
+	// slashes from the home directory, because both Windows and POSIX will work
+	// in their presence.
+	var containerPath string
+	if t.containerIsWindows {
+		containerPath = fmt.Sprintf("%s:%s\\%s", t.pod, t.containerHomeDirectory, remoteName)
+	} else {
+		containerPath = fmt.Sprintf("%s:%s/%s", t.pod, t.containerHomeDirectory, remoteName)
+	}
+
+	// Set up arguments. kubectl treats any source path containing a colon as a
+	// pod path, which would break for Windows paths with drive letters, so we
+	// specify the local file by its base name and run the command from within
+	// its parent directory.
+	kubectlArguments := []string{"cp"}
+	if t.namespace != "" {
+		kubectlArguments = append(kubectlArguments, "--namespace", t.namespace)
+	}
+	if t.container != "" {
+		kubectlArguments = append(kubectlArguments, "--container", t.container)
+	}
+	kubectlArguments = append(kubectlArguments, filepath.Base(localPath), containerPath)
+
+	// Create the command.
+	kubectlCommand, err := t.kubectlCommand(kubectlArguments...)
+	if err != nil {
+		return errors.Wrap(err, "unable to set up kubectl invocation")
+	}
+	kubectlCommand.Dir = filepath.Dir(localPath)
+
+	// Run the operation. Files copied using kubectl cp are extracted by the
+	// container's default user, so their ownership is already appropriate.
+	if err := kubectlCommand.Run(); err != nil {
+		return errors.Wrap(err, "unable to run kubectl copy command")
+	}
+
+	// Success.
+	return nil
+}
+
+// Command implements the Command method of agent.Transport.
+func (t *transport) Command(command string) (process.Process, error) {
+	// Ensure that the container has been probed.
+	if err := t.probeContainer(); err != nil {
+		return nil, errors.Wrap(err, "unable to probe container")
+	}
+
+	// Since kubectl doesn't support setting the working directory, we need to
+	// wrap the command in a shell invocation that changes to the home
+	// directory before running it. On POSIX, we pass the home directory and
+	// command as positional parameters to avoid any quoting issues. All
+	// agent.Transport interfaces only need to support commands that can be
+	// lexed by splitting on spaces, so a simple split suffices there. On
+	// Windows, cmd.exe parses its own command line (which the container runtime
+	// assembles from the arguments), so we pass the directory change and the
+	// command verbatim as a single command line rather than splitting them into
+	// arguments that would be re-quoted and re-joined. We don't quote the home
+	// directory because cd treats the remainder of its command as the path.
+	var arguments []string
+	if t.containerIsWindows {
+		arguments = []string{"cmd", "/c", windowsCommandLine(t.containerHomeDirectory, command)}
+	} else {
+		arguments = []string{"sh", "-c", `cd "$0" && exec "$@"`, t.containerHomeDirectory}
+		arguments = append(arguments, strings.Split(command, " ")...)
+	}
+
+	// Generate the command.
+	kubectlCommand, err := t.command(arguments...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wrap the command.
+	return process.NewLocalProcess(kubectlCommand), nil
+}
+
+// ClassifyError implements the ClassifyError method of agent.Transport.
+func (t *transport) ClassifyError(exitCode int, errorOutput string) (bool, bool, error) {
+	// Ensure that the container has been probed.
+	if err := t.probeContainer(); err != nil {
+		return false, false, errors.Wrap(err, "unable to probe container")
+	}
+
+	// kubectl exec faithfully propagates exit codes from the container, so we
+	// can use the same classification as SSH. The exception is that we already
+	// know the container platform, so we can report it directly rather than
+	// relying on the error type to infer it.
+	if process.IsPOSIXShellInvalidCommandExitCode(exitCode) {
+		return true, t.containerIsWindows, nil
+	} else if process.IsPOSIXShellCommandNotFoundExitCode(exitCode) {
+		return true, t.containerIsWindows, nil
+	} else if process.OutputIsWindowsInvalidCommand(errorOutput) {
+		// As with SSH, this usually indicates that we were trying to invoke
+		// the agent using POSIX syntax in a cmd.exe environment, so we don't
+		// request re-installation and instead let the dialer reconnect under
+		// the Windows hypothesis.
+		return false, true, nil
+	} else if process.OutputIsWindowsCommandNotFound(errorOutput) {
+		return true, true, nil
+	}
+
+	// Just bail if we weren't able to determine the nature of the error.
+	return false, false, errors.New("unknown error condition encountered")
+}
+
+// windowsCommandLine generates the cmd.exe command line that runs the specified
+// command from within the specified directory.
+func windowsCommandLine(directory, command string) string {
+	return fmt.Sprintf("cd /d %s && %s", directory, command)
+}
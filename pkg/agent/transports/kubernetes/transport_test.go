@@ -0,0 +1,148 @@
+package kubernetes
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/process"
+)
+
+// stubKubectlScript is a stub kubectl implementation for POSIX systems. It
+// records its arguments and working directory to a log file and emulates a
+// POSIX container environment.
+const stubKubectlScript = `#!/bin/sh
+echo "$PWD|$KUBECONFIG|$*" >> "$(dirname "$0")/log"
+case "$*" in
+  *" -- env") echo "PATH=/bin"; echo "HOME=/home/test" ;;
+esac
+exit 0
+`
+
+// installStubKubectl installs a stub kubectl implementation into a temporary
+// directory and points MUTAGEN_KUBECTL_PATH at it. It returns the path to the
+// stub's log file and a cleanup function.
+func installStubKubectl(t *testing.T) (string, func()) {
+	// Mark this as a helper function.
+	t.Helper()
+
+	// Skip on Windows, where we can't run shell scripts.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Create the stub.
+	directory, err := ioutil.TempDir("", "mutagen_kubectl")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	stub := filepath.Join(directory, "kubectl")
+	if err := ioutil.WriteFile(stub, []byte(stubKubectlScript), 0700); err != nil {
+		os.RemoveAll(directory)
+		t.Fatal("unable to write stub kubectl:", err)
+	}
+
+	// Point kubectl lookup at the stub.
+	previous, previousSet := os.LookupEnv("MUTAGEN_KUBECTL_PATH")
+	os.Setenv("MUTAGEN_KUBECTL_PATH", directory)
+
+	// Done.
+	return filepath.Join(directory, "log"), func() {
+		if previousSet {
+			os.Setenv("MUTAGEN_KUBECTL_PATH", previous)
+		} else {
+			os.Unsetenv("MUTAGEN_KUBECTL_PATH")
+		}
+		os.RemoveAll(directory)
+	}
+}
+
+func TestNewTransportInvalidSpecification(t *testing.T) {
+	if _, err := NewTransport("a/b/c/d", nil); err == nil {
+		t.Error("transport creation succeeded with invalid pod specification")
+	}
+}
+
+func TestTransportCommand(t *testing.T) {
+	// Install the stub.
+	_, cleanup := installStubKubectl(t)
+	defer cleanup()
+
+	// Create the transport.
+	transport, err := NewTransport("namespace/pod/container", nil)
+	if err != nil {
+		t.Fatal("unable to create transport:", err)
+	}
+
+	// Create a command and verify its arguments.
+	command, err := transport.Command(".mutagen/agents/mutagen-agent synchronizer")
+	if err != nil {
+		t.Fatal("unable to create command:", err)
+	}
+	expected := []string{
+		"exec", "--stdin", "--namespace", "namespace", "pod", "--container", "container", "--",
+		"sh", "-c", `cd "$0" && exec "$@"`, "/home/test",
+		".mutagen/agents/mutagen-agent", "synchronizer",
+	}
+	local, ok := command.(*process.LocalProcess)
+	if !ok {
+		t.Fatal("command is not a local process")
+	}
+	if arguments := local.Args[1:]; strings.Join(arguments, "\x00") != strings.Join(expected, "\x00") {
+		t.Error("command arguments do not match expected:", arguments)
+	}
+}
+
+func TestTransportCopy(t *testing.T) {
+	// Install the stub.
+	log, cleanup := installStubKubectl(t)
+	defer cleanup()
+
+	// Create a file to copy.
+	directory, err := ioutil.TempDir("", "mutagen_kubectl_copy")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+	if directory, err = filepath.EvalSymlinks(directory); err != nil {
+		t.Fatal("unable to resolve temporary directory:", err)
+	}
+	source := filepath.Join(directory, "agent")
+	if err := ioutil.WriteFile(source, []byte("agent"), 0600); err != nil {
+		t.Fatal("unable to create source file:", err)
+	}
+
+	// Create the transport and perform the copy.
+	transport, err := NewTransport("pod", map[string]string{"KUBECONFIG": "/frozen/config"})
+	if err != nil {
+		t.Fatal("unable to create transport:", err)
+	}
+	if err := transport.Copy(source, "mutagen-agent-123"); err != nil {
+		t.Fatal("copy failed:", err)
+	}
+
+	// Verify the recorded copy invocation.
+	contents, err := ioutil.ReadFile(log)
+	if err != nil {
+		t.Fatal("unable to read stub log:", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	expected := directory + "|/frozen/config|cp agent pod:/home/test/mutagen-agent-123"
+	if last := lines[len(lines)-1]; last != expected {
+		t.Error("copy invocation does not match expected:", last, "!=", expected)
+	}
+}
+
+func TestWindowsCommandLine(t *testing.T) {
+	commandLine := windowsCommandLine(
+		`C:\Users\Container User`,
+		`.mutagen\agents\mutagen-agent.exe  synchronizer`,
+	)
+	expected := `cd /d C:\Users\Container User && .mutagen\agents\mutagen-agent.exe  synchronizer`
+	if commandLine != expected {
+		t.Error("command line does not match expected:", commandLine, "!=", expected)
+	}
+}
@@ -0,0 +1,13 @@
+package ssh
+
+import (
+	"fmt"
+)
+
+// MultiplexingKey computes the key used to share agent connections (via
+// agent.DialMultiplexed) between endpoints targeting the same SSH remote.
+// Endpoints with the same user, host, port, jump host, and proxy command will
+// share a single SSH connection.
+func MultiplexingKey(user, host string, port uint16, jump, proxyCommand string) string {
+	return fmt.Sprintf("ssh:%s@%s:%d?jump=%q&proxy=%q", user, host, port, jump, proxyCommand)
+}
@@ -0,0 +1,23 @@
+package ssh
+
+import (
+	"testing"
+)
+
+func TestMultiplexingKey(t *testing.T) {
+	if MultiplexingKey("user", "host", 22, "", "") == MultiplexingKey("user", "host", 2222, "", "") {
+		t.Error("keys for different ports are equal")
+	}
+	if MultiplexingKey("user", "host", 22, "", "") == MultiplexingKey("other", "host", 22, "", "") {
+		t.Error("keys for different users are equal")
+	}
+	if MultiplexingKey("user", "host", 22, "", "") != MultiplexingKey("user", "host", 22, "", "") {
+		t.Error("keys for identical remotes differ")
+	}
+	if MultiplexingKey("user", "host", 22, "", "") == MultiplexingKey("user", "host", 22, "bastion", "") {
+		t.Error("keys for different jump hosts are equal")
+	}
+	if MultiplexingKey("user", "host", 22, "", "") == MultiplexingKey("user", "host", 22, "", "nc %h %p") {
+		t.Error("keys for different proxy commands are equal")
+	}
+}
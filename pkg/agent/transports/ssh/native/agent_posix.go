@@ -0,0 +1,18 @@
+// +build !windows
+
+package native
+
+import (
+	"net"
+	"os"
+)
+
+// defaultAgentSocket returns the default SSH agent socket path, if any.
+func defaultAgentSocket() string {
+	return os.Getenv("SSH_AUTH_SOCK")
+}
+
+// dialAgent connects to the SSH agent at the specified socket path.
+func dialAgent(path string) (net.Conn, error) {
+	return net.Dial("unix", path)
+}
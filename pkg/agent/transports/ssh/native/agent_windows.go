@@ -0,0 +1,33 @@
+package native
+
+import (
+	"net"
+	"os"
+	"time"
+
+	"github.com/Microsoft/go-winio"
+)
+
+const (
+	// windowsAgentPipe is the named pipe used by the Windows port of
+	// OpenSSH's agent.
+	windowsAgentPipe = `\\.\pipe\openssh-ssh-agent`
+	// agentDialTimeout is the timeout to use when connecting to the agent pipe.
+	agentDialTimeout = 5 * time.Second
+)
+
+// defaultAgentSocket returns the default SSH agent socket path, if any. On
+// Windows, this will be the SSH_AUTH_SOCK environment variable if set, and
+// otherwise the OpenSSH agent's named pipe.
+func defaultAgentSocket() string {
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		return socket
+	}
+	return windowsAgentPipe
+}
+
+// dialAgent connects to the SSH agent at the specified named pipe path.
+func dialAgent(path string) (net.Conn, error) {
+	timeout := agentDialTimeout
+	return winio.DialPipe(path, &timeout)
+}
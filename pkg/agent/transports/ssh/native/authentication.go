@@ -0,0 +1,243 @@
+package native
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+
+	"github.com/mutagen-io/mutagen/pkg/prompt"
+)
+
+// defaultIdentityFiles are the identity files (relative to the user's home
+// directory) that are tried if none are specified in the OpenSSH configuration.
+var defaultIdentityFiles = []string{
+	"~/.ssh/id_rsa",
+	"~/.ssh/id_ecdsa",
+	"~/.ssh/id_ed25519",
+}
+
+// credentials caches secrets obtained via prompting so that they don't need to
+// be re-entered for subsequent connections made by the same transport. It is
+// not safe for concurrent usage, but since agent transports don't need to
+// support concurrent usage, this is fine.
+type credentials struct {
+	// password is the password that was last used successfully.
+	password string
+	// passphrases maps identity file paths to their decryption passphrases.
+	passphrases map[string][]byte
+}
+
+// authenticator constructs authentication methods for a single connection
+// attempt.
+type authenticator struct {
+	// user is the remote user.
+	user string
+	// host is the remote host.
+	host string
+	// identityFiles are the identity file paths to try.
+	identityFiles []string
+	// agentSocket is the SSH agent socket path, if any.
+	agentSocket string
+	// prompter is the prompter identifier to use for prompting.
+	prompter string
+	// credentials are the cached credentials for the transport.
+	credentials *credentials
+	// triedCachedPassword indicates whether or not the cached password has
+	// been tried.
+	triedCachedPassword bool
+	// pendingPassword is the most recently entered password. It will be moved
+	// into the credential cache if authentication succeeds.
+	pendingPassword string
+	// agentConnection is the connection to the SSH agent, if any.
+	agentConnection interface{ Close() error }
+	// deadline is the handshake deadline for the connection, which is
+	// suspended while prompting. It may be nil.
+	deadline *handshakeDeadline
+}
+
+// prompt prompts the user with the specified message, suspending the handshake
+// deadline while waiting for a response.
+func (a *authenticator) prompt(message string) (string, error) {
+	a.deadline.suspend()
+	defer a.deadline.set()
+	return prompt.Prompt(a.prompter, message)
+}
+
+// methods creates the authentication methods for the connection attempt. As
+// with OpenSSH, public key authentication (using the agent and then identity
+// files) is tried first, followed by keyboard-interactive and password
+// authentication.
+func (a *authenticator) methods() []ssh.AuthMethod {
+	// Create the result.
+	var methods []ssh.AuthMethod
+
+	// Add public key authentication.
+	methods = append(methods, ssh.PublicKeysCallback(a.signers))
+
+	// If we have a prompter, then add interactive authentication methods.
+	if a.prompter != "" {
+		methods = append(methods,
+			ssh.RetryableAuthMethod(ssh.KeyboardInteractive(a.keyboardInteractive), 3),
+			ssh.RetryableAuthMethod(ssh.PasswordCallback(a.password), 3),
+		)
+	}
+
+	// Done.
+	return methods
+}
+
+// signers loads the signers available from the SSH agent and identity files.
+// Identity files that can't be loaded are skipped.
+func (a *authenticator) signers() ([]ssh.Signer, error) {
+	// Create the result.
+	var signers []ssh.Signer
+
+	// Add signers from the agent, if available. Agent failures are
+	// non-fatal, since we can still try other mechanisms.
+	if a.agentSocket != "" {
+		if connection, err := dialAgent(a.agentSocket); err == nil {
+			a.agentConnection = connection
+			if agentSigners, err := agent.NewClient(connection).Signers(); err == nil {
+				signers = append(signers, agentSigners...)
+			}
+		}
+	}
+
+	// Add signers from identity files.
+	for _, path := range a.identityFiles {
+		if signer, err := a.loadIdentity(path); err == nil && signer != nil {
+			signers = append(signers, signer)
+		}
+	}
+
+	// Done.
+	return signers, nil
+}
+
+// loadIdentity loads a signer from an identity file, prompting for a
+// passphrase if the key is encrypted. It returns a nil signer and nil error if
+// the file doesn't exist.
+func (a *authenticator) loadIdentity(path string) (ssh.Signer, error) {
+	// Read the key.
+	keyBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "unable to read identity file")
+	}
+
+	// Attempt to parse the key without a passphrase. If this fails for any
+	// reason other than the key being encrypted (in either the legacy PEM
+	// format or the OpenSSH format), then the key is unusable.
+	signer, err := ssh.ParsePrivateKey(keyBytes)
+	if err == nil {
+		return signer, nil
+	} else if _, ok := err.(*ssh.PassphraseMissingError); !ok {
+		return nil, err
+	}
+
+	// Check for a cached passphrase.
+	if passphrase, ok := a.credentials.passphrases[path]; ok {
+		return ssh.ParsePrivateKeyWithPassphrase(keyBytes, passphrase)
+	}
+
+	// Prompt for the passphrase.
+	if a.prompter == "" {
+		return nil, errors.New("encrypted identity file and no prompter available")
+	}
+	message := fmt.Sprintf("Enter passphrase for key '%s': ", path)
+	passphrase, err := a.prompt(message)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to prompt for passphrase")
+	}
+
+	// Decrypt the key and cache the passphrase if successful.
+	signer, err = ssh.ParsePrivateKeyWithPassphrase(keyBytes, []byte(passphrase))
+	if err != nil {
+		return nil, err
+	}
+	if a.credentials.passphrases == nil {
+		a.credentials.passphrases = make(map[string][]byte)
+	}
+	a.credentials.passphrases[path] = []byte(passphrase)
+
+	// Success.
+	return signer, nil
+}
+
+// password implements password authentication by prompting.
+func (a *authenticator) password() (string, error) {
+	// Try the cached password first.
+	if a.credentials.password != "" && !a.triedCachedPassword {
+		a.triedCachedPassword = true
+		a.pendingPassword = a.credentials.password
+		return a.credentials.password, nil
+	}
+
+	// Prompt for the password.
+	message := fmt.Sprintf("%s@%s's password: ", a.user, a.host)
+	password, err := a.prompt(message)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to prompt for password")
+	}
+	a.pendingPassword = password
+
+	// Done.
+	return password, nil
+}
+
+// keyboardInteractive implements keyboard-interactive authentication by
+// prompting for each question.
+func (a *authenticator) keyboardInteractive(name, instruction string, questions []string, _ []bool) ([]string, error) {
+	// Display any name or instruction information.
+	var header []string
+	if name != "" {
+		header = append(header, name)
+	}
+	if instruction != "" {
+		header = append(header, instruction)
+	}
+	if len(header) > 0 {
+		a.deadline.suspend()
+		err := prompt.Message(a.prompter, strings.Join(header, "\n"))
+		a.deadline.set()
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to message prompter")
+		}
+	}
+
+	// Prompt for each answer.
+	answers := make([]string, len(questions))
+	for i, question := range questions {
+		answer, err := a.prompt(question)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to prompt for response")
+		}
+		answers[i] = answer
+	}
+
+	// Done.
+	return answers, nil
+}
+
+// succeeded records that authentication succeeded, caching any password that
+// was used.
+func (a *authenticator) succeeded() {
+	if a.pendingPassword != "" {
+		a.credentials.password = a.pendingPassword
+	}
+}
+
+// close releases any resources held by the authenticator.
+func (a *authenticator) close() {
+	if a.agentConnection != nil {
+		a.agentConnection.Close()
+	}
+}
@@ -0,0 +1,256 @@
+package native
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// configuration represents the subset of OpenSSH client configuration options
+// that are supported by the native transport. Options that aren't understood
+// are ignored.
+type configuration struct {
+	// hostName is the value of the HostName option.
+	hostName string
+	// user is the value of the User option.
+	user string
+	// port is the value of the Port option.
+	port uint16
+	// identityFiles are the values of any IdentityFile options.
+	identityFiles []string
+	// identityAgent is the value of the IdentityAgent option.
+	identityAgent string
+	// userKnownHostsFiles are the values of the UserKnownHostsFile option.
+	userKnownHostsFiles []string
+	// strictHostKeyChecking is the (lowercase) value of the
+	// StrictHostKeyChecking option.
+	strictHostKeyChecking string
+}
+
+// splitConfigurationLine splits an OpenSSH configuration line into its keyword
+// and arguments. The keyword is returned in lowercase. Arguments may be
+// enclosed in double quotes to include whitespace.
+func splitConfigurationLine(line string) (string, []string, error) {
+	// Trim whitespace and ignore empty lines and comments.
+	line = strings.TrimSpace(line)
+	if line == "" || line[0] == '#' {
+		return "", nil, nil
+	}
+
+	// Split off the keyword, which is terminated by whitespace or an equals
+	// sign (optionally surrounded by whitespace).
+	end := strings.IndexAny(line, " \t=")
+	if end < 0 {
+		return strings.ToLower(line), nil, nil
+	}
+	keyword := strings.ToLower(line[:end])
+	rest := strings.TrimLeft(line[end:], " \t")
+	if strings.HasPrefix(rest, "=") {
+		rest = strings.TrimLeft(rest[1:], " \t")
+	}
+
+	// Split the arguments, respecting double quotes.
+	var arguments []string
+	var current strings.Builder
+	var inQuotes, inArgument bool
+	for _, r := range rest {
+		if r == '"' {
+			inQuotes = !inQuotes
+			inArgument = true
+		} else if (r == ' ' || r == '\t') && !inQuotes {
+			if inArgument {
+				arguments = append(arguments, current.String())
+				current.Reset()
+				inArgument = false
+			}
+		} else {
+			current.WriteRune(r)
+			inArgument = true
+		}
+	}
+	if inQuotes {
+		return "", nil, errors.New("unterminated quoted argument")
+	} else if inArgument {
+		arguments = append(arguments, current.String())
+	}
+
+	// Done.
+	return keyword, arguments, nil
+}
+
+// matchPattern performs OpenSSH-style pattern matching, where '*' matches zero
+// or more characters and '?' matches exactly one character.
+func matchPattern(pattern, value string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			// Collapse consecutive wildcards and check whether or not the
+			// remainder of the pattern matches at any position.
+			pattern = strings.TrimLeft(pattern, "*")
+			if pattern == "" {
+				return true
+			}
+			for i := 0; i <= len(value); i++ {
+				if matchPattern(pattern, value[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if value == "" {
+				return false
+			}
+		default:
+			if value == "" || value[0] != pattern[0] {
+				return false
+			}
+		}
+		pattern, value = pattern[1:], value[1:]
+	}
+	return value == ""
+}
+
+// matchHostPatterns determines whether or not a host matches a list of Host
+// patterns. A host matches if it matches at least one pattern and doesn't
+// match any negated patterns. Matching is case-insensitive.
+func matchHostPatterns(patterns []string, host string) bool {
+	host = strings.ToLower(host)
+	var matched bool
+	for _, pattern := range patterns {
+		negated := strings.HasPrefix(pattern, "!")
+		if negated {
+			pattern = pattern[1:]
+		}
+		if matchPattern(strings.ToLower(pattern), host) {
+			if negated {
+				return false
+			}
+			matched = true
+		}
+	}
+	return matched
+}
+
+// parseConfiguration parses OpenSSH client configuration for the specified
+// host. As with OpenSSH, the first value obtained for each option is used
+// (except for IdentityFile, which accumulates). Match and Include directives
+// aren't supported, so options inside Match blocks are ignored.
+func parseConfiguration(reader io.Reader, host string) (*configuration, error) {
+	// Create the result.
+	result := &configuration{}
+
+	// Track whether or not we're in a block that applies to the host. Options
+	// that appear before any Host or Match line apply to all hosts.
+	applies := true
+
+	// Process lines.
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		// Split the line.
+		keyword, arguments, err := splitConfigurationLine(scanner.Text())
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse line %d", line)
+		} else if keyword == "" {
+			continue
+		}
+
+		// Handle block delimiters.
+		if keyword == "host" {
+			applies = matchHostPatterns(arguments, host)
+			continue
+		} else if keyword == "match" {
+			applies = false
+			continue
+		} else if !applies {
+			continue
+		}
+
+		// All other options require an argument.
+		if len(arguments) == 0 {
+			return nil, errors.Errorf("missing argument on line %d", line)
+		}
+
+		// Handle options.
+		switch keyword {
+		case "hostname":
+			if result.hostName == "" {
+				result.hostName = arguments[0]
+			}
+		case "user":
+			if result.user == "" {
+				result.user = arguments[0]
+			}
+		case "port":
+			if result.port == 0 {
+				port, err := strconv.ParseUint(arguments[0], 10, 16)
+				if err != nil || port == 0 {
+					return nil, errors.Errorf("invalid port on line %d", line)
+				}
+				result.port = uint16(port)
+			}
+		case "identityfile":
+			result.identityFiles = append(result.identityFiles, arguments[0])
+		case "identityagent":
+			if result.identityAgent == "" {
+				result.identityAgent = arguments[0]
+			}
+		case "userknownhostsfile":
+			if result.userKnownHostsFiles == nil {
+				result.userKnownHostsFiles = arguments
+			}
+		case "stricthostkeychecking":
+			if result.strictHostKeyChecking == "" {
+				result.strictHostKeyChecking = strings.ToLower(arguments[0])
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "unable to read configuration")
+	}
+
+	// Success.
+	return result, nil
+}
+
+// loadConfiguration loads OpenSSH client configuration for the specified host
+// from the specified path. If the file doesn't exist, an empty configuration is
+// returned.
+func loadConfiguration(path, host string) (*configuration, error) {
+	// Open the file, treating non-existence as an empty configuration.
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &configuration{}, nil
+		}
+		return nil, errors.Wrap(err, "unable to open configuration file")
+	}
+	defer file.Close()
+
+	// Parse the configuration.
+	return parseConfiguration(file, host)
+}
+
+// expandPath performs tilde and token expansion on paths specified in OpenSSH
+// configuration. It supports the %d (local home directory), %h (remote host),
+// %r (remote user), %u (local user), and %% tokens.
+func expandPath(path, home, host, remoteUser, localUser string) string {
+	// Perform tilde expansion.
+	if path == "~" {
+		path = home
+	} else if strings.HasPrefix(path, "~/") {
+		path = home + path[1:]
+	}
+
+	// Perform token expansion.
+	return strings.NewReplacer(
+		"%d", home,
+		"%h", host,
+		"%r", remoteUser,
+		"%u", localUser,
+		"%%", "%",
+	).Replace(path)
+}
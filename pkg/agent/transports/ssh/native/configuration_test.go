@@ -0,0 +1,167 @@
+package native
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchPattern(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		pattern  string
+		value    string
+		expected bool
+	}{
+		{"", "", true},
+		{"", "a", false},
+		{"*", "", true},
+		{"*", "anything", true},
+		{"host", "host", true},
+		{"host", "hostname", false},
+		{"*.example.com", "dev.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"h?st", "host", true},
+		{"h?st", "hst", false},
+		{"a**b", "ab", true},
+		{"a*b*c", "axxbyyc", true},
+		{"a*b*c", "axxbyy", false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if result := matchPattern(testCase.pattern, testCase.value); result != testCase.expected {
+			t.Errorf("pattern \"%s\" matching \"%s\" returned %t, expected %t",
+				testCase.pattern, testCase.value, result, testCase.expected,
+			)
+		}
+	}
+}
+
+func TestMatchHostPatterns(t *testing.T) {
+	patterns := []string{"*.example.com", "!secret.example.com", "Bastion"}
+	if !matchHostPatterns(patterns, "dev.example.com") {
+		t.Error("expected wildcard match")
+	}
+	if matchHostPatterns(patterns, "secret.example.com") {
+		t.Error("expected negated pattern to prevent match")
+	}
+	if !matchHostPatterns(patterns, "bastion") {
+		t.Error("expected case-insensitive match")
+	}
+	if matchHostPatterns(patterns, "other") {
+		t.Error("unexpected match")
+	}
+}
+
+func TestSplitConfigurationLine(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		line      string
+		fail      bool
+		keyword   string
+		arguments []string
+	}{
+		{"", false, "", nil},
+		{"   # comment", false, "", nil},
+		{"HostName example.com", false, "hostname", []string{"example.com"}},
+		{"Port=2222", false, "port", []string{"2222"}},
+		{"Port = 2222", false, "port", []string{"2222"}},
+		{"Host a b\tc", false, "host", []string{"a", "b", "c"}},
+		{`IdentityFile "~/my keys/id_rsa"`, false, "identityfile", []string{"~/my keys/id_rsa"}},
+		{`IdentityFile "unterminated`, true, "", nil},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		keyword, arguments, err := splitConfigurationLine(testCase.line)
+		if err != nil {
+			if !testCase.fail {
+				t.Errorf("splitting \"%s\" failed unexpectedly: %v", testCase.line, err)
+			}
+			continue
+		} else if testCase.fail {
+			t.Errorf("splitting \"%s\" succeeded unexpectedly", testCase.line)
+			continue
+		}
+		if keyword != testCase.keyword {
+			t.Error("keyword mismatch:", keyword, "!=", testCase.keyword)
+		}
+		if strings.Join(arguments, "|") != strings.Join(testCase.arguments, "|") {
+			t.Error("arguments mismatch:", arguments, "!=", testCase.arguments)
+		}
+	}
+}
+
+// testConfiguration is an OpenSSH configuration used for testing.
+const testConfiguration = `
+# Global options.
+StrictHostKeyChecking accept-new
+
+Host dev
+    HostName dev.example.com
+    User developer
+    Port 2222
+    IdentityFile ~/.ssh/dev_key
+
+Match host dev
+    User ignored
+
+Host *
+    User fallback
+    IdentityFile ~/.ssh/id_rsa
+    UserKnownHostsFile ~/.ssh/known_hosts_a ~/.ssh/known_hosts_b
+`
+
+func TestParseConfiguration(t *testing.T) {
+	// Parse the configuration.
+	configuration, err := parseConfiguration(strings.NewReader(testConfiguration), "dev")
+	if err != nil {
+		t.Fatal("unable to parse configuration:", err)
+	}
+
+	// Verify values.
+	if configuration.hostName != "dev.example.com" {
+		t.Error("host name mismatch:", configuration.hostName)
+	}
+	if configuration.user != "developer" {
+		t.Error("user mismatch:", configuration.user)
+	}
+	if configuration.port != 2222 {
+		t.Error("port mismatch:", configuration.port)
+	}
+	if strings.Join(configuration.identityFiles, "|") != "~/.ssh/dev_key|~/.ssh/id_rsa" {
+		t.Error("identity files mismatch:", configuration.identityFiles)
+	}
+	if len(configuration.userKnownHostsFiles) != 2 {
+		t.Error("known hosts files mismatch:", configuration.userKnownHostsFiles)
+	}
+	if configuration.strictHostKeyChecking != strictHostKeyCheckingAcceptNew {
+		t.Error("host key checking mismatch:", configuration.strictHostKeyChecking)
+	}
+
+	// Parse the configuration for a different host and verify that only the
+	// wildcard block applies.
+	configuration, err = parseConfiguration(strings.NewReader(testConfiguration), "other")
+	if err != nil {
+		t.Fatal("unable to parse configuration:", err)
+	}
+	if configuration.hostName != "" {
+		t.Error("unexpected host name:", configuration.hostName)
+	}
+	if configuration.user != "fallback" {
+		t.Error("user mismatch:", configuration.user)
+	}
+}
+
+func TestParseConfigurationInvalidPort(t *testing.T) {
+	if _, err := parseConfiguration(strings.NewReader("Port nope"), "host"); err == nil {
+		t.Error("configuration with invalid port parsed successfully")
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	result := expandPath("~/.ssh/%r@%h-%u%%", "/home/local", "remote.example.com", "remote", "local")
+	if expected := "/home/local/.ssh/remote@remote.example.com-local%"; result != expected {
+		t.Error("expanded path mismatch:", result, "!=", expected)
+	}
+}
@@ -0,0 +1,6 @@
+// Package native provides an SSH transport implementation that uses an
+// in-process SSH client rather than the system's OpenSSH executables. It
+// supports a subset of OpenSSH client configuration, known_hosts verification,
+// SSH agents, and identity files, with all prompting performed via the
+// prompting infrastructure.
+package native
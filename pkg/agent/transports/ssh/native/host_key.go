@@ -0,0 +1,147 @@
+package native
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/mutagen-io/mutagen/pkg/prompt"
+)
+
+const (
+	// strictHostKeyCheckingYes indicates that unknown host keys should be
+	// rejected.
+	strictHostKeyCheckingYes = "yes"
+	// strictHostKeyCheckingNo indicates that unknown host keys should be
+	// accepted and recorded without prompting.
+	strictHostKeyCheckingNo = "no"
+	// strictHostKeyCheckingAcceptNew is equivalent to strictHostKeyCheckingNo
+	// for our purposes, since we never accept changed host keys.
+	strictHostKeyCheckingAcceptNew = "accept-new"
+)
+
+// unknownHostKeyPromptFormat is the format string for prompts about unknown
+// host keys. It matches OpenSSH's prompt so that the prompting infrastructure
+// recognizes it as a yes/no prompt.
+const unknownHostKeyPromptFormat = `The authenticity of host '%s (%s)' can't be established.
+%s key fingerprint is %s.
+Are you sure you want to continue connecting (yes/no)? `
+
+// hostKeyCallback creates a host key callback that verifies host keys against
+// the specified known_hosts files. Keys for unknown hosts are handled according
+// to the StrictHostKeyChecking mode, which may involve prompting the user, and
+// accepted keys are recorded in the first known_hosts file. Mismatched and
+// revoked keys are always rejected.
+func hostKeyCallback(knownHostsFiles []string, strictHostKeyChecking, prompter string) (ssh.HostKeyCallback, error) {
+	// Ensure that we have at least one known_hosts file to record keys.
+	if len(knownHostsFiles) == 0 {
+		return nil, errors.New("no known hosts files specified")
+	}
+
+	// Filter out known_hosts files that don't exist, since the knownhosts
+	// package requires that all files exist.
+	var existing []string
+	for _, path := range knownHostsFiles {
+		if _, err := os.Stat(path); err == nil {
+			existing = append(existing, path)
+		} else if !os.IsNotExist(err) {
+			return nil, errors.Wrap(err, "unable to probe known hosts file")
+		}
+	}
+
+	// Create the base callback. If there are no known_hosts files, then every
+	// host is unknown.
+	var base ssh.HostKeyCallback
+	if len(existing) > 0 {
+		var err error
+		if base, err = knownhosts.New(existing...); err != nil {
+			return nil, errors.Wrap(err, "unable to load known hosts")
+		}
+	} else {
+		base = func(_ string, _ net.Addr, _ ssh.PublicKey) error {
+			return &knownhosts.KeyError{}
+		}
+	}
+
+	// Create the callback.
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		// Check the key against known keys. If it's known, then we're done.
+		err := base(hostname, remote, key)
+		if err == nil {
+			return nil
+		}
+
+		// If this isn't a key error for an unknown host, then reject the key.
+		// Revocation errors and key mismatches fall into this category.
+		if keyErr, ok := err.(*knownhosts.KeyError); !ok {
+			return err
+		} else if len(keyErr.Want) > 0 {
+			return errors.Errorf(
+				"host key for %s has changed (received %s key with fingerprint %s)",
+				hostname, key.Type(), ssh.FingerprintSHA256(key),
+			)
+		}
+
+		// Handle the unknown host based on the checking mode.
+		switch strictHostKeyChecking {
+		case strictHostKeyCheckingYes:
+			return errors.Errorf("no host key is known for %s", hostname)
+		case strictHostKeyCheckingNo, strictHostKeyCheckingAcceptNew:
+		default:
+			if prompter == "" {
+				return errors.Errorf("no host key is known for %s and no prompter is available", hostname)
+			}
+			message := fmt.Sprintf(unknownHostKeyPromptFormat,
+				hostname, remote, key.Type(), ssh.FingerprintSHA256(key),
+			)
+			for {
+				if response, err := prompt.Prompt(prompter, message); err != nil {
+					return errors.Wrap(err, "unable to prompt for host key verification")
+				} else if response == "no" {
+					return errors.New("host key verification failed")
+				} else if response == "yes" {
+					break
+				}
+			}
+		}
+
+		// Record the key in the first known_hosts file.
+		if err := recordHostKey(knownHostsFiles[0], hostname, key); err != nil {
+			return errors.Wrap(err, "unable to record host key")
+		}
+
+		// Success.
+		return nil
+	}, nil
+}
+
+// recordHostKey appends a host key to a known_hosts file, creating the file
+// (and its parent directory) if necessary.
+func recordHostKey(path, hostname string, key ssh.PublicKey) error {
+	// Ensure that the parent directory exists.
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Wrap(err, "unable to create known hosts directory")
+	}
+
+	// Open the file for appending.
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Wrap(err, "unable to open known hosts file")
+	}
+
+	// Write the entry.
+	line := knownhosts.Line([]string{knownhosts.Normalize(hostname)}, key) + "\n"
+	if _, err := file.Write([]byte(line)); err != nil {
+		file.Close()
+		return errors.Wrap(err, "unable to write known hosts entry")
+	}
+
+	// Close the file.
+	return errors.Wrap(file.Close(), "unable to close known hosts file")
+}
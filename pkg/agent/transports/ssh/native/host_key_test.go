@@ -0,0 +1,187 @@
+package native
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/mutagen-io/mutagen/pkg/prompt"
+)
+
+const (
+	// testHostKeyHostname is the hostname used for host key tests.
+	testHostKeyHostname = "example.com:22"
+)
+
+// testHostKeyRemote is the remote address used for host key tests.
+var testHostKeyRemote = &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 22}
+
+// hostKeyTestPrompter is a prompter that gives a fixed response to prompts and
+// counts the prompts that it receives.
+type hostKeyTestPrompter struct {
+	// response is the response to give.
+	response string
+	// prompts is the number of prompts received.
+	prompts int
+}
+
+// Message implements prompt.Prompter.Message.
+func (p *hostKeyTestPrompter) Message(_ string) error {
+	return nil
+}
+
+// Prompt implements prompt.Prompter.Prompt.
+func (p *hostKeyTestPrompter) Prompt(_ string) (string, error) {
+	p.prompts++
+	return p.response, nil
+}
+
+// newTestHostKey generates a new host public key.
+func newTestHostKey(t *testing.T) ssh.PublicKey {
+	// Mark this as a helper function.
+	t.Helper()
+
+	// Generate the key.
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("unable to generate key:", err)
+	}
+	key, err := ssh.NewPublicKey(&private.PublicKey)
+	if err != nil {
+		t.Fatal("unable to convert key:", err)
+	}
+
+	// Done.
+	return key
+}
+
+// writeKnownHosts writes a known_hosts file containing an entry for the test
+// hostname with the specified key.
+func writeKnownHosts(t *testing.T, path string, key ssh.PublicKey) {
+	// Mark this as a helper function.
+	t.Helper()
+
+	// Write the file.
+	line := knownhosts.Line([]string{knownhosts.Normalize(testHostKeyHostname)}, key) + "\n"
+	if err := ioutil.WriteFile(path, []byte(line), 0600); err != nil {
+		t.Fatal("unable to write known hosts file:", err)
+	}
+}
+
+func TestHostKeyCallbackNoKnownHostsFiles(t *testing.T) {
+	if _, err := hostKeyCallback(nil, "", ""); err == nil {
+		t.Error("host key callback created without known hosts files")
+	}
+}
+
+func TestHostKeyCallback(t *testing.T) {
+	// Define test cases. The known key is present in the known_hosts file
+	// indicated by knownIn (0 for none, 1 for the first file, and 2 for the
+	// second file), while the presented key is either the known key or a
+	// different key, based on mismatch.
+	testCases := []struct {
+		knownIn               int
+		mismatch              bool
+		strictHostKeyChecking string
+		promptResponse        string
+		disablePrompter       bool
+		expectFailure         bool
+		expectPrompt          bool
+		expectRecorded        bool
+	}{
+		{1, false, "", "", false, false, false, false},
+		{2, false, strictHostKeyCheckingYes, "", false, false, false, false},
+		{1, true, "", "yes", false, true, false, false},
+		{2, true, strictHostKeyCheckingNo, "", false, true, false, false},
+		{0, false, strictHostKeyCheckingYes, "", false, true, false, false},
+		{0, false, strictHostKeyCheckingNo, "", false, false, false, true},
+		{0, false, strictHostKeyCheckingAcceptNew, "", false, false, false, true},
+		{0, false, "ask", "yes", false, false, true, true},
+		{0, false, "", "yes", false, false, true, true},
+		{0, false, "", "no", false, true, true, false},
+		{0, false, "", "", true, true, false, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create a temporary directory for known_hosts files. We place the
+		// first file in a subdirectory that doesn't exist unless the file is
+		// written so that we verify directory creation when recording keys.
+		directory, err := ioutil.TempDir("", "mutagen_ssh_known_hosts")
+		if err != nil {
+			t.Fatal("unable to create temporary directory:", err)
+		}
+		knownHostsFiles := []string{
+			filepath.Join(directory, "user", "known_hosts"),
+			filepath.Join(directory, "global_known_hosts"),
+		}
+
+		// Generate keys and write the known key.
+		known := newTestHostKey(t)
+		presented := known
+		if testCase.mismatch {
+			presented = newTestHostKey(t)
+		}
+		if testCase.knownIn > 0 {
+			path := knownHostsFiles[testCase.knownIn-1]
+			if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+				t.Fatal("unable to create known hosts directory:", err)
+			}
+			writeKnownHosts(t, path, known)
+		}
+
+		// Register the prompter, if any.
+		prompter := &hostKeyTestPrompter{response: testCase.promptResponse}
+		var identifier string
+		if !testCase.disablePrompter {
+			if identifier, err = prompt.RegisterPrompter(prompter); err != nil {
+				t.Fatal("unable to register prompter:", err)
+			}
+		}
+
+		// Create the callback and verify the presented key.
+		callback, err := hostKeyCallback(knownHostsFiles, testCase.strictHostKeyChecking, identifier)
+		if err != nil {
+			t.Errorf("test index %d: unable to create host key callback: %v", i, err)
+		} else {
+			err = callback(testHostKeyHostname, testHostKeyRemote, presented)
+			if err != nil && !testCase.expectFailure {
+				t.Errorf("test index %d: host key incorrectly rejected: %v", i, err)
+			} else if err == nil && testCase.expectFailure {
+				t.Errorf("test index %d: host key incorrectly accepted", i)
+			}
+		}
+
+		// Verify prompting behavior.
+		if prompted := prompter.prompts > 0; prompted != testCase.expectPrompt {
+			t.Errorf("test index %d: prompting behavior mismatch: %t != %t", i, prompted, testCase.expectPrompt)
+		}
+
+		// Verify that the key was recorded (or not) by checking whether or not
+		// a strict callback accepts it.
+		if testCase.knownIn == 0 {
+			if strict, err := hostKeyCallback(knownHostsFiles, strictHostKeyCheckingYes, ""); err != nil {
+				t.Errorf("test index %d: unable to create strict host key callback: %v", i, err)
+			} else {
+				recorded := strict(testHostKeyHostname, testHostKeyRemote, presented) == nil
+				if recorded != testCase.expectRecorded {
+					t.Errorf("test index %d: recording behavior mismatch: %t != %t", i, recorded, testCase.expectRecorded)
+				}
+			}
+		}
+
+		// Clean up.
+		if identifier != "" {
+			prompt.UnregisterPrompter(identifier)
+		}
+		os.RemoveAll(directory)
+	}
+}
@@ -0,0 +1,114 @@
+package native
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// sessionProcess implements process.Process using an SSH session. It owns the
+// underlying SSH client, which is closed once the process exits or is killed.
+type sessionProcess struct {
+	// client is the underlying SSH client.
+	client *ssh.Client
+	// session is the SSH session.
+	session *ssh.Session
+	// command is the command to execute.
+	command string
+	// started indicates whether or not the process has been started.
+	started bool
+	// exitCode is the process exit code. It is -1 until the process exits.
+	exitCode int
+}
+
+// newSessionProcess creates a new session process for the specified command.
+func newSessionProcess(client *ssh.Client, command string) (*sessionProcess, error) {
+	// Create the session.
+	session, err := client.NewSession()
+	if err != nil {
+		client.Close()
+		return nil, errors.Wrap(err, "unable to create SSH session")
+	}
+
+	// Attempt to coax the server into using a C (ASCII) locale, as the OpenSSH
+	// transport does. Many servers won't accept environment variables, so we
+	// ignore any failure here.
+	session.Setenv("LC_ALL", "C")
+
+	// Done.
+	return &sessionProcess{
+		client:   client,
+		session:  session,
+		command:  command,
+		exitCode: -1,
+	}, nil
+}
+
+// StdinPipe implements process.Process.StdinPipe.
+func (p *sessionProcess) StdinPipe() (io.WriteCloser, error) {
+	return p.session.StdinPipe()
+}
+
+// StdoutPipe implements process.Process.StdoutPipe.
+func (p *sessionProcess) StdoutPipe() (io.Reader, error) {
+	return p.session.StdoutPipe()
+}
+
+// SetStderr implements process.Process.SetStderr.
+func (p *sessionProcess) SetStderr(stderr io.Writer) {
+	p.session.Stderr = stderr
+}
+
+// Start implements process.Process.Start.
+func (p *sessionProcess) Start() error {
+	if err := p.session.Start(p.command); err != nil {
+		p.client.Close()
+		return err
+	}
+	p.started = true
+	return nil
+}
+
+// Wait implements process.Process.Wait.
+func (p *sessionProcess) Wait() error {
+	// Ensure that the process was started.
+	if !p.started {
+		return errors.New("process not started")
+	}
+
+	// Wait for the session to complete and close the client.
+	err := p.session.Wait()
+	p.client.Close()
+
+	// Record the exit code.
+	if err == nil {
+		p.exitCode = 0
+	} else if exitErr, ok := err.(*ssh.ExitError); ok {
+		p.exitCode = exitErr.ExitStatus()
+	}
+
+	// Done.
+	return err
+}
+
+// Kill implements process.Process.Kill.
+func (p *sessionProcess) Kill() error {
+	// Ensure that the process was started.
+	if !p.started {
+		return errors.New("process not started")
+	}
+
+	// Request termination of the remote process. Many servers don't support
+	// signals, so we ignore any error here and instead rely on closing the
+	// connection, which will cause the remote process to receive SIGHUP and
+	// will unblock any pending wait operation.
+	p.session.Signal(ssh.SIGKILL)
+	return p.client.Close()
+}
+
+// ExitCode implements process.Process.ExitCode.
+func (p *sessionProcess) ExitCode() int {
+	return p.exitCode
+}
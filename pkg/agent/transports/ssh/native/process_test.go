@@ -0,0 +1,3 @@
+package native
+
+// TODO: Implement.
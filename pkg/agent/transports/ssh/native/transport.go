@@ -0,0 +1,348 @@
+package native
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/process"
+)
+
+const (
+	// connectTimeout is the timeout to use when establishing connections. It
+	// matches the timeout used by the OpenSSH transport.
+	connectTimeout = 5 * time.Second
+	// handshakeTimeout is the timeout to use for the SSH handshake (including
+	// authentication), excluding any time spent waiting on user interaction.
+	handshakeTimeout = 15 * time.Second
+	// defaultPort is the default SSH port.
+	defaultPort = 22
+)
+
+// transport implements the agent.Transport interface using an in-process SSH
+// client.
+type transport struct {
+	// user is the SSH user under which agents should be invoked. If empty, the
+	// user will be determined using OpenSSH configuration or the local user.
+	user string
+	// host is the target host, which may be an alias defined in OpenSSH
+	// configuration.
+	host string
+	// port is the target port. If 0, the port will be determined using
+	// OpenSSH configuration or the default SSH port.
+	port uint16
+	// prompter is the prompter identifier to use for prompting.
+	prompter string
+	// credentials are the cached credentials for the transport.
+	credentials *credentials
+}
+
+// NewTransport creates a new native SSH transport using the specified
+// parameters.
+func NewTransport(user, host string, port uint16, prompter string) (agent.Transport, error) {
+	return &transport{
+		user:        user,
+		host:        host,
+		port:        port,
+		prompter:    prompter,
+		credentials: &credentials{},
+	}, nil
+}
+
+// localUser returns the name of the local user. On Windows, any domain prefix
+// is removed.
+func localUser() (string, error) {
+	current, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	name := current.Username
+	if index := strings.LastIndex(name, "\\"); index >= 0 {
+		name = name[index+1:]
+	}
+	return name, nil
+}
+
+// dial establishes a new SSH client connection to the target.
+func (t *transport) dial() (*ssh.Client, error) {
+	// Determine the local user and home directory.
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to determine home directory")
+	}
+	local, err := localUser()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to determine local user")
+	}
+
+	// Load OpenSSH configuration for the host.
+	configurationPath := filepath.Join(home, ".ssh", "config")
+	configuration, err := loadConfiguration(configurationPath, t.host)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load SSH configuration")
+	}
+
+	// Compute connection parameters, with explicitly specified values taking
+	// precedence over configuration values.
+	hostName := t.host
+	if configuration.hostName != "" {
+		hostName = strings.ReplaceAll(configuration.hostName, "%h", t.host)
+	}
+	remoteUser := t.user
+	if remoteUser == "" {
+		remoteUser = configuration.user
+	}
+	if remoteUser == "" {
+		remoteUser = local
+	}
+	port := t.port
+	if port == 0 {
+		port = configuration.port
+	}
+	if port == 0 {
+		port = defaultPort
+	}
+	address := net.JoinHostPort(hostName, strconv.Itoa(int(port)))
+
+	// Compute known_hosts files.
+	knownHostsFiles := configuration.userKnownHostsFiles
+	if len(knownHostsFiles) == 0 {
+		knownHostsFiles = []string{"~/.ssh/known_hosts"}
+	}
+	for i, path := range knownHostsFiles {
+		knownHostsFiles[i] = expandPath(path, home, hostName, remoteUser, local)
+	}
+
+	// Compute identity files.
+	identityFiles := configuration.identityFiles
+	if len(identityFiles) == 0 {
+		identityFiles = defaultIdentityFiles
+	}
+	expandedIdentityFiles := make([]string, len(identityFiles))
+	for i, path := range identityFiles {
+		expandedIdentityFiles[i] = expandPath(path, home, hostName, remoteUser, local)
+	}
+
+	// Compute the agent socket.
+	agentSocket := defaultAgentSocket()
+	if configuration.identityAgent == "none" {
+		agentSocket = ""
+	} else if configuration.identityAgent == "SSH_AUTH_SOCK" {
+		agentSocket = os.Getenv("SSH_AUTH_SOCK")
+	} else if configuration.identityAgent != "" {
+		agentSocket = expandPath(configuration.identityAgent, home, hostName, remoteUser, local)
+	}
+
+	// Create the host key callback.
+	hostKeyCallback, err := hostKeyCallback(knownHostsFiles, configuration.strictHostKeyChecking, t.prompter)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create host key verifier")
+	}
+
+	// Establish the network connection.
+	connection, err := net.DialTimeout("tcp", address, connectTimeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to connect to host")
+	}
+
+	// Apply a deadline to the handshake. The deadline is suspended while
+	// prompting (for host key verification or authentication) so that it
+	// doesn't limit user interaction.
+	deadline := &handshakeDeadline{connection}
+	deadline.set()
+	verifyHostKey := func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		deadline.suspend()
+		defer deadline.set()
+		return hostKeyCallback(hostname, remote, key)
+	}
+
+	// Create the authenticator and defer its cleanup.
+	authenticator := &authenticator{
+		user:          remoteUser,
+		host:          hostName,
+		identityFiles: expandedIdentityFiles,
+		agentSocket:   agentSocket,
+		prompter:      t.prompter,
+		credentials:   t.credentials,
+		deadline:      deadline,
+	}
+	defer authenticator.close()
+
+	// Perform the SSH handshake and clear the deadline once it's complete.
+	clientConnection, channels, requests, err := ssh.NewClientConn(connection, address, &ssh.ClientConfig{
+		User:            remoteUser,
+		Auth:            authenticator.methods(),
+		HostKeyCallback: verifyHostKey,
+	})
+	if err != nil {
+		connection.Close()
+		return nil, errors.Wrap(err, "unable to establish SSH connection")
+	}
+	deadline.suspend()
+
+	// Record successful authentication.
+	authenticator.succeeded()
+
+	// Success.
+	return ssh.NewClient(clientConnection, channels, requests), nil
+}
+
+// handshakeDeadline manages the deadline applied to a connection during the
+// SSH handshake. Its methods are safe to call on a nil handshakeDeadline.
+type handshakeDeadline struct {
+	// connection is the underlying network connection.
+	connection net.Conn
+}
+
+// set (re-)arms the deadline for the full handshake timeout.
+func (d *handshakeDeadline) set() {
+	if d != nil {
+		d.connection.SetDeadline(time.Now().Add(handshakeTimeout))
+	}
+}
+
+// suspend clears the deadline.
+func (d *handshakeDeadline) suspend() {
+	if d != nil {
+		d.connection.SetDeadline(time.Time{})
+	}
+}
+
+// readAcknowledgement reads an SCP protocol acknowledgement.
+func readAcknowledgement(reader *bufio.Reader) error {
+	// Read the response code.
+	code, err := reader.ReadByte()
+	if err != nil {
+		return errors.Wrap(err, "unable to read response")
+	} else if code == 0 {
+		return nil
+	}
+
+	// Read the error message.
+	message, _ := reader.ReadString('\n')
+	return errors.Errorf("remote error: %s", strings.TrimSpace(message))
+}
+
+// Copy implements the Copy method of agent.Transport. It streams the file using
+// the SCP protocol over an SSH session.
+func (t *transport) Copy(localPath, remoteName string) error {
+	// Open the local file and determine its size and permissions.
+	file, err := os.Open(localPath)
+	if err != nil {
+		return errors.Wrap(err, "unable to open source file")
+	}
+	defer file.Close()
+	metadata, err := file.Stat()
+	if err != nil {
+		return errors.Wrap(err, "unable to query source file metadata")
+	}
+
+	// Connect to the remote and create a session process. The process takes
+	// ownership of the client.
+	client, err := t.dial()
+	if err != nil {
+		return err
+	}
+	copier, err := newSessionProcess(client, fmt.Sprintf("scp -t %s", remoteName))
+	if err != nil {
+		return err
+	}
+
+	// Set up I/O.
+	input, err := copier.StdinPipe()
+	if err != nil {
+		client.Close()
+		return errors.Wrap(err, "unable to redirect copy input")
+	}
+	outputPipe, err := copier.StdoutPipe()
+	if err != nil {
+		client.Close()
+		return errors.Wrap(err, "unable to redirect copy output")
+	}
+	output := bufio.NewReader(outputPipe)
+
+	// Start the copy process.
+	if err := copier.Start(); err != nil {
+		return errors.Wrap(err, "unable to start copy process")
+	}
+
+	// Perform the transfer.
+	transfer := func() error {
+		if err := readAcknowledgement(output); err != nil {
+			return errors.Wrap(err, "copy process not ready")
+		}
+		header := fmt.Sprintf("C%04o %d %s\n", metadata.Mode().Perm(), metadata.Size(), remoteName)
+		if _, err := io.WriteString(input, header); err != nil {
+			return errors.Wrap(err, "unable to send file header")
+		} else if err := readAcknowledgement(output); err != nil {
+			return errors.Wrap(err, "file header rejected")
+		} else if _, err := io.CopyN(input, file, metadata.Size()); err != nil {
+			return errors.Wrap(err, "unable to send file contents")
+		} else if _, err := input.Write([]byte{0}); err != nil {
+			return errors.Wrap(err, "unable to send file terminator")
+		} else if err := readAcknowledgement(output); err != nil {
+			return errors.Wrap(err, "file transfer failed")
+		}
+		return nil
+	}
+	if err := transfer(); err != nil {
+		copier.Kill()
+		copier.Wait()
+		return err
+	}
+
+	// Signal the end of the transfer and wait for the process to exit.
+	input.Close()
+	if err := copier.Wait(); err != nil {
+		return errors.Wrap(err, "copy process failed")
+	}
+
+	// Success.
+	return nil
+}
+
+// Command implements the Command method of agent.Transport.
+func (t *transport) Command(command string) (process.Process, error) {
+	// Connect to the remote.
+	client, err := t.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the process, which will take ownership of the client. The command
+	// will be executed in the user's home directory, which is the default
+	// working directory for SSH sessions.
+	return newSessionProcess(client, command)
+}
+
+// ClassifyError implements the ClassifyError method of agent.Transport.
+func (t *transport) ClassifyError(exitCode int, errorOutput string) (bool, bool, error) {
+	// SSH faithfully returns exit codes and error output, so we can use the
+	// same classification logic as the OpenSSH transport.
+	if process.IsPOSIXShellInvalidCommandExitCode(exitCode) {
+		return true, false, nil
+	} else if process.IsPOSIXShellCommandNotFoundExitCode(exitCode) {
+		return true, false, nil
+	} else if process.OutputIsWindowsInvalidCommand(errorOutput) {
+		// See the OpenSSH transport for an explanation of why we don't request
+		// re-installation in this case.
+		return false, true, nil
+	} else if process.OutputIsWindowsCommandNotFound(errorOutput) {
+		return true, true, nil
+	}
+
+	// Just bail if we weren't able to determine the nature of the error.
+	return false, false, errors.New("unknown error condition encountered")
+}
@@ -0,0 +1,487 @@
+package native
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/mutagen-io/mutagen/pkg/prompt"
+)
+
+const (
+	// testUser is the username accepted by the test server.
+	testUser = "tester"
+	// testPassword is the password accepted by the test server.
+	testPassword = "secret"
+	// testHost is the host alias used in test configurations.
+	testHost = "testhost"
+)
+
+// testServer is an in-process SSH server that supports a handful of commands
+// used for testing.
+type testServer struct {
+	// listener is the server listener.
+	listener net.Listener
+	// hostKey is the server's host key.
+	hostKey ssh.Signer
+	// filesLock guards files.
+	filesLock sync.Mutex
+	// files maps names of files received via SCP to their headers and
+	// contents.
+	files map[string]string
+}
+
+// newTestServer creates and starts a new test server.
+func newTestServer(t *testing.T) *testServer {
+	// Mark this as a helper function.
+	t.Helper()
+
+	// Generate a host key.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("unable to generate host key:", err)
+	}
+	hostKey, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal("unable to create host key signer:", err)
+	}
+
+	// Create the listener.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("unable to create listener:", err)
+	}
+
+	// Create the server and start serving.
+	server := &testServer{
+		listener: listener,
+		hostKey:  hostKey,
+		files:    make(map[string]string),
+	}
+	go server.serve()
+
+	// Done.
+	return server
+}
+
+// port returns the port on which the server is listening.
+func (s *testServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// serve accepts and serves connections.
+func (s *testServer) serve() {
+	// Create the server configuration.
+	configuration := &ssh.ServerConfig{
+		PasswordCallback: func(metadata ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if metadata.User() == testUser && string(password) == testPassword {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("invalid credentials")
+		},
+	}
+	configuration.AddHostKey(s.hostKey)
+
+	// Accept connections.
+	for {
+		connection, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.serveConnection(connection, configuration)
+	}
+}
+
+// serveConnection serves an individual connection.
+func (s *testServer) serveConnection(connection net.Conn, configuration *ssh.ServerConfig) {
+	// Perform the handshake.
+	serverConnection, channels, requests, err := ssh.NewServerConn(connection, configuration)
+	if err != nil {
+		connection.Close()
+		return
+	}
+	defer serverConnection.Close()
+	go ssh.DiscardRequests(requests)
+
+	// Serve session channels.
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go s.serveSession(channel, channelRequests)
+	}
+}
+
+// serveSession serves an individual session.
+func (s *testServer) serveSession(channel ssh.Channel, requests <-chan *ssh.Request) {
+	for request := range requests {
+		switch request.Type {
+		case "env":
+			request.Reply(true, nil)
+		case "exec":
+			var payload struct{ Command string }
+			if err := ssh.Unmarshal(request.Payload, &payload); err != nil {
+				request.Reply(false, nil)
+				continue
+			}
+			request.Reply(true, nil)
+			go s.run(channel, payload.Command)
+		default:
+			request.Reply(false, nil)
+		}
+	}
+}
+
+// run executes a command on a channel.
+func (s *testServer) run(channel ssh.Channel, command string) {
+	// Run the command and compute its exit code.
+	var code uint32
+	switch {
+	case command == "echo hello":
+		io.WriteString(channel, "hello\n")
+	case strings.HasPrefix(command, "scp -t "):
+		if err := s.receive(channel, strings.TrimPrefix(command, "scp -t ")); err != nil {
+			io.WriteString(channel.Stderr(), err.Error())
+			code = 1
+		}
+	default:
+		io.WriteString(channel.Stderr(), "command not found")
+		code = 127
+	}
+
+	// Send the exit status and close the channel.
+	channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{code}))
+	channel.Close()
+}
+
+// receive implements the sink side of the SCP protocol for a single file.
+func (s *testServer) receive(channel ssh.Channel, name string) error {
+	// Indicate readiness.
+	reader := bufio.NewReader(channel)
+	if _, err := channel.Write([]byte{0}); err != nil {
+		return err
+	}
+
+	// Read and parse the header.
+	header, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	fields := strings.Fields(strings.TrimSpace(header))
+	if len(fields) != 3 || fields[2] != name {
+		return fmt.Errorf("invalid header")
+	}
+	size, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return err
+	}
+	if _, err := channel.Write([]byte{0}); err != nil {
+		return err
+	}
+
+	// Read the contents and terminator.
+	contents := make([]byte, size+1)
+	if _, err := io.ReadFull(reader, contents); err != nil {
+		return err
+	} else if contents[size] != 0 {
+		return fmt.Errorf("invalid terminator")
+	}
+
+	// Record the file.
+	s.filesLock.Lock()
+	s.files[name] = fields[0] + " " + string(contents[:size])
+	s.filesLock.Unlock()
+
+	// Acknowledge the transfer.
+	_, err = channel.Write([]byte{0})
+	return err
+}
+
+// close shuts down the server.
+func (s *testServer) close() {
+	s.listener.Close()
+}
+
+// testPrompter is a prompter that responds affirmatively to host key prompts
+// and supplies the test password to password prompts. It records the prompts
+// that it receives.
+type testPrompter struct {
+	// lock guards prompts.
+	lock sync.Mutex
+	// prompts are the prompts received.
+	prompts []string
+}
+
+// Message implements prompt.Prompter.Message.
+func (p *testPrompter) Message(_ string) error {
+	return nil
+}
+
+// Prompt implements prompt.Prompter.Prompt.
+func (p *testPrompter) Prompt(message string) (string, error) {
+	p.lock.Lock()
+	p.prompts = append(p.prompts, message)
+	p.lock.Unlock()
+	if strings.HasSuffix(message, "(yes/no)? ") {
+		return "yes", nil
+	} else if strings.Contains(message, "password") {
+		return testPassword, nil
+	}
+	return "", fmt.Errorf("unexpected prompt")
+}
+
+// count returns the number of prompts received.
+func (p *testPrompter) count() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.prompts)
+}
+
+// setupTestEnvironment creates a temporary home directory containing an
+// OpenSSH configuration that maps the test host alias to the specified server,
+// adding any additional configuration lines. It returns the home directory and
+// a cleanup function.
+func setupTestEnvironment(t *testing.T, server *testServer, extra string) (string, func()) {
+	// Mark this as a helper function.
+	t.Helper()
+
+	// Skip on Windows, where the home directory isn't controlled by HOME.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Create the home directory and configuration.
+	home, err := ioutil.TempDir("", "mutagen_native_ssh")
+	if err != nil {
+		t.Fatal("unable to create temporary home directory:", err)
+	}
+	if err := os.Mkdir(filepath.Join(home, ".ssh"), 0700); err != nil {
+		os.RemoveAll(home)
+		t.Fatal("unable to create SSH directory:", err)
+	}
+	configuration := fmt.Sprintf("Host %s\n  HostName 127.0.0.1\n  Port %d\n  User %s\n%s",
+		testHost, server.port(), testUser, extra,
+	)
+	if err := ioutil.WriteFile(filepath.Join(home, ".ssh", "config"), []byte(configuration), 0600); err != nil {
+		os.RemoveAll(home)
+		t.Fatal("unable to write SSH configuration:", err)
+	}
+
+	// Override the environment.
+	previousHome := os.Getenv("HOME")
+	previousAgent, previousAgentSet := os.LookupEnv("SSH_AUTH_SOCK")
+	os.Setenv("HOME", home)
+	os.Unsetenv("SSH_AUTH_SOCK")
+
+	// Done.
+	return home, func() {
+		os.Setenv("HOME", previousHome)
+		if previousAgentSet {
+			os.Setenv("SSH_AUTH_SOCK", previousAgent)
+		}
+		os.RemoveAll(home)
+	}
+}
+
+// runForOutput runs a command via the transport and returns its output.
+func runForOutput(t *testing.T, transport *transport, command string) string {
+	// Mark this as a helper function.
+	t.Helper()
+
+	// Create and start the process.
+	process, err := transport.Command(command)
+	if err != nil {
+		t.Fatal("unable to create command:", err)
+	}
+	output, err := process.StdoutPipe()
+	if err != nil {
+		t.Fatal("unable to redirect output:", err)
+	}
+	if err := process.Start(); err != nil {
+		t.Fatal("unable to start process:", err)
+	}
+
+	// Read output and wait for the process to exit.
+	data, err := ioutil.ReadAll(output)
+	if err != nil {
+		t.Fatal("unable to read output:", err)
+	}
+	if err := process.Wait(); err != nil {
+		t.Fatal("process failed:", err)
+	}
+	if process.ExitCode() != 0 {
+		t.Error("unexpected exit code:", process.ExitCode())
+	}
+
+	// Done.
+	return string(data)
+}
+
+// registerTestPrompter registers a test prompter and returns it along with its
+// identifier.
+func registerTestPrompter(t *testing.T) (*testPrompter, string) {
+	// Mark this as a helper function.
+	t.Helper()
+
+	// Register the prompter.
+	prompter := &testPrompter{}
+	identifier, err := prompt.RegisterPrompter(prompter)
+	if err != nil {
+		t.Fatal("unable to register prompter:", err)
+	}
+
+	// Done.
+	return prompter, identifier
+}
+
+func TestTransport(t *testing.T) {
+	// Create the server and test environment.
+	server := newTestServer(t)
+	defer server.close()
+	home, cleanup := setupTestEnvironment(t, server, "")
+	defer cleanup()
+
+	// Register a prompter.
+	prompter, identifier := registerTestPrompter(t)
+	defer prompt.UnregisterPrompter(identifier)
+
+	// Create the transport.
+	generic, err := NewTransport("", testHost, 0, identifier)
+	if err != nil {
+		t.Fatal("unable to create transport:", err)
+	}
+	transport := generic.(*transport)
+
+	// Run a command and verify that we were prompted for host key verification
+	// and a password.
+	if output := runForOutput(t, transport, "echo hello"); output != "hello\n" {
+		t.Error("unexpected command output:", output)
+	}
+	if prompter.count() != 2 {
+		t.Fatal("unexpected number of prompts:", prompter.count())
+	}
+
+	// Verify that the host key was recorded.
+	knownHosts, err := ioutil.ReadFile(filepath.Join(home, ".ssh", "known_hosts"))
+	if err != nil {
+		t.Fatal("unable to read known hosts file:", err)
+	} else if !strings.Contains(string(knownHosts), fmt.Sprintf("[127.0.0.1]:%d", server.port())) {
+		t.Error("known hosts file does not contain host entry")
+	}
+
+	// Run another command and verify that no further prompting was required.
+	runForOutput(t, transport, "echo hello")
+	if prompter.count() != 2 {
+		t.Error("unexpected prompting for subsequent connection:", prompter.prompts[2:])
+	}
+
+	// Copy a file.
+	source := filepath.Join(home, "source")
+	if err := ioutil.WriteFile(source, []byte("agent"), 0750); err != nil {
+		t.Fatal("unable to create source file:", err)
+	}
+	if err := transport.Copy(source, "mutagen-agent-copy"); err != nil {
+		t.Fatal("unable to copy file:", err)
+	}
+	server.filesLock.Lock()
+	copied := server.files["mutagen-agent-copy"]
+	server.filesLock.Unlock()
+	if copied != "C0750 agent" {
+		t.Error("copied file does not match expected:", copied)
+	}
+
+	// Run a command that doesn't exist and verify error classification.
+	process, err := transport.Command("missing")
+	if err != nil {
+		t.Fatal("unable to create command:", err)
+	}
+	if err := process.Start(); err != nil {
+		t.Fatal("unable to start process:", err)
+	}
+	if err := process.Wait(); err == nil {
+		t.Error("missing command succeeded")
+	}
+	if tryInstall, cmdExe, err := transport.ClassifyError(process.ExitCode(), ""); err != nil {
+		t.Error("unable to classify error:", err)
+	} else if !tryInstall || cmdExe {
+		t.Error("unexpected error classification")
+	}
+}
+
+func TestTransportStrictHostKeyChecking(t *testing.T) {
+	// Create the server and test environment.
+	server := newTestServer(t)
+	defer server.close()
+	_, cleanup := setupTestEnvironment(t, server, "  StrictHostKeyChecking yes\n")
+	defer cleanup()
+
+	// Register a prompter.
+	_, identifier := registerTestPrompter(t)
+	defer prompt.UnregisterPrompter(identifier)
+
+	// Verify that connecting to an unknown host fails.
+	transport, err := NewTransport("", testHost, 0, identifier)
+	if err != nil {
+		t.Fatal("unable to create transport:", err)
+	}
+	if _, err := transport.Command("echo hello"); err == nil {
+		t.Error("connection to unknown host succeeded with strict host key checking")
+	}
+}
+
+func TestTransportHostKeyMismatch(t *testing.T) {
+	// Create the server and test environment.
+	server := newTestServer(t)
+	defer server.close()
+	home, cleanup := setupTestEnvironment(t, server, "")
+	defer cleanup()
+
+	// Record a different host key for the server.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("unable to generate key:", err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal("unable to create signer:", err)
+	}
+	hostname := fmt.Sprintf("127.0.0.1:%d", server.port())
+	if err := recordHostKey(filepath.Join(home, ".ssh", "known_hosts"), hostname, signer.PublicKey()); err != nil {
+		t.Fatal("unable to record host key:", err)
+	}
+
+	// Register a prompter.
+	prompter, identifier := registerTestPrompter(t)
+	defer prompt.UnregisterPrompter(identifier)
+
+	// Verify that connecting fails without prompting.
+	transport, err := NewTransport("", testHost, 0, identifier)
+	if err != nil {
+		t.Fatal("unable to create transport:", err)
+	}
+	if _, err := transport.Command("echo hello"); err == nil {
+		t.Error("connection succeeded despite host key mismatch")
+	}
+	if prompter.count() != 0 {
+		t.Error("unexpected prompting:", prompter.prompts)
+	}
+}
@@ -4,17 +4,27 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 
 	"github.com/pkg/errors"
 
 	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/agent/transports/ssh/native"
 	"github.com/mutagen-io/mutagen/pkg/process"
 	"github.com/mutagen-io/mutagen/pkg/tools/ssh"
 )
 
 const (
+	// transportEnvironmentVariable is the environment variable that can be used
+	// to select the SSH transport implementation.
+	transportEnvironmentVariable = "MUTAGEN_SSH_TRANSPORT"
+	// transportOpenSSH is the transport selection value for the OpenSSH-based
+	// transport.
+	transportOpenSSH = "openssh"
+	// transportNative is the transport selection value for the native
+	// transport.
+	transportNative = "native"
+
 	// connectTimeoutSeconds is the default timeout value (in seconds) to use
 	// with SSH-based commands. We may want to make this configurable in the
 	// future.
@@ -29,20 +39,76 @@ type transport struct {
 	host string
 	// port is the target port.
 	port uint16
+	// jump is the jump host specification (if any) to pass to OpenSSH's
+	// ProxyJump option.
+	jump string
+	// proxyCommand is the proxy command (if any) to pass to OpenSSH's
+	// ProxyCommand option.
+	proxyCommand string
 	// prompter is the prompter identifier to use for prompting.
 	prompter string
 }
 
-// NewTransport creates a new SSH transport using the specified parameters.
-func NewTransport(user, host string, port uint16, prompter string) (agent.Transport, error) {
+// NewTransport creates a new SSH transport using the specified parameters. By
+// default, it uses the system's OpenSSH executables. The native (in-process)
+// transport is opt-in and can be selected by setting the MUTAGEN_SSH_TRANSPORT
+// environment variable to "native" (or explicitly set to "openssh"). We don't
+// fall back to the native transport automatically, since it doesn't honor all
+// OpenSSH configuration and silently switching implementations would make
+// connection behavior depend on the presence of OpenSSH. If a jump host or
+// proxy command is specified, then the OpenSSH transport is required.
+func NewTransport(user, host string, port uint16, jump, proxyCommand, prompter string) (agent.Transport, error) {
+	// Determine whether or not we need OpenSSH-specific functionality.
+	requiresOpenSSH := jump != "" || proxyCommand != ""
+
+	// Determine which implementation to use.
+	switch selection := os.Getenv(transportEnvironmentVariable); selection {
+	case transportNative:
+		if requiresOpenSSH {
+			return nil, errors.New("native SSH transport does not support jump hosts or proxy commands")
+		}
+		return native.NewTransport(user, host, port, prompter)
+	case "":
+		// We only use the command lookup here to check for availability.
+		if _, err := ssh.SSHCommand(context.Background()); err != nil {
+			if requiresOpenSSH {
+				return nil, errors.Wrap(err, "jump hosts and proxy commands require OpenSSH")
+			}
+			return nil, errors.Wrapf(err,
+				"unable to locate OpenSSH (set %s=%s to use the built-in SSH client)",
+				transportEnvironmentVariable, transportNative,
+			)
+		}
+	case transportOpenSSH:
+	default:
+		return nil, errors.Errorf("unknown SSH transport: %s", selection)
+	}
+
+	// Create the OpenSSH transport.
 	return &transport{
-		user:     user,
-		host:     host,
-		port:     port,
-		prompter: prompter,
+		user:         user,
+		host:         host,
+		port:         port,
+		jump:         jump,
+		proxyCommand: proxyCommand,
+		prompter:     prompter,
 	}, nil
 }
 
+// proxyArguments returns the OpenSSH arguments needed to configure jump hosts
+// and proxy commands. These are specified using -o options since they're
+// understood by both ssh and scp (which doesn't support -J in older versions).
+func (t *transport) proxyArguments() []string {
+	var arguments []string
+	if t.jump != "" {
+		arguments = append(arguments, "-o", fmt.Sprintf("ProxyJump=%s", t.jump))
+	}
+	if t.proxyCommand != "" {
+		arguments = append(arguments, "-o", fmt.Sprintf("ProxyCommand=%s", t.proxyCommand))
+	}
+	return arguments
+}
+
 // Copy implements the Copy method of agent.Transport.
 func (t *transport) Copy(localPath, remoteName string) error {
 	// HACK: On Windows, we attempt to use SCP executables that might not
@@ -79,6 +145,7 @@ func (t *transport) Copy(localPath, remoteName string) error {
 	var scpArguments []string
 	scpArguments = append(scpArguments, ssh.CompressionArgument())
 	scpArguments = append(scpArguments, ssh.TimeoutArgument(connectTimeoutSeconds))
+	scpArguments = append(scpArguments, t.proxyArguments()...)
 	if t.port != 0 {
 		scpArguments = append(scpArguments, "-P", fmt.Sprintf("%d", t.port))
 	}
@@ -121,7 +188,7 @@ func (t *transport) Copy(localPath, remoteName string) error {
 }
 
 // Command implements the Command method of agent.Transport.
-func (t *transport) Command(command string) (*exec.Cmd, error) {
+func (t *transport) Command(command string) (process.Process, error) {
 	// Compute the target.
 	target := t.host
 	if t.user != "" {
@@ -134,6 +201,7 @@ func (t *transport) Command(command string) (*exec.Cmd, error) {
 	// implementation.
 	var sshArguments []string
 	sshArguments = append(sshArguments, ssh.TimeoutArgument(connectTimeoutSeconds))
+	sshArguments = append(sshArguments, t.proxyArguments()...)
 	if t.port != 0 {
 		sshArguments = append(sshArguments, "-p", fmt.Sprintf("%d", t.port))
 	}
@@ -164,19 +232,19 @@ func (t *transport) Command(command string) (*exec.Cmd, error) {
 	sshCommand.Env = environment
 
 	// Done.
-	return sshCommand, nil
+	return process.NewLocalProcess(sshCommand), nil
 }
 
 // ClassifyError implements the ClassifyError method of agent.Transport.
-func (t *transport) ClassifyError(processState *os.ProcessState, errorOutput string) (bool, bool, error) {
+func (t *transport) ClassifyError(exitCode int, errorOutput string) (bool, bool, error) {
 	// SSH faithfully returns exit codes and error output, so we can use direct
 	// methods for testing and classification. Note that we may get POSIX-like
 	// error codes back even from Windows remotes, but that indicates a POSIX
 	// shell on the remote and thus we should continue connecting under that
 	// hypothesis (instead of the cmd.exe hypothesis).
-	if process.IsPOSIXShellInvalidCommand(processState) {
+	if process.IsPOSIXShellInvalidCommandExitCode(exitCode) {
 		return true, false, nil
-	} else if process.IsPOSIXShellCommandNotFound(processState) {
+	} else if process.IsPOSIXShellCommandNotFoundExitCode(exitCode) {
 		return true, false, nil
 	} else if process.OutputIsWindowsInvalidCommand(errorOutput) {
 		// A Windows invalid command error doesn't necessarily indicate that
@@ -11,6 +11,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/process"
 )
 
 func TestCopy(t *testing.T) {
@@ -105,7 +106,7 @@ func TestCommandOutput(t *testing.T) {
 	// matches the expected home directory since we've already queried the user?
 	if command, err := transport.Command(command); err != nil {
 		t.Fatal("unable to create command:", err)
-	} else if output, err := command.Output(); err != nil {
+	} else if output, err := command.(*process.LocalProcess).Output(); err != nil {
 		t.Fatal("unable to run command:", err)
 	} else if !strings.Contains(string(output), content) {
 		t.Error("output does not contain expected content")
@@ -113,3 +114,40 @@ func TestCommandOutput(t *testing.T) {
 		t.Error("output not in UTF-8 encoding")
 	}
 }
+
+func TestProxyArguments(t *testing.T) {
+	// Verify that no arguments are generated by default.
+	if arguments := (&transport{host: "host"}).proxyArguments(); len(arguments) != 0 {
+		t.Error("unexpected proxy arguments:", arguments)
+	}
+
+	// Verify jump host arguments.
+	jump := (&transport{host: "host", jump: "bastion"}).proxyArguments()
+	if len(jump) != 2 || jump[0] != "-o" || jump[1] != "ProxyJump=bastion" {
+		t.Error("unexpected jump host arguments:", jump)
+	}
+
+	// Verify proxy command arguments.
+	proxy := (&transport{host: "host", proxyCommand: "nc %h %p"}).proxyArguments()
+	if len(proxy) != 2 || proxy[0] != "-o" || proxy[1] != "ProxyCommand=nc %h %p" {
+		t.Error("unexpected proxy command arguments:", proxy)
+	}
+}
+
+func TestNewTransportNativeWithJumpHost(t *testing.T) {
+	// Force the native transport and defer restoration of the environment.
+	previous, set := os.LookupEnv(transportEnvironmentVariable)
+	os.Setenv(transportEnvironmentVariable, transportNative)
+	defer func() {
+		if set {
+			os.Setenv(transportEnvironmentVariable, previous)
+		} else {
+			os.Unsetenv(transportEnvironmentVariable)
+		}
+	}()
+
+	// Ensure that transport creation fails.
+	if _, err := NewTransport("", "host", 0, "bastion", "", ""); err == nil {
+		t.Error("native transport created with jump host")
+	}
+}
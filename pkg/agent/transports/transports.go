@@ -0,0 +1,33 @@
+package transports
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/agent/transports/docker"
+	"github.com/mutagen-io/mutagen/pkg/agent/transports/kubernetes"
+	"github.com/mutagen-io/mutagen/pkg/agent/transports/ssh"
+	urlpkg "github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// New creates an agent transport for the remote targeted by the specified URL.
+// Only URL protocols that use Mutagen-managed agents are supported. The path
+// portion of the URL is ignored.
+func New(url *urlpkg.URL, prompter string) (agent.Transport, error) {
+	switch url.Protocol {
+	case urlpkg.Protocol_SSH:
+		return ssh.NewTransport(
+			url.User, url.Host, uint16(url.Port),
+			url.Parameters[urlpkg.SSHJumpParameter], url.Parameters[urlpkg.SSHProxyCommandParameter],
+			prompter,
+		)
+	case urlpkg.Protocol_Docker:
+		return docker.NewTransport(url.Host, url.User, url.Environment, prompter)
+	case urlpkg.Protocol_Podman:
+		return docker.NewPodmanTransport(url.Host, url.User, url.Environment, prompter)
+	case urlpkg.Protocol_Kubernetes:
+		return kubernetes.NewTransport(url.Host, url.Environment)
+	default:
+		return nil, errors.New("URL protocol does not use managed agents")
+	}
+}
@@ -0,0 +1,29 @@
+package transports
+
+import (
+	"testing"
+
+	urlpkg "github.com/mutagen-io/mutagen/pkg/url"
+)
+
+func TestNewUnsupportedProtocol(t *testing.T) {
+	for _, protocol := range []urlpkg.Protocol{urlpkg.Protocol_Local, urlpkg.Protocol_TCP} {
+		if _, err := New(&urlpkg.URL{Protocol: protocol}, ""); err == nil {
+			t.Error("transport creation succeeded for unsupported protocol:", protocol)
+		}
+	}
+}
+
+func TestNewSSH(t *testing.T) {
+	url := &urlpkg.URL{
+		Protocol: urlpkg.Protocol_SSH,
+		User:     "user",
+		Host:     "host",
+		Path:     "~",
+	}
+	if transport, err := New(url, ""); err != nil {
+		t.Error("unable to create SSH transport:", err)
+	} else if transport == nil {
+		t.Error("nil transport returned")
+	}
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"os/exec"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/daemon"
+)
+
+const (
+	// defaultExecutableName is the name of the Mutagen executable that will be
+	// resolved from the executable search path if no executable path is
+	// specified for autostart.
+	defaultExecutableName = "mutagen"
+)
+
+// resolveExecutable determines the path of the Mutagen executable to use for
+// autostarting the daemon.
+func resolveExecutable(executable string) (string, error) {
+	// If an executable has been specified explicitly, then use it.
+	if executable != "" {
+		return executable, nil
+	}
+
+	// Otherwise search for the default executable.
+	path, err := exec.LookPath(defaultExecutableName)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to locate Mutagen executable")
+	}
+	return path, nil
+}
+
+// startDaemon starts the daemon in the background, using the system mechanism
+// if the daemon is registered with the system and the specified executable
+// otherwise.
+func startDaemon(executable string) error {
+	// If the daemon is registered with the system, it may have a different
+	// start mechanism, so see if the system should handle it.
+	if handled, err := daemon.RegisteredStart(); err != nil {
+		return errors.Wrap(err, "unable to start daemon using system mechanism")
+	} else if handled {
+		return nil
+	}
+
+	// Determine the executable path.
+	executablePath, err := resolveExecutable(executable)
+	if err != nil {
+		return err
+	}
+
+	// Start the daemon in the background.
+	daemonProcess := &exec.Cmd{
+		Path:        executablePath,
+		Args:        []string{"mutagen", "daemon", "run"},
+		SysProcAttr: daemonProcessAttributes,
+	}
+	if err := daemonProcess.Start(); err != nil {
+		return errors.Wrap(err, "unable to fork daemon")
+	}
+
+	// Unlike the command line, which exits shortly after autostarting the
+	// daemon, the host process may be long-lived, so reap the daemon process in
+	// the background if it exits before the host process.
+	go daemonProcess.Wait()
+
+	// Success.
+	return nil
+}
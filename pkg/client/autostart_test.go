@@ -0,0 +1,15 @@
+package client
+
+import (
+	"testing"
+)
+
+// TestResolveExecutableExplicit tests that an explicitly specified executable
+// path is used as-is.
+func TestResolveExecutableExplicit(t *testing.T) {
+	if executable, err := resolveExecutable("/path/to/mutagen"); err != nil {
+		t.Fatal("unable to resolve explicit executable:", err)
+	} else if executable != "/path/to/mutagen" {
+		t.Error("explicit executable path not used:", executable)
+	}
+}
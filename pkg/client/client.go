@@ -0,0 +1,268 @@
+package client
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/pkg/daemon"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/ipc"
+	"github.com/mutagen-io/mutagen/pkg/mutagen"
+	"github.com/mutagen-io/mutagen/pkg/prompt"
+	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+)
+
+const (
+	// dialTimeout is the timeout to use when attempting to connect to the
+	// daemon IPC endpoint.
+	dialTimeout = 100 * time.Millisecond
+	// autostartWaitInterval is the wait period between reconnect attempts after
+	// autostarting the daemon.
+	autostartWaitInterval = 100 * time.Millisecond
+	// autostartRetryCount is the number of times to try reconnecting after
+	// autostarting the daemon.
+	autostartRetryCount = 10
+)
+
+// Options encodes options for connecting to the daemon.
+type Options struct {
+	// Autostart indicates whether or not the daemon should be started if it
+	// isn't already running. It has no effect if autostart has been disabled
+	// via the MUTAGEN_DISABLE_AUTOSTART environment variable.
+	Autostart bool
+	// Executable is the path to the Mutagen executable to use when
+	// autostarting the daemon. If empty, the executable is located using the
+	// executable search path.
+	Executable string
+	// EnforceVersionMatch indicates whether or not the daemon's version must
+	// match the version of this package.
+	EnforceVersionMatch bool
+	// Prompter is the prompter to which messages describing the progress of
+	// daemon autostart are sent. If nil, then these messages are discarded.
+	Prompter prompt.Prompter
+}
+
+// Client is a daemon API client. It is safe for concurrent usage.
+type Client struct {
+	// connection is the underlying daemon connection.
+	connection *grpc.ClientConn
+	// daemon is the daemon service client.
+	daemon daemonsvc.DaemonClient
+	// synchronization is the synchronization session client.
+	synchronization *SynchronizationClient
+	// forwarding is the forwarding session client.
+	forwarding *ForwardingClient
+}
+
+// dial performs a single dialing attempt for the daemon IPC endpoint.
+func dial(endpoint string) (*grpc.ClientConn, error) {
+	// Create a context to timeout the dial and defer its cancellation. If the
+	// dialing operation succeeds, this has no effect, but it is necessary to
+	// clean up the Goroutine that backs the context.
+	dialContext, dialCancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer dialCancel()
+
+	// Attempt to dial.
+	return grpc.DialContext(
+		dialContext, endpoint,
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(ipc.DialContext),
+		grpc.WithBlock(),
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(grpcutil.MaximumMessageSize)),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutil.MaximumMessageSize)),
+	)
+}
+
+// Connect creates a new client connected to the daemon. If options is nil,
+// then default options are used (i.e. no autostart and no version
+// verification).
+func Connect(options *Options) (*Client, error) {
+	// Use default options if none have been specified.
+	if options == nil {
+		options = &Options{}
+	}
+
+	// Compute the path to the daemon IPC endpoint.
+	endpoint, err := daemon.EndpointPath()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to compute endpoint path")
+	}
+
+	// Check if autostart has been requested and not globally disabled.
+	autostart := options.Autostart && !daemon.AutostartDisabled
+
+	// Determine the prompter to use for autostart messages.
+	prompter := options.Prompter
+	if prompter == nil {
+		prompter = discardingPrompter{}
+	}
+
+	// Perform dialing in a loop until failure or success.
+	remainingPostAutostartAttempts := autostartRetryCount
+	invokedStart := false
+	var connection *grpc.ClientConn
+	for {
+		// Attempt to dial.
+		connection, err = dial(endpoint)
+
+		// Handle success, noting if we started the daemon.
+		if err == nil {
+			if invokedStart {
+				prompter.Message("Started Mutagen daemon in background (terminate with \"mutagen daemon stop\")")
+			}
+			break
+		}
+
+		// If we failed for any reason other than a timeout, then bail.
+		if err != context.DeadlineExceeded {
+			return nil, err
+		}
+
+		// If autostart is enabled, and we have attempts remaining, then try
+		// autostarting, waiting, and retrying.
+		if autostart && remainingPostAutostartAttempts > 0 {
+			if !invokedStart {
+				prompter.Message("Attempting to start Mutagen daemon...")
+				if err := startDaemon(options.Executable); err != nil {
+					return nil, errors.Wrap(err, "unable to autostart daemon")
+				}
+				invokedStart = true
+			}
+			time.Sleep(autostartWaitInterval)
+			remainingPostAutostartAttempts--
+			continue
+		}
+
+		// Otherwise just fail due to the timeout.
+		return nil, errors.New("connection timed out (is the daemon running?)")
+	}
+
+	// Create the client.
+	client := New(connection)
+
+	// If requested, verify that the daemon version matches our version.
+	if options.EnforceVersionMatch {
+		version, err := client.Version(context.Background())
+		if err != nil {
+			connection.Close()
+			return nil, errors.Wrap(err, "unable to query daemon version")
+		}
+		versionMatch := version.Major == mutagen.VersionMajor &&
+			version.Minor == mutagen.VersionMinor &&
+			version.Patch == mutagen.VersionPatch &&
+			version.Tag == mutagen.VersionTag
+		if !versionMatch {
+			connection.Close()
+			return nil, errors.New("client/daemon version mismatch (daemon restart recommended)")
+		}
+	}
+
+	// Success.
+	return client, nil
+}
+
+// New creates a new client using an existing daemon connection. The client
+// takes ownership of the connection and will close it when closed.
+func New(connection *grpc.ClientConn) *Client {
+	return &Client{
+		connection: connection,
+		daemon:     daemonsvc.NewDaemonClient(connection),
+		synchronization: &SynchronizationClient{
+			service: synchronizationsvc.NewSynchronizationClient(connection),
+		},
+		forwarding: &ForwardingClient{
+			service: forwardingsvc.NewForwardingClient(connection),
+		},
+	}
+}
+
+// Connection returns the underlying daemon connection, which can be used to
+// create clients for daemon services that aren't wrapped by this package. The
+// connection remains owned by the client and will be closed when the client is
+// closed.
+func (c *Client) Connection() *grpc.ClientConn {
+	return c.connection
+}
+
+// Version queries the daemon version.
+func (c *Client) Version(ctx context.Context) (*daemonsvc.VersionResponse, error) {
+	version, err := c.daemon.Version(ctx, &daemonsvc.VersionRequest{})
+	if err != nil {
+		return nil, grpcutil.PeelAwayRPCErrorLayer(err)
+	}
+	return version, nil
+}
+
+// TerminateDaemon requests daemon termination.
+func (c *Client) TerminateDaemon(ctx context.Context) error {
+	if _, err := c.daemon.Terminate(ctx, &daemonsvc.TerminateRequest{}); err != nil {
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	}
+	return nil
+}
+
+// Logs streams daemon log records to the specified callback in batches. If
+// sessions is non-empty, then only records generated by the specified sessions
+// (by identifier) are included. If level is non-empty, then only records at or
+// below the specified level of verbosity are included. If follow is true, then
+// newly logged records are streamed until the context is cancelled, otherwise
+// the call returns once all existing records have been delivered. If the
+// callback returns an error, then streaming stops and the error is returned.
+func (c *Client) Logs(
+	ctx context.Context,
+	sessions []string,
+	level string,
+	follow bool,
+	callback func([]*daemonsvc.LogRecord) error,
+) error {
+	// Invoke the logs method. The stream will close when the associated
+	// context is cancelled.
+	logsContext, cancel := context.WithCancel(ctx)
+	defer cancel()
+	request := &daemonsvc.LogsRequest{
+		Sessions: sessions,
+		Level:    level,
+		Follow:   follow,
+	}
+	stream, err := c.daemon.Logs(logsContext, request)
+	if err != nil {
+		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to invoke logs")
+	}
+
+	// Relay records until the stream ends.
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "logs failed")
+		} else if err = response.EnsureValid(); err != nil {
+			return errors.Wrap(err, "invalid logs response received")
+		}
+		if err := callback(response.Records); err != nil {
+			return err
+		}
+	}
+}
+
+// Synchronization returns the client's synchronization session client.
+func (c *Client) Synchronization() *SynchronizationClient {
+	return c.synchronization
+}
+
+// Forwarding returns the client's forwarding session client.
+func (c *Client) Forwarding() *ForwardingClient {
+	return c.forwarding
+}
+
+// Close closes the client's daemon connection.
+func (c *Client) Close() error {
+	return c.connection.Close()
+}
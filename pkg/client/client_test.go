@@ -0,0 +1,275 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/pkg/daemon"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/ipc"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/mutagen"
+	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+
+	// Explicitly import packages that need to register protocol handlers.
+	_ "github.com/mutagen-io/mutagen/pkg/forwarding/protocols/local"
+	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/local"
+)
+
+// testMainInternal is the internal testing entry point, needed so that shutdown
+// operations can be deferred (since TestMain will invoke os.Exit). It points
+// Mutagen at a temporary data directory, sets up an in-process daemon serving
+// the daemon, synchronization, and forwarding services, runs tests, and finally
+// tears down all of the aforementioned infrastructure.
+func testMainInternal(m *testing.M) (int, error) {
+	// Disable logging.
+	log.SetOutput(ioutil.Discard)
+
+	// Create a temporary data directory, point Mutagen at it, and defer its
+	// removal.
+	directory, err := ioutil.TempDir("", "mutagen_client")
+	if err != nil {
+		return -1, errors.Wrap(err, "unable to create temporary data directory")
+	}
+	defer os.RemoveAll(directory)
+	if err := os.Setenv("MUTAGEN_DATA_DIRECTORY", directory); err != nil {
+		return -1, errors.Wrap(err, "unable to set data directory")
+	}
+
+	// Disable autostart to ensure that tests never start a real daemon.
+	daemon.AutostartDisabled = true
+
+	// Acquire the daemon lock and defer its release.
+	lock, err := daemon.AcquireLock()
+	if err != nil {
+		return -1, errors.Wrap(err, "unable to acquire daemon lock")
+	}
+	defer lock.Release()
+
+	// Log to the daemon log file and defer its closure.
+	logPath, err := daemon.LogPath()
+	if err != nil {
+		return -1, errors.Wrap(err, "unable to compute log path")
+	}
+	logFile, err := logging.LogToFile(logPath)
+	if err != nil {
+		return -1, errors.Wrap(err, "unable to start logging to file")
+	}
+	defer logFile.Close()
+
+	// Create a forwarding session manager and defer its shutdown.
+	forwardingManager, err := forwarding.NewManager(logging.RootLogger.Sublogger("forwarding"))
+	if err != nil {
+		return -1, errors.Wrap(err, "unable to create forwarding session manager")
+	}
+	defer forwardingManager.Shutdown()
+
+	// Create a synchronization session manager and defer its shutdown.
+	synchronizationManager, err := synchronization.NewManager(logging.RootLogger.Sublogger("sync"))
+	if err != nil {
+		return -1, errors.Wrap(err, "unable to create synchronization session manager")
+	}
+	defer synchronizationManager.Shutdown()
+
+	// Create the gRPC server and defer its stoppage. We use a hard stop rather
+	// than a graceful stop so that it doesn't hang on open requests.
+	server := grpc.NewServer(
+		grpc.MaxSendMsgSize(grpcutil.MaximumMessageSize),
+		grpc.MaxRecvMsgSize(grpcutil.MaximumMessageSize),
+		grpc.UnaryInterceptor(grpcutil.UnaryServerInterceptor),
+		grpc.StreamInterceptor(grpcutil.StreamServerInterceptor),
+	)
+	defer server.Stop()
+
+	// Create and register the daemon service and defer its shutdown.
+	daemonServer := daemonsvc.NewServer()
+	daemonsvc.RegisterDaemonServer(server, daemonServer)
+	defer daemonServer.Shutdown()
+
+	// Create and register the forwarding and synchronization services.
+	forwardingsvc.RegisterForwardingServer(server, forwardingsvc.NewServer(forwardingManager))
+	synchronizationsvc.RegisterSynchronizationServer(server, synchronizationsvc.NewServer(synchronizationManager))
+
+	// Compute the path to the daemon IPC endpoint.
+	endpoint, err := daemon.EndpointPath()
+	if err != nil {
+		return -1, errors.Wrap(err, "unable to compute endpoint path")
+	}
+
+	// Create the daemon listener and defer its closure.
+	listener, err := ipc.NewListener(endpoint)
+	if err != nil {
+		return -1, errors.Wrap(err, "unable to create daemon listener")
+	}
+	defer listener.Close()
+
+	// Serve incoming connections in a separate Goroutine.
+	go server.Serve(listener)
+
+	// Run tests.
+	return m.Run(), nil
+}
+
+// TestMain is the entry point for client tests (overriding the default
+// generated entry point).
+func TestMain(m *testing.M) {
+	// Invoke the internal entry point. If there's an error, print it out before
+	// exiting.
+	result, err := testMainInternal(m)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+
+	// Exit with the result.
+	os.Exit(result)
+}
+
+// TestConnect tests connecting to the daemon with version verification.
+func TestConnect(t *testing.T) {
+	// Connect to the daemon and defer closure of the client.
+	client, err := Connect(&Options{EnforceVersionMatch: true})
+	if err != nil {
+		t.Fatal("unable to connect to daemon:", err)
+	}
+	defer client.Close()
+
+	// Verify that the underlying connection is exposed.
+	if client.Connection() == nil {
+		t.Error("nil connection returned")
+	}
+
+	// Verify the daemon version.
+	version, err := client.Version(context.Background())
+	if err != nil {
+		t.Fatal("unable to query daemon version:", err)
+	} else if version.Major != mutagen.VersionMajor ||
+		version.Minor != mutagen.VersionMinor ||
+		version.Patch != mutagen.VersionPatch ||
+		version.Tag != mutagen.VersionTag {
+		t.Error("daemon version does not match client version")
+	}
+}
+
+// TestConnectDefaultOptions tests connecting to the daemon with nil options.
+func TestConnectDefaultOptions(t *testing.T) {
+	client, err := Connect(nil)
+	if err != nil {
+		t.Fatal("unable to connect to daemon:", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Error("unable to close client:", err)
+	}
+}
+
+// TestTerminateDaemon tests requesting daemon termination. The testing daemon
+// doesn't monitor for termination requests, so this only verifies that the
+// request succeeds.
+func TestTerminateDaemon(t *testing.T) {
+	client, err := Connect(nil)
+	if err != nil {
+		t.Fatal("unable to connect to daemon:", err)
+	}
+	defer client.Close()
+	if err := client.TerminateDaemon(context.Background()); err != nil {
+		t.Error("unable to request daemon termination:", err)
+	}
+}
+
+// TestLogs tests reading the daemon log history filtered by session.
+func TestLogs(t *testing.T) {
+	// Connect to the daemon and defer closure of the client.
+	client, err := Connect(nil)
+	if err != nil {
+		t.Fatal("unable to connect to daemon:", err)
+	}
+	defer client.Close()
+
+	// Log records for two sessions.
+	logging.RootLogger.Sublogger("sync").Sublogger("sync_logs_a").Print("first")
+	logging.RootLogger.Sublogger("sync").Sublogger("sync_logs_b").Print("second")
+
+	// Read the history for one session.
+	var records []*daemonsvc.LogRecord
+	err = client.Logs(context.Background(), []string{"sync_logs_a"}, "info", false,
+		func(batch []*daemonsvc.LogRecord) error {
+			records = append(records, batch...)
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatal("unable to read logs:", err)
+	}
+	if len(records) != 1 {
+		t.Fatal("unexpected record count:", len(records))
+	} else if records[0].Logger != "sync.sync_logs_a" || records[0].Message != "first" {
+		t.Error("unexpected record:", records[0].Logger, records[0].Message)
+	}
+
+	// Verify that invalid levels are rejected.
+	err = client.Logs(context.Background(), nil, "verbose", false,
+		func(_ []*daemonsvc.LogRecord) error { return nil },
+	)
+	if err == nil {
+		t.Error("invalid log level accepted")
+	}
+}
+
+// TestLogsFollow tests following newly logged records.
+func TestLogsFollow(t *testing.T) {
+	// Connect to the daemon and defer closure of the client.
+	client, err := Connect(nil)
+	if err != nil {
+		t.Fatal("unable to connect to daemon:", err)
+	}
+	defer client.Close()
+
+	// Periodically log records in the background until the test completes,
+	// since we can't know exactly when the daemon starts following.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		logger := logging.RootLogger.Sublogger("forwarding").Sublogger("fwrd_follow")
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				logger.Print("followed")
+			}
+		}
+	}()
+
+	// Follow logs until a new record is received. We use a sentinel error to
+	// stop streaming.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	received := errors.New("received")
+	err = client.Logs(ctx, []string{"fwrd_follow"}, "", true,
+		func(batch []*daemonsvc.LogRecord) error {
+			for _, record := range batch {
+				if record.Message == "followed" {
+					return received
+				}
+			}
+			return nil
+		},
+	)
+	if err != received {
+		t.Fatal("followed record not received:", err)
+	}
+}
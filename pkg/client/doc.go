@@ -0,0 +1,6 @@
+// Package client provides a Go client for the Mutagen daemon API. It handles
+// daemon connectivity (including autostart and version verification) and
+// exposes typed methods for managing synchronization and forwarding sessions,
+// relaying any daemon-initiated messages and prompts to programmatic
+// prompters.
+package client
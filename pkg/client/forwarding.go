@@ -0,0 +1,287 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/prompt"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+)
+
+// ForwardingClient provides methods for managing forwarding sessions. Methods
+// that accept a prompter will relay any messages and prompts from the daemon to
+// that prompter. If a nil prompter is provided, then messages are
+// discarded and prompts fail.
+type ForwardingClient struct {
+	// service is the underlying forwarding service client.
+	service forwardingsvc.ForwardingClient
+}
+
+// Create creates a new forwarding session, returning its identifier.
+func (c *ForwardingClient) Create(
+	ctx context.Context,
+	specification *forwardingsvc.CreationSpecification,
+	prompter prompt.Prompter,
+) (string, error) {
+	// Validate the specification.
+	if err := specification.EnsureValid(); err != nil {
+		return "", errors.Wrap(err, "invalid creation specification")
+	}
+
+	// Invoke the create method. The stream will close when the associated
+	// context is cancelled.
+	createContext, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.service.Create(createContext)
+	if err != nil {
+		return "", errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to invoke create")
+	}
+
+	// Send the initial request.
+	if err := stream.Send(&forwardingsvc.CreateRequest{Specification: specification}); err != nil {
+		return "", errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send create request")
+	}
+
+	// Relay responses until the session is created.
+	var session string
+	err = relay(prompter,
+		func() (string, string, bool, error) {
+			response, err := stream.Recv()
+			if err != nil {
+				return "", "", false, errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "create failed")
+			} else if err = response.EnsureValid(); err != nil {
+				return "", "", false, errors.Wrap(err, "invalid create response received")
+			}
+			session = response.Session
+			return response.Message, response.Prompt, session != "", nil
+		},
+		func(response string) error {
+			if err := stream.Send(&forwardingsvc.CreateRequest{Response: response}); err != nil {
+				return grpcutil.PeelAwayRPCErrorLayer(err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	// Success.
+	return session, nil
+}
+
+// List returns the states of sessions matching the specified selection, along
+// with the corresponding state index. If previousStateIndex is non-zero, then
+// the call will block until the state index differs from the specified value,
+// allowing callers to efficiently poll for changes.
+func (c *ForwardingClient) List(
+	ctx context.Context,
+	selection *selection.Selection,
+	previousStateIndex uint64,
+) (uint64, []*forwarding.State, error) {
+	// Validate the selection.
+	if err := selection.EnsureValid(); err != nil {
+		return 0, nil, errors.Wrap(err, "invalid session selection specification")
+	}
+
+	// Invoke list.
+	request := &forwardingsvc.ListRequest{
+		Selection:          selection,
+		PreviousStateIndex: previousStateIndex,
+	}
+	response, err := c.service.List(ctx, request)
+	if err != nil {
+		return 0, nil, errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "list failed")
+	} else if err = response.EnsureValid(); err != nil {
+		return 0, nil, errors.Wrap(err, "invalid list response received")
+	}
+
+	// Success.
+	return response.StateIndex, response.SessionStates, nil
+}
+
+// Update updates the configuration, name, and labels of an existing session.
+// The session should be identified by its identifier to avoid any ambiguity
+// introduced by concurrent renames.
+func (c *ForwardingClient) Update(
+	ctx context.Context,
+	specification *forwardingsvc.UpdateSpecification,
+	prompter prompt.Prompter,
+) error {
+	// Validate the specification.
+	if err := specification.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid update specification")
+	}
+
+	// Invoke the update method. The stream will close when the associated
+	// context is cancelled.
+	updateContext, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.service.Update(updateContext)
+	if err != nil {
+		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to invoke update")
+	}
+
+	// Send the initial request.
+	if err := stream.Send(&forwardingsvc.UpdateRequest{Specification: specification}); err != nil {
+		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send update request")
+	}
+
+	// Relay responses until the operation completes.
+	return relay(prompter,
+		func() (string, string, bool, error) {
+			response, err := stream.Recv()
+			if err != nil {
+				return "", "", false, errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "update failed")
+			} else if err = response.EnsureValid(); err != nil {
+				return "", "", false, errors.Wrap(err, "invalid update response received")
+			}
+			return response.Message, response.Prompt, response.Message == "" && response.Prompt == "", nil
+		},
+		func(response string) error {
+			if err := stream.Send(&forwardingsvc.UpdateRequest{Response: response}); err != nil {
+				return grpcutil.PeelAwayRPCErrorLayer(err)
+			}
+			return nil
+		},
+	)
+}
+
+// Pause pauses sessions matching the specified selection.
+func (c *ForwardingClient) Pause(
+	ctx context.Context,
+	selection *selection.Selection,
+	prompter prompt.Prompter,
+) error {
+	// Validate the selection.
+	if err := selection.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid session selection specification")
+	}
+
+	// Invoke the pause method. The stream will close when the associated
+	// context is cancelled.
+	pauseContext, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.service.Pause(pauseContext)
+	if err != nil {
+		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to invoke pause")
+	}
+
+	// Send the initial request.
+	if err := stream.Send(&forwardingsvc.PauseRequest{Selection: selection}); err != nil {
+		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send pause request")
+	}
+
+	// Relay responses until the operation completes.
+	return relay(prompter,
+		func() (string, string, bool, error) {
+			response, err := stream.Recv()
+			if err != nil {
+				return "", "", false, errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "pause failed")
+			} else if err = response.EnsureValid(); err != nil {
+				return "", "", false, errors.Wrap(err, "invalid pause response received")
+			}
+			return response.Message, "", response.Message == "", nil
+		},
+		func(_ string) error {
+			if err := stream.Send(&forwardingsvc.PauseRequest{}); err != nil {
+				return grpcutil.PeelAwayRPCErrorLayer(err)
+			}
+			return nil
+		},
+	)
+}
+
+// Resume resumes sessions matching the specified selection.
+func (c *ForwardingClient) Resume(
+	ctx context.Context,
+	selection *selection.Selection,
+	prompter prompt.Prompter,
+) error {
+	// Validate the selection.
+	if err := selection.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid session selection specification")
+	}
+
+	// Invoke the resume method. The stream will close when the associated
+	// context is cancelled.
+	resumeContext, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.service.Resume(resumeContext)
+	if err != nil {
+		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to invoke resume")
+	}
+
+	// Send the initial request.
+	if err := stream.Send(&forwardingsvc.ResumeRequest{Selection: selection}); err != nil {
+		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send resume request")
+	}
+
+	// Relay responses until the operation completes.
+	return relay(prompter,
+		func() (string, string, bool, error) {
+			response, err := stream.Recv()
+			if err != nil {
+				return "", "", false, errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "resume failed")
+			} else if err = response.EnsureValid(); err != nil {
+				return "", "", false, errors.Wrap(err, "invalid resume response received")
+			}
+			return response.Message, response.Prompt, response.Message == "" && response.Prompt == "", nil
+		},
+		func(response string) error {
+			if err := stream.Send(&forwardingsvc.ResumeRequest{Response: response}); err != nil {
+				return grpcutil.PeelAwayRPCErrorLayer(err)
+			}
+			return nil
+		},
+	)
+}
+
+// Terminate terminates sessions matching the specified selection.
+func (c *ForwardingClient) Terminate(
+	ctx context.Context,
+	selection *selection.Selection,
+	prompter prompt.Prompter,
+) error {
+	// Validate the selection.
+	if err := selection.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid session selection specification")
+	}
+
+	// Invoke the terminate method. The stream will close when the associated
+	// context is cancelled.
+	terminateContext, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.service.Terminate(terminateContext)
+	if err != nil {
+		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to invoke terminate")
+	}
+
+	// Send the initial request.
+	if err := stream.Send(&forwardingsvc.TerminateRequest{Selection: selection}); err != nil {
+		return errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "unable to send terminate request")
+	}
+
+	// Relay responses until the operation completes.
+	return relay(prompter,
+		func() (string, string, bool, error) {
+			response, err := stream.Recv()
+			if err != nil {
+				return "", "", false, errors.Wrap(grpcutil.PeelAwayRPCErrorLayer(err), "terminate failed")
+			} else if err = response.EnsureValid(); err != nil {
+				return "", "", false, errors.Wrap(err, "invalid terminate response received")
+			}
+			return response.Message, "", response.Message == "", nil
+		},
+		func(_ string) error {
+			if err := stream.Send(&forwardingsvc.TerminateRequest{}); err != nil {
+				return grpcutil.PeelAwayRPCErrorLayer(err)
+			}
+			return nil
+		},
+	)
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// freeLocalAddress returns a currently unused local TCP address.
+func freeLocalAddress() (string, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return "", err
+	}
+	defer listener.Close()
+	return fmt.Sprintf("localhost:%d", listener.Addr().(*net.TCPAddr).Port), nil
+}
+
+// TestForwardingLifecycle tests creating, listing, updating, pausing,
+// resuming, and terminating a local forwarding session.
+func TestForwardingLifecycle(t *testing.T) {
+	// Compute endpoint URLs.
+	sourceAddress, err := freeLocalAddress()
+	if err != nil {
+		t.Fatal("unable to compute source address:", err)
+	}
+	destinationAddress, err := freeLocalAddress()
+	if err != nil {
+		t.Fatal("unable to compute destination address:", err)
+	}
+	source, err := url.Parse("tcp:"+sourceAddress, url.Kind_Forwarding, true)
+	if err != nil {
+		t.Fatal("unable to parse source URL:", err)
+	}
+	destination, err := url.Parse("tcp:"+destinationAddress, url.Kind_Forwarding, false)
+	if err != nil {
+		t.Fatal("unable to parse destination URL:", err)
+	}
+
+	// Connect to the daemon and defer closure of the client.
+	client, err := Connect(nil)
+	if err != nil {
+		t.Fatal("unable to connect to daemon:", err)
+	}
+	defer client.Close()
+	sessions := client.Forwarding()
+
+	// Create a paused session.
+	prompter := &recordingPrompter{}
+	identifier, err := sessions.Create(context.Background(), &forwardingsvc.CreationSpecification{
+		Source:                   source,
+		Destination:              destination,
+		Configuration:            &forwarding.Configuration{},
+		ConfigurationSource:      &forwarding.Configuration{},
+		ConfigurationDestination: &forwarding.Configuration{},
+		Name:                     "clientTest",
+		Labels:                   map[string]string{"test": "client"},
+		Paused:                   true,
+	}, prompter)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	}
+	labelSelection := &selection.Selection{LabelSelector: "test=client"}
+	selection := &selection.Selection{Specifications: []string{identifier}}
+
+	// Ensure that the session is terminated in the event of test failure.
+	defer sessions.Terminate(context.Background(), selection, nil)
+
+	// List the session by label and verify its state.
+	_, states, err := sessions.List(context.Background(), labelSelection, 0)
+	if err != nil {
+		t.Fatal("unable to list session:", err)
+	} else if len(states) != 1 {
+		t.Fatal("unexpected number of session states:", len(states))
+	} else if states[0].Session.Identifier != identifier {
+		t.Error("session identifier mismatch")
+	} else if !states[0].Session.Paused {
+		t.Error("session not created paused")
+	}
+
+	// Update the session's labels and verify that the update is applied.
+	if err := sessions.Update(context.Background(), &forwardingsvc.UpdateSpecification{
+		Session:                  identifier,
+		Configuration:            states[0].Session.Configuration,
+		ConfigurationSource:      states[0].Session.ConfigurationSource,
+		ConfigurationDestination: states[0].Session.ConfigurationDestination,
+		Name:                     states[0].Session.Name,
+		Labels:                   map[string]string{"test": "updated"},
+	}, prompter); err != nil {
+		t.Fatal("unable to update session:", err)
+	}
+	if _, states, err := sessions.List(context.Background(), selection, 0); err != nil {
+		t.Fatal("unable to list session:", err)
+	} else if states[0].Session.Labels["test"] != "updated" {
+		t.Error("session labels not updated")
+	}
+
+	// Resume the session and verify that it's no longer paused.
+	if err := sessions.Resume(context.Background(), selection, prompter); err != nil {
+		t.Fatal("unable to resume session:", err)
+	}
+	if _, states, err := sessions.List(context.Background(), selection, 0); err != nil {
+		t.Fatal("unable to list session:", err)
+	} else if states[0].Session.Paused {
+		t.Error("session still paused")
+	}
+
+	// Pause the session and verify that it's paused.
+	if err := sessions.Pause(context.Background(), selection, prompter); err != nil {
+		t.Fatal("unable to pause session:", err)
+	}
+	if _, states, err := sessions.List(context.Background(), selection, 0); err != nil {
+		t.Fatal("unable to list session:", err)
+	} else if !states[0].Session.Paused {
+		t.Error("session not paused")
+	}
+
+	// Terminate the session and verify that it no longer exists.
+	if err := sessions.Terminate(context.Background(), selection, prompter); err != nil {
+		t.Fatal("unable to terminate session:", err)
+	}
+	if _, _, err := sessions.List(context.Background(), selection, 0); err == nil {
+		t.Error("terminated session still listed")
+	}
+}
@@ -0,0 +1,15 @@
+// +build !windows,!plan9
+
+// TODO: Figure out what to do for Plan 9. It doesn't support Setsid.
+
+package client
+
+import (
+	"syscall"
+)
+
+// daemonProcessAttributes are the process attributes used when autostarting
+// the daemon.
+var daemonProcessAttributes = &syscall.SysProcAttr{
+	Setsid: true,
+}
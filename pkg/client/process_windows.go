@@ -0,0 +1,13 @@
+package client
+
+import (
+	"syscall"
+
+	"github.com/mutagen-io/mutagen/pkg/process"
+)
+
+// daemonProcessAttributes are the process attributes used when autostarting
+// the daemon.
+var daemonProcessAttributes = &syscall.SysProcAttr{
+	CreationFlags: process.DETACHED_PROCESS | syscall.CREATE_NEW_PROCESS_GROUP,
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/prompt"
+)
+
+// discardingPrompter is a prompter that discards messages and fails prompts.
+// It is used when no prompter is provided for an operation.
+type discardingPrompter struct{}
+
+// Message implements prompt.Prompter.Message.
+func (discardingPrompter) Message(_ string) error {
+	return nil
+}
+
+// Prompt implements prompt.Prompter.Prompt.
+func (discardingPrompter) Prompt(_ string) (string, error) {
+	return "", errors.New("prompting not supported")
+}
+
+// relay processes the responses for a prompting RPC stream until the
+// operation completes. The receive function should receive and validate the
+// next response, returning its message and prompt (at most one of which will
+// be non-empty), as well as whether or not the response indicates completion.
+// The respond function should send a request containing the specified
+// response. If prompter is nil, then messages are discarded and prompts fail.
+func relay(
+	prompter prompt.Prompter,
+	receive func() (message, prompt string, done bool, err error),
+	respond func(response string) error,
+) error {
+	// Use a discarding prompter if none has been specified.
+	if prompter == nil {
+		prompter = discardingPrompter{}
+	}
+
+	// Receive and process responses until we're done.
+	for {
+		message, promptText, done, err := receive()
+		if err != nil {
+			return err
+		} else if done {
+			return nil
+		} else if message != "" {
+			if err := prompter.Message(message); err != nil {
+				return errors.Wrap(err, "unable to relay message")
+			} else if err := respond(""); err != nil {
+				return errors.Wrap(err, "unable to send message response")
+			}
+		} else if promptText != "" {
+			if response, err := prompter.Prompt(promptText); err != nil {
+				return errors.Wrap(err, "unable to perform prompting")
+			} else if err := respond(response); err != nil {
+				return errors.Wrap(err, "unable to send prompt response")
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// recordingPrompter is a prompter that records messages and prompts, answering
+// prompts with a fixed response.
+type recordingPrompter struct {
+	// messages are the messages received by the prompter.
+	messages []string
+	// prompts are the prompts received by the prompter.
+	prompts []string
+	// response is the response to return for prompts.
+	response string
+}
+
+// Message implements prompt.Prompter.Message.
+func (p *recordingPrompter) Message(message string) error {
+	p.messages = append(p.messages, message)
+	return nil
+}
+
+// Prompt implements prompt.Prompter.Prompt.
+func (p *recordingPrompter) Prompt(prompt string) (string, error) {
+	p.prompts = append(p.prompts, prompt)
+	return p.response, nil
+}
+
+// relayTestResponse is a response used to drive relay tests.
+type relayTestResponse struct {
+	// message is the response message.
+	message string
+	// prompt is the response prompt.
+	prompt string
+}
+
+// relayTestStream creates receive and respond functions that simulate a
+// prompting stream yielding the specified responses before completing. The
+// returned slice pointer records responses sent by relay.
+func relayTestStream(responses []relayTestResponse) (
+	func() (string, string, bool, error),
+	func(string) error,
+	*[]string,
+) {
+	var sent []string
+	receive := func() (string, string, bool, error) {
+		if len(responses) == 0 {
+			return "", "", true, nil
+		}
+		response := responses[0]
+		responses = responses[1:]
+		return response.message, response.prompt, false, nil
+	}
+	respond := func(response string) error {
+		sent = append(sent, response)
+		return nil
+	}
+	return receive, respond, &sent
+}
+
+// TestRelay tests that relay forwards messages and prompts to the prompter and
+// sends the corresponding responses.
+func TestRelay(t *testing.T) {
+	// Create the stream and prompter.
+	receive, respond, sent := relayTestStream([]relayTestResponse{
+		{message: "Connecting"},
+		{prompt: "Password:"},
+	})
+	prompter := &recordingPrompter{response: "secret"}
+
+	// Perform relaying.
+	if err := relay(prompter, receive, respond); err != nil {
+		t.Fatal("relay failed:", err)
+	}
+
+	// Verify results.
+	if len(prompter.messages) != 1 || prompter.messages[0] != "Connecting" {
+		t.Error("unexpected messages:", prompter.messages)
+	}
+	if len(prompter.prompts) != 1 || prompter.prompts[0] != "Password:" {
+		t.Error("unexpected prompts:", prompter.prompts)
+	}
+	if len(*sent) != 2 || (*sent)[0] != "" || (*sent)[1] != "secret" {
+		t.Error("unexpected responses sent:", *sent)
+	}
+}
+
+// TestRelayNilPrompter tests that relay discards messages and fails prompts
+// when no prompter is provided.
+func TestRelayNilPrompter(t *testing.T) {
+	// Verify that messages are discarded.
+	receive, respond, _ := relayTestStream([]relayTestResponse{{message: "Connecting"}})
+	if err := relay(nil, receive, respond); err != nil {
+		t.Error("relay failed with message:", err)
+	}
+
+	// Verify that prompts fail.
+	receive, respond, _ = relayTestStream([]relayTestResponse{{prompt: "Password:"}})
+	if relay(nil, receive, respond) == nil {
+		t.Error("relay succeeded with prompt and no prompter")
+	}
+}
+
+// TestRelayReceiveError tests that relay propagates receive errors.
+func TestRelayReceiveError(t *testing.T) {
+	receive := func() (string, string, bool, error) {
+		return "", "", false, errors.New("receive failed")
+	}
+	respond := func(_ string) error {
+		return nil
+	}
+	if relay(nil, receive, respond) == nil {
+		t.Error("relay succeeded despite receive error")
+	}
+}
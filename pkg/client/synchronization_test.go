@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// TestSynchronizationLifecycle tests creating, listing, flushing, updating,
+// pausing, resuming, and terminating a local synchronization session.
+func TestSynchronizationLifecycle(t *testing.T) {
+	// Create temporary directories to act as synchronization roots and defer
+	// their removal.
+	directory, err := ioutil.TempDir("", "mutagen_client_sync")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+	alphaRoot := filepath.Join(directory, "alpha")
+	betaRoot := filepath.Join(directory, "beta")
+	if err := os.Mkdir(alphaRoot, 0700); err != nil {
+		t.Fatal("unable to create alpha root:", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(alphaRoot, "file"), []byte("content"), 0600); err != nil {
+		t.Fatal("unable to create test file:", err)
+	}
+
+	// Parse endpoint URLs.
+	alpha, err := url.Parse(alphaRoot, url.Kind_Synchronization, true)
+	if err != nil {
+		t.Fatal("unable to parse alpha URL:", err)
+	}
+	beta, err := url.Parse(betaRoot, url.Kind_Synchronization, false)
+	if err != nil {
+		t.Fatal("unable to parse beta URL:", err)
+	}
+
+	// Connect to the daemon and defer closure of the client.
+	client, err := Connect(nil)
+	if err != nil {
+		t.Fatal("unable to connect to daemon:", err)
+	}
+	defer client.Close()
+	sessions := client.Synchronization()
+
+	// Create a session.
+	prompter := &recordingPrompter{}
+	identifier, err := sessions.Create(context.Background(), &synchronizationsvc.CreationSpecification{
+		Alpha:              alpha,
+		Beta:               beta,
+		Configuration:      &synchronization.Configuration{},
+		ConfigurationAlpha: &synchronization.Configuration{},
+		ConfigurationBeta:  &synchronization.Configuration{},
+		Name:               "clientTest",
+	}, prompter)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	} else if identifier == "" {
+		t.Fatal("empty session identifier returned")
+	}
+	selection := &selection.Selection{Specifications: []string{identifier}}
+
+	// Ensure that the session is terminated in the event of test failure.
+	defer sessions.Terminate(context.Background(), selection, nil)
+
+	// Flush the session, waiting for a synchronization cycle to complete.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := sessions.Flush(ctx, selection, false, prompter); err != nil {
+		t.Fatal("unable to flush session:", err)
+	}
+
+	// Verify that the file was synchronized.
+	if content, err := ioutil.ReadFile(filepath.Join(betaRoot, "file")); err != nil {
+		t.Error("unable to read synchronized file:", err)
+	} else if string(content) != "content" {
+		t.Error("synchronized file content does not match")
+	}
+
+	// List the session and verify its state.
+	stateIndex, states, err := sessions.List(context.Background(), selection, 0)
+	if err != nil {
+		t.Fatal("unable to list session:", err)
+	} else if stateIndex == 0 {
+		t.Error("zero state index returned")
+	} else if len(states) != 1 {
+		t.Fatal("unexpected number of session states:", len(states))
+	} else if states[0].Session.Identifier != identifier {
+		t.Error("session identifier mismatch")
+	} else if states[0].Session.Name != "clientTest" {
+		t.Error("session name mismatch")
+	}
+
+	// Rename the session and verify that the update is applied.
+	if err := sessions.Update(context.Background(), &synchronizationsvc.UpdateSpecification{
+		Session:            identifier,
+		Configuration:      states[0].Session.Configuration,
+		ConfigurationAlpha: states[0].Session.ConfigurationAlpha,
+		ConfigurationBeta:  states[0].Session.ConfigurationBeta,
+		Name:               "clientTestRenamed",
+	}, prompter); err != nil {
+		t.Fatal("unable to update session:", err)
+	}
+	if _, states, err := sessions.List(context.Background(), selection, 0); err != nil {
+		t.Fatal("unable to list session:", err)
+	} else if states[0].Session.Name != "clientTestRenamed" {
+		t.Error("session not renamed")
+	}
+
+	// Pause the session and verify that it's paused.
+	if err := sessions.Pause(context.Background(), selection, prompter); err != nil {
+		t.Fatal("unable to pause session:", err)
+	}
+	if _, states, err := sessions.List(context.Background(), selection, 0); err != nil {
+		t.Fatal("unable to list session:", err)
+	} else if !states[0].Session.Paused {
+		t.Error("session not paused")
+	}
+
+	// Resume the session and verify that it's no longer paused.
+	if err := sessions.Resume(context.Background(), selection, prompter); err != nil {
+		t.Fatal("unable to resume session:", err)
+	}
+	if _, states, err := sessions.List(context.Background(), selection, 0); err != nil {
+		t.Fatal("unable to list session:", err)
+	} else if states[0].Session.Paused {
+		t.Error("session still paused")
+	}
+
+	// Terminate the session and verify that it no longer exists.
+	if err := sessions.Terminate(context.Background(), selection, prompter); err != nil {
+		t.Fatal("unable to terminate session:", err)
+	}
+	if _, _, err := sessions.List(context.Background(), selection, 0); err == nil {
+		t.Error("terminated session still listed")
+	}
+}
+
+// TestSynchronizationUpdateInvalid tests that updating with an invalid
+// specification fails without contacting the daemon.
+func TestSynchronizationUpdateInvalid(t *testing.T) {
+	sessions := &SynchronizationClient{}
+	if err := sessions.Update(context.Background(), &synchronizationsvc.UpdateSpecification{}, nil); err == nil {
+		t.Error("update succeeded with invalid specification")
+	}
+}
+
+// TestSynchronizationCreateInvalid tests that creation with an invalid
+// specification fails without contacting the daemon.
+func TestSynchronizationCreateInvalid(t *testing.T) {
+	sessions := &SynchronizationClient{}
+	if _, err := sessions.Create(context.Background(), &synchronizationsvc.CreationSpecification{}, nil); err == nil {
+		t.Error("creation succeeded with invalid specification")
+	}
+}
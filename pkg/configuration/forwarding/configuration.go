@@ -8,6 +8,41 @@ import (
 // Configuration represents a YAML-based Mutagen forwarding session
 // configuration.
 type Configuration struct {
+	// Dial contains parameters related to dialing destination connections.
+	Dial struct {
+		// RetryCount specifies the number of times that a failed dial should be
+		// retried before the corresponding incoming connection is rejected.
+		RetryCount uint32 `yaml:"retryCount"`
+		// RetryInterval specifies the initial interval (in milliseconds) to
+		// wait before retrying a failed dial. A value of 0 specifies that
+		// Mutagen's internal default interval should be used.
+		RetryInterval uint32 `yaml:"retryInterval"`
+	} `yaml:"dial"`
+	// TLS contains parameters related to TLS termination and origination.
+	TLS struct {
+		// Mode specifies whether or not connections should be wrapped in TLS.
+		// Listener endpoints will terminate TLS and dialer endpoints will
+		// originate TLS.
+		Mode forwarding.TLSMode `yaml:"mode"`
+		// Certificate specifies the path to a PEM-encoded certificate (chain)
+		// to use for TLS.
+		Certificate string `yaml:"certificate"`
+		// Key specifies the path to the PEM-encoded private key corresponding
+		// to Certificate.
+		Key string `yaml:"key"`
+		// CertificateAuthority specifies the path to a PEM-encoded bundle of
+		// certificate authority certificates to use for verifying peers.
+		CertificateAuthority string `yaml:"certificateAuthority"`
+		// ServerName specifies the server name to use for verifying targets
+		// when originating TLS.
+		ServerName string `yaml:"serverName"`
+	} `yaml:"tls"`
+	// HTTP contains parameters related to HTTP-aware forwarding.
+	HTTP struct {
+		// Routes maps request hosts to alternative forwarding targets (in the
+		// same format as forwarding endpoint URLs) for HTTP-aware sessions.
+		Routes map[string]string `yaml:"routes"`
+	} `yaml:"http"`
 	// Socket contains parameters related to Unix domain socket handling.
 	Socket struct {
 		// OverwriteMode specifies the default socket overwrite mode to use for
@@ -29,9 +64,17 @@ type Configuration struct {
 // session configuration. It does not validate the resulting configuration.
 func (c *Configuration) Configuration() *forwarding.Configuration {
 	return &forwarding.Configuration{
-		SocketOverwriteMode:  c.Socket.OverwriteMode,
-		SocketOwner:          c.Socket.Owner,
-		SocketGroup:          c.Socket.Group,
-		SocketPermissionMode: uint32(c.Socket.PermissionMode),
+		DialRetryCount:          c.Dial.RetryCount,
+		DialRetryInterval:       c.Dial.RetryInterval,
+		Tls:                     c.TLS.Mode,
+		TlsCertificate:          c.TLS.Certificate,
+		TlsKey:                  c.TLS.Key,
+		TlsCertificateAuthority: c.TLS.CertificateAuthority,
+		TlsServerName:           c.TLS.ServerName,
+		HttpRoutes:              c.HTTP.Routes,
+		SocketOverwriteMode:     c.Socket.OverwriteMode,
+		SocketOwner:             c.Socket.Owner,
+		SocketGroup:             c.Socket.Group,
+		SocketPermissionMode:    uint32(c.Socket.PermissionMode),
 	}
 }
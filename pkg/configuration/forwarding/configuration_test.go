@@ -11,6 +11,18 @@ import (
 
 const (
 	testYAMLConfiguration = `
+dial:
+  retryCount: 3
+  retryInterval: 500
+tls:
+  mode: "enabled"
+  certificate: "~/certificate.pem"
+  key: "~/key.pem"
+  certificateAuthority: "~/ca.pem"
+  serverName: "example.com"
+http:
+  routes:
+    api.localhost: "tcp:localhost:8081"
 socket:
   overwriteMode: "overwrite"
   owner: "george"
@@ -22,10 +34,18 @@ socket:
 // expectedConfiguration is the configuration that's expected based on the
 // human-readable configuration given above.
 var expectedConfiguration = &forwarding.Configuration{
-	SocketOverwriteMode:  forwarding.SocketOverwriteMode_SocketOverwriteModeOverwrite,
-	SocketOwner:          "george",
-	SocketGroup:          "presidents",
-	SocketPermissionMode: 0600,
+	DialRetryCount:          3,
+	DialRetryInterval:       500,
+	Tls:                     forwarding.TLSMode_TLSModeEnabled,
+	TlsCertificate:          "~/certificate.pem",
+	TlsKey:                  "~/key.pem",
+	TlsCertificateAuthority: "~/ca.pem",
+	TlsServerName:           "example.com",
+	HttpRoutes:              map[string]string{"api.localhost": "tcp:localhost:8081"},
+	SocketOverwriteMode:     forwarding.SocketOverwriteMode_SocketOverwriteModeOverwrite,
+	SocketOwner:             "george",
+	SocketGroup:             "presidents",
+	SocketPermissionMode:    0600,
 }
 
 // TestLoadConfiguration tests loading a YAML-based session configuration.
@@ -56,6 +76,35 @@ func TestLoadConfiguration(t *testing.T) {
 	}
 
 	// Verify that the configuration matches what's expected.
+	if configuration.DialRetryCount != expectedConfiguration.DialRetryCount {
+		t.Error("dial retry count mismatch:", configuration.DialRetryCount, "!=", expectedConfiguration.DialRetryCount)
+	}
+	if configuration.DialRetryInterval != expectedConfiguration.DialRetryInterval {
+		t.Error("dial retry interval mismatch:", configuration.DialRetryInterval, "!=", expectedConfiguration.DialRetryInterval)
+	}
+	if configuration.Tls != expectedConfiguration.Tls {
+		t.Error("TLS mode mismatch:", configuration.Tls, "!=", expectedConfiguration.Tls)
+	}
+	if configuration.TlsCertificate != expectedConfiguration.TlsCertificate {
+		t.Error("TLS certificate mismatch:", configuration.TlsCertificate, "!=", expectedConfiguration.TlsCertificate)
+	}
+	if configuration.TlsKey != expectedConfiguration.TlsKey {
+		t.Error("TLS key mismatch:", configuration.TlsKey, "!=", expectedConfiguration.TlsKey)
+	}
+	if configuration.TlsCertificateAuthority != expectedConfiguration.TlsCertificateAuthority {
+		t.Error("TLS certificate authority mismatch:", configuration.TlsCertificateAuthority, "!=", expectedConfiguration.TlsCertificateAuthority)
+	}
+	if configuration.TlsServerName != expectedConfiguration.TlsServerName {
+		t.Error("TLS server name mismatch:", configuration.TlsServerName, "!=", expectedConfiguration.TlsServerName)
+	}
+	if len(configuration.HttpRoutes) != len(expectedConfiguration.HttpRoutes) {
+		t.Error("HTTP route count mismatch:", len(configuration.HttpRoutes), "!=", len(expectedConfiguration.HttpRoutes))
+	}
+	for host, target := range expectedConfiguration.HttpRoutes {
+		if configuration.HttpRoutes[host] != target {
+			t.Error("HTTP route mismatch for", host, ":", configuration.HttpRoutes[host], "!=", target)
+		}
+	}
 	if configuration.SocketOverwriteMode != expectedConfiguration.SocketOverwriteMode {
 		t.Error("socket overwrite mode mismatch:", configuration.SocketOverwriteMode, "!=", expectedConfiguration.SocketOverwriteMode)
 	}
@@ -2,6 +2,7 @@ package global
 
 import (
 	"github.com/mutagen-io/mutagen/pkg/configuration/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/configuration/notification"
 	"github.com/mutagen-io/mutagen/pkg/configuration/synchronization"
 	"github.com/mutagen-io/mutagen/pkg/encoding"
 )
@@ -18,6 +19,58 @@ type Configuration struct {
 		// Defaults are the global synchronization configuration defaults.
 		Defaults synchronization.Configuration `yaml:"defaults"`
 	} `yaml:"sync"`
+	// Notification is the global notification configuration. It is loaded by
+	// the daemon when it starts and reloaded when this file changes.
+	Notification notification.Configuration `yaml:"notify"`
+	// Metrics is the global metrics configuration. It is loaded by the daemon
+	// when it starts.
+	Metrics struct {
+		// Listen is the address on which the daemon should expose metrics,
+		// specified as "tcp:<host>:<port>" or "unix:<path>". Since the metrics
+		// endpoint is unauthenticated, TCP hosts must be loopback addresses. If
+		// empty, metrics aren't exposed.
+		Listen string `yaml:"listen"`
+	} `yaml:"metrics"`
+	// API is the global API gateway configuration. It is loaded by the daemon
+	// when it starts.
+	API struct {
+		// Listen is the address on which the daemon should serve its HTTP/JSON
+		// API, specified as "tcp:<host>:<port>" or "unix:<path>". Requests must
+		// authenticate using the token that the daemon writes to its API token
+		// file when the API is enabled. If empty, the API isn't served.
+		//
+		// Note that the session creation endpoints accept SSH proxy commands,
+		// synchronization hooks, and forwarding endpoints using the command
+		// protocols, all of which can run commands with the user's credentials.
+		// These are restricted to the commands in the allowlists below, but the
+		// token file should still be protected accordingly.
+		Listen string `yaml:"listen"`
+		// AllowedProxyCommands are the SSH proxy commands that sessions created
+		// via the API may specify. Since proxy commands are run on the local
+		// system, sessions specifying any other proxy command are rejected.
+		AllowedProxyCommands []string `yaml:"allowedProxyCommands"`
+		// AllowedHookCommands are the synchronization hook commands that
+		// sessions created via the API may specify for hooks that execute on
+		// the local system, either because they use the local location or
+		// because they execute on a local endpoint. Sessions specifying any
+		// other locally executed hook command are rejected.
+		AllowedHookCommands []string `yaml:"allowedHookCommands"`
+		// AllowedEndpointCommands are the commands that forwarding sessions
+		// created via the API may specify for endpoints using the command
+		// protocols (exec: and stdio:). Since these commands are run on the
+		// local system, sessions specifying any other command are rejected.
+		AllowedEndpointCommands []string `yaml:"allowedEndpointCommands"`
+	} `yaml:"api"`
+	// Logging is the global logging configuration. It is loaded by the daemon
+	// when it starts.
+	Logging struct {
+		// Level is the daemon log level ("error", "warn", "info", "debug", or
+		// "trace"). If empty, the default level is used.
+		Level string `yaml:"level"`
+		// Format is the daemon log format ("text" or "json"). If empty, text
+		// formatting is used.
+		Format string `yaml:"format"`
+	} `yaml:"logging"`
 }
 
 // LoadConfiguration attempts to load a YAML-based Mutagen global configuration
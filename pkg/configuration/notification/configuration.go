@@ -0,0 +1,99 @@
+package notification
+
+import (
+	"sort"
+
+	"github.com/mutagen-io/mutagen/pkg/notification"
+)
+
+// Webhook represents a human-readable webhook configuration.
+type Webhook struct {
+	// URL specifies the URL to which notifications are POSTed.
+	URL string `yaml:"url"`
+	// Headers specifies additional HTTP headers to include with each request.
+	Headers map[string]string `yaml:"headers"`
+	// Events specifies the events that should be delivered. If empty, all
+	// events are delivered.
+	Events []notification.Event `yaml:"events"`
+	// Sessions specifies the names or identifiers of sessions whose
+	// notifications should be delivered. If empty, notifications for all
+	// sessions are delivered.
+	Sessions []string `yaml:"sessions"`
+	// LabelSelector specifies a label selector that sessions must match for
+	// their notifications to be delivered.
+	LabelSelector string `yaml:"labelSelector"`
+	// Retries specifies the number of times that failed deliveries are retried.
+	// A value of 0 specifies that Mutagen's internal default should be used.
+	Retries uint32 `yaml:"retries"`
+}
+
+// Session represents a human-readable per-session notification configuration.
+type Session struct {
+	// Webhooks specifies additional webhooks to which the session's
+	// notifications are delivered. Their session filters are ignored.
+	Webhooks []Webhook `yaml:"webhooks"`
+	// DisableGlobalWebhooks specifies that the session's notifications should
+	// not be delivered to the global webhooks.
+	DisableGlobalWebhooks bool `yaml:"disableGlobalWebhooks"`
+}
+
+// Configuration represents a human-readable notification configuration.
+type Configuration struct {
+	// Webhooks specifies the global webhooks to which notifications are
+	// delivered.
+	Webhooks []Webhook `yaml:"webhooks"`
+	// Sessions specifies per-session notification configurations, keyed by
+	// session name or identifier.
+	Sessions map[string]Session `yaml:"sessions"`
+}
+
+// notificationWebhook converts a YAML-based webhook to a webhook.
+func (w *Webhook) notificationWebhook() *notification.Webhook {
+	return &notification.Webhook{
+		URL:           w.URL,
+		Headers:       w.Headers,
+		Events:        w.Events,
+		Sessions:      w.Sessions,
+		LabelSelector: w.LabelSelector,
+		Retries:       w.Retries,
+	}
+}
+
+// NotificationWebhooks converts a YAML-based notification configuration to a
+// list of webhooks. Global webhooks come first, followed by per-session
+// webhooks in order of session specification. It does not validate the
+// resulting webhooks.
+func (c *Configuration) NotificationWebhooks() []*notification.Webhook {
+	// Sort the per-session specifications so that results are deterministic
+	// and identify sessions that opt out of global webhooks.
+	specifications := make([]string, 0, len(c.Sessions))
+	var excluded []string
+	for specification, session := range c.Sessions {
+		specifications = append(specifications, specification)
+		if session.DisableGlobalWebhooks {
+			excluded = append(excluded, specification)
+		}
+	}
+	sort.Strings(specifications)
+	sort.Strings(excluded)
+
+	// Convert global webhooks.
+	var result []*notification.Webhook
+	for _, webhook := range c.Webhooks {
+		converted := webhook.notificationWebhook()
+		converted.ExcludedSessions = excluded
+		result = append(result, converted)
+	}
+
+	// Convert per-session webhooks, restricting them to their sessions.
+	for _, specification := range specifications {
+		for _, webhook := range c.Sessions[specification].Webhooks {
+			converted := webhook.notificationWebhook()
+			converted.Sessions = []string{specification}
+			result = append(result, converted)
+		}
+	}
+
+	// Done.
+	return result
+}
@@ -0,0 +1,110 @@
+package notification
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/notification"
+)
+
+const (
+	testYAMLConfiguration = `
+webhooks:
+  - url: "http://localhost:8080/mutagen"
+    headers:
+      Authorization: "Bearer token"
+    events: ["halted", "conflicts"]
+    sessions: ["web"]
+    labelSelector: "env=dev"
+    retries: 5
+  - url: "https://hooks.example.com/all"
+sessions:
+  web:
+    webhooks:
+      - url: "http://localhost:8080/web"
+        sessions: ["ignored"]
+  api:
+    disableGlobalWebhooks: true
+`
+)
+
+// expectedWebhooks are the webhooks that are expected based on the
+// human-readable configuration given above.
+var expectedWebhooks = []*notification.Webhook{
+	{
+		URL:              "http://localhost:8080/mutagen",
+		Headers:          map[string]string{"Authorization": "Bearer token"},
+		Events:           []notification.Event{notification.EventHalted, notification.EventConflicts},
+		Sessions:         []string{"web"},
+		ExcludedSessions: []string{"api"},
+		LabelSelector:    "env=dev",
+		Retries:          5,
+	},
+	{
+		URL:              "https://hooks.example.com/all",
+		ExcludedSessions: []string{"api"},
+	},
+	{
+		URL:      "http://localhost:8080/web",
+		Sessions: []string{"web"},
+	},
+}
+
+// TestLoadConfiguration tests loading a YAML-based notification configuration.
+func TestLoadConfiguration(t *testing.T) {
+	// Write a valid configuration to a temporary file and defer its cleanup.
+	file, err := ioutil.TempFile("", "mutagen_configuration")
+	if err != nil {
+		t.Fatal("unable to create temporary file:", err)
+	} else if _, err = file.Write([]byte(testYAMLConfiguration)); err != nil {
+		t.Fatal("unable to write data to temporary file:", err)
+	} else if err = file.Close(); err != nil {
+		t.Fatal("unable to close temporary file:", err)
+	}
+	defer os.Remove(file.Name())
+
+	// Attempt to load.
+	yamlConfiguration := &Configuration{}
+	if err := encoding.LoadAndUnmarshalYAML(file.Name(), yamlConfiguration); err != nil {
+		t.Fatal("configuration loading failed:", err)
+	}
+
+	// Compute the webhooks.
+	webhooks := yamlConfiguration.NotificationWebhooks()
+
+	// Ensure that the resulting webhooks are valid.
+	for _, webhook := range webhooks {
+		if err := webhook.EnsureValid(); err != nil {
+			t.Error("derived webhook invalid:", err)
+		}
+	}
+
+	// Verify that the webhooks match what's expected.
+	if !reflect.DeepEqual(webhooks, expectedWebhooks) {
+		t.Error("webhooks do not match expected")
+	}
+}
+
+// TestLoadConfigurationInvalidEvent tests that loading a configuration with an
+// unknown event fails.
+func TestLoadConfigurationInvalidEvent(t *testing.T) {
+	// Write an invalid configuration to a temporary file and defer its cleanup.
+	file, err := ioutil.TempFile("", "mutagen_configuration")
+	if err != nil {
+		t.Fatal("unable to create temporary file:", err)
+	} else if _, err = file.Write([]byte("webhooks:\n  - url: \"http://localhost\"\n    events: [\"exploded\"]\n")); err != nil {
+		t.Fatal("unable to write data to temporary file:", err)
+	} else if err = file.Close(); err != nil {
+		t.Fatal("unable to close temporary file:", err)
+	}
+	defer os.Remove(file.Name())
+
+	// Attempt to load.
+	yamlConfiguration := &Configuration{}
+	if encoding.LoadAndUnmarshalYAML(file.Name(), yamlConfiguration) == nil {
+		t.Error("configuration loading succeeded unexpectedly")
+	}
+}
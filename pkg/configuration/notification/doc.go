@@ -0,0 +1,3 @@
+// Package notification provides definitions for human-readable notification
+// configuration.
+package notification
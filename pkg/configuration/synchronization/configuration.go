@@ -64,12 +64,40 @@ type Configuration struct {
 		// permission propagation mode.
 		DefaultGroup string `yaml:"defaultGroup"`
 	} `yaml:"permissions"`
+	// Hooks specifies commands to run in response to synchronization events.
+	Hooks []struct {
+		// Trigger specifies the event that causes the hook to run.
+		Trigger synchronization.HookTrigger `yaml:"trigger"`
+		// Location specifies where the hook is executed.
+		Location synchronization.HookLocation `yaml:"location"`
+		// Command specifies the command to run.
+		Command string `yaml:"command"`
+		// WorkingDirectory specifies the directory in which the command is
+		// run.
+		WorkingDirectory string `yaml:"workingDirectory"`
+		// Timeout specifies the maximum execution time (in seconds) for the
+		// command.
+		Timeout uint32 `yaml:"timeout"`
+	} `yaml:"hooks"`
 }
 
 // Configuration converts a YAML-based session configuration to a Protocol
 // Buffers session configuration. It does not validate the resulting
 // configuration.
 func (c *Configuration) Configuration() *synchronization.Configuration {
+	// Convert hooks.
+	var hooks []*synchronization.Hook
+	for _, hook := range c.Hooks {
+		hooks = append(hooks, &synchronization.Hook{
+			Trigger:          hook.Trigger,
+			Location:         hook.Location,
+			Command:          hook.Command,
+			WorkingDirectory: hook.WorkingDirectory,
+			Timeout:          hook.Timeout,
+		})
+	}
+
+	// Perform the conversion.
 	return &synchronization.Configuration{
 		SynchronizationMode:    c.Mode,
 		MaximumEntryCount:      c.MaximumEntryCount,
@@ -86,5 +114,6 @@ func (c *Configuration) Configuration() *synchronization.Configuration {
 		DefaultDirectoryMode:   uint32(c.Permissions.DefaultDirectoryMode),
 		DefaultOwner:           c.Permissions.DefaultOwner,
 		DefaultGroup:           c.Permissions.DefaultGroup,
+		Hooks:                  hooks,
 	}
 }
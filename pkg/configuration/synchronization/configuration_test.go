@@ -38,6 +38,15 @@ permissions:
   defaultDirectoryMode: 0755
   defaultOwner: "george"
   defaultGroup: "presidents"
+
+hooks:
+  - trigger: "cycle-completed"
+    location: "beta"
+    command: "make restart"
+    workingDirectory: "app"
+    timeout: 30
+  - trigger: "conflict-detected"
+    command: "notify-send conflict"
 `
 )
 
@@ -63,6 +72,19 @@ var expectedConfiguration = &synchronization.Configuration{
 	DefaultDirectoryMode: 0755,
 	DefaultOwner:         "george",
 	DefaultGroup:         "presidents",
+	Hooks: []*synchronization.Hook{
+		{
+			Trigger:          synchronization.HookTrigger_HookTriggerCycleCompleted,
+			Location:         synchronization.HookLocation_HookLocationBeta,
+			Command:          "make restart",
+			WorkingDirectory: "app",
+			Timeout:          30,
+		},
+		{
+			Trigger: synchronization.HookTrigger_HookTriggerConflictDetected,
+			Command: "notify-send conflict",
+		},
+	},
 }
 
 // TestLoadConfiguration tests loading a YAML-based session configuration.
@@ -144,6 +166,20 @@ func TestLoadConfiguration(t *testing.T) {
 	if configuration.DefaultGroup != expectedConfiguration.DefaultGroup {
 		t.Error("default owner mismatch:", configuration.DefaultGroup, "!=", expectedConfiguration.DefaultGroup)
 	}
+	if len(configuration.Hooks) != len(expectedConfiguration.Hooks) {
+		t.Error("hook count mismatch:", len(configuration.Hooks), "!=", len(expectedConfiguration.Hooks))
+	} else {
+		for i, hook := range configuration.Hooks {
+			expected := expectedConfiguration.Hooks[i]
+			if hook.Trigger != expected.Trigger ||
+				hook.Location != expected.Location ||
+				hook.Command != expected.Command ||
+				hook.WorkingDirectory != expected.WorkingDirectory ||
+				hook.Timeout != expected.Timeout {
+				t.Error("hook mismatch at index", i)
+			}
+		}
+	}
 }
 
 // TODO: Expand tests, including testing for invalid configurations.
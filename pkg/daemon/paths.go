@@ -15,6 +15,13 @@ const (
 	// endpointName is the name of the daemon IPC endpoint. It resides within
 	// the daemon subdirectory of the Mutagen directory.
 	endpointName = "daemon.sock"
+	// apiTokenName is the name of the file containing the daemon API gateway
+	// authentication token. It resides within the daemon subdirectory of the
+	// Mutagen directory.
+	apiTokenName = "api.token"
+	// logName is the name of the daemon log file. It resides within the daemon
+	// subdirectory of the Mutagen directory, alongside its rotated backups.
+	logName = "daemon.log"
 )
 
 // subpath computes a subpath of the daemon subdirectory, creating the daemon
@@ -41,3 +48,15 @@ func lockPath() (string, error) {
 func EndpointPath() (string, error) {
 	return subpath(endpointName)
 }
+
+// APITokenPath computes the path to the daemon API gateway authentication token
+// file, creating any intermediate directories as necessary.
+func APITokenPath() (string, error) {
+	return subpath(apiTokenName)
+}
+
+// LogPath computes the path to the daemon log file, creating any intermediate
+// directories as necessary.
+func LogPath() (string, error) {
+	return subpath(logName)
+}
@@ -39,3 +39,21 @@ func TestEndpointPath(t *testing.T) {
 		t.Error("empty IPC endpoint path returned")
 	}
 }
+
+// TestAPITokenPath tests that APITokenPath succeeds.
+func TestAPITokenPath(t *testing.T) {
+	if path, err := APITokenPath(); err != nil {
+		t.Fatal("unable to compute API token path:", err)
+	} else if path == "" {
+		t.Error("empty API token path returned")
+	}
+}
+
+// TestLogPath tests that LogPath succeeds.
+func TestLogPath(t *testing.T) {
+	if path, err := LogPath(); err != nil {
+		t.Fatal("unable to compute log path:", err)
+	} else if path == "" {
+		t.Error("empty log path returned")
+	}
+}
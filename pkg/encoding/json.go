@@ -0,0 +1,87 @@
+package encoding
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"gopkg.in/yaml.v2"
+)
+
+// JSONToYAML converts a JSON-encoded object, or array of objects, to YAML,
+// preserving key order.
+func JSONToYAML(data []byte) ([]byte, error) {
+	// Decode the value. Since YAML is a superset of JSON, we can use the YAML
+	// decoder, and decoding into a MapSlice preserves key ordering (including
+	// for nested objects).
+	var value interface{}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		list := []yaml.MapSlice{}
+		if err := yaml.Unmarshal(data, &list); err != nil {
+			return nil, errors.Wrap(err, "unable to decode JSON")
+		}
+		value = list
+	} else {
+		var object yaml.MapSlice
+		if err := yaml.Unmarshal(data, &object); err != nil {
+			return nil, errors.Wrap(err, "unable to decode JSON")
+		}
+		value = object
+	}
+
+	// Re-encode the value as YAML.
+	return yaml.Marshal(value)
+}
+
+// YAMLToJSON converts YAML-encoded data to JSON. All mapping keys must be
+// strings.
+func YAMLToJSON(data []byte) ([]byte, error) {
+	// Decode the data.
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, errors.Wrap(err, "unable to decode YAML")
+	}
+
+	// Convert mappings to a JSON-compatible representation.
+	converted, err := jsonCompatible(value)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-encode the data as JSON.
+	return json.Marshal(converted)
+}
+
+// jsonCompatible recursively converts YAML mappings (which use interface{}
+// keys) to JSON-compatible mappings (which use string keys).
+func jsonCompatible(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			k, ok := key.(string)
+			if !ok {
+				return nil, errors.Errorf("non-string mapping key: %v", key)
+			}
+			converted, err := jsonCompatible(element)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = converted
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, element := range v {
+			converted, err := jsonCompatible(element)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = converted
+		}
+		return result, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,67 @@
+package encoding
+
+import (
+	"testing"
+)
+
+// TestJSONToYAML tests conversion from JSON to YAML.
+func TestJSONToYAML(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		json        string
+		expected    string
+		expectError bool
+	}{
+		{`{}`, "{}\n", false},
+		{`{"b": 1, "a": "text"}`, "b: 1\na: text\n", false},
+		{`{"outer": {"z": true, "y": [1, 2]}}`, "outer:\n  z: true\n  \"y\":\n  - 1\n  - 2\n", false},
+		{`{"value": "yes"}`, "value: \"yes\"\n", false},
+		{`[]`, "[]\n", false},
+		{`[{"b": 1}, {"a": 2}]`, "- b: 1\n- a: 2\n", false},
+		{`[1, 2]`, "", true},
+		{`{`, "", true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if result, err := JSONToYAML([]byte(testCase.json)); err != nil {
+			if !testCase.expectError {
+				t.Errorf("test index %d: unexpected conversion error: %v", i, err)
+			}
+		} else if testCase.expectError {
+			t.Errorf("test index %d: conversion unexpectedly succeeded", i)
+		} else if string(result) != testCase.expected {
+			t.Errorf("test index %d: result does not match expected: %q != %q", i, string(result), testCase.expected)
+		}
+	}
+}
+
+// TestYAMLToJSON tests conversion from YAML to JSON.
+func TestYAMLToJSON(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		yaml        string
+		expected    string
+		expectError bool
+	}{
+		{"{}", `{}`, false},
+		{"a: text\nb: 1\n", `{"a":"text","b":1}`, false},
+		{"outer:\n  list:\n  - x: 1\n", `{"outer":{"list":[{"x":1}]}}`, false},
+		{"- 1\n- 2\n", `[1,2]`, false},
+		{"1: value\n", "", true},
+		{"a: [", "", true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if result, err := YAMLToJSON([]byte(testCase.yaml)); err != nil {
+			if !testCase.expectError {
+				t.Errorf("test index %d: unexpected conversion error: %v", i, err)
+			}
+		} else if testCase.expectError {
+			t.Errorf("test index %d: conversion unexpectedly succeeded", i)
+		} else if string(result) != testCase.expected {
+			t.Errorf("test index %d: result does not match expected: %s != %s", i, string(result), testCase.expected)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+// Package export provides encoding and decoding of portable session export
+// documents, which record the information needed to recreate sessions (e.g. on
+// another system) in JSON or YAML format.
+package export
@@ -0,0 +1,140 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+)
+
+const (
+	// KindSynchronization is the document kind for synchronization sessions.
+	KindSynchronization = "sync"
+	// KindForwarding is the document kind for forwarding sessions.
+	KindForwarding = "forward"
+
+	// documentVersion is the current export document version.
+	documentVersion = 1
+)
+
+// document is the top-level structure of an export document.
+type document struct {
+	// Kind is the kind of sessions contained in the document.
+	Kind string `json:"kind"`
+	// Version is the document version.
+	Version uint32 `json:"version"`
+	// Sessions are the JSON-encoded session messages.
+	Sessions []json.RawMessage `json:"sessions"`
+}
+
+// Marshal encodes session messages into an export document of the specified
+// kind and format.
+func Marshal(kind string, sessions []proto.Message, format Format) ([]byte, error) {
+	// Encode the sessions.
+	marshaler := &jsonpb.Marshaler{}
+	encoded := make([]json.RawMessage, len(sessions))
+	for i, session := range sessions {
+		var buffer bytes.Buffer
+		if err := marshaler.Marshal(&buffer, session); err != nil {
+			return nil, errors.Wrapf(err, "unable to encode session at index %d", i)
+		}
+		encoded[i] = buffer.Bytes()
+	}
+
+	// Encode the document as JSON.
+	data, err := json.MarshalIndent(&document{
+		Kind:     kind,
+		Version:  documentVersion,
+		Sessions: encoded,
+	}, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to encode document")
+	}
+
+	// Handle format conversion.
+	if format == FormatYAML {
+		if data, err = encoding.JSONToYAML(data); err != nil {
+			return nil, errors.Wrap(err, "unable to convert document to YAML")
+		}
+	} else {
+		data = append(data, '\n')
+	}
+
+	// Success.
+	return data, nil
+}
+
+// Unmarshal decodes an export document of the specified kind and format. The
+// create callback is used to allocate session messages for decoding.
+func Unmarshal(data []byte, kind string, format Format, create func() proto.Message) ([]proto.Message, error) {
+	// Handle format conversion.
+	if format == FormatYAML {
+		var err error
+		if data, err = encoding.YAMLToJSON(data); err != nil {
+			return nil, errors.Wrap(err, "unable to convert document from YAML")
+		}
+	}
+
+	// Decode the document and validate its metadata.
+	d := &document{}
+	if err := json.Unmarshal(data, d); err != nil {
+		return nil, errors.Wrap(err, "unable to decode document")
+	} else if d.Kind != kind {
+		return nil, errors.Errorf("document kind (%s) does not match expected (%s)", d.Kind, kind)
+	} else if d.Version != documentVersion {
+		return nil, errors.Errorf("unsupported document version: %d", d.Version)
+	}
+
+	// Decode the sessions.
+	unmarshaler := &jsonpb.Unmarshaler{}
+	sessions := make([]proto.Message, len(d.Sessions))
+	for i, encoded := range d.Sessions {
+		session := create()
+		if err := unmarshaler.Unmarshal(bytes.NewReader(encoded), session); err != nil {
+			return nil, errors.Wrapf(err, "unable to decode session at index %d", i)
+		}
+		sessions[i] = session
+	}
+
+	// Success.
+	return sessions, nil
+}
+
+// Save encodes session messages into an export document of the specified kind
+// and saves it to the specified path. The format is determined by the path's
+// extension.
+func Save(path, kind string, sessions []proto.Message) error {
+	// Determine the format.
+	format, err := FormatForPath(path)
+	if err != nil {
+		return err
+	}
+
+	// Encode and save the document.
+	return encoding.MarshalAndSave(path, func() ([]byte, error) {
+		return Marshal(kind, sessions, format)
+	})
+}
+
+// Load loads and decodes an export document of the specified kind from the
+// specified path. The format is determined by the path's extension.
+func Load(path, kind string, create func() proto.Message) ([]proto.Message, error) {
+	// Determine the format.
+	format, err := FormatForPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load and decode the document.
+	var sessions []proto.Message
+	err = encoding.LoadAndUnmarshal(path, func(data []byte) error {
+		sessions, err = Unmarshal(data, kind, format, create)
+		return err
+	})
+	return sessions, err
+}
@@ -0,0 +1,124 @@
+package export
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// testSessions are the messages used for document tests.
+var testSessions = []proto.Message{
+	&url.URL{
+		Kind:     url.Kind_Synchronization,
+		Protocol: url.Protocol_SSH,
+		User:     "user",
+		Host:     "example.com",
+		Port:     2222,
+		Path:     "/home/user/project",
+	},
+	&url.URL{
+		Kind:        url.Kind_Synchronization,
+		Protocol:    url.Protocol_Docker,
+		Host:        "container",
+		Path:        "/code",
+		Environment: map[string]string{"DOCKER_HOST": "unix:///var/run/docker.sock"},
+	},
+}
+
+// newTestSession creates a new test session message for decoding.
+func newTestSession() proto.Message {
+	return &url.URL{}
+}
+
+// checkTestSessions verifies that decoded sessions match the test sessions.
+func checkTestSessions(t *testing.T, sessions []proto.Message) {
+	if len(sessions) != len(testSessions) {
+		t.Fatal("session count does not match expected:", len(sessions), "!=", len(testSessions))
+	}
+	for i, session := range sessions {
+		if !proto.Equal(session, testSessions[i]) {
+			t.Errorf("session at index %d does not match expected", i)
+		}
+	}
+}
+
+// TestDocumentRoundTrip tests that export documents can be encoded and decoded
+// in each format.
+func TestDocumentRoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatYAML, FormatJSON} {
+		data, err := Marshal(KindSynchronization, testSessions, format)
+		if err != nil {
+			t.Fatal("unable to marshal document:", err)
+		}
+		sessions, err := Unmarshal(data, KindSynchronization, format, newTestSession)
+		if err != nil {
+			t.Fatal("unable to unmarshal document:", err)
+		}
+		checkTestSessions(t, sessions)
+	}
+}
+
+// TestDocumentKindMismatch tests that documents of the wrong kind are rejected.
+func TestDocumentKindMismatch(t *testing.T) {
+	data, err := Marshal(KindSynchronization, testSessions, FormatJSON)
+	if err != nil {
+		t.Fatal("unable to marshal document:", err)
+	}
+	if _, err := Unmarshal(data, KindForwarding, FormatJSON, newTestSession); err == nil {
+		t.Error("document with mismatched kind decoded successfully")
+	}
+}
+
+// TestDocumentInvalid tests that invalid documents are rejected.
+func TestDocumentInvalid(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		data   string
+		format Format
+	}{
+		{`{"kind": "sync", "version": 2, "sessions": []}`, FormatJSON},
+		{`{"kind": "sync", "version": 1, "sessions": [{"unknown": 1}]}`, FormatJSON},
+		{"kind: sync\nversion: 1\nsessions:\n- port: invalid\n", FormatYAML},
+		{`{"kind": "sync"`, FormatJSON},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if _, err := Unmarshal([]byte(testCase.data), KindSynchronization, testCase.format, newTestSession); err == nil {
+			t.Errorf("test index %d: invalid document decoded successfully", i)
+		}
+	}
+}
+
+// TestSaveLoad tests saving and loading export documents.
+func TestSaveLoad(t *testing.T) {
+	// Create a temporary directory and defer its removal.
+	directory, err := ioutil.TempDir("", "mutagen_export")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Test each supported extension.
+	for _, name := range []string{"sessions.yaml", "sessions.yml", "sessions.json"} {
+		path := filepath.Join(directory, name)
+		if err := Save(path, KindForwarding, testSessions); err != nil {
+			t.Fatal("unable to save document:", err)
+		}
+		sessions, err := Load(path, KindForwarding, newTestSession)
+		if err != nil {
+			t.Fatal("unable to load document:", err)
+		}
+		checkTestSessions(t, sessions)
+	}
+
+	// Verify that an unknown extension is rejected.
+	if err := Save(filepath.Join(directory, "sessions.txt"), KindForwarding, testSessions); err == nil {
+		t.Error("document saved with unknown extension")
+	}
+}
@@ -0,0 +1,31 @@
+package export
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Format represents an export document encoding format.
+type Format uint8
+
+const (
+	// FormatYAML indicates YAML encoding.
+	FormatYAML Format = iota
+	// FormatJSON indicates JSON encoding.
+	FormatJSON
+)
+
+// FormatForPath determines the export document format based on the extension
+// of the specified path.
+func FormatForPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return FormatYAML, errors.Errorf("unable to determine export format for path: %s", path)
+	}
+}
@@ -0,0 +1,36 @@
+package export
+
+import (
+	"testing"
+)
+
+// TestFormatForPath tests FormatForPath.
+func TestFormatForPath(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		path        string
+		expected    Format
+		expectError bool
+	}{
+		{"sessions.yaml", FormatYAML, false},
+		{"sessions.yml", FormatYAML, false},
+		{"sessions.YML", FormatYAML, false},
+		{"sessions.json", FormatJSON, false},
+		{"/path/to/sessions.json", FormatJSON, false},
+		{"sessions", FormatYAML, true},
+		{"sessions.toml", FormatYAML, true},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if format, err := FormatForPath(testCase.path); err != nil {
+			if !testCase.expectError {
+				t.Errorf("unexpected error for path %s: %v", testCase.path, err)
+			}
+		} else if testCase.expectError {
+			t.Errorf("expected error for path %s", testCase.path)
+		} else if format != testCase.expected {
+			t.Errorf("format for path %s does not match expected: %d != %d", testCase.path, format, testCase.expected)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package export
+
+import (
+	"strconv"
+)
+
+// UniqueName generates a variant of the specified session name that doesn't
+// collide with any of the specified existing names by appending the smallest
+// numeric suffix (starting from 2) that yields an unused name. Since session
+// names may contain letters and numbers, the result remains a valid name. If
+// the name doesn't collide, then it is returned unmodified.
+func UniqueName(name string, existing map[string]bool) string {
+	if !existing[name] {
+		return name
+	}
+	for suffix := 2; ; suffix++ {
+		candidate := name + strconv.Itoa(suffix)
+		if !existing[candidate] {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package export
+
+import (
+	"testing"
+)
+
+// TestUniqueName tests UniqueName.
+func TestUniqueName(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		name     string
+		existing map[string]bool
+		expected string
+	}{
+		{"web", nil, "web"},
+		{"web", map[string]bool{"api": true}, "web"},
+		{"web", map[string]bool{"web": true}, "web2"},
+		{"web", map[string]bool{"web": true, "web2": true, "web3": true}, "web4"},
+		{"web", map[string]bool{"web": true, "web3": true}, "web2"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if result := UniqueName(testCase.name, testCase.existing); result != testCase.expected {
+			t.Errorf("unique name for %s does not match expected: %s != %s", testCase.name, result, testCase.expected)
+		}
+	}
+}
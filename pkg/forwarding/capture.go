@@ -0,0 +1,337 @@
+package forwarding
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	syncpkg "sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	forwardingurl "github.com/mutagen-io/mutagen/pkg/url/forwarding"
+)
+
+const (
+	// captureFirstClientPort is the first client port used for synthetic TCP
+	// streams in pcapng captures. Subsequent connections use subsequent ports,
+	// wrapping around as necessary.
+	captureFirstClientPort = 1024
+	// captureDefaultServerPort is the server port used for synthetic TCP
+	// streams in pcapng captures if one can't be determined from the session's
+	// destination.
+	captureDefaultServerPort = 1
+)
+
+// captureServerPort determines the server port to use for synthetic TCP streams
+// in pcapng captures based on the session's destination.
+func captureServerPort(session *Session) uint16 {
+	if _, address, err := forwardingurl.Parse(session.Destination.Path); err != nil {
+		return captureDefaultServerPort
+	} else if _, port, err := net.SplitHostPort(address); err != nil {
+		return captureDefaultServerPort
+	} else if value, err := strconv.ParseUint(port, 10, 16); err != nil || value == 0 {
+		return captureDefaultServerPort
+	} else {
+		return uint16(value)
+	}
+}
+
+// captureStream represents the capture state for an individual connection.
+type captureStream struct {
+	// index is the zero-based index of the connection within the capture.
+	index uint64
+	// tcp is the synthetic TCP stream for the connection. It is only used for
+	// pcapng captures.
+	tcp *syntheticTCPStream
+	// sourceFile is the file receiving data sent from the source side of the
+	// connection. It is only used for raw captures.
+	sourceFile *os.File
+	// destinationFile is the file receiving data sent from the destination
+	// side of the connection. It is only used for raw captures.
+	destinationFile *os.File
+	// closed indicates whether or not the stream has been closed.
+	closed bool
+}
+
+// capture records traffic for forwarded connections. It is safe for concurrent
+// usage.
+type capture struct {
+	// format is the capture format.
+	format CaptureFormat
+	// path is the output path.
+	path string
+	// connectionLimit is the maximum number of connections to capture. A value
+	// of 0 indicates no limit.
+	connectionLimit uint64
+	// serverPort is the server port to use for synthetic TCP streams.
+	serverPort uint16
+	// lock guards the remaining fields.
+	lock syncpkg.Mutex
+	// file is the output file. It is only used for pcapng captures.
+	file *os.File
+	// writer is the pcapng writer. It is only used for pcapng captures.
+	writer *pcapngWriter
+	// connections is the number of connections captured so far.
+	connections uint64
+	// streams are the currently open streams.
+	streams map[*captureStream]bool
+	// closed indicates whether or not the capture has been closed.
+	closed bool
+	// err is the first error encountered while writing capture output, if any.
+	// Once an error occurs, no further output is written.
+	err error
+}
+
+// newCapture creates a new capture. For pcapng captures, path specifies the
+// output file. For raw captures, path specifies an output directory, which
+// will be created if it doesn't exist.
+func newCapture(format CaptureFormat, path string, connectionLimit uint64, serverPort uint16) (*capture, error) {
+	// Convert the default format.
+	if format.IsDefault() {
+		format = CaptureFormat_CaptureFormatPCAPNG
+	}
+
+	// Create the capture.
+	result := &capture{
+		format:          format,
+		path:            path,
+		connectionLimit: connectionLimit,
+		serverPort:      serverPort,
+		streams:         make(map[*captureStream]bool),
+	}
+
+	// Perform format-specific initialization.
+	switch format {
+	case CaptureFormat_CaptureFormatPCAPNG:
+		file, err := os.Create(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to create capture file")
+		}
+		writer, err := newPCAPNGWriter(file)
+		if err != nil {
+			file.Close()
+			return nil, errors.Wrap(err, "unable to write capture file header")
+		}
+		result.file = file
+		result.writer = writer
+	case CaptureFormat_CaptureFormatRaw:
+		if err := os.MkdirAll(path, 0700); err != nil {
+			return nil, errors.Wrap(err, "unable to create capture directory")
+		}
+	default:
+		return nil, errors.New("unsupported capture format")
+	}
+
+	// Success.
+	return result, nil
+}
+
+// failWithoutLock records an output error and stops further output. It must be
+// called with the capture lock held.
+func (c *capture) failWithoutLock(err error) {
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+// writePacketsWithoutLock writes packets to a pcapng capture. It must be called
+// with the capture lock held.
+func (c *capture) writePacketsWithoutLock(packets [][]byte) {
+	now := time.Now()
+	for _, packet := range packets {
+		if c.err != nil {
+			return
+		} else if err := c.writer.writePacket(now, packet); err != nil {
+			c.failWithoutLock(errors.Wrap(err, "unable to write packet"))
+		}
+	}
+}
+
+// open starts capturing a new connection. It returns nil if the capture has
+// been closed, has failed, or has reached its connection limit.
+func (c *capture) open() *captureStream {
+	// Lock the capture and defer its release.
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Check whether or not we should capture this connection.
+	if c.closed || c.err != nil {
+		return nil
+	} else if c.connectionLimit > 0 && c.connections >= c.connectionLimit {
+		return nil
+	}
+
+	// Create the stream.
+	stream := &captureStream{index: c.connections}
+	c.connections++
+
+	// Perform format-specific initialization.
+	if c.format == CaptureFormat_CaptureFormatPCAPNG {
+		stream.tcp = &syntheticTCPStream{
+			clientPort: uint16(captureFirstClientPort + stream.index%(65536-captureFirstClientPort)),
+			serverPort: c.serverPort,
+		}
+		c.writePacketsWithoutLock(stream.tcp.open())
+	} else {
+		prefix := filepath.Join(c.path, fmt.Sprintf("connection-%d", stream.index))
+		var err error
+		if stream.sourceFile, err = os.Create(prefix + "-source.raw"); err != nil {
+			c.failWithoutLock(errors.Wrap(err, "unable to create source capture file"))
+			return nil
+		} else if stream.destinationFile, err = os.Create(prefix + "-destination.raw"); err != nil {
+			stream.sourceFile.Close()
+			c.failWithoutLock(errors.Wrap(err, "unable to create destination capture file"))
+			return nil
+		}
+	}
+
+	// Register the stream.
+	c.streams[stream] = true
+
+	// Success.
+	return stream
+}
+
+// record records data sent on a connection. The fromSource parameter indicates
+// the direction in which the data was sent.
+func (c *capture) record(stream *captureStream, fromSource bool, data []byte) {
+	// Lock the capture and defer its release.
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Ignore data for closed streams or failed captures.
+	if stream.closed || c.err != nil {
+		return
+	}
+
+	// Record the data.
+	if c.format == CaptureFormat_CaptureFormatPCAPNG {
+		c.writePacketsWithoutLock(stream.tcp.data(fromSource, data))
+	} else {
+		file := stream.destinationFile
+		if fromSource {
+			file = stream.sourceFile
+		}
+		if _, err := file.Write(data); err != nil {
+			c.failWithoutLock(errors.Wrap(err, "unable to write raw capture data"))
+		}
+	}
+}
+
+// closeStreamWithoutLock finishes capturing a connection. It must be called
+// with the capture lock held.
+func (c *capture) closeStreamWithoutLock(stream *captureStream) {
+	// If the stream is already closed, then there's nothing to do.
+	if stream.closed {
+		return
+	}
+
+	// Perform format-specific finalization.
+	if c.format == CaptureFormat_CaptureFormatPCAPNG {
+		if c.err == nil {
+			c.writePacketsWithoutLock(stream.tcp.close())
+		}
+	} else {
+		if err := stream.sourceFile.Close(); err != nil {
+			c.failWithoutLock(errors.Wrap(err, "unable to close source capture file"))
+		}
+		if err := stream.destinationFile.Close(); err != nil {
+			c.failWithoutLock(errors.Wrap(err, "unable to close destination capture file"))
+		}
+	}
+
+	// Mark the stream as closed and deregister it.
+	stream.closed = true
+	delete(c.streams, stream)
+}
+
+// closeStream finishes capturing a connection.
+func (c *capture) closeStream(stream *captureStream) {
+	c.lock.Lock()
+	c.closeStreamWithoutLock(stream)
+	c.lock.Unlock()
+}
+
+// close terminates the capture, finalizing any open streams. It returns the
+// first error encountered during capture, if any.
+func (c *capture) close() error {
+	// Lock the capture and defer its release.
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// If we're already closed, then there's nothing to do.
+	if c.closed {
+		return c.err
+	}
+
+	// Close any open streams.
+	for stream := range c.streams {
+		c.closeStreamWithoutLock(stream)
+	}
+
+	// Close the output file, if any.
+	if c.file != nil {
+		if err := c.file.Close(); err != nil {
+			c.failWithoutLock(errors.Wrap(err, "unable to close capture file"))
+		}
+	}
+
+	// Mark the capture as closed.
+	c.closed = true
+
+	// Done.
+	return c.err
+}
+
+// wrap wraps a connection accepted from the source so that its traffic is
+// recorded. If the connection shouldn't be captured, then it is returned
+// unmodified.
+func (c *capture) wrap(connection net.Conn) net.Conn {
+	if stream := c.open(); stream != nil {
+		return &capturedConnection{Conn: connection, capture: c, stream: stream}
+	}
+	return connection
+}
+
+// capturedConnection is a net.Conn wrapper that records traffic to a capture.
+// It wraps connections accepted from the source, so data read from the
+// connection was sent by the source side and data written to the connection
+// was sent by the destination side.
+type capturedConnection struct {
+	net.Conn
+	// capture is the associated capture.
+	capture *capture
+	// stream is the associated capture stream.
+	stream *captureStream
+	// closeOnce ensures that the stream is only closed once.
+	closeOnce syncpkg.Once
+}
+
+// Read implements net.Conn.Read.
+func (c *capturedConnection) Read(buffer []byte) (int, error) {
+	n, err := c.Conn.Read(buffer)
+	if n > 0 {
+		c.capture.record(c.stream, true, buffer[:n])
+	}
+	return n, err
+}
+
+// Write implements net.Conn.Write.
+func (c *capturedConnection) Write(buffer []byte) (int, error) {
+	n, err := c.Conn.Write(buffer)
+	if n > 0 {
+		c.capture.record(c.stream, false, buffer[:n])
+	}
+	return n, err
+}
+
+// Close implements net.Conn.Close.
+func (c *capturedConnection) Close() error {
+	c.closeOnce.Do(func() {
+		c.capture.closeStream(c.stream)
+	})
+	return c.Conn.Close()
+}
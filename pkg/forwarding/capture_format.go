@@ -0,0 +1,58 @@
+package forwarding
+
+import (
+	"github.com/pkg/errors"
+)
+
+// IsDefault indicates whether or not the capture format is
+// CaptureFormat_CaptureFormatDefault.
+func (f CaptureFormat) IsDefault() bool {
+	return f == CaptureFormat_CaptureFormatDefault
+}
+
+// UnmarshalText implements the text unmarshalling interface used when loading
+// from text-based formats.
+func (f *CaptureFormat) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a capture format.
+	switch text {
+	case "pcapng":
+		*f = CaptureFormat_CaptureFormatPCAPNG
+	case "raw":
+		*f = CaptureFormat_CaptureFormatRaw
+	default:
+		return errors.Errorf("unknown capture format specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular capture format is a valid,
+// non-default value.
+func (f CaptureFormat) Supported() bool {
+	switch f {
+	case CaptureFormat_CaptureFormatPCAPNG:
+		return true
+	case CaptureFormat_CaptureFormatRaw:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a capture format.
+func (f CaptureFormat) Description() string {
+	switch f {
+	case CaptureFormat_CaptureFormatDefault:
+		return "Default"
+	case CaptureFormat_CaptureFormatPCAPNG:
+		return "pcapng"
+	case CaptureFormat_CaptureFormatRaw:
+		return "Raw"
+	default:
+		return "Unknown"
+	}
+}
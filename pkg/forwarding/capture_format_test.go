@@ -0,0 +1,91 @@
+package forwarding
+
+import (
+	"testing"
+)
+
+// TestCaptureFormatUnmarshal tests that unmarshaling from a string
+// specification succeeds for CaptureFormat.
+func TestCaptureFormatUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text           string
+		expectedFormat CaptureFormat
+		expectFailure  bool
+	}{
+		{"", CaptureFormat_CaptureFormatDefault, true},
+		{"asdf", CaptureFormat_CaptureFormatDefault, true},
+		{"pcapng", CaptureFormat_CaptureFormatPCAPNG, false},
+		{"raw", CaptureFormat_CaptureFormatRaw, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var format CaptureFormat
+		if err := format.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if format != testCase.expectedFormat {
+			t.Errorf(
+				"unmarshaled format (%s) does not match expected (%s)",
+				format,
+				testCase.expectedFormat,
+			)
+		}
+	}
+}
+
+// TestCaptureFormatSupported tests that CaptureFormat support
+// detection works as expected.
+func TestCaptureFormatSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		format          CaptureFormat
+		expectSupported bool
+	}{
+		{CaptureFormat_CaptureFormatDefault, false},
+		{CaptureFormat_CaptureFormatPCAPNG, true},
+		{CaptureFormat_CaptureFormatRaw, true},
+		{(CaptureFormat_CaptureFormatRaw + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.format.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"format support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestCaptureFormatDescription tests that CaptureFormat description
+// generation works as expected.
+func TestCaptureFormatDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		format              CaptureFormat
+		expectedDescription string
+	}{
+		{CaptureFormat_CaptureFormatDefault, "Default"},
+		{CaptureFormat_CaptureFormatPCAPNG, "pcapng"},
+		{CaptureFormat_CaptureFormatRaw, "Raw"},
+		{(CaptureFormat_CaptureFormatRaw + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.format.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"format description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
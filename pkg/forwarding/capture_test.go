@@ -0,0 +1,127 @@
+package forwarding
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// exchangeThroughCapture wraps one side of a pipe with the specified capture,
+// sends a request from the other side, and replies, closing both sides when
+// done.
+func exchangeThroughCapture(t *testing.T, c *capture, request, response string) {
+	// Create a pipe and wrap the incoming side.
+	incoming, remote := net.Pipe()
+	incoming = c.wrap(incoming)
+	defer incoming.Close()
+	defer remote.Close()
+
+	// Send the request in the background.
+	go remote.Write([]byte(request))
+
+	// Read the request from the wrapped side.
+	buffer := make([]byte, len(request))
+	if _, err := io.ReadFull(incoming, buffer); err != nil {
+		t.Fatal("unable to read request:", err)
+	}
+
+	// Read the response from the remote side in the background. We send the
+	// response synchronously to ensure that it's recorded before closure.
+	readErrors := make(chan error, 1)
+	go func() {
+		_, err := io.ReadFull(remote, make([]byte, len(response)))
+		readErrors <- err
+	}()
+	if _, err := incoming.Write([]byte(response)); err != nil {
+		t.Fatal("unable to write response:", err)
+	} else if err = <-readErrors; err != nil {
+		t.Fatal("unable to read response:", err)
+	}
+}
+
+// TestRawCapture tests raw traffic capture and connection limits.
+func TestRawCapture(t *testing.T) {
+	// Create a temporary directory and defer its removal.
+	directory, err := ioutil.TempDir("", "mutagen_forwarding_capture")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Create a raw capture limited to a single connection.
+	c, err := newCapture(CaptureFormat_CaptureFormatRaw, directory, 1, 80)
+	if err != nil {
+		t.Fatal("unable to create capture:", err)
+	}
+
+	// Perform two exchanges, only the first of which should be captured.
+	exchangeThroughCapture(t, c, "request", "response")
+	exchangeThroughCapture(t, c, "ignored", "ignored")
+
+	// Close the capture.
+	if err := c.close(); err != nil {
+		t.Fatal("capture failed:", err)
+	}
+
+	// Verify the captured data.
+	expected := map[string]string{
+		"connection-0-source.raw":      "request",
+		"connection-0-destination.raw": "response",
+	}
+	for name, contents := range expected {
+		if data, err := ioutil.ReadFile(filepath.Join(directory, name)); err != nil {
+			t.Error("unable to read capture file:", err)
+		} else if string(data) != contents {
+			t.Errorf("capture file %s contents mismatch: %s != %s", name, string(data), contents)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(directory, "connection-1-source.raw")); !os.IsNotExist(err) {
+		t.Error("connection beyond limit was captured")
+	}
+}
+
+// TestPCAPNGCapture tests pcapng traffic capture.
+func TestPCAPNGCapture(t *testing.T) {
+	// Create a temporary directory and defer its removal.
+	directory, err := ioutil.TempDir("", "mutagen_forwarding_capture")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Create a pcapng capture using the default format.
+	path := filepath.Join(directory, "capture.pcapng")
+	c, err := newCapture(CaptureFormat_CaptureFormatDefault, path, 0, 80)
+	if err != nil {
+		t.Fatal("unable to create capture:", err)
+	}
+
+	// Perform an exchange and close the capture.
+	exchangeThroughCapture(t, c, "request", "response")
+	if err := c.close(); err != nil {
+		t.Fatal("capture failed:", err)
+	}
+
+	// Ensure that the capture contains the headers and packets for the
+	// handshake, data, and closing handshake (each in its own block).
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal("unable to query capture file:", err)
+	}
+	minimumSize := int64(28 + 20 + 8*(32+syntheticTCPHeaderSize))
+	if info.Size() < minimumSize {
+		t.Error("capture file smaller than expected:", info.Size(), "<", minimumSize)
+	}
+}
+
+// TestStopCaptureWithoutCapture tests that stopping a capture for a session
+// without a capture in progress succeeds.
+func TestStopCaptureWithoutCapture(t *testing.T) {
+	c := &controller{}
+	if err := c.stopCapture(); err != nil {
+		t.Error("stopping nonexistent capture failed:", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	forwardingurl "github.com/mutagen-io/mutagen/pkg/url/forwarding"
 )
 
 // EnsureValid ensures that Configuration's invariants are respected. The
@@ -15,6 +16,37 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 		return errors.New("nil configuration")
 	}
 
+	// We don't verify the dial retry count or interval because any value is
+	// technically valid (though very large values might not be sane).
+
+	// Verify that the TLS mode is unspecified or supported for usage.
+	if !(c.Tls.IsDefault() || c.Tls.Supported()) {
+		return errors.New("unknown or unsupported TLS mode")
+	}
+
+	// Verify that TLS certificates and keys are specified together.
+	if (c.TlsCertificate == "") != (c.TlsKey == "") {
+		return errors.New("TLS certificate and key must be specified together")
+	}
+
+	// We don't verify that TLS parameters are only specified when TLS is
+	// enabled because TLS might only be enabled by a higher-priority
+	// configuration. We also don't verify the TLS server name because there's
+	// not really any way to know if it's sane.
+
+	// Verify that HTTP routes are well-formed. We don't allow HTTP targets
+	// since HTTP awareness is only implemented on the source side.
+	for host, target := range c.HttpRoutes {
+		if host == "" {
+			return errors.New("HTTP route with empty host")
+		}
+		if protocol, _, err := forwardingurl.Parse(target); err != nil {
+			return errors.Wrapf(err, "invalid target for HTTP route \"%s\"", host)
+		} else if protocol == "http" {
+			return errors.Errorf("HTTP target for HTTP route \"%s\"", host)
+		}
+	}
+
 	// Verify that the socket overwrite mode is unspecified or supported for
 	// usage.
 	if !(c.SocketOverwriteMode.IsDefault() || c.SocketOverwriteMode.Supported()) {
@@ -48,6 +80,59 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 	// Create the resulting configuration.
 	result := &Configuration{}
 
+	// Merge dial retry count.
+	if higher.DialRetryCount != 0 {
+		result.DialRetryCount = higher.DialRetryCount
+	} else {
+		result.DialRetryCount = lower.DialRetryCount
+	}
+
+	// Merge dial retry interval.
+	if higher.DialRetryInterval != 0 {
+		result.DialRetryInterval = higher.DialRetryInterval
+	} else {
+		result.DialRetryInterval = lower.DialRetryInterval
+	}
+
+	// Merge TLS mode.
+	if !higher.Tls.IsDefault() {
+		result.Tls = higher.Tls
+	} else {
+		result.Tls = lower.Tls
+	}
+
+	// Merge TLS certificate and key. These are merged as a pair to avoid mixing
+	// certificates and keys from different configurations.
+	if higher.TlsCertificate != "" {
+		result.TlsCertificate = higher.TlsCertificate
+		result.TlsKey = higher.TlsKey
+	} else {
+		result.TlsCertificate = lower.TlsCertificate
+		result.TlsKey = lower.TlsKey
+	}
+
+	// Merge TLS certificate authority.
+	if higher.TlsCertificateAuthority != "" {
+		result.TlsCertificateAuthority = higher.TlsCertificateAuthority
+	} else {
+		result.TlsCertificateAuthority = lower.TlsCertificateAuthority
+	}
+
+	// Merge TLS server name.
+	if higher.TlsServerName != "" {
+		result.TlsServerName = higher.TlsServerName
+	} else {
+		result.TlsServerName = lower.TlsServerName
+	}
+
+	// Merge HTTP routes. Route maps aren't merged key-by-key because doing so
+	// would make it impossible to remove a lower-priority route.
+	if len(higher.HttpRoutes) > 0 {
+		result.HttpRoutes = higher.HttpRoutes
+	} else {
+		result.HttpRoutes = lower.HttpRoutes
+	}
+
 	// Merge socket overwrite mode.
 	if !higher.SocketOverwriteMode.IsDefault() {
 		result.SocketOverwriteMode = higher.SocketOverwriteMode
@@ -24,6 +24,45 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 // commands to specify configuration options, for loading global configuration
 // options, and for storing a merged configuration inside sessions.
 type Configuration struct {
+	// DialRetryCount specifies the number of times that a failed dial of the
+	// destination should be retried before the corresponding incoming
+	// connection is rejected. A value of 0 disables retries.
+	DialRetryCount uint32 `protobuf:"varint,1,opt,name=dialRetryCount,proto3" json:"dialRetryCount,omitempty"`
+	// DialRetryInterval specifies the initial interval (in milliseconds) to
+	// wait before retrying a failed dial of the destination. The interval
+	// doubles with each subsequent retry. A value of 0 specifies that the
+	// default interval for the session version should be used.
+	DialRetryInterval uint32 `protobuf:"varint,2,opt,name=dialRetryInterval,proto3" json:"dialRetryInterval,omitempty"`
+	// TLS specifies whether or not connections should be wrapped in TLS.
+	// Listener (source) endpoints will terminate TLS for incoming connections
+	// and dialer (destination) endpoints will originate TLS for outgoing
+	// connections. The default mode is treated as disabled.
+	Tls TLSMode `protobuf:"varint,3,opt,name=tls,proto3,enum=forwarding.TLSMode" json:"tls,omitempty"`
+	// TLSCertificate specifies the path to a PEM-encoded certificate (or
+	// certificate chain) to use for TLS. It is required for listener endpoints
+	// and optional (for client certificate authentication) for dialer
+	// endpoints. The path is interpreted on the endpoint's host.
+	TlsCertificate string `protobuf:"bytes,4,opt,name=tlsCertificate,proto3" json:"tlsCertificate,omitempty"`
+	// TLSKey specifies the path to the PEM-encoded private key corresponding to
+	// TLSCertificate. The path is interpreted on the endpoint's host.
+	TlsKey string `protobuf:"bytes,5,opt,name=tlsKey,proto3" json:"tlsKey,omitempty"`
+	// TLSCertificateAuthority specifies the path to a PEM-encoded bundle of
+	// certificate authority certificates. For listener endpoints, it enables
+	// client certificate authentication against these authorities. For dialer
+	// endpoints, it is used to verify the target instead of the system roots.
+	// The path is interpreted on the endpoint's host.
+	TlsCertificateAuthority string `protobuf:"bytes,6,opt,name=tlsCertificateAuthority,proto3" json:"tlsCertificateAuthority,omitempty"`
+	// TLSServerName specifies the server name to use for verifying the target
+	// of dialer endpoints (and for server name indication). If empty, the host
+	// component of the dialing address is used.
+	TlsServerName string `protobuf:"bytes,7,opt,name=tlsServerName,proto3" json:"tlsServerName,omitempty"`
+	// HTTPRoutes specifies alternative targets for HTTP-aware sessions (i.e.
+	// sessions with an "http" source protocol), keyed by the host name from the
+	// HTTP Host header. Each target is a forwarding endpoint specification
+	// (e.g. "tcp:localhost:3000") that is dialed by the destination endpoint.
+	// Requests for hosts without a route are forwarded to the destination's
+	// default target.
+	HttpRoutes map[string]string `protobuf:"bytes,8,rep,name=httpRoutes,proto3" json:"httpRoutes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// SocketOverwriteMode specifies whether or not existing Unix domain sockets
 	// should be overwritten when creating new listener sockets.
 	SocketOverwriteMode SocketOverwriteMode `protobuf:"varint,41,opt,name=socketOverwriteMode,proto3,enum=forwarding.SocketOverwriteMode" json:"socketOverwriteMode,omitempty"`
@@ -66,6 +105,62 @@ func (m *Configuration) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Configuration proto.InternalMessageInfo
 
+func (m *Configuration) GetDialRetryCount() uint32 {
+	if m != nil {
+		return m.DialRetryCount
+	}
+	return 0
+}
+
+func (m *Configuration) GetDialRetryInterval() uint32 {
+	if m != nil {
+		return m.DialRetryInterval
+	}
+	return 0
+}
+
+func (m *Configuration) GetTls() TLSMode {
+	if m != nil {
+		return m.Tls
+	}
+	return TLSMode_TLSModeDefault
+}
+
+func (m *Configuration) GetTlsCertificate() string {
+	if m != nil {
+		return m.TlsCertificate
+	}
+	return ""
+}
+
+func (m *Configuration) GetTlsKey() string {
+	if m != nil {
+		return m.TlsKey
+	}
+	return ""
+}
+
+func (m *Configuration) GetTlsCertificateAuthority() string {
+	if m != nil {
+		return m.TlsCertificateAuthority
+	}
+	return ""
+}
+
+func (m *Configuration) GetTlsServerName() string {
+	if m != nil {
+		return m.TlsServerName
+	}
+	return ""
+}
+
+func (m *Configuration) GetHttpRoutes() map[string]string {
+	if m != nil {
+		return m.HttpRoutes
+	}
+	return nil
+}
+
 func (m *Configuration) GetSocketOverwriteMode() SocketOverwriteMode {
 	if m != nil {
 		return m.SocketOverwriteMode
@@ -96,24 +191,38 @@ func (m *Configuration) GetSocketPermissionMode() uint32 {
 
 func init() {
 	proto.RegisterType((*Configuration)(nil), "forwarding.Configuration")
+	proto.RegisterMapType((map[string]string)(nil), "forwarding.Configuration.HttpRoutesEntry")
 }
 
 func init() { proto.RegisterFile("forwarding/configuration.proto", fileDescriptor_5e51e4766fb5528c) }
 
 var fileDescriptor_5e51e4766fb5528c = []byte{
-	// 224 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x4b, 0xcb, 0x2f, 0x2a,
-	0x4f, 0x2c, 0x4a, 0xc9, 0xcc, 0x4b, 0xd7, 0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0x2f, 0x2d, 0x4a,
-	0x2c, 0xc9, 0xcc, 0xcf, 0xd3, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x42, 0xc8, 0x4b, 0xa9,
-	0x21, 0xa9, 0x2d, 0xce, 0x4f, 0xce, 0x4e, 0x2d, 0x89, 0xcf, 0x2f, 0x4b, 0x2d, 0x2a, 0x2f, 0xca,
-	0x2c, 0x49, 0x8d, 0xcf, 0xcd, 0x4f, 0x49, 0x85, 0xe8, 0x51, 0xba, 0xc5, 0xc8, 0xc5, 0xeb, 0x8c,
-	0x6c, 0x96, 0x50, 0x20, 0x97, 0x30, 0x44, 0x83, 0x3f, 0x4c, 0xbd, 0x6f, 0x7e, 0x4a, 0xaa, 0x84,
-	0xa6, 0x02, 0xa3, 0x06, 0x9f, 0x91, 0xbc, 0x1e, 0xc2, 0x5c, 0xbd, 0x60, 0x4c, 0x65, 0x41, 0xd8,
-	0xf4, 0x0a, 0x29, 0x70, 0x71, 0x43, 0x85, 0xcb, 0xf3, 0x52, 0x8b, 0x24, 0xb4, 0x14, 0x18, 0x35,
-	0x38, 0x83, 0x90, 0x85, 0x10, 0x2a, 0xdc, 0x8b, 0xf2, 0x4b, 0x0b, 0x24, 0xb4, 0x91, 0x55, 0x80,
-	0x85, 0x84, 0x8c, 0xb8, 0x44, 0x20, 0xdc, 0x80, 0xd4, 0xa2, 0xdc, 0xcc, 0xe2, 0xe2, 0xcc, 0xfc,
-	0x3c, 0xb0, 0xbb, 0x74, 0x14, 0x18, 0x35, 0x78, 0x83, 0xb0, 0xca, 0x39, 0xe9, 0x45, 0xe9, 0xa4,
-	0x67, 0x96, 0x64, 0x94, 0x26, 0xe9, 0x25, 0xe7, 0xe7, 0xea, 0xe7, 0x96, 0x96, 0x24, 0xa6, 0xa7,
-	0xe6, 0xe9, 0x66, 0xe6, 0xc3, 0x98, 0xfa, 0x05, 0xd9, 0xe9, 0xfa, 0x08, 0x0f, 0x25, 0xb1, 0x81,
-	0xc3, 0xc4, 0x18, 0x10, 0x00, 0x00, 0xff, 0xff, 0x58, 0x20, 0x56, 0xf4, 0x69, 0x01, 0x00, 0x00,
+	// 424 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xcb, 0x6f, 0x13, 0x31,
+	0x10, 0xc6, 0xb5, 0x0d, 0x09, 0x64, 0xaa, 0x14, 0x70, 0x2b, 0x30, 0x39, 0xc0, 0x0a, 0x41, 0x95,
+	0x42, 0xd8, 0x48, 0xe1, 0x52, 0x21, 0x71, 0x80, 0x08, 0x41, 0xc5, 0xdb, 0xe1, 0xc4, 0xa5, 0xda,
+	0x26, 0x93, 0x8d, 0x95, 0x5d, 0x3b, 0xb2, 0xc7, 0x89, 0xf6, 0xc8, 0x7f, 0x8e, 0xe2, 0xb4, 0x5d,
+	0x27, 0x4d, 0x6f, 0x9e, 0xef, 0xfb, 0x8d, 0xed, 0x79, 0xc0, 0xd3, 0x89, 0x36, 0xcb, 0xd4, 0x8c,
+	0xa5, 0xca, 0x7a, 0x23, 0xad, 0x26, 0x32, 0x73, 0x26, 0x25, 0xa9, 0x55, 0x32, 0x37, 0x9a, 0x34,
+	0x83, 0xca, 0x6f, 0x1f, 0x07, 0xac, 0xd5, 0xa3, 0x19, 0xd2, 0xb9, 0x5e, 0xa0, 0x59, 0x1a, 0x49,
+	0x78, 0x5e, 0xe8, 0x31, 0xae, 0x73, 0xda, 0x4f, 0x02, 0x8e, 0x72, 0x1b, 0x58, 0xcf, 0xff, 0xd5,
+	0xa1, 0x35, 0x08, 0x9f, 0x61, 0xc7, 0x70, 0x30, 0x96, 0x69, 0x2e, 0x90, 0x4c, 0x39, 0xd0, 0x4e,
+	0x11, 0x8f, 0xe2, 0xa8, 0xd3, 0x12, 0x5b, 0x2a, 0xeb, 0xc2, 0xc3, 0x6b, 0xe5, 0x4c, 0x11, 0x9a,
+	0x45, 0x9a, 0xf3, 0x3d, 0x8f, 0xde, 0x34, 0xd8, 0x4b, 0xa8, 0x51, 0x6e, 0x79, 0x2d, 0x8e, 0x3a,
+	0x07, 0xfd, 0xc3, 0xa4, 0xfa, 0x50, 0xf2, 0xe7, 0xdb, 0xf0, 0xbb, 0x1e, 0xa3, 0x58, 0xf9, 0xab,
+	0xc7, 0x29, 0xb7, 0x03, 0x34, 0x24, 0x27, 0x72, 0x94, 0x12, 0xf2, 0x3b, 0x71, 0xd4, 0x69, 0x8a,
+	0x2d, 0x95, 0x3d, 0x82, 0x06, 0xe5, 0xf6, 0x2b, 0x96, 0xbc, 0xee, 0xfd, 0xcb, 0x88, 0x9d, 0xc2,
+	0xe3, 0x4d, 0xf2, 0x83, 0xa3, 0xa9, 0x36, 0x92, 0x4a, 0xde, 0xf0, 0xe0, 0x6d, 0x36, 0x7b, 0x01,
+	0x2d, 0xca, 0xed, 0x10, 0xcd, 0x02, 0xcd, 0x8f, 0xb4, 0x40, 0x7e, 0xd7, 0xf3, 0x9b, 0x22, 0x3b,
+	0x03, 0x98, 0x12, 0xcd, 0x85, 0x76, 0x84, 0x96, 0xdf, 0x8b, 0x6b, 0x9d, 0xfd, 0xfe, 0x49, 0x58,
+	0xcd, 0x46, 0x2f, 0x93, 0x2f, 0xd7, 0xec, 0x27, 0x45, 0xa6, 0x14, 0x41, 0x32, 0xfb, 0x0d, 0x87,
+	0xeb, 0x99, 0xfd, 0xbc, 0x1a, 0xd9, 0xaa, 0x0d, 0xfc, 0xc4, 0x77, 0xe8, 0x59, 0x78, 0xe7, 0xf0,
+	0x26, 0x26, 0x76, 0xe5, 0xb2, 0x18, 0xf6, 0x2f, 0xe5, 0xa5, 0x42, 0xc3, 0x5f, 0xf9, 0x0a, 0x42,
+	0xa9, 0x22, 0x3e, 0x1b, 0xed, 0xe6, 0xfc, 0x75, 0x48, 0x78, 0x89, 0xf5, 0xe1, 0x68, 0x1d, 0xfe,
+	0x42, 0x53, 0x48, 0x6b, 0xa5, 0x56, 0xfe, 0x5f, 0x5d, 0x3f, 0xd9, 0x9d, 0x5e, 0xfb, 0x3d, 0xdc,
+	0xdf, 0xaa, 0x94, 0x3d, 0x80, 0xda, 0x0c, 0x4b, 0xbf, 0x3a, 0x4d, 0xb1, 0x3a, 0xb2, 0x23, 0xa8,
+	0x2f, 0xd2, 0xdc, 0xa1, 0xdf, 0x91, 0xa6, 0x58, 0x07, 0xef, 0xf6, 0x4e, 0xa3, 0x8f, 0xc9, 0xdf,
+	0x6e, 0x26, 0x69, 0xea, 0x2e, 0x92, 0x91, 0x2e, 0x7a, 0x85, 0xa3, 0x34, 0x43, 0xf5, 0x46, 0xea,
+	0xab, 0x63, 0x6f, 0x3e, 0xcb, 0x7a, 0x55, 0x3f, 0x2e, 0x1a, 0x7e, 0x75, 0xdf, 0xfe, 0x1f, 0x00,
+	0x70, 0xd7, 0xa9, 0xa5, 0x2b, 0x03, 0x00, 0x00,
 }
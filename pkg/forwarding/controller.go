@@ -37,19 +37,22 @@ type controller struct {
 	// and the state member.
 	stateLock *state.TrackingLock
 	// session encodes the associated session metadata. It is considered static
-	// and safe for concurrent access except for its Paused field, for which the
-	// stateLock member should be held. It should be saved to disk any time it
-	// is modified.
+	// and safe for concurrent access except for its Paused, Configuration,
+	// ConfigurationSource, ConfigurationDestination, Name, and Labels fields,
+	// for which the stateLock member should be held (though the forwarding loop
+	// may access them without the lock since they can only change while the
+	// loop isn't running). It should be saved to disk any time it is modified.
 	session *Session
 	// mergedSourceConfiguration is the source-specific configuration object
 	// (computed from the core configuration and source-specific overrides). It
-	// is considered static and safe for concurrent access. It is a derived
-	// field and not saved to disk.
+	// may only be modified while no forwarding loop is running and while
+	// holding the lifecycleLock. It is a derived field and not saved to disk.
 	mergedSourceConfiguration *Configuration
 	// mergedDestinationConfiguration is the destination-specific configuration
 	// object (computed from the core configuration and destination-specific
-	// overrides). It is considered static and safe for concurrent access. It is
-	// a derived field and not saved to disk.
+	// overrides). It may only be modified while no forwarding loop is running
+	// and while holding the lifecycleLock. It is a derived field and not saved
+	// to disk.
 	mergedDestinationConfiguration *Configuration
 	// state represents the current synchronization state.
 	state *State
@@ -70,6 +73,13 @@ type controller struct {
 	cancel contextpkg.CancelFunc
 	// done will be closed by the current synchronization loop when it exits.
 	done chan struct{}
+	// requestLog is the log of recent requests for HTTP-aware sessions. It is
+	// safe for concurrent access.
+	requestLog httpRequestLog
+	// captureLock guards the capture member.
+	captureLock syncpkg.Mutex
+	// capture is the active traffic capture, if any.
+	capture *capture
 }
 
 // newSession creates a new session and corresponding controller.
@@ -310,6 +320,14 @@ func (c *controller) resume(prompter string) error {
 		c.done = nil
 	}
 
+	// Connect and start a forwarding loop.
+	return c.start(prompter)
+}
+
+// start marks the session as unpaused, attempts to connect to both endpoints,
+// and starts a forwarding loop. It must be called with the lifecycle lock held
+// and with no forwarding loop running.
+func (c *controller) start(prompter string) error {
 	// Mark the session as unpaused and save it to disk.
 	c.stateLock.Lock()
 	c.session.Paused = false
@@ -373,6 +391,74 @@ func (c *controller) resume(prompter string) error {
 	return nil
 }
 
+// update replaces the session configuration, name, and labels and saves the
+// session to disk. If a forwarding loop is running, then it's stopped and
+// restarted with the new configuration, which will close any connections that
+// are currently being forwarded.
+func (c *controller) update(
+	configuration, configurationSource, configurationDestination *Configuration,
+	name string,
+	labels map[string]string,
+	prompter string,
+) error {
+	// Update status.
+	prompt.Message(prompter, fmt.Sprintf("Updating session %s...", c.session.Identifier))
+
+	// Lock the controller's lifecycle and defer its release.
+	c.lifecycleLock.Lock()
+	defer c.lifecycleLock.Unlock()
+
+	// Don't allow any update operations if the controller is disabled.
+	if c.disabled {
+		return errors.New("controller disabled")
+	}
+
+	// Stop any existing forwarding loop, recording whether or not one was
+	// running so that we can restart it.
+	running := c.cancel != nil
+	if running {
+		// Cancel the forwarding loop and wait for it to finish.
+		c.cancel()
+		<-c.done
+
+		// Nil out any lifecycle state.
+		c.cancel = nil
+		c.done = nil
+	}
+
+	// Update the session and save it to disk. We replace the configuration and
+	// label members rather than modifying them because they may be shared with
+	// state copies that are currently in use. The merged configurations are
+	// only accessed by the forwarding loop, which isn't running.
+	c.stateLock.Lock()
+	c.session.Configuration = configuration
+	c.session.ConfigurationSource = configurationSource
+	c.session.ConfigurationDestination = configurationDestination
+	c.session.Name = name
+	c.session.Labels = labels
+	c.mergedSourceConfiguration = MergeConfigurations(configuration, configurationSource)
+	c.mergedDestinationConfiguration = MergeConfigurations(configuration, configurationDestination)
+	saveErr := encoding.MarshalAndSaveProtobuf(c.sessionPath, c.session)
+	c.stateLock.Unlock()
+
+	// Restart the forwarding loop if one was running. We do this even if saving
+	// failed so that the session continues running.
+	var startErr error
+	if running {
+		startErr = c.start(prompter)
+	}
+
+	// Report any errors.
+	if saveErr != nil {
+		return errors.Wrap(saveErr, "unable to save session")
+	} else if startErr != nil {
+		return errors.Wrap(startErr, "unable to restart session")
+	}
+
+	// Success.
+	return nil
+}
+
 // controllerHaltMode represents the behavior to use when halting a session.
 type controllerHaltMode uint8
 
@@ -441,10 +527,16 @@ func (c *controller) halt(mode controllerHaltMode, prompter string) error {
 	} else if mode == controllerHaltModeShutdown {
 		// Disable the controller.
 		c.disabled = true
+
+		// Stop any traffic capture.
+		c.stopCapture()
 	} else if mode == controllerHaltModeTerminate {
 		// Disable the controller.
 		c.disabled = true
 
+		// Stop any traffic capture.
+		c.stopCapture()
+
 		// Wipe the session information from disk.
 		sessionRemoveErr := os.Remove(c.sessionPath)
 		if sessionRemoveErr != nil {
@@ -458,6 +550,66 @@ func (c *controller) halt(mode controllerHaltMode, prompter string) error {
 	return nil
 }
 
+// startCapture starts capturing traffic for connections forwarded by the
+// session. Only connections accepted after the capture starts are recorded.
+func (c *controller) startCapture(format CaptureFormat, path string, connectionLimit uint64) error {
+	// Lock the capture state and defer its release.
+	c.captureLock.Lock()
+	defer c.captureLock.Unlock()
+
+	// Ensure that a capture isn't already in progress.
+	if c.capture != nil {
+		return errors.New("capture already in progress")
+	}
+
+	// Create the capture.
+	capture, err := newCapture(format, path, connectionLimit, captureServerPort(c.session))
+	if err != nil {
+		return err
+	}
+	c.capture = capture
+
+	// Success.
+	c.logger.Println("Started traffic capture to", path)
+	return nil
+}
+
+// stopCapture stops any traffic capture that's in progress, returning any error
+// that occurred while capturing.
+func (c *controller) stopCapture() error {
+	// Lock the capture state and defer its release.
+	c.captureLock.Lock()
+	defer c.captureLock.Unlock()
+
+	// If no capture is in progress, then there's nothing to stop.
+	if c.capture == nil {
+		return nil
+	}
+
+	// Close the capture.
+	err := c.capture.close()
+	c.capture = nil
+
+	// Done.
+	c.logger.Println("Stopped traffic capture")
+	return err
+}
+
+// wrapForCapture wraps an incoming connection so that its traffic is recorded
+// by any traffic capture that's in progress.
+func (c *controller) wrapForCapture(connection net.Conn) net.Conn {
+	// Grab the current capture.
+	c.captureLock.Lock()
+	capture := c.capture
+	c.captureLock.Unlock()
+
+	// Wrap the connection if necessary.
+	if capture != nil {
+		return capture.wrap(connection)
+	}
+	return connection
+}
+
 // run is the main runloop for the controller, managing connectivity and
 // synchronization.
 func (c *controller) run(context contextpkg.Context, source, destination Endpoint) {
@@ -476,7 +628,8 @@ func (c *controller) run(context contextpkg.Context, source, destination Endpoin
 		// Reset the state.
 		c.stateLock.Lock()
 		c.state = &State{
-			Session: c.session,
+			Session:       c.session,
+			Reconnections: c.state.Reconnections,
 		}
 		c.stateLock.Unlock()
 
@@ -484,6 +637,10 @@ func (c *controller) run(context contextpkg.Context, source, destination Endpoin
 		close(c.done)
 	}()
 
+	// Track whether or not we've previously connected to both endpoints so
+	// that we can count reconnections.
+	var previouslyConnected bool
+
 	// Loop until cancelled.
 	for {
 		// Loop until we're connected to both endpoints. We do a non-blocking
@@ -551,6 +708,12 @@ func (c *controller) run(context contextpkg.Context, source, destination Endpoin
 			// it in the loop condition we'd still need this check to avoid a
 			// sleep every time (even if already successfully connected).
 			if source != nil && destination != nil {
+				if previouslyConnected {
+					c.stateLock.Lock()
+					c.state.Reconnections++
+					c.stateLock.Unlock()
+				}
+				previouslyConnected = true
 				break
 			}
 
@@ -602,8 +765,9 @@ func (c *controller) run(context contextpkg.Context, source, destination Endpoin
 		// failure.
 		c.stateLock.Lock()
 		c.state = &State{
-			Session:   c.session,
-			LastError: sessionErr.Error(),
+			Session:       c.session,
+			LastError:     sessionErr.Error(),
+			Reconnections: c.state.Reconnections,
 		}
 		c.stateLock.Unlock()
 
@@ -642,24 +806,90 @@ func (c *controller) forward(source, destination Endpoint) error {
 	c.state.Status = Status_ForwardingConnections
 	c.stateLock.Unlock()
 
-	// Accept and forward connections until there's an error.
-	for {
-		// Accept a connection from the source.
-		connection, err := source.Open()
-		if err != nil {
-			return errors.Wrap(err, "unable to accept connection")
-		}
+	// Grab the current state object. Since the run loop replaces this object
+	// once forwarding terminates, we need to ensure that dialing and forwarding
+	// Goroutines continue to reference the same object (see forwardAndClose).
+	state := c.state
+
+	// If this is an HTTP-aware session, then forwarding is handled at the
+	// request level.
+	if isHTTPSession(c.session) {
+		return c.forwardHTTP(source, destination, state)
+	}
 
-		// Open the target connection to which we should forward.
-		target, err := destination.Open()
-		if err != nil {
-			connection.Close()
-			return errors.Wrap(err, "unable to open forwarding connection")
+	// Create a channel to track terminal forwarding errors. Only the first
+	// error matters, so sends on this channel should be non-blocking.
+	forwardingErrors := make(chan error, 1)
+
+	// Accept connections in a background Goroutine and hand them off for
+	// dialing and forwarding. Dialing is performed lazily (and concurrently)
+	// for each connection so that a slow or failing destination doesn't block
+	// the acceptance of further connections.
+	go func() {
+		for {
+			// Accept a connection from the source.
+			connection, err := source.Open()
+			if err != nil {
+				select {
+				case forwardingErrors <- errors.Wrap(err, "unable to accept connection"):
+				default:
+				}
+				return
+			}
+
+			// Wrap the connection for capture if necessary.
+			connection = c.wrapForCapture(connection)
+
+			// Dial the destination and perform forwarding.
+			go dialAndForward(context, connection, destination, c.stateLock, state, forwardingErrors)
 		}
+	}()
 
-		// Perform forwarding.
-		go forwardAndClose(context, connection, target, c.stateLock, c.state)
+	// Wait for a terminal forwarding error.
+	return <-forwardingErrors
+}
+
+// dialAndForward is a utility function used by controller.forward to open an
+// outgoing connection for an individual incoming connection and then forward
+// between the two. If the destination fails to dial the connection, then only
+// the incoming connection is closed and the failure is recorded in the state.
+// If the destination itself has failed, then the failure is reported on the
+// provided errors channel (using a non-blocking send).
+func dialAndForward(
+	context contextpkg.Context,
+	connection net.Conn,
+	destination Endpoint,
+	stateLock *state.TrackingLock,
+	state *State,
+	forwardingErrors chan<- error,
+) {
+	// Open the target connection to which we should forward.
+	target, err := destination.Open()
+	if err != nil {
+		// Close the incoming connection.
+		connection.Close()
+
+		// If this is a dial failure, then record it and reject only this
+		// connection. Otherwise, the destination has failed and we need to
+		// terminate forwarding.
+		if IsDialError(err) {
+			stateLock.Lock()
+			state.FailedConnections++
+			state.LastError = errors.Wrap(err, "unable to dial destination").Error()
+			stateLock.Unlock()
+		} else {
+			select {
+			case forwardingErrors <- errors.Wrap(err, "unable to open forwarding connection"):
+			default:
+			}
+		}
+		return
 	}
+
+	// Perform forwarding, counting the data sent to and received from the
+	// destination.
+	target = newCountingConnection(target, stateLock, state)
+	forwardAndClose(context, connection, target, stateLock, state)
 }
 
 // forwardAndClose is a utility function used by controller.forward to handle
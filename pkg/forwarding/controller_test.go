@@ -1,3 +1,83 @@
 package forwarding
 
-// TODO: Implement.
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/state"
+)
+
+// TestControllerUpdatePaused tests updating a paused session.
+func TestControllerUpdatePaused(t *testing.T) {
+	// Create a temporary directory for session storage and defer its removal.
+	directory, err := ioutil.TempDir("", "mutagen_controller_update")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Create a paused controller.
+	session := &Session{
+		Identifier:               "session",
+		Version:                  Version_Version1,
+		Configuration:            &Configuration{},
+		ConfigurationSource:      &Configuration{},
+		ConfigurationDestination: &Configuration{},
+		Name:                     "old",
+		Paused:                   true,
+	}
+	controller := &controller{
+		sessionPath: filepath.Join(directory, "session"),
+		stateLock:   state.NewTrackingLock(state.NewTracker()),
+		session:     session,
+		state:       &State{Session: session},
+	}
+
+	// Perform an update.
+	err = controller.update(
+		&Configuration{DialRetryCount: 3},
+		&Configuration{},
+		&Configuration{HttpRoutes: map[string]string{"example.com": "tcp:localhost:8080"}},
+		"new",
+		map[string]string{"key": "value"},
+		"",
+	)
+	if err != nil {
+		t.Fatal("unable to update session:", err)
+	}
+
+	// Verify that the session was saved with its updated values.
+	saved := &Session{}
+	if err := encoding.LoadAndUnmarshalProtobuf(controller.sessionPath, saved); err != nil {
+		t.Fatal("unable to load session:", err)
+	} else if saved.Name != "new" {
+		t.Error("session name not updated:", saved.Name)
+	} else if saved.Labels["key"] != "value" {
+		t.Error("session labels not updated")
+	} else if saved.Configuration.DialRetryCount != 3 {
+		t.Error("session configuration not updated")
+	} else if !saved.Paused {
+		t.Error("session unexpectedly unpaused")
+	}
+
+	// Verify that the merged configurations were updated.
+	if controller.mergedSourceConfiguration.DialRetryCount != 3 {
+		t.Error("merged source configuration not updated")
+	} else if controller.mergedDestinationConfiguration.HttpRoutes["example.com"] != "tcp:localhost:8080" {
+		t.Error("merged destination configuration not updated")
+	}
+}
+
+// TestControllerUpdateDisabled tests that updating a disabled controller fails.
+func TestControllerUpdateDisabled(t *testing.T) {
+	controller := &controller{
+		session:  &Session{Identifier: "session"},
+		disabled: true,
+	}
+	if controller.update(&Configuration{}, &Configuration{}, &Configuration{}, "", nil, "") == nil {
+		t.Error("update of disabled controller succeeded")
+	}
+}
@@ -0,0 +1,55 @@
+package forwarding
+
+import (
+	"net"
+
+	"github.com/mutagen-io/mutagen/pkg/state"
+)
+
+// countingConnection is a net.Conn implementation that records the amount of
+// data transferred over a destination connection in forwarding state. It
+// wraps connections opened to the destination, so data written to the
+// connection is sent to the destination and data read from the connection is
+// sent to the source.
+type countingConnection struct {
+	net.Conn
+	// stateLock is the lock guarding the state.
+	stateLock *state.TrackingLock
+	// state is the forwarding state to update.
+	state *State
+}
+
+// newCountingConnection wraps a destination connection so that the data that
+// it transfers is recorded in the specified state. As with connection counts,
+// the state may be updated after forwarding has terminated.
+func newCountingConnection(connection net.Conn, stateLock *state.TrackingLock, state *State) net.Conn {
+	return &countingConnection{
+		Conn:      connection,
+		stateLock: stateLock,
+		state:     state,
+	}
+}
+
+// Read implements net.Conn.Read. Since it's invoked for every read, it doesn't
+// notify of state changes.
+func (c *countingConnection) Read(buffer []byte) (int, error) {
+	n, err := c.Conn.Read(buffer)
+	if n > 0 {
+		c.stateLock.Lock()
+		c.state.BytesToSource += uint64(n)
+		c.stateLock.UnlockWithoutNotify()
+	}
+	return n, err
+}
+
+// Write implements net.Conn.Write. Since it's invoked for every write, it
+// doesn't notify of state changes.
+func (c *countingConnection) Write(buffer []byte) (int, error) {
+	n, err := c.Conn.Write(buffer)
+	if n > 0 {
+		c.stateLock.Lock()
+		c.state.BytesToDestination += uint64(n)
+		c.stateLock.UnlockWithoutNotify()
+	}
+	return n, err
+}
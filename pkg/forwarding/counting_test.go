@@ -0,0 +1,45 @@
+package forwarding
+
+import (
+	"io"
+	"net"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/state"
+)
+
+// TestCountingConnection tests that countingConnection records transferred
+// data in forwarding state.
+func TestCountingConnection(t *testing.T) {
+	// Create an in-memory connection pair and defer their closure.
+	local, remote := net.Pipe()
+	defer local.Close()
+	defer remote.Close()
+
+	// Wrap the local end.
+	stateLock := state.NewTrackingLock(state.NewTracker())
+	forwardingState := &State{}
+	connection := newCountingConnection(local, stateLock, forwardingState)
+
+	// Echo data back from the remote end.
+	go io.Copy(remote, remote)
+
+	// Write and read back data.
+	if _, err := connection.Write([]byte("hello")); err != nil {
+		t.Fatal("unable to write data:", err)
+	}
+	buffer := make([]byte, 5)
+	if _, err := io.ReadFull(connection, buffer); err != nil {
+		t.Fatal("unable to read data:", err)
+	}
+
+	// Verify counts.
+	stateLock.Lock()
+	defer stateLock.UnlockWithoutNotify()
+	if forwardingState.BytesToDestination != 5 {
+		t.Error("destination byte count incorrect:", forwardingState.BytesToDestination)
+	}
+	if forwardingState.BytesToSource != 5 {
+		t.Error("source byte count incorrect:", forwardingState.BytesToSource)
+	}
+}
@@ -2,18 +2,55 @@ package forwarding
 
 import (
 	"net"
+
+	"github.com/pkg/errors"
 )
 
 // Endpoint is a generic network connectivity interface that can represent both
 // listening or dialing. In general, it is not safe for concurrent calls. It is,
-// however, required that Shutdown be callable concurrently with other methods.
+// however, required that Shutdown be callable concurrently with other methods
+// and that Open be callable concurrently for dialer endpoints.
 type Endpoint interface {
 	// Open should open a net connection for the endpoint. For listener (source)
 	// endpoints, this function should block until an incoming connection
 	// arrives. For dialer (destination) endpoints, this function should dial
-	// the underlying target.
+	// the underlying target. If a dialer endpoint fails to dial the target but
+	// otherwise remains operational, then it should return a *DialError so
+	// that only the associated incoming connection is rejected.
 	Open() (net.Conn, error)
 	// Shutdown shuts down the endpoint. This function should unblock any
 	// pending Open call.
 	Shutdown() error
 }
+
+// DialError is the error type returned by dialer endpoints when they fail to
+// establish an individual outgoing connection. It indicates that the failure is
+// specific to that connection and that the endpoint itself is still usable.
+type DialError struct {
+	// Err is the underlying dialing error.
+	Err error
+}
+
+// Error implements error.Error.
+func (e *DialError) Error() string {
+	return e.Err.Error()
+}
+
+// IsDialError determines whether or not an error (or its underlying cause, if
+// it has been wrapped) is a *DialError.
+func IsDialError(err error) bool {
+	_, ok := errors.Cause(err).(*DialError)
+	return ok
+}
+
+// RoutingEndpoint is an optional interface that dialer endpoints can implement
+// to support dialing the alternative targets specified by HTTP routes (see
+// Configuration.HttpRoutes). It is required that OpenRoute be callable
+// concurrently with other methods.
+type RoutingEndpoint interface {
+	Endpoint
+	// OpenRoute behaves like Open, but dials the target associated with the
+	// specified route. An empty route specifies the default target. If the
+	// route is unknown, then a *DialError should be returned.
+	OpenRoute(route string) (net.Conn, error)
+}
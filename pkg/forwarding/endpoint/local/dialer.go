@@ -2,12 +2,68 @@ package local
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
+	"time"
+
+	"github.com/pkg/errors"
 
 	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	forwardingurl "github.com/mutagen-io/mutagen/pkg/url/forwarding"
+)
+
+const (
+	// maximumDialRetryInterval is the maximum interval to wait between dial
+	// retries, regardless of the configured initial interval and backoff.
+	maximumDialRetryInterval = 10 * time.Second
+
+	// tlsHandshakeTimeout is the maximum amount of time to wait for a TLS
+	// handshake to complete when originating TLS.
+	tlsHandshakeTimeout = 10 * time.Second
 )
 
-// dialerEndpoint implements forwarding.Endpoint for dialer endpoints.
+// dialTarget represents a target that a dialer endpoint can dial.
+type dialTarget struct {
+	// network is the network to use for dialing.
+	network string
+	// address is the address to use for dialing.
+	address string
+	// command is the command to spawn for each connection. If non-empty, it
+	// is used instead of dialing network and address.
+	command string
+	// tlsConfiguration is the TLS configuration to use for originating TLS. It
+	// is nil if TLS is disabled.
+	tlsConfiguration *tls.Config
+}
+
+// newDialTarget creates a new dial target.
+func newDialTarget(configuration *forwarding.Configuration, protocol, address string) (*dialTarget, error) {
+	// If TLS is enabled, then create the TLS configuration.
+	var tlsConfiguration *tls.Config
+	if configuration.Tls.Enabled() {
+		if c, err := newDialerTLSConfiguration(configuration, protocol, address); err != nil {
+			return nil, errors.Wrap(err, "unable to create TLS configuration")
+		} else {
+			tlsConfiguration = c
+		}
+	}
+
+	// Create the target. For command targets, the address is the command.
+	if forwardingurl.IsCommandProtocol(protocol) {
+		return &dialTarget{
+			command:          address,
+			tlsConfiguration: tlsConfiguration,
+		}, nil
+	}
+	return &dialTarget{
+		network:          forwardingurl.Network(protocol),
+		address:          address,
+		tlsConfiguration: tlsConfiguration,
+	}, nil
+}
+
+// dialerEndpoint implements forwarding.Endpoint and forwarding.RoutingEndpoint
+// for dialer endpoints.
 type dialerEndpoint struct {
 	// dialingContext is the context that governs dialing operations.
 	dialingContext context.Context
@@ -16,20 +72,52 @@ type dialerEndpoint struct {
 	dialingCancel context.CancelFunc
 	// dialer is the underlying dialer.
 	dialer *net.Dialer
-	// protocol is the protocol to use for dialing.
-	protocol string
-	// address is the address to use for dialing.
-	address string
+	// targets maps routes to their corresponding targets. The default target
+	// is stored under the empty route.
+	targets map[string]*dialTarget
+	// retryCount is the number of times to retry a failed dial.
+	retryCount uint32
+	// retryInterval is the initial interval to wait before retrying a failed
+	// dial.
+	retryInterval time.Duration
 }
 
 // NewDialerEndpoint creates a new forwarding.Endpoint that behaves as a
-// dialer.
+// dialer. The resulting endpoint also implements forwarding.RoutingEndpoint,
+// supporting any HTTP routes specified in the configuration.
 func NewDialerEndpoint(
 	version forwarding.Version,
 	configuration *forwarding.Configuration,
 	protocol string,
 	address string,
 ) (forwarding.Endpoint, error) {
+	// Compute the effective dial retry interval.
+	retryInterval := time.Duration(configuration.DialRetryInterval) * time.Millisecond
+	if retryInterval == 0 {
+		retryInterval = version.DefaultDialRetryInterval()
+	}
+
+	// Create the default target.
+	targets := make(map[string]*dialTarget, len(configuration.HttpRoutes)+1)
+	if target, err := newDialTarget(configuration, protocol, address); err != nil {
+		return nil, err
+	} else {
+		targets[""] = target
+	}
+
+	// Create targets for any HTTP routes.
+	for route, specification := range configuration.HttpRoutes {
+		routeProtocol, routeAddress, err := forwardingurl.Parse(specification)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse target for route \"%s\"", route)
+		}
+		if target, err := newDialTarget(configuration, routeProtocol, routeAddress); err != nil {
+			return nil, errors.Wrapf(err, "unable to create target for route \"%s\"", route)
+		} else {
+			targets[route] = target
+		}
+	}
+
 	// Create a cancellable context that we can use to regulate connections.
 	dialingContext, dialingCancel := context.WithCancel(context.Background())
 
@@ -38,14 +126,108 @@ func NewDialerEndpoint(
 		dialingContext: dialingContext,
 		dialingCancel:  dialingCancel,
 		dialer:         &net.Dialer{},
-		protocol:       protocol,
-		address:        address,
+		targets:        targets,
+		retryCount:     configuration.DialRetryCount,
+		retryInterval:  retryInterval,
 	}, nil
 }
 
-// Shutdown implements forwarding.Endpoint.Open.
+// Open implements forwarding.Endpoint.Open.
 func (e *dialerEndpoint) Open() (net.Conn, error) {
-	return e.dialer.DialContext(e.dialingContext, e.protocol, e.address)
+	return e.OpenRoute("")
+}
+
+// OpenRoute implements forwarding.RoutingEndpoint.OpenRoute.
+func (e *dialerEndpoint) OpenRoute(route string) (net.Conn, error) {
+	// Look up the target.
+	target, ok := e.targets[route]
+	if !ok {
+		return nil, &forwarding.DialError{Err: errors.Errorf("unknown route: %s", route)}
+	}
+
+	// Dial until we succeed, run out of retries, or are shut down. We back off
+	// exponentially between retries, up to a maximum interval.
+	retryInterval := e.retryInterval
+	for attempt := uint32(0); ; attempt++ {
+		// Attempt to dial.
+		connection, err := e.dial(target)
+		if err == nil {
+			return connection, nil
+		}
+
+		// If we've been shut down, then there's no point in retrying, and the
+		// failure isn't specific to this connection.
+		if e.dialingContext.Err() != nil {
+			return nil, errors.New("dialer shut down")
+		}
+
+		// If we're out of retries, then report a connection-specific failure.
+		if attempt == e.retryCount {
+			return nil, &forwarding.DialError{Err: err}
+		}
+
+		// Wait to retry, watching for shutdown in the mean time.
+		select {
+		case <-e.dialingContext.Done():
+			return nil, errors.New("dialer shut down")
+		case <-time.After(retryInterval):
+		}
+
+		// Back off for the next retry.
+		if retryInterval *= 2; retryInterval > maximumDialRetryInterval {
+			retryInterval = maximumDialRetryInterval
+		}
+	}
+}
+
+// dial performs a single dialing attempt for the specified target (spawning its
+// command for exec targets), performing a TLS handshake if TLS is enabled.
+func (e *dialerEndpoint) dial(target *dialTarget) (net.Conn, error) {
+	// Dial the target.
+	var connection net.Conn
+	var err error
+	if target.command != "" {
+		connection, err = startCommand(e.dialingContext, target.command)
+	} else {
+		connection, err = e.dialer.DialContext(e.dialingContext, target.network, target.address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// If TLS is disabled, then we're done.
+	if target.tlsConfiguration == nil {
+		return connection, nil
+	}
+
+	// Wrap the connection in TLS and perform the handshake eagerly so that
+	// handshake failures are treated as dial failures. The handshake is bounded
+	// by a timeout and aborted if the dialing context is cancelled, which we
+	// enforce by forcing the connection's deadline into the past.
+	tlsConnection := tls.Client(connection, target.tlsConfiguration)
+	handshakeContext, handshakeCancel := context.WithTimeout(e.dialingContext, tlsHandshakeTimeout)
+	handshakeDone := make(chan struct{})
+	watcherDone := make(chan struct{})
+	go func() {
+		select {
+		case <-handshakeContext.Done():
+			tlsConnection.SetDeadline(time.Unix(1, 0))
+		case <-handshakeDone:
+		}
+		close(watcherDone)
+	}()
+	err = tlsConnection.Handshake()
+	close(handshakeDone)
+	<-watcherDone
+	handshakeCancel()
+	if err != nil {
+		tlsConnection.Close()
+		return nil, errors.Wrap(err, "TLS handshake failed")
+	}
+	tlsConnection.SetDeadline(time.Time{})
+
+	// Success.
+	return tlsConnection, nil
 }
 
 // Shutdown implements forwarding.Endpoint.Shutdown.
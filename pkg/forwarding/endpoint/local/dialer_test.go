@@ -1,3 +1,169 @@
 package local
 
-// TODO: Implement.
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+)
+
+// unusedTCPAddress returns a loopback TCP address that isn't currently being
+// listened on.
+func unusedTCPAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("unable to create listener:", err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+	return address
+}
+
+// TestDialerEndpointDialFailure tests that a dialer endpoint reports a
+// connection-specific error when its target isn't available.
+func TestDialerEndpointDialFailure(t *testing.T) {
+	// Create a dialer endpoint targeting an unused address and defer its
+	// shutdown.
+	endpoint, err := NewDialerEndpoint(
+		forwarding.Version_Version1,
+		&forwarding.Configuration{DialRetryCount: 1, DialRetryInterval: 10},
+		"tcp",
+		unusedTCPAddress(t),
+	)
+	if err != nil {
+		t.Fatal("unable to create dialer endpoint:", err)
+	}
+	defer endpoint.Shutdown()
+
+	// Attempt to open a connection and ensure that the failure is reported as
+	// a dial error.
+	if connection, err := endpoint.Open(); err == nil {
+		connection.Close()
+		t.Fatal("connection unexpectedly succeeded")
+	} else if !forwarding.IsDialError(err) {
+		t.Error("dial failure not reported as dial error:", err)
+	}
+}
+
+// TestDialerEndpointDialRetry tests that a dialer endpoint retries failed dials
+// until its target becomes available.
+func TestDialerEndpointDialRetry(t *testing.T) {
+	// Compute an unused address.
+	address := unusedTCPAddress(t)
+
+	// Create a dialer endpoint targeting the address and defer its shutdown.
+	endpoint, err := NewDialerEndpoint(
+		forwarding.Version_Version1,
+		&forwarding.Configuration{DialRetryCount: 10, DialRetryInterval: 50},
+		"tcp",
+		address,
+	)
+	if err != nil {
+		t.Fatal("unable to create dialer endpoint:", err)
+	}
+	defer endpoint.Shutdown()
+
+	// Start listening on the address after a short delay.
+	listenerErrors := make(chan error, 1)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			listenerErrors <- err
+			return
+		}
+		defer listener.Close()
+		connection, err := listener.Accept()
+		if err == nil {
+			connection.Close()
+		}
+		listenerErrors <- err
+	}()
+
+	// Attempt to open a connection.
+	if connection, err := endpoint.Open(); err != nil {
+		t.Fatal("unable to open connection:", err)
+	} else {
+		connection.Close()
+	}
+
+	// Check for listener errors.
+	if err := <-listenerErrors; err != nil {
+		t.Error("listener failure:", err)
+	}
+}
+
+// TestDialerEndpointShutdownDuringRetry tests that shutting down a dialer
+// endpoint unblocks a retrying Open call with an error that isn't reported as a
+// dial error.
+func TestDialerEndpointShutdownDuringRetry(t *testing.T) {
+	// Create a dialer endpoint targeting an unused address with a long retry
+	// interval.
+	endpoint, err := NewDialerEndpoint(
+		forwarding.Version_Version1,
+		&forwarding.Configuration{DialRetryCount: 1, DialRetryInterval: 60000},
+		"tcp",
+		unusedTCPAddress(t),
+	)
+	if err != nil {
+		t.Fatal("unable to create dialer endpoint:", err)
+	}
+
+	// Shut down the endpoint after a short delay.
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		endpoint.Shutdown()
+	}()
+
+	// Attempt to open a connection and ensure that the failure isn't reported
+	// as a dial error.
+	if connection, err := endpoint.Open(); err == nil {
+		connection.Close()
+		t.Fatal("connection unexpectedly succeeded")
+	} else if forwarding.IsDialError(err) {
+		t.Error("shutdown reported as dial error")
+	}
+}
+
+// TestDialerEndpointRoutes tests that a dialer endpoint dials the targets
+// associated with HTTP routes and rejects unknown routes.
+func TestDialerEndpointRoutes(t *testing.T) {
+	// Create a listener to act as the route target and defer its closure.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("unable to create listener:", err)
+	}
+	defer listener.Close()
+
+	// Create a dialer endpoint whose default target is unavailable but whose
+	// route targets the listener, and defer its shutdown.
+	endpoint, err := NewDialerEndpoint(
+		forwarding.Version_Version1,
+		&forwarding.Configuration{
+			HttpRoutes: map[string]string{"api.localhost": "tcp:" + listener.Addr().String()},
+		},
+		"tcp",
+		unusedTCPAddress(t),
+	)
+	if err != nil {
+		t.Fatal("unable to create dialer endpoint:", err)
+	}
+	defer endpoint.Shutdown()
+	router := endpoint.(forwarding.RoutingEndpoint)
+
+	// Ensure that the route can be dialed.
+	if connection, err := router.OpenRoute("api.localhost"); err != nil {
+		t.Error("unable to open route connection:", err)
+	} else {
+		connection.Close()
+	}
+
+	// Ensure that unknown routes are reported as dial errors.
+	if connection, err := router.OpenRoute("unknown.localhost"); err == nil {
+		connection.Close()
+		t.Error("unknown route connection unexpectedly succeeded")
+	} else if !forwarding.IsDialError(err) {
+		t.Error("unknown route not reported as dial error:", err)
+	}
+}
@@ -0,0 +1,155 @@
+package local
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// commandAddress is the net.Addr implementation used by commandConnection.
+type commandAddress struct {
+	// command is the command associated with the address.
+	command string
+}
+
+// Network implements net.Addr.Network.
+func (a commandAddress) Network() string {
+	return "exec"
+}
+
+// String implements net.Addr.String.
+func (a commandAddress) String() string {
+	return a.command
+}
+
+// commandConnection implements net.Conn on top of the standard input and
+// output of a spawned process.
+type commandConnection struct {
+	// process is the underlying process.
+	process *exec.Cmd
+	// address is the address of the connection.
+	address commandAddress
+	// input is the write end of the process' standard input.
+	input *os.File
+	// output is the read end of the process' standard output.
+	output *os.File
+	// closeOnce ensures that closure is only performed once.
+	closeOnce sync.Once
+}
+
+// startCommand spawns the specified command (using the platform's shell) and
+// creates a connection to its standard input and output. The process' standard
+// error stream is discarded. The process is started in its own process group
+// (where supported) so that closing the connection terminates any processes
+// that it spawns. The context only regulates starting the process, not the
+// lifetime of the resulting connection.
+func startCommand(ctx context.Context, command string) (net.Conn, error) {
+	// Don't bother starting the process if the context has been cancelled.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Create the process' standard input and output pipes.
+	inputReader, inputWriter, err := os.Pipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create input pipe")
+	}
+	outputReader, outputWriter, err := os.Pipe()
+	if err != nil {
+		inputReader.Close()
+		inputWriter.Close()
+		return nil, errors.Wrap(err, "unable to create output pipe")
+	}
+
+	// Create and start the process.
+	process := shellCommand(command)
+	process.Stdin = inputReader
+	process.Stdout = outputWriter
+	err = process.Start()
+
+	// Close the process' ends of the pipes, which are no longer needed (in this
+	// process) regardless of whether or not the process started.
+	inputReader.Close()
+	outputWriter.Close()
+
+	// Handle process start failure.
+	if err != nil {
+		inputWriter.Close()
+		outputReader.Close()
+		return nil, errors.Wrap(err, "unable to start command")
+	}
+
+	// If the context was cancelled while we were starting the process, then
+	// terminate it.
+	if err := ctx.Err(); err != nil {
+		inputWriter.Close()
+		outputReader.Close()
+		terminateCommand(process)
+		process.Wait()
+		return nil, err
+	}
+
+	// Success.
+	return &commandConnection{
+		process: process,
+		address: commandAddress{command},
+		input:   inputWriter,
+		output:  outputReader,
+	}, nil
+}
+
+// Read implements net.Conn.Read.
+func (c *commandConnection) Read(buffer []byte) (int, error) {
+	return c.output.Read(buffer)
+}
+
+// Write implements net.Conn.Write.
+func (c *commandConnection) Write(buffer []byte) (int, error) {
+	return c.input.Write(buffer)
+}
+
+// Close implements net.Conn.Close. It closes the process' standard input,
+// terminates the process (and any processes that it has spawned), and waits
+// for it to exit.
+func (c *commandConnection) Close() error {
+	c.closeOnce.Do(func() {
+		c.input.Close()
+		c.output.Close()
+		terminateCommand(c.process)
+		c.process.Wait()
+	})
+	return nil
+}
+
+// LocalAddr implements net.Conn.LocalAddr.
+func (c *commandConnection) LocalAddr() net.Addr {
+	return c.address
+}
+
+// RemoteAddr implements net.Conn.RemoteAddr.
+func (c *commandConnection) RemoteAddr() net.Addr {
+	return c.address
+}
+
+// SetDeadline implements net.Conn.SetDeadline.
+func (c *commandConnection) SetDeadline(t time.Time) error {
+	if err := c.input.SetWriteDeadline(t); err != nil {
+		return err
+	}
+	return c.output.SetReadDeadline(t)
+}
+
+// SetReadDeadline implements net.Conn.SetReadDeadline.
+func (c *commandConnection) SetReadDeadline(t time.Time) error {
+	return c.output.SetReadDeadline(t)
+}
+
+// SetWriteDeadline implements net.Conn.SetWriteDeadline.
+func (c *commandConnection) SetWriteDeadline(t time.Time) error {
+	return c.input.SetWriteDeadline(t)
+}
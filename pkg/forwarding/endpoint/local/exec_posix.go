@@ -0,0 +1,26 @@
+// +build !windows
+
+package local
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// shellCommand creates a command that runs the specified command string using
+// the system shell. The command is configured to run in its own process group.
+func shellCommand(command string) *exec.Cmd {
+	result := exec.Command("/bin/sh", "-c", command)
+	result.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	return result
+}
+
+// terminateCommand forcibly terminates a command started by shellCommand,
+// along with any processes in its process group.
+func terminateCommand(command *exec.Cmd) {
+	// Signal the process group. Since the shell is the group leader, its
+	// process identifier is also the process group identifier.
+	if err := syscall.Kill(-command.Process.Pid, syscall.SIGKILL); err != nil {
+		command.Process.Kill()
+	}
+}
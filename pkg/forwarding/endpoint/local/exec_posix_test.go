@@ -0,0 +1,72 @@
+// +build !windows
+
+package local
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// processExited returns whether or not the process with the specified
+// identifier has exited. Processes that have exited but not yet been reaped
+// (which can happen for orphaned processes) are treated as exited.
+func processExited(pid int) bool {
+	if err := syscall.Kill(pid, 0); err == syscall.ESRCH {
+		return true
+	}
+	if stat, err := ioutil.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat"); err == nil {
+		if fields := strings.Fields(string(stat)); len(fields) > 2 && fields[2] == "Z" {
+			return true
+		}
+	}
+	return false
+}
+
+// TestCommandConnectionCloseTerminatesProcessGroup tests that closing a command
+// connection terminates processes spawned by the command.
+func TestCommandConnectionCloseTerminatesProcessGroup(t *testing.T) {
+	// Start a command that spawns a background process and reports its process
+	// identifier.
+	connection, err := startCommand(context.Background(), "sleep 60 & echo $!; wait")
+	if err != nil {
+		t.Fatal("unable to start command:", err)
+	}
+	defer connection.Close()
+
+	// Read the background process' identifier.
+	line, err := bufio.NewReader(connection).ReadString('\n')
+	if err != nil {
+		t.Fatal("unable to read process identifier:", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil {
+		t.Fatal("unable to parse process identifier:", err)
+	}
+
+	// Close the connection and ensure that the background process exits.
+	connection.Close()
+	for i := 0; !processExited(pid); i++ {
+		if i == 100 {
+			syscall.Kill(pid, syscall.SIGKILL)
+			t.Fatal("background process not terminated")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// TestStartCommandCancelledContext tests that commands aren't started with a
+// cancelled context.
+func TestStartCommandCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if connection, err := startCommand(ctx, "cat"); err == nil {
+		connection.Close()
+		t.Error("command started with cancelled context")
+	}
+}
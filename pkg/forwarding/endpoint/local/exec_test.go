@@ -0,0 +1,120 @@
+package local
+
+import (
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+)
+
+// testCommandDialerEndpoint tests that a command dialer endpoint with the
+// specified protocol pipes connections to a spawned command.
+func testCommandDialerEndpoint(t *testing.T, protocol string) {
+	// Skip this test on Windows, where we don't have a reliable echo command.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Create a command dialer endpoint that echoes its input and defer its
+	// shutdown.
+	endpoint, err := NewDialerEndpoint(
+		forwarding.Version_Version1,
+		&forwarding.Configuration{},
+		protocol,
+		"cat",
+	)
+	if err != nil {
+		t.Fatal("unable to create dialer endpoint:", err)
+	}
+	defer endpoint.Shutdown()
+
+	// Open a connection and defer its closure.
+	connection, err := endpoint.Open()
+	if err != nil {
+		t.Fatal("unable to open connection:", err)
+	}
+	defer connection.Close()
+
+	// Exchange a message.
+	if _, err := connection.Write([]byte("hello")); err != nil {
+		t.Fatal("unable to write message:", err)
+	}
+	buffer := make([]byte, 5)
+	if _, err := io.ReadFull(connection, buffer); err != nil {
+		t.Fatal("unable to read message:", err)
+	} else if string(buffer) != "hello" {
+		t.Error("echoed message does not match:", string(buffer))
+	}
+}
+
+// TestExecDialerEndpoint tests that an exec dialer endpoint pipes connections
+// to a spawned command.
+func TestExecDialerEndpoint(t *testing.T) {
+	testCommandDialerEndpoint(t, "exec")
+}
+
+// TestStdioDialerEndpoint tests that a stdio dialer endpoint pipes connections
+// to a spawned command.
+func TestStdioDialerEndpoint(t *testing.T) {
+	testCommandDialerEndpoint(t, "stdio")
+}
+
+// TestExecDialerEndpointCommandExit tests that the exit of a spawned command
+// is seen as the end of the connection.
+func TestExecDialerEndpointCommandExit(t *testing.T) {
+	// Skip this test on Windows, where the shell command syntax differs.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Create an exec dialer endpoint that writes a message and exits, and defer
+	// its shutdown.
+	endpoint, err := NewDialerEndpoint(
+		forwarding.Version_Version1,
+		&forwarding.Configuration{},
+		"exec",
+		"printf done",
+	)
+	if err != nil {
+		t.Fatal("unable to create dialer endpoint:", err)
+	}
+	defer endpoint.Shutdown()
+
+	// Open a connection and defer its closure.
+	connection, err := endpoint.Open()
+	if err != nil {
+		t.Fatal("unable to open connection:", err)
+	}
+	defer connection.Close()
+
+	// Read until the end of the connection.
+	var output []byte
+	buffer := make([]byte, 16)
+	for {
+		n, err := connection.Read(buffer)
+		output = append(output, buffer[:n]...)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal("unable to read output:", err)
+		}
+	}
+	if string(output) != "done" {
+		t.Error("command output does not match:", string(output))
+	}
+}
+
+// TestExecListenerEndpointRejected tests that exec endpoints can't be used for
+// listening.
+func TestExecListenerEndpointRejected(t *testing.T) {
+	if listener, err := NewListenerEndpoint(
+		forwarding.Version_Version1,
+		&forwarding.Configuration{},
+		"exec",
+		"cat",
+	); err == nil {
+		listener.Shutdown()
+		t.Error("exec listener endpoint created successfully")
+	}
+}
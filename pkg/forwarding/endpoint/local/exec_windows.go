@@ -0,0 +1,36 @@
+package local
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// shellCommand creates a command that runs the specified command string using
+// the system shell.
+func shellCommand(command string) *exec.Cmd {
+	// Determine the shell to use.
+	shell := os.Getenv("COMSPEC")
+	if shell == "" {
+		shell = "cmd.exe"
+	}
+
+	// Create the command. We pass the command line verbatim since cmd.exe
+	// doesn't follow the standard argument quoting rules.
+	result := exec.Command(shell)
+	result.SysProcAttr = &syscall.SysProcAttr{
+		CmdLine: shell + " /C " + command,
+	}
+	return result
+}
+
+// terminateCommand forcibly terminates a command started by shellCommand,
+// along with any processes that it has spawned. Windows doesn't have process
+// groups in the POSIX sense, so we use taskkill to terminate the process tree.
+func terminateCommand(command *exec.Cmd) {
+	killer := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(command.Process.Pid))
+	if err := killer.Run(); err != nil {
+		command.Process.Kill()
+	}
+}
@@ -1,6 +1,7 @@
 package local
 
 import (
+	"crypto/tls"
 	"net"
 	"os"
 
@@ -8,6 +9,7 @@ import (
 
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	forwardingurl "github.com/mutagen-io/mutagen/pkg/url/forwarding"
 )
 
 // listenerEndpoint implements forwarding.Endpoint for listener endpoints.
@@ -30,10 +32,16 @@ func NewListenerEndpoint(
 		socketOverwriteMode = version.DefaultSocketOverwriteMode()
 	}
 
+	// Ensure that the protocol supports listening.
+	if !forwardingurl.SupportsListening(protocol) {
+		return nil, errors.Errorf("%s endpoints can't be used for listening", protocol)
+	}
+
 	// Create the underlying listener. If this is a Unix domain socket listener
 	// and we fail due to an existing file, then attempt a removal and re-listen
 	// if requested.
-	listener, err := net.Listen(protocol, address)
+	network := forwardingurl.Network(protocol)
+	listener, err := net.Listen(network, address)
 	if err != nil {
 		// HACK: os.IsExist doesn't seem to recognize the error here, so we
 		// don't perform that check. This may be fixed in Go 1.13.
@@ -43,7 +51,7 @@ func NewListenerEndpoint(
 			if err := os.Remove(address); err != nil {
 				return nil, errors.Wrap(err, "unable to overwrite existing socket")
 			}
-			listener, err = net.Listen(protocol, address)
+			listener, err = net.Listen(network, address)
 			if err != nil {
 				return nil, errors.Wrap(err, "unable to create listener after socket overwrite")
 			}
@@ -90,6 +98,19 @@ func NewListenerEndpoint(
 		}
 	}
 
+	// If TLS is enabled, then wrap the listener so that TLS is terminated for
+	// incoming connections. The handshake is performed lazily on the first
+	// read or write, so a failed handshake will only affect the associated
+	// connection.
+	if configuration.Tls.Enabled() {
+		tlsConfiguration, err := newListenerTLSConfiguration(configuration)
+		if err != nil {
+			listener.Close()
+			return nil, errors.Wrap(err, "unable to create TLS configuration")
+		}
+		listener = tls.NewListener(listener, tlsConfiguration)
+	}
+
 	// Create the endpoint.
 	return &listenerEndpoint{
 		listener: listener,
@@ -0,0 +1,139 @@
+package local
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	forwardingurl "github.com/mutagen-io/mutagen/pkg/url/forwarding"
+)
+
+// loadTLSCertificate loads a PEM-encoded certificate and private key from the
+// specified paths, performing normalization on the paths.
+func loadTLSCertificate(certificatePath, keyPath string) (tls.Certificate, error) {
+	// Normalize the certificate path.
+	certificatePath, err := filesystem.Normalize(certificatePath)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "unable to normalize certificate path")
+	}
+
+	// Normalize the key path.
+	keyPath, err = filesystem.Normalize(keyPath)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "unable to normalize key path")
+	}
+
+	// Load the certificate and key.
+	return tls.LoadX509KeyPair(certificatePath, keyPath)
+}
+
+// loadTLSCertificateAuthority loads a PEM-encoded bundle of certificate
+// authority certificates from the specified path, performing normalization on
+// the path.
+func loadTLSCertificateAuthority(path string) (*x509.CertPool, error) {
+	// Normalize the path.
+	path, err := filesystem.Normalize(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to normalize path")
+	}
+
+	// Read the bundle.
+	bundle, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read bundle")
+	}
+
+	// Parse the bundle.
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, errors.New("bundle contains no valid certificates")
+	}
+
+	// Success.
+	return pool, nil
+}
+
+// newListenerTLSConfiguration creates a TLS configuration suitable for
+// terminating TLS on a listener endpoint.
+func newListenerTLSConfiguration(configuration *forwarding.Configuration) (*tls.Config, error) {
+	// Ensure that a certificate has been specified. The configuration's
+	// validation will have ensured that a key is present if a certificate is.
+	if configuration.TlsCertificate == "" {
+		return nil, errors.New("TLS termination requires a certificate and key")
+	}
+
+	// Load the certificate and key.
+	certificate, err := loadTLSCertificate(configuration.TlsCertificate, configuration.TlsKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load TLS certificate")
+	}
+
+	// Create the configuration.
+	result := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+	}
+
+	// If a certificate authority has been specified, then require and verify
+	// client certificates.
+	if configuration.TlsCertificateAuthority != "" {
+		pool, err := loadTLSCertificateAuthority(configuration.TlsCertificateAuthority)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load TLS certificate authority")
+		}
+		result.ClientCAs = pool
+		result.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	// Success.
+	return result, nil
+}
+
+// newDialerTLSConfiguration creates a TLS configuration suitable for
+// originating TLS on a dialer endpoint that targets the specified address.
+func newDialerTLSConfiguration(configuration *forwarding.Configuration, protocol, address string) (*tls.Config, error) {
+	// Compute the server name. If one hasn't been specified explicitly, then
+	// we use the host component of TCP addresses.
+	serverName := configuration.TlsServerName
+	if serverName == "" && protocol != "unix" && !forwardingurl.IsCommandProtocol(protocol) {
+		if host, _, err := net.SplitHostPort(address); err != nil {
+			return nil, errors.Wrap(err, "unable to extract host from address")
+		} else {
+			serverName = host
+		}
+	}
+	if serverName == "" {
+		return nil, errors.New("unable to determine TLS server name")
+	}
+
+	// Create the configuration.
+	result := &tls.Config{
+		ServerName: serverName,
+	}
+
+	// If a client certificate has been specified, then load it.
+	if configuration.TlsCertificate != "" {
+		certificate, err := loadTLSCertificate(configuration.TlsCertificate, configuration.TlsKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load TLS client certificate")
+		}
+		result.Certificates = []tls.Certificate{certificate}
+	}
+
+	// If a certificate authority has been specified, then use it instead of the
+	// system roots.
+	if configuration.TlsCertificateAuthority != "" {
+		pool, err := loadTLSCertificateAuthority(configuration.TlsCertificateAuthority)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load TLS certificate authority")
+		}
+		result.RootCAs = pool
+	}
+
+	// Success.
+	return result, nil
+}
@@ -0,0 +1,307 @@
+package local
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+)
+
+// writeTestTLSCertificate generates a self-signed certificate (valid for the
+// IPv4 loopback address) and corresponding key, writes them to the specified
+// directory, and returns their paths.
+func writeTestTLSCertificate(t *testing.T, directory string) (string, string) {
+	// Generate a key.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("unable to generate key:", err)
+	}
+
+	// Generate a self-signed certificate.
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "mutagen-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal("unable to create certificate:", err)
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal("unable to marshal key:", err)
+	}
+
+	// Write the certificate and key.
+	certificatePath := filepath.Join(directory, "certificate.pem")
+	keyPath := filepath.Join(directory, "key.pem")
+	certificatePEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+	if err := ioutil.WriteFile(certificatePath, certificatePEM, 0600); err != nil {
+		t.Fatal("unable to write certificate:", err)
+	} else if err = ioutil.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		t.Fatal("unable to write key:", err)
+	}
+
+	// Done.
+	return certificatePath, keyPath
+}
+
+// TestTLSTerminationAndOrigination tests that a TLS-enabled dialer endpoint can
+// communicate with a TLS-enabled listener endpoint.
+func TestTLSTerminationAndOrigination(t *testing.T) {
+	// Create a temporary directory and defer its removal.
+	directory, err := ioutil.TempDir("", "mutagen_forwarding_tls")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Generate a certificate and key.
+	certificatePath, keyPath := writeTestTLSCertificate(t, directory)
+
+	// Create a TLS-enabled listener endpoint and defer its shutdown.
+	listener, err := NewListenerEndpoint(
+		forwarding.Version_Version1,
+		&forwarding.Configuration{
+			Tls:            forwarding.TLSMode_TLSModeEnabled,
+			TlsCertificate: certificatePath,
+			TlsKey:         keyPath,
+		},
+		"tcp",
+		"127.0.0.1:0",
+	)
+	if err != nil {
+		t.Fatal("unable to create listener endpoint:", err)
+	}
+	defer listener.Shutdown()
+
+	// Create a TLS-enabled dialer endpoint targeting the listener (and trusting
+	// the self-signed certificate) and defer its shutdown.
+	dialer, err := NewDialerEndpoint(
+		forwarding.Version_Version1,
+		&forwarding.Configuration{
+			Tls:                     forwarding.TLSMode_TLSModeEnabled,
+			TlsCertificateAuthority: certificatePath,
+		},
+		"tcp",
+		listener.(*listenerEndpoint).listener.Addr().String(),
+	)
+	if err != nil {
+		t.Fatal("unable to create dialer endpoint:", err)
+	}
+	defer dialer.Shutdown()
+
+	// Accept a connection in the background and echo a message.
+	echoErrors := make(chan error, 1)
+	go func() {
+		connection, err := listener.Open()
+		if err != nil {
+			echoErrors <- err
+			return
+		}
+		defer connection.Close()
+		buffer := make([]byte, 5)
+		if _, err := io.ReadFull(connection, buffer); err != nil {
+			echoErrors <- err
+			return
+		}
+		_, err = connection.Write(buffer)
+		echoErrors <- err
+	}()
+
+	// Dial and exchange a message.
+	connection, err := dialer.Open()
+	if err != nil {
+		t.Fatal("unable to open connection:", err)
+	}
+	defer connection.Close()
+	if _, err := connection.Write([]byte("hello")); err != nil {
+		t.Fatal("unable to write message:", err)
+	}
+	buffer := make([]byte, 5)
+	if _, err := io.ReadFull(connection, buffer); err != nil {
+		t.Fatal("unable to read message:", err)
+	} else if string(buffer) != "hello" {
+		t.Error("echoed message does not match:", string(buffer))
+	}
+
+	// Check for echo errors.
+	if err := <-echoErrors; err != nil {
+		t.Error("echo failure:", err)
+	}
+}
+
+// TestTLSOriginationVerificationFailure tests that a TLS-enabled dialer endpoint
+// reports a dial error when it can't verify its target.
+func TestTLSOriginationVerificationFailure(t *testing.T) {
+	// Create a temporary directory and defer its removal.
+	directory, err := ioutil.TempDir("", "mutagen_forwarding_tls")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Generate a certificate and key.
+	certificatePath, keyPath := writeTestTLSCertificate(t, directory)
+
+	// Create a TLS-enabled listener endpoint and defer its shutdown.
+	listener, err := NewListenerEndpoint(
+		forwarding.Version_Version1,
+		&forwarding.Configuration{
+			Tls:            forwarding.TLSMode_TLSModeEnabled,
+			TlsCertificate: certificatePath,
+			TlsKey:         keyPath,
+		},
+		"tcp",
+		"127.0.0.1:0",
+	)
+	if err != nil {
+		t.Fatal("unable to create listener endpoint:", err)
+	}
+	defer listener.Shutdown()
+
+	// Accept connections in the background and drive their handshakes.
+	go func() {
+		for {
+			connection, err := listener.Open()
+			if err != nil {
+				return
+			}
+			go func() {
+				connection.Read(make([]byte, 1))
+				connection.Close()
+			}()
+		}
+	}()
+
+	// Create a TLS-enabled dialer endpoint that uses the system roots (which
+	// won't trust the self-signed certificate) and defer its shutdown.
+	dialer, err := NewDialerEndpoint(
+		forwarding.Version_Version1,
+		&forwarding.Configuration{Tls: forwarding.TLSMode_TLSModeEnabled},
+		"tcp",
+		listener.(*listenerEndpoint).listener.Addr().String(),
+	)
+	if err != nil {
+		t.Fatal("unable to create dialer endpoint:", err)
+	}
+	defer dialer.Shutdown()
+
+	// Attempt to dial and ensure that a dial error is reported.
+	if connection, err := dialer.Open(); err == nil {
+		connection.Close()
+		t.Fatal("connection unexpectedly succeeded")
+	} else if !forwarding.IsDialError(err) {
+		t.Error("verification failure not reported as dial error:", err)
+	}
+}
+
+// TestTLSTerminationRequiresCertificate tests that a TLS-enabled listener
+// endpoint can't be created without a certificate.
+func TestTLSTerminationRequiresCertificate(t *testing.T) {
+	if listener, err := NewListenerEndpoint(
+		forwarding.Version_Version1,
+		&forwarding.Configuration{Tls: forwarding.TLSMode_TLSModeEnabled},
+		"tcp",
+		"127.0.0.1:0",
+	); err == nil {
+		listener.Shutdown()
+		t.Error("listener endpoint created without TLS certificate")
+	}
+}
+
+// TestTLSOriginationHandshakeShutdown tests that shutting down a TLS-enabled
+// dialer endpoint aborts an in-progress TLS handshake.
+func TestTLSOriginationHandshakeShutdown(t *testing.T) {
+	// Create a plain TCP listener that accepts connections but never responds
+	// and defer its closure.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("unable to create listener:", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			connection, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer connection.Close()
+		}
+	}()
+
+	// Create a TLS-enabled dialer endpoint.
+	dialer, err := NewDialerEndpoint(
+		forwarding.Version_Version1,
+		&forwarding.Configuration{Tls: forwarding.TLSMode_TLSModeEnabled},
+		"tcp",
+		listener.Addr().String(),
+	)
+	if err != nil {
+		t.Fatal("unable to create dialer endpoint:", err)
+	}
+
+	// Start dialing in the background.
+	results := make(chan error, 1)
+	go func() {
+		connection, err := dialer.Open()
+		if err == nil {
+			connection.Close()
+		}
+		results <- err
+	}()
+
+	// Shut down the endpoint and ensure that dialing fails promptly.
+	time.Sleep(100 * time.Millisecond)
+	dialer.Shutdown()
+	select {
+	case err := <-results:
+		if err == nil {
+			t.Error("connection unexpectedly succeeded")
+		}
+	case <-time.After(tlsHandshakeTimeout / 2):
+		t.Fatal("TLS handshake not aborted by shutdown")
+	}
+}
+
+// TestTLSModeEndpointOverride tests that an endpoint-specific TLS mode can
+// disable TLS enabled by a session-level configuration.
+func TestTLSModeEndpointOverride(t *testing.T) {
+	// Merge an endpoint-specific configuration disabling TLS into a session
+	// configuration enabling TLS.
+	configuration := forwarding.MergeConfigurations(
+		&forwarding.Configuration{Tls: forwarding.TLSMode_TLSModeEnabled},
+		&forwarding.Configuration{Tls: forwarding.TLSMode_TLSModeDisabled},
+	)
+
+	// Ensure that a listener endpoint can be created without a certificate.
+	if listener, err := NewListenerEndpoint(
+		forwarding.Version_Version1,
+		configuration,
+		"tcp",
+		"127.0.0.1:0",
+	); err != nil {
+		t.Error("unable to create listener endpoint with TLS disabled:", err)
+	} else {
+		listener.Shutdown()
+	}
+}
@@ -1,6 +1,9 @@
 package remote
 
 import (
+	"encoding/binary"
+	"io"
+	"io/ioutil"
 	"net"
 
 	"github.com/pkg/errors"
@@ -12,7 +15,7 @@ import (
 )
 
 // client is a client for a remote forwarding.Endpoint and implements
-// forwarding.Endpoint itself.
+// forwarding.Endpoint (and forwarding.RoutingEndpoint) itself.
 type client struct {
 	// multiplexer is the underlying multiplexer.
 	multiplexer *yamux.Session
@@ -96,11 +99,57 @@ func NewEndpoint(
 
 // Open implements forwarding.Endpoint.Open.
 func (c *client) Open() (net.Conn, error) {
+	// If the remote is a listener, then we just need to wait for it to open a
+	// stream for an incoming connection.
 	if c.listener {
 		return c.multiplexer.Accept()
-	} else {
-		return c.multiplexer.Open()
 	}
+
+	// Otherwise, dial the default target.
+	return c.OpenRoute("")
+}
+
+// OpenRoute implements forwarding.RoutingEndpoint.OpenRoute.
+func (c *client) OpenRoute(route string) (net.Conn, error) {
+	// Verify that the remote is a dialer and that the route can be encoded.
+	if c.listener {
+		return nil, errors.New("routes not supported for listeners")
+	} else if len(route) > maximumRouteLength {
+		return nil, &forwarding.DialError{Err: errors.New("route name too long")}
+	}
+
+	// Open a stream, which will cause the remote to dial.
+	stream, err := c.multiplexer.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the route header.
+	header := make([]byte, 2+len(route))
+	binary.BigEndian.PutUint16(header, uint16(len(route)))
+	copy(header[2:], route)
+	if _, err := stream.Write(header); err != nil {
+		stream.Close()
+		return nil, errors.Wrap(err, "unable to send route header")
+	}
+
+	// Receive the dial result.
+	var result [1]byte
+	if _, err := io.ReadFull(stream, result[:]); err != nil {
+		stream.Close()
+		return nil, errors.Wrap(err, "unable to receive dial result")
+	}
+
+	// If dialing failed, then read the error message and report a
+	// connection-specific failure.
+	if result[0] != dialResultSuccess {
+		message, _ := ioutil.ReadAll(io.LimitReader(stream, maximumDialErrorMessageLength))
+		stream.Close()
+		return nil, &forwarding.DialError{Err: errors.New(string(message))}
+	}
+
+	// Success.
+	return stream, nil
 }
 
 // Shutdown implements forwarding.Endpoint.Shutdown.
@@ -6,6 +6,26 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/url/forwarding"
 )
 
+const (
+	// maximumRouteLength is the maximum length of route name that can be
+	// transmitted in a stream's route header. The route header consists of a
+	// 16-bit big-endian length followed by the route name.
+	maximumRouteLength = 1<<16 - 1
+
+	// dialResultSuccess is the dial result byte sent by remote dialer endpoints
+	// on a newly opened stream when the outgoing connection was established.
+	dialResultSuccess byte = iota
+	// dialResultFailure is the dial result byte sent by remote dialer
+	// endpoints on a newly opened stream when the outgoing connection couldn't
+	// be established. It is followed by the dialing error message, after which
+	// the stream is closed.
+	dialResultFailure
+
+	// maximumDialErrorMessageLength is the maximum length of dialing error
+	// message that will be read from a stream.
+	maximumDialErrorMessageLength = 1024
+)
+
 // ensureValid ensures that InitializeForwardingRequest's invariants are respected.
 func (r *InitializeForwardingRequest) ensureValid() error {
 	// A nil request is invalid.
@@ -1,6 +1,7 @@
 package remote
 
 import (
+	"encoding/binary"
 	"io"
 	"net"
 
@@ -99,38 +100,66 @@ func ServeEndpoint(logger *logging.Logger, connection net.Conn) error {
 
 	// Receive and forward connections indefinitely.
 	for {
-		// Receive the next connection. If this fails, then we should terminate
-		// serving because either the local listener has failed or the
-		// multiplexer has failed.
-		var receivedConnection net.Conn
+		// If we're a listener, then accept the next incoming connection and
+		// open a corresponding stream. If we're a dialer, then accept the next
+		// stream and dial the target in the background. In either case, if
+		// the listener or multiplexer fails, then we should terminate serving.
 		if request.Listener {
-			if receivedConnection, err = endpoint.Open(); err != nil {
+			incoming, err := endpoint.Open()
+			if err != nil {
 				return errors.Wrap(err, "listener failure")
 			}
-		} else {
-			if receivedConnection, err = multiplexer.Accept(); err != nil {
-				return errors.Wrap(err, "multiplexer failure")
-			}
-		}
-
-		// Open the corresponding target connection. If the multiplexer fails,
-		// then we should terminate serving. If local dialing fails, then we can
-		// just close the accepted connection.
-		var outgoingConnection net.Conn
-		if request.Listener {
-			if outgoingConnection, err = multiplexer.Open(); err != nil {
+			outgoing, err := multiplexer.Open()
+			if err != nil {
+				incoming.Close()
 				return errors.Wrap(err, "multiplexer failure")
 			}
+			go forwardAndClose(incoming, outgoing)
 		} else {
-			if outgoingConnection, err = endpoint.Open(); err != nil {
-				receivedConnection.Close()
-				continue
+			stream, err := multiplexer.Accept()
+			if err != nil {
+				return errors.Wrap(err, "multiplexer failure")
 			}
+			go dialAndForward(stream, endpoint)
 		}
+	}
+}
+
+// dialAndForward is a utility function designed to receive the route header for
+// a stream, dial the corresponding target, report the dial result, and perform
+// forwarding in a background Goroutine. If dialing fails, then the error is
+// reported on the stream and the stream is closed.
+func dialAndForward(stream net.Conn, endpoint forwarding.Endpoint) {
+	// Receive the route header.
+	var routeLength [2]byte
+	if _, err := io.ReadFull(stream, routeLength[:]); err != nil {
+		stream.Close()
+		return
+	}
+	route := make([]byte, binary.BigEndian.Uint16(routeLength[:]))
+	if _, err := io.ReadFull(stream, route); err != nil {
+		stream.Close()
+		return
+	}
 
-		// Perform forwarding.
-		go forwardAndClose(receivedConnection, outgoingConnection)
+	// Attempt to dial the target. Dialer endpoints created by the local
+	// package always support routing.
+	target, err := endpoint.(forwarding.RoutingEndpoint).OpenRoute(string(route))
+	if err != nil {
+		stream.Write(append([]byte{dialResultFailure}, err.Error()...))
+		stream.Close()
+		return
+	}
+
+	// Report the successful dial.
+	if _, err := stream.Write([]byte{dialResultSuccess}); err != nil {
+		stream.Close()
+		target.Close()
+		return
 	}
+
+	// Perform forwarding.
+	forwardAndClose(stream, target)
 }
 
 // forwardAndClose is a simple utility function designed to perform connection
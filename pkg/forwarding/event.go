@@ -0,0 +1,154 @@
+package forwarding
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// EnsureValid ensures that Event's invariants are respected.
+func (e *Event) EnsureValid() error {
+	// A nil event is not valid.
+	if e == nil {
+		return errors.New("nil event")
+	}
+
+	// Ensure that the session identifier is non-empty.
+	if e.Session == "" {
+		return errors.New("empty session identifier")
+	}
+
+	// We intentionally don't validate the event type or statuses for the same
+	// reasons that we don't validate statuses in State.
+
+	// Success.
+	return nil
+}
+
+// diffState computes the events that describe the transition between two
+// snapshots of a session's state. Since snapshots are taken at state index
+// changes, rapid intermediate transitions may be coalesced.
+func diffState(previous, current *State, stateIndex uint64) []*Event {
+	// Extract the session identifier.
+	session := current.Session.Identifier
+
+	// Track events.
+	var events []*Event
+
+	// Check for pausing and resuming.
+	if !previous.Session.Paused && current.Session.Paused {
+		events = append(events, &Event{Type: EventType_SessionPaused, Session: session, StateIndex: stateIndex})
+	} else if previous.Session.Paused && !current.Session.Paused {
+		events = append(events, &Event{Type: EventType_SessionResumed, Session: session, StateIndex: stateIndex})
+	}
+
+	// Check for endpoint connection changes.
+	if previous.SourceConnected != current.SourceConnected {
+		events = append(events, &Event{
+			Type:       EventType_SourceConnectionChanged,
+			Session:    session,
+			StateIndex: stateIndex,
+			Connected:  current.SourceConnected,
+		})
+	}
+	if previous.DestinationConnected != current.DestinationConnected {
+		events = append(events, &Event{
+			Type:       EventType_DestinationConnectionChanged,
+			Session:    session,
+			StateIndex: stateIndex,
+			Connected:  current.DestinationConnected,
+		})
+	}
+
+	// Check for status changes.
+	if previous.Status != current.Status {
+		events = append(events, &Event{
+			Type:           EventType_StatusChanged,
+			Session:        session,
+			StateIndex:     stateIndex,
+			PreviousStatus: previous.Status,
+			Status:         current.Status,
+		})
+	}
+
+	// Check for connection count changes.
+	if previous.OpenConnections != current.OpenConnections ||
+		previous.TotalConnections != current.TotalConnections ||
+		previous.FailedConnections != current.FailedConnections {
+		events = append(events, &Event{
+			Type:              EventType_ConnectionsChanged,
+			Session:           session,
+			StateIndex:        stateIndex,
+			OpenConnections:   current.OpenConnections,
+			TotalConnections:  current.TotalConnections,
+			FailedConnections: current.FailedConnections,
+		})
+	}
+
+	// Check for error changes.
+	if previous.LastError != current.LastError {
+		events = append(events, &Event{
+			Type:       EventType_ErrorChanged,
+			Session:    session,
+			StateIndex: stateIndex,
+			LastError:  current.LastError,
+		})
+	}
+
+	// Done.
+	return events
+}
+
+// diffStates computes the events that describe the transition between two sets
+// of session state snapshots, each keyed by session identifier. Sessions that
+// only appear in the current set are reported as added and sessions that only
+// appear in the previous set are reported as removed. Events are ordered by
+// session creation time, with removals reported last.
+func diffStates(previous, current map[string]*State, stateIndex uint64) []*Event {
+	// Sort the current states by session creation time.
+	states := make([]*State, 0, len(current))
+	for _, state := range current {
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		iTime := states[i].Session.CreationTime
+		jTime := states[j].Session.CreationTime
+		return iTime.Seconds < jTime.Seconds ||
+			(iTime.Seconds == jTime.Seconds && iTime.Nanos < jTime.Nanos)
+	})
+
+	// Compute events for current sessions.
+	var events []*Event
+	for _, state := range states {
+		identifier := state.Session.Identifier
+		if p, ok := previous[identifier]; ok {
+			events = append(events, diffState(p, state, stateIndex)...)
+			continue
+		}
+		events = append(events, &Event{
+			Type:       EventType_SessionAdded,
+			Session:    identifier,
+			StateIndex: stateIndex,
+			Status:     state.Status,
+		})
+	}
+
+	// Compute events for removed sessions.
+	var removed []string
+	for identifier := range previous {
+		if _, ok := current[identifier]; !ok {
+			removed = append(removed, identifier)
+		}
+	}
+	sort.Strings(removed)
+	for _, identifier := range removed {
+		events = append(events, &Event{
+			Type:       EventType_SessionRemoved,
+			Session:    identifier,
+			StateIndex: stateIndex,
+		})
+	}
+
+	// Done.
+	return events
+}
@@ -0,0 +1,89 @@
+package forwarding
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// checkEventTypes verifies that a list of events has the expected types.
+func checkEventTypes(t *testing.T, events []*Event, expected ...EventType) {
+	// Mark this as a helper function to remove it from error traces.
+	t.Helper()
+
+	// Compare types.
+	if len(events) != len(expected) {
+		t.Fatal("event count mismatch:", len(events), "!=", len(expected))
+	}
+	for i, event := range events {
+		if event.Type != expected[i] {
+			t.Fatal("event type mismatch at index", i, ":", event.Type, "!=", expected[i])
+		}
+	}
+}
+
+func TestDiffStatesAddedAndRemoved(t *testing.T) {
+	// Create states.
+	first := &State{
+		Session: &Session{Identifier: "first", CreationTime: &timestamp.Timestamp{Seconds: 1}},
+		Status:  Status_ForwardingConnections,
+	}
+	second := &State{
+		Session: &Session{Identifier: "second", CreationTime: &timestamp.Timestamp{Seconds: 2}},
+	}
+
+	// Verify initial events, which should be ordered by creation time.
+	events := diffStates(nil, map[string]*State{"second": second, "first": first}, 1)
+	checkEventTypes(t, events, EventType_SessionAdded, EventType_SessionAdded)
+	if events[0].Session != "first" || events[1].Session != "second" {
+		t.Error("session addition events incorrectly ordered")
+	}
+	if events[0].Status != Status_ForwardingConnections {
+		t.Error("session addition event has incorrect status")
+	}
+
+	// Verify removal events.
+	events = diffStates(map[string]*State{"first": first, "second": second}, map[string]*State{"first": first}, 2)
+	checkEventTypes(t, events, EventType_SessionRemoved)
+	if events[0].Session != "second" {
+		t.Error("session removal event has incorrect session")
+	}
+}
+
+func TestDiffState(t *testing.T) {
+	// Create states.
+	previous := &State{
+		Session: &Session{Identifier: "session", Paused: true},
+	}
+	current := &State{
+		Session:              &Session{Identifier: "session"},
+		Status:               Status_ForwardingConnections,
+		SourceConnected:      true,
+		DestinationConnected: true,
+		OpenConnections:      1,
+		TotalConnections:     2,
+		LastError:            "error",
+	}
+
+	// Verify events.
+	events := diffState(previous, current, 1)
+	checkEventTypes(t, events,
+		EventType_SessionResumed,
+		EventType_SourceConnectionChanged,
+		EventType_DestinationConnectionChanged,
+		EventType_StatusChanged,
+		EventType_ConnectionsChanged,
+		EventType_ErrorChanged,
+	)
+	if events[3].PreviousStatus != Status_Disconnected || events[3].Status != Status_ForwardingConnections {
+		t.Error("status change event has incorrect statuses")
+	}
+	if events[4].OpenConnections != 1 || events[4].TotalConnections != 2 {
+		t.Error("connection change event has incorrect counts")
+	}
+
+	// Verify that identical states don't generate events.
+	if events := diffState(current, current, 2); len(events) != 0 {
+		t.Error("identical states generated events")
+	}
+}
@@ -0,0 +1,346 @@
+package forwarding
+
+import (
+	"bufio"
+	contextpkg "context"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	syncpkg "sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/golang/protobuf/ptypes"
+
+	forwardingurl "github.com/mutagen-io/mutagen/pkg/url/forwarding"
+)
+
+const (
+	// maximumHTTPRequestLogEntries is the maximum number of entries retained in
+	// an HTTP request log.
+	maximumHTTPRequestLogEntries = 1000
+)
+
+// httpRequestLog is a bounded log of recent HTTP requests. Its zero value is
+// valid and it is safe for concurrent usage.
+type httpRequestLog struct {
+	// lock guards the entries member.
+	lock syncpkg.Mutex
+	// entries are the log entries, ordered from oldest to newest.
+	entries []*HTTPRequestLogEntry
+}
+
+// record adds an entry to the log, evicting the oldest entry if necessary.
+func (l *httpRequestLog) record(entry *HTTPRequestLogEntry) {
+	// Lock the log and defer its release.
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	// Evict the oldest entry if the log is full.
+	if len(l.entries) == maximumHTTPRequestLogEntries {
+		copy(l.entries, l.entries[1:])
+		l.entries = l.entries[:len(l.entries)-1]
+	}
+
+	// Record the entry.
+	l.entries = append(l.entries, entry)
+}
+
+// snapshot returns a copy of the current log entries. The entries themselves
+// are not copied since they are never modified after being recorded.
+func (l *httpRequestLog) snapshot() []*HTTPRequestLogEntry {
+	// Lock the log and defer its release.
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	// Copy the entries.
+	result := make([]*HTTPRequestLogEntry, len(l.entries))
+	copy(result, l.entries)
+	return result
+}
+
+// isHTTPSession determines whether or not a session should perform HTTP-aware
+// forwarding, which is the case if its source uses the HTTP protocol.
+func isHTTPSession(session *Session) bool {
+	protocol, _, err := forwardingurl.Parse(session.Source.Path)
+	return err == nil && protocol == "http"
+}
+
+// httpRouteForHost determines the route that should be used for a request
+// with the specified host. Hosts are matched exactly first and then without
+// any port specification. If no route matches, then the default (empty) route
+// is returned.
+func httpRouteForHost(routes map[string]string, host string) string {
+	if _, ok := routes[host]; ok {
+		return host
+	} else if h, _, err := net.SplitHostPort(host); err == nil {
+		if _, ok := routes[h]; ok {
+			return h
+		}
+	}
+	return ""
+}
+
+// endpointListener adapts an Endpoint to the net.Listener interface. Closing
+// the listener doesn't shut down the underlying endpoint, which remains the
+// responsibility of the caller.
+type endpointListener struct {
+	// endpoint is the underlying endpoint.
+	endpoint Endpoint
+	// wrap is an optional function used to wrap accepted connections.
+	wrap func(net.Conn) net.Conn
+}
+
+// Accept implements net.Listener.Accept.
+func (l *endpointListener) Accept() (net.Conn, error) {
+	connection, err := l.endpoint.Open()
+	if err != nil || l.wrap == nil {
+		return connection, err
+	}
+	return l.wrap(connection), nil
+}
+
+// Close implements net.Listener.Close.
+func (l *endpointListener) Close() error {
+	return nil
+}
+
+// httpAddress is the net.Addr implementation returned by endpointListener.
+type httpAddress struct{}
+
+// Network implements net.Addr.Network.
+func (httpAddress) Network() string {
+	return "forwarding"
+}
+
+// String implements net.Addr.String.
+func (httpAddress) String() string {
+	return "forwarding"
+}
+
+// Addr implements net.Listener.Addr.
+func (l *endpointListener) Addr() net.Addr {
+	return httpAddress{}
+}
+
+// httpRouteContextKey is the context key type used to pass the selected route
+// from the request handler to the proxy's dialing function.
+type httpRouteContextKey struct{}
+
+// httpResponseRecorder wraps an http.ResponseWriter to record the response
+// status, the time at which response headers were written, and any proxying
+// error. It passes through flushing and hijacking support (the latter being
+// required for protocol upgrades like WebSockets).
+type httpResponseRecorder struct {
+	http.ResponseWriter
+	// status is the response status code.
+	status int
+	// headerTime is the time at which response headers were written.
+	headerTime time.Time
+	// err is any error encountered while proxying.
+	err error
+	// hijacked indicates whether or not the underlying connection has been
+	// hijacked (e.g. for a WebSocket upgrade).
+	hijacked bool
+}
+
+// WriteHeader implements http.ResponseWriter.WriteHeader.
+func (r *httpResponseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+		r.headerTime = time.Now()
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements http.ResponseWriter.Write.
+func (r *httpResponseRecorder) Write(data []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+		r.headerTime = time.Now()
+	}
+	return r.ResponseWriter.Write(data)
+}
+
+// Flush implements http.Flusher.Flush.
+func (r *httpResponseRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker.Hijack.
+func (r *httpResponseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("hijacking not supported")
+	}
+	connection, buffer, err := hijacker.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	r.hijacked = true
+	if r.status == 0 {
+		r.status = http.StatusSwitchingProtocols
+		r.headerTime = time.Now()
+	}
+	return connection, buffer, nil
+}
+
+// forwardHTTP is the forwarding loop used for HTTP-aware sessions. Instead of
+// forwarding raw connections, it serves HTTP on connections accepted from the
+// source and proxies requests to the destination, selecting the destination
+// target for each request based on its host and the configured HTTP routes.
+// Requests are recorded in the controller's request log.
+func (c *controller) forwardHTTP(source, destination Endpoint, state *State) error {
+	// Create a channel to track terminal forwarding errors. Only the first
+	// error matters, so sends on this channel should be non-blocking.
+	forwardingErrors := make(chan error, 1)
+
+	// Create the transport that we'll use to reach the destination. Since
+	// connections are pooled by request host and each host maps to a single
+	// route, pooled connections will always be associated with the correct
+	// route.
+	transport := &http.Transport{
+		DialContext: func(context contextpkg.Context, _, _ string) (net.Conn, error) {
+			// Extract the route.
+			route, _ := context.Value(httpRouteContextKey{}).(string)
+
+			// Open a connection to the target.
+			var connection net.Conn
+			var err error
+			if router, ok := destination.(RoutingEndpoint); ok {
+				connection, err = router.OpenRoute(route)
+			} else if route == "" {
+				connection, err = destination.Open()
+			} else {
+				err = &DialError{Err: errors.New("destination does not support routing")}
+			}
+
+			// Handle failure. Dial failures only affect the current request,
+			// but any other failure indicates that the destination has failed.
+			if err != nil {
+				if IsDialError(err) {
+					c.stateLock.Lock()
+					state.FailedConnections++
+					state.LastError = errors.Wrap(err, "unable to dial destination").Error()
+					c.stateLock.Unlock()
+				} else {
+					select {
+					case forwardingErrors <- errors.Wrap(err, "unable to open forwarding connection"):
+					default:
+					}
+				}
+				return nil, err
+			}
+
+			// Success. We count the data sent to and received from the
+			// destination in the same manner as raw forwarding.
+			return newCountingConnection(connection, c.stateLock, state), nil
+		},
+		MaxIdleConnsPerHost: 8,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	defer transport.CloseIdleConnections()
+
+	// Create the proxy.
+	proxy := &httputil.ReverseProxy{
+		Director: func(request *http.Request) {
+			request.URL.Scheme = "http"
+			request.URL.Host = request.Host
+			if request.URL.Host == "" {
+				request.URL.Host = "localhost"
+			}
+		},
+		Transport: transport,
+		ErrorLog:  log.New(c.logger.DebugWriter(), "", 0),
+		ErrorHandler: func(writer http.ResponseWriter, request *http.Request, err error) {
+			if recorder, ok := writer.(*httpResponseRecorder); ok {
+				recorder.err = err
+			}
+			writer.WriteHeader(http.StatusBadGateway)
+		},
+	}
+
+	// Extract HTTP routes.
+	routes := c.mergedDestinationConfiguration.HttpRoutes
+
+	// Create the server. We track incoming connections in the same manner as
+	// raw forwarding so that connection counts remain meaningful.
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			// Record the start time and select the route.
+			start := time.Now()
+			route := httpRouteForHost(routes, request.Host)
+
+			// Proxy the request.
+			recorder := &httpResponseRecorder{ResponseWriter: writer}
+			context := contextpkg.WithValue(request.Context(), httpRouteContextKey{}, route)
+			proxy.ServeHTTP(recorder, request.WithContext(context))
+
+			// If the connection was hijacked, then the server no longer tracks
+			// it and won't report its closure. The proxy closes hijacked
+			// connections before returning, so we count the closure here.
+			if recorder.hijacked {
+				c.stateLock.Lock()
+				state.OpenConnections--
+				c.stateLock.Unlock()
+			}
+
+			// Compute latency.
+			latency := time.Since(start)
+			if !recorder.headerTime.IsZero() {
+				latency = recorder.headerTime.Sub(start)
+			}
+
+			// Create and record the log entry.
+			entry := &HTTPRequestLogEntry{
+				Method:  request.Method,
+				Host:    request.Host,
+				Path:    request.URL.RequestURI(),
+				Route:   route,
+				Status:  uint32(recorder.status),
+				Latency: ptypes.DurationProto(latency),
+			}
+			if recorder.err != nil {
+				entry.Status = 0
+				entry.Error = recorder.err.Error()
+			}
+			if timestamp, err := ptypes.TimestampProto(start); err == nil {
+				entry.Time = timestamp
+			}
+			c.requestLog.record(entry)
+			c.logger.Debugf("%s %s%s -> %d (%s)", entry.Method, entry.Host, entry.Path, entry.Status, latency)
+		}),
+		ConnState: func(_ net.Conn, connectionState http.ConnState) {
+			switch connectionState {
+			case http.StateNew:
+				c.stateLock.Lock()
+				state.OpenConnections++
+				state.TotalConnections++
+				c.stateLock.Unlock()
+			case http.StateClosed:
+				c.stateLock.Lock()
+				state.OpenConnections--
+				c.stateLock.Unlock()
+			}
+		},
+		ErrorLog: log.New(c.logger.DebugWriter(), "", 0),
+	}
+	defer server.Close()
+
+	// Serve requests in a background Goroutine. Serving will only terminate if
+	// the source fails to accept connections.
+	go func() {
+		err := server.Serve(&endpointListener{source, c.wrapForCapture})
+		select {
+		case forwardingErrors <- errors.Wrap(err, "unable to accept connection"):
+		default:
+		}
+	}()
+
+	// Wait for a terminal forwarding error.
+	return <-forwardingErrors
+}
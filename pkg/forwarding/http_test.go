@@ -0,0 +1,285 @@
+package forwarding
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/state"
+)
+
+// TestHTTPRequestLogBounded tests that HTTP request logs retain only the most
+// recent entries.
+func TestHTTPRequestLogBounded(t *testing.T) {
+	// Record more entries than the log can hold.
+	log := &httpRequestLog{}
+	for i := 0; i < maximumHTTPRequestLogEntries+10; i++ {
+		log.record(&HTTPRequestLogEntry{Path: fmt.Sprintf("/%d", i)})
+	}
+
+	// Verify the log contents.
+	entries := log.snapshot()
+	if len(entries) != maximumHTTPRequestLogEntries {
+		t.Fatal("request log has unexpected length:", len(entries))
+	}
+	if entries[0].Path != "/10" {
+		t.Error("oldest entry has unexpected path:", entries[0].Path)
+	}
+	if last := entries[len(entries)-1].Path; last != fmt.Sprintf("/%d", maximumHTTPRequestLogEntries+9) {
+		t.Error("newest entry has unexpected path:", last)
+	}
+}
+
+// TestHTTPRouteForHost tests HTTP route selection.
+func TestHTTPRouteForHost(t *testing.T) {
+	// Create routes.
+	routes := map[string]string{
+		"api.localhost":      "tcp:localhost:8081",
+		"admin.localhost:80": "tcp:localhost:8082",
+	}
+
+	// Define test cases.
+	testCases := []struct {
+		host     string
+		expected string
+	}{
+		{"", ""},
+		{"localhost", ""},
+		{"api.localhost", "api.localhost"},
+		{"api.localhost:8080", "api.localhost"},
+		{"admin.localhost:80", "admin.localhost:80"},
+		{"admin.localhost", ""},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if route := httpRouteForHost(routes, testCase.host); route != testCase.expected {
+			t.Errorf("route for host \"%s\" does not match expected: \"%s\" != \"%s\"",
+				testCase.host, route, testCase.expected,
+			)
+		}
+	}
+}
+
+// testListenerEndpoint is a listener Endpoint implementation used for testing.
+type testListenerEndpoint struct {
+	net.Listener
+}
+
+// Open implements Endpoint.Open.
+func (e *testListenerEndpoint) Open() (net.Conn, error) {
+	return e.Accept()
+}
+
+// Shutdown implements Endpoint.Shutdown.
+func (e *testListenerEndpoint) Shutdown() error {
+	return e.Close()
+}
+
+// testRoutingEndpoint is a dialer RoutingEndpoint implementation used for
+// testing.
+type testRoutingEndpoint struct {
+	// targets maps routes to TCP addresses.
+	targets map[string]string
+}
+
+// Open implements Endpoint.Open.
+func (e *testRoutingEndpoint) Open() (net.Conn, error) {
+	return e.OpenRoute("")
+}
+
+// OpenRoute implements RoutingEndpoint.OpenRoute.
+func (e *testRoutingEndpoint) OpenRoute(route string) (net.Conn, error) {
+	if address, ok := e.targets[route]; !ok {
+		return nil, &DialError{Err: errors.New("unknown route")}
+	} else if connection, err := net.Dial("tcp", address); err != nil {
+		return nil, &DialError{Err: err}
+	} else {
+		return connection, nil
+	}
+}
+
+// Shutdown implements Endpoint.Shutdown.
+func (e *testRoutingEndpoint) Shutdown() error {
+	return nil
+}
+
+// TestForwardHTTP tests HTTP-aware forwarding with routing.
+func TestForwardHTTP(t *testing.T) {
+	// Create default and routed target servers.
+	defaultServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("default"))
+	}))
+	defer defaultServer.Close()
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("api"))
+	}))
+	defer apiServer.Close()
+
+	// Create the source endpoint.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("unable to create listener:", err)
+	}
+	source := &testListenerEndpoint{listener}
+	defer source.Shutdown()
+
+	// Create the destination endpoint.
+	destination := &testRoutingEndpoint{targets: map[string]string{
+		"":              strings.TrimPrefix(defaultServer.URL, "http://"),
+		"api.localhost": strings.TrimPrefix(apiServer.URL, "http://"),
+	}}
+
+	// Create a controller and start forwarding.
+	c := &controller{
+		stateLock: state.NewTrackingLock(state.NewTracker()),
+		mergedDestinationConfiguration: &Configuration{
+			HttpRoutes: map[string]string{"api.localhost": "unused"},
+		},
+	}
+	go c.forwardHTTP(source, destination, &State{})
+
+	// Define test cases.
+	address := listener.Addr().String()
+	testCases := []struct {
+		host   string
+		status int
+		body   string
+		route  string
+	}{
+		{address, http.StatusOK, "default", ""},
+		{"api.localhost", http.StatusCreated, "api", "api.localhost"},
+	}
+
+	// Process test cases.
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	for _, testCase := range testCases {
+		request, err := http.NewRequest(http.MethodGet, "http://"+address+"/path", nil)
+		if err != nil {
+			t.Fatal("unable to create request:", err)
+		}
+		request.Host = testCase.host
+		response, err := client.Do(request)
+		if err != nil {
+			t.Fatal("unable to perform request:", err)
+		}
+		body, err := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			t.Fatal("unable to read response body:", err)
+		}
+		if response.StatusCode != testCase.status {
+			t.Error("response status mismatch:", response.StatusCode, "!=", testCase.status)
+		}
+		if string(body) != testCase.body {
+			t.Error("response body mismatch:", string(body), "!=", testCase.body)
+		}
+	}
+
+	// Verify the request log.
+	entries := c.requestLog.snapshot()
+	if len(entries) != len(testCases) {
+		t.Fatal("request log has unexpected length:", len(entries))
+	}
+	for i, entry := range entries {
+		if entry.Route != testCases[i].route {
+			t.Error("logged route mismatch:", entry.Route, "!=", testCases[i].route)
+		}
+		if entry.Status != uint32(testCases[i].status) {
+			t.Error("logged status mismatch:", entry.Status, "!=", testCases[i].status)
+		}
+		if entry.Path != "/path" {
+			t.Error("logged path mismatch:", entry.Path)
+		}
+	}
+}
+
+// TestForwardHTTPUpgradeConnectionCount tests that upgraded (hijacked)
+// connections are counted as open until they're closed.
+func TestForwardHTTPUpgradeConnectionCount(t *testing.T) {
+	// Create a target server that upgrades connections and then echoes data.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		connection, buffer, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer connection.Close()
+		buffer.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: test\r\nConnection: Upgrade\r\n\r\n")
+		buffer.Flush()
+		io.Copy(connection, buffer)
+	}))
+	defer server.Close()
+
+	// Create the source endpoint.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("unable to create listener:", err)
+	}
+	source := &testListenerEndpoint{listener}
+	defer source.Shutdown()
+
+	// Create the destination endpoint.
+	destination := &testRoutingEndpoint{targets: map[string]string{
+		"": strings.TrimPrefix(server.URL, "http://"),
+	}}
+
+	// Create a controller and start forwarding.
+	c := &controller{
+		stateLock:                      state.NewTrackingLock(state.NewTracker()),
+		mergedDestinationConfiguration: &Configuration{},
+	}
+	forwardingState := &State{}
+	go c.forwardHTTP(source, destination, forwardingState)
+
+	// Create a function to read the open connection count.
+	openConnections := func() uint64 {
+		c.stateLock.Lock()
+		defer c.stateLock.Unlock()
+		return forwardingState.OpenConnections
+	}
+
+	// Perform an upgrade request.
+	connection, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal("unable to connect:", err)
+	}
+	defer connection.Close()
+	fmt.Fprint(connection, "GET / HTTP/1.1\r\nHost: localhost\r\nUpgrade: test\r\nConnection: Upgrade\r\n\r\n")
+	reader := bufio.NewReader(connection)
+	response, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatal("unable to read upgrade response:", err)
+	} else if response.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatal("unexpected upgrade response status:", response.StatusCode)
+	}
+
+	// Verify that the upgraded connection works and is counted as open.
+	fmt.Fprint(connection, "ping\n")
+	if line, err := reader.ReadString('\n'); err != nil {
+		t.Fatal("unable to read echoed data:", err)
+	} else if line != "ping\n" {
+		t.Error("echoed data mismatch:", line)
+	}
+	if count := openConnections(); count != 1 {
+		t.Error("open connection count while upgraded mismatch:", count, "!= 1")
+	}
+
+	// Close the connection and verify that it's eventually counted as closed.
+	connection.Close()
+	for i := 0; openConnections() != 0; i++ {
+		if i == 100 {
+			t.Fatal("upgraded connection closure not counted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
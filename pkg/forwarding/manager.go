@@ -1,6 +1,7 @@
 package forwarding
 
 import (
+	contextpkg "context"
 	"sort"
 
 	"github.com/pkg/errors"
@@ -98,13 +99,16 @@ func (m *Manager) findControllersBySpecification(specifications []string) ([]*co
 	for _, specification := range specifications {
 		var matched bool
 		for _, controller := range m.sessions {
-			if controller.session.Identifier == specification || controller.session.Name == specification {
+			controller.stateLock.Lock()
+			name := controller.session.Name
+			controller.stateLock.UnlockWithoutNotify()
+			if controller.session.Identifier == specification || name == specification {
 				controllerSet[controller] = true
 				matched = true
 			}
 		}
 		if !matched {
-			return nil, errors.Errorf("specification \"%s\" did not match any sessions", specification)
+			return nil, &selection.NoMatchError{Specification: specification}
 		}
 	}
 
@@ -134,7 +138,10 @@ func (m *Manager) findControllersByLabelSelector(labelSelector string) ([]*contr
 	// Loop over controllers and look for matches.
 	var controllers []*controller
 	for _, controller := range m.sessions {
-		if selector.Matches(controller.session.Labels) {
+		controller.stateLock.Lock()
+		labels := controller.session.Labels
+		controller.stateLock.UnlockWithoutNotify()
+		if selector.Matches(labels) {
 			controllers = append(controllers, controller)
 		}
 	}
@@ -254,6 +261,81 @@ func (m *Manager) List(selection *selection.Selection, previousStateIndex uint64
 	return stateIndex, states, nil
 }
 
+// Watch monitors sessions matching the given selection and invokes the provided
+// callback with events describing changes to their states. Sessions that exist
+// when watching begins are reported via initial session addition events. If
+// the selection uses specifications, then they're resolved once when watching
+// begins, otherwise the selection is re-evaluated at each state change. Watch
+// runs until the context is cancelled, state tracking is terminated, or the
+// callback returns an error.
+func (m *Manager) Watch(context contextpkg.Context, selection *selection.Selection, callback func([]*Event) error) error {
+	return m.WatchStates(context, selection, func(events []*Event, _ map[string]*State) error {
+		return callback(events)
+	})
+}
+
+// WatchStates is like Watch, but it also provides the callback with the session
+// state snapshot from which events were computed, keyed by session identifier.
+// Since the snapshot is captured atomically with the events, callbacks can use
+// it to look up session metadata without racing against subsequent changes.
+// The snapshot should be treated as read-only.
+func (m *Manager) WatchStates(context contextpkg.Context, selection *selection.Selection, callback func([]*Event, map[string]*State) error) error {
+	// Validate the selection. If it uses specifications, then resolve them to
+	// a fixed set of session identifiers.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return errors.Wrap(err, "unable to locate requested sessions")
+	}
+	var identifiers map[string]bool
+	if len(selection.Specifications) > 0 {
+		identifiers = make(map[string]bool, len(controllers))
+		for _, controller := range controllers {
+			identifiers[controller.session.Identifier] = true
+		}
+	}
+
+	// Loop and report changes. Since the tracker's state index starts at 1, our
+	// initial wait will return immediately.
+	var stateIndex uint64
+	var previous map[string]*State
+	for {
+		// Wait for a state change.
+		var poisoned bool
+		stateIndex, poisoned, err = m.tracker.WaitForChangeWithContext(context, stateIndex)
+		if err != nil {
+			return err
+		} else if poisoned {
+			return errors.New("state tracking terminated")
+		}
+
+		// Extract the controllers for the sessions of interest.
+		if identifiers != nil {
+			controllers = controllers[:0]
+			for _, controller := range m.allControllers() {
+				if identifiers[controller.session.Identifier] {
+					controllers = append(controllers, controller)
+				}
+			}
+		} else if controllers, err = m.selectControllers(selection); err != nil {
+			return errors.Wrap(err, "unable to locate requested sessions")
+		}
+
+		// Extract the state from each controller.
+		current := make(map[string]*State, len(controllers))
+		for _, controller := range controllers {
+			current[controller.session.Identifier] = controller.currentState()
+		}
+
+		// Compute and report events.
+		if events := diffStates(previous, current, stateIndex); len(events) > 0 {
+			if err := callback(events, current); err != nil {
+				return err
+			}
+		}
+		previous = current
+	}
+}
+
 // Pause tells the manager to pause sessions matching the given specifications.
 func (m *Manager) Pause(selection *selection.Selection, prompter string) error {
 	// Extract the controllers for the sessions of interest.
@@ -293,6 +375,33 @@ func (m *Manager) Resume(selection *selection.Selection, prompter string) error
 	return nil
 }
 
+// Update tells the manager to replace the configuration, name, and labels of
+// the session matching the given specification, which must match exactly one
+// session.
+func (m *Manager) Update(
+	specification string,
+	configuration, configurationSource, configurationDestination *Configuration,
+	name string,
+	labels map[string]string,
+	prompter string,
+) error {
+	// Extract the controller for the session of interest.
+	controllers, err := m.findControllersBySpecification([]string{specification})
+	if err != nil {
+		return errors.Wrap(err, "unable to locate requested session")
+	} else if len(controllers) != 1 {
+		return errors.Errorf("specification \"%s\" matched multiple sessions", specification)
+	}
+
+	// Attempt to update.
+	if err := controllers[0].update(configuration, configurationSource, configurationDestination, name, labels, prompter); err != nil {
+		return errors.Wrap(err, "unable to update session")
+	}
+
+	// Success.
+	return nil
+}
+
 // Terminate tells the manager to terminate sessions matching the given
 // specifications.
 func (m *Manager) Terminate(selection *selection.Selection, prompter string) error {
@@ -316,3 +425,82 @@ func (m *Manager) Terminate(selection *selection.Selection, prompter string) err
 	// Success.
 	return nil
 }
+
+// RequestLog retrieves the logs of recent requests for sessions. Only
+// HTTP-aware sessions are included in the result.
+func (m *Manager) RequestLog(selection *selection.Selection) ([]*HTTPRequestLog, error) {
+	// Extract the controllers for the sessions of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to locate requested sessions")
+	}
+
+	// Sort controllers by session creation time.
+	sort.Slice(controllers, func(i, j int) bool {
+		iTime := controllers[i].session.CreationTime
+		jTime := controllers[j].session.CreationTime
+		return iTime.Seconds < jTime.Seconds ||
+			(iTime.Seconds == jTime.Seconds && iTime.Nanos < jTime.Nanos)
+	})
+
+	// Extract request logs.
+	var logs []*HTTPRequestLog
+	for _, controller := range controllers {
+		if isHTTPSession(controller.session) {
+			logs = append(logs, &HTTPRequestLog{
+				Session: controller.session.Identifier,
+				Entries: controller.requestLog.snapshot(),
+			})
+		}
+	}
+
+	// Success.
+	return logs, nil
+}
+
+// StartCapture starts capturing traffic for a session. The selection must
+// identify exactly one session.
+func (m *Manager) StartCapture(
+	selection *selection.Selection,
+	format CaptureFormat,
+	path string,
+	connectionLimit uint64,
+) error {
+	// Extract the controllers for the sessions of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return errors.Wrap(err, "unable to locate requested sessions")
+	} else if len(controllers) != 1 {
+		return errors.New("capture requires exactly one session")
+	}
+
+	// Start the capture.
+	if err := controllers[0].startCapture(format, path, connectionLimit); err != nil {
+		return errors.Wrap(err, "unable to start capture")
+	}
+
+	// Success.
+	return nil
+}
+
+// StopCapture stops capturing traffic for sessions. Sessions without a capture
+// in progress are ignored. Captures are stopped for all selected sessions even
+// if stopping one of them fails, in which case the first error is returned.
+func (m *Manager) StopCapture(selection *selection.Selection) error {
+	// Extract the controllers for the sessions of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return errors.Wrap(err, "unable to locate requested sessions")
+	}
+
+	// Stop the captures, recording the first failure.
+	var firstErr error
+	for _, controller := range controllers {
+		if err := controller.stopCapture(); err != nil && firstErr == nil {
+			firstErr = errors.Wrapf(err, "unable to stop capture for session %s", controller.session.Identifier)
+		}
+	}
+
+	// Done.
+	return firstErr
+}
@@ -0,0 +1,241 @@
+package forwarding
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"time"
+)
+
+const (
+	// pcapngBlockTypeSectionHeader is the pcapng section header block type.
+	pcapngBlockTypeSectionHeader = 0x0A0D0D0A
+	// pcapngBlockTypeInterfaceDescription is the pcapng interface description
+	// block type.
+	pcapngBlockTypeInterfaceDescription = 0x00000001
+	// pcapngBlockTypeEnhancedPacket is the pcapng enhanced packet block type.
+	pcapngBlockTypeEnhancedPacket = 0x00000006
+	// pcapngByteOrderMagic is the pcapng byte order magic number.
+	pcapngByteOrderMagic = 0x1A2B3C4D
+	// pcapngLinkTypeRaw is the link type for raw IP packets.
+	pcapngLinkTypeRaw = 101
+
+	// syntheticTCPHeaderSize is the combined size of the IPv4 and TCP headers
+	// used in synthetic packets.
+	syntheticTCPHeaderSize = 40
+	// maximumSyntheticTCPPayloadSize is the maximum payload size that can be
+	// encoded in a single synthetic packet.
+	maximumSyntheticTCPPayloadSize = 65535 - syntheticTCPHeaderSize
+
+	// tcpFlagFIN is the TCP FIN flag.
+	tcpFlagFIN = 0x01
+	// tcpFlagSYN is the TCP SYN flag.
+	tcpFlagSYN = 0x02
+	// tcpFlagPSH is the TCP PSH flag.
+	tcpFlagPSH = 0x08
+	// tcpFlagACK is the TCP ACK flag.
+	tcpFlagACK = 0x10
+)
+
+var (
+	// syntheticClientIP is the IP address used for the client side of
+	// synthetic TCP streams.
+	syntheticClientIP = net.IPv4(10, 0, 0, 1).To4()
+	// syntheticServerIP is the IP address used for the server side of
+	// synthetic TCP streams.
+	syntheticServerIP = net.IPv4(10, 0, 0, 2).To4()
+)
+
+// pcapngWriter writes pcapng files containing raw IPv4 packets. It is not safe
+// for concurrent usage.
+type pcapngWriter struct {
+	// writer is the underlying writer.
+	writer io.Writer
+}
+
+// newPCAPNGWriter creates a new pcapng writer, writing the section header and
+// interface description blocks to the underlying writer.
+func newPCAPNGWriter(writer io.Writer) (*pcapngWriter, error) {
+	// Create the section header block.
+	sectionHeader := make([]byte, 28)
+	binary.LittleEndian.PutUint32(sectionHeader[0:], pcapngBlockTypeSectionHeader)
+	binary.LittleEndian.PutUint32(sectionHeader[4:], 28)
+	binary.LittleEndian.PutUint32(sectionHeader[8:], pcapngByteOrderMagic)
+	binary.LittleEndian.PutUint16(sectionHeader[12:], 1)
+	binary.LittleEndian.PutUint16(sectionHeader[14:], 0)
+	binary.LittleEndian.PutUint64(sectionHeader[16:], 0xFFFFFFFFFFFFFFFF)
+	binary.LittleEndian.PutUint32(sectionHeader[24:], 28)
+
+	// Create the interface description block. We use a snapshot length of 0,
+	// which indicates no limit, and the default timestamp resolution of
+	// microseconds.
+	interfaceDescription := make([]byte, 20)
+	binary.LittleEndian.PutUint32(interfaceDescription[0:], pcapngBlockTypeInterfaceDescription)
+	binary.LittleEndian.PutUint32(interfaceDescription[4:], 20)
+	binary.LittleEndian.PutUint16(interfaceDescription[8:], pcapngLinkTypeRaw)
+	binary.LittleEndian.PutUint32(interfaceDescription[12:], 0)
+	binary.LittleEndian.PutUint32(interfaceDescription[16:], 20)
+
+	// Write the blocks.
+	if _, err := writer.Write(sectionHeader); err != nil {
+		return nil, err
+	} else if _, err = writer.Write(interfaceDescription); err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &pcapngWriter{writer: writer}, nil
+}
+
+// writePacket writes a packet to the file using an enhanced packet block.
+func (w *pcapngWriter) writePacket(timestamp time.Time, packet []byte) error {
+	// Compute the padded packet length and the total block length.
+	paddedLength := (len(packet) + 3) &^ 3
+	blockLength := 32 + paddedLength
+
+	// Create the block.
+	microseconds := uint64(timestamp.UnixNano() / int64(time.Microsecond))
+	block := make([]byte, blockLength)
+	binary.LittleEndian.PutUint32(block[0:], pcapngBlockTypeEnhancedPacket)
+	binary.LittleEndian.PutUint32(block[4:], uint32(blockLength))
+	binary.LittleEndian.PutUint32(block[8:], 0)
+	binary.LittleEndian.PutUint32(block[12:], uint32(microseconds>>32))
+	binary.LittleEndian.PutUint32(block[16:], uint32(microseconds))
+	binary.LittleEndian.PutUint32(block[20:], uint32(len(packet)))
+	binary.LittleEndian.PutUint32(block[24:], uint32(len(packet)))
+	copy(block[28:], packet)
+	binary.LittleEndian.PutUint32(block[blockLength-4:], uint32(blockLength))
+
+	// Write the block.
+	_, err := w.writer.Write(block)
+	return err
+}
+
+// syntheticTCPStream generates packets for a synthetic TCP stream between a
+// fixed client and server address. It is not safe for concurrent usage.
+type syntheticTCPStream struct {
+	// clientPort is the client port.
+	clientPort uint16
+	// serverPort is the server port.
+	serverPort uint16
+	// clientSequence is the next client sequence number.
+	clientSequence uint32
+	// serverSequence is the next server sequence number.
+	serverSequence uint32
+}
+
+// checksum computes the Internet checksum of the specified data, starting from
+// the specified initial (unfolded) sum.
+func checksum(sum uint32, data []byte) uint16 {
+	for i := 0; i+1 < len(data); i += 2 {
+		sum += uint32(data[i])<<8 | uint32(data[i+1])
+	}
+	if len(data)%2 == 1 {
+		sum += uint32(data[len(data)-1]) << 8
+	}
+	for sum > 0xFFFF {
+		sum = (sum >> 16) + (sum & 0xFFFF)
+	}
+	return ^uint16(sum)
+}
+
+// packet generates a single packet with the specified flags and payload. The
+// payload must not exceed maximumSyntheticTCPPayloadSize. It does not update
+// sequence numbers.
+func (s *syntheticTCPStream) packet(fromClient bool, flags byte, payload []byte) []byte {
+	// Determine addressing and sequencing based on direction.
+	sourceIP, destinationIP := syntheticClientIP, syntheticServerIP
+	sourcePort, destinationPort := s.clientPort, s.serverPort
+	sequence, acknowledgement := s.clientSequence, s.serverSequence
+	if !fromClient {
+		sourceIP, destinationIP = destinationIP, sourceIP
+		sourcePort, destinationPort = destinationPort, sourcePort
+		sequence, acknowledgement = acknowledgement, sequence
+	}
+
+	// Allocate the packet.
+	packet := make([]byte, syntheticTCPHeaderSize+len(payload))
+
+	// Fill in the IPv4 header.
+	ip := packet[:20]
+	ip[0] = 0x45
+	binary.BigEndian.PutUint16(ip[2:], uint16(len(packet)))
+	binary.BigEndian.PutUint16(ip[6:], 0x4000)
+	ip[8] = 64
+	ip[9] = 6
+	copy(ip[12:], sourceIP)
+	copy(ip[16:], destinationIP)
+	binary.BigEndian.PutUint16(ip[10:], checksum(0, ip))
+
+	// Fill in the TCP header and payload.
+	tcp := packet[20:]
+	binary.BigEndian.PutUint16(tcp[0:], sourcePort)
+	binary.BigEndian.PutUint16(tcp[2:], destinationPort)
+	binary.BigEndian.PutUint32(tcp[4:], sequence)
+	if flags&tcpFlagACK != 0 {
+		binary.BigEndian.PutUint32(tcp[8:], acknowledgement)
+	}
+	tcp[12] = 5 << 4
+	tcp[13] = flags
+	binary.BigEndian.PutUint16(tcp[14:], 0xFFFF)
+	copy(tcp[20:], payload)
+
+	// Compute the TCP checksum, including the pseudo-header.
+	pseudoSum := uint32(sourceIP[0])<<8 | uint32(sourceIP[1])
+	pseudoSum += uint32(sourceIP[2])<<8 | uint32(sourceIP[3])
+	pseudoSum += uint32(destinationIP[0])<<8 | uint32(destinationIP[1])
+	pseudoSum += uint32(destinationIP[2])<<8 | uint32(destinationIP[3])
+	pseudoSum += 6 + uint32(len(tcp))
+	binary.BigEndian.PutUint16(tcp[16:], checksum(pseudoSum, tcp))
+
+	// Done.
+	return packet
+}
+
+// open generates the packets for the stream's three-way handshake.
+func (s *syntheticTCPStream) open() [][]byte {
+	// Generate the handshake. Both sides use an initial sequence number of 0.
+	syn := s.packet(true, tcpFlagSYN, nil)
+	s.clientSequence++
+	synAck := s.packet(false, tcpFlagSYN|tcpFlagACK, nil)
+	s.serverSequence++
+	ack := s.packet(true, tcpFlagACK, nil)
+
+	// Done.
+	return [][]byte{syn, synAck, ack}
+}
+
+// data generates the packets necessary to transmit the specified data.
+func (s *syntheticTCPStream) data(fromClient bool, data []byte) [][]byte {
+	var packets [][]byte
+	for len(data) > 0 {
+		// Compute the payload for this packet.
+		payload := data
+		if len(payload) > maximumSyntheticTCPPayloadSize {
+			payload = payload[:maximumSyntheticTCPPayloadSize]
+		}
+		data = data[len(payload):]
+
+		// Generate the packet and update sequencing.
+		packets = append(packets, s.packet(fromClient, tcpFlagPSH|tcpFlagACK, payload))
+		if fromClient {
+			s.clientSequence += uint32(len(payload))
+		} else {
+			s.serverSequence += uint32(len(payload))
+		}
+	}
+	return packets
+}
+
+// close generates the packets for the stream's closing handshake.
+func (s *syntheticTCPStream) close() [][]byte {
+	// Generate the closing handshake.
+	clientFin := s.packet(true, tcpFlagFIN|tcpFlagACK, nil)
+	s.clientSequence++
+	serverFin := s.packet(false, tcpFlagFIN|tcpFlagACK, nil)
+	s.serverSequence++
+	ack := s.packet(true, tcpFlagACK, nil)
+
+	// Done.
+	return [][]byte{clientFin, serverFin, ack}
+}
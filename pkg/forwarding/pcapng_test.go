@@ -0,0 +1,98 @@
+package forwarding
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+// TestPCAPNGWriter tests that pcapng output is correctly framed.
+func TestPCAPNGWriter(t *testing.T) {
+	// Create a writer and write a packet with a length that requires padding.
+	buffer := &bytes.Buffer{}
+	writer, err := newPCAPNGWriter(buffer)
+	if err != nil {
+		t.Fatal("unable to create writer:", err)
+	}
+	if err := writer.writePacket(time.Now(), []byte{1, 2, 3, 4, 5}); err != nil {
+		t.Fatal("unable to write packet:", err)
+	}
+
+	// Walk the blocks and verify their types and framing.
+	data := buffer.Bytes()
+	expectedTypes := []uint32{
+		pcapngBlockTypeSectionHeader,
+		pcapngBlockTypeInterfaceDescription,
+		pcapngBlockTypeEnhancedPacket,
+	}
+	for _, expectedType := range expectedTypes {
+		if len(data) < 12 {
+			t.Fatal("truncated block")
+		}
+		blockType := binary.LittleEndian.Uint32(data[0:])
+		length := binary.LittleEndian.Uint32(data[4:])
+		if blockType != expectedType {
+			t.Error("block type mismatch:", blockType, "!=", expectedType)
+		}
+		if length%4 != 0 || int(length) > len(data) {
+			t.Fatal("invalid block length:", length)
+		}
+		if trailing := binary.LittleEndian.Uint32(data[length-4:]); trailing != length {
+			t.Error("trailing block length mismatch:", trailing, "!=", length)
+		}
+		data = data[length:]
+	}
+	if len(data) != 0 {
+		t.Error("unexpected trailing data")
+	}
+}
+
+// TestSyntheticTCPStreamChecksums tests that synthetic packets carry valid IPv4
+// and TCP checksums.
+func TestSyntheticTCPStreamChecksums(t *testing.T) {
+	// Create a stream and generate a full set of packets.
+	stream := &syntheticTCPStream{clientPort: 1024, serverPort: 8080}
+	packets := stream.open()
+	packets = append(packets, stream.data(true, []byte("hello"))...)
+	packets = append(packets, stream.data(false, []byte("world!"))...)
+	packets = append(packets, stream.close()...)
+
+	// Verify checksums. Recomputing a checksum over data that includes a valid
+	// checksum yields zero.
+	for i, packet := range packets {
+		if checksum(0, packet[:20]) != 0 {
+			t.Error("invalid IPv4 checksum for packet", i)
+		}
+		tcp := packet[20:]
+		pseudoSum := uint32(packet[12])<<8 | uint32(packet[13])
+		pseudoSum += uint32(packet[14])<<8 | uint32(packet[15])
+		pseudoSum += uint32(packet[16])<<8 | uint32(packet[17])
+		pseudoSum += uint32(packet[18])<<8 | uint32(packet[19])
+		pseudoSum += 6 + uint32(len(tcp))
+		if checksum(pseudoSum, tcp) != 0 {
+			t.Error("invalid TCP checksum for packet", i)
+		}
+	}
+
+	// Verify sequencing.
+	if stream.clientSequence != 1+5+1 {
+		t.Error("unexpected client sequence number:", stream.clientSequence)
+	}
+	if stream.serverSequence != 1+6+1 {
+		t.Error("unexpected server sequence number:", stream.serverSequence)
+	}
+}
+
+// TestSyntheticTCPStreamSegmentation tests that large payloads are split
+// across multiple packets.
+func TestSyntheticTCPStreamSegmentation(t *testing.T) {
+	stream := &syntheticTCPStream{clientPort: 1024, serverPort: 8080}
+	packets := stream.data(true, make([]byte, 2*maximumSyntheticTCPPayloadSize+1))
+	if len(packets) != 3 {
+		t.Fatal("unexpected packet count:", len(packets))
+	}
+	if length := binary.BigEndian.Uint16(packets[2][2:]); length != syntheticTCPHeaderSize+1 {
+		t.Error("unexpected final packet length:", length)
+	}
+}
@@ -0,0 +1,62 @@
+package kubernetes
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/agent/transports/kubernetes"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/forwarding/endpoint/remote"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	urlpkg "github.com/mutagen-io/mutagen/pkg/url"
+	forwardingurlpkg "github.com/mutagen-io/mutagen/pkg/url/forwarding"
+)
+
+// protocolHandler implements the forwarding.ProtocolHandler interface for
+// connecting to remote forwarding endpoints inside Kubernetes pods. It uses
+// the agent infrastructure over a Kubernetes transport.
+type protocolHandler struct{}
+
+// Connect connects to a Kubernetes endpoint.
+func (p *protocolHandler) Connect(
+	logger *logging.Logger,
+	url *urlpkg.URL,
+	prompter string,
+	session string,
+	version forwarding.Version,
+	configuration *forwarding.Configuration,
+	source bool,
+) (forwarding.Endpoint, error) {
+	// Verify that the URL is of the correct kind and protocol.
+	if url.Kind != urlpkg.Kind_Forwarding {
+		panic("non-forwarding URL dispatched to forwarding protocol handler")
+	} else if url.Protocol != urlpkg.Protocol_Kubernetes {
+		panic("non-Kubernetes URL dispatched to Kubernetes protocol handler")
+	}
+
+	// Parse the target specification from the URL's Path component.
+	protocol, address, err := forwardingurlpkg.Parse(url.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse target specification")
+	}
+
+	// Create a Kubernetes agent transport.
+	transport, err := kubernetes.NewTransport(url.Host, url.Environment)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create Kubernetes transport")
+	}
+
+	// Dial an agent in forwarding mode.
+	connection, err := agent.Dial(logger, transport, agent.ModeForwarder, prompter)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to dial agent endpoint")
+	}
+
+	// Create the endpoint.
+	return remote.NewEndpoint(connection, version, configuration, protocol, address, source)
+}
+
+func init() {
+	// Register the Kubernetes protocol handler with the forwarding package.
+	forwarding.ProtocolHandlers[urlpkg.Protocol_Kubernetes] = &protocolHandler{}
+}
@@ -0,0 +1,3 @@
+package kubernetes
+
+// TODO: Implement.
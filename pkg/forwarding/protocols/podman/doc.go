@@ -0,0 +1,3 @@
+// Package podman provides the Podman forwarding session protocol
+// implementation.
+package podman
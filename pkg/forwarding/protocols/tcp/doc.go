@@ -0,0 +1,3 @@
+// Package tcp provides the direct TCP forwarding session protocol
+// implementation.
+package tcp
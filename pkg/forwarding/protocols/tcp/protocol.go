@@ -0,0 +1,56 @@
+package tcp
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/agent/direct"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/forwarding/endpoint/remote"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	urlpkg "github.com/mutagen-io/mutagen/pkg/url"
+	forwardingurlpkg "github.com/mutagen-io/mutagen/pkg/url/forwarding"
+)
+
+// protocolHandler implements the forwarding.ProtocolHandler interface for
+// connecting to remote endpoints served by long-lived agents listening for
+// direct TCP connections.
+type protocolHandler struct{}
+
+// Connect connects to a TCP endpoint.
+func (p *protocolHandler) Connect(
+	logger *logging.Logger,
+	url *urlpkg.URL,
+	prompter string,
+	session string,
+	version forwarding.Version,
+	configuration *forwarding.Configuration,
+	source bool,
+) (forwarding.Endpoint, error) {
+	// Verify that the URL is of the correct kind and protocol.
+	if url.Kind != urlpkg.Kind_Forwarding {
+		panic("non-forwarding URL dispatched to forwarding protocol handler")
+	} else if url.Protocol != urlpkg.Protocol_TCP {
+		panic("non-TCP URL dispatched to TCP protocol handler")
+	}
+
+	// Parse the target specification from the URL's Path component.
+	protocol, address, err := forwardingurlpkg.Parse(url.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse target specification")
+	}
+
+	// Dial the agent in forwarding mode.
+	connection, err := direct.Dial(url.Host, uint16(url.Port), url.Environment, agent.ModeForwarder)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to dial agent endpoint")
+	}
+
+	// Create the endpoint.
+	return remote.NewEndpoint(connection, version, configuration, protocol, address, source)
+}
+
+func init() {
+	// Register the TCP protocol handler with the forwarding package.
+	forwarding.ProtocolHandlers[urlpkg.Protocol_TCP] = &protocolHandler{}
+}
@@ -0,0 +1,3 @@
+package tcp
+
+// TODO: Implement.
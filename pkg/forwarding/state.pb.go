@@ -78,7 +78,20 @@ type State struct {
 	OpenConnections uint64 `protobuf:"varint,6,opt,name=openConnections,proto3" json:"openConnections,omitempty"`
 	// TotalConnections is the number of total connections that have been opened
 	// and forwarded (including those that are currently open).
-	TotalConnections     uint64   `protobuf:"varint,7,opt,name=totalConnections,proto3" json:"totalConnections,omitempty"`
+	TotalConnections uint64 `protobuf:"varint,7,opt,name=totalConnections,proto3" json:"totalConnections,omitempty"`
+	// FailedConnections is the number of incoming connections that have been
+	// rejected because a corresponding outgoing connection couldn't be
+	// established to the destination.
+	FailedConnections uint64 `protobuf:"varint,8,opt,name=failedConnections,proto3" json:"failedConnections,omitempty"`
+	// Reconnections is the number of times that the session has reconnected
+	// to its endpoints after a forwarding failure.
+	Reconnections uint64 `protobuf:"varint,9,opt,name=reconnections,proto3" json:"reconnections,omitempty"`
+	// BytesToDestination is the number of bytes that have been forwarded from
+	// the source to the destination.
+	BytesToDestination uint64 `protobuf:"varint,10,opt,name=bytesToDestination,proto3" json:"bytesToDestination,omitempty"`
+	// BytesToSource is the number of bytes that have been forwarded from the
+	// destination to the source.
+	BytesToSource        uint64   `protobuf:"varint,11,opt,name=bytesToSource,proto3" json:"bytesToSource,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -158,6 +171,34 @@ func (m *State) GetTotalConnections() uint64 {
 	return 0
 }
 
+func (m *State) GetFailedConnections() uint64 {
+	if m != nil {
+		return m.FailedConnections
+	}
+	return 0
+}
+
+func (m *State) GetReconnections() uint64 {
+	if m != nil {
+		return m.Reconnections
+	}
+	return 0
+}
+
+func (m *State) GetBytesToDestination() uint64 {
+	if m != nil {
+		return m.BytesToDestination
+	}
+	return 0
+}
+
+func (m *State) GetBytesToSource() uint64 {
+	if m != nil {
+		return m.BytesToSource
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("forwarding.Status", Status_name, Status_value)
 	proto.RegisterType((*State)(nil), "forwarding.State")
@@ -166,24 +207,27 @@ func init() {
 func init() { proto.RegisterFile("forwarding/state.proto", fileDescriptor_074de8db3d66f399) }
 
 var fileDescriptor_074de8db3d66f399 = []byte{
-	// 299 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x4d, 0x4e, 0xf3, 0x30,
-	0x10, 0x86, 0x3f, 0xf7, 0x27, 0xfd, 0x3a, 0x20, 0xb0, 0x4c, 0x41, 0x06, 0xb1, 0x88, 0x58, 0x59,
-	0x15, 0x4d, 0xa4, 0x72, 0x03, 0x28, 0x1c, 0x20, 0xdd, 0xb1, 0x73, 0x53, 0x37, 0x58, 0xb4, 0x9e,
-	0xca, 0x76, 0xc4, 0xc5, 0x38, 0x20, 0x4a, 0xd2, 0xe0, 0x50, 0xd8, 0x45, 0xcf, 0xfb, 0x8c, 0x26,
-	0xef, 0x18, 0xae, 0x36, 0x68, 0x3f, 0xa4, 0x5d, 0x6b, 0x53, 0xa4, 0xce, 0x4b, 0xaf, 0x92, 0xbd,
-	0x45, 0x8f, 0x0c, 0x02, 0xbf, 0xe1, 0x5d, 0x47, 0x39, 0xa7, 0xd1, 0x34, 0xd6, 0xdd, 0x67, 0x0f,
-	0x86, 0xcb, 0x6a, 0x8a, 0xcd, 0x60, 0x74, 0x88, 0x38, 0x89, 0x89, 0x38, 0x99, 0x5f, 0x24, 0x61,
-	0x2a, 0x59, 0x36, 0x51, 0xd6, 0x3a, 0x6c, 0x0a, 0x51, 0xb5, 0xad, 0x74, 0xbc, 0x17, 0x13, 0x71,
-	0x36, 0x67, 0x3f, 0xec, 0x3a, 0xc9, 0x0e, 0x06, 0x13, 0x70, 0xee, 0xb0, 0xb4, 0xb9, 0x7a, 0x42,
-	0x63, 0x54, 0xee, 0xd5, 0x9a, 0xf7, 0x63, 0x22, 0xfe, 0x67, 0xc7, 0x98, 0xcd, 0x61, 0xb2, 0x56,
-	0xce, 0x6b, 0x23, 0xbd, 0x46, 0x13, 0xf4, 0x41, 0xad, 0xff, 0x99, 0xb1, 0x5b, 0x18, 0x6f, 0xa5,
-	0xf3, 0xcf, 0xd6, 0xa2, 0xe5, 0xc3, 0x98, 0x88, 0x71, 0x16, 0x40, 0xb5, 0x1b, 0xf7, 0xaa, 0xd5,
-	0x35, 0x1a, 0xc7, 0xa3, 0x98, 0x88, 0x41, 0x76, 0x8c, 0xd9, 0x14, 0xa8, 0x47, 0x2f, 0xb7, 0x5d,
-	0x75, 0x54, 0xab, 0xbf, 0xf8, 0x74, 0x03, 0x51, 0xd3, 0x91, 0x51, 0x38, 0x5d, 0x68, 0x97, 0xb7,
-	0x7f, 0x43, 0xff, 0xb1, 0x09, 0xd0, 0x56, 0x35, 0xc5, 0xb2, 0x2e, 0x48, 0x09, 0xbb, 0x86, 0xcb,
-	0x40, 0x17, 0xa1, 0x07, 0xed, 0x55, 0xd1, 0xcb, 0xf7, 0xed, 0x3a, 0x5b, 0x68, 0xff, 0x31, 0x79,
-	0xbd, 0x2f, 0xb4, 0x7f, 0x2b, 0x57, 0x49, 0x8e, 0xbb, 0x74, 0x57, 0x7a, 0x59, 0x28, 0x33, 0xd3,
-	0xd8, 0x7e, 0xa6, 0xfb, 0xf7, 0x22, 0x0d, 0x87, 0x5f, 0x45, 0xf5, 0xab, 0x3e, 0x7c, 0x05, 0x00,
-	0x00, 0xff, 0xff, 0x30, 0x88, 0x49, 0x7b, 0x15, 0x02, 0x00, 0x00,
+	// 350 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xcb, 0x4e, 0xeb, 0x30,
+	0x10, 0x86, 0x4f, 0x7a, 0x49, 0xdb, 0xe9, 0x39, 0x87, 0x30, 0x14, 0x64, 0x10, 0x8b, 0x08, 0xb1,
+	0x88, 0xaa, 0x36, 0x91, 0xca, 0x1b, 0x40, 0xe1, 0x01, 0x52, 0x56, 0xec, 0xd2, 0xc4, 0x0d, 0x16,
+	0xad, 0x5d, 0xd9, 0x8e, 0x10, 0x2f, 0xcb, 0xb3, 0xa0, 0x38, 0x0d, 0x4e, 0x2f, 0xbb, 0xe8, 0xfb,
+	0x3f, 0x7b, 0xe2, 0x99, 0x81, 0xab, 0x95, 0x90, 0x9f, 0x89, 0xcc, 0x18, 0xcf, 0x23, 0xa5, 0x13,
+	0x4d, 0xc3, 0xad, 0x14, 0x5a, 0x20, 0x58, 0x7e, 0x43, 0x9a, 0x0e, 0x55, 0x8a, 0x09, 0x5e, 0x59,
+	0x77, 0xdf, 0x6d, 0xe8, 0x2e, 0xca, 0x53, 0x38, 0x85, 0xde, 0x2e, 0x22, 0x8e, 0xef, 0x04, 0xc3,
+	0xd9, 0x45, 0x68, 0x4f, 0x85, 0x8b, 0x2a, 0x8a, 0x6b, 0x07, 0xc7, 0xe0, 0x96, 0xd5, 0x0a, 0x45,
+	0x5a, 0xbe, 0x13, 0xfc, 0x9f, 0xe1, 0x9e, 0x6d, 0x92, 0x78, 0x67, 0x60, 0x00, 0x67, 0x4a, 0x14,
+	0x32, 0xa5, 0x4f, 0x82, 0x73, 0x9a, 0x6a, 0x9a, 0x91, 0xb6, 0xef, 0x04, 0xfd, 0xf8, 0x10, 0xe3,
+	0x0c, 0x46, 0x19, 0x55, 0x9a, 0xf1, 0x44, 0x33, 0xc1, 0xad, 0xde, 0x31, 0xfa, 0xc9, 0x0c, 0x6f,
+	0x61, 0xb0, 0x4e, 0x94, 0x7e, 0x96, 0x52, 0x48, 0xd2, 0xf5, 0x9d, 0x60, 0x10, 0x5b, 0x50, 0xd6,
+	0x16, 0x5b, 0x5a, 0xeb, 0x4c, 0x70, 0x45, 0x5c, 0xdf, 0x09, 0x3a, 0xf1, 0x21, 0xc6, 0x31, 0x78,
+	0x5a, 0xe8, 0x64, 0xdd, 0x54, 0x7b, 0x46, 0x3d, 0xe2, 0x38, 0x81, 0xf3, 0x55, 0xc2, 0xd6, 0x34,
+	0x6b, 0xca, 0x7d, 0x23, 0x1f, 0x07, 0x78, 0x0f, 0xff, 0x24, 0x4d, 0x1b, 0xe6, 0xc0, 0x98, 0xfb,
+	0x10, 0x43, 0xc0, 0xe5, 0x97, 0xa6, 0xea, 0x55, 0xcc, 0xed, 0x33, 0x09, 0x18, 0xf5, 0x44, 0x52,
+	0xde, 0xba, 0xa3, 0x0b, 0xd3, 0x45, 0x32, 0xac, 0x6e, 0xdd, 0x83, 0xe3, 0x15, 0xb8, 0xd5, 0x34,
+	0xd0, 0x83, 0xbf, 0x73, 0xa6, 0xd2, 0xba, 0x6f, 0xde, 0x1f, 0x1c, 0x81, 0x57, 0xff, 0x26, 0xcf,
+	0x2b, 0xdf, 0x73, 0xf0, 0x1a, 0x2e, 0x2d, 0x6d, 0x14, 0xf4, 0x5a, 0x65, 0xf4, 0xf2, 0x3b, 0xe5,
+	0xc6, 0x0b, 0xbd, 0xf6, 0x63, 0xf8, 0x36, 0xc9, 0x99, 0x7e, 0x2f, 0x96, 0x61, 0x2a, 0x36, 0xd1,
+	0xa6, 0xd0, 0x49, 0x4e, 0xf9, 0x94, 0x89, 0xfa, 0x33, 0xda, 0x7e, 0xe4, 0x91, 0x5d, 0x91, 0xa5,
+	0x6b, 0xf6, 0xef, 0xe1, 0x67, 0x00, 0x1b, 0x95, 0xa2, 0x3a, 0xbf, 0x02, 0x00, 0x00,
 }
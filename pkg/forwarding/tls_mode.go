@@ -0,0 +1,63 @@
+package forwarding
+
+import (
+	"github.com/pkg/errors"
+)
+
+// IsDefault indicates whether or not the TLS mode is TLSMode_TLSModeDefault.
+func (m TLSMode) IsDefault() bool {
+	return m == TLSMode_TLSModeDefault
+}
+
+// Enabled indicates whether or not the TLS mode enables TLS. The default TLS
+// mode is treated as disabled.
+func (m TLSMode) Enabled() bool {
+	return m == TLSMode_TLSModeEnabled
+}
+
+// UnmarshalText implements the text unmarshalling interface used when loading
+// from TOML files.
+func (m *TLSMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a TLS mode.
+	switch text {
+	case "enabled":
+		*m = TLSMode_TLSModeEnabled
+	case "disabled":
+		*m = TLSMode_TLSModeDisabled
+	default:
+		return errors.Errorf("unknown TLS mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular TLS mode is a valid,
+// non-default value.
+func (m TLSMode) Supported() bool {
+	switch m {
+	case TLSMode_TLSModeEnabled:
+		return true
+	case TLSMode_TLSModeDisabled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a TLS mode.
+func (m TLSMode) Description() string {
+	switch m {
+	case TLSMode_TLSModeDefault:
+		return "Default"
+	case TLSMode_TLSModeEnabled:
+		return "Enabled"
+	case TLSMode_TLSModeDisabled:
+		return "Disabled"
+	default:
+		return "Unknown"
+	}
+}
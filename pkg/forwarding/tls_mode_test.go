@@ -0,0 +1,91 @@
+package forwarding
+
+import (
+	"testing"
+)
+
+// TestTLSModeUnmarshal tests that unmarshaling from a string specification
+// succeeds for TLSMode.
+func TestTLSModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  TLSMode
+		expectFailure bool
+	}{
+		{"", TLSMode_TLSModeDefault, true},
+		{"asdf", TLSMode_TLSModeDefault, true},
+		{"enabled", TLSMode_TLSModeEnabled, false},
+		{"disabled", TLSMode_TLSModeDisabled, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode TLSMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestTLSModeSupported tests that TLSMode support detection works as
+// expected.
+func TestTLSModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            TLSMode
+		expectSupported bool
+	}{
+		{TLSMode_TLSModeDefault, false},
+		{TLSMode_TLSModeEnabled, true},
+		{TLSMode_TLSModeDisabled, true},
+		{(TLSMode_TLSModeDisabled + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestTLSModeDescription tests that TLSMode description generation works as
+// expected.
+func TestTLSModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                TLSMode
+		expectedDescription string
+	}{
+		{TLSMode_TLSModeDefault, "Default"},
+		{TLSMode_TLSModeEnabled, "Enabled"},
+		{TLSMode_TLSModeDisabled, "Disabled"},
+		{(TLSMode_TLSModeDisabled + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package forwarding
 
 import (
+	"time"
+
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 )
 
@@ -14,6 +16,17 @@ func (v Version) Supported() bool {
 	}
 }
 
+// DefaultDialRetryInterval returns the default initial dial retry interval for
+// the session version.
+func (v Version) DefaultDialRetryInterval() time.Duration {
+	switch v {
+	case Version_Version1:
+		return 250 * time.Millisecond
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
 // DefaultSocketOverwriteMode returns the default socket overwrite mode for the
 // session version.
 func (v Version) DefaultSocketOverwriteMode() SocketOverwriteMode {
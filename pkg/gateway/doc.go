@@ -0,0 +1,6 @@
+// Package gateway provides an HTTP/JSON API gateway for the daemon. It maps
+// REST-style requests onto the daemon's gRPC services, encoding request and
+// response bodies using the Protocol Buffers JSON representation of the
+// corresponding service messages, and streams session state changes using
+// server-sent events.
+package gateway
@@ -0,0 +1,80 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// eventSource is a function that retrieves session states, blocking until the
+// state index differs from previousStateIndex (if non-zero). It returns the
+// new state index and a message encoding the states.
+type eventSource func(ctx context.Context, previousStateIndex uint64) (uint64, proto.Message, error)
+
+// streamEvents streams session states as server-sent events until the client
+// disconnects or an error occurs. Each state change is sent as a "state" event
+// whose identifier is the state index and whose data is the JSON encoding of
+// the states. Errors are reported as an "error" event before the stream ends.
+func streamEvents(writer http.ResponseWriter, request *http.Request, source eventSource) {
+	// Ensure that the response writer supports flushing.
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		writeError(writer, http.StatusInternalServerError, errors.New("streaming not supported"))
+		return
+	}
+
+	// Determine the initial state index. If the client is reconnecting, then
+	// it will provide the last state index that it saw.
+	previousStateIndex, err := queryStateIndex(request)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	if lastEventID := request.Header.Get("Last-Event-ID"); lastEventID != "" {
+		if previousStateIndex, err = strconv.ParseUint(lastEventID, 10, 64); err != nil {
+			writeError(writer, http.StatusBadRequest, errors.Wrap(err, "invalid last event identifier"))
+			return
+		}
+	}
+
+	// Write the response header.
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// Stream state changes.
+	ctx := request.Context()
+	for {
+		// Wait for and encode the next state.
+		stateIndex, states, err := source(ctx, previousStateIndex)
+		var encoded string
+		if err == nil {
+			encoded, err = marshaler.MarshalToString(states)
+		}
+
+		// Handle errors. If the client has disconnected, then there's nobody to
+		// report the error to.
+		if err != nil {
+			if ctx.Err() == nil {
+				data, _ := json.Marshal(&errorResponse{err.Error()})
+				fmt.Fprintf(writer, "event: error\ndata: %s\n\n", data)
+				flusher.Flush()
+			}
+			return
+		}
+
+		// Send the event.
+		fmt.Fprintf(writer, "id: %d\nevent: state\ndata: %s\n\n", stateIndex, encoded)
+		flusher.Flush()
+
+		// Update the state index.
+		previousStateIndex = stateIndex
+	}
+}
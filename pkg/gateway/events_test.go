@@ -0,0 +1,81 @@
+package gateway
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/golang/protobuf/proto"
+
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+)
+
+// TestStreamEvents tests server-sent event streaming, including resumption via
+// the Last-Event-ID header and error reporting.
+func TestStreamEvents(t *testing.T) {
+	// Create a server that streams two states and then fails, recording the
+	// previous state indices that it observes.
+	var observed []uint64
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		streamEvents(writer, request, func(_ context.Context, previousStateIndex uint64) (uint64, proto.Message, error) {
+			observed = append(observed, previousStateIndex)
+			if len(observed) > 2 {
+				return 0, nil, errors.New("source failed")
+			}
+			return previousStateIndex + 1, &synchronizationsvc.ListResponse{StateIndex: previousStateIndex + 1}, nil
+		})
+	}))
+	defer server.Close()
+
+	// Perform a request that resumes from a particular state index.
+	request, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatal("unable to create request:", err)
+	}
+	request.Header.Set("Last-Event-ID", "5")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal("unable to perform request:", err)
+	}
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		t.Fatal("unable to read response:", err)
+	}
+
+	// Verify the response.
+	if contentType := response.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Error("unexpected content type:", contentType)
+	}
+	expected := "id: 6\nevent: state\ndata: {\"stateIndex\":\"6\",\"sessionStates\":[]}\n\n" +
+		"id: 7\nevent: state\ndata: {\"stateIndex\":\"7\",\"sessionStates\":[]}\n\n" +
+		"event: error\ndata: {\"error\":\"source failed\"}\n\n"
+	if string(body) != expected {
+		t.Errorf("unexpected event stream:\n%s", body)
+	}
+	if len(observed) != 3 || observed[0] != 5 || observed[1] != 6 || observed[2] != 7 {
+		t.Error("unexpected state indices observed:", observed)
+	}
+}
+
+// TestStreamEventsInvalidLastEventID tests that an invalid Last-Event-ID header
+// is rejected.
+func TestStreamEventsInvalidLastEventID(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/", nil)
+	request.Header.Set("Last-Event-ID", "invalid")
+	streamEvents(recorder, request, func(_ context.Context, _ uint64) (uint64, proto.Message, error) {
+		t.Error("event source invoked unexpectedly")
+		return 0, nil, errors.New("unexpected invocation")
+	})
+	if recorder.Code != http.StatusBadRequest {
+		t.Error("unexpected status code:", recorder.Code)
+	} else if !strings.Contains(recorder.Body.String(), "invalid last event identifier") {
+		t.Error("unexpected error response:", recorder.Body.String())
+	}
+}
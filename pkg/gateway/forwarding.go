@@ -0,0 +1,153 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/golang/protobuf/proto"
+
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+)
+
+// forwardingSessions handles forwarding session listing (GET) and creation
+// (POST) requests. Listing returns a ListResponse message. Creation accepts a
+// CreationSpecification message and returns a CreateResponse message containing
+// the new session's identifier.
+func (h *handler) forwardingSessions(writer http.ResponseWriter, request *http.Request) {
+	switch request.Method {
+	case http.MethodGet:
+		previousStateIndex, err := queryStateIndex(request)
+		if err != nil {
+			writeError(writer, http.StatusBadRequest, err)
+			return
+		}
+		selection := querySelection(request)
+		if err := ensureSelectionValid(selection); err != nil {
+			writeError(writer, http.StatusBadRequest, err)
+			return
+		}
+		stateIndex, states, err := h.client.Forwarding().List(request.Context(), selection, previousStateIndex)
+		if err != nil {
+			writeError(writer, errorStatus(err), err)
+			return
+		}
+		writeMessage(writer, http.StatusOK, &forwardingsvc.ListResponse{
+			StateIndex:    stateIndex,
+			SessionStates: states,
+		})
+	case http.MethodPost:
+		specification := &forwardingsvc.CreationSpecification{}
+		if !readMessage(writer, request, specification) {
+			return
+		}
+		if err := specification.EnsureValid(); err != nil {
+			writeError(writer, http.StatusBadRequest, errors.Wrap(err, "invalid creation specification"))
+			return
+		}
+		if err := h.ensureProxyCommandsAllowed(specification.Source, specification.Destination); err != nil {
+			writeError(writer, http.StatusBadRequest, err)
+			return
+		}
+		if err := h.ensureEndpointCommandsAllowed(specification.Source, specification.Destination); err != nil {
+			writeError(writer, http.StatusBadRequest, err)
+			return
+		}
+		session, err := h.client.Forwarding().Create(request.Context(), specification, nil)
+		if err != nil {
+			writeError(writer, errorStatus(err), err)
+			return
+		}
+		writeMessage(writer, http.StatusCreated, &forwardingsvc.CreateResponse{Session: session})
+	default:
+		writer.Header().Set("Allow", "GET, POST")
+		writeError(writer, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+	}
+}
+
+// forwardingPause handles forwarding session pause requests. It accepts a
+// PauseRequest message.
+func (h *handler) forwardingPause(writer http.ResponseWriter, request *http.Request) {
+	if !allowMethod(writer, request, http.MethodPost) {
+		return
+	}
+	pause := &forwardingsvc.PauseRequest{}
+	if !readMessage(writer, request, pause) {
+		return
+	}
+	if err := ensureSelectionValid(pause.Selection); err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.client.Forwarding().Pause(request.Context(), pause.Selection, nil); err != nil {
+		writeError(writer, errorStatus(err), err)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// forwardingResume handles forwarding session resume requests. It accepts a
+// ResumeRequest message.
+func (h *handler) forwardingResume(writer http.ResponseWriter, request *http.Request) {
+	if !allowMethod(writer, request, http.MethodPost) {
+		return
+	}
+	resume := &forwardingsvc.ResumeRequest{}
+	if !readMessage(writer, request, resume) {
+		return
+	}
+	if err := ensureSelectionValid(resume.Selection); err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.client.Forwarding().Resume(request.Context(), resume.Selection, nil); err != nil {
+		writeError(writer, errorStatus(err), err)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// forwardingTerminate handles forwarding session termination requests. It
+// accepts a TerminateRequest message.
+func (h *handler) forwardingTerminate(writer http.ResponseWriter, request *http.Request) {
+	if !allowMethod(writer, request, http.MethodPost) {
+		return
+	}
+	terminate := &forwardingsvc.TerminateRequest{}
+	if !readMessage(writer, request, terminate) {
+		return
+	}
+	if err := ensureSelectionValid(terminate.Selection); err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.client.Forwarding().Terminate(request.Context(), terminate.Selection, nil); err != nil {
+		writeError(writer, errorStatus(err), err)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// forwardingEvents handles forwarding session event stream requests. Each
+// event contains a ListResponse message for the selected sessions.
+func (h *handler) forwardingEvents(writer http.ResponseWriter, request *http.Request) {
+	if !allowMethod(writer, request, http.MethodGet) {
+		return
+	}
+	selection := querySelection(request)
+	if err := ensureSelectionValid(selection); err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	streamEvents(writer, request, func(ctx context.Context, previousStateIndex uint64) (uint64, proto.Message, error) {
+		stateIndex, states, err := h.client.Forwarding().List(ctx, selection, previousStateIndex)
+		if err != nil {
+			return 0, nil, err
+		}
+		return stateIndex, &forwardingsvc.ListResponse{
+			StateIndex:    stateIndex,
+			SessionStates: states,
+		}, nil
+	})
+}
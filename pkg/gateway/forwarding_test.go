@@ -0,0 +1,147 @@
+package gateway
+
+import (
+	"bufio"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// TestForwardingEndpoints tests the forwarding session endpoints, including the
+// event stream, using a paused local session.
+func TestForwardingEndpoints(t *testing.T) {
+	// Parse endpoint URLs. Since the session is never resumed, the addresses
+	// are never used.
+	source, err := url.Parse("tcp:localhost:50001", url.Kind_Forwarding, true)
+	if err != nil {
+		t.Fatal("unable to parse source URL:", err)
+	}
+	destination, err := url.Parse("tcp:localhost:50002", url.Kind_Forwarding, false)
+	if err != nil {
+		t.Fatal("unable to parse destination URL:", err)
+	}
+
+	// Create a paused session.
+	created := &forwardingsvc.CreateResponse{}
+	status, err := performRequest(http.MethodPost, "/api/v1/forwarding/sessions", &forwardingsvc.CreationSpecification{
+		Source:                   source,
+		Destination:              destination,
+		Configuration:            &forwarding.Configuration{},
+		ConfigurationSource:      &forwarding.Configuration{},
+		ConfigurationDestination: &forwarding.Configuration{},
+		Labels:                   map[string]string{"test": "gateway"},
+		Paused:                   true,
+	}, created)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	} else if status != http.StatusCreated {
+		t.Fatal("unexpected creation status code:", status)
+	}
+	selection := &selection.Selection{Specifications: []string{created.Session}}
+
+	// Ensure that the session is terminated in the event of test failure.
+	defer performRequest(http.MethodPost, "/api/v1/forwarding/sessions/terminate", &forwardingsvc.TerminateRequest{Selection: selection}, nil)
+
+	// Open an event stream for the session using query parameter
+	// authentication and verify that the initial event describes the session.
+	response, err := http.Get(gatewayServer.URL + "/api/v1/forwarding/events?labelSelector=test%3Dgateway&token=" + testToken)
+	if err != nil {
+		t.Fatal("unable to open event stream:", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatal("unexpected event stream status code:", response.StatusCode)
+	}
+	reader := bufio.NewReader(response.Body)
+	var data string
+	for data == "" {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal("unable to read event stream:", err)
+		} else if strings.HasPrefix(line, "data: ") {
+			data = line
+		}
+	}
+	if !strings.Contains(data, created.Session) {
+		t.Error("initial event doesn't contain session")
+	}
+
+	// List the session and verify that it's paused.
+	listed := &forwardingsvc.ListResponse{}
+	if status, err := performRequest(http.MethodGet, "/api/v1/forwarding/sessions?labelSelector=test%3Dgateway", nil, listed); err != nil {
+		t.Fatal("unable to list session:", err)
+	} else if status != http.StatusOK {
+		t.Fatal("unexpected list status code:", status)
+	} else if len(listed.SessionStates) != 1 {
+		t.Fatal("unexpected number of session states:", len(listed.SessionStates))
+	} else if !listed.SessionStates[0].Session.Paused {
+		t.Error("session not paused")
+	}
+
+	// Pause the (already paused) session.
+	if status, err := performRequest(http.MethodPost, "/api/v1/forwarding/sessions/pause", &forwardingsvc.PauseRequest{Selection: selection}, nil); err != nil {
+		t.Fatal("unable to pause session:", err)
+	} else if status != http.StatusNoContent {
+		t.Error("unexpected pause status code:", status)
+	}
+
+	// Terminate the session.
+	if status, err := performRequest(http.MethodPost, "/api/v1/forwarding/sessions/terminate", &forwardingsvc.TerminateRequest{Selection: selection}, nil); err != nil {
+		t.Fatal("unable to terminate session:", err)
+	} else if status != http.StatusNoContent {
+		t.Error("unexpected termination status code:", status)
+	}
+}
+
+// TestForwardingInvalidRequests tests that invalid forwarding requests are
+// rejected with a client error status code.
+func TestForwardingInvalidRequests(t *testing.T) {
+	// Attempt to create a session with an invalid specification.
+	if status, err := performRequest(http.MethodPost, "/api/v1/forwarding/sessions", &forwardingsvc.CreationSpecification{}, nil); err != nil {
+		t.Fatal("unable to perform creation request:", err)
+	} else if status != http.StatusBadRequest {
+		t.Error("unexpected status code for invalid specification:", status)
+	}
+
+	// Attempt to terminate a session that doesn't exist.
+	missing := &selection.Selection{Specifications: []string{"missing"}}
+	if status, err := performRequest(http.MethodPost, "/api/v1/forwarding/sessions/terminate", &forwardingsvc.TerminateRequest{Selection: missing}, nil); err != nil {
+		t.Fatal("unable to perform termination request:", err)
+	} else if status != http.StatusNotFound {
+		t.Error("unexpected status code for missing session:", status)
+	}
+}
+
+// TestForwardingCreateEndpointCommandNotAllowed tests that forwarding sessions
+// with endpoint commands that aren't allowed are rejected.
+func TestForwardingCreateEndpointCommandNotAllowed(t *testing.T) {
+	// Parse endpoint URLs, one of which specifies an endpoint command.
+	source, err := url.Parse("tcp:localhost:50003", url.Kind_Forwarding, true)
+	if err != nil {
+		t.Fatal("unable to parse source URL:", err)
+	}
+	destination, err := url.Parse("exec:nc localhost 22", url.Kind_Forwarding, false)
+	if err != nil {
+		t.Fatal("unable to parse destination URL:", err)
+	}
+
+	// Attempt to create a session and verify that it's rejected.
+	status, err := performRequest(http.MethodPost, "/api/v1/forwarding/sessions", &forwardingsvc.CreationSpecification{
+		Source:                   source,
+		Destination:              destination,
+		Configuration:            &forwarding.Configuration{},
+		ConfigurationSource:      &forwarding.Configuration{},
+		ConfigurationDestination: &forwarding.Configuration{},
+		Paused:                   true,
+	}, nil)
+	if err != nil {
+		t.Fatal("unable to perform creation request:", err)
+	} else if status != http.StatusBadRequest {
+		t.Error("unexpected creation status code:", status)
+	}
+}
@@ -0,0 +1,303 @@
+package gateway
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/mutagen-io/mutagen/pkg/client"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+const (
+	// apiPrefix is the path prefix for all API endpoints.
+	apiPrefix = "/api/v1"
+)
+
+// marshaler is the Protocol Buffers JSON marshaler used for response bodies.
+var marshaler = &jsonpb.Marshaler{EmitDefaults: true}
+
+// CommandAllowlist specifies the commands that session creation requests may
+// specify for execution on the local system. Commands must match exactly.
+type CommandAllowlist struct {
+	// ProxyCommands are the allowed SSH proxy commands.
+	ProxyCommands []string
+	// HookCommands are the allowed commands for synchronization hooks that
+	// execute on the local system.
+	HookCommands []string
+	// EndpointCommands are the allowed commands for forwarding endpoints that
+	// use the command protocols (exec: and stdio:).
+	EndpointCommands []string
+}
+
+// stringSet converts a list of strings to a set.
+func stringSet(values []string) map[string]bool {
+	result := make(map[string]bool, len(values))
+	for _, value := range values {
+		result[value] = true
+	}
+	return result
+}
+
+// handler is the HTTP handler for the API gateway.
+type handler struct {
+	// client is the daemon API client.
+	client *client.Client
+	// token is the authentication token that requests must provide.
+	token string
+	// allowedProxyCommands is the set of SSH proxy commands that session
+	// creation requests may specify.
+	allowedProxyCommands map[string]bool
+	// allowedHookCommands is the set of locally executed synchronization hook
+	// commands that session creation requests may specify.
+	allowedHookCommands map[string]bool
+	// allowedEndpointCommands is the set of forwarding endpoint commands that
+	// session creation requests may specify.
+	allowedEndpointCommands map[string]bool
+	// mux is the request multiplexer.
+	mux *http.ServeMux
+}
+
+// NewHandler creates a new API gateway handler that serves requests using the
+// specified daemon client. Requests must provide the specified token. Since
+// requests can't be answered interactively, operations that require prompting
+// (e.g. for SSH passwords) will fail, while informational messages from the
+// daemon are discarded. Since SSH proxy commands, locally executed
+// synchronization hooks, and forwarding endpoints using the command protocols
+// all run commands on the local system, session creation requests may only
+// specify such commands if they're included in the provided allowlist.
+func NewHandler(client *client.Client, token string, allowlist CommandAllowlist) http.Handler {
+	// Create the handler.
+	h := &handler{
+		client:                  client,
+		token:                   token,
+		allowedProxyCommands:    stringSet(allowlist.ProxyCommands),
+		allowedHookCommands:     stringSet(allowlist.HookCommands),
+		allowedEndpointCommands: stringSet(allowlist.EndpointCommands),
+		mux:                     http.NewServeMux(),
+	}
+
+	// Register daemon endpoints.
+	h.mux.HandleFunc(apiPrefix+"/daemon/version", h.daemonVersion)
+	h.mux.HandleFunc(apiPrefix+"/daemon/terminate", h.daemonTerminate)
+
+	// Register synchronization endpoints.
+	h.mux.HandleFunc(apiPrefix+"/synchronization/sessions", h.synchronizationSessions)
+	h.mux.HandleFunc(apiPrefix+"/synchronization/sessions/flush", h.synchronizationFlush)
+	h.mux.HandleFunc(apiPrefix+"/synchronization/sessions/pause", h.synchronizationPause)
+	h.mux.HandleFunc(apiPrefix+"/synchronization/sessions/resume", h.synchronizationResume)
+	h.mux.HandleFunc(apiPrefix+"/synchronization/sessions/terminate", h.synchronizationTerminate)
+	h.mux.HandleFunc(apiPrefix+"/synchronization/events", h.synchronizationEvents)
+
+	// Register forwarding endpoints.
+	h.mux.HandleFunc(apiPrefix+"/forwarding/sessions", h.forwardingSessions)
+	h.mux.HandleFunc(apiPrefix+"/forwarding/sessions/pause", h.forwardingPause)
+	h.mux.HandleFunc(apiPrefix+"/forwarding/sessions/resume", h.forwardingResume)
+	h.mux.HandleFunc(apiPrefix+"/forwarding/sessions/terminate", h.forwardingTerminate)
+	h.mux.HandleFunc(apiPrefix+"/forwarding/events", h.forwardingEvents)
+
+	// Done.
+	return h
+}
+
+// ServeHTTP implements http.Handler.ServeHTTP.
+func (h *handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	// Verify that the request is authorized.
+	if !authorized(request, h.token) {
+		writer.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(writer, http.StatusUnauthorized, errors.New("unauthorized"))
+		return
+	}
+
+	// Dispatch the request.
+	h.mux.ServeHTTP(writer, request)
+}
+
+// allowMethod verifies that a request uses the specified method, writing an
+// error response if it doesn't.
+func allowMethod(writer http.ResponseWriter, request *http.Request, method string) bool {
+	if request.Method != method {
+		writer.Header().Set("Allow", method)
+		writeError(writer, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return false
+	}
+	return true
+}
+
+// errorResponse is the JSON response body for errors.
+type errorResponse struct {
+	// Error is the error message.
+	Error string `json:"error"`
+}
+
+// writeError writes a JSON error response.
+func writeError(writer http.ResponseWriter, status int, err error) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	json.NewEncoder(writer).Encode(&errorResponse{err.Error()})
+}
+
+// writeMessage writes a JSON-encoded message response.
+func writeMessage(writer http.ResponseWriter, status int, message proto.Message) {
+	// Encode the message.
+	encoded, err := marshaler.MarshalToString(message)
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response"))
+		return
+	}
+
+	// Write the response. If this fails, then the client has most likely
+	// disconnected, so there's nothing we can do.
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	io.WriteString(writer, encoded+"\n")
+}
+
+// readMessage decodes a JSON-encoded message from a request body, writing an
+// error response if decoding fails.
+func readMessage(writer http.ResponseWriter, request *http.Request, message proto.Message) bool {
+	body := http.MaxBytesReader(writer, request.Body, grpcutil.MaximumMessageSize)
+	if err := jsonpb.Unmarshal(body, message); err != nil {
+		writeError(writer, http.StatusBadRequest, errors.Wrap(err, "unable to decode request"))
+		return false
+	}
+	return true
+}
+
+// ensureProxyCommandsAllowed verifies that any SSH proxy commands specified by
+// the provided URLs are allowed.
+func (h *handler) ensureProxyCommandsAllowed(urls ...*url.URL) error {
+	for _, u := range urls {
+		if command := u.ProxyCommand(); command != "" && !h.allowedProxyCommands[command] {
+			return errors.Errorf("SSH proxy command not allowed: %s", command)
+		}
+	}
+	return nil
+}
+
+// ensureHooksAllowed verifies that any synchronization hooks specified by the
+// provided configurations that will execute on the local system are allowed.
+// Hooks executed by an endpoint are treated as local if that endpoint's URL is
+// local. The alpha and beta URLs are those of the session being created.
+func (h *handler) ensureHooksAllowed(alpha, beta *url.URL, configurations ...*synchronization.Configuration) error {
+	for _, configuration := range configurations {
+		for _, hook := range configuration.GetHooks() {
+			var local bool
+			switch hook.Location {
+			case synchronization.HookLocation_HookLocationAlpha:
+				local = alpha.Protocol == url.Protocol_Local
+			case synchronization.HookLocation_HookLocationBeta:
+				local = beta.Protocol == url.Protocol_Local
+			default:
+				local = true
+			}
+			if local && !h.allowedHookCommands[hook.Command] {
+				return errors.Errorf("hook command not allowed: %s", hook.Command)
+			}
+		}
+	}
+	return nil
+}
+
+// ensureEndpointCommandsAllowed verifies that any forwarding endpoint commands
+// specified by the provided URLs are allowed.
+func (h *handler) ensureEndpointCommandsAllowed(urls ...*url.URL) error {
+	for _, u := range urls {
+		if command := u.EndpointCommand(); command != "" && !h.allowedEndpointCommands[command] {
+			return errors.Errorf("endpoint command not allowed: %s", command)
+		}
+	}
+	return nil
+}
+
+// ensureSelectionValid verifies that a session selection is valid, including
+// parsing any label selector, so that invalid selections can be rejected
+// before they reach the daemon.
+func ensureSelectionValid(s *selection.Selection) error {
+	if err := s.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid session selection")
+	} else if s.LabelSelector != "" {
+		if _, err := selection.ParseLabelSelector(s.LabelSelector); err != nil {
+			return errors.Wrap(err, "invalid label selector")
+		}
+	}
+	return nil
+}
+
+// errorStatus determines the HTTP status code to use when reporting an error
+// returned by the daemon client.
+func errorStatus(err error) int {
+	switch grpcutil.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// querySelection creates a session selection from a request's query
+// parameters. Sessions may be specified using one or more session parameters
+// or a labelSelector parameter. If neither is provided, then all sessions are
+// selected.
+func querySelection(request *http.Request) *selection.Selection {
+	query := request.URL.Query()
+	specifications := query["session"]
+	labelSelector := query.Get("labelSelector")
+	return &selection.Selection{
+		All:            len(specifications) == 0 && labelSelector == "",
+		Specifications: specifications,
+		LabelSelector:  labelSelector,
+	}
+}
+
+// queryStateIndex extracts the previousStateIndex query parameter from a
+// request, if present.
+func queryStateIndex(request *http.Request) (uint64, error) {
+	value := request.URL.Query().Get("previousStateIndex")
+	if value == "" {
+		return 0, nil
+	}
+	index, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid previous state index")
+	}
+	return index, nil
+}
+
+// daemonVersion handles daemon version requests.
+func (h *handler) daemonVersion(writer http.ResponseWriter, request *http.Request) {
+	if !allowMethod(writer, request, http.MethodGet) {
+		return
+	}
+	version, err := h.client.Version(request.Context())
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, errors.Wrap(err, "unable to query daemon version"))
+		return
+	}
+	writeMessage(writer, http.StatusOK, version)
+}
+
+// daemonTerminate handles daemon termination requests.
+func (h *handler) daemonTerminate(writer http.ResponseWriter, request *http.Request) {
+	if !allowMethod(writer, request, http.MethodPost) {
+		return
+	}
+	if err := h.client.TerminateDaemon(request.Context()); err != nil {
+		writeError(writer, http.StatusInternalServerError, errors.Wrap(err, "unable to terminate daemon"))
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,360 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/pkg/client"
+	"github.com/mutagen-io/mutagen/pkg/daemon"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/ipc"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/mutagen"
+	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/url"
+
+	// Explicitly import packages that need to register protocol handlers.
+	_ "github.com/mutagen-io/mutagen/pkg/forwarding/protocols/local"
+	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/local"
+)
+
+const (
+	// testToken is the authentication token used by the testing gateway.
+	testToken = "token"
+	// testAllowedProxyCommand is the SSH proxy command allowed by the testing
+	// gateway.
+	testAllowedProxyCommand = "allowed %h %p"
+	// testAllowedHookCommand is the locally executed hook command allowed by
+	// the testing gateway.
+	testAllowedHookCommand = "allowed hook"
+	// testAllowedEndpointCommand is the forwarding endpoint command allowed by
+	// the testing gateway.
+	testAllowedEndpointCommand = "allowed endpoint"
+)
+
+// testAllowlist is the command allowlist used by the testing gateway.
+var testAllowlist = CommandAllowlist{
+	ProxyCommands:    []string{testAllowedProxyCommand},
+	HookCommands:     []string{testAllowedHookCommand},
+	EndpointCommands: []string{testAllowedEndpointCommand},
+}
+
+// gatewayServer is the testing gateway server.
+var gatewayServer *httptest.Server
+
+// testMainInternal is the internal testing entry point, needed so that shutdown
+// operations can be deferred (since TestMain will invoke os.Exit). It points
+// Mutagen at a temporary data directory, sets up an in-process daemon and a
+// gateway server that proxies to it, runs tests, and finally tears down all of
+// the aforementioned infrastructure.
+func testMainInternal(m *testing.M) (int, error) {
+	// Disable logging.
+	log.SetOutput(ioutil.Discard)
+
+	// Create a temporary data directory, point Mutagen at it, and defer its
+	// removal.
+	directory, err := ioutil.TempDir("", "mutagen_gateway")
+	if err != nil {
+		return -1, errors.Wrap(err, "unable to create temporary data directory")
+	}
+	defer os.RemoveAll(directory)
+	if err := os.Setenv("MUTAGEN_DATA_DIRECTORY", directory); err != nil {
+		return -1, errors.Wrap(err, "unable to set data directory")
+	}
+
+	// Acquire the daemon lock and defer its release.
+	lock, err := daemon.AcquireLock()
+	if err != nil {
+		return -1, errors.Wrap(err, "unable to acquire daemon lock")
+	}
+	defer lock.Release()
+
+	// Create a forwarding session manager and defer its shutdown.
+	forwardingManager, err := forwarding.NewManager(logging.RootLogger.Sublogger("forwarding"))
+	if err != nil {
+		return -1, errors.Wrap(err, "unable to create forwarding session manager")
+	}
+	defer forwardingManager.Shutdown()
+
+	// Create a synchronization session manager and defer its shutdown.
+	synchronizationManager, err := synchronization.NewManager(logging.RootLogger.Sublogger("sync"))
+	if err != nil {
+		return -1, errors.Wrap(err, "unable to create synchronization session manager")
+	}
+	defer synchronizationManager.Shutdown()
+
+	// Create the gRPC server and defer its stoppage.
+	server := grpc.NewServer(
+		grpc.MaxSendMsgSize(grpcutil.MaximumMessageSize),
+		grpc.MaxRecvMsgSize(grpcutil.MaximumMessageSize),
+		grpc.UnaryInterceptor(grpcutil.UnaryServerInterceptor),
+		grpc.StreamInterceptor(grpcutil.StreamServerInterceptor),
+	)
+	defer server.Stop()
+
+	// Create and register services.
+	daemonServer := daemonsvc.NewServer()
+	daemonsvc.RegisterDaemonServer(server, daemonServer)
+	defer daemonServer.Shutdown()
+	forwardingsvc.RegisterForwardingServer(server, forwardingsvc.NewServer(forwardingManager))
+	synchronizationsvc.RegisterSynchronizationServer(server, synchronizationsvc.NewServer(synchronizationManager))
+
+	// Compute the path to the daemon IPC endpoint, create the daemon listener,
+	// and defer its closure.
+	endpoint, err := daemon.EndpointPath()
+	if err != nil {
+		return -1, errors.Wrap(err, "unable to compute endpoint path")
+	}
+	listener, err := ipc.NewListener(endpoint)
+	if err != nil {
+		return -1, errors.Wrap(err, "unable to create daemon listener")
+	}
+	defer listener.Close()
+
+	// Serve incoming connections in a separate Goroutine.
+	go server.Serve(listener)
+
+	// Connect to the daemon and defer closure of the client.
+	daemonClient, err := client.Connect(nil)
+	if err != nil {
+		return -1, errors.Wrap(err, "unable to connect to daemon")
+	}
+	defer daemonClient.Close()
+
+	// Create the gateway server and defer its closure.
+	gatewayServer = httptest.NewServer(NewHandler(daemonClient, testToken, testAllowlist))
+	defer gatewayServer.Close()
+
+	// Run tests.
+	return m.Run(), nil
+}
+
+// TestMain is the entry point for gateway tests (overriding the default
+// generated entry point).
+func TestMain(m *testing.M) {
+	// Invoke the internal entry point. If there's an error, print it out before
+	// exiting.
+	result, err := testMainInternal(m)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+
+	// Exit with the result.
+	os.Exit(result)
+}
+
+// performRequest performs an authenticated request against the testing gateway
+// server. If body is non-nil, it is sent as the JSON-encoded request body. If
+// response is non-nil and the request succeeds with a body, the body is
+// decoded into it. The response status code is returned.
+func performRequest(method, path string, body, response proto.Message) (int, error) {
+	// Encode the request body, if any.
+	var encoded []byte
+	if body != nil {
+		data, err := (&jsonpb.Marshaler{}).MarshalToString(body)
+		if err != nil {
+			return 0, errors.Wrap(err, "unable to encode request body")
+		}
+		encoded = []byte(data)
+	}
+
+	// Create and perform the request.
+	request, err := http.NewRequest(method, gatewayServer.URL+path, bytes.NewReader(encoded))
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to create request")
+	}
+	request.Header.Set("Authorization", "Bearer "+testToken)
+	result, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to perform request")
+	}
+	defer result.Body.Close()
+
+	// Decode the response body, if requested and successful.
+	if response != nil && result.StatusCode < 300 {
+		if err := jsonpb.Unmarshal(result.Body, response); err != nil {
+			return result.StatusCode, errors.Wrap(err, "unable to decode response body")
+		}
+	}
+
+	// Success.
+	return result.StatusCode, nil
+}
+
+// TestHandlerUnauthorized tests that unauthenticated requests are rejected.
+func TestHandlerUnauthorized(t *testing.T) {
+	for _, path := range []string{"/api/v1/daemon/version", "/api/v1/daemon/version?token=wrong"} {
+		response, err := http.Get(gatewayServer.URL + path)
+		if err != nil {
+			t.Fatal("unable to perform request:", err)
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusUnauthorized {
+			t.Error("unexpected status code for unauthenticated request:", response.StatusCode)
+		}
+	}
+}
+
+// TestHandlerDaemonVersion tests the daemon version endpoint.
+func TestHandlerDaemonVersion(t *testing.T) {
+	version := &daemonsvc.VersionResponse{}
+	if status, err := performRequest(http.MethodGet, "/api/v1/daemon/version", nil, version); err != nil {
+		t.Fatal("unable to query version:", err)
+	} else if status != http.StatusOK {
+		t.Fatal("unexpected status code:", status)
+	}
+	if version.Major != mutagen.VersionMajor ||
+		version.Minor != mutagen.VersionMinor ||
+		version.Patch != mutagen.VersionPatch ||
+		version.Tag != mutagen.VersionTag {
+		t.Error("daemon version mismatch")
+	}
+}
+
+// TestHandlerMethodNotAllowed tests that incorrect request methods are
+// rejected.
+func TestHandlerMethodNotAllowed(t *testing.T) {
+	if status, err := performRequest(http.MethodPost, "/api/v1/daemon/version", nil, nil); err != nil {
+		t.Fatal("unable to perform request:", err)
+	} else if status != http.StatusMethodNotAllowed {
+		t.Error("unexpected status code:", status)
+	}
+	if status, err := performRequest(http.MethodDelete, "/api/v1/synchronization/sessions", nil, nil); err != nil {
+		t.Fatal("unable to perform request:", err)
+	} else if status != http.StatusMethodNotAllowed {
+		t.Error("unexpected status code:", status)
+	}
+}
+
+// TestHandlerInvalidBody tests that malformed request bodies are rejected with
+// a JSON error response.
+func TestHandlerInvalidBody(t *testing.T) {
+	request, err := http.NewRequest(http.MethodPost, gatewayServer.URL+"/api/v1/synchronization/sessions/pause", bytes.NewReader([]byte("{")))
+	if err != nil {
+		t.Fatal("unable to create request:", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+testToken)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal("unable to perform request:", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusBadRequest {
+		t.Error("unexpected status code:", response.StatusCode)
+	}
+	var body errorResponse
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		t.Error("unable to decode error response:", err)
+	} else if body.Error == "" {
+		t.Error("empty error message")
+	}
+}
+
+func TestHandlerEnsureProxyCommandsAllowed(t *testing.T) {
+	// Create a handler.
+	h := NewHandler(nil, testToken, testAllowlist).(*handler)
+
+	// Verify that URLs without proxy commands and with allowed proxy commands
+	// are accepted.
+	allowed := &url.URL{
+		Protocol:   url.Protocol_SSH,
+		Host:       "host",
+		Parameters: map[string]string{url.SSHProxyCommandParameter: testAllowedProxyCommand},
+	}
+	if err := h.ensureProxyCommandsAllowed(&url.URL{Path: "/path"}, allowed); err != nil {
+		t.Error("allowed URLs rejected:", err)
+	}
+
+	// Verify that URLs with other proxy commands are rejected.
+	disallowed := &url.URL{
+		Protocol:   url.Protocol_SSH,
+		Host:       "host",
+		Parameters: map[string]string{url.SSHProxyCommandParameter: "nc %h %p"},
+	}
+	if err := h.ensureProxyCommandsAllowed(allowed, disallowed); err == nil {
+		t.Error("disallowed proxy command accepted")
+	}
+}
+
+func TestHandlerEnsureHooksAllowed(t *testing.T) {
+	// Create a handler.
+	h := NewHandler(nil, testToken, testAllowlist).(*handler)
+
+	// Create local and remote URLs.
+	local := &url.URL{Path: "/path"}
+	remote := &url.URL{Protocol: url.Protocol_SSH, Host: "host", Path: "/path"}
+
+	// Define test cases.
+	testCases := []struct {
+		alpha         *url.URL
+		beta          *url.URL
+		location      synchronization.HookLocation
+		command       string
+		expectFailure bool
+	}{
+		{local, local, synchronization.HookLocation_HookLocationDefault, testAllowedHookCommand, false},
+		{local, local, synchronization.HookLocation_HookLocationDefault, "touch file", true},
+		{remote, remote, synchronization.HookLocation_HookLocationLocal, "touch file", true},
+		{remote, remote, synchronization.HookLocation_HookLocationAlpha, "touch file", false},
+		{remote, local, synchronization.HookLocation_HookLocationBeta, "touch file", true},
+		{local, remote, synchronization.HookLocation_HookLocationAlpha, "touch file", true},
+		{local, remote, synchronization.HookLocation_HookLocationBeta, "touch file", false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		configuration := &synchronization.Configuration{
+			Hooks: []*synchronization.Hook{{
+				Trigger:  synchronization.HookTrigger_HookTriggerCycleCompleted,
+				Location: testCase.location,
+				Command:  testCase.command,
+			}},
+		}
+		err := h.ensureHooksAllowed(testCase.alpha, testCase.beta, nil, configuration)
+		if err != nil && !testCase.expectFailure {
+			t.Errorf("test index %d: hook incorrectly rejected: %v", i, err)
+		} else if err == nil && testCase.expectFailure {
+			t.Errorf("test index %d: hook incorrectly accepted", i)
+		}
+	}
+}
+
+func TestHandlerEnsureEndpointCommandsAllowed(t *testing.T) {
+	// Create a handler.
+	h := NewHandler(nil, testToken, testAllowlist).(*handler)
+
+	// Verify that URLs without endpoint commands and with allowed endpoint
+	// commands are accepted.
+	tcp := &url.URL{Kind: url.Kind_Forwarding, Path: "tcp:localhost:50000"}
+	allowed := &url.URL{Kind: url.Kind_Forwarding, Path: "exec:" + testAllowedEndpointCommand}
+	if err := h.ensureEndpointCommandsAllowed(tcp, allowed); err != nil {
+		t.Error("allowed URLs rejected:", err)
+	}
+
+	// Verify that URLs with other endpoint commands are rejected, regardless
+	// of the command protocol.
+	for _, path := range []string{"exec:nc localhost 22", "stdio:nc localhost 22"} {
+		disallowed := &url.URL{Kind: url.Kind_Forwarding, Path: path}
+		if err := h.ensureEndpointCommandsAllowed(tcp, disallowed); err == nil {
+			t.Error("disallowed endpoint command accepted:", path)
+		}
+	}
+}
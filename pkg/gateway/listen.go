@@ -0,0 +1,15 @@
+package gateway
+
+import (
+	"net"
+
+	"github.com/mutagen-io/mutagen/pkg/ipc"
+)
+
+// Listen creates a listener for the API gateway. The address must be of the
+// form "tcp:<host>:<port>" or "unix:<path>". For TCP addresses, the host must
+// be a loopback address (or "localhost") since the gateway is only intended
+// for use by local tools.
+func Listen(address string) (net.Listener, error) {
+	return ipc.ListenAddress(address)
+}
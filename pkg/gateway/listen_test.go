@@ -0,0 +1,25 @@
+package gateway
+
+import (
+	"testing"
+)
+
+// TestListen tests API gateway listener creation.
+func TestListen(t *testing.T) {
+	// Verify that invalid and non-loopback addresses are rejected.
+	for _, address := range []string{"", "localhost:0", "tcp:0.0.0.0:0", "tcp:example.com:0", "tcp:localhost"} {
+		if listener, err := Listen(address); err == nil {
+			listener.Close()
+			t.Error("listening succeeded unexpectedly for address:", address)
+		}
+	}
+
+	// Verify that loopback listening works.
+	for _, address := range []string{"tcp:127.0.0.1:0", "tcp:localhost:0"} {
+		if listener, err := Listen(address); err != nil {
+			t.Error("unable to listen on loopback address:", address, err)
+		} else {
+			listener.Close()
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/golang/protobuf/proto"
+
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+)
+
+// synchronizationSessions handles synchronization session listing (GET) and
+// creation (POST) requests. Listing returns a ListResponse message. Creation
+// accepts a CreationSpecification message and returns a CreateResponse message
+// containing the new session's identifier.
+func (h *handler) synchronizationSessions(writer http.ResponseWriter, request *http.Request) {
+	switch request.Method {
+	case http.MethodGet:
+		previousStateIndex, err := queryStateIndex(request)
+		if err != nil {
+			writeError(writer, http.StatusBadRequest, err)
+			return
+		}
+		selection := querySelection(request)
+		if err := ensureSelectionValid(selection); err != nil {
+			writeError(writer, http.StatusBadRequest, err)
+			return
+		}
+		stateIndex, states, err := h.client.Synchronization().List(request.Context(), selection, previousStateIndex)
+		if err != nil {
+			writeError(writer, errorStatus(err), err)
+			return
+		}
+		writeMessage(writer, http.StatusOK, &synchronizationsvc.ListResponse{
+			StateIndex:    stateIndex,
+			SessionStates: states,
+		})
+	case http.MethodPost:
+		specification := &synchronizationsvc.CreationSpecification{}
+		if !readMessage(writer, request, specification) {
+			return
+		}
+		if err := specification.EnsureValid(); err != nil {
+			writeError(writer, http.StatusBadRequest, errors.Wrap(err, "invalid creation specification"))
+			return
+		}
+		if err := h.ensureProxyCommandsAllowed(specification.Alpha, specification.Beta); err != nil {
+			writeError(writer, http.StatusBadRequest, err)
+			return
+		}
+		if err := h.ensureHooksAllowed(
+			specification.Alpha, specification.Beta,
+			specification.Configuration,
+			specification.ConfigurationAlpha,
+			specification.ConfigurationBeta,
+		); err != nil {
+			writeError(writer, http.StatusBadRequest, err)
+			return
+		}
+		session, err := h.client.Synchronization().Create(request.Context(), specification, nil)
+		if err != nil {
+			writeError(writer, errorStatus(err), err)
+			return
+		}
+		writeMessage(writer, http.StatusCreated, &synchronizationsvc.CreateResponse{Session: session})
+	default:
+		writer.Header().Set("Allow", "GET, POST")
+		writeError(writer, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+	}
+}
+
+// synchronizationFlush handles synchronization session flush requests. It
+// accepts a FlushRequest message.
+func (h *handler) synchronizationFlush(writer http.ResponseWriter, request *http.Request) {
+	if !allowMethod(writer, request, http.MethodPost) {
+		return
+	}
+	flush := &synchronizationsvc.FlushRequest{}
+	if !readMessage(writer, request, flush) {
+		return
+	}
+	if err := ensureSelectionValid(flush.Selection); err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.client.Synchronization().Flush(request.Context(), flush.Selection, flush.SkipWait, nil); err != nil {
+		writeError(writer, errorStatus(err), err)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// synchronizationPause handles synchronization session pause requests. It
+// accepts a PauseRequest message.
+func (h *handler) synchronizationPause(writer http.ResponseWriter, request *http.Request) {
+	if !allowMethod(writer, request, http.MethodPost) {
+		return
+	}
+	pause := &synchronizationsvc.PauseRequest{}
+	if !readMessage(writer, request, pause) {
+		return
+	}
+	if err := ensureSelectionValid(pause.Selection); err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.client.Synchronization().Pause(request.Context(), pause.Selection, nil); err != nil {
+		writeError(writer, errorStatus(err), err)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// synchronizationResume handles synchronization session resume requests. It
+// accepts a ResumeRequest message.
+func (h *handler) synchronizationResume(writer http.ResponseWriter, request *http.Request) {
+	if !allowMethod(writer, request, http.MethodPost) {
+		return
+	}
+	resume := &synchronizationsvc.ResumeRequest{}
+	if !readMessage(writer, request, resume) {
+		return
+	}
+	if err := ensureSelectionValid(resume.Selection); err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.client.Synchronization().Resume(request.Context(), resume.Selection, nil); err != nil {
+		writeError(writer, errorStatus(err), err)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// synchronizationTerminate handles synchronization session termination
+// requests. It accepts a TerminateRequest message.
+func (h *handler) synchronizationTerminate(writer http.ResponseWriter, request *http.Request) {
+	if !allowMethod(writer, request, http.MethodPost) {
+		return
+	}
+	terminate := &synchronizationsvc.TerminateRequest{}
+	if !readMessage(writer, request, terminate) {
+		return
+	}
+	if err := ensureSelectionValid(terminate.Selection); err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.client.Synchronization().Terminate(request.Context(), terminate.Selection, nil); err != nil {
+		writeError(writer, errorStatus(err), err)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// synchronizationEvents handles synchronization session event stream requests.
+// Each event contains a ListResponse message for the selected sessions.
+func (h *handler) synchronizationEvents(writer http.ResponseWriter, request *http.Request) {
+	if !allowMethod(writer, request, http.MethodGet) {
+		return
+	}
+	selection := querySelection(request)
+	if err := ensureSelectionValid(selection); err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	streamEvents(writer, request, func(ctx context.Context, previousStateIndex uint64) (uint64, proto.Message, error) {
+		stateIndex, states, err := h.client.Synchronization().List(ctx, selection, previousStateIndex)
+		if err != nil {
+			return 0, nil, err
+		}
+		return stateIndex, &synchronizationsvc.ListResponse{
+			StateIndex:    stateIndex,
+			SessionStates: states,
+		}, nil
+	})
+}
@@ -0,0 +1,203 @@
+package gateway
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// TestSynchronizationEndpoints tests the synchronization session endpoints
+// using a local session.
+func TestSynchronizationEndpoints(t *testing.T) {
+	// Create temporary directories to act as synchronization roots and defer
+	// their removal.
+	directory, err := ioutil.TempDir("", "mutagen_gateway_sync")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+	alpha, err := url.Parse(filepath.Join(directory, "alpha"), url.Kind_Synchronization, true)
+	if err != nil {
+		t.Fatal("unable to parse alpha URL:", err)
+	}
+	beta, err := url.Parse(filepath.Join(directory, "beta"), url.Kind_Synchronization, false)
+	if err != nil {
+		t.Fatal("unable to parse beta URL:", err)
+	}
+
+	// Create a session.
+	created := &synchronizationsvc.CreateResponse{}
+	status, err := performRequest(http.MethodPost, "/api/v1/synchronization/sessions", &synchronizationsvc.CreationSpecification{
+		Alpha:              alpha,
+		Beta:               beta,
+		Configuration:      &synchronization.Configuration{},
+		ConfigurationAlpha: &synchronization.Configuration{},
+		ConfigurationBeta:  &synchronization.Configuration{},
+		Name:               "gatewayTest",
+	}, created)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	} else if status != http.StatusCreated {
+		t.Fatal("unexpected creation status code:", status)
+	} else if created.Session == "" {
+		t.Fatal("empty session identifier returned")
+	}
+	selection := &selection.Selection{Specifications: []string{created.Session}}
+
+	// Ensure that the session is terminated in the event of test failure.
+	defer performRequest(http.MethodPost, "/api/v1/synchronization/sessions/terminate", &synchronizationsvc.TerminateRequest{Selection: selection}, nil)
+
+	// Flush the session.
+	if status, err := performRequest(http.MethodPost, "/api/v1/synchronization/sessions/flush", &synchronizationsvc.FlushRequest{Selection: selection}, nil); err != nil {
+		t.Fatal("unable to flush session:", err)
+	} else if status != http.StatusNoContent {
+		t.Error("unexpected flush status code:", status)
+	}
+
+	// Pause the session.
+	if status, err := performRequest(http.MethodPost, "/api/v1/synchronization/sessions/pause", &synchronizationsvc.PauseRequest{Selection: selection}, nil); err != nil {
+		t.Fatal("unable to pause session:", err)
+	} else if status != http.StatusNoContent {
+		t.Error("unexpected pause status code:", status)
+	}
+
+	// List the session by name and verify that it's paused.
+	listed := &synchronizationsvc.ListResponse{}
+	if status, err := performRequest(http.MethodGet, "/api/v1/synchronization/sessions?session=gatewayTest", nil, listed); err != nil {
+		t.Fatal("unable to list session:", err)
+	} else if status != http.StatusOK {
+		t.Fatal("unexpected list status code:", status)
+	} else if len(listed.SessionStates) != 1 {
+		t.Fatal("unexpected number of session states:", len(listed.SessionStates))
+	} else if listed.SessionStates[0].Session.Identifier != created.Session {
+		t.Error("session identifier mismatch")
+	} else if !listed.SessionStates[0].Session.Paused {
+		t.Error("session not paused")
+	}
+
+	// Resume the session.
+	if status, err := performRequest(http.MethodPost, "/api/v1/synchronization/sessions/resume", &synchronizationsvc.ResumeRequest{Selection: selection}, nil); err != nil {
+		t.Fatal("unable to resume session:", err)
+	} else if status != http.StatusNoContent {
+		t.Error("unexpected resume status code:", status)
+	}
+
+	// Terminate the session.
+	if status, err := performRequest(http.MethodPost, "/api/v1/synchronization/sessions/terminate", &synchronizationsvc.TerminateRequest{Selection: selection}, nil); err != nil {
+		t.Fatal("unable to terminate session:", err)
+	} else if status != http.StatusNoContent {
+		t.Error("unexpected termination status code:", status)
+	}
+
+	// Verify that the session can no longer be listed.
+	if status, err := performRequest(http.MethodGet, "/api/v1/synchronization/sessions?session="+created.Session, nil, nil); err != nil {
+		t.Fatal("unable to perform list request:", err)
+	} else if status != http.StatusNotFound {
+		t.Error("unexpected status code listing terminated session:", status)
+	}
+}
+
+// TestSynchronizationInvalidRequests tests that invalid synchronization
+// requests are rejected with a client error status code.
+func TestSynchronizationInvalidRequests(t *testing.T) {
+	// Attempt to create a session with an invalid specification.
+	if status, err := performRequest(http.MethodPost, "/api/v1/synchronization/sessions", &synchronizationsvc.CreationSpecification{}, nil); err != nil {
+		t.Fatal("unable to perform creation request:", err)
+	} else if status != http.StatusBadRequest {
+		t.Error("unexpected status code for invalid specification:", status)
+	}
+
+	// Attempt to list sessions with multiple selection mechanisms.
+	if status, err := performRequest(http.MethodGet, "/api/v1/synchronization/sessions?session=name&labelSelector=key", nil, nil); err != nil {
+		t.Fatal("unable to perform list request:", err)
+	} else if status != http.StatusBadRequest {
+		t.Error("unexpected status code for invalid selection:", status)
+	}
+
+	// Attempt to pause sessions using an invalid label selector.
+	invalidLabelSelector := &selection.Selection{LabelSelector: "!!!"}
+	if status, err := performRequest(http.MethodPost, "/api/v1/synchronization/sessions/pause", &synchronizationsvc.PauseRequest{Selection: invalidLabelSelector}, nil); err != nil {
+		t.Fatal("unable to perform pause request:", err)
+	} else if status != http.StatusBadRequest {
+		t.Error("unexpected status code for invalid label selector:", status)
+	}
+
+	// Attempt to pause a session that doesn't exist.
+	missing := &selection.Selection{Specifications: []string{"missing"}}
+	if status, err := performRequest(http.MethodPost, "/api/v1/synchronization/sessions/pause", &synchronizationsvc.PauseRequest{Selection: missing}, nil); err != nil {
+		t.Fatal("unable to perform pause request:", err)
+	} else if status != http.StatusNotFound {
+		t.Error("unexpected status code for missing session:", status)
+	}
+}
+
+// TestSynchronizationCreateProxyCommandNotAllowed tests that synchronization
+// session creation requests specifying SSH proxy commands that aren't allowed
+// are rejected.
+func TestSynchronizationCreateProxyCommandNotAllowed(t *testing.T) {
+	// Create URLs, one of which specifies a proxy command.
+	alpha, err := url.Parse("ssh://host/path?proxy=nc+%25h+%25p", url.Kind_Synchronization, true)
+	if err != nil {
+		t.Fatal("unable to parse alpha URL:", err)
+	}
+	beta, err := url.Parse(filepath.Join(os.TempDir(), "beta"), url.Kind_Synchronization, false)
+	if err != nil {
+		t.Fatal("unable to parse beta URL:", err)
+	}
+
+	// Attempt to create a session and verify that it's rejected.
+	status, err := performRequest(http.MethodPost, "/api/v1/synchronization/sessions", &synchronizationsvc.CreationSpecification{
+		Alpha:              alpha,
+		Beta:               beta,
+		Configuration:      &synchronization.Configuration{},
+		ConfigurationAlpha: &synchronization.Configuration{},
+		ConfigurationBeta:  &synchronization.Configuration{},
+	}, nil)
+	if err != nil {
+		t.Fatal("unable to perform creation request:", err)
+	} else if status != http.StatusBadRequest {
+		t.Error("unexpected creation status code:", status)
+	}
+}
+
+// TestSynchronizationCreateHookNotAllowed tests that synchronization sessions
+// with locally executed hooks that aren't allowed are rejected.
+func TestSynchronizationCreateHookNotAllowed(t *testing.T) {
+	// Create URLs.
+	alpha, err := url.Parse(filepath.Join(os.TempDir(), "alpha"), url.Kind_Synchronization, true)
+	if err != nil {
+		t.Fatal("unable to parse alpha URL:", err)
+	}
+	beta, err := url.Parse(filepath.Join(os.TempDir(), "beta"), url.Kind_Synchronization, false)
+	if err != nil {
+		t.Fatal("unable to parse beta URL:", err)
+	}
+
+	// Attempt to create a session with a locally executed hook and verify that
+	// it's rejected.
+	status, err := performRequest(http.MethodPost, "/api/v1/synchronization/sessions", &synchronizationsvc.CreationSpecification{
+		Alpha: alpha,
+		Beta:  beta,
+		Configuration: &synchronization.Configuration{
+			Hooks: []*synchronization.Hook{{
+				Trigger:  synchronization.HookTrigger_HookTriggerCycleCompleted,
+				Location: synchronization.HookLocation_HookLocationLocal,
+				Command:  "touch file",
+			}},
+		},
+		ConfigurationAlpha: &synchronization.Configuration{},
+		ConfigurationBeta:  &synchronization.Configuration{},
+	}, nil)
+	if err != nil {
+		t.Fatal("unable to perform creation request:", err)
+	} else if status != http.StatusBadRequest {
+		t.Error("unexpected creation status code:", status)
+	}
+}
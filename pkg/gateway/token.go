@@ -0,0 +1,47 @@
+package gateway
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// tokenLength is the number of random bytes used to generate tokens.
+	tokenLength = 32
+	// bearerPrefix is the authorization header prefix for bearer tokens.
+	bearerPrefix = "Bearer "
+	// tokenQueryParameter is the query parameter that may be used to provide
+	// the token for clients that can't set request headers (e.g. browser
+	// EventSource implementations).
+	tokenQueryParameter = "token"
+)
+
+// GenerateToken generates a new random authentication token.
+func GenerateToken() (string, error) {
+	token := make([]byte, tokenLength)
+	if _, err := rand.Read(token); err != nil {
+		return "", errors.Wrap(err, "unable to generate random token")
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// authorized determines whether or not a request provides the specified token,
+// either as a bearer token in the authorization header or via the token query
+// parameter.
+func authorized(request *http.Request, token string) bool {
+	// Extract the provided token.
+	var provided string
+	if header := request.Header.Get("Authorization"); strings.HasPrefix(header, bearerPrefix) {
+		provided = header[len(bearerPrefix):]
+	} else {
+		provided = request.URL.Query().Get(tokenQueryParameter)
+	}
+
+	// Perform a constant-time comparison. An empty token never matches.
+	return token != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
@@ -0,0 +1,50 @@
+package gateway
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGenerateToken tests that token generation yields distinct tokens of the
+// expected length.
+func TestGenerateToken(t *testing.T) {
+	first, err := GenerateToken()
+	if err != nil {
+		t.Fatal("unable to generate token:", err)
+	}
+	second, err := GenerateToken()
+	if err != nil {
+		t.Fatal("unable to generate token:", err)
+	}
+	if len(first) != 2*tokenLength {
+		t.Error("token has unexpected length:", len(first))
+	} else if first == second {
+		t.Error("generated tokens are identical")
+	}
+}
+
+// TestAuthorized tests request authorization.
+func TestAuthorized(t *testing.T) {
+	// Verify bearer token authorization.
+	request := httptest.NewRequest("GET", "/", nil)
+	request.Header.Set("Authorization", "Bearer secret")
+	if !authorized(request, "secret") {
+		t.Error("valid bearer token rejected")
+	} else if authorized(request, "other") {
+		t.Error("invalid bearer token accepted")
+	}
+
+	// Verify query parameter authorization.
+	request = httptest.NewRequest("GET", "/?token=secret", nil)
+	if !authorized(request, "secret") {
+		t.Error("valid query token rejected")
+	}
+
+	// Verify that requests without a token are rejected.
+	request = httptest.NewRequest("GET", "/", nil)
+	if authorized(request, "secret") {
+		t.Error("request without token accepted")
+	} else if authorized(request, "") {
+		t.Error("request accepted with empty expected token")
+	}
+}
@@ -1,21 +1,105 @@
 package grpcutil
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// rpcError is the error type returned by PeelAwayRPCErrorLayer. It reports only
+// the underlying error message, but retains the RPC status code so that it can
+// be recovered by Code.
+type rpcError struct {
+	// code is the RPC status code.
+	code codes.Code
+	// message is the underlying error message.
+	message string
+}
+
+// Error implements error.Error.
+func (e *rpcError) Error() string {
+	return e.message
+}
+
 // PeelAwayRPCErrorLayer peels away any intermediate RPC error layer from an
 // error returned by gRPC-based code and constructs an error object using the
-// underlying error message. If this unwrapping fails, the argument is returned
-// directly.
+// underlying error message. The RPC status code remains available via Code. If
+// this unwrapping fails, the argument is returned directly.
 func PeelAwayRPCErrorLayer(err error) error {
 	// Attempt to peel away the RPC layer.
 	if s, ok := status.FromError(err); ok {
-		return errors.New(s.Message())
+		return &rpcError{code: s.Code(), message: s.Message()}
 	}
 
 	// Otherwise return the argument directly.
 	return err
 }
+
+// Code returns the RPC status code associated with an error, looking through
+// any wrapping applied with github.com/pkg/errors. A nil error has an OK code,
+// while errors that don't carry a status code have an Unknown code.
+func Code(err error) codes.Code {
+	switch cause := errors.Cause(err).(type) {
+	case *rpcError:
+		return cause.code
+	case nil:
+		return codes.OK
+	default:
+		if s, ok := status.FromError(cause); ok {
+			return s.Code()
+		}
+		return codes.Unknown
+	}
+}
+
+// notFound is the interface implemented by errors indicating that a requested
+// resource doesn't exist.
+type notFound interface {
+	// NotFound indicates whether or not the error represents a missing
+	// resource.
+	NotFound() bool
+}
+
+// statusError converts an error returned by an RPC handler to an error with an
+// appropriate RPC status code. Errors whose cause implements NotFound (and
+// returns true) are reported with a NotFound code. Other errors are returned
+// unmodified, and will be reported with an Unknown code.
+func statusError(err error) error {
+	if n, ok := errors.Cause(err).(notFound); ok && n.NotFound() {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
+
+// UnaryServerInterceptor is a gRPC unary server interceptor that assigns RPC
+// status codes to handler errors.
+func UnaryServerInterceptor(
+	ctx context.Context,
+	request interface{},
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	response, err := handler(ctx, request)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return response, nil
+}
+
+// StreamServerInterceptor is a gRPC stream server interceptor that assigns RPC
+// status codes to handler errors.
+func StreamServerInterceptor(
+	server interface{},
+	stream grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := handler(server, stream); err != nil {
+		return statusError(err)
+	}
+	return nil
+}
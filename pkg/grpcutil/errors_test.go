@@ -0,0 +1,81 @@
+package grpcutil
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testNotFoundError is an error type indicating a missing resource.
+type testNotFoundError struct{}
+
+// Error implements error.Error.
+func (testNotFoundError) Error() string {
+	return "not found"
+}
+
+// NotFound implements notFound.NotFound.
+func (testNotFoundError) NotFound() bool {
+	return true
+}
+
+// TestPeelAwayRPCErrorLayer tests that peeling away the RPC error layer retains
+// the underlying message and status code.
+func TestPeelAwayRPCErrorLayer(t *testing.T) {
+	// Test an RPC error.
+	peeled := PeelAwayRPCErrorLayer(status.Error(codes.NotFound, "message"))
+	if peeled.Error() != "message" {
+		t.Error("peeled error message mismatch:", peeled.Error())
+	} else if code := Code(errors.Wrap(peeled, "context")); code != codes.NotFound {
+		t.Error("peeled error code mismatch:", code)
+	}
+
+	// Test a non-RPC error.
+	plain := errors.New("plain")
+	if PeelAwayRPCErrorLayer(plain) != plain {
+		t.Error("non-RPC error modified")
+	}
+}
+
+// TestCode tests error code extraction.
+func TestCode(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		err      error
+		expected codes.Code
+	}{
+		{nil, codes.OK},
+		{errors.New("plain"), codes.Unknown},
+		{status.Error(codes.InvalidArgument, "invalid"), codes.InvalidArgument},
+		{errors.Wrap(PeelAwayRPCErrorLayer(status.Error(codes.NotFound, "missing")), "context"), codes.NotFound},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if code := Code(testCase.err); code != testCase.expected {
+			t.Errorf("test index %d: code does not match expected: %v != %v", i, code, testCase.expected)
+		}
+	}
+}
+
+// TestStatusError tests conversion of handler errors to RPC status errors.
+func TestStatusError(t *testing.T) {
+	// Test an error whose cause indicates a missing resource.
+	converted := statusError(errors.Wrap(testNotFoundError{}, "context"))
+	if s, ok := status.FromError(converted); !ok {
+		t.Error("missing resource error not converted to status error")
+	} else if s.Code() != codes.NotFound {
+		t.Error("unexpected status code:", s.Code())
+	} else if s.Message() != "context: not found" {
+		t.Error("status message mismatch:", s.Message())
+	}
+
+	// Test other errors.
+	plain := errors.New("plain")
+	if statusError(plain) != plain {
+		t.Error("plain error modified")
+	}
+}
@@ -3,14 +3,10 @@ package housekeeping
 import (
 	"os"
 	"path/filepath"
-	"runtime"
 	"time"
 
-	"github.com/shibukawa/extstat"
-
 	"github.com/mutagen-io/mutagen/pkg/agent"
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
-	"github.com/mutagen-io/mutagen/pkg/process"
 )
 
 const (
@@ -37,38 +33,20 @@ func Housekeep() {
 
 // housekeepAgents performs housekeeping of agent binaries.
 func housekeepAgents() {
-	// Compute the path to the agents directory. If we fail, just abort. We
-	// don't attempt to create the directory, because if it doesn't exist, then
-	// we don't need to do anything and we'll just bail when we fail to list the
-	// agent directory below.
-	agentsDirectoryPath, err := filesystem.Mutagen(false, filesystem.MutagenAgentsDirectoryName)
-	if err != nil {
-		return
-	}
-
 	// Get the list of locally installed agent versions. If we fail, just abort.
-	agentDirectoryContents, err := filesystem.DirectoryContentsByPath(agentsDirectoryPath)
+	agents, err := agent.InstalledAgents()
 	if err != nil {
 		return
 	}
 
-	// Compute the name of the agent binary.
-	agentName := process.ExecutableName(agent.BaseName, runtime.GOOS)
-
 	// Grab the current time.
 	now := time.Now()
 
-	// Loop through each agent version, compute the time it was last launched,
-	// and remove it if longer than the maximum allowed period. Skip contents
-	// where failures are encountered.
-	for _, c := range agentDirectoryContents {
-		// TODO: Ensure that the name matches the expected format. Be mindful of
-		// the fact that it might contain a tag.
-		agentVersion := c.Name()
-		if stat, err := extstat.NewFromFileName(filepath.Join(agentsDirectoryPath, agentVersion, agentName)); err != nil {
-			continue
-		} else if now.Sub(stat.AccessTime) > maximumAgentIdlePeriod {
-			os.RemoveAll(filepath.Join(agentsDirectoryPath, agentVersion))
+	// Loop through each agent version and remove it if it was last launched
+	// longer ago than the maximum allowed period. Ignore failures.
+	for _, a := range agents {
+		if now.Sub(a.LastUsed) > maximumAgentIdlePeriod {
+			agent.UninstallVersion(a.Version)
 		}
 	}
 }
@@ -25,12 +25,18 @@ import (
 
 	// Explicitly import packages that need to register protocol handlers.
 	_ "github.com/mutagen-io/mutagen/pkg/forwarding/protocols/docker"
+	_ "github.com/mutagen-io/mutagen/pkg/forwarding/protocols/kubernetes"
 	_ "github.com/mutagen-io/mutagen/pkg/forwarding/protocols/local"
+	_ "github.com/mutagen-io/mutagen/pkg/forwarding/protocols/podman"
 	_ "github.com/mutagen-io/mutagen/pkg/forwarding/protocols/ssh"
+	_ "github.com/mutagen-io/mutagen/pkg/forwarding/protocols/tcp"
 	_ "github.com/mutagen-io/mutagen/pkg/integration/protocols/netpipe"
 	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/docker"
+	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/kubernetes"
 	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/local"
+	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/podman"
 	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/ssh"
+	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/tcp"
 )
 
 // forwardingManager is the forwarding session manager for the integration
@@ -83,6 +89,8 @@ func testMainInternal(m *testing.M) (int, error) {
 	server := grpc.NewServer(
 		grpc.MaxSendMsgSize(grpcutil.MaximumMessageSize),
 		grpc.MaxRecvMsgSize(grpcutil.MaximumMessageSize),
+		grpc.UnaryInterceptor(grpcutil.UnaryServerInterceptor),
+		grpc.StreamInterceptor(grpcutil.StreamServerInterceptor),
 	)
 	defer server.Stop()
 
@@ -59,6 +59,7 @@ func testSessionLifecycle(prompter string, alpha, beta *url.URL, configuration *
 		&synchronization.Configuration{},
 		"testSynchronizationSession",
 		nil,
+		nil,
 		false,
 		prompter,
 	)
@@ -0,0 +1,65 @@
+package ipc
+
+import (
+	"net"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// tcpAddressPrefix is the prefix for TCP listening addresses.
+	tcpAddressPrefix = "tcp:"
+	// unixAddressPrefix is the prefix for Unix domain socket listening
+	// addresses.
+	unixAddressPrefix = "unix:"
+)
+
+// ListenAddress creates a listener for an auxiliary daemon endpoint (e.g. the
+// metrics or API endpoint). The address must be of the form "tcp:<host>:<port>"
+// or "unix:<path>". Since auxiliary endpoints are only intended for use by
+// local tools, TCP hosts must be loopback addresses (or "localhost"). Any
+// existing Unix domain socket at the specified path is removed, so the caller
+// should ensure that it's not in use (e.g. by holding the daemon lock), but
+// other types of files are left in place and cause listening to fail.
+func ListenAddress(address string) (net.Listener, error) {
+	if strings.HasPrefix(address, tcpAddressPrefix) {
+		// Ensure that the host is a loopback address.
+		address = address[len(tcpAddressPrefix):]
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid TCP address")
+		}
+		if host != "localhost" {
+			if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+				return nil, errors.Errorf("non-loopback host: %s", host)
+			}
+		}
+
+		// Create the listener.
+		return net.Listen("tcp", address)
+	} else if strings.HasPrefix(address, unixAddressPrefix) {
+		// Validate the path.
+		path := address[len(unixAddressPrefix):]
+		if path == "" {
+			return nil, errors.New("empty socket path")
+		}
+
+		// Remove any existing socket at the path, but refuse to touch any
+		// other type of file.
+		if metadata, err := os.Lstat(path); err == nil {
+			if metadata.Mode()&os.ModeSocket == 0 {
+				return nil, errors.New("existing non-socket file at socket path")
+			} else if err := os.Remove(path); err != nil {
+				return nil, errors.Wrap(err, "unable to remove existing socket")
+			}
+		} else if !os.IsNotExist(err) {
+			return nil, errors.Wrap(err, "unable to query socket path")
+		}
+
+		// Create the listener.
+		return net.Listen("unix", path)
+	}
+	return nil, errors.Errorf("invalid listening address: %s", address)
+}
@@ -0,0 +1,65 @@
+package ipc
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestListenAddress tests auxiliary endpoint listener creation.
+func TestListenAddress(t *testing.T) {
+	// Verify that invalid addresses are rejected.
+	for _, address := range []string{"", "localhost:9102", "udp:localhost:9102", "unix:", "tcp:0.0.0.0:0", "tcp:localhost"} {
+		if listener, err := ListenAddress(address); err == nil {
+			listener.Close()
+			t.Error("listening succeeded unexpectedly for address:", address)
+		}
+	}
+
+	// Verify that TCP listening works.
+	if listener, err := ListenAddress("tcp:127.0.0.1:0"); err != nil {
+		t.Error("unable to listen on TCP address:", err)
+	} else {
+		listener.Close()
+	}
+
+	// Create a temporary directory for a Unix domain socket and defer its
+	// removal.
+	directory, err := ioutil.TempDir("", "mutagen_ipc_test")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Verify that Unix domain socket listening works, including when a stale
+	// socket exists at the path.
+	socket := filepath.Join(directory, "test.sock")
+	if listener, err := ListenAddress("unix:" + socket); err != nil {
+		t.Error("unable to listen on Unix domain socket:", err)
+	} else if unixListener, ok := listener.(*net.UnixListener); !ok {
+		listener.Close()
+		t.Error("unexpected listener type")
+	} else {
+		unixListener.SetUnlinkOnClose(false)
+		unixListener.Close()
+		if listener, err := ListenAddress("unix:" + socket); err != nil {
+			t.Error("unable to listen on Unix domain socket with stale socket:", err)
+		} else {
+			listener.Close()
+		}
+	}
+
+	// Verify that existing non-socket files are left in place.
+	file := filepath.Join(directory, "file")
+	if err := ioutil.WriteFile(file, []byte("data"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+	if listener, err := ListenAddress("unix:" + file); err == nil {
+		listener.Close()
+		t.Error("listening succeeded unexpectedly at non-socket path")
+	} else if _, err := os.Stat(file); err != nil {
+		t.Error("non-socket file removed:", err)
+	}
+}
@@ -0,0 +1,57 @@
+package logging
+
+// Level represents a log level. Levels are ordered by increasing verbosity.
+type Level uint8
+
+const (
+	// LevelError indicates that only errors should be logged.
+	LevelError Level = iota
+	// LevelWarn indicates that errors and warnings should be logged.
+	LevelWarn
+	// LevelInfo indicates that errors, warnings, and informational messages
+	// should be logged.
+	LevelInfo
+	// LevelDebug indicates that debugging messages should also be logged.
+	LevelDebug
+	// LevelTrace indicates that all messages, including low-level tracing
+	// messages, should be logged.
+	LevelTrace
+)
+
+// NameToLevel converts a string-based representation of a log level to the
+// appropriate Level value. It returns a boolean indicating whether or not the
+// conversion was valid.
+func NameToLevel(name string) (Level, bool) {
+	switch name {
+	case "error":
+		return LevelError, true
+	case "warn":
+		return LevelWarn, true
+	case "info":
+		return LevelInfo, true
+	case "debug":
+		return LevelDebug, true
+	case "trace":
+		return LevelTrace, true
+	default:
+		return LevelError, false
+	}
+}
+
+// String provides a human-readable representation of a log level.
+func (l Level) String() string {
+	switch l {
+	case LevelError:
+		return "error"
+	case LevelWarn:
+		return "warn"
+	case LevelInfo:
+		return "info"
+	case LevelDebug:
+		return "debug"
+	case LevelTrace:
+		return "trace"
+	default:
+		return "unknown"
+	}
+}
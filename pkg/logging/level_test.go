@@ -0,0 +1,33 @@
+package logging
+
+import (
+	"testing"
+)
+
+// TestLevelNameRoundTrip tests that level names round-trip through NameToLevel
+// and String.
+func TestLevelNameRoundTrip(t *testing.T) {
+	for _, level := range []Level{LevelError, LevelWarn, LevelInfo, LevelDebug, LevelTrace} {
+		if parsed, ok := NameToLevel(level.String()); !ok {
+			t.Error("unable to parse level name:", level.String())
+		} else if parsed != level {
+			t.Error("level name round-trip mismatch:", parsed, "!=", level)
+		}
+	}
+}
+
+// TestNameToLevelInvalid tests that invalid level names are rejected.
+func TestNameToLevelInvalid(t *testing.T) {
+	for _, name := range []string{"", "warning", "INFO", "verbose"} {
+		if _, ok := NameToLevel(name); ok {
+			t.Error("invalid level name accepted:", name)
+		}
+	}
+}
+
+// TestLevelOrdering tests that levels are ordered by increasing verbosity.
+func TestLevelOrdering(t *testing.T) {
+	if !(LevelError < LevelWarn && LevelWarn < LevelInfo && LevelInfo < LevelDebug && LevelDebug < LevelTrace) {
+		t.Error("levels not ordered by increasing verbosity")
+	}
+}
@@ -5,11 +5,8 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
-
-	"github.com/fatih/color"
-
-	"github.com/mutagen-io/mutagen/pkg/mutagen"
+	"strings"
+	"time"
 )
 
 // writer is an io.Writer that splits its input stream into lines and writes
@@ -76,9 +73,11 @@ func (w *writer) Write(buffer []byte) (int, error) {
 }
 
 // Logger is the main logger type. It has the novel property that it still
-// functions if nil, but it doesn't log anything. It is designed to use the
-// standard logger provided by the log package, so it respects any flags set for
-// that logger. It is safe for concurrent usage.
+// functions if nil, but it doesn't log anything. Each logging method is
+// associated with a log level, and records more verbose than the global log
+// level (see SetLevel) are discarded. Text-formatted records are written using
+// the standard logger provided by the log package, so they respect any flags
+// set for that logger. It is safe for concurrent usage.
 type Logger struct {
 	// prefix is any prefix specified for the logger.
 	prefix string
@@ -106,35 +105,35 @@ func (l *Logger) Sublogger(name string) *Logger {
 	}
 }
 
-// output is the internal logging method.
-func (l *Logger) output(calldepth int, line string) {
-	// Add a prefix if necessary.
-	if l.prefix != "" {
-		line = fmt.Sprintf("[%s] %s", l.prefix, line)
-	}
-
-	// Log.
-	log.Output(calldepth, line)
+// output is the internal logging method. Callers are responsible for verifying
+// that the specified level is enabled.
+func (l *Logger) output(level Level, calldepth int, line string) {
+	emit(calldepth, &Record{
+		Time:    time.Now().Truncate(time.Microsecond),
+		Level:   level,
+		Logger:  l.prefix,
+		Message: strings.TrimSuffix(line, "\n"),
+	})
 }
 
 // Print logs information with semantics equivalent to fmt.Print.
 func (l *Logger) Print(v ...interface{}) {
-	if l != nil {
-		l.output(3, fmt.Sprint(v...))
+	if l != nil && enabled(LevelInfo) {
+		l.output(LevelInfo, 3, fmt.Sprint(v...))
 	}
 }
 
 // Printf logs information with semantics equivalent to fmt.Printf.
 func (l *Logger) Printf(format string, v ...interface{}) {
-	if l != nil {
-		l.output(3, fmt.Sprintf(format, v...))
+	if l != nil && enabled(LevelInfo) {
+		l.output(LevelInfo, 3, fmt.Sprintf(format, v...))
 	}
 }
 
 // Println logs information with semantics equivalent to fmt.Println.
 func (l *Logger) Println(v ...interface{}) {
-	if l != nil {
-		l.output(3, fmt.Sprintln(v...))
+	if l != nil && enabled(LevelInfo) {
+		l.output(LevelInfo, 3, fmt.Sprintln(v...))
 	}
 }
 
@@ -155,26 +154,26 @@ func (l *Logger) Writer() io.Writer {
 }
 
 // Debug logs information with semantics equivalent to fmt.Print, but only if
-// debugging is enabled (otherwise it's a no-op).
+// debug logging is enabled (otherwise it's a no-op).
 func (l *Logger) Debug(v ...interface{}) {
-	if l != nil && mutagen.DebugEnabled {
-		l.output(3, fmt.Sprint(v...))
+	if l != nil && enabled(LevelDebug) {
+		l.output(LevelDebug, 3, fmt.Sprint(v...))
 	}
 }
 
 // Debugf logs information with semantics equivalent to fmt.Printf, but only if
-// debugging is enabled (otherwise it's a no-op).
+// debug logging is enabled (otherwise it's a no-op).
 func (l *Logger) Debugf(format string, v ...interface{}) {
-	if l != nil && mutagen.DebugEnabled {
-		l.output(3, fmt.Sprintf(format, v...))
+	if l != nil && enabled(LevelDebug) {
+		l.output(LevelDebug, 3, fmt.Sprintf(format, v...))
 	}
 }
 
 // Debugln logs information with semantics equivalent to fmt.Println, but only
-// if debugging is enabled (otherwise it's a no-op).
+// if debug logging is enabled (otherwise it's a no-op).
 func (l *Logger) Debugln(v ...interface{}) {
-	if l != nil && mutagen.DebugEnabled {
-		l.output(3, fmt.Sprintln(v...))
+	if l != nil && enabled(LevelDebug) {
+		l.output(LevelDebug, 3, fmt.Sprintln(v...))
 	}
 }
 
@@ -194,16 +193,42 @@ func (l *Logger) DebugWriter() io.Writer {
 	}
 }
 
-// Warn logs error information with a warning prefix and yellow color.
+// Trace logs information with semantics equivalent to fmt.Print, but only if
+// trace logging is enabled (otherwise it's a no-op).
+func (l *Logger) Trace(v ...interface{}) {
+	if l != nil && enabled(LevelTrace) {
+		l.output(LevelTrace, 3, fmt.Sprint(v...))
+	}
+}
+
+// Tracef logs information with semantics equivalent to fmt.Printf, but only if
+// trace logging is enabled (otherwise it's a no-op).
+func (l *Logger) Tracef(format string, v ...interface{}) {
+	if l != nil && enabled(LevelTrace) {
+		l.output(LevelTrace, 3, fmt.Sprintf(format, v...))
+	}
+}
+
+// Traceln logs information with semantics equivalent to fmt.Println, but only
+// if trace logging is enabled (otherwise it's a no-op).
+func (l *Logger) Traceln(v ...interface{}) {
+	if l != nil && enabled(LevelTrace) {
+		l.output(LevelTrace, 3, fmt.Sprintln(v...))
+	}
+}
+
+// Warn logs error information at the warning level. Text-formatted records
+// written to standard error have a warning prefix and yellow color.
 func (l *Logger) Warn(err error) {
-	if l != nil {
-		l.output(3, color.YellowString("Warning: %v", err))
+	if l != nil && enabled(LevelWarn) {
+		l.output(LevelWarn, 3, fmt.Sprint(err))
 	}
 }
 
-// Error logs error information with an error prefix and red color.
+// Error logs error information at the error level. Text-formatted records
+// written to standard error have an error prefix and red color.
 func (l *Logger) Error(err error) {
-	if l != nil {
-		l.output(3, color.RedString("Error: %v", err))
+	if l != nil && enabled(LevelError) {
+		l.output(LevelError, 3, fmt.Sprint(err))
 	}
 }
@@ -1,3 +1,111 @@
 package logging
 
-// TODO: Implement.
+import (
+	"errors"
+	"testing"
+)
+
+// TestNilLogger tests that a nil logger can be used without panicking.
+func TestNilLogger(t *testing.T) {
+	var logger *Logger
+	logger.Print("test")
+	logger.Debugf("%s", "test")
+	logger.Traceln("test")
+	logger.Warn(errors.New("test"))
+	logger.Error(errors.New("test"))
+	if logger.Sublogger("child") != nil {
+		t.Error("sublogger of nil logger is non-nil")
+	}
+	if logger.Writer() == nil {
+		t.Error("writer for nil logger is nil")
+	}
+}
+
+// TestSubloggerPrefix tests that sublogger names are joined to form prefixes.
+func TestSubloggerPrefix(t *testing.T) {
+	logger := RootLogger.Sublogger("sync").Sublogger("identifier").Sublogger("alpha")
+	if logger.prefix != "sync.identifier.alpha" {
+		t.Error("unexpected sublogger prefix:", logger.prefix)
+	}
+}
+
+// TestLoggerLevels tests that records are emitted with the appropriate levels
+// and that records more verbose than the current level are discarded.
+func TestLoggerLevels(t *testing.T) {
+	// Set the log level and defer its restoration.
+	defer SetLevel(CurrentLevel())
+	SetLevel(LevelDebug)
+
+	// Subscribe to records and defer cancellation.
+	records, cancel := Subscribe(10)
+	defer cancel()
+
+	// Log at a variety of levels.
+	logger := RootLogger.Sublogger("test")
+	logger.Println("info")
+	logger.Debug("debug")
+	logger.Trace("trace")
+	logger.Warn(errors.New("warning"))
+	logger.Error(errors.New("error"))
+
+	// Verify the received records.
+	expected := []struct {
+		level   Level
+		message string
+	}{
+		{LevelInfo, "info"},
+		{LevelDebug, "debug"},
+		{LevelWarn, "warning"},
+		{LevelError, "error"},
+	}
+	for _, e := range expected {
+		select {
+		case record := <-records:
+			if record.Level != e.level {
+				t.Error("record level mismatch:", record.Level, "!=", e.level)
+			}
+			if record.Message != e.message {
+				t.Error("record message mismatch:", record.Message, "!=", e.message)
+			}
+			if record.Logger != "test" {
+				t.Error("record logger mismatch:", record.Logger)
+			}
+		default:
+			t.Fatal("expected record not received")
+		}
+	}
+	select {
+	case record := <-records:
+		t.Error("unexpected record received:", record.Message)
+	default:
+	}
+}
+
+// TestWriter tests that the logger's writer splits input into records.
+func TestWriter(t *testing.T) {
+	// Subscribe to records and defer cancellation.
+	records, cancel := Subscribe(10)
+	defer cancel()
+
+	// Write content, including a partial line that shouldn't be logged.
+	writer := RootLogger.Sublogger("test").Writer()
+	writer.Write([]byte("first\r\nsec"))
+	writer.Write([]byte("ond\nthird"))
+
+	// Verify the received records.
+	for _, expected := range []string{"first", "second"} {
+		select {
+		case record := <-records:
+			if record.Message != expected {
+				t.Error("record message mismatch:", record.Message, "!=", expected)
+			}
+		default:
+			t.Fatal("expected record not received")
+		}
+	}
+	select {
+	case record := <-records:
+		t.Error("unexpected record received:", record.Message)
+	default:
+	}
+}
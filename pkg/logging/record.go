@@ -0,0 +1,197 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// timeFormat is the time format used for text-formatted log records.
+	timeFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// Format represents a log record encoding format.
+type Format uint8
+
+const (
+	// FormatText indicates that log records should be encoded as
+	// human-readable lines.
+	FormatText Format = iota
+	// FormatJSON indicates that log records should be encoded as JSON objects,
+	// one per line.
+	FormatJSON
+)
+
+// NameToFormat converts a string-based representation of a log format to the
+// appropriate Format value. It returns a boolean indicating whether or not the
+// conversion was valid.
+func NameToFormat(name string) (Format, bool) {
+	switch name {
+	case "text":
+		return FormatText, true
+	case "json":
+		return FormatJSON, true
+	default:
+		return FormatText, false
+	}
+}
+
+// String provides a human-readable representation of a log format.
+func (f Format) String() string {
+	switch f {
+	case FormatText:
+		return "text"
+	case FormatJSON:
+		return "json"
+	default:
+		return "unknown"
+	}
+}
+
+// Record represents a single log record.
+type Record struct {
+	// Time is the time at which the record was logged. It is truncated to
+	// microsecond precision so that it survives encoding.
+	Time time.Time
+	// Level is the record's log level.
+	Level Level
+	// Logger is the name of the logger that generated the record.
+	Logger string
+	// Message is the record's message.
+	Message string
+	// Sequence is the record's sequence number. Sequence numbers are assigned
+	// in increasing order as records are logged by the current process. They
+	// aren't encoded, so they're zero for records read from log files.
+	Sequence uint64
+}
+
+// MatchesSession determines whether or not a record was generated by a logger
+// associated with the specified session identifier. Session loggers include
+// the session identifier as a component of their name (e.g. sync.<id>.alpha).
+func (r *Record) MatchesSession(identifier string) bool {
+	for _, component := range strings.Split(r.Logger, ".") {
+		if component == identifier {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonRecord is the JSON representation of a log record.
+type jsonRecord struct {
+	// Time is the time at which the record was logged.
+	Time time.Time `json:"time"`
+	// Level is the name of the record's log level.
+	Level string `json:"level"`
+	// Logger is the name of the logger that generated the record.
+	Logger string `json:"logger,omitempty"`
+	// Message is the record's message.
+	Message string `json:"message"`
+}
+
+// encode encodes a record in the specified format, including a trailing
+// newline.
+func (r *Record) encode(format Format) ([]byte, error) {
+	// Handle JSON encoding.
+	if format == FormatJSON {
+		encoded, err := json.Marshal(&jsonRecord{
+			Time:    r.Time,
+			Level:   r.Level.String(),
+			Logger:  r.Logger,
+			Message: r.Message,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return append(encoded, '\n'), nil
+	}
+
+	// Handle text encoding.
+	if r.Logger != "" {
+		return []byte(fmt.Sprintf("%s %s [%s] %s\n",
+			r.Time.Format(timeFormat), strings.ToUpper(r.Level.String()), r.Logger, r.Message,
+		)), nil
+	}
+	return []byte(fmt.Sprintf("%s %s %s\n",
+		r.Time.Format(timeFormat), strings.ToUpper(r.Level.String()), r.Message,
+	)), nil
+}
+
+// parseRecord parses a single encoded log record line. Both text and JSON
+// encodings are supported.
+func parseRecord(line string) (*Record, error) {
+	// Handle JSON-encoded records.
+	if strings.HasPrefix(line, "{") {
+		encoded := &jsonRecord{}
+		if err := json.Unmarshal([]byte(line), encoded); err != nil {
+			return nil, errors.Wrap(err, "unable to decode JSON record")
+		}
+		level, ok := NameToLevel(encoded.Level)
+		if !ok {
+			return nil, errors.Errorf("invalid log level: %s", encoded.Level)
+		}
+		return &Record{
+			Time:    encoded.Time,
+			Level:   level,
+			Logger:  encoded.Logger,
+			Message: encoded.Message,
+		}, nil
+	}
+
+	// Parse the timestamp.
+	fields := strings.SplitN(line, " ", 3)
+	if len(fields) < 2 {
+		return nil, errors.New("truncated record")
+	}
+	timestamp, err := time.Parse(timeFormat, fields[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse record time")
+	}
+
+	// Parse the level.
+	level, ok := NameToLevel(strings.ToLower(fields[1]))
+	if !ok {
+		return nil, errors.Errorf("invalid log level: %s", fields[1])
+	}
+
+	// Parse the logger name and message.
+	var logger, message string
+	if len(fields) == 3 {
+		message = fields[2]
+		if strings.HasPrefix(message, "[") {
+			if end := strings.Index(message, "] "); end != -1 {
+				logger = message[1:end]
+				message = message[end+2:]
+			}
+		}
+	}
+
+	// Success.
+	return &Record{
+		Time:    timestamp,
+		Level:   level,
+		Logger:  logger,
+		Message: message,
+	}, nil
+}
+
+// parseRecords parses a sequence of encoded log record lines. Lines that can't
+// be parsed as records are treated as continuations of the preceding record's
+// message (which occurs for text-encoded multi-line messages). Lines that
+// precede any valid record are discarded.
+func parseRecords(lines []string) []*Record {
+	var records []*Record
+	for _, line := range lines {
+		if record, err := parseRecord(line); err == nil {
+			records = append(records, record)
+		} else if len(records) > 0 {
+			previous := records[len(records)-1]
+			previous.Message = previous.Message + "\n" + line
+		}
+	}
+	return records
+}
@@ -0,0 +1,97 @@
+package logging
+
+import (
+	"testing"
+	"time"
+)
+
+// TestNameToFormat tests format name conversion.
+func TestNameToFormat(t *testing.T) {
+	for _, format := range []Format{FormatText, FormatJSON} {
+		if parsed, ok := NameToFormat(format.String()); !ok || parsed != format {
+			t.Error("format name round-trip failed for", format)
+		}
+	}
+	if _, ok := NameToFormat("xml"); ok {
+		t.Error("invalid format name accepted")
+	}
+}
+
+// TestRecordEncodeParse tests that records round-trip through encoding and
+// parsing in both formats.
+func TestRecordEncodeParse(t *testing.T) {
+	records := []*Record{
+		{
+			Time:    time.Date(2019, 7, 1, 12, 30, 45, 123456000, time.UTC),
+			Level:   LevelWarn,
+			Logger:  "sync.identifier.alpha",
+			Message: "something [odd] happened",
+		},
+		{
+			Time:    time.Date(2019, 7, 1, 12, 30, 45, 0, time.UTC),
+			Level:   LevelTrace,
+			Message: "root message",
+		},
+	}
+	for _, format := range []Format{FormatText, FormatJSON} {
+		for _, record := range records {
+			encoded, err := record.encode(format)
+			if err != nil {
+				t.Fatal("unable to encode record:", err)
+			}
+			if encoded[len(encoded)-1] != '\n' {
+				t.Error("encoded record missing trailing newline")
+			}
+			parsed, err := parseRecord(string(encoded[:len(encoded)-1]))
+			if err != nil {
+				t.Fatal("unable to parse record:", err)
+			}
+			if !parsed.Time.Equal(record.Time) {
+				t.Error("record time mismatch:", parsed.Time, "!=", record.Time)
+			}
+			if parsed.Level != record.Level {
+				t.Error("record level mismatch:", parsed.Level, "!=", record.Level)
+			}
+			if parsed.Logger != record.Logger {
+				t.Error("record logger mismatch:", parsed.Logger, "!=", record.Logger)
+			}
+			if parsed.Message != record.Message {
+				t.Error("record message mismatch:", parsed.Message, "!=", record.Message)
+			}
+		}
+	}
+}
+
+// TestParseRecordsContinuation tests that unparseable lines are treated as
+// message continuations.
+func TestParseRecordsContinuation(t *testing.T) {
+	records := parseRecords([]string{
+		"garbage before records",
+		"2019-07-01T12:30:45.000000Z INFO [test] first line",
+		"second line",
+		"2019-07-01T12:30:46.000000Z ERROR other",
+	})
+	if len(records) != 2 {
+		t.Fatal("unexpected record count:", len(records))
+	}
+	if records[0].Message != "first line\nsecond line" {
+		t.Error("continuation not appended:", records[0].Message)
+	}
+	if records[1].Logger != "" || records[1].Message != "other" {
+		t.Error("unexpected second record:", records[1].Logger, records[1].Message)
+	}
+}
+
+// TestRecordMatchesSession tests session matching.
+func TestRecordMatchesSession(t *testing.T) {
+	record := &Record{Logger: "forwarding.fwrd_abc.source"}
+	if !record.MatchesSession("fwrd_abc") {
+		t.Error("record doesn't match its session")
+	}
+	if record.MatchesSession("fwrd_ab") {
+		t.Error("record matches session identifier prefix")
+	}
+	if record.MatchesSession("sync_abc") {
+		t.Error("record matches unrelated session")
+	}
+}
@@ -0,0 +1,236 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maximumLogFileSize is the size at which log files are rotated.
+	maximumLogFileSize = 10 * 1024 * 1024
+	// maximumLogFileBackups is the number of rotated log files to retain.
+	maximumLogFileBackups = 3
+)
+
+// backupPath computes the path for the log file backup with the specified
+// index.
+func backupPath(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
+
+// rotatingFile is an io.WriteCloser that writes to a log file, rotating it
+// when it exceeds a maximum size. Rotated files are stored alongside the log
+// file with numeric suffixes, with higher suffixes indicating older files. It
+// is safe for concurrent usage.
+type rotatingFile struct {
+	// path is the path to the log file.
+	path string
+	// maximumSize is the size at which the log file is rotated.
+	maximumSize int64
+	// backups is the number of rotated log files to retain.
+	backups int
+	// lock serializes access to the fields below.
+	lock sync.Mutex
+	// file is the current log file.
+	file *os.File
+	// size is the current size of the log file.
+	size int64
+	// rotations is the number of rotations that have been performed.
+	rotations uint64
+}
+
+// logFileRange represents the initial portion of a log file.
+type logFileRange struct {
+	// path is the path to the log file.
+	path string
+	// size is the number of bytes at the start of the file.
+	size int64
+}
+
+// openRotatingFile opens a rotating log file at the specified path, appending
+// to any existing content.
+func openRotatingFile(path string, maximumSize int64, backups int) (*rotatingFile, error) {
+	// Create the file.
+	result := &rotatingFile{
+		path:        path,
+		maximumSize: maximumSize,
+		backups:     backups,
+	}
+
+	// Open the underlying file.
+	if err := result.open(); err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return result, nil
+}
+
+// open opens the underlying log file for appending. It must be called with the
+// lock held (or before the file is shared).
+func (f *rotatingFile) open() error {
+	// Open the file.
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Wrap(err, "unable to open log file")
+	}
+
+	// Determine its current size.
+	metadata, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return errors.Wrap(err, "unable to query log file metadata")
+	}
+
+	// Success.
+	f.file = file
+	f.size = metadata.Size()
+	return nil
+}
+
+// rotate closes the current log file, shifts existing backups, moves the log
+// file to the first backup position, and opens a new log file. It must be
+// called with the lock held.
+func (f *rotatingFile) rotate() error {
+	// Close the current file.
+	if err := f.file.Close(); err != nil {
+		return errors.Wrap(err, "unable to close log file")
+	}
+	f.file = nil
+
+	// Shift backups. The oldest backup is overwritten by the rename.
+	for i := f.backups - 1; i > 0; i-- {
+		os.Rename(backupPath(f.path, i), backupPath(f.path, i+1))
+	}
+
+	// Move the current file into the first backup position. If we're not
+	// retaining backups, then just remove it.
+	if f.backups > 0 {
+		if err := os.Rename(f.path, backupPath(f.path, 1)); err != nil {
+			return errors.Wrap(err, "unable to move log file")
+		}
+	} else if err := os.Remove(f.path); err != nil {
+		return errors.Wrap(err, "unable to remove log file")
+	}
+
+	// Record the rotation and open a new file.
+	f.rotations++
+	return f.open()
+}
+
+// Write implements io.Writer.Write. Callers should provide complete records
+// since rotation only occurs between writes.
+func (f *rotatingFile) Write(data []byte) (int, error) {
+	// Lock the file and defer its release.
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	// Verify that the file hasn't been closed.
+	if f.file == nil {
+		return 0, errors.New("log file closed")
+	}
+
+	// Rotate the file if this write would exceed the maximum size. We don't
+	// rotate empty files, since that wouldn't help with oversized writes.
+	if f.size > 0 && f.size+int64(len(data)) > f.maximumSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	// Perform the write.
+	n, err := f.file.Write(data)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close implements io.Closer.Close.
+func (f *rotatingFile) Close() error {
+	// Lock the file and defer its release.
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	// If the file has already been closed, then there's nothing to do.
+	if f.file == nil {
+		return nil
+	}
+
+	// Close the file.
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// snapshot records the current extents of the log file and its backups, in
+// chronological order, along with the number of rotations performed so far.
+// Since the log file is only ever appended to, the recorded ranges can be read
+// after further writes, so long as no rotation has occurred in the meantime.
+func (f *rotatingFile) snapshot() ([]logFileRange, uint64, error) {
+	// Lock the file and defer its release.
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	// Record the extents of any backups, from oldest to newest. Missing
+	// backups are ignored.
+	var ranges []logFileRange
+	for i := f.backups; i > 0; i-- {
+		path := backupPath(f.path, i)
+		metadata, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, 0, errors.Wrap(err, "unable to query log file metadata")
+		}
+		ranges = append(ranges, logFileRange{path, metadata.Size()})
+	}
+
+	// Record the extent of the current log file.
+	ranges = append(ranges, logFileRange{f.path, f.size})
+
+	// Success.
+	return ranges, f.rotations, nil
+}
+
+// rotationCount returns the number of rotations that have been performed.
+func (f *rotatingFile) rotationCount() uint64 {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.rotations
+}
+
+// readLogLines reads the lines contained in the specified log file ranges,
+// returning them in the order of the ranges. Missing files are ignored.
+func readLogLines(ranges []logFileRange) ([]string, error) {
+	var lines []string
+	for _, r := range ranges {
+		contents, err := readLogFileRange(r)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrap(err, "unable to read log file")
+		}
+		for _, line := range strings.Split(string(contents), "\n") {
+			if line = strings.TrimSuffix(line, "\r"); line != "" {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines, nil
+}
+
+// readLogFileRange reads the contents of a log file range.
+func readLogFileRange(r logFileRange) ([]byte, error) {
+	file, err := os.Open(r.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ioutil.ReadAll(io.LimitReader(file, r.size))
+}
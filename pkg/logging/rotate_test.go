@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestRotatingFile tests log file rotation and backup retention.
+func TestRotatingFile(t *testing.T) {
+	// Create a temporary directory and defer its removal.
+	directory, err := ioutil.TempDir("", "mutagen_logging")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Open a rotating file with a small maximum size and defer its closure.
+	path := filepath.Join(directory, "test.log")
+	file, err := openRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal("unable to open rotating file:", err)
+	}
+	defer file.Close()
+
+	// Perform writes that will force rotation.
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatal("unable to write to rotating file:", err)
+		}
+	}
+
+	// Record the extents of the retained files and verify that the rotations
+	// were counted.
+	ranges, rotations, err := file.snapshot()
+	if err != nil {
+		t.Fatal("unable to record log file extents:", err)
+	} else if rotations != 3 {
+		t.Error("unexpected rotation count:", rotations)
+	}
+
+	// Perform a write that doesn't force rotation and verify that only the
+	// retained content that existed when the extents were recorded is read
+	// back, in order.
+	if _, err := file.Write([]byte("x\n")); err != nil {
+		t.Fatal("unable to write to rotating file:", err)
+	}
+	lines, err := readLogLines(ranges)
+	if err != nil {
+		t.Fatal("unable to read log lines:", err)
+	}
+	expected := []string{"second", "third", "fourth"}
+	if len(lines) != len(expected) {
+		t.Fatal("unexpected line count:", len(lines))
+	}
+	for i, line := range lines {
+		if line != expected[i] {
+			t.Error("line mismatch:", line, "!=", expected[i])
+		}
+	}
+
+	// Verify that writes fail after closure.
+	file.Close()
+	if _, err := file.Write([]byte("fifth\n")); err == nil {
+		t.Error("write succeeded after closure")
+	}
+}
+
+// TestReadLogLinesMissing tests that missing log files are ignored.
+func TestReadLogLinesMissing(t *testing.T) {
+	ranges := []logFileRange{{filepath.Join(os.TempDir(), "mutagen_nonexistent.log"), 10}}
+	lines, err := readLogLines(ranges)
+	if err != nil {
+		t.Fatal("unable to read missing log file:", err)
+	}
+	if len(lines) != 0 {
+		t.Error("lines read from missing log file")
+	}
+}
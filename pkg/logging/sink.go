@@ -0,0 +1,233 @@
+package logging
+
+import (
+	"io"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fatih/color"
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/mutagen"
+)
+
+// sink is the global log record destination. Records are always written to
+// standard error (via the standard logger for text-formatted records) and, if
+// configured, to a rotating log file. They are also distributed to any active
+// subscriptions.
+var sink struct {
+	// sequence is the sequence number of the most recently emitted record. It
+	// must be accessed atomically. It's the first field so that it's 64-bit
+	// aligned on 32-bit platforms. Sequence numbers are assigned while holding
+	// the lock for reading, so holding it for writing ensures that every
+	// record with a sequence number up to the current value has been written.
+	sequence uint64
+	// level is the current log level, stored as an int32 so that it can be
+	// accessed atomically without holding the lock.
+	level int32
+	// lock serializes access to the fields below.
+	lock sync.RWMutex
+	// format is the current log format.
+	format Format
+	// file is the current log file, if any.
+	file *rotatingFile
+	// subscriptions is the set of active record subscriptions.
+	subscriptions map[chan *Record]bool
+}
+
+// jsonStandardError is the logger used to write JSON-formatted records to
+// standard error. We avoid the standard logger for these records since it
+// would prefix them with non-JSON content.
+var jsonStandardError = log.New(os.Stderr, "", 0)
+
+func init() {
+	// Set the default log level. Debugging output is enabled by default if
+	// Mutagen debugging is enabled.
+	sink.level = int32(LevelInfo)
+	if mutagen.DebugEnabled {
+		sink.level = int32(LevelDebug)
+	}
+
+	// Initialize the subscription set.
+	sink.subscriptions = make(map[chan *Record]bool)
+}
+
+// SetLevel sets the global log level. Records more verbose than this level are
+// discarded.
+func SetLevel(level Level) {
+	atomic.StoreInt32(&sink.level, int32(level))
+}
+
+// CurrentLevel returns the global log level.
+func CurrentLevel() Level {
+	return Level(atomic.LoadInt32(&sink.level))
+}
+
+// SetFormat sets the global log format. It affects records written to
+// standard error and to any log file.
+func SetFormat(format Format) {
+	sink.lock.Lock()
+	sink.format = format
+	sink.lock.Unlock()
+}
+
+// fileCloser is the io.Closer returned by LogToFile.
+type fileCloser struct {
+	// file is the log file to detach and close.
+	file *rotatingFile
+}
+
+// Close implements io.Closer.Close.
+func (c *fileCloser) Close() error {
+	// Detach the file from the sink if it's still attached.
+	sink.lock.Lock()
+	if sink.file == c.file {
+		sink.file = nil
+	}
+	sink.lock.Unlock()
+
+	// Close the file.
+	return c.file.Close()
+}
+
+// LogToFile starts writing log records to a rotating log file at the specified
+// path, in addition to standard error. It replaces any existing log file
+// destination. The returned io.Closer stops logging to the file and closes it.
+func LogToFile(path string) (io.Closer, error) {
+	// Open the log file.
+	file, err := openRotatingFile(path, maximumLogFileSize, maximumLogFileBackups)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register it with the sink, closing any existing file.
+	sink.lock.Lock()
+	if sink.file != nil {
+		sink.file.Close()
+	}
+	sink.file = file
+	sink.lock.Unlock()
+
+	// Success.
+	return &fileCloser{file}, nil
+}
+
+// ErrFileLoggingDisabled is returned by History if no log file is configured.
+var ErrFileLoggingDisabled = errors.New("file logging not enabled")
+
+// History returns the records stored in the current log file and its rotated
+// backups, in chronological order, along with the sequence number of the most
+// recently logged record included in the history. Subscribed records with a
+// higher sequence number weren't included. It returns ErrFileLoggingDisabled
+// if no log file is configured.
+func History() ([]*Record, uint64, error) {
+	for {
+		// Record the current sequence number and the extents of the log files
+		// while holding the sink lock for writing. Since sequence numbers are
+		// only assigned with the lock held for reading, the recorded extents
+		// contain exactly the records up to the recorded sequence number.
+		sink.lock.Lock()
+		file := sink.file
+		if file == nil {
+			sink.lock.Unlock()
+			return nil, 0, ErrFileLoggingDisabled
+		}
+		ranges, rotations, err := file.snapshot()
+		sequence := atomic.LoadUint64(&sink.sequence)
+		sink.lock.Unlock()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// Read the recorded ranges without holding the sink lock so that
+		// logging isn't blocked while reading. If the log file was rotated in
+		// the meantime, then the recorded paths no longer correspond to the
+		// recorded extents, so we need to try again.
+		lines, err := readLogLines(ranges)
+		if file.rotationCount() != rotations {
+			continue
+		} else if err != nil {
+			return nil, 0, err
+		}
+
+		// Parse the log lines.
+		return parseRecords(lines), sequence, nil
+	}
+}
+
+// Subscribe creates a subscription that receives all subsequently logged
+// records via the returned channel, which has the specified buffer size.
+// Records are delivered in a non-blocking fashion, so subscribers that fall
+// behind will miss records. The returned function cancels the subscription
+// and must be called when the subscription is no longer needed.
+func Subscribe(buffer int) (<-chan *Record, func()) {
+	// Create and register the subscription.
+	subscription := make(chan *Record, buffer)
+	sink.lock.Lock()
+	sink.subscriptions[subscription] = true
+	sink.lock.Unlock()
+
+	// Create the cancellation function. We never close the channel since
+	// that would make it impossible for subscribers to distinguish between
+	// cancellation and delivery.
+	return subscription, func() {
+		sink.lock.Lock()
+		delete(sink.subscriptions, subscription)
+		sink.lock.Unlock()
+	}
+}
+
+// enabled determines whether or not records at the specified level are
+// currently being logged.
+func enabled(level Level) bool {
+	return level <= CurrentLevel()
+}
+
+// emit writes a record to all destinations.
+func emit(calldepth int, record *Record) {
+	// Lock the sink for reading. Since the log file and standard logger are
+	// both safe for concurrent usage, we don't need exclusive access.
+	sink.lock.RLock()
+	defer sink.lock.RUnlock()
+
+	// Assign the record's sequence number.
+	record.Sequence = atomic.AddUint64(&sink.sequence, 1)
+
+	// Write the record to standard error. For text output, we use the
+	// standard logger so that its flags are respected, and we highlight
+	// warnings and errors.
+	if sink.format == FormatJSON {
+		if encoded, err := record.encode(FormatJSON); err == nil {
+			jsonStandardError.Print(string(encoded))
+		}
+	} else {
+		line := record.Message
+		if record.Level == LevelWarn {
+			line = color.YellowString("Warning: %s", line)
+		} else if record.Level == LevelError {
+			line = color.RedString("Error: %s", line)
+		}
+		if record.Logger != "" {
+			line = "[" + record.Logger + "] " + line
+		}
+		log.Output(calldepth+1, line)
+	}
+
+	// Write the record to the log file, if any. There's nowhere to report
+	// failures, so we ignore them.
+	if sink.file != nil {
+		if encoded, err := record.encode(sink.format); err == nil {
+			sink.file.Write(encoded)
+		}
+	}
+
+	// Distribute the record to subscriptions.
+	for subscription := range sink.subscriptions {
+		select {
+		case subscription <- record:
+		default:
+		}
+	}
+}
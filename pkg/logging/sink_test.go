@@ -0,0 +1,108 @@
+package logging
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestHistoryWithoutFile tests that history is unavailable without a log file.
+func TestHistoryWithoutFile(t *testing.T) {
+	if _, _, err := History(); err != ErrFileLoggingDisabled {
+		t.Error("unexpected error reading history without log file:", err)
+	}
+}
+
+// TestLogToFile tests that records are written to a log file and can be read
+// back via History in both formats.
+func TestLogToFile(t *testing.T) {
+	// Create a temporary directory and defer its removal.
+	directory, err := ioutil.TempDir("", "mutagen_logging")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Defer restoration of the log format.
+	defer SetFormat(FormatText)
+
+	// Test each format.
+	for _, format := range []Format{FormatText, FormatJSON} {
+		// Configure logging to a file.
+		SetFormat(format)
+		closer, err := LogToFile(filepath.Join(directory, format.String()+".log"))
+		if err != nil {
+			t.Fatal("unable to start logging to file:", err)
+		}
+
+		// Log some records.
+		logger := RootLogger.Sublogger("sync").Sublogger("identifier")
+		logger.Println("first")
+		logger.Warn(errors.New("second"))
+
+		// Read the history.
+		records, sequence, err := History()
+		if err != nil {
+			t.Fatal("unable to read history:", err)
+		}
+		if len(records) != 2 {
+			t.Fatal("unexpected record count:", len(records))
+		}
+		if records[0].Message != "first" || records[0].Level != LevelInfo {
+			t.Error("unexpected first record:", records[0].Level, records[0].Message)
+		}
+		if records[1].Message != "second" || records[1].Level != LevelWarn {
+			t.Error("unexpected second record:", records[1].Level, records[1].Message)
+		}
+		if !records[0].MatchesSession("identifier") {
+			t.Error("record doesn't match session")
+		}
+
+		// Verify that subsequently logged records have higher sequence
+		// numbers than the history.
+		subscription, cancel := Subscribe(1)
+		logger.Println("third")
+		cancel()
+		if record := <-subscription; record.Sequence <= sequence {
+			t.Error("record logged after history has non-increasing sequence number")
+		}
+
+		// Stop logging to the file.
+		if err := closer.Close(); err != nil {
+			t.Error("unable to stop logging to file:", err)
+		}
+		if _, _, err := History(); err == nil {
+			t.Error("history available after closing log file")
+		}
+	}
+}
+
+// TestSequenceNumbers tests that records are assigned increasing sequence
+// numbers.
+func TestSequenceNumbers(t *testing.T) {
+	records, cancel := Subscribe(2)
+	defer cancel()
+	RootLogger.Print("first")
+	RootLogger.Print("second")
+	first, second := <-records, <-records
+	if first.Sequence == 0 {
+		t.Error("record not assigned sequence number")
+	} else if second.Sequence <= first.Sequence {
+		t.Error("sequence numbers not increasing:", first.Sequence, second.Sequence)
+	}
+}
+
+// TestSubscriptionCancellation tests that cancelled subscriptions no longer
+// receive records.
+func TestSubscriptionCancellation(t *testing.T) {
+	records, cancel := Subscribe(1)
+	cancel()
+	RootLogger.Print("test")
+	select {
+	case <-records:
+		t.Error("record received after cancellation")
+	default:
+	}
+}
@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// boolValue converts a boolean to a sample value.
+func boolValue(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// durationSeconds converts a Protocol Buffers duration to seconds, treating
+// nil or invalid durations as zero.
+func durationSeconds(value *duration.Duration) float64 {
+	if d, err := ptypes.Duration(value); err == nil {
+		return d.Seconds()
+	}
+	return 0
+}
+
+// sessionLabels returns the identifying labels for a session.
+func sessionLabels(identifier, name string) []label {
+	return []label{{"session", identifier}, {"name", name}}
+}
+
+// withLabel returns a copy of a label set with an additional label.
+func withLabel(labels []label, name, value string) []label {
+	result := make([]label, len(labels), len(labels)+1)
+	copy(result, labels)
+	return append(result, label{name, value})
+}
+
+// collectSynchronization computes metric families for synchronization
+// sessions. Cycle, staging, and duration counters are reset when a session
+// reconnects, which consumers will see as counter resets.
+func collectSynchronization(states []*synchronization.State) []*family {
+	// Create families.
+	status := &family{name: "mutagen_sync_session_status", typ: metricTypeGauge,
+		help: "Synchronization session status, with a value of 1 for the current status."}
+	paused := &family{name: "mutagen_sync_session_paused", typ: metricTypeGauge,
+		help: "Whether or not the synchronization session is paused."}
+	errored := &family{name: "mutagen_sync_session_errored", typ: metricTypeGauge,
+		help: "Whether or not the synchronization session has a recorded error."}
+	connected := &family{name: "mutagen_sync_endpoint_connected", typ: metricTypeGauge,
+		help: "Whether or not the synchronization endpoint is connected."}
+	cycles := &family{name: "mutagen_sync_cycles", typ: metricTypeCounter,
+		help: "Number of successful synchronization cycles since the session last connected."}
+	cycleDuration := &family{name: "mutagen_sync_cycle_duration_seconds", typ: metricTypeCounter,
+		help: "Cumulative duration of successful synchronization cycles since the session last connected."}
+	lastCycleDuration := &family{name: "mutagen_sync_last_cycle_duration_seconds", typ: metricTypeGauge,
+		help: "Duration of the most recent successful synchronization cycle."}
+	stagedBytes := &family{name: "mutagen_sync_staged_bytes", typ: metricTypeCounter,
+		help: "Number of bytes of file data staged since the session last connected."}
+	conflicts := &family{name: "mutagen_sync_conflicts", typ: metricTypeGauge,
+		help: "Number of synchronization conflicts."}
+	problems := &family{name: "mutagen_sync_problems", typ: metricTypeGauge,
+		help: "Number of problems encountered by the synchronization endpoint."}
+	reconnections := &family{name: "mutagen_sync_reconnections", typ: metricTypeCounter,
+		help: "Number of times that the synchronization session has reconnected after a failure."}
+
+	// Record samples.
+	for _, state := range states {
+		labels := sessionLabels(state.Session.Identifier, state.Session.Name)
+		status.add(1, withLabel(labels, "status", state.Status.String())...)
+		paused.add(boolValue(state.Session.Paused), labels...)
+		errored.add(boolValue(state.LastError != ""), labels...)
+		connected.add(boolValue(state.AlphaConnected), withLabel(labels, "endpoint", "alpha")...)
+		connected.add(boolValue(state.BetaConnected), withLabel(labels, "endpoint", "beta")...)
+		cycles.add(float64(state.SuccessfulSynchronizationCycles), labels...)
+		cycleDuration.add(durationSeconds(state.TotalSynchronizationCycleDuration), labels...)
+		lastCycleDuration.add(durationSeconds(state.LastSynchronizationCycleDuration), labels...)
+		stagedBytes.add(float64(state.StagedBytes), labels...)
+		conflicts.add(float64(len(state.Conflicts)), labels...)
+		problems.add(float64(len(state.AlphaProblems)), withLabel(labels, "endpoint", "alpha")...)
+		problems.add(float64(len(state.BetaProblems)), withLabel(labels, "endpoint", "beta")...)
+		reconnections.add(float64(state.Reconnections), labels...)
+	}
+
+	// Done.
+	return []*family{
+		status, paused, errored, connected,
+		cycles, cycleDuration, lastCycleDuration, stagedBytes,
+		conflicts, problems, reconnections,
+	}
+}
+
+// collectForwarding computes metric families for forwarding sessions.
+// Connection and data counters are reset when a session reconnects, which
+// consumers will see as counter resets.
+func collectForwarding(states []*forwarding.State) []*family {
+	// Create families.
+	status := &family{name: "mutagen_forward_session_status", typ: metricTypeGauge,
+		help: "Forwarding session status, with a value of 1 for the current status."}
+	paused := &family{name: "mutagen_forward_session_paused", typ: metricTypeGauge,
+		help: "Whether or not the forwarding session is paused."}
+	errored := &family{name: "mutagen_forward_session_errored", typ: metricTypeGauge,
+		help: "Whether or not the forwarding session has a recorded error."}
+	connected := &family{name: "mutagen_forward_endpoint_connected", typ: metricTypeGauge,
+		help: "Whether or not the forwarding endpoint is connected."}
+	openConnections := &family{name: "mutagen_forward_open_connections", typ: metricTypeGauge,
+		help: "Number of connections currently being forwarded."}
+	totalConnections := &family{name: "mutagen_forward_connections", typ: metricTypeCounter,
+		help: "Number of connections forwarded since the session last connected."}
+	failedConnections := &family{name: "mutagen_forward_failed_connections", typ: metricTypeCounter,
+		help: "Number of connections that couldn't be forwarded since the session last connected."}
+	bytes := &family{name: "mutagen_forward_bytes", typ: metricTypeCounter,
+		help: "Number of bytes forwarded since the session last connected."}
+	reconnections := &family{name: "mutagen_forward_reconnections", typ: metricTypeCounter,
+		help: "Number of times that the forwarding session has reconnected after a failure."}
+
+	// Record samples.
+	for _, state := range states {
+		labels := sessionLabels(state.Session.Identifier, state.Session.Name)
+		status.add(1, withLabel(labels, "status", state.Status.String())...)
+		paused.add(boolValue(state.Session.Paused), labels...)
+		errored.add(boolValue(state.LastError != ""), labels...)
+		connected.add(boolValue(state.SourceConnected), withLabel(labels, "endpoint", "source")...)
+		connected.add(boolValue(state.DestinationConnected), withLabel(labels, "endpoint", "destination")...)
+		openConnections.add(float64(state.OpenConnections), labels...)
+		totalConnections.add(float64(state.TotalConnections), labels...)
+		failedConnections.add(float64(state.FailedConnections), labels...)
+		bytes.add(float64(state.BytesToDestination), withLabel(labels, "direction", "to_destination")...)
+		bytes.add(float64(state.BytesToSource), withLabel(labels, "direction", "to_source")...)
+		reconnections.add(float64(state.Reconnections), labels...)
+	}
+
+	// Done.
+	return []*family{
+		status, paused, errored, connected,
+		openConnections, totalConnections, failedConnections, bytes,
+		reconnections,
+	}
+}
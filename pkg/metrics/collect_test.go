@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// render renders families in Prometheus format for verification.
+func render(t *testing.T, families []*family) string {
+	buffer := &bytes.Buffer{}
+	if err := writeFamilies(buffer, families, false); err != nil {
+		t.Fatal("unable to write families:", err)
+	}
+	return buffer.String()
+}
+
+// TestCollectSynchronization tests synchronization session metric collection.
+func TestCollectSynchronization(t *testing.T) {
+	output := render(t, collectSynchronization([]*synchronization.State{
+		{
+			Session:                           &synchronization.Session{Identifier: "sync_a", Name: "web"},
+			Status:                            synchronization.Status_Watching,
+			AlphaConnected:                    true,
+			SuccessfulSynchronizationCycles:   4,
+			StagedBytes:                       2048,
+			Reconnections:                     2,
+			LastSynchronizationCycleDuration:  ptypes.DurationProto(500 * time.Millisecond),
+			TotalSynchronizationCycleDuration: ptypes.DurationProto(3 * time.Second),
+			Conflicts:                         []*core.Conflict{{}},
+			BetaProblems:                      []*core.Problem{{}, {}},
+		},
+	}))
+
+	// Verify expected samples.
+	expected := []string{
+		`mutagen_sync_session_status{session="sync_a",name="web",status="Watching"} 1`,
+		`mutagen_sync_session_paused{session="sync_a",name="web"} 0`,
+		`mutagen_sync_endpoint_connected{session="sync_a",name="web",endpoint="alpha"} 1`,
+		`mutagen_sync_endpoint_connected{session="sync_a",name="web",endpoint="beta"} 0`,
+		`mutagen_sync_cycles_total{session="sync_a",name="web"} 4`,
+		`mutagen_sync_cycle_duration_seconds_total{session="sync_a",name="web"} 3`,
+		`mutagen_sync_last_cycle_duration_seconds{session="sync_a",name="web"} 0.5`,
+		`mutagen_sync_staged_bytes_total{session="sync_a",name="web"} 2048`,
+		`mutagen_sync_conflicts{session="sync_a",name="web"} 1`,
+		`mutagen_sync_problems{session="sync_a",name="web",endpoint="beta"} 2`,
+		`mutagen_sync_reconnections_total{session="sync_a",name="web"} 2`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(output, line+"\n") {
+			t.Error("missing expected sample:", line)
+		}
+	}
+}
+
+// TestCollectForwarding tests forwarding session metric collection.
+func TestCollectForwarding(t *testing.T) {
+	output := render(t, collectForwarding([]*forwarding.State{
+		{
+			Session:            &forwarding.Session{Identifier: "fwd_a", Paused: true},
+			Status:             forwarding.Status_Disconnected,
+			LastError:          "failure",
+			OpenConnections:    1,
+			TotalConnections:   5,
+			FailedConnections:  2,
+			BytesToDestination: 100,
+			BytesToSource:      200,
+		},
+	}))
+
+	// Verify expected samples.
+	expected := []string{
+		`mutagen_forward_session_status{session="fwd_a",name="",status="Disconnected"} 1`,
+		`mutagen_forward_session_paused{session="fwd_a",name=""} 1`,
+		`mutagen_forward_session_errored{session="fwd_a",name=""} 1`,
+		`mutagen_forward_open_connections{session="fwd_a",name=""} 1`,
+		`mutagen_forward_connections_total{session="fwd_a",name=""} 5`,
+		`mutagen_forward_failed_connections_total{session="fwd_a",name=""} 2`,
+		`mutagen_forward_bytes_total{session="fwd_a",name="",direction="to_destination"} 100`,
+		`mutagen_forward_bytes_total{session="fwd_a",name="",direction="to_source"} 200`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(output, line+"\n") {
+			t.Error("missing expected sample:", line)
+		}
+	}
+}
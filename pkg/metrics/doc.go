@@ -0,0 +1,3 @@
+// Package metrics provides a Prometheus/OpenMetrics exposition endpoint for
+// daemon session metrics.
+package metrics
@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// PrometheusContentType is the content type for the Prometheus text
+	// exposition format.
+	PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+	// OpenMetricsContentType is the content type for the OpenMetrics text
+	// exposition format.
+	OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+// metricType is the type of a metric family.
+type metricType string
+
+const (
+	// metricTypeGauge indicates a gauge metric.
+	metricTypeGauge metricType = "gauge"
+	// metricTypeCounter indicates a counter metric. Counter sample names are
+	// suffixed with "_total".
+	metricTypeCounter metricType = "counter"
+)
+
+// label is a metric label.
+type label struct {
+	// name is the label name.
+	name string
+	// value is the label value.
+	value string
+}
+
+// sample is a single metric sample.
+type sample struct {
+	// labels are the sample labels.
+	labels []label
+	// value is the sample value.
+	value float64
+}
+
+// family is a metric family.
+type family struct {
+	// name is the family name. For counters, it doesn't include the "_total"
+	// suffix.
+	name string
+	// help is the family description.
+	help string
+	// typ is the family type.
+	typ metricType
+	// samples are the family samples.
+	samples []sample
+}
+
+// add adds a sample to the family.
+func (f *family) add(value float64, labels ...label) {
+	f.samples = append(f.samples, sample{labels: labels, value: value})
+}
+
+// labelValueEscaper escapes label values.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// helpEscaper escapes help text.
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+// formatValue formats a sample value.
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+// writeFamilies writes metric families in the Prometheus text exposition
+// format or, if openMetrics is true, the OpenMetrics text exposition format.
+// Families without samples are omitted.
+func writeFamilies(writer io.Writer, families []*family, openMetrics bool) error {
+	// Create a buffered writer.
+	buffered := bufio.NewWriter(writer)
+
+	// Write families.
+	for _, f := range families {
+		// Skip empty families.
+		if len(f.samples) == 0 {
+			continue
+		}
+
+		// Compute the sample name. OpenMetrics uses the bare family name in
+		// metadata, whereas the Prometheus format uses the sample name.
+		sampleName := f.name
+		if f.typ == metricTypeCounter {
+			sampleName += "_total"
+		}
+		metadataName := sampleName
+		if openMetrics {
+			metadataName = f.name
+		}
+
+		// Write metadata.
+		buffered.WriteString("# HELP " + metadataName + " " + helpEscaper.Replace(f.help) + "\n")
+		buffered.WriteString("# TYPE " + metadataName + " " + string(f.typ) + "\n")
+
+		// Write samples.
+		for _, s := range f.samples {
+			buffered.WriteString(sampleName)
+			if len(s.labels) > 0 {
+				buffered.WriteByte('{')
+				for i, l := range s.labels {
+					if i > 0 {
+						buffered.WriteByte(',')
+					}
+					buffered.WriteString(l.name + `="` + labelValueEscaper.Replace(l.value) + `"`)
+				}
+				buffered.WriteByte('}')
+			}
+			buffered.WriteString(" " + formatValue(s.value) + "\n")
+		}
+	}
+
+	// Write the OpenMetrics terminator if necessary.
+	if openMetrics {
+		buffered.WriteString("# EOF\n")
+	}
+
+	// Flush output.
+	return buffered.Flush()
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+// testFamilies are the families used for exposition tests.
+var testFamilies = []*family{
+	{
+		name: "test_cycles",
+		help: "Cycle count.",
+		typ:  metricTypeCounter,
+		samples: []sample{
+			{labels: []label{{"session", "a"}, {"name", "quote\"back\\slash\nnewline"}}, value: 3},
+		},
+	},
+	{
+		name: "test_empty",
+		help: "Empty family.",
+		typ:  metricTypeGauge,
+	},
+	{
+		name: "test_gauge",
+		help: "Gauge value.",
+		typ:  metricTypeGauge,
+		samples: []sample{
+			{value: 0.5},
+			{value: math.Inf(1)},
+		},
+	},
+}
+
+// TestWriteFamiliesPrometheus tests Prometheus text exposition.
+func TestWriteFamiliesPrometheus(t *testing.T) {
+	expected := `# HELP test_cycles_total Cycle count.
+# TYPE test_cycles_total counter
+test_cycles_total{session="a",name="quote\"back\\slash\nnewline"} 3
+# HELP test_gauge Gauge value.
+# TYPE test_gauge gauge
+test_gauge 0.5
+test_gauge +Inf
+`
+	buffer := &bytes.Buffer{}
+	if err := writeFamilies(buffer, testFamilies, false); err != nil {
+		t.Fatal("unable to write families:", err)
+	} else if buffer.String() != expected {
+		t.Errorf("exposition does not match expected:\n%s", buffer.String())
+	}
+}
+
+// TestWriteFamiliesOpenMetrics tests OpenMetrics text exposition.
+func TestWriteFamiliesOpenMetrics(t *testing.T) {
+	expected := `# HELP test_cycles Cycle count.
+# TYPE test_cycles counter
+test_cycles_total{session="a",name="quote\"back\\slash\nnewline"} 3
+# HELP test_gauge Gauge value.
+# TYPE test_gauge gauge
+test_gauge 0.5
+test_gauge +Inf
+# EOF
+`
+	buffer := &bytes.Buffer{}
+	if err := writeFamilies(buffer, testFamilies, true); err != nil {
+		t.Fatal("unable to write families:", err)
+	} else if buffer.String() != expected {
+		t.Errorf("exposition does not match expected:\n%s", buffer.String())
+	}
+}
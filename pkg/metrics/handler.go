@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/mutagen"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// handler is the HTTP handler for the metrics endpoint.
+type handler struct {
+	// synchronizationStates retrieves the current synchronization session
+	// states.
+	synchronizationStates func() ([]*synchronization.State, error)
+	// forwardingStates retrieves the current forwarding session states.
+	forwardingStates func() ([]*forwarding.State, error)
+}
+
+// NewHandler creates a new HTTP handler that exposes metrics for the sessions
+// managed by the specified managers. Session state is retrieved on each
+// request, so the handler doesn't perform any background work.
+func NewHandler(synchronizationManager *synchronization.Manager, forwardingManager *forwarding.Manager) http.Handler {
+	return &handler{
+		synchronizationStates: func() ([]*synchronization.State, error) {
+			_, states, err := synchronizationManager.List(&selection.Selection{All: true}, 0)
+			return states, err
+		},
+		forwardingStates: func() ([]*forwarding.State, error) {
+			_, states, err := forwardingManager.List(&selection.Selection{All: true}, 0)
+			return states, err
+		},
+	}
+}
+
+// ServeHTTP implements http.Handler.ServeHTTP.
+func (h *handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	// Only allow retrieval.
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		http.Error(writer, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Retrieve session states.
+	synchronizationStates, err := h.synchronizationStates()
+	if err != nil {
+		http.Error(writer, errors.Wrap(err, "unable to list synchronization sessions").Error(), http.StatusInternalServerError)
+		return
+	}
+	forwardingStates, err := h.forwardingStates()
+	if err != nil {
+		http.Error(writer, errors.Wrap(err, "unable to list forwarding sessions").Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Compute metric families.
+	info := &family{name: "mutagen_daemon_info", typ: metricTypeGauge, help: "Mutagen daemon information."}
+	info.add(1, label{"version", mutagen.Version})
+	families := []*family{info}
+	families = append(families, collectSynchronization(synchronizationStates)...)
+	families = append(families, collectForwarding(forwardingStates)...)
+
+	// Determine the exposition format based on content negotiation.
+	openMetrics := strings.Contains(request.Header.Get("Accept"), "application/openmetrics-text")
+	if openMetrics {
+		writer.Header().Set("Content-Type", OpenMetricsContentType)
+	} else {
+		writer.Header().Set("Content-Type", PrometheusContentType)
+	}
+
+	// Write the response. If this fails, then the client has most likely
+	// disconnected, so there's nothing we can do.
+	writeFamilies(writer, families, openMetrics)
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// TestHandler tests the metrics HTTP handler.
+func TestHandler(t *testing.T) {
+	// Create a handler with fixed session states.
+	server := httptest.NewServer(&handler{
+		synchronizationStates: func() ([]*synchronization.State, error) {
+			return []*synchronization.State{
+				{Session: &synchronization.Session{Identifier: "sync_a"}, SuccessfulSynchronizationCycles: 7},
+			}, nil
+		},
+		forwardingStates: func() ([]*forwarding.State, error) {
+			return nil, nil
+		},
+	})
+	defer server.Close()
+
+	// Perform a Prometheus-format request.
+	response, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal("unable to perform request:", err)
+	}
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		t.Fatal("unable to read response:", err)
+	}
+	if contentType := response.Header.Get("Content-Type"); contentType != PrometheusContentType {
+		t.Error("unexpected content type:", contentType)
+	}
+	if !strings.Contains(string(body), `mutagen_sync_cycles_total{session="sync_a",name=""} 7`) {
+		t.Error("response missing expected sample")
+	}
+	if !strings.Contains(string(body), "mutagen_daemon_info{version=") {
+		t.Error("response missing daemon information")
+	}
+
+	// Perform an OpenMetrics-format request.
+	request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	request.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	response, err = http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal("unable to perform request:", err)
+	}
+	body, err = ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		t.Fatal("unable to read response:", err)
+	}
+	if contentType := response.Header.Get("Content-Type"); contentType != OpenMetricsContentType {
+		t.Error("unexpected content type:", contentType)
+	}
+	if !strings.HasSuffix(string(body), "# EOF\n") {
+		t.Error("OpenMetrics response missing terminator")
+	}
+
+	// Verify that other methods are rejected.
+	if response, err := http.Post(server.URL, "text/plain", nil); err != nil {
+		t.Fatal("unable to perform request:", err)
+	} else {
+		response.Body.Close()
+		if response.StatusCode != http.StatusMethodNotAllowed {
+			t.Error("unexpected status for POST request:", response.StatusCode)
+		}
+	}
+}
+
+// TestHandlerListingFailure tests that the metrics HTTP handler reports
+// session listing failures.
+func TestHandlerListingFailure(t *testing.T) {
+	server := httptest.NewServer(&handler{
+		synchronizationStates: func() ([]*synchronization.State, error) {
+			return nil, errors.New("state tracking terminated")
+		},
+		forwardingStates: func() ([]*forwarding.State, error) {
+			return nil, nil
+		},
+	})
+	defer server.Close()
+	if response, err := http.Get(server.URL); err != nil {
+		t.Fatal("unable to perform request:", err)
+	} else {
+		response.Body.Close()
+		if response.StatusCode != http.StatusInternalServerError {
+			t.Error("unexpected status for listing failure:", response.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package notification
+
+import (
+	"time"
+)
+
+// sessionCondition tracks the notification-relevant condition of a session.
+type sessionCondition struct {
+	// name is the session name.
+	name string
+	// labels are the session labels.
+	labels map[string]string
+	// halted indicates whether or not the session is halted.
+	halted bool
+	// lastError is the session's last error.
+	lastError string
+	// troubled indicates whether or not a halted or errored notification has
+	// been generated since the session last operated normally.
+	troubled bool
+}
+
+// conditionTracker tracks session conditions and generates notifications in
+// response to changes in those conditions. It is not safe for concurrent use.
+type conditionTracker struct {
+	// kind is the kind of session being tracked.
+	kind string
+	// now is the time source used to timestamp notifications.
+	now func() time.Time
+	// sessions maps session identifiers to their conditions.
+	sessions map[string]*sessionCondition
+}
+
+// newConditionTracker creates a new condition tracker for the specified kind
+// of session.
+func newConditionTracker(kind string) *conditionTracker {
+	return &conditionTracker{
+		kind:     kind,
+		now:      time.Now,
+		sessions: make(map[string]*sessionCondition),
+	}
+}
+
+// notification creates a new notification for the specified session.
+func (t *conditionTracker) notification(event Event, session string, condition *sessionCondition) *Notification {
+	return &Notification{
+		Event:   event,
+		Kind:    t.kind,
+		Session: session,
+		Name:    condition.name,
+		Labels:  condition.labels,
+		Time:    t.now(),
+	}
+}
+
+// recovered checks whether or not a troubled session has returned to normal
+// operation, generating a recovery notification if so.
+func (t *conditionTracker) recovered(session string, condition *sessionCondition) *Notification {
+	if condition.troubled && !condition.halted && condition.lastError == "" {
+		condition.troubled = false
+		return t.notification(EventRecovered, session, condition)
+	}
+	return nil
+}
+
+// add starts tracking a session. Conditions present when tracking starts don't
+// generate notifications.
+func (t *conditionTracker) add(session, name string, labels map[string]string, halted bool, lastError string) {
+	t.sessions[session] = &sessionCondition{
+		name:      name,
+		labels:    labels,
+		halted:    halted,
+		lastError: lastError,
+	}
+}
+
+// remove stops tracking a session.
+func (t *conditionTracker) remove(session string) {
+	delete(t.sessions, session)
+}
+
+// statusChanged records a change in a session's halted state. The status
+// description is included in any halted notification.
+func (t *conditionTracker) statusChanged(session string, halted bool, status string) *Notification {
+	// Look up the session.
+	condition, ok := t.sessions[session]
+	if !ok {
+		return nil
+	}
+
+	// Handle halting.
+	if halted && !condition.halted {
+		condition.halted = true
+		condition.troubled = true
+		notification := t.notification(EventHalted, session, condition)
+		notification.Status = status
+		return notification
+	}
+
+	// Handle recovery.
+	condition.halted = halted
+	return t.recovered(session, condition)
+}
+
+// errorChanged records a change in a session's last error. Only transitions
+// from an error-free state generate errored notifications, so a session that
+// repeatedly fails with different errors won't flood webhooks.
+func (t *conditionTracker) errorChanged(session, lastError string) *Notification {
+	// Look up the session.
+	condition, ok := t.sessions[session]
+	if !ok {
+		return nil
+	}
+
+	// Handle errors.
+	previous := condition.lastError
+	condition.lastError = lastError
+	if lastError != "" {
+		if previous != "" {
+			return nil
+		}
+		condition.troubled = true
+		notification := t.notification(EventErrored, session, condition)
+		notification.Error = lastError
+		return notification
+	}
+
+	// Handle recovery.
+	return t.recovered(session, condition)
+}
+
+// conflictsAdded records the detection of new conflicts with the specified
+// roots.
+func (t *conditionTracker) conflictsAdded(session string, roots []string) *Notification {
+	// Look up the session.
+	condition, ok := t.sessions[session]
+	if !ok || len(roots) == 0 {
+		return nil
+	}
+
+	// Create the notification.
+	notification := t.notification(EventConflicts, session, condition)
+	notification.Conflicts = roots
+	return notification
+}
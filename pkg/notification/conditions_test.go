@@ -0,0 +1,84 @@
+package notification
+
+import (
+	"testing"
+)
+
+// TestConditionTracker tests the notification sequence generated by
+// conditionTracker as a session fails and recovers.
+func TestConditionTracker(t *testing.T) {
+	// Create a tracker and start tracking a healthy session.
+	tracker := newConditionTracker(KindSynchronization)
+	tracker.add("id", "name", map[string]string{"key": "value"}, false, "")
+
+	// Verify that untracked sessions don't generate notifications.
+	if n := tracker.errorChanged("other", "failure"); n != nil {
+		t.Error("notification generated for untracked session")
+	}
+
+	// Verify that an error generates an errored notification with session
+	// metadata attached.
+	if n := tracker.errorChanged("id", "failure"); n == nil {
+		t.Fatal("no notification generated for error")
+	} else if n.Event != EventErrored || n.Error != "failure" {
+		t.Error("unexpected notification for error:", n.Event, n.Error)
+	} else if n.Kind != KindSynchronization || n.Session != "id" || n.Name != "name" || n.Labels["key"] != "value" {
+		t.Error("notification has incorrect session metadata")
+	}
+
+	// Verify that a changed error doesn't generate a notification.
+	if n := tracker.errorChanged("id", "different failure"); n != nil {
+		t.Error("notification generated for changed error")
+	}
+
+	// Verify that halting generates a halted notification.
+	if n := tracker.statusChanged("id", true, "Halted"); n == nil {
+		t.Fatal("no notification generated for halt")
+	} else if n.Event != EventHalted || n.Status != "Halted" {
+		t.Error("unexpected notification for halt:", n.Event, n.Status)
+	}
+
+	// Verify that clearing the error doesn't generate a recovery notification
+	// while the session is halted.
+	if n := tracker.errorChanged("id", ""); n != nil {
+		t.Error("notification generated while halted")
+	}
+
+	// Verify that resuming generates a recovery notification.
+	if n := tracker.statusChanged("id", false, "Watching"); n == nil {
+		t.Fatal("no notification generated for recovery")
+	} else if n.Event != EventRecovered {
+		t.Error("unexpected notification for recovery:", n.Event)
+	}
+
+	// Verify that further healthy status changes don't generate notifications.
+	if n := tracker.statusChanged("id", false, "Scanning"); n != nil {
+		t.Error("notification generated for healthy status change")
+	}
+
+	// Verify that conflicts generate notifications.
+	if n := tracker.conflictsAdded("id", []string{"a", "b"}); n == nil {
+		t.Fatal("no notification generated for conflicts")
+	} else if n.Event != EventConflicts || len(n.Conflicts) != 2 {
+		t.Error("unexpected notification for conflicts:", n.Event, n.Conflicts)
+	}
+
+	// Verify that removed sessions don't generate notifications.
+	tracker.remove("id")
+	if n := tracker.errorChanged("id", "failure"); n != nil {
+		t.Error("notification generated for removed session")
+	}
+}
+
+// TestConditionTrackerInitialError tests that errors present when tracking
+// starts don't generate notifications.
+func TestConditionTrackerInitialError(t *testing.T) {
+	tracker := newConditionTracker(KindForwarding)
+	tracker.add("id", "", nil, false, "failure")
+	if n := tracker.errorChanged("id", "other failure"); n != nil {
+		t.Error("notification generated for pre-existing error")
+	}
+	if n := tracker.errorChanged("id", ""); n != nil {
+		t.Error("recovery notification generated without prior errored notification")
+	}
+}
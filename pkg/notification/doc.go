@@ -0,0 +1,3 @@
+// Package notification provides a notifier that reports session state changes
+// to external services via webhooks.
+package notification
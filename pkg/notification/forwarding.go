@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+)
+
+// processForwardingEvents updates a condition tracker based on forwarding
+// session events and returns any resulting notifications. Forwarding sessions
+// can't halt or conflict, so only errors and recoveries are reported. The
+// lookup function is used to retrieve the state of newly added sessions from
+// the snapshot in which the events were computed and may return nil if a
+// session is not present.
+func processForwardingEvents(
+	tracker *conditionTracker,
+	events []*forwarding.Event,
+	lookup func(string) *forwarding.State,
+) []*Notification {
+	var notifications []*Notification
+	for _, event := range events {
+		var notification *Notification
+		switch event.Type {
+		case forwarding.EventType_SessionAdded:
+			if state := lookup(event.Session); state != nil {
+				tracker.add(
+					event.Session,
+					state.Session.Name,
+					state.Session.Labels,
+					false,
+					state.LastError,
+				)
+			}
+		case forwarding.EventType_SessionRemoved:
+			tracker.remove(event.Session)
+		case forwarding.EventType_ErrorChanged:
+			notification = tracker.errorChanged(event.Session, event.LastError)
+		}
+		if notification != nil {
+			notifications = append(notifications, notification)
+		}
+	}
+	return notifications
+}
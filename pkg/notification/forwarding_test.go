@@ -0,0 +1,39 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+)
+
+// TestProcessForwardingEvents tests that forwarding session events are
+// translated to the expected notifications.
+func TestProcessForwardingEvents(t *testing.T) {
+	// Create a tracker and a lookup function.
+	tracker := newConditionTracker(KindForwarding)
+	lookup := func(session string) *forwarding.State {
+		return &forwarding.State{
+			Session: &forwarding.Session{Identifier: session, Labels: map[string]string{"env": "dev"}},
+		}
+	}
+
+	// Process a sequence of events.
+	notifications := processForwardingEvents(tracker, []*forwarding.Event{
+		{Type: forwarding.EventType_SessionAdded, Session: "id"},
+		{Type: forwarding.EventType_ErrorChanged, Session: "id", LastError: "failure"},
+		{Type: forwarding.EventType_ErrorChanged, Session: "id"},
+		{Type: forwarding.EventType_SessionRemoved, Session: "id"},
+		{Type: forwarding.EventType_ErrorChanged, Session: "id", LastError: "failure"},
+	}, lookup)
+
+	// Verify the notifications.
+	if len(notifications) != 2 {
+		t.Fatal("unexpected number of notifications:", len(notifications))
+	}
+	if n := notifications[0]; n.Event != EventErrored || n.Kind != KindForwarding || n.Labels["env"] != "dev" {
+		t.Error("unexpected errored notification:", n)
+	}
+	if n := notifications[1]; n.Event != EventRecovered {
+		t.Error("unexpected recovered notification:", n)
+	}
+}
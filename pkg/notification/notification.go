@@ -0,0 +1,85 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Event identifies the session condition being reported by a notification.
+type Event string
+
+const (
+	// EventHalted indicates that a synchronization session has halted.
+	EventHalted Event = "halted"
+	// EventErrored indicates that a session has encountered an error.
+	EventErrored Event = "errored"
+	// EventConflicts indicates that a synchronization session has detected new
+	// conflicts.
+	EventConflicts Event = "conflicts"
+	// EventRecovered indicates that a session which previously halted or
+	// encountered an error has returned to normal operation.
+	EventRecovered Event = "recovered"
+)
+
+// UnmarshalText implements the text unmarshalling interface used when loading
+// from YAML files.
+func (e *Event) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to an event.
+	switch event := Event(text); event {
+	case EventHalted, EventErrored, EventConflicts, EventRecovered:
+		*e = event
+	default:
+		return errors.Errorf("unknown notification event specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular event is a valid value.
+func (e Event) Supported() bool {
+	switch e {
+	case EventHalted, EventErrored, EventConflicts, EventRecovered:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	// KindSynchronization indicates that a notification refers to a
+	// synchronization session.
+	KindSynchronization = "sync"
+	// KindForwarding indicates that a notification refers to a forwarding
+	// session.
+	KindForwarding = "forward"
+)
+
+// Notification is the JSON payload delivered to webhooks.
+type Notification struct {
+	// Event is the condition being reported.
+	Event Event `json:"event"`
+	// Kind is the kind of session to which the notification applies. It will
+	// be either KindSynchronization or KindForwarding.
+	Kind string `json:"kind"`
+	// Session is the identifier of the session.
+	Session string `json:"session"`
+	// Name is the name of the session, if any.
+	Name string `json:"name,omitempty"`
+	// Labels are the labels of the session, if any.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Status is a human-readable description of the session status. It is set
+	// for halted notifications.
+	Status string `json:"status,omitempty"`
+	// Error is the session's last error. It is set for errored notifications.
+	Error string `json:"error,omitempty"`
+	// Conflicts are the root paths of newly detected conflicts. They are set
+	// for conflict notifications.
+	Conflicts []string `json:"conflicts,omitempty"`
+	// Time is the time at which the condition was observed.
+	Time time.Time `json:"time"`
+}
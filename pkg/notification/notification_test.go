@@ -0,0 +1,68 @@
+package notification
+
+import (
+	"testing"
+)
+
+// TestEventUnmarshal tests that unmarshaling from a string specification
+// succeeds for Event.
+func TestEventUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedEvent Event
+		expectFailure bool
+	}{
+		{"", "", true},
+		{"asdf", "", true},
+		{"halted", EventHalted, false},
+		{"errored", EventErrored, false},
+		{"conflicts", EventConflicts, false},
+		{"recovered", EventRecovered, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var event Event
+		if err := event.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if event != testCase.expectedEvent {
+			t.Errorf(
+				"unmarshaled event (%s) does not match expected (%s)",
+				event,
+				testCase.expectedEvent,
+			)
+		}
+	}
+}
+
+// TestEventSupported tests that Event support detection works as expected.
+func TestEventSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		event           Event
+		expectSupported bool
+	}{
+		{"", false},
+		{"asdf", false},
+		{EventHalted, true},
+		{EventErrored, true},
+		{EventConflicts, true},
+		{EventRecovered, true},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.event.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"event support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
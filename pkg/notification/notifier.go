@@ -0,0 +1,200 @@
+package notification
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// Notifier watches session managers and delivers notifications about session
+// state changes to webhooks. The set of webhooks can be updated while the
+// notifier is running.
+type Notifier struct {
+	// logger is the underlying logger.
+	logger *logging.Logger
+	// context is the context that regulates notifier Goroutines.
+	context context.Context
+	// cancel cancels the notifier context.
+	cancel context.CancelFunc
+	// done tracks the completion of notifier Goroutines.
+	done sync.WaitGroup
+	// sendersLock serializes access to senders and cancelSenders. It also
+	// serializes the addition of delivery Goroutines with shutdown.
+	sendersLock sync.RWMutex
+	// senders are the current webhook senders.
+	senders []*webhookSender
+	// cancelSenders cancels the delivery Goroutines for the current senders.
+	cancelSenders context.CancelFunc
+}
+
+// NewNotifier creates a new notifier that delivers notifications to the
+// specified webhooks.
+func NewNotifier(logger *logging.Logger, webhooks []*Webhook) (*Notifier, error) {
+	// Create a context to regulate Goroutines.
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Create the notifier.
+	notifier := &Notifier{
+		logger:  logger,
+		context: ctx,
+		cancel:  cancel,
+	}
+
+	// Set the initial webhooks.
+	if err := notifier.SetWebhooks(webhooks); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Success.
+	return notifier, nil
+}
+
+// SetWebhooks replaces the notifier's webhooks. If any of the webhooks are
+// invalid, then an error is returned and the existing webhooks are retained.
+// Notifications that are still awaiting delivery to the previous webhooks are
+// discarded.
+func (n *Notifier) SetWebhooks(webhooks []*Webhook) error {
+	// Validate webhooks and create their senders.
+	senders := make([]*webhookSender, len(webhooks))
+	for i, webhook := range webhooks {
+		if err := webhook.EnsureValid(); err != nil {
+			return errors.Wrapf(err, "invalid webhook at index %d", i)
+		}
+		sender, err := newWebhookSender(webhook)
+		if err != nil {
+			return errors.Wrapf(err, "unable to create sender for webhook at index %d", i)
+		}
+		senders[i] = sender
+	}
+
+	// Lock the senders and defer their release.
+	n.sendersLock.Lock()
+	defer n.sendersLock.Unlock()
+
+	// Ensure that the notifier hasn't been shut down.
+	if n.context.Err() != nil {
+		return errors.New("notifier shut down")
+	}
+
+	// Stop delivery for the existing senders.
+	if n.cancelSenders != nil {
+		n.cancelSenders()
+	}
+
+	// Start delivery Goroutines for the new senders.
+	ctx, cancel := context.WithCancel(n.context)
+	for _, sender := range senders {
+		n.done.Add(1)
+		go n.send(ctx, sender)
+	}
+
+	// Record the new senders.
+	n.senders = senders
+	n.cancelSenders = cancel
+
+	// Success.
+	return nil
+}
+
+// send performs delivery for a single webhook sender until the specified
+// context is cancelled.
+func (n *Notifier) send(ctx context.Context, sender *webhookSender) {
+	defer n.done.Done()
+	for {
+		select {
+		case notification := <-sender.queue:
+			if err := sender.deliver(ctx, notification); err != nil && ctx.Err() == nil {
+				n.logger.Warn(errors.Wrapf(err, "unable to deliver notification to %s", sender.webhook.URL))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// dispatch queues notifications for delivery to matching webhooks. If a
+// webhook's queue is full, then the notification is dropped for that webhook.
+func (n *Notifier) dispatch(notifications []*Notification) {
+	n.sendersLock.RLock()
+	defer n.sendersLock.RUnlock()
+	for _, notification := range notifications {
+		n.logger.Debugf("Session %s %s", notification.Session, notification.Event)
+		for _, sender := range n.senders {
+			if !sender.matches(notification) {
+				continue
+			}
+			select {
+			case sender.queue <- notification:
+			default:
+				n.logger.Warn(errors.Errorf("notification queue full for %s, dropping notification", sender.webhook.URL))
+			}
+		}
+	}
+}
+
+// WatchSynchronization starts watching the specified synchronization session
+// manager for notification-worthy changes.
+func (n *Notifier) WatchSynchronization(manager *synchronization.Manager) {
+	// Create a condition tracker. Sessions are always watched, even if there
+	// are currently no webhooks, so that condition tracking is accurate if
+	// webhooks are added later.
+	tracker := newConditionTracker(KindSynchronization)
+
+	// Start watching.
+	n.done.Add(1)
+	go func() {
+		defer n.done.Done()
+		err := manager.WatchStates(n.context, &selection.Selection{All: true}, func(events []*synchronization.Event, states map[string]*synchronization.State) error {
+			lookup := func(session string) *synchronization.State {
+				return states[session]
+			}
+			n.dispatch(processSynchronizationEvents(tracker, events, lookup))
+			return nil
+		})
+		if n.context.Err() == nil {
+			n.logger.Warn(errors.Wrap(err, "synchronization session watching failed"))
+		}
+	}()
+}
+
+// WatchForwarding starts watching the specified forwarding session manager for
+// notification-worthy changes.
+func (n *Notifier) WatchForwarding(manager *forwarding.Manager) {
+	// Create a condition tracker. Sessions are always watched, even if there
+	// are currently no webhooks, so that condition tracking is accurate if
+	// webhooks are added later.
+	tracker := newConditionTracker(KindForwarding)
+
+	// Start watching.
+	n.done.Add(1)
+	go func() {
+		defer n.done.Done()
+		err := manager.WatchStates(n.context, &selection.Selection{All: true}, func(events []*forwarding.Event, states map[string]*forwarding.State) error {
+			lookup := func(session string) *forwarding.State {
+				return states[session]
+			}
+			n.dispatch(processForwardingEvents(tracker, events, lookup))
+			return nil
+		})
+		if n.context.Err() == nil {
+			n.logger.Warn(errors.Wrap(err, "forwarding session watching failed"))
+		}
+	}()
+}
+
+// Shutdown stops watching and delivery. Any undelivered notifications are
+// discarded. It should be called before the watched managers are shut down,
+// otherwise their shutdown will be logged as a watching failure.
+func (n *Notifier) Shutdown() {
+	n.sendersLock.Lock()
+	n.cancel()
+	n.sendersLock.Unlock()
+	n.done.Wait()
+}
@@ -0,0 +1,110 @@
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestNotifierDispatch tests that the notifier delivers notifications to
+// matching webhooks.
+func TestNotifierDispatch(t *testing.T) {
+	// Create a server that records received notifications.
+	received := make(chan *Notification, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		notification := &Notification{}
+		if err := json.NewDecoder(r.Body).Decode(notification); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- notification
+	}))
+	defer server.Close()
+
+	// Create a notifier with a webhook that only receives halted
+	// notifications and defer its shutdown.
+	notifier, err := NewNotifier(nil, []*Webhook{{URL: server.URL, Events: []Event{EventHalted}}})
+	if err != nil {
+		t.Fatal("unable to create notifier:", err)
+	}
+	defer notifier.Shutdown()
+
+	// Dispatch notifications.
+	notifier.dispatch([]*Notification{
+		{Event: EventErrored, Session: "errored"},
+		{Event: EventHalted, Session: "halted"},
+	})
+
+	// Verify that only the matching notification is delivered.
+	select {
+	case notification := <-received:
+		if notification.Session != "halted" {
+			t.Error("unexpected notification delivered:", notification.Session)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for notification delivery")
+	}
+}
+
+// TestNewNotifierInvalidWebhook tests that notifier creation fails with an
+// invalid webhook.
+func TestNewNotifierInvalidWebhook(t *testing.T) {
+	if _, err := NewNotifier(nil, []*Webhook{{URL: "invalid"}}); err == nil {
+		t.Error("notifier creation succeeded with invalid webhook")
+	}
+}
+
+// TestNotifierSetWebhooks tests that webhooks can be replaced while the
+// notifier is running.
+func TestNotifierSetWebhooks(t *testing.T) {
+	// Create a server that records received notifications.
+	received := make(chan *Notification, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		notification := &Notification{}
+		if err := json.NewDecoder(r.Body).Decode(notification); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- notification
+	}))
+	defer server.Close()
+
+	// Create a notifier without any webhooks.
+	notifier, err := NewNotifier(nil, nil)
+	if err != nil {
+		t.Fatal("unable to create notifier:", err)
+	}
+
+	// Add a webhook and verify that notifications are delivered to it.
+	if err := notifier.SetWebhooks([]*Webhook{{URL: server.URL}}); err != nil {
+		t.Fatal("unable to set webhooks:", err)
+	}
+	notifier.dispatch([]*Notification{{Event: EventHalted, Session: "halted"}})
+	select {
+	case notification := <-received:
+		if notification.Session != "halted" {
+			t.Error("unexpected notification delivered:", notification.Session)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for notification delivery")
+	}
+
+	// Verify that invalid webhooks are rejected and the existing webhooks are
+	// retained.
+	if err := notifier.SetWebhooks([]*Webhook{{URL: "invalid"}}); err == nil {
+		t.Error("invalid webhooks set successfully")
+	}
+	notifier.sendersLock.RLock()
+	if len(notifier.senders) != 1 {
+		t.Error("existing webhooks not retained")
+	}
+	notifier.sendersLock.RUnlock()
+
+	// Verify that webhooks can't be set after shutdown.
+	notifier.Shutdown()
+	if err := notifier.SetWebhooks(nil); err == nil {
+		t.Error("webhooks set successfully after shutdown")
+	}
+}
@@ -0,0 +1,60 @@
+package notification
+
+import (
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// synchronizationStatusHalted determines whether or not a synchronization
+// session status indicates that the session has halted.
+func synchronizationStatusHalted(status synchronization.Status) bool {
+	return status == synchronization.Status_HaltedOnRootDeletion ||
+		status == synchronization.Status_HaltedOnRootTypeChange
+}
+
+// processSynchronizationEvents updates a condition tracker based on
+// synchronization session events and returns any resulting notifications. The
+// lookup function is used to retrieve the state of newly added sessions from
+// the snapshot in which the events were computed and may return nil if a
+// session is not present.
+func processSynchronizationEvents(
+	tracker *conditionTracker,
+	events []*synchronization.Event,
+	lookup func(string) *synchronization.State,
+) []*Notification {
+	var notifications []*Notification
+	for _, event := range events {
+		var notification *Notification
+		switch event.Type {
+		case synchronization.EventType_SessionAdded:
+			if state := lookup(event.Session); state != nil {
+				tracker.add(
+					event.Session,
+					state.Session.Name,
+					state.Session.Labels,
+					synchronizationStatusHalted(state.Status),
+					state.LastError,
+				)
+			}
+		case synchronization.EventType_SessionRemoved:
+			tracker.remove(event.Session)
+		case synchronization.EventType_StatusChanged:
+			notification = tracker.statusChanged(
+				event.Session,
+				synchronizationStatusHalted(event.Status),
+				event.Status.Description(),
+			)
+		case synchronization.EventType_ErrorChanged:
+			notification = tracker.errorChanged(event.Session, event.LastError)
+		case synchronization.EventType_ConflictsAdded:
+			roots := make([]string, len(event.Conflicts))
+			for i, conflict := range event.Conflicts {
+				roots[i] = conflict.Root()
+			}
+			notification = tracker.conflictsAdded(event.Session, roots)
+		}
+		if notification != nil {
+			notifications = append(notifications, notification)
+		}
+	}
+	return notifications
+}
@@ -0,0 +1,60 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// TestProcessSynchronizationEvents tests that synchronization session events
+// are translated to the expected notifications.
+func TestProcessSynchronizationEvents(t *testing.T) {
+	// Create a tracker and a lookup function.
+	tracker := newConditionTracker(KindSynchronization)
+	lookup := func(session string) *synchronization.State {
+		if session != "id" {
+			return nil
+		}
+		return &synchronization.State{
+			Session: &synchronization.Session{Identifier: "id", Name: "web"},
+			Status:  synchronization.Status_Watching,
+		}
+	}
+
+	// Process a sequence of events.
+	notifications := processSynchronizationEvents(tracker, []*synchronization.Event{
+		{Type: synchronization.EventType_SessionAdded, Session: "id"},
+		{Type: synchronization.EventType_SessionAdded, Session: "missing"},
+		{Type: synchronization.EventType_CycleCompleted, Session: "id"},
+		{
+			Type:    synchronization.EventType_ConflictsAdded,
+			Session: "id",
+			Conflicts: []*core.Conflict{{
+				AlphaChanges: []*core.Change{{Path: "file"}},
+				BetaChanges:  []*core.Change{{Path: "file"}},
+			}},
+		},
+		{
+			Type:    synchronization.EventType_StatusChanged,
+			Session: "id",
+			Status:  synchronization.Status_HaltedOnRootDeletion,
+		},
+		{
+			Type:      synchronization.EventType_ErrorChanged,
+			Session:   "missing",
+			LastError: "failure",
+		},
+	}, lookup)
+
+	// Verify the notifications.
+	if len(notifications) != 2 {
+		t.Fatal("unexpected number of notifications:", len(notifications))
+	}
+	if n := notifications[0]; n.Event != EventConflicts || n.Name != "web" || len(n.Conflicts) != 1 || n.Conflicts[0] != "file" {
+		t.Error("unexpected conflict notification:", n)
+	}
+	if n := notifications[1]; n.Event != EventHalted || n.Status != synchronization.Status_HaltedOnRootDeletion.Description() {
+		t.Error("unexpected halted notification:", n)
+	}
+}
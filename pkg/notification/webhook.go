@@ -0,0 +1,262 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/mutagen"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+)
+
+const (
+	// DefaultRetries is the default number of times that delivery of a
+	// notification will be retried.
+	DefaultRetries = 3
+	// webhookRequestTimeout is the maximum amount of time allowed for a single
+	// webhook delivery attempt.
+	webhookRequestTimeout = 10 * time.Second
+	// webhookRetryBaseDelay is the delay before the first delivery retry. The
+	// delay doubles with each subsequent retry.
+	webhookRetryBaseDelay = time.Second
+	// webhookRetryMaximumDelay is the maximum delay between delivery retries.
+	webhookRetryMaximumDelay = time.Minute
+	// webhookQueueSize is the number of notifications that can be queued for
+	// delivery to a single webhook before new notifications are dropped.
+	webhookQueueSize = 64
+)
+
+// Webhook describes an HTTP endpoint to which notifications are delivered.
+type Webhook struct {
+	// URL is the URL to which notifications are POSTed. It must use the http
+	// or https scheme.
+	URL string
+	// Headers are additional HTTP headers to include with each request.
+	Headers map[string]string
+	// Events are the events that should be delivered. If empty, all events
+	// are delivered.
+	Events []Event
+	// Sessions restricts delivery to sessions with the specified names or
+	// identifiers. If empty, notifications for all sessions are delivered.
+	Sessions []string
+	// ExcludedSessions suppresses delivery for sessions with the specified
+	// names or identifiers. It takes precedence over Sessions.
+	ExcludedSessions []string
+	// LabelSelector restricts delivery to sessions whose labels match the
+	// specified label selector. If empty, no label filtering is performed.
+	LabelSelector string
+	// Retries is the number of times that a failed delivery will be retried.
+	// A value of 0 indicates that DefaultRetries should be used.
+	Retries uint32
+}
+
+// EnsureValid ensures that Webhook's invariants are respected.
+func (w *Webhook) EnsureValid() error {
+	// A nil webhook is not valid.
+	if w == nil {
+		return errors.New("nil webhook")
+	}
+
+	// Verify that the URL is a valid HTTP URL.
+	if w.URL == "" {
+		return errors.New("empty webhook URL")
+	} else if target, err := url.Parse(w.URL); err != nil {
+		return errors.Wrap(err, "invalid webhook URL")
+	} else if target.Scheme != "http" && target.Scheme != "https" {
+		return errors.Errorf("unsupported webhook URL scheme: %s", target.Scheme)
+	} else if target.Host == "" {
+		return errors.New("webhook URL has empty host")
+	}
+
+	// Verify that the events are supported.
+	for _, event := range w.Events {
+		if !event.Supported() {
+			return errors.Errorf("unsupported notification event: %s", event)
+		}
+	}
+
+	// Verify that session specifications are non-empty.
+	for _, session := range w.Sessions {
+		if session == "" {
+			return errors.New("empty session specification")
+		}
+	}
+	for _, session := range w.ExcludedSessions {
+		if session == "" {
+			return errors.New("empty excluded session specification")
+		}
+	}
+
+	// Verify that the label selector is valid.
+	if w.LabelSelector != "" {
+		if _, err := selection.ParseLabelSelector(w.LabelSelector); err != nil {
+			return errors.Wrap(err, "invalid label selector")
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// webhookSender performs filtering and delivery of notifications for a single
+// webhook.
+type webhookSender struct {
+	// webhook is the underlying webhook.
+	webhook *Webhook
+	// labelSelector is the parsed label selector, if any.
+	labelSelector selection.LabelSelector
+	// client is the HTTP client used for delivery.
+	client *http.Client
+	// retryBaseDelay is the delay before the first retry.
+	retryBaseDelay time.Duration
+	// queue is the queue of notifications awaiting delivery.
+	queue chan *Notification
+}
+
+// newWebhookSender creates a new sender for the specified webhook, which must
+// be valid.
+func newWebhookSender(webhook *Webhook) (*webhookSender, error) {
+	// Parse the label selector, if any.
+	var labelSelector selection.LabelSelector
+	if webhook.LabelSelector != "" {
+		var err error
+		if labelSelector, err = selection.ParseLabelSelector(webhook.LabelSelector); err != nil {
+			return nil, errors.Wrap(err, "unable to parse label selector")
+		}
+	}
+
+	// Create the sender.
+	return &webhookSender{
+		webhook:        webhook,
+		labelSelector:  labelSelector,
+		client:         &http.Client{Timeout: webhookRequestTimeout},
+		retryBaseDelay: webhookRetryBaseDelay,
+		queue:          make(chan *Notification, webhookQueueSize),
+	}, nil
+}
+
+// matches determines whether or not a notification should be delivered to the
+// webhook.
+func (s *webhookSender) matches(notification *Notification) bool {
+	// Check the event.
+	if len(s.webhook.Events) > 0 {
+		var found bool
+		for _, event := range s.webhook.Events {
+			if event == notification.Event {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	// Check the session specifications.
+	if len(s.webhook.Sessions) > 0 && !notification.matchesAny(s.webhook.Sessions) {
+		return false
+	} else if notification.matchesAny(s.webhook.ExcludedSessions) {
+		return false
+	}
+
+	// Check the label selector.
+	if s.labelSelector != nil && !s.labelSelector.Matches(notification.Labels) {
+		return false
+	}
+
+	// Success.
+	return true
+}
+
+// matchesAny determines whether or not a notification's session is identified
+// by any of the specified session names or identifiers.
+func (n *Notification) matchesAny(specifications []string) bool {
+	for _, specification := range specifications {
+		if specification == n.Session || (n.Name != "" && specification == n.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// post performs a single delivery attempt. It returns an error indicating
+// whether or not the attempt succeeded and whether or not a failed attempt
+// should be retried.
+func (s *webhookSender) post(ctx context.Context, payload []byte) (bool, error) {
+	// Create the request.
+	request, err := http.NewRequest(http.MethodPost, s.webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return false, errors.Wrap(err, "unable to create request")
+	}
+	request = request.WithContext(ctx)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", fmt.Sprintf("mutagen/%s", mutagen.Version))
+	for key, value := range s.webhook.Headers {
+		request.Header.Set(key, value)
+	}
+
+	// Perform the request. Network failures are retryable.
+	response, err := s.client.Do(request)
+	if err != nil {
+		return true, errors.Wrap(err, "unable to perform request")
+	}
+
+	// Drain and close the response body so that the connection can be reused.
+	io.Copy(ioutil.Discard, response.Body)
+	response.Body.Close()
+
+	// Check the response status. Server errors and rate limiting are
+	// retryable, but other failures (e.g. authentication failures) won't be
+	// resolved by retrying.
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return false, nil
+	}
+	retry := response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests
+	return retry, errors.Errorf("received unexpected response status: %s", response.Status)
+}
+
+// deliver delivers a notification to the webhook, retrying with exponential
+// backoff if necessary.
+func (s *webhookSender) deliver(ctx context.Context, notification *Notification) error {
+	// Encode the payload.
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return errors.Wrap(err, "unable to encode notification")
+	}
+
+	// Compute the number of retries.
+	retries := s.webhook.Retries
+	if retries == 0 {
+		retries = DefaultRetries
+	}
+
+	// Perform delivery attempts.
+	delay := s.retryBaseDelay
+	for attempt := uint32(0); ; attempt++ {
+		// Attempt delivery.
+		retry, err := s.post(ctx, payload)
+		if err == nil {
+			return nil
+		} else if !retry || attempt == retries {
+			return errors.Wrapf(err, "delivery failed after %d attempt(s)", attempt+1)
+		}
+
+		// Wait before retrying.
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return errors.New("delivery cancelled")
+		}
+		if delay *= 2; delay > webhookRetryMaximumDelay {
+			delay = webhookRetryMaximumDelay
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestWebhookEnsureValid tests Webhook.EnsureValid.
+func TestWebhookEnsureValid(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		webhook       *Webhook
+		expectFailure bool
+	}{
+		{nil, true},
+		{&Webhook{}, true},
+		{&Webhook{URL: "ftp://example.com"}, true},
+		{&Webhook{URL: "http://"}, true},
+		{&Webhook{URL: "http://example.com", Events: []Event{"exploded"}}, true},
+		{&Webhook{URL: "http://example.com", Sessions: []string{""}}, true},
+		{&Webhook{URL: "http://example.com", ExcludedSessions: []string{""}}, true},
+		{&Webhook{URL: "http://example.com", LabelSelector: "!!!"}, true},
+		{&Webhook{URL: "http://localhost:8080/hook"}, false},
+		{
+			&Webhook{
+				URL:           "https://example.com/hook",
+				Events:        []Event{EventHalted},
+				Sessions:      []string{"web"},
+				LabelSelector: "env=dev",
+			},
+			false,
+		},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		err := testCase.webhook.EnsureValid()
+		if err == nil && testCase.expectFailure {
+			t.Errorf("test case %d: webhook validation succeeded unexpectedly", i)
+		} else if err != nil && !testCase.expectFailure {
+			t.Errorf("test case %d: webhook validation failed unexpectedly: %v", i, err)
+		}
+	}
+}
+
+// TestWebhookSenderMatches tests webhook notification filtering.
+func TestWebhookSenderMatches(t *testing.T) {
+	// Create a filtered sender.
+	sender, err := newWebhookSender(&Webhook{
+		URL:              "http://localhost",
+		Events:           []Event{EventHalted, EventErrored},
+		Sessions:         []string{"web", "sync_abc", "api"},
+		ExcludedSessions: []string{"api"},
+		LabelSelector:    "env=dev",
+	})
+	if err != nil {
+		t.Fatal("unable to create sender:", err)
+	}
+
+	// Set up test cases.
+	labels := map[string]string{"env": "dev"}
+	testCases := []struct {
+		notification *Notification
+		expected     bool
+	}{
+		{&Notification{Event: EventHalted, Session: "sync_xyz", Name: "web", Labels: labels}, true},
+		{&Notification{Event: EventErrored, Session: "sync_abc", Labels: labels}, true},
+		{&Notification{Event: EventConflicts, Session: "sync_abc", Labels: labels}, false},
+		{&Notification{Event: EventHalted, Session: "sync_xyz", Labels: labels}, false},
+		{&Notification{Event: EventHalted, Session: "sync_abc"}, false},
+		{&Notification{Event: EventHalted, Session: "sync_def", Name: "api", Labels: labels}, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if matches := sender.matches(testCase.notification); matches != testCase.expected {
+			t.Errorf("test case %d: match result (%t) does not match expected (%t)", i, matches, testCase.expected)
+		}
+	}
+}
+
+// TestWebhookSenderDeliverRetries tests that delivery is retried after server
+// errors and that the payload and headers are delivered correctly.
+func TestWebhookSenderDeliverRetries(t *testing.T) {
+	// Create a server that fails the first two requests.
+	var requests int32
+	received := make(chan *Notification, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("Content-Type") != "application/json" || r.Header.Get("X-Token") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		notification := &Notification{}
+		if err := json.NewDecoder(r.Body).Decode(notification); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- notification
+	}))
+	defer server.Close()
+
+	// Create a sender with a short retry delay.
+	sender, err := newWebhookSender(&Webhook{
+		URL:     server.URL,
+		Headers: map[string]string{"X-Token": "secret"},
+	})
+	if err != nil {
+		t.Fatal("unable to create sender:", err)
+	}
+	sender.retryBaseDelay = time.Millisecond
+
+	// Perform delivery.
+	notification := &Notification{Event: EventErrored, Kind: KindSynchronization, Session: "id", Error: "failure"}
+	if err := sender.deliver(context.Background(), notification); err != nil {
+		t.Fatal("delivery failed:", err)
+	}
+
+	// Verify the received notification.
+	if r := <-received; r.Event != EventErrored || r.Session != "id" || r.Error != "failure" {
+		t.Error("received notification does not match sent notification")
+	}
+	if r := atomic.LoadInt32(&requests); r != 3 {
+		t.Error("unexpected request count:", r)
+	}
+}
+
+// TestWebhookSenderDeliverNoRetry tests that delivery isn't retried after
+// non-retryable failures and that retries are bounded.
+func TestWebhookSenderDeliverNoRetry(t *testing.T) {
+	// Create a server that returns a status code specified by the test.
+	var requests int32
+	var status int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	}))
+	defer server.Close()
+
+	// Create a sender with a short retry delay.
+	sender, err := newWebhookSender(&Webhook{URL: server.URL, Retries: 2})
+	if err != nil {
+		t.Fatal("unable to create sender:", err)
+	}
+	sender.retryBaseDelay = time.Millisecond
+
+	// Verify that client errors aren't retried.
+	atomic.StoreInt32(&status, http.StatusUnauthorized)
+	if sender.deliver(context.Background(), &Notification{}) == nil {
+		t.Error("delivery succeeded unexpectedly")
+	} else if r := atomic.SwapInt32(&requests, 0); r != 1 {
+		t.Error("unexpected request count for client error:", r)
+	}
+
+	// Verify that server errors are retried the configured number of times.
+	atomic.StoreInt32(&status, http.StatusInternalServerError)
+	if sender.deliver(context.Background(), &Notification{}) == nil {
+		t.Error("delivery succeeded unexpectedly")
+	} else if r := atomic.LoadInt32(&requests); r != 3 {
+		t.Error("unexpected request count for server error:", r)
+	}
+}
@@ -3,7 +3,6 @@ package process
 import (
 	"io"
 	"net"
-	"os/exec"
 	"sync"
 	"time"
 
@@ -30,7 +29,7 @@ func (_ address) String() string {
 // called.
 type Connection struct {
 	// process is the underlying process.
-	process *exec.Cmd
+	process Process
 	// standardOutput is the source for process output data.
 	standardOutput io.Reader
 	// standardInput is the destination for process input data.
@@ -42,11 +41,11 @@ type Connection struct {
 	killDelay time.Duration
 }
 
-// NewConnection creates a new net.Conn object by wraping a command object. It
+// NewConnection creates a new net.Conn object by wraping a process object. It
 // must be called before the corresponding process is started. The specified
 // kill delay period must be greater than or equal to zero, otherwise this
 // function will panic.
-func NewConnection(process *exec.Cmd, killDelay time.Duration) (*Connection, error) {
+func NewConnection(process Process, killDelay time.Duration) (*Connection, error) {
 	// Validate the kill delay time.
 	if killDelay < time.Duration(0) {
 		panic("negative kill delay specified")
@@ -114,11 +113,6 @@ func (c *Connection) SetKillDelay(killDelay time.Duration) {
 // - it's all platform dependent. But terminating the process will close the
 // remote ends of the pipes and thus unblocks and reads/writes.
 func (c *Connection) Close() error {
-	// Verify that the process was actually started.
-	if c.process.Process == nil {
-		return errors.New("process not started")
-	}
-
 	// Extract the current kill delay.
 	c.killDelayLock.Lock()
 	killDelay := c.killDelay
@@ -146,7 +140,7 @@ func (c *Connection) Close() error {
 	// signal could fail, and that the process could run indefinitely. That's
 	// highly unlikely though, and it's safer to block indefinitely in that case
 	// than to return with an error that might not be checked.
-	c.process.Process.Kill()
+	c.process.Kill()
 
 	// Wait for the wait operation to complete.
 	return errors.Wrap(<-waitResults, "process wait failed")
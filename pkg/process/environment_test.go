@@ -0,0 +1,32 @@
+package process
+
+import (
+	"testing"
+)
+
+// TestFindEnvironmentVariable tests that FindEnvironmentVariable behaves as
+// expected for a variety of test cases.
+func TestFindEnvironmentVariable(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		block    string
+		variable string
+		value    string
+		found    bool
+	}{
+		{"", "HOME", "", false},
+		{"HOME=/root\nPATH=/bin\n", "HOME", "/root", true},
+		{"PATH=C:\\Windows\r\nUSERPROFILE=C:\\Users\\ContainerUser\r\n", "USERPROFILE", `C:\Users\ContainerUser`, true},
+		{"HOMER=simpson\n", "HOME", "", false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		value, found := FindEnvironmentVariable(testCase.block, testCase.variable)
+		if found != testCase.found {
+			t.Error("found mismatch for", testCase.variable, ":", found, "!=", testCase.found)
+		} else if value != testCase.value {
+			t.Error("value mismatch for", testCase.variable, ":", value, "!=", testCase.value)
+		}
+	}
+}
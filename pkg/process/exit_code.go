@@ -25,16 +25,38 @@ const (
 	// TODO: Figure out if other shells return different exit codes when a
 	// command isn't found. Is this exit code defined in a standard somewhere?
 	posixShellCommandNotFoundExitCode = 127
+
+	// windowsCommandNotFoundExitCode is the exit code returned by cmd.exe when
+	// the provided command isn't recognized.
+	windowsCommandNotFoundExitCode = 9009
 )
 
 // IsPOSIXShellInvalidCommand returns whether or not a process state represents
 // an "invalid" error from a POSIX shell.
 func IsPOSIXShellInvalidCommand(state *os.ProcessState) bool {
-	return state.ExitCode() == posixShellInvalidCommandExitCode
+	return IsPOSIXShellInvalidCommandExitCode(state.ExitCode())
+}
+
+// IsPOSIXShellInvalidCommandExitCode returns whether or not an exit code
+// represents an "invalid" error from a POSIX shell.
+func IsPOSIXShellInvalidCommandExitCode(code int) bool {
+	return code == posixShellInvalidCommandExitCode
 }
 
 // IsPOSIXShellCommandNotFound returns whether or not a process state represents
 // a "command not found" error from a POSIX shell.
 func IsPOSIXShellCommandNotFound(state *os.ProcessState) bool {
-	return state.ExitCode() == posixShellCommandNotFoundExitCode
+	return IsPOSIXShellCommandNotFoundExitCode(state.ExitCode())
+}
+
+// IsPOSIXShellCommandNotFoundExitCode returns whether or not an exit code
+// represents a "command not found" error from a POSIX shell.
+func IsPOSIXShellCommandNotFoundExitCode(code int) bool {
+	return code == posixShellCommandNotFoundExitCode
+}
+
+// IsWindowsCommandNotFoundExitCode returns whether or not an exit code
+// represents a "command not found" error from cmd.exe.
+func IsWindowsCommandNotFoundExitCode(code int) bool {
+	return code == windowsCommandNotFoundExitCode
 }
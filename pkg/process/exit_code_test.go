@@ -52,3 +52,14 @@ func TestIsPOSIXShellCommandNotFound(t *testing.T) {
 		t.Error("expected POSIX command not found classification")
 	}
 }
+
+// TestIsWindowsCommandNotFoundExitCode tests that the
+// IsWindowsCommandNotFoundExitCode function correctly classifies exit codes.
+func TestIsWindowsCommandNotFoundExitCode(t *testing.T) {
+	if !IsWindowsCommandNotFoundExitCode(9009) {
+		t.Error("cmd.exe command not found exit code not classified")
+	}
+	if IsWindowsCommandNotFoundExitCode(1) {
+		t.Error("generic failure exit code classified as command not found")
+	}
+}
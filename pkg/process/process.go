@@ -0,0 +1,72 @@
+package process
+
+import (
+	"io"
+	"os/exec"
+
+	"github.com/pkg/errors"
+)
+
+// Process is the interface for processes whose standard input and output
+// streams can be used for communication. It is modeled on the subset of
+// os/exec.Cmd functionality required by the agent infrastructure, allowing
+// processes to be implemented by mechanisms other than local executables (e.g.
+// remote commands invoked over an in-process SSH connection).
+type Process interface {
+	// StdinPipe returns a pipe connected to the process' standard input. It
+	// must be called before Start.
+	StdinPipe() (io.WriteCloser, error)
+	// StdoutPipe returns a pipe connected to the process' standard output. It
+	// must be called before Start.
+	StdoutPipe() (io.Reader, error)
+	// SetStderr sets the destination for the process' standard error output.
+	// It must be called before Start.
+	SetStderr(io.Writer)
+	// Start starts the process.
+	Start() error
+	// Wait waits for the process to exit. It returns a non-nil error if the
+	// process exits with a non-zero exit code.
+	Wait() error
+	// Kill forcibly terminates the process.
+	Kill() error
+	// ExitCode returns the exit code of the process. It returns -1 if the
+	// process hasn't exited or if the exit code couldn't be determined (e.g.
+	// due to termination by a signal).
+	ExitCode() int
+}
+
+// LocalProcess implements Process using a local os/exec.Cmd.
+type LocalProcess struct {
+	*exec.Cmd
+}
+
+// NewLocalProcess creates a new local process wrapping the specified command.
+func NewLocalProcess(command *exec.Cmd) *LocalProcess {
+	return &LocalProcess{command}
+}
+
+// StdoutPipe implements Process.StdoutPipe.
+func (p *LocalProcess) StdoutPipe() (io.Reader, error) {
+	return p.Cmd.StdoutPipe()
+}
+
+// SetStderr implements Process.SetStderr.
+func (p *LocalProcess) SetStderr(stderr io.Writer) {
+	p.Stderr = stderr
+}
+
+// Kill implements Process.Kill.
+func (p *LocalProcess) Kill() error {
+	if p.Process == nil {
+		return errors.New("process not started")
+	}
+	return p.Process.Kill()
+}
+
+// ExitCode implements Process.ExitCode.
+func (p *LocalProcess) ExitCode() int {
+	if p.ProcessState == nil {
+		return -1
+	}
+	return p.ProcessState.ExitCode()
+}
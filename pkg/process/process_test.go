@@ -0,0 +1,55 @@
+package process
+
+import (
+	"io/ioutil"
+	"os/exec"
+	"runtime"
+	"testing"
+)
+
+// TestLocalProcess tests that LocalProcess correctly exposes process I/O and
+// exit codes.
+func TestLocalProcess(t *testing.T) {
+	// Skip on Windows, where we don't have a POSIX shell.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Create the process.
+	process := NewLocalProcess(exec.Command("/bin/sh", "-c", "cat; exit 3"))
+	if process.ExitCode() != -1 {
+		t.Error("unstarted process has unexpected exit code:", process.ExitCode())
+	}
+
+	// Set up I/O.
+	input, err := process.StdinPipe()
+	if err != nil {
+		t.Fatal("unable to create input pipe:", err)
+	}
+	output, err := process.StdoutPipe()
+	if err != nil {
+		t.Fatal("unable to create output pipe:", err)
+	}
+
+	// Start the process, send input, and verify that it's echoed back.
+	if err := process.Start(); err != nil {
+		t.Fatal("unable to start process:", err)
+	}
+	if _, err := input.Write([]byte("data")); err != nil {
+		t.Fatal("unable to write input:", err)
+	}
+	input.Close()
+	if data, err := ioutil.ReadAll(output); err != nil {
+		t.Fatal("unable to read output:", err)
+	} else if string(data) != "data" {
+		t.Error("output does not match input:", string(data))
+	}
+
+	// Wait for the process and verify its exit code.
+	if err := process.Wait(); err == nil {
+		t.Error("process with non-zero exit code did not return error")
+	}
+	if process.ExitCode() != 3 {
+		t.Error("process has unexpected exit code:", process.ExitCode())
+	}
+}
@@ -1,6 +1,8 @@
 package selection
 
 import (
+	"fmt"
+
 	"github.com/pkg/errors"
 )
 
@@ -44,3 +46,21 @@ func (s *Selection) EnsureValid() error {
 	// Success.
 	return nil
 }
+
+// NoMatchError indicates that a session specification didn't match any
+// sessions.
+type NoMatchError struct {
+	// Specification is the specification that didn't match.
+	Specification string
+}
+
+// Error implements error.Error.
+func (e *NoMatchError) Error() string {
+	return fmt.Sprintf("specification \"%s\" did not match any sessions", e.Specification)
+}
+
+// NotFound indicates that the error represents a missing resource. It allows
+// the error to be classified without a dependency on this package.
+func (e *NoMatchError) NotFound() bool {
+	return true
+}
@@ -0,0 +1,87 @@
+package daemon
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+// ensureValid verifies that a LogsRequest is valid.
+func (r *LogsRequest) ensureValid() error {
+	// A nil logs request is not valid.
+	if r == nil {
+		return errors.New("nil logs request")
+	}
+
+	// Ensure that session identifiers are non-empty.
+	for _, session := range r.Sessions {
+		if session == "" {
+			return errors.New("empty session identifier")
+		}
+	}
+
+	// Ensure that the level is valid, if specified.
+	if r.Level != "" {
+		if _, ok := logging.NameToLevel(r.Level); !ok {
+			return errors.Errorf("invalid log level: %s", r.Level)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// newLogRecord converts a log record to its Protocol Buffers representation.
+func newLogRecord(record *logging.Record) (*LogRecord, error) {
+	timestamp, err := ptypes.TimestampProto(record.Time)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert record time")
+	}
+	return &LogRecord{
+		Time:    timestamp,
+		Level:   record.Level.String(),
+		Logger:  record.Logger,
+		Message: record.Message,
+	}, nil
+}
+
+// EnsureValid verifies that a LogRecord is valid.
+func (r *LogRecord) EnsureValid() error {
+	// A nil log record is not valid.
+	if r == nil {
+		return errors.New("nil log record")
+	}
+
+	// Ensure that the time is valid.
+	if _, err := ptypes.Timestamp(r.Time); err != nil {
+		return errors.Wrap(err, "invalid record time")
+	}
+
+	// Ensure that the level is valid.
+	if _, ok := logging.NameToLevel(r.Level); !ok {
+		return errors.Errorf("invalid log level: %s", r.Level)
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that a LogsResponse is valid.
+func (r *LogsResponse) EnsureValid() error {
+	// A nil logs response is not valid.
+	if r == nil {
+		return errors.New("nil logs response")
+	}
+
+	// Ensure that all records are valid.
+	for _, record := range r.Records {
+		if err := record.EnsureValid(); err != nil {
+			return errors.Wrap(err, "invalid log record")
+		}
+	}
+
+	// Success.
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 	math "math"
 )
@@ -180,33 +181,209 @@ func (m *TerminateResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_TerminateResponse proto.InternalMessageInfo
 
+type LogsRequest struct {
+	// Sessions restricts records to those generated by the loggers of the
+	// specified sessions (by identifier). If empty, all records are included.
+	Sessions []string `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	// Level is the most verbose log level to include. If empty, all levels
+	// are included.
+	Level string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	// Follow indicates whether or not newly logged records should be streamed
+	// after existing records have been sent.
+	Follow               bool     `protobuf:"varint,3,opt,name=follow,proto3" json:"follow,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogsRequest) Reset()         { *m = LogsRequest{} }
+func (m *LogsRequest) String() string { return proto.CompactTextString(m) }
+func (*LogsRequest) ProtoMessage()    {}
+func (*LogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_75ea5f9af01261a0, []int{4}
+}
+
+func (m *LogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogsRequest.Unmarshal(m, b)
+}
+func (m *LogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogsRequest.Marshal(b, m, deterministic)
+}
+func (m *LogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogsRequest.Merge(m, src)
+}
+func (m *LogsRequest) XXX_Size() int {
+	return xxx_messageInfo_LogsRequest.Size(m)
+}
+func (m *LogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogsRequest proto.InternalMessageInfo
+
+func (m *LogsRequest) GetSessions() []string {
+	if m != nil {
+		return m.Sessions
+	}
+	return nil
+}
+
+func (m *LogsRequest) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *LogsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+type LogRecord struct {
+	Time                 *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Level                string               `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	Logger               string               `protobuf:"bytes,3,opt,name=logger,proto3" json:"logger,omitempty"`
+	Message              string               `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *LogRecord) Reset()         { *m = LogRecord{} }
+func (m *LogRecord) String() string { return proto.CompactTextString(m) }
+func (*LogRecord) ProtoMessage()    {}
+func (*LogRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_75ea5f9af01261a0, []int{5}
+}
+
+func (m *LogRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogRecord.Unmarshal(m, b)
+}
+func (m *LogRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogRecord.Marshal(b, m, deterministic)
+}
+func (m *LogRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogRecord.Merge(m, src)
+}
+func (m *LogRecord) XXX_Size() int {
+	return xxx_messageInfo_LogRecord.Size(m)
+}
+func (m *LogRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogRecord proto.InternalMessageInfo
+
+func (m *LogRecord) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *LogRecord) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *LogRecord) GetLogger() string {
+	if m != nil {
+		return m.Logger
+	}
+	return ""
+}
+
+func (m *LogRecord) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type LogsResponse struct {
+	Records              []*LogRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *LogsResponse) Reset()         { *m = LogsResponse{} }
+func (m *LogsResponse) String() string { return proto.CompactTextString(m) }
+func (*LogsResponse) ProtoMessage()    {}
+func (*LogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_75ea5f9af01261a0, []int{6}
+}
+
+func (m *LogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogsResponse.Unmarshal(m, b)
+}
+func (m *LogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogsResponse.Marshal(b, m, deterministic)
+}
+func (m *LogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogsResponse.Merge(m, src)
+}
+func (m *LogsResponse) XXX_Size() int {
+	return xxx_messageInfo_LogsResponse.Size(m)
+}
+func (m *LogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogsResponse proto.InternalMessageInfo
+
+func (m *LogsResponse) GetRecords() []*LogRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*VersionRequest)(nil), "daemon.VersionRequest")
 	proto.RegisterType((*VersionResponse)(nil), "daemon.VersionResponse")
 	proto.RegisterType((*TerminateRequest)(nil), "daemon.TerminateRequest")
 	proto.RegisterType((*TerminateResponse)(nil), "daemon.TerminateResponse")
+	proto.RegisterType((*LogsRequest)(nil), "daemon.LogsRequest")
+	proto.RegisterType((*LogRecord)(nil), "daemon.LogRecord")
+	proto.RegisterType((*LogsResponse)(nil), "daemon.LogsResponse")
 }
 
 func init() { proto.RegisterFile("service/daemon/daemon.proto", fileDescriptor_75ea5f9af01261a0) }
 
 var fileDescriptor_75ea5f9af01261a0 = []byte{
-	// 245 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0x31, 0x4f, 0xc3, 0x30,
-	0x10, 0x85, 0x09, 0x2d, 0x41, 0xbd, 0x01, 0xc2, 0x81, 0xc0, 0x94, 0xa5, 0xca, 0xd4, 0x85, 0x04,
-	0x95, 0x95, 0xa9, 0xe2, 0x17, 0x44, 0x88, 0x81, 0xcd, 0x0d, 0xa7, 0xd4, 0x20, 0xfb, 0x82, 0xed,
-	0xf0, 0x23, 0xf8, 0xd5, 0x28, 0x71, 0x5c, 0x51, 0x60, 0xf2, 0xbd, 0xcf, 0xd6, 0xbb, 0xe7, 0x07,
-	0x37, 0x8e, 0xec, 0xa7, 0xaa, 0xa9, 0x7c, 0x95, 0xa4, 0xd9, 0x8c, 0x47, 0xd1, 0x5a, 0xf6, 0x8c,
-	0x69, 0x50, 0x79, 0x06, 0x27, 0xcf, 0x64, 0x9d, 0x62, 0x53, 0xd1, 0x47, 0x47, 0xce, 0xe7, 0x04,
-	0xa7, 0x3b, 0xe2, 0x5a, 0x36, 0x8e, 0xf0, 0x02, 0x8e, 0xb4, 0x7c, 0x63, 0x2b, 0x92, 0x45, 0xb2,
-	0x9c, 0x56, 0x41, 0x0c, 0x54, 0x19, 0xb6, 0xe2, 0x70, 0xa4, 0xbd, 0xe8, 0x69, 0x2b, 0x7d, 0xbd,
-	0x15, 0x93, 0x40, 0x07, 0x81, 0x19, 0x4c, 0xbc, 0x6c, 0xc4, 0x74, 0x91, 0x2c, 0x67, 0x55, 0x3f,
-	0xe6, 0x08, 0xd9, 0x13, 0x59, 0xad, 0x8c, 0xf4, 0x14, 0x57, 0x9f, 0xc3, 0xd9, 0x0f, 0x16, 0x96,
-	0xaf, 0xbe, 0x12, 0x48, 0x1f, 0x87, 0xb0, 0xf8, 0x00, 0xc7, 0x63, 0x34, 0xbc, 0x2c, 0xc6, 0xef,
-	0xec, 0xa7, 0x9f, 0x5f, 0xfd, 0xe1, 0xc1, 0x26, 0x3f, 0xc0, 0x35, 0xcc, 0x76, 0xee, 0x28, 0xe2,
-	0xbb, 0xdf, 0x21, 0xe6, 0xd7, 0xff, 0xdc, 0x44, 0x8f, 0xf5, 0xea, 0xe5, 0xae, 0x51, 0x7e, 0xdb,
-	0x6d, 0x8a, 0x9a, 0x75, 0xa9, 0x3b, 0x2f, 0x1b, 0x32, 0xb7, 0x8a, 0xe3, 0x58, 0xb6, 0xef, 0x4d,
-	0xb9, 0xdf, 0xfb, 0x26, 0x1d, 0x1a, 0xbf, 0xff, 0x0e, 0x00, 0x00, 0xff, 0xff, 0xe8, 0x0f, 0xca,
-	0xa0, 0x90, 0x01, 0x00, 0x00,
+	// 409 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0x4d, 0x6f, 0xd3, 0x40,
+	0x14, 0xc4, 0x24, 0x24, 0xf5, 0x0b, 0x82, 0x74, 0x5b, 0x15, 0x63, 0x0e, 0x44, 0x3e, 0x45, 0x42,
+	0xd8, 0x55, 0x10, 0x27, 0x38, 0x55, 0x1c, 0x7b, 0x5a, 0x55, 0x20, 0x71, 0xdb, 0xa4, 0xaf, 0xdb,
+	0x05, 0xaf, 0x9f, 0xd9, 0xdd, 0x94, 0x1f, 0xc0, 0x2f, 0xe3, 0x9f, 0x21, 0xef, 0x87, 0xd5, 0x00,
+	0x3d, 0x65, 0x67, 0xf6, 0x6d, 0x66, 0xde, 0x8c, 0xe1, 0x95, 0x45, 0x73, 0xa7, 0x76, 0xd8, 0x5c,
+	0x0b, 0xd4, 0xd4, 0xc5, 0x9f, 0xba, 0x37, 0xe4, 0x88, 0xcd, 0x02, 0x2a, 0x5f, 0x4b, 0x22, 0xd9,
+	0x62, 0xe3, 0xd9, 0xed, 0xfe, 0xa6, 0x71, 0x4a, 0xa3, 0x75, 0x42, 0xf7, 0x61, 0xb0, 0x5a, 0xc2,
+	0xb3, 0xcf, 0x68, 0xac, 0xa2, 0x8e, 0xe3, 0x8f, 0x3d, 0x5a, 0x57, 0x21, 0x3c, 0x1f, 0x19, 0xdb,
+	0x53, 0x67, 0x91, 0x9d, 0xc2, 0x13, 0x2d, 0xbe, 0x91, 0x29, 0xb2, 0x55, 0xb6, 0x9e, 0xf2, 0x00,
+	0x3c, 0xab, 0x3a, 0x32, 0xc5, 0xe3, 0xc8, 0xaa, 0x2e, 0xb0, 0xbd, 0x70, 0xbb, 0xdb, 0x62, 0x12,
+	0x58, 0x0f, 0xd8, 0x12, 0x26, 0x4e, 0xc8, 0x62, 0xba, 0xca, 0xd6, 0x39, 0x1f, 0x8e, 0x15, 0x83,
+	0xe5, 0x15, 0x1a, 0xad, 0x3a, 0xe1, 0x30, 0x49, 0x9f, 0xc0, 0xf1, 0x3d, 0x2e, 0x88, 0x57, 0x5f,
+	0x60, 0x71, 0x49, 0xd2, 0xc6, 0x19, 0x56, 0xc2, 0x91, 0x45, 0x3b, 0xd8, 0xb3, 0x45, 0xb6, 0x9a,
+	0xac, 0x73, 0x3e, 0xe2, 0x41, 0xbb, 0xc5, 0x3b, 0x6c, 0xbd, 0xa3, 0x9c, 0x07, 0xc0, 0xce, 0x60,
+	0x76, 0x43, 0x6d, 0x4b, 0x3f, 0xbd, 0xa5, 0x23, 0x1e, 0x51, 0xf5, 0x2b, 0x83, 0xfc, 0x92, 0x24,
+	0xc7, 0x1d, 0x99, 0x6b, 0x56, 0xc3, 0x74, 0xc8, 0xc6, 0xaf, 0xb8, 0xd8, 0x94, 0x75, 0x08, 0xae,
+	0x4e, 0xc1, 0xd5, 0x57, 0x29, 0x38, 0xee, 0xe7, 0x1e, 0xd6, 0x6a, 0x49, 0x4a, 0x34, 0x5e, 0x2b,
+	0xe7, 0x11, 0xb1, 0x02, 0xe6, 0x1a, 0xad, 0x15, 0x12, 0x63, 0x06, 0x09, 0x56, 0x1f, 0xe0, 0x69,
+	0x58, 0x2f, 0x66, 0xfd, 0x06, 0xe6, 0xc6, 0x3b, 0x0a, 0xeb, 0x2d, 0x36, 0xc7, 0x75, 0x6c, 0x76,
+	0xf4, 0xca, 0xd3, 0xc4, 0xe6, 0x77, 0x06, 0xb3, 0x4f, 0xfe, 0x96, 0x7d, 0x84, 0x79, 0xac, 0x8d,
+	0x9d, 0xa5, 0x17, 0x87, 0xcd, 0x96, 0x2f, 0xfe, 0xe1, 0x63, 0xc4, 0x8f, 0xd8, 0x05, 0xe4, 0x63,
+	0xf2, 0xac, 0x48, 0x73, 0x7f, 0x17, 0x54, 0xbe, 0xfc, 0xcf, 0xcd, 0xf8, 0x1f, 0xef, 0x61, 0x3a,
+	0x6c, 0xc2, 0x4e, 0xee, 0x19, 0x4e, 0xb5, 0x95, 0xa7, 0x87, 0x64, 0x7a, 0x74, 0x9e, 0x5d, 0x6c,
+	0xbe, 0x9e, 0x4b, 0xe5, 0x6e, 0xf7, 0xdb, 0x7a, 0x47, 0xba, 0xd1, 0x7b, 0x27, 0x24, 0x76, 0x6f,
+	0x15, 0xa5, 0x63, 0xd3, 0x7f, 0x97, 0xcd, 0xe1, 0xb7, 0xbe, 0x9d, 0xf9, 0x5a, 0xde, 0xfd, 0x19,
+	0x00, 0x30, 0x92, 0xc4, 0x3d, 0x04, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -223,6 +400,7 @@ const _ = grpc.SupportPackageIsVersion4
 type DaemonClient interface {
 	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
 	Terminate(ctx context.Context, in *TerminateRequest, opts ...grpc.CallOption) (*TerminateResponse, error)
+	Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (Daemon_LogsClient, error)
 }
 
 type daemonClient struct {
@@ -251,10 +429,43 @@ func (c *daemonClient) Terminate(ctx context.Context, in *TerminateRequest, opts
 	return out, nil
 }
 
+func (c *daemonClient) Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (Daemon_LogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Daemon_serviceDesc.Streams[0], "/daemon.Daemon/Logs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daemonLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Daemon_LogsClient interface {
+	Recv() (*LogsResponse, error)
+	grpc.ClientStream
+}
+
+type daemonLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *daemonLogsClient) Recv() (*LogsResponse, error) {
+	m := new(LogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DaemonServer is the server API for Daemon service.
 type DaemonServer interface {
 	Version(context.Context, *VersionRequest) (*VersionResponse, error)
 	Terminate(context.Context, *TerminateRequest) (*TerminateResponse, error)
+	Logs(*LogsRequest, Daemon_LogsServer) error
 }
 
 func RegisterDaemonServer(s *grpc.Server, srv DaemonServer) {
@@ -297,6 +508,27 @@ func _Daemon_Terminate_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Daemon_Logs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).Logs(m, &daemonLogsServer{stream})
+}
+
+type Daemon_LogsServer interface {
+	Send(*LogsResponse) error
+	grpc.ServerStream
+}
+
+type daemonLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *daemonLogsServer) Send(m *LogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Daemon_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "daemon.Daemon",
 	HandlerType: (*DaemonServer)(nil),
@@ -310,6 +542,12 @@ var _Daemon_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Daemon_Terminate_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Logs",
+			Handler:       _Daemon_Logs_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "service/daemon/daemon.proto",
 }
@@ -0,0 +1,87 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+// TestLogsRequestEnsureValid tests LogsRequest.ensureValid.
+func TestLogsRequestEnsureValid(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		request       *LogsRequest
+		expectFailure bool
+	}{
+		{nil, true},
+		{&LogsRequest{}, false},
+		{&LogsRequest{Follow: true}, false},
+		{&LogsRequest{Sessions: []string{"identifier"}, Level: "debug"}, false},
+		{&LogsRequest{Sessions: []string{"identifier", ""}}, true},
+		{&LogsRequest{Level: "verbose"}, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		err := testCase.request.ensureValid()
+		if err != nil && !testCase.expectFailure {
+			t.Errorf("test index %d: request incorrectly classified as invalid: %v", i, err)
+		} else if err == nil && testCase.expectFailure {
+			t.Errorf("test index %d: request incorrectly classified as valid", i)
+		}
+	}
+}
+
+// TestLogRecordEnsureValid tests LogRecord.EnsureValid.
+func TestLogRecordEnsureValid(t *testing.T) {
+	// Create a valid timestamp.
+	now, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		t.Fatal("unable to create timestamp:", err)
+	}
+
+	// Define test cases.
+	testCases := []struct {
+		record        *LogRecord
+		expectFailure bool
+	}{
+		{nil, true},
+		{&LogRecord{Time: now, Level: "info"}, false},
+		{&LogRecord{Time: now, Level: "trace", Logger: "sync", Message: "message"}, false},
+		{&LogRecord{Level: "info"}, true},
+		{&LogRecord{Time: &timestamp.Timestamp{Nanos: -1}, Level: "info"}, true},
+		{&LogRecord{Time: now}, true},
+		{&LogRecord{Time: now, Level: "verbose"}, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		err := testCase.record.EnsureValid()
+		if err != nil && !testCase.expectFailure {
+			t.Errorf("test index %d: record incorrectly classified as invalid: %v", i, err)
+		} else if err == nil && testCase.expectFailure {
+			t.Errorf("test index %d: record incorrectly classified as valid", i)
+		}
+	}
+}
+
+// TestNewLogRecord tests that converted log records are valid.
+func TestNewLogRecord(t *testing.T) {
+	converted, err := newLogRecord(&logging.Record{
+		Time:    time.Now(),
+		Level:   logging.LevelWarn,
+		Logger:  "sync",
+		Message: "message",
+	})
+	if err != nil {
+		t.Fatal("unable to convert record:", err)
+	} else if err = converted.EnsureValid(); err != nil {
+		t.Error("converted record invalid:", err)
+	} else if converted.Level != "warn" {
+		t.Error("converted record level mismatch:", converted.Level)
+	}
+}
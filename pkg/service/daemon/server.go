@@ -4,7 +4,10 @@ import (
 	"context"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/mutagen-io/mutagen/pkg/housekeeping"
+	"github.com/mutagen-io/mutagen/pkg/logging"
 	"github.com/mutagen-io/mutagen/pkg/mutagen"
 )
 
@@ -12,6 +15,12 @@ const (
 	// housekeepingInterval is the interval at which housekeeping will be
 	// invoked by the daemon.
 	housekeepingInterval = 24 * time.Hour
+	// logsBatchSize is the maximum number of historical log records to send in
+	// a single logs response.
+	logsBatchSize = 100
+	// logsSubscriptionBufferSize is the buffer size to use for log record
+	// subscriptions when following logs.
+	logsSubscriptionBufferSize = 1000
 )
 
 // Server provides an implementation of the Daemon service.
@@ -98,3 +107,104 @@ func (s *Server) Terminate(_ context.Context, _ *TerminateRequest) (*TerminateRe
 	// Success.
 	return &TerminateResponse{}, nil
 }
+
+// Logs streams daemon log records. Records from the daemon log file are sent
+// first, followed (if requested) by newly logged records until the client
+// disconnects or the daemon shuts down. If file logging is disabled, then
+// there's no history, but newly logged records can still be followed.
+func (s *Server) Logs(request *LogsRequest, stream Daemon_LogsServer) error {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return errors.Wrap(err, "received invalid logs request")
+	}
+
+	// Determine the most verbose level to include.
+	level := logging.LevelTrace
+	if request.Level != "" {
+		level, _ = logging.NameToLevel(request.Level)
+	}
+
+	// Create a record filter.
+	include := func(record *logging.Record) bool {
+		if record.Level > level {
+			return false
+		} else if len(request.Sessions) == 0 {
+			return true
+		}
+		for _, session := range request.Sessions {
+			if record.MatchesSession(session) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// If following, subscribe to new records before reading the log history
+	// so that we don't miss any records logged in between.
+	var records <-chan *logging.Record
+	if request.Follow {
+		var cancel func()
+		records, cancel = logging.Subscribe(logsSubscriptionBufferSize)
+		defer cancel()
+	}
+
+	// Read the log history, tracking the sequence number of the last record
+	// that it includes. If we're following, then a lack of history is fine.
+	history, last, err := logging.History()
+	if err == logging.ErrFileLoggingDisabled && request.Follow {
+		history = nil
+	} else if err != nil {
+		return errors.Wrap(err, "unable to read log history")
+	}
+
+	// Send the log history in batches.
+	batch := make([]*LogRecord, 0, logsBatchSize)
+	for _, record := range history {
+		if !include(record) {
+			continue
+		}
+		converted, err := newLogRecord(record)
+		if err != nil {
+			return err
+		}
+		batch = append(batch, converted)
+		if len(batch) == logsBatchSize {
+			if err := stream.Send(&LogsResponse{Records: batch}); err != nil {
+				return errors.Wrap(err, "unable to send response")
+			}
+			batch = make([]*LogRecord, 0, logsBatchSize)
+		}
+	}
+	if len(batch) > 0 {
+		if err := stream.Send(&LogsResponse{Records: batch}); err != nil {
+			return errors.Wrap(err, "unable to send response")
+		}
+	}
+
+	// If we're not following, then we're done.
+	if !request.Follow {
+		return nil
+	}
+
+	// Stream new records until the client disconnects or the daemon shuts
+	// down. We skip records that were already included in the log history.
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.workerContext.Done():
+			return errors.New("daemon shutting down")
+		case record := <-records:
+			if record.Sequence <= last || !include(record) {
+				continue
+			}
+			converted, err := newLogRecord(record)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(&LogsResponse{Records: []*LogRecord{converted}}); err != nil {
+				return errors.Wrap(err, "unable to send response")
+			}
+		}
+	}
+}
@@ -1,14 +1,17 @@
 package forwarding
 
 import (
+	"path/filepath"
+
 	"github.com/pkg/errors"
 
 	"github.com/mutagen-io/mutagen/pkg/selection"
 	"github.com/mutagen-io/mutagen/pkg/url"
+	forwardingurl "github.com/mutagen-io/mutagen/pkg/url/forwarding"
 )
 
-// ensureValid verifies that a CreationSpecification is valid.
-func (s *CreationSpecification) ensureValid() error {
+// EnsureValid verifies that a CreationSpecification is valid.
+func (s *CreationSpecification) EnsureValid() error {
 	// A nil creation specification is not valid.
 	if s == nil {
 		return errors.New("nil creation specification")
@@ -19,6 +22,10 @@ func (s *CreationSpecification) ensureValid() error {
 		return errors.Wrap(err, "invalid source URL")
 	} else if s.Source.Kind != url.Kind_Forwarding {
 		return errors.New("source URL is not a forwarding URL")
+	} else if protocol, _, err := forwardingurl.Parse(s.Source.Path); err != nil {
+		return errors.Wrap(err, "invalid source forwarding endpoint URL")
+	} else if !forwardingurl.SupportsListening(protocol) {
+		return errors.Errorf("source URL protocol (%s) can't be used for listening", protocol)
 	}
 
 	// Verify that the destination URL is valid and is a forwarding URL.
@@ -74,7 +81,7 @@ func (r *CreateRequest) ensureValid(first bool) error {
 	// the stream.
 	if first {
 		// Verify that the creation specification is valid.
-		if err := r.Specification.ensureValid(); err != nil {
+		if err := r.Specification.EnsureValid(); err != nil {
 			return err
 		}
 
@@ -161,6 +168,40 @@ func (r *ListResponse) EnsureValid() error {
 	return nil
 }
 
+// ensureValid verifies that a WatchRequest is valid.
+func (r *WatchRequest) ensureValid() error {
+	// A nil watch request is not valid.
+	if r == nil {
+		return errors.New("nil watch request")
+	}
+
+	// Validate the session specification.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid session specification")
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that a WatchResponse is valid.
+func (r *WatchResponse) EnsureValid() error {
+	// A nil watch response is not valid.
+	if r == nil {
+		return errors.New("nil watch response")
+	}
+
+	// Ensure that all events are valid.
+	for _, e := range r.Events {
+		if err := e.EnsureValid(); err != nil {
+			return errors.Wrap(err, "invalid event")
+		}
+	}
+
+	// Success.
+	return nil
+}
+
 // ensureValid verifies that a PauseRequest is valid.
 func (r *PauseRequest) ensureValid(first bool) error {
 	// A nil pause request is not valid.
@@ -263,6 +304,145 @@ func (r *ResumeResponse) EnsureValid() error {
 	return nil
 }
 
+// EnsureValid verifies that an UpdateSpecification is valid.
+func (s *UpdateSpecification) EnsureValid() error {
+	// A nil update specification is not valid.
+	if s == nil {
+		return errors.New("nil update specification")
+	}
+
+	// Verify that a session has been specified.
+	if s.Session == "" {
+		return errors.New("empty session specification")
+	}
+
+	// Verify that the configuration is valid.
+	if err := s.Configuration.EnsureValid(false); err != nil {
+		return errors.Wrap(err, "invalid session configuration")
+	}
+
+	// Verify that the source-specific configuration is valid.
+	if err := s.ConfigurationSource.EnsureValid(true); err != nil {
+		return errors.Wrap(err, "invalid source-specific configuration")
+	}
+
+	// Verify that the destination-specific configuration is valid.
+	if err := s.ConfigurationDestination.EnsureValid(true); err != nil {
+		return errors.Wrap(err, "invalid destination-specific configuration")
+	}
+
+	// Verify that the name is valid.
+	if err := selection.EnsureNameValid(s.Name); err != nil {
+		return errors.Wrap(err, "invalid name")
+	}
+
+	// Verify that labels are valid.
+	for k, v := range s.Labels {
+		if err := selection.EnsureLabelKeyValid(k); err != nil {
+			return errors.Wrap(err, "invalid label key")
+		} else if err = selection.EnsureLabelValueValid(v); err != nil {
+			return errors.Wrap(err, "invalid label value")
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid verifies that an UpdateRequest is valid.
+func (r *UpdateRequest) ensureValid(first bool) error {
+	// A nil update request is not valid.
+	if r == nil {
+		return errors.New("nil update request")
+	}
+
+	// Handle validation based on whether or not this is the first request in
+	// the stream.
+	if first {
+		// Verify that the update specification is valid.
+		if err := r.Specification.EnsureValid(); err != nil {
+			return err
+		}
+
+		// Verify that the response field is empty.
+		if r.Response != "" {
+			return errors.New("non-empty prompt response")
+		}
+	} else {
+		// Verify that the update specification is nil.
+		if r.Specification != nil {
+			return errors.New("update specification present")
+		}
+
+		// We can't really validate the response field, and an empty value may
+		// be appropriate. It's up to the process performing the prompting to
+		// decide.
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that an UpdateResponse is valid.
+func (r *UpdateResponse) EnsureValid() error {
+	// A nil update response is not valid.
+	if r == nil {
+		return errors.New("nil update response")
+	}
+
+	// Count the number of fields that are set.
+	var fieldsSet uint
+	if r.Message != "" {
+		fieldsSet++
+	}
+	if r.Prompt != "" {
+		fieldsSet++
+	}
+
+	// Enforce that at most a single field is set. If neither is set, then
+	// this indicates completion.
+	if fieldsSet > 1 {
+		return errors.New("multiple fields set")
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid verifies that an ExportRequest is valid.
+func (r *ExportRequest) ensureValid() error {
+	// A nil export request is not valid.
+	if r == nil {
+		return errors.New("nil export request")
+	}
+
+	// Validate the session specification.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid session specification")
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that an ExportResponse is valid.
+func (r *ExportResponse) EnsureValid() error {
+	// A nil export response is not valid.
+	if r == nil {
+		return errors.New("nil export response")
+	}
+
+	// Ensure that all specifications are valid.
+	for _, s := range r.Specifications {
+		if err := s.EnsureValid(); err != nil {
+			return errors.Wrap(err, "invalid creation specification")
+		}
+	}
+
+	// Success.
+	return nil
+}
+
 // ensureValid verifies that a TerminateRequest is valid.
 func (r *TerminateRequest) ensureValid(first bool) error {
 	// A nil terminate request is not valid.
@@ -305,3 +485,106 @@ func (r *TerminateResponse) EnsureValid() error {
 	// Success.
 	return nil
 }
+
+// ensureValid verifies that a RequestLogRequest is valid.
+func (r *RequestLogRequest) ensureValid() error {
+	// A nil request log request is not valid.
+	if r == nil {
+		return errors.New("nil request log request")
+	}
+
+	// Validate the session selection specification.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid session selection specification")
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that a RequestLogResponse is valid.
+func (r *RequestLogResponse) EnsureValid() error {
+	// A nil request log response is not valid.
+	if r == nil {
+		return errors.New("nil request log response")
+	}
+
+	// Ensure that all logs are non-nil and identify their session.
+	for _, l := range r.Logs {
+		if l == nil {
+			return errors.New("nil request log")
+		} else if l.Session == "" {
+			return errors.New("request log missing session identifier")
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid verifies that a StartCaptureRequest is valid.
+func (r *StartCaptureRequest) ensureValid() error {
+	// A nil start capture request is not valid.
+	if r == nil {
+		return errors.New("nil start capture request")
+	}
+
+	// Validate the session selection specification.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid session selection specification")
+	}
+
+	// Verify that the format is supported (or default).
+	if !(r.Format.IsDefault() || r.Format.Supported()) {
+		return errors.New("unsupported capture format")
+	}
+
+	// Verify that the path is absolute, since it will be interpreted by the
+	// daemon rather than the client.
+	if !filepath.IsAbs(r.Path) {
+		return errors.New("capture path is not absolute")
+	}
+
+	// There's no need to validate the connection limit - any value is valid.
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that a StartCaptureResponse is valid.
+func (r *StartCaptureResponse) EnsureValid() error {
+	// A nil start capture response is not valid.
+	if r == nil {
+		return errors.New("nil start capture response")
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid verifies that a StopCaptureRequest is valid.
+func (r *StopCaptureRequest) ensureValid() error {
+	// A nil stop capture request is not valid.
+	if r == nil {
+		return errors.New("nil stop capture request")
+	}
+
+	// Validate the session selection specification.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid session selection specification")
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that a StopCaptureResponse is valid.
+func (r *StopCaptureResponse) EnsureValid() error {
+	// A nil stop capture response is not valid.
+	if r == nil {
+		return errors.New("nil stop capture response")
+	}
+
+	// Success.
+	return nil
+}
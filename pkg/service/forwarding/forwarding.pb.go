@@ -330,6 +330,84 @@ func (m *ListResponse) GetSessionStates() []*forwarding.State {
 	return nil
 }
 
+type WatchRequest struct {
+	Selection            *selection.Selection `protobuf:"bytes,1,opt,name=selection,proto3" json:"selection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3507425a8852e9f1, []int{5}
+}
+
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchRequest.Unmarshal(m, b)
+}
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+func (m *WatchRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchRequest.Size(m)
+}
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+func (m *WatchRequest) GetSelection() *selection.Selection {
+	if m != nil {
+		return m.Selection
+	}
+	return nil
+}
+
+type WatchResponse struct {
+	Events               []*forwarding.Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *WatchResponse) Reset()         { *m = WatchResponse{} }
+func (m *WatchResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchResponse) ProtoMessage()    {}
+func (*WatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3507425a8852e9f1, []int{6}
+}
+
+func (m *WatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchResponse.Unmarshal(m, b)
+}
+func (m *WatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchResponse.Merge(m, src)
+}
+func (m *WatchResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchResponse.Size(m)
+}
+func (m *WatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchResponse proto.InternalMessageInfo
+
+func (m *WatchResponse) GetEvents() []*forwarding.Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
 type PauseRequest struct {
 	Selection            *selection.Selection `protobuf:"bytes,1,opt,name=selection,proto3" json:"selection,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
@@ -341,7 +419,7 @@ func (m *PauseRequest) Reset()         { *m = PauseRequest{} }
 func (m *PauseRequest) String() string { return proto.CompactTextString(m) }
 func (*PauseRequest) ProtoMessage()    {}
 func (*PauseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3507425a8852e9f1, []int{5}
+	return fileDescriptor_3507425a8852e9f1, []int{7}
 }
 
 func (m *PauseRequest) XXX_Unmarshal(b []byte) error {
@@ -380,7 +458,7 @@ func (m *PauseResponse) Reset()         { *m = PauseResponse{} }
 func (m *PauseResponse) String() string { return proto.CompactTextString(m) }
 func (*PauseResponse) ProtoMessage()    {}
 func (*PauseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3507425a8852e9f1, []int{6}
+	return fileDescriptor_3507425a8852e9f1, []int{8}
 }
 
 func (m *PauseResponse) XXX_Unmarshal(b []byte) error {
@@ -420,7 +498,7 @@ func (m *ResumeRequest) Reset()         { *m = ResumeRequest{} }
 func (m *ResumeRequest) String() string { return proto.CompactTextString(m) }
 func (*ResumeRequest) ProtoMessage()    {}
 func (*ResumeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3507425a8852e9f1, []int{7}
+	return fileDescriptor_3507425a8852e9f1, []int{9}
 }
 
 func (m *ResumeRequest) XXX_Unmarshal(b []byte) error {
@@ -467,7 +545,7 @@ func (m *ResumeResponse) Reset()         { *m = ResumeResponse{} }
 func (m *ResumeResponse) String() string { return proto.CompactTextString(m) }
 func (*ResumeResponse) ProtoMessage()    {}
 func (*ResumeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3507425a8852e9f1, []int{8}
+	return fileDescriptor_3507425a8852e9f1, []int{10}
 }
 
 func (m *ResumeResponse) XXX_Unmarshal(b []byte) error {
@@ -502,6 +580,265 @@ func (m *ResumeResponse) GetPrompt() string {
 	return ""
 }
 
+type UpdateSpecification struct {
+	// Session is the specification (identifier or name) of the session to
+	// update. It must match exactly one session.
+	Session string `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	// Configuration is the new base session configuration.
+	Configuration *forwarding.Configuration `protobuf:"bytes,2,opt,name=configuration,proto3" json:"configuration,omitempty"`
+	// ConfigurationSource is the new source-specific session configuration.
+	ConfigurationSource *forwarding.Configuration `protobuf:"bytes,3,opt,name=configurationSource,proto3" json:"configurationSource,omitempty"`
+	// ConfigurationDestination is the new destination-specific session
+	// configuration.
+	ConfigurationDestination *forwarding.Configuration `protobuf:"bytes,4,opt,name=configurationDestination,proto3" json:"configurationDestination,omitempty"`
+	// Name is the new name for the session object.
+	Name string `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	// Labels are the new labels for the session object.
+	Labels               map[string]string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *UpdateSpecification) Reset()         { *m = UpdateSpecification{} }
+func (m *UpdateSpecification) String() string { return proto.CompactTextString(m) }
+func (*UpdateSpecification) ProtoMessage()    {}
+func (*UpdateSpecification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3507425a8852e9f1, []int{11}
+}
+
+func (m *UpdateSpecification) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateSpecification.Unmarshal(m, b)
+}
+func (m *UpdateSpecification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateSpecification.Marshal(b, m, deterministic)
+}
+func (m *UpdateSpecification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateSpecification.Merge(m, src)
+}
+func (m *UpdateSpecification) XXX_Size() int {
+	return xxx_messageInfo_UpdateSpecification.Size(m)
+}
+func (m *UpdateSpecification) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateSpecification.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateSpecification proto.InternalMessageInfo
+
+func (m *UpdateSpecification) GetSession() string {
+	if m != nil {
+		return m.Session
+	}
+	return ""
+}
+
+func (m *UpdateSpecification) GetConfiguration() *forwarding.Configuration {
+	if m != nil {
+		return m.Configuration
+	}
+	return nil
+}
+
+func (m *UpdateSpecification) GetConfigurationSource() *forwarding.Configuration {
+	if m != nil {
+		return m.ConfigurationSource
+	}
+	return nil
+}
+
+func (m *UpdateSpecification) GetConfigurationDestination() *forwarding.Configuration {
+	if m != nil {
+		return m.ConfigurationDestination
+	}
+	return nil
+}
+
+func (m *UpdateSpecification) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateSpecification) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type UpdateRequest struct {
+	Specification        *UpdateSpecification `protobuf:"bytes,1,opt,name=specification,proto3" json:"specification,omitempty"`
+	Response             string               `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
+func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateRequest) ProtoMessage()    {}
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3507425a8852e9f1, []int{12}
+}
+
+func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
+}
+func (m *UpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRequest.Merge(m, src)
+}
+func (m *UpdateRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateRequest.Size(m)
+}
+func (m *UpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRequest proto.InternalMessageInfo
+
+func (m *UpdateRequest) GetSpecification() *UpdateSpecification {
+	if m != nil {
+		return m.Specification
+	}
+	return nil
+}
+
+func (m *UpdateRequest) GetResponse() string {
+	if m != nil {
+		return m.Response
+	}
+	return ""
+}
+
+type UpdateResponse struct {
+	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Prompt               string   `protobuf:"bytes,2,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateResponse) Reset()         { *m = UpdateResponse{} }
+func (m *UpdateResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateResponse) ProtoMessage()    {}
+func (*UpdateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3507425a8852e9f1, []int{13}
+}
+
+func (m *UpdateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateResponse.Unmarshal(m, b)
+}
+func (m *UpdateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateResponse.Merge(m, src)
+}
+func (m *UpdateResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateResponse.Size(m)
+}
+func (m *UpdateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateResponse proto.InternalMessageInfo
+
+func (m *UpdateResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *UpdateResponse) GetPrompt() string {
+	if m != nil {
+		return m.Prompt
+	}
+	return ""
+}
+
+type ExportRequest struct {
+	Selection            *selection.Selection `protobuf:"bytes,1,opt,name=selection,proto3" json:"selection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ExportRequest) Reset()         { *m = ExportRequest{} }
+func (m *ExportRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportRequest) ProtoMessage()    {}
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3507425a8852e9f1, []int{14}
+}
+
+func (m *ExportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportRequest.Unmarshal(m, b)
+}
+func (m *ExportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportRequest.Merge(m, src)
+}
+func (m *ExportRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportRequest.Size(m)
+}
+func (m *ExportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportRequest proto.InternalMessageInfo
+
+func (m *ExportRequest) GetSelection() *selection.Selection {
+	if m != nil {
+		return m.Selection
+	}
+	return nil
+}
+
+type ExportResponse struct {
+	Specifications       []*CreationSpecification `protobuf:"bytes,1,rep,name=specifications,proto3" json:"specifications,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *ExportResponse) Reset()         { *m = ExportResponse{} }
+func (m *ExportResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportResponse) ProtoMessage()    {}
+func (*ExportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3507425a8852e9f1, []int{15}
+}
+
+func (m *ExportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportResponse.Unmarshal(m, b)
+}
+func (m *ExportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportResponse.Marshal(b, m, deterministic)
+}
+func (m *ExportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportResponse.Merge(m, src)
+}
+func (m *ExportResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportResponse.Size(m)
+}
+func (m *ExportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportResponse proto.InternalMessageInfo
+
+func (m *ExportResponse) GetSpecifications() []*CreationSpecification {
+	if m != nil {
+		return m.Specifications
+	}
+	return nil
+}
+
 type TerminateRequest struct {
 	Selection            *selection.Selection `protobuf:"bytes,1,opt,name=selection,proto3" json:"selection,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
@@ -513,7 +850,7 @@ func (m *TerminateRequest) Reset()         { *m = TerminateRequest{} }
 func (m *TerminateRequest) String() string { return proto.CompactTextString(m) }
 func (*TerminateRequest) ProtoMessage()    {}
 func (*TerminateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3507425a8852e9f1, []int{9}
+	return fileDescriptor_3507425a8852e9f1, []int{16}
 }
 
 func (m *TerminateRequest) XXX_Unmarshal(b []byte) error {
@@ -552,7 +889,7 @@ func (m *TerminateResponse) Reset()         { *m = TerminateResponse{} }
 func (m *TerminateResponse) String() string { return proto.CompactTextString(m) }
 func (*TerminateResponse) ProtoMessage()    {}
 func (*TerminateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3507425a8852e9f1, []int{10}
+	return fileDescriptor_3507425a8852e9f1, []int{17}
 }
 
 func (m *TerminateResponse) XXX_Unmarshal(b []byte) error {
@@ -580,6 +917,248 @@ func (m *TerminateResponse) GetMessage() string {
 	return ""
 }
 
+type RequestLogRequest struct {
+	Selection            *selection.Selection `protobuf:"bytes,1,opt,name=selection,proto3" json:"selection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RequestLogRequest) Reset()         { *m = RequestLogRequest{} }
+func (m *RequestLogRequest) String() string { return proto.CompactTextString(m) }
+func (*RequestLogRequest) ProtoMessage()    {}
+func (*RequestLogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3507425a8852e9f1, []int{18}
+}
+
+func (m *RequestLogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestLogRequest.Unmarshal(m, b)
+}
+func (m *RequestLogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestLogRequest.Marshal(b, m, deterministic)
+}
+func (m *RequestLogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestLogRequest.Merge(m, src)
+}
+func (m *RequestLogRequest) XXX_Size() int {
+	return xxx_messageInfo_RequestLogRequest.Size(m)
+}
+func (m *RequestLogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestLogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestLogRequest proto.InternalMessageInfo
+
+func (m *RequestLogRequest) GetSelection() *selection.Selection {
+	if m != nil {
+		return m.Selection
+	}
+	return nil
+}
+
+type RequestLogResponse struct {
+	Logs                 []*forwarding.HTTPRequestLog `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *RequestLogResponse) Reset()         { *m = RequestLogResponse{} }
+func (m *RequestLogResponse) String() string { return proto.CompactTextString(m) }
+func (*RequestLogResponse) ProtoMessage()    {}
+func (*RequestLogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3507425a8852e9f1, []int{19}
+}
+
+func (m *RequestLogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestLogResponse.Unmarshal(m, b)
+}
+func (m *RequestLogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestLogResponse.Marshal(b, m, deterministic)
+}
+func (m *RequestLogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestLogResponse.Merge(m, src)
+}
+func (m *RequestLogResponse) XXX_Size() int {
+	return xxx_messageInfo_RequestLogResponse.Size(m)
+}
+func (m *RequestLogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestLogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestLogResponse proto.InternalMessageInfo
+
+func (m *RequestLogResponse) GetLogs() []*forwarding.HTTPRequestLog {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+type StartCaptureRequest struct {
+	Selection            *selection.Selection     `protobuf:"bytes,1,opt,name=selection,proto3" json:"selection,omitempty"`
+	Format               forwarding.CaptureFormat `protobuf:"varint,2,opt,name=format,proto3,enum=forwarding.CaptureFormat" json:"format,omitempty"`
+	Path                 string                   `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	ConnectionLimit      uint64                   `protobuf:"varint,4,opt,name=connectionLimit,proto3" json:"connectionLimit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *StartCaptureRequest) Reset()         { *m = StartCaptureRequest{} }
+func (m *StartCaptureRequest) String() string { return proto.CompactTextString(m) }
+func (*StartCaptureRequest) ProtoMessage()    {}
+func (*StartCaptureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3507425a8852e9f1, []int{20}
+}
+
+func (m *StartCaptureRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartCaptureRequest.Unmarshal(m, b)
+}
+func (m *StartCaptureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartCaptureRequest.Marshal(b, m, deterministic)
+}
+func (m *StartCaptureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartCaptureRequest.Merge(m, src)
+}
+func (m *StartCaptureRequest) XXX_Size() int {
+	return xxx_messageInfo_StartCaptureRequest.Size(m)
+}
+func (m *StartCaptureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartCaptureRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartCaptureRequest proto.InternalMessageInfo
+
+func (m *StartCaptureRequest) GetSelection() *selection.Selection {
+	if m != nil {
+		return m.Selection
+	}
+	return nil
+}
+
+func (m *StartCaptureRequest) GetFormat() forwarding.CaptureFormat {
+	if m != nil {
+		return m.Format
+	}
+	return forwarding.CaptureFormat_CaptureFormatDefault
+}
+
+func (m *StartCaptureRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *StartCaptureRequest) GetConnectionLimit() uint64 {
+	if m != nil {
+		return m.ConnectionLimit
+	}
+	return 0
+}
+
+type StartCaptureResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartCaptureResponse) Reset()         { *m = StartCaptureResponse{} }
+func (m *StartCaptureResponse) String() string { return proto.CompactTextString(m) }
+func (*StartCaptureResponse) ProtoMessage()    {}
+func (*StartCaptureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3507425a8852e9f1, []int{21}
+}
+
+func (m *StartCaptureResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartCaptureResponse.Unmarshal(m, b)
+}
+func (m *StartCaptureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartCaptureResponse.Marshal(b, m, deterministic)
+}
+func (m *StartCaptureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartCaptureResponse.Merge(m, src)
+}
+func (m *StartCaptureResponse) XXX_Size() int {
+	return xxx_messageInfo_StartCaptureResponse.Size(m)
+}
+func (m *StartCaptureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartCaptureResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartCaptureResponse proto.InternalMessageInfo
+
+type StopCaptureRequest struct {
+	Selection            *selection.Selection `protobuf:"bytes,1,opt,name=selection,proto3" json:"selection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *StopCaptureRequest) Reset()         { *m = StopCaptureRequest{} }
+func (m *StopCaptureRequest) String() string { return proto.CompactTextString(m) }
+func (*StopCaptureRequest) ProtoMessage()    {}
+func (*StopCaptureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3507425a8852e9f1, []int{22}
+}
+
+func (m *StopCaptureRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopCaptureRequest.Unmarshal(m, b)
+}
+func (m *StopCaptureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopCaptureRequest.Marshal(b, m, deterministic)
+}
+func (m *StopCaptureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopCaptureRequest.Merge(m, src)
+}
+func (m *StopCaptureRequest) XXX_Size() int {
+	return xxx_messageInfo_StopCaptureRequest.Size(m)
+}
+func (m *StopCaptureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopCaptureRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopCaptureRequest proto.InternalMessageInfo
+
+func (m *StopCaptureRequest) GetSelection() *selection.Selection {
+	if m != nil {
+		return m.Selection
+	}
+	return nil
+}
+
+type StopCaptureResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopCaptureResponse) Reset()         { *m = StopCaptureResponse{} }
+func (m *StopCaptureResponse) String() string { return proto.CompactTextString(m) }
+func (*StopCaptureResponse) ProtoMessage()    {}
+func (*StopCaptureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3507425a8852e9f1, []int{23}
+}
+
+func (m *StopCaptureResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopCaptureResponse.Unmarshal(m, b)
+}
+func (m *StopCaptureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopCaptureResponse.Marshal(b, m, deterministic)
+}
+func (m *StopCaptureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopCaptureResponse.Merge(m, src)
+}
+func (m *StopCaptureResponse) XXX_Size() int {
+	return xxx_messageInfo_StopCaptureResponse.Size(m)
+}
+func (m *StopCaptureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopCaptureResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopCaptureResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*CreationSpecification)(nil), "forwarding.CreationSpecification")
 	proto.RegisterMapType((map[string]string)(nil), "forwarding.CreationSpecification.LabelsEntry")
@@ -587,12 +1166,26 @@ func init() {
 	proto.RegisterType((*CreateResponse)(nil), "forwarding.CreateResponse")
 	proto.RegisterType((*ListRequest)(nil), "forwarding.ListRequest")
 	proto.RegisterType((*ListResponse)(nil), "forwarding.ListResponse")
+	proto.RegisterType((*WatchRequest)(nil), "forwarding.WatchRequest")
+	proto.RegisterType((*WatchResponse)(nil), "forwarding.WatchResponse")
 	proto.RegisterType((*PauseRequest)(nil), "forwarding.PauseRequest")
 	proto.RegisterType((*PauseResponse)(nil), "forwarding.PauseResponse")
 	proto.RegisterType((*ResumeRequest)(nil), "forwarding.ResumeRequest")
 	proto.RegisterType((*ResumeResponse)(nil), "forwarding.ResumeResponse")
+	proto.RegisterType((*UpdateSpecification)(nil), "forwarding.UpdateSpecification")
+	proto.RegisterMapType((map[string]string)(nil), "forwarding.UpdateSpecification.LabelsEntry")
+	proto.RegisterType((*UpdateRequest)(nil), "forwarding.UpdateRequest")
+	proto.RegisterType((*UpdateResponse)(nil), "forwarding.UpdateResponse")
+	proto.RegisterType((*ExportRequest)(nil), "forwarding.ExportRequest")
+	proto.RegisterType((*ExportResponse)(nil), "forwarding.ExportResponse")
 	proto.RegisterType((*TerminateRequest)(nil), "forwarding.TerminateRequest")
 	proto.RegisterType((*TerminateResponse)(nil), "forwarding.TerminateResponse")
+	proto.RegisterType((*RequestLogRequest)(nil), "forwarding.RequestLogRequest")
+	proto.RegisterType((*RequestLogResponse)(nil), "forwarding.RequestLogResponse")
+	proto.RegisterType((*StartCaptureRequest)(nil), "forwarding.StartCaptureRequest")
+	proto.RegisterType((*StartCaptureResponse)(nil), "forwarding.StartCaptureResponse")
+	proto.RegisterType((*StopCaptureRequest)(nil), "forwarding.StopCaptureRequest")
+	proto.RegisterType((*StopCaptureResponse)(nil), "forwarding.StopCaptureResponse")
 }
 
 func init() {
@@ -600,50 +1193,73 @@ func init() {
 }
 
 var fileDescriptor_3507425a8852e9f1 = []byte{
-	// 673 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x5b, 0x6f, 0xd3, 0x4a,
-	0x10, 0x3e, 0x4e, 0xd2, 0x34, 0x9e, 0xd4, 0x55, 0xbb, 0xa7, 0xa7, 0xc7, 0xb1, 0xce, 0xa9, 0x82,
-	0x79, 0x09, 0x48, 0x75, 0x50, 0x40, 0x5c, 0x1f, 0x90, 0x52, 0x5a, 0x84, 0xa8, 0x10, 0xda, 0xd0,
-	0x17, 0x84, 0x84, 0x5c, 0x67, 0x6a, 0xac, 0xc6, 0x97, 0xee, 0xda, 0x85, 0xfe, 0x66, 0xfe, 0x00,
-	0x8f, 0xc8, 0x6b, 0x6f, 0xb2, 0xdb, 0xa6, 0xe4, 0xa1, 0x6f, 0x3b, 0x33, 0xdf, 0x5c, 0xbf, 0x19,
-	0x2d, 0xdc, 0xe7, 0xc8, 0x2e, 0xa3, 0x00, 0x87, 0x67, 0x29, 0xfb, 0xee, 0xb3, 0x69, 0x94, 0x84,
-	0xca, 0xd3, 0xcb, 0x58, 0x9a, 0xa7, 0x04, 0x16, 0x1a, 0xa7, 0xc7, 0x71, 0x86, 0x41, 0x1e, 0xa5,
-	0xc9, 0x70, 0xfe, 0xaa, 0x60, 0xce, 0x9e, 0x12, 0x23, 0x48, 0x93, 0xb3, 0x28, 0x2c, 0x98, 0xaf,
-	0xd8, 0x77, 0x15, 0x3b, 0xcf, 0xfd, 0x1c, 0x6b, 0xbd, 0x55, 0xb0, 0xd9, 0xb0, 0x60, 0xb3, 0x4a,
-	0x74, 0x7f, 0x35, 0xe1, 0x9f, 0x03, 0x86, 0xc2, 0x73, 0x92, 0x61, 0x10, 0x9d, 0x45, 0x81, 0x10,
-	0x48, 0x1f, 0xda, 0x3c, 0x2d, 0x58, 0x80, 0xb6, 0xd1, 0x37, 0x06, 0xdd, 0x51, 0xc7, 0x2b, 0xbd,
-	0x4e, 0xe8, 0x31, 0xad, 0xf5, 0xe4, 0x21, 0x74, 0xa7, 0xc8, 0xf3, 0x28, 0x11, 0x0e, 0x76, 0xe3,
-	0x1a, 0x4c, 0x35, 0x92, 0xd7, 0x60, 0x69, 0x55, 0xda, 0x4d, 0x81, 0xee, 0x79, 0x4a, 0xff, 0x07,
-	0x2a, 0x80, 0xea, 0x78, 0xf2, 0x1e, 0xfe, 0xd6, 0x14, 0x93, 0xaa, 0xb6, 0xd6, 0xaa, 0x30, 0xcb,
-	0xbc, 0xc8, 0x09, 0xd8, 0x9a, 0xfa, 0x8d, 0xd2, 0xc6, 0xda, 0xaa, 0x88, 0xb7, 0xba, 0x12, 0x02,
-	0xad, 0xc4, 0x8f, 0xd1, 0x6e, 0xf7, 0x8d, 0x81, 0x49, 0xc5, 0x9b, 0x1c, 0x42, 0x7b, 0xe6, 0x9f,
-	0xe2, 0x8c, 0xdb, 0xeb, 0xfd, 0xe6, 0xa0, 0x3b, 0xda, 0xd7, 0x02, 0x2f, 0x9b, 0xbc, 0x77, 0x2c,
-	0xf0, 0x87, 0x49, 0xce, 0xae, 0x68, 0xed, 0x4c, 0x76, 0xa1, 0x9d, 0xf9, 0x05, 0xc7, 0xa9, 0xdd,
-	0xe9, 0x1b, 0x83, 0x0e, 0xad, 0x25, 0xe7, 0x05, 0x74, 0x15, 0x38, 0xd9, 0x82, 0xe6, 0x39, 0x5e,
-	0x09, 0xc6, 0x4c, 0x5a, 0x3e, 0xc9, 0x0e, 0xac, 0x5d, 0xfa, 0xb3, 0x02, 0x05, 0x3d, 0x26, 0xad,
-	0x84, 0x97, 0x8d, 0xe7, 0x86, 0x9b, 0x83, 0x25, 0xf2, 0x23, 0xc5, 0x8b, 0x02, 0x79, 0x4e, 0xde,
-	0x82, 0xc5, 0xd5, 0x42, 0x6a, 0xe2, 0xef, 0xad, 0xac, 0x98, 0xea, 0x7e, 0xc4, 0x81, 0x0e, 0x43,
-	0x9e, 0xa5, 0x09, 0x97, 0x69, 0xe7, 0xb2, 0xfb, 0x05, 0x36, 0x65, 0xd6, 0x4a, 0x43, 0x6c, 0x58,
-	0xe7, 0xc8, 0xb9, 0x4c, 0x68, 0x52, 0x29, 0x96, 0x96, 0x18, 0x39, 0xf7, 0x43, 0x19, 0x46, 0x8a,
-	0x62, 0x1c, 0x2c, 0x8d, 0xb3, 0x5c, 0xec, 0x91, 0x49, 0x6b, 0xc9, 0xbd, 0x80, 0xee, 0x71, 0xc4,
-	0x73, 0xd9, 0xd1, 0x08, 0xcc, 0xf9, 0xdd, 0xd4, 0xdd, 0xec, 0x78, 0x8b, 0x4b, 0x9a, 0xc8, 0x17,
-	0x5d, 0xc0, 0x88, 0x07, 0x24, 0x63, 0x78, 0x19, 0xa5, 0x05, 0x9f, 0x94, 0x77, 0xf3, 0x2e, 0x99,
-	0xe2, 0x0f, 0x91, 0xbf, 0x45, 0x97, 0x58, 0xdc, 0x10, 0x36, 0xaa, 0x94, 0x75, 0x3b, 0x7b, 0x00,
-	0x7c, 0xe1, 0x67, 0x08, 0x3f, 0x45, 0x43, 0x9e, 0x81, 0x55, 0xf7, 0x27, 0x82, 0x70, 0xbb, 0x21,
-	0xf6, 0x62, 0x5b, 0x9d, 0xb2, 0xb0, 0x50, 0x1d, 0xe7, 0x8e, 0x61, 0xe3, 0x63, 0x49, 0xfa, 0x1d,
-	0x9a, 0x73, 0x1f, 0x80, 0x55, 0xc7, 0x58, 0x0c, 0x5f, 0x8e, 0xd8, 0xd0, 0x46, 0xec, 0x7e, 0x05,
-	0x8b, 0x22, 0x2f, 0xe2, 0xbb, 0xe4, 0xfb, 0xe3, 0x26, 0x8c, 0x61, 0x53, 0x26, 0x58, 0x55, 0x8c,
-	0xc2, 0x77, 0x43, 0xe3, 0xfb, 0x08, 0xb6, 0x3e, 0x21, 0x8b, 0xcb, 0x03, 0xbc, 0xd3, 0x5c, 0xf6,
-	0x61, 0x5b, 0x89, 0xb3, 0xaa, 0x9c, 0xd1, 0xcf, 0x06, 0xc0, 0xd1, 0x9c, 0xae, 0xf2, 0xc6, 0xab,
-	0x9d, 0x26, 0xbd, 0x1b, 0xb7, 0x22, 0xcb, 0x72, 0x9c, 0x65, 0xa6, 0x7a, 0x14, 0x7f, 0x0d, 0x8c,
-	0x47, 0x06, 0x79, 0x05, 0xad, 0x72, 0x93, 0xc8, 0xbf, 0x2a, 0x52, 0x59, 0x67, 0xc7, 0xbe, 0x69,
-	0x90, 0x01, 0xc8, 0x18, 0xd6, 0x04, 0xb3, 0x44, 0x03, 0xa9, 0x0b, 0xe3, 0xf4, 0x96, 0x58, 0xb4,
-	0x02, 0x0e, 0xa1, 0x5d, 0x31, 0xa2, 0xf7, 0xa1, 0xad, 0x81, 0xde, 0x87, 0x4e, 0x60, 0x1d, 0xe6,
-	0x03, 0x98, 0xf3, 0x61, 0x92, 0xff, 0x54, 0xf8, 0x75, 0xae, 0x9c, 0xff, 0x6f, 0xb1, 0xaa, 0xf1,
-	0xc6, 0x4f, 0x3f, 0x3f, 0x09, 0xa3, 0xfc, 0x5b, 0x71, 0xea, 0x05, 0x69, 0x3c, 0x8c, 0x8b, 0xdc,
-	0x0f, 0x31, 0xd9, 0x8f, 0x52, 0xf9, 0x1c, 0x66, 0xe7, 0xe1, 0xf0, 0xe6, 0xd7, 0x7a, 0xda, 0x16,
-	0x5f, 0xdc, 0xe3, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd3, 0xdf, 0x16, 0xc3, 0x77, 0x07, 0x00,
-	0x00,
+	// 1052 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0x5d, 0x73, 0xdb, 0x44,
+	0x17, 0x7e, 0x15, 0x3b, 0x6a, 0x7c, 0x1c, 0xf9, 0x6d, 0x36, 0x1f, 0xc8, 0x3b, 0x34, 0x31, 0xe2,
+	0xc6, 0x85, 0x89, 0x5d, 0x02, 0xc3, 0x47, 0xb9, 0x00, 0xec, 0x3a, 0x6d, 0x07, 0xc3, 0x64, 0xe4,
+	0x64, 0x98, 0x01, 0x66, 0x3a, 0x8a, 0xbd, 0x71, 0x34, 0xb5, 0x25, 0x55, 0xbb, 0x0a, 0xed, 0x3f,
+	0xe2, 0x86, 0x3f, 0xc0, 0x2f, 0xe3, 0x92, 0xd1, 0x6a, 0x57, 0xde, 0x95, 0x65, 0x0c, 0x75, 0xef,
+	0x76, 0xf7, 0x9c, 0xf3, 0x9c, 0x0f, 0x9d, 0xf3, 0xec, 0x0a, 0x3e, 0xa4, 0x24, 0xbe, 0xf3, 0xc7,
+	0xa4, 0x7b, 0x13, 0xc6, 0xbf, 0x79, 0xf1, 0xc4, 0x0f, 0xa6, 0xca, 0xb2, 0x13, 0xc5, 0x21, 0x0b,
+	0x11, 0x2c, 0x4e, 0x70, 0x93, 0x92, 0x19, 0x19, 0x33, 0x3f, 0x0c, 0xba, 0xf9, 0x2a, 0x53, 0xc3,
+	0x27, 0x0a, 0xc6, 0xd8, 0x8b, 0x58, 0x12, 0x93, 0x17, 0x37, 0x61, 0x3c, 0xf7, 0x98, 0x50, 0x38,
+	0x56, 0x15, 0xc2, 0xe0, 0xc6, 0x9f, 0x26, 0xb1, 0xa7, 0x00, 0x1c, 0x29, 0x72, 0x72, 0x47, 0x02,
+	0x69, 0x77, 0xa8, 0x9c, 0xdf, 0x32, 0x16, 0x95, 0xa8, 0x53, 0xe6, 0x31, 0x22, 0xce, 0xad, 0x24,
+	0x9e, 0x75, 0x93, 0x78, 0x96, 0x6d, 0x9d, 0xbf, 0x2a, 0x70, 0xd8, 0x8f, 0x09, 0x77, 0x34, 0x8a,
+	0xc8, 0xd8, 0xbf, 0xf1, 0xc7, 0x7c, 0x83, 0x5a, 0x60, 0xd2, 0x30, 0x89, 0xc7, 0xc4, 0x36, 0x5a,
+	0x46, 0xbb, 0x7e, 0xb6, 0xd3, 0x49, 0xad, 0xae, 0xdc, 0xa1, 0x2b, 0xce, 0xd1, 0x47, 0x50, 0x9f,
+	0x10, 0xca, 0xfc, 0x80, 0x1b, 0xd8, 0x5b, 0x05, 0x35, 0x55, 0x88, 0xbe, 0x01, 0x4b, 0x4b, 0xca,
+	0xae, 0x70, 0xed, 0x66, 0x47, 0xa9, 0x67, 0x5f, 0x55, 0x70, 0x75, 0x7d, 0xf4, 0x3d, 0xec, 0x6b,
+	0x07, 0xa3, 0x2c, 0xb6, 0xea, 0x3a, 0x98, 0x32, 0x2b, 0x74, 0x05, 0xb6, 0x76, 0xfc, 0x44, 0x49,
+	0x63, 0x7b, 0x1d, 0xe2, 0x4a, 0x53, 0x84, 0xa0, 0x1a, 0x78, 0x73, 0x62, 0x9b, 0x2d, 0xa3, 0x5d,
+	0x73, 0xf9, 0x1a, 0x0d, 0xc0, 0x9c, 0x79, 0xd7, 0x64, 0x46, 0xed, 0x7b, 0xad, 0x4a, 0xbb, 0x7e,
+	0x76, 0xaa, 0x01, 0x97, 0x55, 0xbe, 0x33, 0xe4, 0xfa, 0x83, 0x80, 0xc5, 0x6f, 0x5c, 0x61, 0x8c,
+	0x8e, 0xc0, 0x8c, 0xbc, 0x84, 0x92, 0x89, 0xbd, 0xd3, 0x32, 0xda, 0x3b, 0xae, 0xd8, 0xe1, 0xaf,
+	0xa0, 0xae, 0xa8, 0xa3, 0xfb, 0x50, 0x79, 0x49, 0xde, 0xf0, 0x2f, 0x56, 0x73, 0xd3, 0x25, 0x3a,
+	0x80, 0xed, 0x3b, 0x6f, 0x96, 0x10, 0xfe, 0x79, 0x6a, 0x6e, 0xb6, 0x79, 0xbc, 0xf5, 0xa5, 0xe1,
+	0x30, 0xb0, 0xb8, 0x7f, 0xe2, 0x92, 0x57, 0x09, 0xa1, 0x0c, 0x3d, 0x05, 0x8b, 0xaa, 0x81, 0x88,
+	0x0f, 0xff, 0xc1, 0xda, 0x88, 0x5d, 0xdd, 0x0e, 0x61, 0xd8, 0x89, 0x09, 0x8d, 0xc2, 0x80, 0x4a,
+	0xb7, 0xf9, 0xde, 0xf9, 0x15, 0x1a, 0xd2, 0x6b, 0x76, 0x82, 0x6c, 0xb8, 0x47, 0x09, 0xa5, 0xd2,
+	0x61, 0xcd, 0x95, 0xdb, 0x54, 0x32, 0x27, 0x94, 0x7a, 0x53, 0x09, 0x23, 0xb7, 0xbc, 0x1c, 0x71,
+	0x38, 0x8f, 0x18, 0xef, 0xa3, 0x9a, 0x2b, 0x76, 0xce, 0x2b, 0xa8, 0x0f, 0x7d, 0xca, 0x64, 0x46,
+	0x67, 0x50, 0xcb, 0xe7, 0x50, 0x64, 0x73, 0xd0, 0xc9, 0x4f, 0x3a, 0x23, 0xb9, 0x72, 0x17, 0x6a,
+	0xa8, 0x03, 0x28, 0x8a, 0xc9, 0x9d, 0x1f, 0x26, 0x74, 0x94, 0xce, 0xcd, 0xf3, 0x60, 0x42, 0x5e,
+	0x73, 0xff, 0x55, 0xb7, 0x44, 0xe2, 0x4c, 0x61, 0x37, 0x73, 0x29, 0xd2, 0x39, 0x06, 0xa0, 0x0b,
+	0x3b, 0x83, 0xdb, 0x29, 0x27, 0xe8, 0x0b, 0xb0, 0x44, 0x7e, 0x1c, 0x84, 0xda, 0x5b, 0xbc, 0x2f,
+	0xf6, 0xd4, 0x2a, 0x73, 0x89, 0xab, 0xeb, 0x39, 0x3d, 0xd8, 0xfd, 0xc9, 0x63, 0xe3, 0xdb, 0x0d,
+	0x92, 0x73, 0x1e, 0x83, 0x25, 0x30, 0x44, 0xb4, 0x0f, 0xc1, 0xe4, 0x64, 0x42, 0x6d, 0x63, 0x39,
+	0x8c, 0x41, 0x2a, 0x71, 0x85, 0x42, 0xea, 0xff, 0x22, 0x6d, 0xba, 0x4d, 0xfc, 0x3f, 0x04, 0x4b,
+	0x60, 0x2c, 0x3e, 0xbe, 0xfc, 0xc4, 0x86, 0xf6, 0x89, 0x9d, 0x17, 0x60, 0xb9, 0x84, 0x26, 0xf3,
+	0x4d, 0xfc, 0xfd, 0x63, 0x27, 0xf6, 0xa0, 0x21, 0x1d, 0xac, 0x0b, 0x46, 0xe9, 0xb7, 0x2d, 0xad,
+	0xdf, 0x7e, 0xaf, 0xc0, 0xfe, 0x55, 0x34, 0xf1, 0x18, 0xd1, 0xc9, 0x73, 0x75, 0x4f, 0x2f, 0x11,
+	0xe1, 0xd6, 0xbb, 0x21, 0xc2, 0xca, 0x3b, 0x27, 0xc2, 0xea, 0xe6, 0x44, 0xb8, 0xad, 0x10, 0x61,
+	0x3f, 0x27, 0x42, 0x93, 0x77, 0xda, 0xc7, 0x2a, 0x70, 0x49, 0x0d, 0xcb, 0x68, 0x70, 0x13, 0xba,
+	0x8b, 0xc1, 0xca, 0xbc, 0xc8, 0x7e, 0x1a, 0x94, 0xd3, 0xdd, 0xc9, 0x9a, 0xb8, 0xfe, 0x0b, 0xd9,
+	0xf5, 0xa0, 0x21, 0x7d, 0xbe, 0x75, 0x8b, 0xf5, 0xc1, 0x1a, 0xbc, 0x8e, 0xc2, 0x78, 0x13, 0x52,
+	0x73, 0x7e, 0x81, 0x86, 0x04, 0x11, 0x81, 0x3c, 0x87, 0x86, 0x96, 0x87, 0x24, 0x80, 0x7f, 0xc1,
+	0xf6, 0x05, 0x43, 0xe7, 0x1c, 0xee, 0x5f, 0x92, 0x78, 0xee, 0x07, 0x4a, 0x71, 0xdf, 0x26, 0xc8,
+	0x53, 0xd8, 0x53, 0x70, 0xd6, 0x12, 0xc4, 0x53, 0xd8, 0x13, 0xde, 0x86, 0xe1, 0x74, 0x13, 0xbf,
+	0x4f, 0x00, 0xa9, 0x40, 0xc2, 0x71, 0x07, 0xaa, 0xb3, 0x70, 0x2a, 0xcb, 0x82, 0xd5, 0xb2, 0x3c,
+	0xbb, 0xbc, 0xbc, 0x50, 0x2c, 0xb8, 0x9e, 0xf3, 0xa7, 0x01, 0xfb, 0x23, 0xe6, 0xc5, 0xac, 0x9f,
+	0xbd, 0xee, 0x36, 0xa1, 0xad, 0x4f, 0xc0, 0xcc, 0xde, 0x86, 0xbc, 0x17, 0x1a, 0x85, 0x21, 0xcc,
+	0xf0, 0xcf, 0xb9, 0x82, 0x2b, 0x14, 0xd3, 0x91, 0x8b, 0x3c, 0x76, 0x2b, 0xee, 0x43, 0xbe, 0x46,
+	0x6d, 0xf8, 0xff, 0x38, 0x0c, 0x82, 0x0c, 0x74, 0xe8, 0xcf, 0x7d, 0xc6, 0x87, 0xba, 0xea, 0x16,
+	0x8f, 0x9d, 0x23, 0x38, 0xd0, 0x63, 0x17, 0x0d, 0xfc, 0x0c, 0xd0, 0x88, 0x85, 0xd1, 0xe6, 0x29,
+	0x39, 0x87, 0xb0, 0xaf, 0x21, 0x65, 0x0e, 0xce, 0xfe, 0x30, 0x01, 0xce, 0xf3, 0xdc, 0xd2, 0xd7,
+	0x52, 0xf6, 0x3a, 0x40, 0xcd, 0xa5, 0x3e, 0x94, 0xee, 0x31, 0x2e, 0x13, 0x89, 0x80, 0xff, 0xd7,
+	0x36, 0x1e, 0x19, 0xe8, 0x6b, 0xa8, 0xa6, 0x77, 0x32, 0x7a, 0x4f, 0xd5, 0x54, 0x1e, 0x06, 0xd8,
+	0x5e, 0x16, 0x48, 0x00, 0xf4, 0x2d, 0x6c, 0xf3, 0x3b, 0x12, 0x69, 0x4a, 0xea, 0xd5, 0x8b, 0x9b,
+	0x25, 0x12, 0x69, 0xff, 0xc8, 0x40, 0x3d, 0xd8, 0xe6, 0xb7, 0x9c, 0x8e, 0xa0, 0x5e, 0x9e, 0xb8,
+	0x59, 0x22, 0xd1, 0x52, 0x18, 0x80, 0x99, 0xdd, 0x4e, 0x7a, 0x25, 0xb4, 0x2b, 0x11, 0xe3, 0x32,
+	0x51, 0x11, 0x26, 0x63, 0x20, 0x1d, 0x46, 0x63, 0x42, 0x8c, 0xcb, 0x44, 0x1a, 0xcc, 0x77, 0x60,
+	0x66, 0xfc, 0xa1, 0xc3, 0x68, 0xc4, 0x84, 0x71, 0x99, 0x28, 0x2f, 0xeb, 0x8f, 0x50, 0xcb, 0xa7,
+	0x1b, 0xbd, 0xaf, 0xaa, 0x16, 0xc9, 0x03, 0x3f, 0x58, 0x21, 0xd5, 0x42, 0xfa, 0x01, 0x60, 0x31,
+	0x83, 0xe8, 0x81, 0x5e, 0x89, 0x02, 0x2d, 0xe0, 0xe3, 0x55, 0xe2, 0x3c, 0xbc, 0x11, 0xec, 0xaa,
+	0x13, 0x80, 0x4e, 0x0a, 0xef, 0xb1, 0xe2, 0x5c, 0xe3, 0xd6, 0x6a, 0x85, 0x1c, 0xf4, 0x02, 0xea,
+	0x4a, 0xd3, 0xa3, 0x63, 0xdd, 0xa4, 0x38, 0x57, 0xf8, 0x64, 0xa5, 0x5c, 0x22, 0xf6, 0x3e, 0xff,
+	0xf9, 0xb3, 0xa9, 0xcf, 0x6e, 0x93, 0xeb, 0xce, 0x38, 0x9c, 0x77, 0xe7, 0x09, 0xf3, 0xa6, 0x24,
+	0x38, 0xf5, 0x43, 0xb9, 0xec, 0x46, 0x2f, 0xa7, 0xdd, 0xe5, 0xdf, 0xd6, 0x6b, 0x93, 0xff, 0xee,
+	0x7d, 0xfa, 0xf7, 0x00, 0x81, 0x41, 0x95, 0xe4, 0xd3, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -660,9 +1276,15 @@ const _ = grpc.SupportPackageIsVersion4
 type ForwardingClient interface {
 	Create(ctx context.Context, opts ...grpc.CallOption) (Forwarding_CreateClient, error)
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Forwarding_WatchClient, error)
 	Pause(ctx context.Context, opts ...grpc.CallOption) (Forwarding_PauseClient, error)
 	Resume(ctx context.Context, opts ...grpc.CallOption) (Forwarding_ResumeClient, error)
+	Update(ctx context.Context, opts ...grpc.CallOption) (Forwarding_UpdateClient, error)
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error)
 	Terminate(ctx context.Context, opts ...grpc.CallOption) (Forwarding_TerminateClient, error)
+	RequestLog(ctx context.Context, in *RequestLogRequest, opts ...grpc.CallOption) (*RequestLogResponse, error)
+	StartCapture(ctx context.Context, in *StartCaptureRequest, opts ...grpc.CallOption) (*StartCaptureResponse, error)
+	StopCapture(ctx context.Context, in *StopCaptureRequest, opts ...grpc.CallOption) (*StopCaptureResponse, error)
 }
 
 type forwardingClient struct {
@@ -713,8 +1335,40 @@ func (c *forwardingClient) List(ctx context.Context, in *ListRequest, opts ...gr
 	return out, nil
 }
 
+func (c *forwardingClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Forwarding_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Forwarding_serviceDesc.Streams[1], "/forwarding.Forwarding/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &forwardingWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Forwarding_WatchClient interface {
+	Recv() (*WatchResponse, error)
+	grpc.ClientStream
+}
+
+type forwardingWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *forwardingWatchClient) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *forwardingClient) Pause(ctx context.Context, opts ...grpc.CallOption) (Forwarding_PauseClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Forwarding_serviceDesc.Streams[1], "/forwarding.Forwarding/Pause", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Forwarding_serviceDesc.Streams[2], "/forwarding.Forwarding/Pause", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -745,7 +1399,7 @@ func (x *forwardingPauseClient) Recv() (*PauseResponse, error) {
 }
 
 func (c *forwardingClient) Resume(ctx context.Context, opts ...grpc.CallOption) (Forwarding_ResumeClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Forwarding_serviceDesc.Streams[2], "/forwarding.Forwarding/Resume", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Forwarding_serviceDesc.Streams[3], "/forwarding.Forwarding/Resume", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -775,8 +1429,48 @@ func (x *forwardingResumeClient) Recv() (*ResumeResponse, error) {
 	return m, nil
 }
 
+func (c *forwardingClient) Update(ctx context.Context, opts ...grpc.CallOption) (Forwarding_UpdateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Forwarding_serviceDesc.Streams[4], "/forwarding.Forwarding/Update", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &forwardingUpdateClient{stream}
+	return x, nil
+}
+
+type Forwarding_UpdateClient interface {
+	Send(*UpdateRequest) error
+	Recv() (*UpdateResponse, error)
+	grpc.ClientStream
+}
+
+type forwardingUpdateClient struct {
+	grpc.ClientStream
+}
+
+func (x *forwardingUpdateClient) Send(m *UpdateRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *forwardingUpdateClient) Recv() (*UpdateResponse, error) {
+	m := new(UpdateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *forwardingClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error) {
+	out := new(ExportResponse)
+	err := c.cc.Invoke(ctx, "/forwarding.Forwarding/Export", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *forwardingClient) Terminate(ctx context.Context, opts ...grpc.CallOption) (Forwarding_TerminateClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Forwarding_serviceDesc.Streams[3], "/forwarding.Forwarding/Terminate", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Forwarding_serviceDesc.Streams[5], "/forwarding.Forwarding/Terminate", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -806,13 +1500,46 @@ func (x *forwardingTerminateClient) Recv() (*TerminateResponse, error) {
 	return m, nil
 }
 
+func (c *forwardingClient) RequestLog(ctx context.Context, in *RequestLogRequest, opts ...grpc.CallOption) (*RequestLogResponse, error) {
+	out := new(RequestLogResponse)
+	err := c.cc.Invoke(ctx, "/forwarding.Forwarding/RequestLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forwardingClient) StartCapture(ctx context.Context, in *StartCaptureRequest, opts ...grpc.CallOption) (*StartCaptureResponse, error) {
+	out := new(StartCaptureResponse)
+	err := c.cc.Invoke(ctx, "/forwarding.Forwarding/StartCapture", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forwardingClient) StopCapture(ctx context.Context, in *StopCaptureRequest, opts ...grpc.CallOption) (*StopCaptureResponse, error) {
+	out := new(StopCaptureResponse)
+	err := c.cc.Invoke(ctx, "/forwarding.Forwarding/StopCapture", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ForwardingServer is the server API for Forwarding service.
 type ForwardingServer interface {
 	Create(Forwarding_CreateServer) error
 	List(context.Context, *ListRequest) (*ListResponse, error)
+	Watch(*WatchRequest, Forwarding_WatchServer) error
 	Pause(Forwarding_PauseServer) error
 	Resume(Forwarding_ResumeServer) error
+	Update(Forwarding_UpdateServer) error
+	Export(context.Context, *ExportRequest) (*ExportResponse, error)
 	Terminate(Forwarding_TerminateServer) error
+	RequestLog(context.Context, *RequestLogRequest) (*RequestLogResponse, error)
+	StartCapture(context.Context, *StartCaptureRequest) (*StartCaptureResponse, error)
+	StopCapture(context.Context, *StopCaptureRequest) (*StopCaptureResponse, error)
 }
 
 func RegisterForwardingServer(s *grpc.Server, srv ForwardingServer) {
@@ -863,6 +1590,27 @@ func _Forwarding_List_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Forwarding_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ForwardingServer).Watch(m, &forwardingWatchServer{stream})
+}
+
+type Forwarding_WatchServer interface {
+	Send(*WatchResponse) error
+	grpc.ServerStream
+}
+
+type forwardingWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *forwardingWatchServer) Send(m *WatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Forwarding_Pause_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(ForwardingServer).Pause(&forwardingPauseServer{stream})
 }
@@ -915,6 +1663,50 @@ func (x *forwardingResumeServer) Recv() (*ResumeRequest, error) {
 	return m, nil
 }
 
+func _Forwarding_Update_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ForwardingServer).Update(&forwardingUpdateServer{stream})
+}
+
+type Forwarding_UpdateServer interface {
+	Send(*UpdateResponse) error
+	Recv() (*UpdateRequest, error)
+	grpc.ServerStream
+}
+
+type forwardingUpdateServer struct {
+	grpc.ServerStream
+}
+
+func (x *forwardingUpdateServer) Send(m *UpdateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *forwardingUpdateServer) Recv() (*UpdateRequest, error) {
+	m := new(UpdateRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Forwarding_Export_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForwardingServer).Export(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/forwarding.Forwarding/Export",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForwardingServer).Export(ctx, req.(*ExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Forwarding_Terminate_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(ForwardingServer).Terminate(&forwardingTerminateServer{stream})
 }
@@ -941,6 +1733,60 @@ func (x *forwardingTerminateServer) Recv() (*TerminateRequest, error) {
 	return m, nil
 }
 
+func _Forwarding_RequestLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForwardingServer).RequestLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/forwarding.Forwarding/RequestLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForwardingServer).RequestLog(ctx, req.(*RequestLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Forwarding_StartCapture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartCaptureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForwardingServer).StartCapture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/forwarding.Forwarding/StartCapture",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForwardingServer).StartCapture(ctx, req.(*StartCaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Forwarding_StopCapture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopCaptureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForwardingServer).StopCapture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/forwarding.Forwarding/StopCapture",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForwardingServer).StopCapture(ctx, req.(*StopCaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Forwarding_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "forwarding.Forwarding",
 	HandlerType: (*ForwardingServer)(nil),
@@ -949,6 +1795,22 @@ var _Forwarding_serviceDesc = grpc.ServiceDesc{
 			MethodName: "List",
 			Handler:    _Forwarding_List_Handler,
 		},
+		{
+			MethodName: "Export",
+			Handler:    _Forwarding_Export_Handler,
+		},
+		{
+			MethodName: "RequestLog",
+			Handler:    _Forwarding_RequestLog_Handler,
+		},
+		{
+			MethodName: "StartCapture",
+			Handler:    _Forwarding_StartCapture_Handler,
+		},
+		{
+			MethodName: "StopCapture",
+			Handler:    _Forwarding_StopCapture_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -957,6 +1819,11 @@ var _Forwarding_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Forwarding_Watch_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Pause",
 			Handler:       _Forwarding_Pause_Handler,
@@ -969,6 +1836,12 @@ var _Forwarding_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Update",
+			Handler:       _Forwarding_Update_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "Terminate",
 			Handler:       _Forwarding_Terminate_Handler,
@@ -120,6 +120,46 @@ func (p *resumeStreamPrompter) Prompt(prompt string) (string, error) {
 	}
 }
 
+// updateStreamPrompter implements Prompter on top of a
+// Forwarding_UpdateServer stream.
+type updateStreamPrompter struct {
+	// stream is the underlying Forwarding_UpdateServer stream.
+	stream Forwarding_UpdateServer
+}
+
+// sendReceive performs a send/receive cycle by sending an UpdateResponse and
+// receiving an UpdateRequest.
+func (p *updateStreamPrompter) sendReceive(request *UpdateResponse) (*UpdateRequest, error) {
+	// Send the request.
+	if err := p.stream.Send(request); err != nil {
+		return nil, errors.Wrap(err, "unable to send request")
+	}
+
+	// Receive the response.
+	if response, err := p.stream.Recv(); err != nil {
+		return nil, errors.Wrap(err, "unable to receive response")
+	} else if err = response.ensureValid(false); err != nil {
+		return nil, errors.Wrap(err, "invalid response received")
+	} else {
+		return response, nil
+	}
+}
+
+// Message implements the Message method of Prompter.
+func (p *updateStreamPrompter) Message(message string) error {
+	_, err := p.sendReceive(&UpdateResponse{Message: message})
+	return err
+}
+
+// Prompt implements the Prompt method of Prompter.
+func (p *updateStreamPrompter) Prompt(prompt string) (string, error) {
+	if response, err := p.sendReceive(&UpdateResponse{Prompt: prompt}); err != nil {
+		return "", err
+	} else {
+		return response.Response, nil
+	}
+}
+
 // terminateStreamPrompter implements Prompter on top of a
 // Forwarding_TerminateServer stream.
 type terminateStreamPrompter struct {
@@ -90,6 +90,22 @@ func (s *Server) List(_ context.Context, request *ListRequest) (*ListResponse, e
 	}, nil
 }
 
+// Watch streams events describing changes to existing sessions.
+func (s *Server) Watch(request *WatchRequest, stream Forwarding_WatchServer) error {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return errors.Wrap(err, "received invalid watch request")
+	}
+
+	// Stream events until the client disconnects or an error occurs.
+	return s.manager.Watch(stream.Context(), request.Selection, func(events []*forwarding.Event) error {
+		if err := stream.Send(&WatchResponse{Events: events}); err != nil {
+			return errors.Wrap(err, "unable to send response")
+		}
+		return nil
+	})
+}
+
 // Pause pauses existing sessions.
 func (s *Server) Pause(stream Forwarding_PauseServer) error {
 	// Receive the first request.
@@ -164,6 +180,85 @@ func (s *Server) Resume(stream Forwarding_ResumeServer) error {
 	return nil
 }
 
+// Update updates the configuration of an existing session.
+func (s *Server) Update(stream Forwarding_UpdateServer) error {
+	// Receive the first request.
+	request, err := stream.Recv()
+	if err != nil {
+		return errors.Wrap(err, "unable to receive request")
+	} else if err = request.ensureValid(true); err != nil {
+		return errors.Wrap(err, "received invalid update request")
+	}
+
+	// Wrap the stream in a prompter and register it with the prompt server.
+	prompter, err := prompt.RegisterPrompter(&updateStreamPrompter{stream})
+	if err != nil {
+		return errors.Wrap(err, "unable to register prompter")
+	}
+
+	// Perform the update.
+	// TODO: Figure out a way to monitor for cancellation.
+	err = s.manager.Update(
+		request.Specification.Session,
+		request.Specification.Configuration,
+		request.Specification.ConfigurationSource,
+		request.Specification.ConfigurationDestination,
+		request.Specification.Name,
+		request.Specification.Labels,
+		prompter,
+	)
+
+	// Unregister the prompter.
+	prompt.UnregisterPrompter(prompter)
+
+	// Handle any errors.
+	if err != nil {
+		return err
+	}
+
+	// Signal completion.
+	if err := stream.Send(&UpdateResponse{}); err != nil {
+		return errors.Wrap(err, "unable to send response")
+	}
+
+	// Success.
+	return nil
+}
+
+// Export exports the creation specifications for existing sessions. Since URL
+// environment variables are specific to the local system and may reference
+// credentials, they're omitted from the exported URLs.
+func (s *Server) Export(_ context.Context, request *ExportRequest) (*ExportResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, errors.Wrap(err, "received invalid export request")
+	}
+
+	// Perform listing.
+	_, states, err := s.manager.List(request.Selection, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert session states to creation specifications.
+	specifications := make([]*CreationSpecification, len(states))
+	for i, state := range states {
+		specifications[i] = &CreationSpecification{
+			Source:                   state.Session.Source.WithoutEnvironment(),
+			Destination:              state.Session.Destination.WithoutEnvironment(),
+			Configuration:            state.Session.Configuration,
+			ConfigurationSource:      state.Session.ConfigurationSource,
+			ConfigurationDestination: state.Session.ConfigurationDestination,
+			Name:                     state.Session.Name,
+			Labels:                   state.Session.Labels,
+			Paused:                   state.Session.Paused,
+		}
+	}
+
+	// Success.
+	return &ExportResponse{Specifications: specifications}, nil
+}
+
 // Terminate terminates existing sessions.
 func (s *Server) Terminate(stream Forwarding_TerminateServer) error {
 	// Receive the first request.
@@ -200,3 +295,57 @@ func (s *Server) Terminate(stream Forwarding_TerminateServer) error {
 	// Success.
 	return nil
 }
+
+// RequestLog retrieves the logs of recent requests for HTTP-aware sessions.
+func (s *Server) RequestLog(_ context.Context, request *RequestLogRequest) (*RequestLogResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, errors.Wrap(err, "received invalid request log request")
+	}
+
+	// Retrieve the request logs.
+	logs, err := s.manager.RequestLog(request.Selection)
+	if err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &RequestLogResponse{Logs: logs}, nil
+}
+
+// StartCapture starts capturing traffic for a session.
+func (s *Server) StartCapture(_ context.Context, request *StartCaptureRequest) (*StartCaptureResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, errors.Wrap(err, "received invalid start capture request")
+	}
+
+	// Start the capture.
+	if err := s.manager.StartCapture(
+		request.Selection,
+		request.Format,
+		request.Path,
+		request.ConnectionLimit,
+	); err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &StartCaptureResponse{}, nil
+}
+
+// StopCapture stops capturing traffic for sessions.
+func (s *Server) StopCapture(_ context.Context, request *StopCaptureRequest) (*StopCaptureResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, errors.Wrap(err, "received invalid stop capture request")
+	}
+
+	// Stop the captures.
+	if err := s.manager.StopCapture(request.Selection); err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &StopCaptureResponse{}, nil
+}
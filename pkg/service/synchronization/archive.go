@@ -0,0 +1,243 @@
+package synchronization
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+const (
+	// archiveChunkSize is the maximum size of the chunks used to stream
+	// serialized archives. It's well below the maximum gRPC message size so
+	// that chunks can be combined with other fields.
+	archiveChunkSize = 1024 * 1024
+)
+
+// archiveChunks serializes an archive and splits it into chunks for streaming.
+// It returns the serialized size along with the chunks.
+func archiveChunks(archive *core.Archive) (uint64, [][]byte, error) {
+	// Serialize the archive.
+	data, err := proto.Marshal(archive)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "unable to serialize archive")
+	}
+
+	// Split the serialized archive into chunks.
+	chunks := make([][]byte, 0, (len(data)+archiveChunkSize-1)/archiveChunkSize)
+	for remaining := data; len(remaining) > 0; {
+		size := archiveChunkSize
+		if len(remaining) < size {
+			size = len(remaining)
+		}
+		chunks = append(chunks, remaining[:size])
+		remaining = remaining[size:]
+	}
+
+	// Done.
+	return uint64(len(data)), chunks, nil
+}
+
+// archiveAssembler reassembles a streamed archive from its chunks.
+type archiveAssembler struct {
+	// remaining is the number of serialized bytes still expected.
+	remaining uint64
+	// buffer stores the serialized bytes received so far.
+	buffer bytes.Buffer
+}
+
+// newArchiveAssembler creates a new archive assembler for a serialized archive
+// of the specified size.
+func newArchiveAssembler(size uint64) *archiveAssembler {
+	return &archiveAssembler{remaining: size}
+}
+
+// complete indicates whether or not all chunks have been received.
+func (a *archiveAssembler) complete() bool {
+	return a.remaining == 0
+}
+
+// add records a chunk. It fails if the chunk is empty or exceeds the remaining
+// size of the serialized archive.
+func (a *archiveAssembler) add(chunk []byte) error {
+	if len(chunk) == 0 {
+		return errors.New("empty archive chunk")
+	} else if uint64(len(chunk)) > a.remaining {
+		return errors.New("archive chunk exceeds expected archive size")
+	}
+	a.buffer.Write(chunk)
+	a.remaining -= uint64(len(chunk))
+	return nil
+}
+
+// archive decodes and validates the reassembled archive. It should only be
+// called once all chunks have been received.
+func (a *archiveAssembler) archive() (*core.Archive, error) {
+	archive := &core.Archive{}
+	if err := proto.Unmarshal(a.buffer.Bytes(), archive); err != nil {
+		return nil, errors.Wrap(err, "unable to decode archive")
+	} else if err = archive.Root.EnsureValid(); err != nil {
+		return nil, errors.Wrap(err, "invalid archive")
+	}
+	return archive, nil
+}
+
+// CreateRequests returns the requests used to initiate session creation with
+// the specified specification. If the specification includes an archive, then
+// the archive is removed from (a copy of) the specification and streamed in
+// chunks following the initial request, allowing it to exceed the maximum
+// message size.
+func CreateRequests(specification *CreationSpecification) ([]*CreateRequest, error) {
+	// If there's no archive, then a single request suffices.
+	if specification.Archive == nil {
+		return []*CreateRequest{{Specification: specification}}, nil
+	}
+
+	// Split the archive into chunks.
+	size, chunks, err := archiveChunks(specification.Archive)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the requests.
+	withoutArchive := proto.Clone(specification).(*CreationSpecification)
+	withoutArchive.Archive = nil
+	requests := make([]*CreateRequest, 0, 1+len(chunks))
+	requests = append(requests, &CreateRequest{
+		Specification: withoutArchive,
+		ArchiveSize:   size,
+	})
+	for _, chunk := range chunks {
+		requests = append(requests, &CreateRequest{ArchiveChunk: chunk})
+	}
+
+	// Done.
+	return requests, nil
+}
+
+// receiveCreationSpecification receives and validates the initial request in
+// a creation stream, along with any archive chunks that follow it, and returns
+// the resulting creation specification.
+func receiveCreationSpecification(stream Synchronization_CreateServer) (*CreationSpecification, error) {
+	// Receive and validate the initial request.
+	request, err := stream.Recv()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to receive request")
+	} else if err = request.ensureValid(true); err != nil {
+		return nil, errors.Wrap(err, "received invalid create request")
+	}
+	specification := request.Specification
+
+	// If no archive follows, then we're done.
+	if request.ArchiveSize == 0 {
+		return specification, nil
+	}
+
+	// Receive archive chunks.
+	assembler := newArchiveAssembler(request.ArchiveSize)
+	for !assembler.complete() {
+		if request, err := stream.Recv(); err != nil {
+			return nil, errors.Wrap(err, "unable to receive archive chunk")
+		} else if err = request.ensureValid(false); err != nil {
+			return nil, errors.Wrap(err, "received invalid create request")
+		} else if err = assembler.add(request.ArchiveChunk); err != nil {
+			return nil, err
+		}
+	}
+
+	// Decode the archive.
+	if specification.Archive, err = assembler.archive(); err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return specification, nil
+}
+
+// exportResponses returns the responses used to export a session with the
+// specified specification. If the specification includes an archive, then the
+// archive is removed from (a copy of) the specification and streamed in chunks
+// following the initial response.
+func exportResponses(specification *CreationSpecification) ([]*ExportResponse, error) {
+	// If there's no archive, then a single response suffices.
+	if specification.Archive == nil {
+		return []*ExportResponse{{Specification: specification}}, nil
+	}
+
+	// Split the archive into chunks.
+	size, chunks, err := archiveChunks(specification.Archive)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the responses.
+	withoutArchive := proto.Clone(specification).(*CreationSpecification)
+	withoutArchive.Archive = nil
+	responses := make([]*ExportResponse, 0, 1+len(chunks))
+	responses = append(responses, &ExportResponse{
+		Specification: withoutArchive,
+		ArchiveSize:   size,
+	})
+	for _, chunk := range chunks {
+		responses = append(responses, &ExportResponse{ArchiveChunk: chunk})
+	}
+
+	// Done.
+	return responses, nil
+}
+
+// ReceiveExport receives and validates the responses from an export stream
+// and returns the exported creation specifications, with any streamed archives
+// reassembled.
+func ReceiveExport(stream Synchronization_ExportClient) ([]*CreationSpecification, error) {
+	var specifications []*CreationSpecification
+	var assembler *archiveAssembler
+	for {
+		// Receive and validate the next response.
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		} else if err = response.EnsureValid(); err != nil {
+			return nil, errors.Wrap(err, "invalid export response received")
+		}
+
+		// Handle archive chunks.
+		if response.Specification == nil {
+			if assembler == nil {
+				return nil, errors.New("unexpected archive chunk received")
+			} else if err := assembler.add(response.ArchiveChunk); err != nil {
+				return nil, err
+			} else if assembler.complete() {
+				last := specifications[len(specifications)-1]
+				if last.Archive, err = assembler.archive(); err != nil {
+					return nil, err
+				}
+				assembler = nil
+			}
+			continue
+		}
+
+		// Handle specifications.
+		if assembler != nil {
+			return nil, errors.New("incomplete archive received")
+		}
+		specifications = append(specifications, response.Specification)
+		if response.ArchiveSize > 0 {
+			assembler = newArchiveAssembler(response.ArchiveSize)
+		}
+	}
+
+	// Ensure that the last archive was completed.
+	if assembler != nil {
+		return nil, errors.New("incomplete archive received")
+	}
+
+	// Success.
+	return specifications, nil
+}
@@ -0,0 +1,206 @@
+package synchronization
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// testLargeArchive creates an archive whose serialized size spans multiple
+// chunks.
+func testLargeArchive() *core.Archive {
+	const count = 3 * archiveChunkSize / 1024
+	contents := make(map[string]*core.Entry, count)
+	for i := 0; i < count; i++ {
+		contents[fmt.Sprintf("file%d", i)] = &core.Entry{
+			Kind:   core.EntryKind_File,
+			Digest: make([]byte, 1024),
+		}
+	}
+	return &core.Archive{Root: &core.Entry{Contents: contents}}
+}
+
+// testSpecification creates a valid creation specification with the specified
+// name and archive.
+func testSpecification(name string, archive *core.Archive) *CreationSpecification {
+	return &CreationSpecification{
+		Alpha:              &url.URL{Kind: url.Kind_Synchronization, Path: "/alpha"},
+		Beta:               &url.URL{Kind: url.Kind_Synchronization, Path: "/beta"},
+		Configuration:      &synchronization.Configuration{},
+		ConfigurationAlpha: &synchronization.Configuration{},
+		ConfigurationBeta:  &synchronization.Configuration{},
+		Name:               name,
+		Archive:            archive,
+	}
+}
+
+// testExportClient is a Synchronization_ExportClient that returns a fixed set
+// of responses.
+type testExportClient struct {
+	grpc.ClientStream
+	// responses are the responses to return.
+	responses []*ExportResponse
+}
+
+// Recv implements Synchronization_ExportClient.Recv.
+func (c *testExportClient) Recv() (*ExportResponse, error) {
+	if len(c.responses) == 0 {
+		return nil, io.EOF
+	}
+	response := c.responses[0]
+	c.responses = c.responses[1:]
+	return response, nil
+}
+
+func TestArchiveChunksRoundTrip(t *testing.T) {
+	// Create and chunk an archive.
+	archive := testLargeArchive()
+	size, chunks, err := archiveChunks(archive)
+	if err != nil {
+		t.Fatal("unable to chunk archive:", err)
+	} else if len(chunks) < 2 {
+		t.Fatal("archive not split into multiple chunks")
+	}
+
+	// Reassemble the archive.
+	assembler := newArchiveAssembler(size)
+	for _, chunk := range chunks {
+		if len(chunk) > archiveChunkSize {
+			t.Error("chunk exceeds maximum chunk size")
+		} else if assembler.complete() {
+			t.Fatal("assembler complete before all chunks added")
+		} else if err := assembler.add(chunk); err != nil {
+			t.Fatal("unable to add chunk:", err)
+		}
+	}
+	if !assembler.complete() {
+		t.Fatal("assembler incomplete after all chunks added")
+	}
+	result, err := assembler.archive()
+	if err != nil {
+		t.Fatal("unable to decode reassembled archive:", err)
+	} else if !proto.Equal(result, archive) {
+		t.Error("reassembled archive does not match original")
+	}
+}
+
+func TestArchiveAssemblerRejectsOversizedChunk(t *testing.T) {
+	assembler := newArchiveAssembler(2)
+	if assembler.add([]byte{0, 1, 2}) == nil {
+		t.Error("oversized chunk accepted")
+	}
+}
+
+func TestArchiveAssemblerRejectsEmptyChunk(t *testing.T) {
+	assembler := newArchiveAssembler(2)
+	if assembler.add(nil) == nil {
+		t.Error("empty chunk accepted")
+	}
+}
+
+func TestCreateRequestsWithoutArchive(t *testing.T) {
+	specification := &CreationSpecification{}
+	requests, err := CreateRequests(specification)
+	if err != nil {
+		t.Fatal("unable to create requests:", err)
+	} else if len(requests) != 1 {
+		t.Fatal("unexpected number of requests:", len(requests))
+	} else if requests[0].Specification != specification {
+		t.Error("specification not included in request")
+	} else if requests[0].ArchiveSize != 0 {
+		t.Error("archive size set without archive")
+	}
+}
+
+func TestCreateRequestsWithArchive(t *testing.T) {
+	// Create the requests.
+	archive := testLargeArchive()
+	specification := &CreationSpecification{Archive: archive}
+	requests, err := CreateRequests(specification)
+	if err != nil {
+		t.Fatal("unable to create requests:", err)
+	} else if len(requests) < 3 {
+		t.Fatal("archive not streamed in multiple chunks")
+	}
+
+	// Verify that the original specification wasn't modified and that the
+	// initial request doesn't embed the archive.
+	if specification.Archive != archive {
+		t.Error("original specification modified")
+	} else if requests[0].Specification.Archive != nil {
+		t.Error("archive embedded in initial request")
+	} else if requests[0].ArchiveSize == 0 {
+		t.Error("archive size not set in initial request")
+	}
+
+	// Verify that the chunks reassemble to the original archive.
+	assembler := newArchiveAssembler(requests[0].ArchiveSize)
+	for _, request := range requests[1:] {
+		if request.Specification != nil {
+			t.Fatal("specification included in chunk request")
+		} else if err := assembler.add(request.ArchiveChunk); err != nil {
+			t.Fatal("unable to add chunk:", err)
+		}
+	}
+	if !assembler.complete() {
+		t.Fatal("archive incomplete")
+	} else if result, err := assembler.archive(); err != nil {
+		t.Fatal("unable to decode archive:", err)
+	} else if !proto.Equal(result, archive) {
+		t.Error("reassembled archive does not match original")
+	}
+}
+
+func TestReceiveExport(t *testing.T) {
+	// Create responses for one session with an archive and one without.
+	archive := testLargeArchive()
+	withArchive, err := exportResponses(testSpecification("first", archive))
+	if err != nil {
+		t.Fatal("unable to create responses:", err)
+	}
+	withoutArchive, err := exportResponses(testSpecification("second", nil))
+	if err != nil {
+		t.Fatal("unable to create responses:", err)
+	}
+	stream := &testExportClient{responses: append(withArchive, withoutArchive...)}
+
+	// Receive the export.
+	specifications, err := ReceiveExport(stream)
+	if err != nil {
+		t.Fatal("unable to receive export:", err)
+	} else if len(specifications) != 2 {
+		t.Fatal("unexpected number of specifications:", len(specifications))
+	} else if specifications[0].Name != "first" || specifications[1].Name != "second" {
+		t.Error("specifications received out of order")
+	} else if !proto.Equal(specifications[0].Archive, archive) {
+		t.Error("received archive does not match original")
+	} else if specifications[1].Archive != nil {
+		t.Error("unexpected archive received")
+	}
+}
+
+func TestReceiveExportIncompleteArchive(t *testing.T) {
+	responses, err := exportResponses(testSpecification("", testLargeArchive()))
+	if err != nil {
+		t.Fatal("unable to create responses:", err)
+	}
+	stream := &testExportClient{responses: responses[:len(responses)-1]}
+	if _, err := ReceiveExport(stream); err == nil {
+		t.Error("incomplete archive accepted")
+	}
+}
+
+func TestReceiveExportUnexpectedChunk(t *testing.T) {
+	stream := &testExportClient{responses: []*ExportResponse{{ArchiveChunk: []byte{0}}}}
+	if _, err := ReceiveExport(stream); err == nil {
+		t.Error("unexpected archive chunk accepted")
+	}
+}
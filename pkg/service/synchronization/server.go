@@ -24,12 +24,10 @@ func NewServer(manager *synchronization.Manager) *Server {
 
 // Create creates a new session.
 func (s *Server) Create(stream Synchronization_CreateServer) error {
-	// Receive and validate the request.
-	request, err := stream.Recv()
+	// Receive and validate the creation specification.
+	specification, err := receiveCreationSpecification(stream)
 	if err != nil {
-		return errors.Wrap(err, "unable to receive request")
-	} else if err = request.ensureValid(true); err != nil {
-		return errors.Wrap(err, "received invalid create request")
+		return err
 	}
 
 	// Wrap the stream in a prompter and register it with the prompt server.
@@ -41,14 +39,15 @@ func (s *Server) Create(stream Synchronization_CreateServer) error {
 	// Perform creation.
 	// TODO: Figure out a way to monitor for cancellation.
 	session, err := s.manager.Create(
-		request.Specification.Alpha,
-		request.Specification.Beta,
-		request.Specification.Configuration,
-		request.Specification.ConfigurationAlpha,
-		request.Specification.ConfigurationBeta,
-		request.Specification.Name,
-		request.Specification.Labels,
-		request.Specification.Paused,
+		specification.Alpha,
+		specification.Beta,
+		specification.Configuration,
+		specification.ConfigurationAlpha,
+		specification.ConfigurationBeta,
+		specification.Name,
+		specification.Labels,
+		specification.Archive,
+		specification.Paused,
 		prompter,
 	)
 
@@ -90,6 +89,22 @@ func (s *Server) List(_ context.Context, request *ListRequest) (*ListResponse, e
 	}, nil
 }
 
+// Watch streams events describing changes to existing sessions.
+func (s *Server) Watch(request *WatchRequest, stream Synchronization_WatchServer) error {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return errors.Wrap(err, "received invalid watch request")
+	}
+
+	// Stream events until the client disconnects or an error occurs.
+	return s.manager.Watch(stream.Context(), request.Selection, func(events []*synchronization.Event) error {
+		if err := stream.Send(&WatchResponse{Events: events}); err != nil {
+			return errors.Wrap(err, "unable to send response")
+		}
+		return nil
+	})
+}
+
 // Flush flushes existing sessions.
 func (s *Server) Flush(stream Synchronization_FlushServer) error {
 	// Receive the first request.
@@ -200,6 +215,101 @@ func (s *Server) Resume(stream Synchronization_ResumeServer) error {
 	return nil
 }
 
+// Update updates the configuration of an existing session.
+func (s *Server) Update(stream Synchronization_UpdateServer) error {
+	// Receive the first request.
+	request, err := stream.Recv()
+	if err != nil {
+		return errors.Wrap(err, "unable to receive request")
+	} else if err = request.ensureValid(true); err != nil {
+		return errors.Wrap(err, "received invalid update request")
+	}
+
+	// Wrap the stream in a prompter and register it with the prompt server.
+	prompter, err := prompt.RegisterPrompter(&updateStreamPrompter{stream})
+	if err != nil {
+		return errors.Wrap(err, "unable to register prompter")
+	}
+
+	// Perform the update.
+	// TODO: Figure out a way to monitor for cancellation.
+	err = s.manager.Update(
+		request.Specification.Session,
+		request.Specification.Configuration,
+		request.Specification.ConfigurationAlpha,
+		request.Specification.ConfigurationBeta,
+		request.Specification.Name,
+		request.Specification.Labels,
+		prompter,
+	)
+
+	// Unregister the prompter.
+	prompt.UnregisterPrompter(prompter)
+
+	// Handle any errors.
+	if err != nil {
+		return err
+	}
+
+	// Signal completion.
+	if err := stream.Send(&UpdateResponse{}); err != nil {
+		return errors.Wrap(err, "unable to send response")
+	}
+
+	// Success.
+	return nil
+}
+
+// Export exports the creation specifications for existing sessions. Each
+// session is sent in its own response, with any archive streamed in chunks
+// following it. Since URL environment variables are specific to the local
+// system and may reference credentials, they're omitted from the exported URLs.
+func (s *Server) Export(request *ExportRequest, stream Synchronization_ExportServer) error {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return errors.Wrap(err, "received invalid export request")
+	}
+
+	// Perform listing.
+	_, states, err := s.manager.List(request.Selection, 0)
+	if err != nil {
+		return err
+	}
+
+	// Convert session states to creation specifications and send them.
+	for _, state := range states {
+		specification := &CreationSpecification{
+			Alpha:              state.Session.Alpha.WithoutEnvironment(),
+			Beta:               state.Session.Beta.WithoutEnvironment(),
+			Configuration:      state.Session.Configuration,
+			ConfigurationAlpha: state.Session.ConfigurationAlpha,
+			ConfigurationBeta:  state.Session.ConfigurationBeta,
+			Name:               state.Session.Name,
+			Labels:             state.Session.Labels,
+			Paused:             state.Session.Paused,
+		}
+		if request.IncludeArchives {
+			if archive, err := s.manager.Archive(state.Session.Identifier); err != nil {
+				return errors.Wrapf(err, "unable to load archive for session %s", state.Session.Identifier)
+			} else {
+				specification.Archive = archive
+			}
+		}
+		responses, err := exportResponses(specification)
+		if err != nil {
+			return errors.Wrapf(err, "unable to export session %s", state.Session.Identifier)
+		}
+		for _, response := range responses {
+			if err := stream.Send(response); err != nil {
+				return errors.Wrap(err, "unable to send response")
+			}
+		}
+	}
+
+	// Success.
+	return nil
+}
+
 // Terminate terminates existing sessions.
 func (s *Server) Terminate(stream Synchronization_TerminateServer) error {
 	// Receive the first request.
@@ -7,8 +7,8 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/url"
 )
 
-// ensureValid verifies that a CreationSpecification is valid.
-func (s *CreationSpecification) ensureValid() error {
+// EnsureValid verifies that a CreationSpecification is valid.
+func (s *CreationSpecification) EnsureValid() error {
 	// A nil creation specification is not valid.
 	if s == nil {
 		return errors.New("nil creation specification")
@@ -59,6 +59,13 @@ func (s *CreationSpecification) ensureValid() error {
 
 	// There's no need to validate the Paused field - either value is valid.
 
+	// Verify that the archive, if present, is valid.
+	if s.Archive != nil {
+		if err := s.Archive.Root.EnsureValid(); err != nil {
+			return errors.Wrap(err, "invalid archive")
+		}
+	}
+
 	// Success.
 	return nil
 }
@@ -74,7 +81,7 @@ func (r *CreateRequest) ensureValid(first bool) error {
 	// the stream.
 	if first {
 		// Verify that the creation specification is valid.
-		if err := r.Specification.ensureValid(); err != nil {
+		if err := r.Specification.EnsureValid(); err != nil {
 			return err
 		}
 
@@ -82,12 +89,32 @@ func (r *CreateRequest) ensureValid(first bool) error {
 		if r.Response != "" {
 			return errors.New("non-empty prompt response")
 		}
+
+		// Verify that an archive isn't both embedded and streamed.
+		if r.ArchiveSize > 0 && r.Specification.Archive != nil {
+			return errors.New("archive both embedded and streamed")
+		}
+
+		// Verify that no archive chunk is present.
+		if len(r.ArchiveChunk) > 0 {
+			return errors.New("archive chunk present")
+		}
 	} else {
 		// Verify that the creation specification is nil.
 		if r.Specification != nil {
 			return errors.New("creation specification present")
 		}
 
+		// Verify that the archive size is unset.
+		if r.ArchiveSize != 0 {
+			return errors.New("archive size present")
+		}
+
+		// Verify that prompt responses and archive chunks aren't combined.
+		if r.Response != "" && len(r.ArchiveChunk) > 0 {
+			return errors.New("prompt response and archive chunk both present")
+		}
+
 		// We can't really validate the response field, and an empty value may
 		// be appropriate. It's up to the process performing the prompting to
 		// decide.
@@ -161,6 +188,40 @@ func (r *ListResponse) EnsureValid() error {
 	return nil
 }
 
+// ensureValid verifies that a WatchRequest is valid.
+func (r *WatchRequest) ensureValid() error {
+	// A nil watch request is not valid.
+	if r == nil {
+		return errors.New("nil watch request")
+	}
+
+	// Validate the session specification.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid session specification")
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that a WatchResponse is valid.
+func (r *WatchResponse) EnsureValid() error {
+	// A nil watch response is not valid.
+	if r == nil {
+		return errors.New("nil watch response")
+	}
+
+	// Ensure that all events are valid.
+	for _, e := range r.Events {
+		if err := e.EnsureValid(); err != nil {
+			return errors.Wrap(err, "invalid event")
+		}
+	}
+
+	// Success.
+	return nil
+}
+
 // ensureValid verifies that a FlushRequest is valid.
 func (r *FlushRequest) ensureValid(first bool) error {
 	// A nil flush request is not valid.
@@ -303,6 +364,157 @@ func (r *ResumeResponse) EnsureValid() error {
 	return nil
 }
 
+// EnsureValid verifies that an UpdateSpecification is valid.
+func (s *UpdateSpecification) EnsureValid() error {
+	// A nil update specification is not valid.
+	if s == nil {
+		return errors.New("nil update specification")
+	}
+
+	// Verify that a session has been specified.
+	if s.Session == "" {
+		return errors.New("empty session specification")
+	}
+
+	// Verify that the configuration is valid.
+	if err := s.Configuration.EnsureValid(false); err != nil {
+		return errors.Wrap(err, "invalid session configuration")
+	}
+
+	// Verify that the alpha-specific configuration is valid.
+	if err := s.ConfigurationAlpha.EnsureValid(true); err != nil {
+		return errors.Wrap(err, "invalid alpha-specific configuration")
+	}
+
+	// Verify that the beta-specific configuration is valid.
+	if err := s.ConfigurationBeta.EnsureValid(true); err != nil {
+		return errors.Wrap(err, "invalid beta-specific configuration")
+	}
+
+	// Verify that the name is valid.
+	if err := selection.EnsureNameValid(s.Name); err != nil {
+		return errors.Wrap(err, "invalid name")
+	}
+
+	// Verify that labels are valid.
+	for k, v := range s.Labels {
+		if err := selection.EnsureLabelKeyValid(k); err != nil {
+			return errors.Wrap(err, "invalid label key")
+		} else if err = selection.EnsureLabelValueValid(v); err != nil {
+			return errors.Wrap(err, "invalid label value")
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid verifies that an UpdateRequest is valid.
+func (r *UpdateRequest) ensureValid(first bool) error {
+	// A nil update request is not valid.
+	if r == nil {
+		return errors.New("nil update request")
+	}
+
+	// Handle validation based on whether or not this is the first request in
+	// the stream.
+	if first {
+		// Verify that the update specification is valid.
+		if err := r.Specification.EnsureValid(); err != nil {
+			return err
+		}
+
+		// Verify that the response field is empty.
+		if r.Response != "" {
+			return errors.New("non-empty prompt response")
+		}
+	} else {
+		// Verify that the update specification is nil.
+		if r.Specification != nil {
+			return errors.New("update specification present")
+		}
+
+		// We can't really validate the response field, and an empty value may
+		// be appropriate. It's up to the process performing the prompting to
+		// decide.
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that an UpdateResponse is valid.
+func (r *UpdateResponse) EnsureValid() error {
+	// A nil update response is not valid.
+	if r == nil {
+		return errors.New("nil update response")
+	}
+
+	// Count the number of fields that are set.
+	var fieldsSet uint
+	if r.Message != "" {
+		fieldsSet++
+	}
+	if r.Prompt != "" {
+		fieldsSet++
+	}
+
+	// Enforce that at most a single field is set. If neither is set, then
+	// this indicates completion.
+	if fieldsSet > 1 {
+		return errors.New("multiple fields set")
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid verifies that an ExportRequest is valid.
+func (r *ExportRequest) ensureValid() error {
+	// A nil export request is not valid.
+	if r == nil {
+		return errors.New("nil export request")
+	}
+
+	// Validate the session specification.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid session specification")
+	}
+
+	// There's no need to validate the archive inclusion flag - either value is
+	// valid.
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that an ExportResponse is valid.
+func (r *ExportResponse) EnsureValid() error {
+	// A nil export response is not valid.
+	if r == nil {
+		return errors.New("nil export response")
+	}
+
+	// Each response should either carry a specification (along with the size
+	// of any archive that follows it) or an archive chunk.
+	if r.Specification != nil {
+		if err := r.Specification.EnsureValid(); err != nil {
+			return errors.Wrap(err, "invalid creation specification")
+		} else if r.ArchiveSize > 0 && r.Specification.Archive != nil {
+			return errors.New("archive both embedded and streamed")
+		} else if len(r.ArchiveChunk) > 0 {
+			return errors.New("archive chunk present with specification")
+		}
+	} else if r.ArchiveSize != 0 {
+		return errors.New("archive size present without specification")
+	} else if len(r.ArchiveChunk) == 0 {
+		return errors.New("empty export response")
+	}
+
+	// Success.
+	return nil
+}
+
 // ensureValid verifies that a TerminateRequest is valid.
 func (r *TerminateRequest) ensureValid(first bool) error {
 	// A nil terminate request is not valid.
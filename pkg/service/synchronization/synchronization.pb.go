@@ -9,6 +9,7 @@ import (
 	proto "github.com/golang/protobuf/proto"
 	selection "github.com/mutagen-io/mutagen/pkg/selection"
 	synchronization "github.com/mutagen-io/mutagen/pkg/synchronization"
+	core "github.com/mutagen-io/mutagen/pkg/synchronization/core"
 	url "github.com/mutagen-io/mutagen/pkg/url"
 	grpc "google.golang.org/grpc"
 	math "math"
@@ -46,10 +47,14 @@ type CreationSpecification struct {
 	// Labels are the labels for the session object.
 	Labels map[string]string `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// Paused indicates whether or not to create the session pre-paused.
-	Paused               bool     `protobuf:"varint,8,opt,name=paused,proto3" json:"paused,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Paused bool `protobuf:"varint,8,opt,name=paused,proto3" json:"paused,omitempty"`
+	// Archive is the initial archive for the session. It is optional and is
+	// only set when recreating a previously exported session. Sessions with a
+	// non-empty archive are always created paused.
+	Archive              *core.Archive `protobuf:"bytes,9,opt,name=archive,proto3" json:"archive,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *CreationSpecification) Reset()         { *m = CreationSpecification{} }
@@ -133,12 +138,26 @@ func (m *CreationSpecification) GetPaused() bool {
 	return false
 }
 
+func (m *CreationSpecification) GetArchive() *core.Archive {
+	if m != nil {
+		return m.Archive
+	}
+	return nil
+}
+
 type CreateRequest struct {
-	Specification        *CreationSpecification `protobuf:"bytes,1,opt,name=specification,proto3" json:"specification,omitempty"`
-	Response             string                 `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Specification *CreationSpecification `protobuf:"bytes,1,opt,name=specification,proto3" json:"specification,omitempty"`
+	Response      string                 `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	// ArchiveSize is the size of a serialized initial archive that follows the
+	// initial request in archive chunks. It may only be set on the initial
+	// request, in which case the specification's archive must be unset. This
+	// allows archives to exceed the maximum message size.
+	ArchiveSize uint64 `protobuf:"varint,3,opt,name=archiveSize,proto3" json:"archiveSize,omitempty"`
+	// ArchiveChunk is a chunk of the serialized initial archive.
+	ArchiveChunk         []byte   `protobuf:"bytes,4,opt,name=archiveChunk,proto3" json:"archiveChunk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
@@ -180,6 +199,20 @@ func (m *CreateRequest) GetResponse() string {
 	return ""
 }
 
+func (m *CreateRequest) GetArchiveSize() uint64 {
+	if m != nil {
+		return m.ArchiveSize
+	}
+	return 0
+}
+
+func (m *CreateRequest) GetArchiveChunk() []byte {
+	if m != nil {
+		return m.ArchiveChunk
+	}
+	return nil
+}
+
 type CreateResponse struct {
 	Session              string   `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
 	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
@@ -329,6 +362,84 @@ func (m *ListResponse) GetSessionStates() []*synchronization.State {
 	return nil
 }
 
+type WatchRequest struct {
+	Selection            *selection.Selection `protobuf:"bytes,1,opt,name=selection,proto3" json:"selection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2876ddae139dc773, []int{5}
+}
+
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchRequest.Unmarshal(m, b)
+}
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+func (m *WatchRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchRequest.Size(m)
+}
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+func (m *WatchRequest) GetSelection() *selection.Selection {
+	if m != nil {
+		return m.Selection
+	}
+	return nil
+}
+
+type WatchResponse struct {
+	Events               []*synchronization.Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *WatchResponse) Reset()         { *m = WatchResponse{} }
+func (m *WatchResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchResponse) ProtoMessage()    {}
+func (*WatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2876ddae139dc773, []int{6}
+}
+
+func (m *WatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchResponse.Unmarshal(m, b)
+}
+func (m *WatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchResponse.Merge(m, src)
+}
+func (m *WatchResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchResponse.Size(m)
+}
+func (m *WatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchResponse proto.InternalMessageInfo
+
+func (m *WatchResponse) GetEvents() []*synchronization.Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
 type FlushRequest struct {
 	Selection            *selection.Selection `protobuf:"bytes,1,opt,name=selection,proto3" json:"selection,omitempty"`
 	SkipWait             bool                 `protobuf:"varint,2,opt,name=skipWait,proto3" json:"skipWait,omitempty"`
@@ -341,7 +452,7 @@ func (m *FlushRequest) Reset()         { *m = FlushRequest{} }
 func (m *FlushRequest) String() string { return proto.CompactTextString(m) }
 func (*FlushRequest) ProtoMessage()    {}
 func (*FlushRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2876ddae139dc773, []int{5}
+	return fileDescriptor_2876ddae139dc773, []int{7}
 }
 
 func (m *FlushRequest) XXX_Unmarshal(b []byte) error {
@@ -387,7 +498,7 @@ func (m *FlushResponse) Reset()         { *m = FlushResponse{} }
 func (m *FlushResponse) String() string { return proto.CompactTextString(m) }
 func (*FlushResponse) ProtoMessage()    {}
 func (*FlushResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2876ddae139dc773, []int{6}
+	return fileDescriptor_2876ddae139dc773, []int{8}
 }
 
 func (m *FlushResponse) XXX_Unmarshal(b []byte) error {
@@ -426,7 +537,7 @@ func (m *PauseRequest) Reset()         { *m = PauseRequest{} }
 func (m *PauseRequest) String() string { return proto.CompactTextString(m) }
 func (*PauseRequest) ProtoMessage()    {}
 func (*PauseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2876ddae139dc773, []int{7}
+	return fileDescriptor_2876ddae139dc773, []int{9}
 }
 
 func (m *PauseRequest) XXX_Unmarshal(b []byte) error {
@@ -465,7 +576,7 @@ func (m *PauseResponse) Reset()         { *m = PauseResponse{} }
 func (m *PauseResponse) String() string { return proto.CompactTextString(m) }
 func (*PauseResponse) ProtoMessage()    {}
 func (*PauseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2876ddae139dc773, []int{8}
+	return fileDescriptor_2876ddae139dc773, []int{10}
 }
 
 func (m *PauseResponse) XXX_Unmarshal(b []byte) error {
@@ -505,7 +616,7 @@ func (m *ResumeRequest) Reset()         { *m = ResumeRequest{} }
 func (m *ResumeRequest) String() string { return proto.CompactTextString(m) }
 func (*ResumeRequest) ProtoMessage()    {}
 func (*ResumeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2876ddae139dc773, []int{9}
+	return fileDescriptor_2876ddae139dc773, []int{11}
 }
 
 func (m *ResumeRequest) XXX_Unmarshal(b []byte) error {
@@ -552,7 +663,7 @@ func (m *ResumeResponse) Reset()         { *m = ResumeResponse{} }
 func (m *ResumeResponse) String() string { return proto.CompactTextString(m) }
 func (*ResumeResponse) ProtoMessage()    {}
 func (*ResumeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2876ddae139dc773, []int{10}
+	return fileDescriptor_2876ddae139dc773, []int{12}
 }
 
 func (m *ResumeResponse) XXX_Unmarshal(b []byte) error {
@@ -587,6 +698,294 @@ func (m *ResumeResponse) GetPrompt() string {
 	return ""
 }
 
+type UpdateSpecification struct {
+	// Session is the specification (identifier or name) of the session to
+	// update. It must match exactly one session.
+	Session string `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	// Configuration is the new base session configuration.
+	Configuration *synchronization.Configuration `protobuf:"bytes,2,opt,name=configuration,proto3" json:"configuration,omitempty"`
+	// ConfigurationAlpha is the new alpha-specific session configuration.
+	ConfigurationAlpha *synchronization.Configuration `protobuf:"bytes,3,opt,name=configurationAlpha,proto3" json:"configurationAlpha,omitempty"`
+	// ConfigurationBeta is the new beta-specific session configuration.
+	ConfigurationBeta *synchronization.Configuration `protobuf:"bytes,4,opt,name=configurationBeta,proto3" json:"configurationBeta,omitempty"`
+	// Name is the new name for the session object.
+	Name string `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	// Labels are the new labels for the session object.
+	Labels               map[string]string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *UpdateSpecification) Reset()         { *m = UpdateSpecification{} }
+func (m *UpdateSpecification) String() string { return proto.CompactTextString(m) }
+func (*UpdateSpecification) ProtoMessage()    {}
+func (*UpdateSpecification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2876ddae139dc773, []int{13}
+}
+
+func (m *UpdateSpecification) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateSpecification.Unmarshal(m, b)
+}
+func (m *UpdateSpecification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateSpecification.Marshal(b, m, deterministic)
+}
+func (m *UpdateSpecification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateSpecification.Merge(m, src)
+}
+func (m *UpdateSpecification) XXX_Size() int {
+	return xxx_messageInfo_UpdateSpecification.Size(m)
+}
+func (m *UpdateSpecification) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateSpecification.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateSpecification proto.InternalMessageInfo
+
+func (m *UpdateSpecification) GetSession() string {
+	if m != nil {
+		return m.Session
+	}
+	return ""
+}
+
+func (m *UpdateSpecification) GetConfiguration() *synchronization.Configuration {
+	if m != nil {
+		return m.Configuration
+	}
+	return nil
+}
+
+func (m *UpdateSpecification) GetConfigurationAlpha() *synchronization.Configuration {
+	if m != nil {
+		return m.ConfigurationAlpha
+	}
+	return nil
+}
+
+func (m *UpdateSpecification) GetConfigurationBeta() *synchronization.Configuration {
+	if m != nil {
+		return m.ConfigurationBeta
+	}
+	return nil
+}
+
+func (m *UpdateSpecification) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateSpecification) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type UpdateRequest struct {
+	Specification        *UpdateSpecification `protobuf:"bytes,1,opt,name=specification,proto3" json:"specification,omitempty"`
+	Response             string               `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
+func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateRequest) ProtoMessage()    {}
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2876ddae139dc773, []int{14}
+}
+
+func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
+}
+func (m *UpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRequest.Merge(m, src)
+}
+func (m *UpdateRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateRequest.Size(m)
+}
+func (m *UpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRequest proto.InternalMessageInfo
+
+func (m *UpdateRequest) GetSpecification() *UpdateSpecification {
+	if m != nil {
+		return m.Specification
+	}
+	return nil
+}
+
+func (m *UpdateRequest) GetResponse() string {
+	if m != nil {
+		return m.Response
+	}
+	return ""
+}
+
+type UpdateResponse struct {
+	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Prompt               string   `protobuf:"bytes,2,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateResponse) Reset()         { *m = UpdateResponse{} }
+func (m *UpdateResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateResponse) ProtoMessage()    {}
+func (*UpdateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2876ddae139dc773, []int{15}
+}
+
+func (m *UpdateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateResponse.Unmarshal(m, b)
+}
+func (m *UpdateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateResponse.Merge(m, src)
+}
+func (m *UpdateResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateResponse.Size(m)
+}
+func (m *UpdateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateResponse proto.InternalMessageInfo
+
+func (m *UpdateResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *UpdateResponse) GetPrompt() string {
+	if m != nil {
+		return m.Prompt
+	}
+	return ""
+}
+
+type ExportRequest struct {
+	Selection            *selection.Selection `protobuf:"bytes,1,opt,name=selection,proto3" json:"selection,omitempty"`
+	IncludeArchives      bool                 `protobuf:"varint,2,opt,name=includeArchives,proto3" json:"includeArchives,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ExportRequest) Reset()         { *m = ExportRequest{} }
+func (m *ExportRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportRequest) ProtoMessage()    {}
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2876ddae139dc773, []int{16}
+}
+
+func (m *ExportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportRequest.Unmarshal(m, b)
+}
+func (m *ExportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportRequest.Merge(m, src)
+}
+func (m *ExportRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportRequest.Size(m)
+}
+func (m *ExportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportRequest proto.InternalMessageInfo
+
+func (m *ExportRequest) GetSelection() *selection.Selection {
+	if m != nil {
+		return m.Selection
+	}
+	return nil
+}
+
+func (m *ExportRequest) GetIncludeArchives() bool {
+	if m != nil {
+		return m.IncludeArchives
+	}
+	return false
+}
+
+type ExportResponse struct {
+	// Specification is the creation specification for an exported session. If
+	// archives are included, then the specification's archive is unset and the
+	// serialized archive follows in archive chunks.
+	Specification *CreationSpecification `protobuf:"bytes,1,opt,name=specification,proto3" json:"specification,omitempty"`
+	// ArchiveSize is the size of the serialized archive that follows the
+	// specification in archive chunks.
+	ArchiveSize uint64 `protobuf:"varint,2,opt,name=archiveSize,proto3" json:"archiveSize,omitempty"`
+	// ArchiveChunk is a chunk of a serialized archive.
+	ArchiveChunk         []byte   `protobuf:"bytes,3,opt,name=archiveChunk,proto3" json:"archiveChunk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportResponse) Reset()         { *m = ExportResponse{} }
+func (m *ExportResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportResponse) ProtoMessage()    {}
+func (*ExportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2876ddae139dc773, []int{17}
+}
+
+func (m *ExportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportResponse.Unmarshal(m, b)
+}
+func (m *ExportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportResponse.Marshal(b, m, deterministic)
+}
+func (m *ExportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportResponse.Merge(m, src)
+}
+func (m *ExportResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportResponse.Size(m)
+}
+func (m *ExportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportResponse proto.InternalMessageInfo
+
+func (m *ExportResponse) GetSpecification() *CreationSpecification {
+	if m != nil {
+		return m.Specification
+	}
+	return nil
+}
+
+func (m *ExportResponse) GetArchiveSize() uint64 {
+	if m != nil {
+		return m.ArchiveSize
+	}
+	return 0
+}
+
+func (m *ExportResponse) GetArchiveChunk() []byte {
+	if m != nil {
+		return m.ArchiveChunk
+	}
+	return nil
+}
+
 type TerminateRequest struct {
 	Selection            *selection.Selection `protobuf:"bytes,1,opt,name=selection,proto3" json:"selection,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
@@ -598,7 +997,7 @@ func (m *TerminateRequest) Reset()         { *m = TerminateRequest{} }
 func (m *TerminateRequest) String() string { return proto.CompactTextString(m) }
 func (*TerminateRequest) ProtoMessage()    {}
 func (*TerminateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2876ddae139dc773, []int{11}
+	return fileDescriptor_2876ddae139dc773, []int{18}
 }
 
 func (m *TerminateRequest) XXX_Unmarshal(b []byte) error {
@@ -637,7 +1036,7 @@ func (m *TerminateResponse) Reset()         { *m = TerminateResponse{} }
 func (m *TerminateResponse) String() string { return proto.CompactTextString(m) }
 func (*TerminateResponse) ProtoMessage()    {}
 func (*TerminateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2876ddae139dc773, []int{12}
+	return fileDescriptor_2876ddae139dc773, []int{19}
 }
 
 func (m *TerminateResponse) XXX_Unmarshal(b []byte) error {
@@ -672,12 +1071,20 @@ func init() {
 	proto.RegisterType((*CreateResponse)(nil), "synchronization.CreateResponse")
 	proto.RegisterType((*ListRequest)(nil), "synchronization.ListRequest")
 	proto.RegisterType((*ListResponse)(nil), "synchronization.ListResponse")
+	proto.RegisterType((*WatchRequest)(nil), "synchronization.WatchRequest")
+	proto.RegisterType((*WatchResponse)(nil), "synchronization.WatchResponse")
 	proto.RegisterType((*FlushRequest)(nil), "synchronization.FlushRequest")
 	proto.RegisterType((*FlushResponse)(nil), "synchronization.FlushResponse")
 	proto.RegisterType((*PauseRequest)(nil), "synchronization.PauseRequest")
 	proto.RegisterType((*PauseResponse)(nil), "synchronization.PauseResponse")
 	proto.RegisterType((*ResumeRequest)(nil), "synchronization.ResumeRequest")
 	proto.RegisterType((*ResumeResponse)(nil), "synchronization.ResumeResponse")
+	proto.RegisterType((*UpdateSpecification)(nil), "synchronization.UpdateSpecification")
+	proto.RegisterMapType((map[string]string)(nil), "synchronization.UpdateSpecification.LabelsEntry")
+	proto.RegisterType((*UpdateRequest)(nil), "synchronization.UpdateRequest")
+	proto.RegisterType((*UpdateResponse)(nil), "synchronization.UpdateResponse")
+	proto.RegisterType((*ExportRequest)(nil), "synchronization.ExportRequest")
+	proto.RegisterType((*ExportResponse)(nil), "synchronization.ExportResponse")
 	proto.RegisterType((*TerminateRequest)(nil), "synchronization.TerminateRequest")
 	proto.RegisterType((*TerminateResponse)(nil), "synchronization.TerminateResponse")
 }
@@ -687,53 +1094,69 @@ func init() {
 }
 
 var fileDescriptor_2876ddae139dc773 = []byte{
-	// 723 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x5b, 0x6f, 0xd3, 0x48,
-	0x14, 0x5e, 0xe7, 0xd6, 0xf8, 0xa4, 0xee, 0x65, 0xd4, 0xad, 0xbc, 0xde, 0x6e, 0x36, 0x18, 0x09,
-	0x85, 0x87, 0x3a, 0x28, 0xbc, 0x50, 0xe0, 0x85, 0x94, 0x56, 0x02, 0x59, 0x14, 0x4d, 0x40, 0x20,
-	0x84, 0x40, 0x8e, 0x3b, 0x4d, 0xac, 0xfa, 0x56, 0x8f, 0x5d, 0x11, 0x7e, 0x33, 0x0f, 0xfc, 0x04,
-	0xe4, 0xb1, 0x27, 0xf1, 0xc4, 0x0e, 0xad, 0xe8, 0xdb, 0x9c, 0xdb, 0x77, 0x6e, 0xdf, 0x91, 0x0d,
-	0x87, 0x94, 0x44, 0xd7, 0x8e, 0x4d, 0x06, 0x74, 0xee, 0xdb, 0xb3, 0x28, 0xf0, 0x9d, 0xef, 0x56,
-	0xec, 0x04, 0xfe, 0xaa, 0x6c, 0x84, 0x51, 0x10, 0x07, 0x68, 0x7b, 0x45, 0xad, 0xfd, 0x43, 0x89,
-	0x4b, 0xec, 0x2c, 0x82, 0xbf, 0x32, 0x5f, 0xed, 0xfe, 0x2a, 0xa4, 0x1d, 0xf8, 0x17, 0xce, 0x34,
-	0x89, 0x0a, 0x80, 0xda, 0xbf, 0xa5, 0xbc, 0xb1, 0x15, 0x93, 0xdc, 0xa8, 0x24, 0x91, 0x3b, 0x48,
-	0x22, 0x37, 0x13, 0xf5, 0x1f, 0x75, 0xf8, 0xfb, 0x38, 0x22, 0xcc, 0x6f, 0x1c, 0x12, 0xdb, 0xb9,
-	0x70, 0x6c, 0x26, 0xa0, 0x2e, 0x34, 0x2d, 0x37, 0x9c, 0x59, 0xaa, 0xd4, 0x93, 0xfa, 0x9d, 0x61,
-	0xdb, 0x48, 0x83, 0xde, 0x63, 0x13, 0x67, 0x6a, 0x74, 0x00, 0x8d, 0x09, 0x89, 0x2d, 0xb5, 0xb6,
-	0x62, 0x66, 0x5a, 0xf4, 0x12, 0x14, 0xa1, 0x34, 0xb5, 0xce, 0xdc, 0xba, 0xc6, 0xea, 0x0c, 0x8e,
-	0x8b, 0x5e, 0x58, 0x0c, 0x42, 0x6f, 0x00, 0x09, 0x8a, 0x17, 0xac, 0xa0, 0xc6, 0xad, 0xa0, 0x2a,
-	0x22, 0x91, 0x09, 0xbb, 0x82, 0x76, 0x94, 0x36, 0xd0, 0xbc, 0x15, 0x5c, 0x39, 0x10, 0x21, 0x68,
-	0xf8, 0x96, 0x47, 0xd4, 0x56, 0x4f, 0xea, 0xcb, 0x98, 0xbd, 0xd1, 0x6b, 0x68, 0xb9, 0xd6, 0x84,
-	0xb8, 0x54, 0xdd, 0xe8, 0xd5, 0xfb, 0x9d, 0xe1, 0xb0, 0x0c, 0x5b, 0x35, 0x6d, 0xc3, 0x64, 0x41,
-	0x27, 0x7e, 0x1c, 0xcd, 0x71, 0x8e, 0x80, 0xf6, 0xa1, 0x15, 0x5a, 0x09, 0x25, 0xe7, 0x6a, 0xbb,
-	0x27, 0xf5, 0xdb, 0x38, 0x97, 0xb4, 0x23, 0xe8, 0x14, 0xdc, 0xd1, 0x0e, 0xd4, 0x2f, 0xc9, 0x9c,
-	0xad, 0x49, 0xc6, 0xe9, 0x13, 0xed, 0x41, 0xf3, 0xda, 0x72, 0x13, 0xc2, 0x76, 0x23, 0xe3, 0x4c,
-	0x78, 0x5a, 0x7b, 0x22, 0xe9, 0x73, 0x50, 0x58, 0x7e, 0x82, 0xc9, 0x55, 0x42, 0x68, 0x8c, 0x4c,
-	0x50, 0x68, 0xb1, 0x90, 0x7c, 0xdb, 0x0f, 0x6e, 0x57, 0x36, 0x16, 0x83, 0x91, 0x06, 0xed, 0x88,
-	0xd0, 0x30, 0xf0, 0x29, 0xcf, 0xbd, 0x90, 0xf5, 0xcf, 0xb0, 0xc5, 0x53, 0x67, 0x1a, 0xa4, 0xc2,
-	0x06, 0x25, 0x94, 0xf2, 0xac, 0x32, 0xe6, 0x62, 0x6a, 0xf1, 0x08, 0xa5, 0xd6, 0x94, 0xc3, 0x70,
-	0x91, 0xcd, 0x24, 0x0a, 0xbc, 0x30, 0x66, 0x84, 0x92, 0x71, 0x2e, 0xe9, 0x57, 0xd0, 0x31, 0x1d,
-	0x1a, 0xf3, 0xb6, 0x86, 0x20, 0x2f, 0x4e, 0x27, 0x6f, 0x69, 0xcf, 0x58, 0x1e, 0xd3, 0x98, 0xbf,
-	0xf0, 0xd2, 0x0d, 0x19, 0x80, 0xc2, 0x88, 0x5c, 0x3b, 0x41, 0x42, 0xc7, 0xe9, 0xc1, 0xbc, 0xf2,
-	0xcf, 0xc9, 0x37, 0x96, 0xbf, 0x81, 0x2b, 0x2c, 0xba, 0x0b, 0x9b, 0x59, 0xca, 0xbc, 0x9d, 0x2e,
-	0x00, 0x5d, 0xc6, 0x49, 0x2c, 0xae, 0xa0, 0x41, 0xcf, 0x41, 0xc9, 0xfb, 0x63, 0x20, 0x54, 0xad,
-	0x31, 0x86, 0xec, 0x97, 0x46, 0xcd, 0xcc, 0x58, 0x74, 0xd6, 0xbf, 0xc0, 0xe6, 0xa9, 0x9b, 0xd0,
-	0xd9, 0x5d, 0x3a, 0xd4, 0xa0, 0x4d, 0x2f, 0x9d, 0xf0, 0x83, 0xe5, 0xc4, 0xac, 0xaf, 0x36, 0x5e,
-	0xc8, 0xfa, 0x43, 0x50, 0x72, 0xfc, 0xe5, 0x76, 0xf8, 0x0e, 0x24, 0x61, 0x07, 0xfa, 0x08, 0x36,
-	0xdf, 0xa6, 0x4c, 0xbc, 0x43, 0x29, 0x69, 0xba, 0x1c, 0xe3, 0xc6, 0x74, 0x5f, 0x41, 0xc1, 0x84,
-	0x26, 0x1e, 0xb9, 0x63, 0xeb, 0x6b, 0x99, 0x39, 0x82, 0x2d, 0x9e, 0xe0, 0xa6, 0x62, 0x0a, 0xfc,
-	0xab, 0x09, 0xfc, 0x3b, 0x85, 0x9d, 0x77, 0x24, 0xf2, 0x1c, 0xbf, 0x70, 0x5b, 0x7f, 0x32, 0x97,
-	0x43, 0xd8, 0x2d, 0xe0, 0xdc, 0x54, 0xce, 0xf0, 0x67, 0x1d, 0xb6, 0xc7, 0x22, 0x7d, 0xd0, 0x19,
-	0xb4, 0xb2, 0x43, 0x43, 0xdd, 0xea, 0x2b, 0xe6, 0x05, 0x6a, 0xff, 0xaf, 0xb5, 0xe7, 0x93, 0xf9,
-	0xab, 0x2f, 0x3d, 0x92, 0xd0, 0x09, 0x34, 0x52, 0xa2, 0xa3, 0x83, 0x92, 0x7b, 0xe1, 0xe4, 0xb4,
-	0xff, 0xd6, 0x58, 0x39, 0x14, 0x32, 0xa1, 0xc9, 0x18, 0x86, 0xca, 0x9e, 0x45, 0x66, 0x6b, 0xdd,
-	0x75, 0x66, 0xa1, 0x28, 0x13, 0x9a, 0x8c, 0x40, 0x15, 0x68, 0x45, 0x72, 0x56, 0xa0, 0x09, 0xbc,
-	0xcb, 0xd1, 0xce, 0xa0, 0x95, 0x51, 0xa0, 0x62, 0x66, 0x02, 0xf9, 0x2a, 0x66, 0x26, 0x72, 0x27,
-	0x07, 0xfc, 0x08, 0xf2, 0x62, 0x8f, 0xe8, 0x5e, 0x29, 0x66, 0x95, 0x2b, 0x9a, 0xfe, 0x3b, 0x97,
-	0x22, 0xf2, 0xe8, 0xd9, 0xa7, 0xa3, 0xa9, 0x13, 0xcf, 0x92, 0x89, 0x61, 0x07, 0xde, 0xc0, 0x4b,
-	0x62, 0x6b, 0x4a, 0xfc, 0x43, 0x27, 0xe0, 0xcf, 0x41, 0x78, 0x39, 0x1d, 0xac, 0xf9, 0x03, 0x99,
-	0xb4, 0xd8, 0x57, 0xff, 0xf1, 0xaf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xed, 0x6a, 0xc9, 0x85, 0xa3,
-	0x08, 0x00, 0x00,
+	// 992 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdd, 0x72, 0xdb, 0x44,
+	0x14, 0x46, 0xfe, 0x51, 0xec, 0x63, 0x2b, 0x69, 0x97, 0xd2, 0x11, 0xa2, 0x38, 0x46, 0x30, 0xe0,
+	0x5e, 0x44, 0xce, 0x98, 0x1b, 0x0a, 0xcc, 0x30, 0x4d, 0x48, 0x07, 0x32, 0x86, 0x32, 0x6b, 0x3a,
+	0x65, 0x18, 0x06, 0x46, 0x51, 0xb6, 0xb6, 0x26, 0xfa, 0xab, 0x56, 0x32, 0x4d, 0xdf, 0x83, 0x37,
+	0xe0, 0x92, 0x77, 0xe0, 0x45, 0x78, 0x18, 0x46, 0xab, 0x5d, 0x47, 0xab, 0x1f, 0x12, 0xea, 0xdc,
+	0xe9, 0xfc, 0x7d, 0x7b, 0xce, 0xd9, 0x73, 0xf6, 0x1b, 0xc1, 0x01, 0x25, 0xf1, 0xda, 0x75, 0xc8,
+	0x94, 0x5e, 0x06, 0xce, 0x2a, 0x0e, 0x03, 0xf7, 0xb5, 0x9d, 0xb8, 0x61, 0x50, 0x96, 0xad, 0x28,
+	0x0e, 0x93, 0x10, 0xed, 0x95, 0xd4, 0xc6, 0xbb, 0x94, 0x78, 0xc4, 0xc9, 0x23, 0xc4, 0x57, 0xee,
+	0x6b, 0x7c, 0x58, 0x86, 0x74, 0xc2, 0xe0, 0x85, 0xbb, 0x4c, 0xe3, 0x02, 0xa0, 0x61, 0x56, 0x9d,
+	0x62, 0x32, 0xb5, 0x63, 0x67, 0xe5, 0xae, 0x09, 0xf7, 0x79, 0xaf, 0xec, 0x43, 0xd6, 0x24, 0x48,
+	0x9a, 0x8c, 0x34, 0xb1, 0x13, 0x11, 0xa9, 0xa5, 0xb1, 0x37, 0x4d, 0x63, 0x2f, 0x17, 0xcd, 0x3f,
+	0x3a, 0xf0, 0xce, 0x71, 0x4c, 0x98, 0xdf, 0x22, 0x22, 0x8e, 0xfb, 0xc2, 0x75, 0x98, 0x80, 0x46,
+	0xd0, 0xb5, 0xbd, 0x68, 0x65, 0xeb, 0xca, 0x58, 0x99, 0x0c, 0x66, 0x3d, 0x2b, 0x0b, 0x7a, 0x86,
+	0xe7, 0x38, 0x57, 0xa3, 0x07, 0xd0, 0x39, 0x23, 0x89, 0xad, 0xb7, 0x4a, 0x66, 0xa6, 0x45, 0x5f,
+	0x83, 0x26, 0xd5, 0xa6, 0xb7, 0x99, 0xdb, 0xc8, 0x2a, 0x37, 0xf1, 0xb8, 0xe8, 0x85, 0xe5, 0x20,
+	0xf4, 0x3d, 0x20, 0x49, 0xf1, 0x98, 0x25, 0xd4, 0xb9, 0x11, 0x54, 0x4d, 0x24, 0x9a, 0xc3, 0x5d,
+	0x49, 0x7b, 0x94, 0x15, 0xd0, 0xbd, 0x11, 0x5c, 0x35, 0x10, 0x21, 0xe8, 0x04, 0xb6, 0x4f, 0x74,
+	0x75, 0xac, 0x4c, 0xfa, 0x98, 0x7d, 0xa3, 0x53, 0x50, 0x3d, 0xfb, 0x8c, 0x78, 0x54, 0xdf, 0x19,
+	0xb7, 0x27, 0x83, 0xd9, 0xac, 0x0a, 0x5b, 0xd7, 0x6d, 0x6b, 0xce, 0x82, 0x4e, 0x82, 0x24, 0xbe,
+	0xc4, 0x1c, 0x01, 0xdd, 0x07, 0x35, 0xb2, 0x53, 0x4a, 0xce, 0xf5, 0xde, 0x58, 0x99, 0xf4, 0x30,
+	0x97, 0xd0, 0x27, 0xb0, 0xc3, 0xa7, 0x41, 0xef, 0xb3, 0xdc, 0x35, 0x2b, 0x1b, 0x11, 0xeb, 0x71,
+	0xae, 0xc4, 0xc2, 0x6a, 0x3c, 0x82, 0x41, 0x01, 0x17, 0xdd, 0x81, 0xf6, 0x05, 0xb9, 0x64, 0xf7,
+	0xd9, 0xc7, 0xd9, 0x27, 0xba, 0x07, 0xdd, 0xb5, 0xed, 0xa5, 0x84, 0x5d, 0x62, 0x1f, 0xe7, 0xc2,
+	0xe7, 0xad, 0xcf, 0x14, 0xf3, 0x6f, 0x05, 0x34, 0x96, 0x29, 0xc1, 0xe4, 0x65, 0x4a, 0x68, 0x82,
+	0xe6, 0xa0, 0xd1, 0x62, 0xca, 0x7c, 0x2e, 0x3e, 0xbe, 0x59, 0x81, 0x58, 0x0e, 0x46, 0x06, 0xf4,
+	0x62, 0x42, 0xa3, 0x30, 0xa0, 0xe2, 0xf0, 0x8d, 0x8c, 0xc6, 0x30, 0xe0, 0x15, 0x2c, 0xdc, 0xd7,
+	0x84, 0x4d, 0x4e, 0x07, 0x17, 0x55, 0xc8, 0x84, 0x21, 0x17, 0x8f, 0x57, 0x69, 0x70, 0xc1, 0x26,
+	0x62, 0x88, 0x25, 0x9d, 0xf9, 0x0b, 0xec, 0x8a, 0x02, 0x38, 0xae, 0x0e, 0x3b, 0x94, 0x50, 0x2a,
+	0x72, 0xef, 0x63, 0x21, 0x66, 0x16, 0x9f, 0x50, 0x6a, 0x2f, 0x45, 0x32, 0x42, 0x64, 0x77, 0x10,
+	0x87, 0x7e, 0x94, 0xb0, 0x34, 0xfa, 0x98, 0x4b, 0xe6, 0x4b, 0x18, 0xcc, 0x5d, 0x9a, 0x88, 0xe6,
+	0xcc, 0xa0, 0xbf, 0xd9, 0x75, 0xde, 0x98, 0x7b, 0xd6, 0x46, 0x63, 0x2d, 0xc4, 0x17, 0xbe, 0x72,
+	0x43, 0x16, 0xa0, 0x28, 0x26, 0x6b, 0x37, 0x4c, 0xe9, 0x22, 0x5b, 0xd0, 0x6f, 0x83, 0x73, 0xf2,
+	0x8a, 0x9d, 0xdf, 0xc1, 0x35, 0x16, 0xd3, 0x83, 0x61, 0x7e, 0x24, 0x2f, 0x67, 0x04, 0x40, 0xaf,
+	0xe2, 0x14, 0x16, 0x57, 0xd0, 0xa0, 0x2f, 0x41, 0xe3, 0xf5, 0x31, 0x10, 0xaa, 0xb7, 0xd8, 0x44,
+	0xde, 0xaf, 0x5c, 0x18, 0x33, 0x63, 0xd9, 0xd9, 0x3c, 0x82, 0xe1, 0x73, 0x3b, 0x71, 0x56, 0x5b,
+	0x54, 0x68, 0x7e, 0x05, 0x1a, 0xc7, 0xe0, 0x29, 0x5b, 0xa0, 0xb2, 0x87, 0x8a, 0xea, 0x4a, 0x43,
+	0x2e, 0x27, 0x99, 0x19, 0x73, 0x2f, 0xf3, 0x57, 0x18, 0x3e, 0xf1, 0x52, 0xba, 0x4d, 0x12, 0xd9,
+	0xa4, 0xd1, 0x0b, 0x37, 0x7a, 0x6e, 0xbb, 0x09, 0x6b, 0x6e, 0x0f, 0x6f, 0x64, 0xf3, 0x21, 0x68,
+	0x1c, 0xff, 0x6a, 0x44, 0xc4, 0x20, 0x28, 0xd2, 0x20, 0x64, 0xfd, 0xf8, 0x21, 0x5b, 0xbf, 0x6d,
+	0xfa, 0xf1, 0x10, 0x34, 0x8e, 0x71, 0xed, 0x71, 0xbf, 0x81, 0x86, 0x09, 0x4d, 0x7d, 0xb2, 0x65,
+	0xe9, 0x4d, 0x4b, 0x66, 0x1e, 0xc1, 0xae, 0x38, 0xe0, 0xba, 0x64, 0x0a, 0x4b, 0xd0, 0x92, 0x96,
+	0xe0, 0xaf, 0x36, 0xbc, 0xfd, 0x2c, 0x3a, 0xb7, 0x13, 0x22, 0x53, 0x47, 0xf3, 0xa2, 0x55, 0x68,
+	0xa1, 0x75, 0x7b, 0xb4, 0xd0, 0xbe, 0x5d, 0x5a, 0xe8, 0x6c, 0x4b, 0x0b, 0xdd, 0x02, 0x2d, 0x7c,
+	0xb3, 0xa1, 0x05, 0x95, 0x0d, 0xfe, 0x61, 0x05, 0xb6, 0xa6, 0x8f, 0x75, 0xa4, 0xb0, 0xcd, 0x9b,
+	0xfe, 0x3b, 0x68, 0xf9, 0x29, 0x62, 0xa6, 0x4e, 0xeb, 0x9f, 0xf4, 0x8f, 0x6e, 0x92, 0xdc, 0xff,
+	0x78, 0xd0, 0xb3, 0x59, 0x13, 0x07, 0xbf, 0xf1, 0xac, 0xf9, 0xa0, 0x9d, 0xbc, 0x8a, 0xc2, 0x78,
+	0xab, 0x27, 0x77, 0x02, 0x7b, 0x6e, 0xe0, 0x78, 0xe9, 0x39, 0xe1, 0x5c, 0x49, 0xf9, 0x93, 0x50,
+	0x56, 0x9b, 0x7f, 0x2a, 0xb0, 0x2b, 0xce, 0xe3, 0x39, 0xdf, 0x2e, 0x01, 0x96, 0x48, 0xae, 0x75,
+	0x3d, 0xc9, 0xb5, 0x6b, 0x48, 0xee, 0x09, 0xdc, 0xf9, 0x91, 0xc4, 0xbe, 0x1b, 0x14, 0x6e, 0xf5,
+	0x4d, 0x5e, 0xa6, 0x03, 0xb8, 0x5b, 0xc0, 0xb9, 0xee, 0x92, 0x66, 0xff, 0x74, 0x61, 0x6f, 0x21,
+	0x57, 0x8d, 0x9e, 0x82, 0x9a, 0xf3, 0x2d, 0x1a, 0xd5, 0x77, 0x44, 0x24, 0x68, 0xec, 0x37, 0xda,
+	0xf9, 0xbc, 0xbc, 0x35, 0x51, 0x0e, 0x15, 0x74, 0x02, 0x9d, 0x8c, 0xef, 0xd0, 0x83, 0x8a, 0x7b,
+	0x81, 0x79, 0x8d, 0xf7, 0x1b, 0xac, 0x02, 0x0a, 0x9d, 0x42, 0x97, 0x91, 0x10, 0xaa, 0x7a, 0x16,
+	0x09, 0xce, 0x18, 0x35, 0x99, 0x05, 0xd2, 0xa1, 0x82, 0xe6, 0xd0, 0x65, 0x7c, 0x51, 0x83, 0x55,
+	0xe4, 0x29, 0x63, 0xd4, 0x64, 0x96, 0x0a, 0x9c, 0x43, 0x97, 0xd1, 0x41, 0x0d, 0x5a, 0x91, 0x6a,
+	0x8c, 0x51, 0x93, 0x59, 0x42, 0x7b, 0x0a, 0x6a, 0xfe, 0xa0, 0xd7, 0xf4, 0x5f, 0xa2, 0x12, 0x63,
+	0xbf, 0xd1, 0x5e, 0x06, 0xcc, 0xb7, 0xb6, 0x06, 0x50, 0x7a, 0x47, 0x8c, 0xfd, 0x46, 0xbb, 0x04,
+	0xf8, 0x1d, 0xa8, 0xf9, 0x4a, 0xd5, 0x00, 0x4a, 0xbb, 0x6d, 0xec, 0x37, 0xda, 0x0b, 0x97, 0xf1,
+	0x13, 0xf4, 0x37, 0x33, 0x8b, 0x3e, 0xa8, 0x44, 0x94, 0xf7, 0xc2, 0x30, 0xff, 0xcb, 0xa5, 0x98,
+	0xe8, 0xd1, 0x17, 0x3f, 0x3f, 0x5a, 0xba, 0xc9, 0x2a, 0x3d, 0xb3, 0x9c, 0xd0, 0x9f, 0xfa, 0x69,
+	0x62, 0x2f, 0x49, 0x70, 0xe0, 0x86, 0xe2, 0x73, 0x1a, 0x5d, 0x2c, 0xa7, 0x0d, 0x7f, 0x89, 0x67,
+	0x2a, 0xfb, 0xb1, 0xfa, 0xf4, 0xdf, 0x01, 0x00, 0x68, 0x5b, 0xd3, 0x4c, 0x47, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -750,9 +1173,12 @@ const _ = grpc.SupportPackageIsVersion4
 type SynchronizationClient interface {
 	Create(ctx context.Context, opts ...grpc.CallOption) (Synchronization_CreateClient, error)
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Synchronization_WatchClient, error)
 	Flush(ctx context.Context, opts ...grpc.CallOption) (Synchronization_FlushClient, error)
 	Pause(ctx context.Context, opts ...grpc.CallOption) (Synchronization_PauseClient, error)
 	Resume(ctx context.Context, opts ...grpc.CallOption) (Synchronization_ResumeClient, error)
+	Update(ctx context.Context, opts ...grpc.CallOption) (Synchronization_UpdateClient, error)
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (Synchronization_ExportClient, error)
 	Terminate(ctx context.Context, opts ...grpc.CallOption) (Synchronization_TerminateClient, error)
 }
 
@@ -804,8 +1230,40 @@ func (c *synchronizationClient) List(ctx context.Context, in *ListRequest, opts
 	return out, nil
 }
 
+func (c *synchronizationClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Synchronization_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Synchronization_serviceDesc.Streams[1], "/synchronization.Synchronization/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &synchronizationWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Synchronization_WatchClient interface {
+	Recv() (*WatchResponse, error)
+	grpc.ClientStream
+}
+
+type synchronizationWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *synchronizationWatchClient) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *synchronizationClient) Flush(ctx context.Context, opts ...grpc.CallOption) (Synchronization_FlushClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Synchronization_serviceDesc.Streams[1], "/synchronization.Synchronization/Flush", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Synchronization_serviceDesc.Streams[2], "/synchronization.Synchronization/Flush", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -836,7 +1294,7 @@ func (x *synchronizationFlushClient) Recv() (*FlushResponse, error) {
 }
 
 func (c *synchronizationClient) Pause(ctx context.Context, opts ...grpc.CallOption) (Synchronization_PauseClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Synchronization_serviceDesc.Streams[2], "/synchronization.Synchronization/Pause", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Synchronization_serviceDesc.Streams[3], "/synchronization.Synchronization/Pause", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -867,7 +1325,7 @@ func (x *synchronizationPauseClient) Recv() (*PauseResponse, error) {
 }
 
 func (c *synchronizationClient) Resume(ctx context.Context, opts ...grpc.CallOption) (Synchronization_ResumeClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Synchronization_serviceDesc.Streams[3], "/synchronization.Synchronization/Resume", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Synchronization_serviceDesc.Streams[4], "/synchronization.Synchronization/Resume", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -897,8 +1355,71 @@ func (x *synchronizationResumeClient) Recv() (*ResumeResponse, error) {
 	return m, nil
 }
 
+func (c *synchronizationClient) Update(ctx context.Context, opts ...grpc.CallOption) (Synchronization_UpdateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Synchronization_serviceDesc.Streams[5], "/synchronization.Synchronization/Update", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &synchronizationUpdateClient{stream}
+	return x, nil
+}
+
+type Synchronization_UpdateClient interface {
+	Send(*UpdateRequest) error
+	Recv() (*UpdateResponse, error)
+	grpc.ClientStream
+}
+
+type synchronizationUpdateClient struct {
+	grpc.ClientStream
+}
+
+func (x *synchronizationUpdateClient) Send(m *UpdateRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *synchronizationUpdateClient) Recv() (*UpdateResponse, error) {
+	m := new(UpdateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *synchronizationClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (Synchronization_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Synchronization_serviceDesc.Streams[6], "/synchronization.Synchronization/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &synchronizationExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Synchronization_ExportClient interface {
+	Recv() (*ExportResponse, error)
+	grpc.ClientStream
+}
+
+type synchronizationExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *synchronizationExportClient) Recv() (*ExportResponse, error) {
+	m := new(ExportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *synchronizationClient) Terminate(ctx context.Context, opts ...grpc.CallOption) (Synchronization_TerminateClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Synchronization_serviceDesc.Streams[4], "/synchronization.Synchronization/Terminate", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Synchronization_serviceDesc.Streams[7], "/synchronization.Synchronization/Terminate", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -932,9 +1453,12 @@ func (x *synchronizationTerminateClient) Recv() (*TerminateResponse, error) {
 type SynchronizationServer interface {
 	Create(Synchronization_CreateServer) error
 	List(context.Context, *ListRequest) (*ListResponse, error)
+	Watch(*WatchRequest, Synchronization_WatchServer) error
 	Flush(Synchronization_FlushServer) error
 	Pause(Synchronization_PauseServer) error
 	Resume(Synchronization_ResumeServer) error
+	Update(Synchronization_UpdateServer) error
+	Export(*ExportRequest, Synchronization_ExportServer) error
 	Terminate(Synchronization_TerminateServer) error
 }
 
@@ -986,6 +1510,27 @@ func _Synchronization_List_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Synchronization_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SynchronizationServer).Watch(m, &synchronizationWatchServer{stream})
+}
+
+type Synchronization_WatchServer interface {
+	Send(*WatchResponse) error
+	grpc.ServerStream
+}
+
+type synchronizationWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *synchronizationWatchServer) Send(m *WatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Synchronization_Flush_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(SynchronizationServer).Flush(&synchronizationFlushServer{stream})
 }
@@ -1064,6 +1609,53 @@ func (x *synchronizationResumeServer) Recv() (*ResumeRequest, error) {
 	return m, nil
 }
 
+func _Synchronization_Update_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SynchronizationServer).Update(&synchronizationUpdateServer{stream})
+}
+
+type Synchronization_UpdateServer interface {
+	Send(*UpdateResponse) error
+	Recv() (*UpdateRequest, error)
+	grpc.ServerStream
+}
+
+type synchronizationUpdateServer struct {
+	grpc.ServerStream
+}
+
+func (x *synchronizationUpdateServer) Send(m *UpdateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *synchronizationUpdateServer) Recv() (*UpdateRequest, error) {
+	m := new(UpdateRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Synchronization_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SynchronizationServer).Export(m, &synchronizationExportServer{stream})
+}
+
+type Synchronization_ExportServer interface {
+	Send(*ExportResponse) error
+	grpc.ServerStream
+}
+
+type synchronizationExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *synchronizationExportServer) Send(m *ExportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Synchronization_Terminate_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(SynchronizationServer).Terminate(&synchronizationTerminateServer{stream})
 }
@@ -1106,6 +1698,11 @@ var _Synchronization_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Synchronization_Watch_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Flush",
 			Handler:       _Synchronization_Flush_Handler,
@@ -1124,6 +1721,17 @@ var _Synchronization_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Update",
+			Handler:       _Synchronization_Update_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Export",
+			Handler:       _Synchronization_Export_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Terminate",
 			Handler:       _Synchronization_Terminate_Handler,
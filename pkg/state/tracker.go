@@ -1,6 +1,7 @@
 package state
 
 import (
+	"context"
 	"sync"
 )
 
@@ -56,3 +57,37 @@ func (t *Tracker) WaitForChange(previousIndex uint64) (uint64, bool) {
 	}
 	return t.index, t.poisoned
 }
+
+// WaitForChangeWithContext is equivalent to WaitForChange, but it also
+// terminates waiting (with the context's error) if the provided context is
+// cancelled before a state index change occurs.
+func (t *Tracker) WaitForChangeWithContext(ctx context.Context, previousIndex uint64) (uint64, bool, error) {
+	// Start a Goroutine to wake waiters if the context is cancelled. We
+	// broadcast with the lock held to avoid missing a waiter that has checked
+	// the context but not yet started waiting.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			t.change.L.Lock()
+			t.change.Broadcast()
+			t.change.L.Unlock()
+		case <-done:
+		}
+	}()
+
+	// Acquire the state lock and ensure its release.
+	t.change.L.Lock()
+	defer t.change.L.Unlock()
+
+	// Wait for the state index to change, tracking being terminated, or the
+	// context being cancelled.
+	for t.index == previousIndex && !t.poisoned && ctx.Err() == nil {
+		t.change.Wait()
+	}
+	if t.index == previousIndex && !t.poisoned {
+		return t.index, false, ctx.Err()
+	}
+	return t.index, t.poisoned, nil
+}
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"context"
 	"testing"
 	"time"
 )
@@ -58,3 +59,31 @@ func TestTracker(t *testing.T) {
 		t.Fatal("timeout failure on state poisoning")
 	}
 }
+
+func TestTrackerWaitForChangeWithContext(t *testing.T) {
+	// Create a tracker.
+	tracker := NewTracker()
+
+	// Verify that a pending change is reported immediately.
+	tracker.NotifyOfChange()
+	if index, poisoned, err := tracker.WaitForChangeWithContext(context.Background(), 1); err != nil {
+		t.Fatal("unexpected wait error:", err)
+	} else if poisoned || index != 2 {
+		t.Fatal("unexpected wait results:", index, poisoned)
+	}
+
+	// Verify that cancellation terminates waiting.
+	ctx, cancel := context.WithTimeout(context.Background(), trackerTestSleep)
+	defer cancel()
+	if _, _, err := tracker.WaitForChangeWithContext(ctx, 2); err != context.DeadlineExceeded {
+		t.Fatal("unexpected wait error:", err)
+	}
+
+	// Verify that poisoning is reported.
+	tracker.Poison()
+	if _, poisoned, err := tracker.WaitForChangeWithContext(context.Background(), 2); err != nil {
+		t.Fatal("unexpected wait error:", err)
+	} else if !poisoned {
+		t.Fatal("poisoning not reported")
+	}
+}
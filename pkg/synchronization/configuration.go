@@ -3,6 +3,8 @@ package synchronization
 import (
 	"github.com/pkg/errors"
 
+	"github.com/golang/protobuf/proto"
+
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
 )
@@ -131,6 +133,18 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 		}
 	}
 
+	// Verify that hooks are unset for endpoint-specific configurations (since
+	// hooks specify their own execution location) and that any specified hooks
+	// are valid.
+	if endpointSpecific && len(c.Hooks) > 0 {
+		return errors.New("hooks cannot be specified on an endpoint-specific basis")
+	}
+	for _, hook := range c.Hooks {
+		if err := hook.EnsureValid(); err != nil {
+			return errors.Wrap(err, "invalid hook")
+		}
+	}
+
 	// Success.
 	return nil
 }
@@ -249,6 +263,24 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 		result.DefaultGroup = lower.DefaultGroup
 	}
 
+	// Merge hooks. Hooks from both configurations are included, but hooks that
+	// appear in both (e.g. because the higher-priority configuration was
+	// derived from the lower-priority configuration) are only included once.
+	for _, hooks := range [][]*Hook{lower.Hooks, higher.Hooks} {
+		for _, hook := range hooks {
+			var duplicate bool
+			for _, existing := range result.Hooks {
+				if proto.Equal(existing, hook) {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				result.Hooks = append(result.Hooks, hook)
+			}
+		}
+	}
+
 	// Done.
 	return result
 }
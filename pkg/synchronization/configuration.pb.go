@@ -75,7 +75,9 @@ type Configuration struct {
 	// DefaultGroup specifies the default group identifier to use when setting
 	// ownership of new files and directories in "portable" permission
 	// propagation mode.
-	DefaultGroup         string   `protobuf:"bytes,66,opt,name=defaultGroup,proto3" json:"defaultGroup,omitempty"`
+	DefaultGroup string `protobuf:"bytes,66,opt,name=defaultGroup,proto3" json:"defaultGroup,omitempty"`
+	// Hooks specifies commands to run in response to synchronization events.
+	Hooks                []*Hook  `protobuf:"bytes,81,rep,name=hooks,proto3" json:"hooks,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -218,6 +220,13 @@ func (m *Configuration) GetDefaultGroup() string {
 	return ""
 }
 
+func (m *Configuration) GetHooks() []*Hook {
+	if m != nil {
+		return m.Hooks
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Configuration)(nil), "synchronization.Configuration")
 }
@@ -227,37 +236,39 @@ func init() {
 }
 
 var fileDescriptor_e5db9b5485282e14 = []byte{
-	// 506 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0x5f, 0x6f, 0xd3, 0x30,
-	0x14, 0xc5, 0x15, 0x81, 0x06, 0xf5, 0xd6, 0x96, 0x79, 0x30, 0x85, 0xbe, 0x2c, 0x0c, 0x04, 0x11,
-	0x82, 0x44, 0x5b, 0x05, 0x82, 0x27, 0x60, 0xe5, 0x8f, 0x2a, 0x84, 0x98, 0x1c, 0x09, 0x24, 0x5e,
-	0x26, 0x37, 0x75, 0x53, 0x6b, 0x89, 0x5d, 0x39, 0x4e, 0x47, 0xf6, 0x99, 0xf8, 0x90, 0x28, 0xb7,
-	0xce, 0x9a, 0x26, 0x59, 0xdf, 0xe2, 0x7b, 0x7e, 0xc7, 0xbe, 0xd7, 0xc7, 0x41, 0x4f, 0xd3, 0x5c,
-	0x84, 0x73, 0x25, 0x05, 0xbf, 0xa6, 0x9a, 0x4b, 0xe1, 0x87, 0x52, 0xcc, 0x78, 0x94, 0x29, 0x58,
-	0x79, 0x0b, 0x25, 0xb5, 0xc4, 0xfd, 0x1a, 0x34, 0x78, 0x36, 0xe3, 0x31, 0x4b, 0xf3, 0x54, 0xb3,
-	0xc4, 0x9f, 0xb0, 0x39, 0x5d, 0x72, 0xa9, 0xfc, 0x85, 0x92, 0x13, 0x76, 0x91, 0xc8, 0x29, 0x5b,
-	0xd9, 0x06, 0x47, 0xf5, 0xbd, 0xd3, 0x90, 0x8a, 0x2a, 0xe0, 0x34, 0x00, 0x4d, 0x23, 0xb6, 0x95,
-	0xb8, 0xa2, 0x3a, 0x9c, 0x57, 0x89, 0x97, 0xcd, 0x01, 0x14, 0xf3, 0x79, 0x24, 0xa4, 0x62, 0x17,
-	0xcb, 0x30, 0xdd, 0xda, 0x10, 0xb0, 0x15, 0xe0, 0x45, 0x2b, 0x90, 0xe6, 0x49, 0xcc, 0xc5, 0x65,
-	0x65, 0xa7, 0xe3, 0x7f, 0x3b, 0xa8, 0x3b, 0xaa, 0xde, 0x14, 0xfe, 0x8e, 0x0e, 0x6a, 0xe6, 0x1f,
-	0x72, 0xca, 0xec, 0x5d, 0xc7, 0x72, 0x7b, 0xa7, 0x8f, 0xbd, 0x62, 0x23, 0x2f, 0x68, 0x02, 0xa4,
-	0xcd, 0x85, 0x5f, 0xa1, 0xfd, 0x84, 0xfe, 0xe5, 0x49, 0x96, 0x7c, 0x11, 0x5a, 0xe5, 0x23, 0x99,
-	0x09, 0x6d, 0xef, 0x39, 0x96, 0x7b, 0x97, 0x34, 0x05, 0xfc, 0x16, 0x1d, 0x9a, 0x62, 0xa0, 0x69,
-	0xc4, 0x45, 0xf4, 0x95, 0xc7, 0x2c, 0xe0, 0xd7, 0xcc, 0xee, 0x82, 0xe5, 0x16, 0x15, 0x9f, 0xa0,
-	0x0e, 0x64, 0x06, 0x8d, 0xf6, 0xa0, 0xd1, 0x03, 0xaf, 0x8c, 0xd3, 0x3b, 0x2f, 0x25, 0xb2, 0xa6,
-	0xf0, 0x1b, 0x74, 0xbf, 0x08, 0x11, 0x1c, 0x7d, 0x33, 0x5a, 0x6d, 0x00, 0x2f, 0x30, 0x00, 0xb9,
-	0x41, 0xf1, 0x3b, 0xd4, 0x81, 0x68, 0xc1, 0xf7, 0x00, 0x7c, 0x83, 0xa6, 0xaf, 0x24, 0xc8, 0x1a,
-	0xc6, 0x43, 0xb4, 0x6b, 0xae, 0x1f, 0xbc, 0x16, 0x78, 0xf7, 0xcb, 0xeb, 0xbc, 0x11, 0x48, 0x95,
-	0x2a, 0x8e, 0x83, 0x77, 0x02, 0x96, 0x47, 0xb7, 0x1c, 0xf7, 0xbb, 0x24, 0xc8, 0x1a, 0xc6, 0xa7,
-	0xe8, 0x21, 0x2c, 0xce, 0x65, 0x1c, 0x73, 0x11, 0x8d, 0x85, 0x66, 0x6a, 0x49, 0x63, 0xfb, 0xd0,
-	0xb1, 0xdc, 0x2e, 0x69, 0xd5, 0xf0, 0x73, 0xd4, 0x9b, 0xb2, 0x19, 0xcd, 0x62, 0x3d, 0x86, 0x57,
-	0x97, 0xda, 0x47, 0xce, 0x1d, 0xb7, 0x43, 0x6a, 0x55, 0x6c, 0xa3, 0x7b, 0xdc, 0x00, 0x0e, 0x00,
-	0xe5, 0x12, 0xbf, 0x47, 0xdd, 0xd5, 0xe7, 0xaf, 0x51, 0x00, 0x3d, 0x3f, 0x31, 0x61, 0xc0, 0x98,
-	0xe3, 0xaa, 0x44, 0x36, 0x49, 0xec, 0xa2, 0xbe, 0x39, 0xa6, 0x88, 0x15, 0xcc, 0x1f, 0xa0, 0xd7,
-	0x7a, 0xb9, 0x18, 0xcd, 0x94, 0x3e, 0x73, 0xc5, 0x42, 0x2d, 0x55, 0x0e, 0xf8, 0xc7, 0xd5, 0x68,
-	0x6d, 0x1a, 0x3e, 0x46, 0x7b, 0xa6, 0xfe, 0xf3, 0x4a, 0x30, 0x65, 0x7f, 0x72, 0x2c, 0xb7, 0x43,
-	0x36, 0x6a, 0x15, 0xe6, 0x9b, 0x92, 0xd9, 0xc2, 0x3e, 0xdb, 0x60, 0xa0, 0x76, 0x36, 0xfc, 0x73,
-	0x12, 0x71, 0x3d, 0xcf, 0x26, 0x5e, 0x28, 0x13, 0x3f, 0xc9, 0x8a, 0x78, 0xc5, 0x6b, 0x2e, 0xcb,
-	0x4f, 0x7f, 0x71, 0x19, 0xf9, 0xb5, 0x80, 0x26, 0x3b, 0xf0, 0xab, 0x0d, 0xff, 0x07, 0x00, 0x00,
-	0xff, 0xff, 0xc5, 0xbe, 0x32, 0x51, 0xa3, 0x04, 0x00, 0x00,
+	// 534 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x94, 0xdf, 0x6f, 0xd3, 0x30,
+	0x10, 0xc7, 0x55, 0x0d, 0x06, 0x75, 0xd7, 0x96, 0x79, 0x6c, 0x0a, 0x7d, 0x69, 0x18, 0x08, 0x22,
+	0x7e, 0x24, 0x5a, 0x2b, 0x10, 0x3c, 0x01, 0x2b, 0xbf, 0x2a, 0x84, 0x18, 0xae, 0x04, 0x12, 0x2f,
+	0x53, 0x9a, 0xba, 0xa9, 0xd5, 0xc4, 0x57, 0x39, 0x4e, 0x47, 0xf7, 0x9f, 0xf2, 0xdf, 0xa0, 0x5c,
+	0x93, 0x35, 0x4d, 0xb2, 0xbd, 0xd9, 0xf7, 0xfd, 0x7c, 0xcf, 0x77, 0x3e, 0x27, 0xe4, 0x51, 0xb4,
+	0x92, 0xde, 0x4c, 0x81, 0x14, 0x97, 0xae, 0x16, 0x20, 0x1d, 0x0f, 0xe4, 0x54, 0xf8, 0xb1, 0xc2,
+	0x9d, 0xbd, 0x50, 0xa0, 0x81, 0xb6, 0x0b, 0x50, 0xe7, 0xf1, 0x54, 0x04, 0x3c, 0x5a, 0x45, 0x9a,
+	0x87, 0xce, 0x98, 0xcf, 0xdc, 0xa5, 0x00, 0xe5, 0x2c, 0x14, 0x8c, 0xf9, 0x79, 0x08, 0x13, 0xbe,
+	0xb6, 0x75, 0x3a, 0xc5, 0xdc, 0x33, 0x80, 0x79, 0xaa, 0x75, 0x8b, 0x5a, 0xe4, 0xb9, 0x32, 0x6f,
+	0x36, 0x4b, 0x80, 0x76, 0x7d, 0x7e, 0x23, 0x71, 0xe1, 0x6a, 0x6f, 0x96, 0x27, 0x9e, 0x95, 0x9b,
+	0x53, 0xdc, 0x11, 0xbe, 0x04, 0xc5, 0xcf, 0x97, 0x5e, 0x94, 0x67, 0xbb, 0x95, 0x6c, 0x0e, 0x78,
+	0x5a, 0x09, 0x44, 0xab, 0x30, 0x10, 0x72, 0x9e, 0xcb, 0x74, 0xfc, 0x6f, 0x97, 0x34, 0x07, 0xf9,
+	0x5b, 0xa4, 0xdf, 0xc8, 0x41, 0xc1, 0xfc, 0x1d, 0x26, 0xdc, 0x68, 0x98, 0x35, 0xab, 0xd5, 0x7b,
+	0x60, 0x27, 0x89, 0xec, 0x51, 0x19, 0x60, 0x55, 0x2e, 0xfa, 0x82, 0xec, 0x87, 0xee, 0x5f, 0x11,
+	0xc6, 0xe1, 0x27, 0xa9, 0xd5, 0x6a, 0x00, 0xb1, 0xd4, 0xc6, 0x9e, 0x59, 0xb3, 0x6e, 0xb1, 0xb2,
+	0x40, 0x5f, 0x93, 0xa3, 0x34, 0x38, 0xd2, 0xae, 0x2f, 0xa4, 0xff, 0x59, 0x04, 0x7c, 0x24, 0x2e,
+	0xb9, 0xd1, 0x44, 0xcb, 0x35, 0x2a, 0x3d, 0x21, 0x75, 0x9c, 0x27, 0x16, 0xda, 0xc2, 0x42, 0x0f,
+	0xec, 0x6c, 0xd4, 0xf6, 0x59, 0x26, 0xb1, 0x0d, 0x45, 0x5f, 0x91, 0xbb, 0xc9, 0x10, 0xd1, 0xd1,
+	0x4e, 0x5b, 0x2b, 0x34, 0x60, 0x8f, 0x52, 0x80, 0x5d, 0xa1, 0xf4, 0x0d, 0xa9, 0xe3, 0x68, 0xd1,
+	0x77, 0x0f, 0x7d, 0x9d, 0xb2, 0x2f, 0x23, 0xd8, 0x06, 0xa6, 0x7d, 0xd2, 0x48, 0xaf, 0x1f, 0xbd,
+	0x35, 0xf4, 0xee, 0x67, 0xd7, 0x79, 0x25, 0xb0, 0x3c, 0x95, 0x1c, 0x87, 0xef, 0x04, 0x2d, 0x87,
+	0xd7, 0x1c, 0xf7, 0x3b, 0x23, 0xd8, 0x06, 0xa6, 0x3d, 0x72, 0x1f, 0x37, 0x67, 0x10, 0x04, 0x42,
+	0xfa, 0x43, 0xa9, 0xb9, 0x5a, 0xba, 0x81, 0x71, 0x64, 0xd6, 0xac, 0x26, 0xab, 0xd4, 0xe8, 0x13,
+	0xd2, 0x9a, 0xf0, 0xa9, 0x1b, 0x07, 0x7a, 0x88, 0xaf, 0x2e, 0x32, 0xba, 0xe6, 0x8e, 0x55, 0x67,
+	0x85, 0x28, 0x35, 0xc8, 0x1d, 0x91, 0x02, 0x26, 0x02, 0xd9, 0x96, 0xbe, 0x25, 0xcd, 0xf5, 0xf2,
+	0xd7, 0x60, 0x84, 0x35, 0x3f, 0x4c, 0x87, 0x81, 0x6d, 0x0e, 0xf3, 0x12, 0xdb, 0x26, 0xa9, 0x45,
+	0xda, 0xe9, 0x31, 0xc9, 0x58, 0xd1, 0xfc, 0x0e, 0x6b, 0x2d, 0x86, 0x93, 0xd6, 0xd2, 0xd0, 0x47,
+	0xa1, 0xb8, 0xa7, 0x41, 0xad, 0x10, 0x7f, 0xbf, 0x6e, 0xad, 0x4a, 0xa3, 0xc7, 0x64, 0x2f, 0x8d,
+	0xff, 0xb8, 0x90, 0x5c, 0x19, 0x1f, 0xcc, 0x9a, 0x55, 0x67, 0x5b, 0xb1, 0x1c, 0xf3, 0x45, 0x41,
+	0xbc, 0x30, 0x4e, 0xb7, 0x18, 0x8c, 0xd1, 0xe7, 0xe4, 0x76, 0xf2, 0x5f, 0x88, 0x8c, 0x9f, 0xe6,
+	0x8e, 0xd5, 0xe8, 0x1d, 0x96, 0x86, 0xf1, 0x15, 0x60, 0xce, 0xd6, 0xcc, 0x69, 0xff, 0xcf, 0x89,
+	0x2f, 0xf4, 0x2c, 0x1e, 0xdb, 0x1e, 0x84, 0x4e, 0x18, 0x27, 0x6f, 0x41, 0xbe, 0x14, 0x90, 0x2d,
+	0x9d, 0xc5, 0xdc, 0x77, 0x0a, 0x09, 0xc6, 0xbb, 0xf8, 0x5d, 0xf6, 0xff, 0x0f, 0x00, 0x14, 0xd8,
+	0xa4, 0x28, 0xec, 0x04, 0x00, 0x00,
 }
@@ -1,3 +1,38 @@
 package synchronization
 
-// TODO: Implement.
+import (
+	"testing"
+)
+
+// TestMergeConfigurationsHooks tests that MergeConfigurations combines hooks
+// from both configurations without duplicating hooks that appear in both.
+func TestMergeConfigurationsHooks(t *testing.T) {
+	// Create hooks.
+	inherited := &Hook{
+		Trigger: HookTrigger_HookTriggerCycleCompleted,
+		Command: "echo inherited",
+	}
+	added := &Hook{
+		Trigger: HookTrigger_HookTriggerHalted,
+		Command: "echo added",
+	}
+
+	// Merge a configuration with a configuration derived from it.
+	lower := &Configuration{Hooks: []*Hook{inherited}}
+	higher := &Configuration{Hooks: []*Hook{
+		{Trigger: HookTrigger_HookTriggerCycleCompleted, Command: "echo inherited"},
+		added,
+	}}
+	merged := MergeConfigurations(lower, higher)
+
+	// Verify the result.
+	if len(merged.Hooks) != 2 {
+		t.Fatal("unexpected number of merged hooks:", len(merged.Hooks))
+	}
+	if merged.Hooks[0].Command != inherited.Command {
+		t.Error("first merged hook does not match expected:", merged.Hooks[0].Command)
+	}
+	if merged.Hooks[1].Command != added.Command {
+		t.Error("second merged hook does not match expected:", merged.Hooks[1].Command)
+	}
+}
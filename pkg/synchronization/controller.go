@@ -4,6 +4,7 @@ import (
 	contextpkg "context"
 	"fmt"
 	"os"
+	"strings"
 	syncpkg "sync"
 	"time"
 
@@ -42,19 +43,21 @@ type controller struct {
 	// and the state member.
 	stateLock *state.TrackingLock
 	// session encodes the associated session metadata. It is considered static
-	// and safe for concurrent access except for its Paused field, for which the
-	// stateLock member should be held. It should be saved to disk any time it
-	// is modified.
+	// and safe for concurrent access except for its Paused, Configuration,
+	// ConfigurationAlpha, ConfigurationBeta, Name, and Labels fields, for which
+	// the stateLock member should be held (though the synchronization loop may
+	// access them without the lock since they can only change while the loop
+	// isn't running). It should be saved to disk any time it is modified.
 	session *Session
 	// mergedAlphaConfiguration is the alpha-specific configuration object
 	// (computed from the core configuration and alpha-specific overrides). It
-	// is considered static and safe for concurrent access. It is a derived
-	// field and not saved to disk.
+	// may only be modified while no synchronization loop is running and while
+	// holding the lifecycleLock. It is a derived field and not saved to disk.
 	mergedAlphaConfiguration *Configuration
 	// mergedBetaConfiguration is the beta-specific configuration object
-	// (computed from the core configuration and beta-specific overrides). It is
-	// considered static and safe for concurrent access. It is a derived field
-	// and not saved to disk.
+	// (computed from the core configuration and beta-specific overrides). It
+	// may only be modified while no synchronization loop is running and while
+	// holding the lifecycleLock. It is a derived field and not saved to disk.
 	mergedBetaConfiguration *Configuration
 	// state represents the current synchronization state.
 	state *State
@@ -79,6 +82,12 @@ type controller struct {
 	flushRequests chan chan error
 	// done will be closed by the current synchronization loop when it exits.
 	done chan struct{}
+	// conflictRoots are the roots of conflicts from the most recent
+	// synchronization cycle, used to detect new conflicts. They're tracked
+	// across synchronization loops so that reconnecting doesn't re-trigger
+	// conflict hooks for existing conflicts. They may only be accessed by the
+	// synchronization loop or while no synchronization loop is running.
+	conflictRoots map[string]bool
 }
 
 // newSession creates a new session and corresponding controller.
@@ -90,6 +99,7 @@ func newSession(
 	configuration, configurationAlpha, configurationBeta *Configuration,
 	name string,
 	labels map[string]string,
+	archive *core.Archive,
 	paused bool,
 	prompter string,
 ) (*controller, error) {
@@ -148,7 +158,7 @@ func newSession(
 		}
 	}
 
-	// Create the session and initial archive.
+	// Create the session and, if one hasn't been provided, the initial archive.
 	session := &Session{
 		Identifier:           identifier,
 		Version:              version,
@@ -165,7 +175,9 @@ func newSession(
 		Labels:               labels,
 		Paused:               paused,
 	}
-	archive := &core.Archive{}
+	if archive == nil {
+		archive = &core.Archive{}
+	}
 
 	// Compute the session and archive paths.
 	sessionPath, err := pathForSession(session.Identifier)
@@ -300,6 +312,19 @@ func (c *controller) currentState() *State {
 	return c.state.Copy()
 }
 
+// loadArchive loads the session's current archive from disk. Since archives
+// are written atomically, this is safe to perform while a synchronization loop
+// is running, though the archive may be superseded at any time.
+func (c *controller) loadArchive() (*core.Archive, error) {
+	archive := &core.Archive{}
+	if err := encoding.LoadAndUnmarshalProtobuf(c.archivePath, archive); err != nil {
+		return nil, errors.Wrap(err, "unable to load archive")
+	} else if err = archive.Root.EnsureValid(); err != nil {
+		return nil, errors.Wrap(err, "invalid archive found on disk")
+	}
+	return archive, nil
+}
+
 // flush attempts to force a synchronization cycle for the session. If wait is
 // specified, then the method will wait until a post-flush synchronization cycle
 // has completed. The provided context (which must be non-nil) can terminate
@@ -417,6 +442,14 @@ func (c *controller) resume(prompter string) error {
 		c.done = nil
 	}
 
+	// Connect and start a synchronization loop.
+	return c.start(prompter)
+}
+
+// start marks the session as unpaused, attempts to connect to both endpoints,
+// and starts a synchronization loop. It must be called with the lifecycle lock
+// held and with no synchronization loop running.
+func (c *controller) start(prompter string) error {
 	// Mark the session as unpaused and save it to disk.
 	c.stateLock.Lock()
 	c.session.Paused = false
@@ -481,6 +514,97 @@ func (c *controller) resume(prompter string) error {
 	return nil
 }
 
+// update replaces the session configuration, name, and labels and saves the
+// session to disk. If a synchronization loop is running, then it's stopped and
+// restarted with the new configuration. The existing archive is retained unless
+// the symbolic link mode changes, since the archive contents depend on it, in
+// which case the archive is reset and the next synchronization cycle will treat
+// any differences between the endpoints as conflicts.
+func (c *controller) update(
+	configuration, configurationAlpha, configurationBeta *Configuration,
+	name string,
+	labels map[string]string,
+	prompter string,
+) error {
+	// Update status.
+	prompt.Message(prompter, fmt.Sprintf("Updating session %s...", c.session.Identifier))
+
+	// Lock the controller's lifecycle and defer its release.
+	c.lifecycleLock.Lock()
+	defer c.lifecycleLock.Unlock()
+
+	// Don't allow any update operations if the controller is disabled.
+	if c.disabled {
+		return errors.New("controller disabled")
+	}
+
+	// Stop any existing synchronization loop, recording whether or not one was
+	// running so that we can restart it.
+	running := c.cancel != nil
+	if running {
+		// Cancel the synchronization loop and wait for it to finish.
+		c.cancel()
+		<-c.done
+
+		// Nil out any lifecycle state.
+		c.cancel = nil
+		c.flushRequests = nil
+		c.done = nil
+	}
+
+	// Determine whether or not the effective symbolic link mode is changing.
+	oldSymlinkMode := c.session.Configuration.SymlinkMode
+	if oldSymlinkMode.IsDefault() {
+		oldSymlinkMode = c.session.Version.DefaultSymlinkMode()
+	}
+	newSymlinkMode := configuration.SymlinkMode
+	if newSymlinkMode.IsDefault() {
+		newSymlinkMode = c.session.Version.DefaultSymlinkMode()
+	}
+	resetArchive := newSymlinkMode != oldSymlinkMode
+
+	// Update the session and save it to disk. We replace the configuration and
+	// label members rather than modifying them because they may be shared with
+	// state copies that are currently in use. The merged configurations are
+	// only accessed by the synchronization loop, which isn't running.
+	c.stateLock.Lock()
+	c.session.Configuration = configuration
+	c.session.ConfigurationAlpha = configurationAlpha
+	c.session.ConfigurationBeta = configurationBeta
+	c.session.Name = name
+	c.session.Labels = labels
+	c.mergedAlphaConfiguration = MergeConfigurations(configuration, configurationAlpha)
+	c.mergedBetaConfiguration = MergeConfigurations(configuration, configurationBeta)
+	saveErr := encoding.MarshalAndSaveProtobuf(c.sessionPath, c.session)
+	c.stateLock.Unlock()
+
+	// Reset the archive if necessary.
+	var archiveErr error
+	if resetArchive {
+		archiveErr = encoding.MarshalAndSaveProtobuf(c.archivePath, &core.Archive{})
+		c.conflictRoots = nil
+	}
+
+	// Restart the synchronization loop if one was running. We do this even if
+	// saving failed so that the session continues running.
+	var startErr error
+	if running {
+		startErr = c.start(prompter)
+	}
+
+	// Report any errors.
+	if saveErr != nil {
+		return errors.Wrap(saveErr, "unable to save session")
+	} else if archiveErr != nil {
+		return errors.Wrap(archiveErr, "unable to reset archive")
+	} else if startErr != nil {
+		return errors.Wrap(startErr, "unable to restart session")
+	}
+
+	// Success.
+	return nil
+}
+
 // controllerHaltMode represents the behavior to use when halting a session.
 type controllerHaltMode uint8
 
@@ -587,7 +711,8 @@ func (c *controller) run(context contextpkg.Context, alpha, beta Endpoint) {
 		// Reset the state.
 		c.stateLock.Lock()
 		c.state = &State{
-			Session: c.session,
+			Session:       c.session,
+			Reconnections: c.state.Reconnections,
 		}
 		c.stateLock.Unlock()
 
@@ -595,6 +720,10 @@ func (c *controller) run(context contextpkg.Context, alpha, beta Endpoint) {
 		close(c.done)
 	}()
 
+	// Track whether or not we've previously connected to both endpoints so
+	// that we can count reconnections.
+	var previouslyConnected bool
+
 	// Loop until cancelled.
 	for {
 		// Loop until we're connected to both endpoints. We do a non-blocking
@@ -654,6 +783,12 @@ func (c *controller) run(context contextpkg.Context, alpha, beta Endpoint) {
 			// it in the loop condition we'd still need this check to avoid a
 			// sleep every time (even if already successfully connected).
 			if alpha != nil && beta != nil {
+				if previouslyConnected {
+					c.stateLock.Lock()
+					c.state.Reconnections++
+					c.stateLock.Unlock()
+				}
+				previouslyConnected = true
 				break
 			}
 
@@ -666,8 +801,11 @@ func (c *controller) run(context contextpkg.Context, alpha, beta Endpoint) {
 			}
 		}
 
-		// Perform synchronization.
-		err := c.synchronize(context, alpha, beta)
+		// Run connection hooks and then perform synchronization.
+		err := c.runHooks(context, HookTrigger_HookTriggerConnected, alpha, beta)
+		if err == nil {
+			err = c.synchronize(context, alpha, beta)
+		}
 
 		// Shutdown the endpoints.
 		alpha.Shutdown()
@@ -676,14 +814,20 @@ func (c *controller) run(context contextpkg.Context, alpha, beta Endpoint) {
 		beta = nil
 
 		// Reset the synchronization state, but propagate the error that caused
-		// failure.
+		// failure and any hook error.
 		c.stateLock.Lock()
 		c.state = &State{
-			Session:   c.session,
-			LastError: err.Error(),
+			Session:       c.session,
+			LastError:     err.Error(),
+			LastHookError: c.state.LastHookError,
+			Reconnections: c.state.Reconnections,
 		}
 		c.stateLock.Unlock()
 
+		// Run disconnection hooks. These are always executed locally, so they
+		// can't fail due to endpoint errors.
+		c.runHooks(context, HookTrigger_HookTriggerDisconnected, nil, nil)
+
 		// If synchronization failed, wait and then try to reconnect. Watch for
 		// cancellation in the mean time. This cancellation check will also
 		// catch cases where the synchronization loop has been cancelled.
@@ -695,6 +839,80 @@ func (c *controller) run(context contextpkg.Context, alpha, beta Endpoint) {
 	}
 }
 
+// runHooks runs the session hooks for the specified trigger. Hook output is
+// recorded in the controller log and hook failures are recorded in the session
+// state. Hooks located on an endpoint are skipped if that endpoint is nil. An
+// error is only returned if communication with an endpoint fails, in which case
+// the endpoint should be considered failed. Hooks are terminated if the
+// provided context is cancelled.
+func (c *controller) runHooks(context contextpkg.Context, trigger HookTrigger, alpha, beta Endpoint) error {
+	// Run matching hooks and track the last failure.
+	var ran bool
+	var failure string
+	for _, hook := range c.session.Configuration.Hooks {
+		// Skip hooks that don't match the trigger.
+		if hook.Trigger != trigger {
+			continue
+		}
+
+		// Run the hook in the appropriate location.
+		var result *HookResult
+		var err error
+		switch hook.Location {
+		case HookLocation_HookLocationAlpha:
+			if alpha == nil {
+				continue
+			}
+			result, err = alpha.RunHook(context, hook)
+		case HookLocation_HookLocationBeta:
+			if beta == nil {
+				continue
+			}
+			result, err = beta.RunHook(context, hook)
+		default:
+			homeDirectory, homeErr := os.UserHomeDir()
+			if homeErr != nil {
+				result = &HookResult{Error: errors.Wrap(homeErr, "unable to compute home directory").Error()}
+			} else {
+				result = RunHook(context, hook, homeDirectory)
+			}
+		}
+		if err != nil {
+			return errors.Wrapf(err, "unable to run %s hook", strings.ToLower(hook.Trigger.Description()))
+		}
+		ran = true
+
+		// Log the hook output and any failure.
+		c.logger.Printf("Ran %s hook: %s", strings.ToLower(hook.Trigger.Description()), hook.Command)
+		if result.Output != "" {
+			for _, line := range strings.Split(strings.TrimRight(result.Output, "\n"), "\n") {
+				c.logger.Println("Hook output:", line)
+			}
+		}
+		if result.Error != "" {
+			failure = fmt.Sprintf("%s hook (%s) failed: %s",
+				hook.Trigger.Description(), hook.Command, result.Error,
+			)
+			c.logger.Println(failure)
+		}
+	}
+
+	// If any hooks ran, then update the hook error state. A set of successful
+	// hook executions clears any previous failure.
+	if ran {
+		c.stateLock.Lock()
+		if c.state.LastHookError != failure {
+			c.state.LastHookError = failure
+			c.stateLock.Unlock()
+		} else {
+			c.stateLock.UnlockWithoutNotify()
+		}
+	}
+
+	// Success.
+	return nil
+}
+
 // synchronize is the main synchronization loop for the controller.
 func (c *controller) synchronize(context contextpkg.Context, alpha, beta Endpoint) error {
 	// Clear any error state upon restart of this function. If there was a
@@ -844,6 +1062,7 @@ func (c *controller) synchronize(context contextpkg.Context, alpha, beta Endpoin
 		c.stateLock.Lock()
 		c.state.Status = Status_Scanning
 		c.stateLock.Unlock()
+		cycleStart := time.Now()
 		forceFullScan := flushRequest != nil
 		var αSnapshot, βSnapshot *core.Entry
 		var αPreservesExecutability, βPreservesExecutability bool
@@ -968,6 +1187,24 @@ func (c *controller) synchronize(context contextpkg.Context, alpha, beta Endpoin
 		c.state.Conflicts = slimConflicts
 		c.stateLock.Unlock()
 
+		// Determine whether or not any new conflicts have been detected (as
+		// compared to the previous cycle) and run hooks if so.
+		conflictRoots := make(map[string]bool, len(conflicts))
+		newConflicts := false
+		for _, conflict := range conflicts {
+			root := conflict.Root()
+			conflictRoots[root] = true
+			if !c.conflictRoots[root] {
+				newConflicts = true
+			}
+		}
+		c.conflictRoots = conflictRoots
+		if newConflicts {
+			if err := c.runHooks(context, HookTrigger_HookTriggerConflictDetected, alpha, beta); err != nil {
+				return err
+			}
+		}
+
 		// Check if a root deletion is being propagated. If so, switch to a
 		// halted state. This is a best-effort safety check. While we'll
 		// definitely detect root deletion, it may happen (for directories) that
@@ -1001,6 +1238,9 @@ func (c *controller) synchronize(context contextpkg.Context, alpha, beta Endpoin
 			c.stateLock.Lock()
 			c.state.Status = Status_HaltedOnRootDeletion
 			c.stateLock.Unlock()
+			if err := c.runHooks(context, HookTrigger_HookTriggerHalted, alpha, beta); err != nil {
+				return err
+			}
 			<-context.Done()
 			return errors.New("cancelled while halted on root deletion")
 		}
@@ -1025,6 +1265,9 @@ func (c *controller) synchronize(context contextpkg.Context, alpha, beta Endpoin
 			c.stateLock.Lock()
 			c.state.Status = Status_HaltedOnRootTypeChange
 			c.stateLock.Unlock()
+			if err := c.runHooks(context, HookTrigger_HookTriggerHalted, alpha, beta); err != nil {
+				return err
+			}
 			<-context.Done()
 			return errors.New("cancelled while halted on root type change")
 		}
@@ -1037,6 +1280,15 @@ func (c *controller) synchronize(context contextpkg.Context, alpha, beta Endpoin
 			return nil
 		}
 
+		// Create a counting callback for rsync staging. Since this is invoked
+		// for every data operation, we don't notify of state changes here and
+		// instead rely on the monitoring callback to do so.
+		counter := func(count uint64) {
+			c.stateLock.Lock()
+			c.state.StagedBytes += count
+			c.stateLock.UnlockWithoutNotify()
+		}
+
 		// Stage files on alpha.
 		c.stateLock.Lock()
 		c.state.Status = Status_StagingAlpha
@@ -1053,6 +1305,7 @@ func (c *controller) synchronize(context contextpkg.Context, alpha, beta Endpoin
 			}
 			if len(filteredPaths) > 0 {
 				receiver = rsync.NewMonitoringReceiver(receiver, filteredPaths, monitor)
+				receiver = rsync.NewCountingReceiver(receiver, counter)
 				receiver = rsync.NewPreemptableReceiver(receiver, context)
 				if err = beta.Supply(filteredPaths, signatures, receiver); err != nil {
 					return errors.Wrap(err, "unable to stage files on alpha")
@@ -1076,6 +1329,7 @@ func (c *controller) synchronize(context contextpkg.Context, alpha, beta Endpoin
 			}
 			if len(filteredPaths) > 0 {
 				receiver = rsync.NewMonitoringReceiver(receiver, filteredPaths, monitor)
+				receiver = rsync.NewCountingReceiver(receiver, counter)
 				receiver = rsync.NewPreemptableReceiver(receiver, context)
 				if err = alpha.Supply(filteredPaths, signatures, receiver); err != nil {
 					return errors.Wrap(err, "unable to stage files on beta")
@@ -1181,16 +1435,31 @@ func (c *controller) synchronize(context contextpkg.Context, alpha, beta Endpoin
 			skippingPollingDueToMissingFiles = false
 		}
 
-		// Increment the synchronization cycle count.
+		// Increment the synchronization cycle count and record the cycle
+		// duration.
+		cycleDuration := time.Since(cycleStart)
 		c.stateLock.Lock()
 		c.state.SuccessfulSynchronizationCycles++
+		c.state.LastSynchronizationCycleDuration = ptypes.DurationProto(cycleDuration)
+		totalCycleDuration, _ := ptypes.Duration(c.state.TotalSynchronizationCycleDuration)
+		c.state.TotalSynchronizationCycleDuration = ptypes.DurationProto(totalCycleDuration + cycleDuration)
 		c.stateLock.Unlock()
 
 		// If a flush request triggered this synchronization cycle, then tell it
-		// that the cycle has completed and remove it from our tracking.
+		// that the cycle has completed and remove it from our tracking. We do
+		// this before running cycle completion hooks so that flushes aren't
+		// delayed by hook execution.
 		if flushRequest != nil {
 			flushRequest <- nil
 			flushRequest = nil
 		}
+
+		// If changes were applied to either endpoint, then run cycle
+		// completion hooks.
+		if len(αTransitions) > 0 || len(βTransitions) > 0 {
+			if err := c.runHooks(context, HookTrigger_HookTriggerCycleCompleted, alpha, beta); err != nil {
+				return err
+			}
+		}
 	}
 }
@@ -1,3 +1,142 @@
 package synchronization
 
-// TODO: Implement.
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/state"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// TestControllerUpdatePaused tests updating a paused session, including the
+// conditions under which its archive is reset.
+func TestControllerUpdatePaused(t *testing.T) {
+	// Create a temporary directory for session storage and defer its removal.
+	directory, err := ioutil.TempDir("", "mutagen_controller_update")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Create a paused controller with a non-empty archive.
+	session := &Session{
+		Identifier:         "session",
+		Version:            Version_Version1,
+		Configuration:      &Configuration{},
+		ConfigurationAlpha: &Configuration{},
+		ConfigurationBeta:  &Configuration{},
+		Name:               "old",
+		Paused:             true,
+	}
+	controller := &controller{
+		sessionPath: filepath.Join(directory, "session"),
+		archivePath: filepath.Join(directory, "archive"),
+		stateLock:   state.NewTrackingLock(state.NewTracker()),
+		session:     session,
+		state:       &State{Session: session},
+	}
+	archive := &core.Archive{Root: &core.Entry{Kind: core.EntryKind_Directory}}
+	if err := encoding.MarshalAndSaveProtobuf(controller.archivePath, archive); err != nil {
+		t.Fatal("unable to save archive:", err)
+	}
+
+	// Perform an update that doesn't change the effective symbolic link mode.
+	err = controller.update(
+		&Configuration{SymlinkMode: core.SymlinkMode_SymlinkModePortable},
+		&Configuration{WatchMode: WatchMode_WatchModeNoWatch},
+		&Configuration{},
+		"new",
+		map[string]string{"key": "value"},
+		"",
+	)
+	if err != nil {
+		t.Fatal("unable to update session:", err)
+	}
+
+	// Verify that the session was saved with its updated values.
+	saved := &Session{}
+	if err := encoding.LoadAndUnmarshalProtobuf(controller.sessionPath, saved); err != nil {
+		t.Fatal("unable to load session:", err)
+	} else if saved.Name != "new" {
+		t.Error("session name not updated:", saved.Name)
+	} else if saved.Labels["key"] != "value" {
+		t.Error("session labels not updated")
+	} else if !saved.Paused {
+		t.Error("session unexpectedly unpaused")
+	}
+
+	// Verify that the merged configuration was updated.
+	if controller.mergedAlphaConfiguration.WatchMode != WatchMode_WatchModeNoWatch {
+		t.Error("merged alpha configuration not updated")
+	}
+
+	// Verify that the archive was retained.
+	archive = &core.Archive{}
+	if err := encoding.LoadAndUnmarshalProtobuf(controller.archivePath, archive); err != nil {
+		t.Fatal("unable to load archive:", err)
+	} else if archive.Root == nil {
+		t.Error("archive unexpectedly reset")
+	}
+
+	// Perform an update that changes the symbolic link mode.
+	err = controller.update(
+		&Configuration{SymlinkMode: core.SymlinkMode_SymlinkModeIgnore},
+		&Configuration{},
+		&Configuration{},
+		"new",
+		nil,
+		"",
+	)
+	if err != nil {
+		t.Fatal("unable to update session:", err)
+	}
+
+	// Verify that the archive was reset.
+	archive = &core.Archive{}
+	if err := encoding.LoadAndUnmarshalProtobuf(controller.archivePath, archive); err != nil {
+		t.Fatal("unable to load archive:", err)
+	} else if archive.Root != nil {
+		t.Error("archive not reset")
+	}
+}
+
+// TestControllerUpdateDisabled tests that updating a disabled controller fails.
+func TestControllerUpdateDisabled(t *testing.T) {
+	controller := &controller{
+		session:  &Session{Identifier: "session"},
+		disabled: true,
+	}
+	if controller.update(&Configuration{}, &Configuration{}, &Configuration{}, "", nil, "") == nil {
+		t.Error("update of disabled controller succeeded")
+	}
+}
+
+// TestControllerLoadArchive tests loading a session's archive.
+func TestControllerLoadArchive(t *testing.T) {
+	// Create a temporary directory for session storage and defer its removal.
+	directory, err := ioutil.TempDir("", "mutagen_controller_archive")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Create a controller and verify that loading fails without an archive.
+	controller := &controller{archivePath: filepath.Join(directory, "archive")}
+	if _, err := controller.loadArchive(); err == nil {
+		t.Error("archive loading succeeded without archive")
+	}
+
+	// Save an archive and verify that it can be loaded.
+	archive := &core.Archive{Root: &core.Entry{Kind: core.EntryKind_Directory}}
+	if err := encoding.MarshalAndSaveProtobuf(controller.archivePath, archive); err != nil {
+		t.Fatal("unable to save archive:", err)
+	}
+	if loaded, err := controller.loadArchive(); err != nil {
+		t.Error("unable to load archive:", err)
+	} else if !loaded.Root.Equal(archive.Root) {
+		t.Error("loaded archive does not match saved archive")
+	}
+}
@@ -64,6 +64,12 @@ type Endpoint interface {
 	// we're creating the root with a huge number of files and wouldn't catch
 	// cancellation until they're all done anyway.
 	Transition(transitions []*core.Change) ([]*core.Entry, []*core.Problem, bool, error)
+	// RunHook executes a hook command on the endpoint, using the
+	// synchronization root as the base working directory. The hook is
+	// terminated if the provided context is cancelled. Failures of the hook
+	// command itself are reported in the result, while the error return value
+	// is reserved for failures communicating with the endpoint.
+	RunHook(context context.Context, hook *Hook) (*HookResult, error)
 
 	// Shutdown terminates any resources associated with the endpoint. For local
 	// endpoints, Shutdown will not preempt calls, but for remote endpoints it
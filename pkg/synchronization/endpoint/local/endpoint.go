@@ -1104,6 +1104,11 @@ func (e *endpoint) Supply(paths []string, signatures []*rsync.Signature, receive
 	return rsync.Transmit(e.root, paths, signatures, receiver)
 }
 
+// RunHook implements the RunHook method for local endpoints.
+func (e *endpoint) RunHook(context context.Context, hook *synchronization.Hook) (*synchronization.HookResult, error) {
+	return synchronization.RunHook(context, hook, e.root), nil
+}
+
 // Transition implements the Transition method for local endpoints.
 func (e *endpoint) Transition(transitions []*core.Change) ([]*core.Entry, []*core.Problem, bool, error) {
 	// If we're in a read-only mode, we shouldn't be performing transitions.
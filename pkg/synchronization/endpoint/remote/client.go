@@ -2,12 +2,14 @@ package remote
 
 import (
 	contextpkg "context"
+	"io"
 	"net"
 
 	"github.com/pkg/errors"
 
 	"github.com/golang/protobuf/proto"
 
+	"github.com/mutagen-io/mutagen/pkg/agent"
 	"github.com/mutagen-io/mutagen/pkg/compression"
 	"github.com/mutagen-io/mutagen/pkg/encoding"
 	"github.com/mutagen-io/mutagen/pkg/synchronization"
@@ -50,9 +52,13 @@ func NewEndpointClient(
 		}
 	}()
 
-	// Enable read/write compression on the connection.
-	reader := compression.NewDecompressingReader(connection)
-	writer := compression.NewCompressingWriter(connection)
+	// Enable read/write compression on the connection if it was negotiated.
+	var reader io.Reader = connection
+	var writer io.Writer = connection
+	if agent.UseCompression(connection) {
+		reader = compression.NewDecompressingReader(connection)
+		writer = compression.NewCompressingWriter(connection)
+	}
 
 	// Create an encoder and decoder.
 	encoder := encoding.NewProtobufEncoder(writer)
@@ -340,6 +346,72 @@ func (e *endpointClient) Transition(transitions []*core.Change) ([]*core.Entry,
 	return results, response.Problems, response.StagerMissingFiles, nil
 }
 
+// RunHook implements the RunHook method for remote endpoints.
+func (e *endpointClient) RunHook(context contextpkg.Context, hook *synchronization.Hook) (*synchronization.HookResult, error) {
+	// Create and send the hook request.
+	request := &EndpointRequest{
+		RunHook: &RunHookRequest{
+			Hook: hook,
+		},
+	}
+	if err := e.encoder.Encode(request); err != nil {
+		return nil, errors.Wrap(err, "unable to send hook request")
+	}
+
+	// Wrap the completion context in a context that we can cancel in order to
+	// force sending the completion request once we receive a response. This
+	// follows the same protocol as polling, with the completion request either
+	// terminating the hook early or acknowledging its completion.
+	completionContext, forceCompletionSend := contextpkg.WithCancel(context)
+	defer forceCompletionSend()
+
+	// Create a Goroutine that will send a hook completion request when the
+	// context is cancelled.
+	completionSendResults := make(chan error, 1)
+	go func() {
+		<-completionContext.Done()
+		completionSendResults <- errors.Wrap(
+			e.encoder.Encode(&RunHookCompletionRequest{}),
+			"unable to send hook completion request",
+		)
+	}()
+
+	// Create a Goroutine that will receive a hook response.
+	response := &RunHookResponse{}
+	responseReceiveResults := make(chan error, 1)
+	go func() {
+		if err := e.decoder.Decode(response); err != nil {
+			responseReceiveResults <- errors.Wrap(err, "unable to receive hook response")
+		} else if err = response.ensureValid(); err != nil {
+			responseReceiveResults <- errors.Wrap(err, "invalid hook response")
+		} else {
+			responseReceiveResults <- nil
+		}
+	}()
+
+	// Wait for both a completion encode to finish and a response to be
+	// received. If the response comes first, we need to force the completion
+	// send.
+	var completionSendErr, responseReceiveErr error
+	select {
+	case completionSendErr = <-completionSendResults:
+		responseReceiveErr = <-responseReceiveResults
+	case responseReceiveErr = <-responseReceiveResults:
+		forceCompletionSend()
+		completionSendErr = <-completionSendResults
+	}
+
+	// Check for errors.
+	if responseReceiveErr != nil {
+		return nil, responseReceiveErr
+	} else if completionSendErr != nil {
+		return nil, completionSendErr
+	}
+
+	// Success.
+	return response.Result, nil
+}
+
 // Shutdown implements the Shutdown method for remote endpoints.
 func (e *endpointClient) Shutdown() error {
 	// Close the underlying connection. This will cause all stream reads/writes
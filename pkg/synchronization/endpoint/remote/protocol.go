@@ -272,6 +272,39 @@ func (r *TransitionResponse) ensureValid(expectedCount int) error {
 	return nil
 }
 
+// ensureValid ensures that RunHookRequest's invariants are respected.
+func (r *RunHookRequest) ensureValid() error {
+	// A nil hook request is not valid.
+	if r == nil {
+		return errors.New("nil hook request")
+	}
+
+	// Ensure that the hook is valid.
+	if err := r.Hook.EnsureValid(); err != nil {
+		return errors.Wrap(err, "invalid hook")
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid ensures that RunHookResponse's invariants are respected.
+func (r *RunHookResponse) ensureValid() error {
+	// A nil hook response is not valid.
+	if r == nil {
+		return errors.New("nil hook response")
+	}
+
+	// Ensure that the result is non-nil. Its output and error don't need to be
+	// validated since any values are valid.
+	if r.Result == nil {
+		return errors.New("nil hook result")
+	}
+
+	// Success.
+	return nil
+}
+
 // ensureValid ensures that EndpointRequest's invariants are respected.
 func (r *EndpointRequest) ensureValid() error {
 	// A nil endpoint request is not valid.
@@ -296,6 +329,9 @@ func (r *EndpointRequest) ensureValid() error {
 	if r.Transition != nil {
 		set++
 	}
+	if r.RunHook != nil {
+		set++
+	}
 	if set != 1 {
 		return errors.New("invalid number of fields set")
 	}
@@ -653,6 +653,121 @@ func (m *TransitionResponse) GetError() string {
 	return ""
 }
 
+// RunHookRequest encodes a request to run a hook.
+type RunHookRequest struct {
+	// Hook is the hook to run.
+	Hook                 *synchronization.Hook `protobuf:"bytes,1,opt,name=hook,proto3" json:"hook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *RunHookRequest) Reset()         { *m = RunHookRequest{} }
+func (m *RunHookRequest) String() string { return proto.CompactTextString(m) }
+func (*RunHookRequest) ProtoMessage()    {}
+func (*RunHookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed323a11ce40f0df, []int{12}
+}
+
+func (m *RunHookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunHookRequest.Unmarshal(m, b)
+}
+func (m *RunHookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunHookRequest.Marshal(b, m, deterministic)
+}
+func (m *RunHookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunHookRequest.Merge(m, src)
+}
+func (m *RunHookRequest) XXX_Size() int {
+	return xxx_messageInfo_RunHookRequest.Size(m)
+}
+func (m *RunHookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunHookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunHookRequest proto.InternalMessageInfo
+
+func (m *RunHookRequest) GetHook() *synchronization.Hook {
+	if m != nil {
+		return m.Hook
+	}
+	return nil
+}
+
+// RunHookCompletionRequest is paired with RunHookRequest and indicates a request
+// for early hook termination or an acknowledgement of completion.
+type RunHookCompletionRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunHookCompletionRequest) Reset()         { *m = RunHookCompletionRequest{} }
+func (m *RunHookCompletionRequest) String() string { return proto.CompactTextString(m) }
+func (*RunHookCompletionRequest) ProtoMessage()    {}
+func (*RunHookCompletionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed323a11ce40f0df, []int{13}
+}
+
+func (m *RunHookCompletionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunHookCompletionRequest.Unmarshal(m, b)
+}
+func (m *RunHookCompletionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunHookCompletionRequest.Marshal(b, m, deterministic)
+}
+func (m *RunHookCompletionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunHookCompletionRequest.Merge(m, src)
+}
+func (m *RunHookCompletionRequest) XXX_Size() int {
+	return xxx_messageInfo_RunHookCompletionRequest.Size(m)
+}
+func (m *RunHookCompletionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunHookCompletionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunHookCompletionRequest proto.InternalMessageInfo
+
+// RunHookResponse encodes the results of running a hook.
+type RunHookResponse struct {
+	// Result is the hook execution result.
+	Result               *synchronization.HookResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *RunHookResponse) Reset()         { *m = RunHookResponse{} }
+func (m *RunHookResponse) String() string { return proto.CompactTextString(m) }
+func (*RunHookResponse) ProtoMessage()    {}
+func (*RunHookResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed323a11ce40f0df, []int{14}
+}
+
+func (m *RunHookResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunHookResponse.Unmarshal(m, b)
+}
+func (m *RunHookResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunHookResponse.Marshal(b, m, deterministic)
+}
+func (m *RunHookResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunHookResponse.Merge(m, src)
+}
+func (m *RunHookResponse) XXX_Size() int {
+	return xxx_messageInfo_RunHookResponse.Size(m)
+}
+func (m *RunHookResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunHookResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunHookResponse proto.InternalMessageInfo
+
+func (m *RunHookResponse) GetResult() *synchronization.HookResult {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
 // EndpointRequest is a sum type that can transmit any type of endpoint request.
 // Only the sent request will be non-nil. We intentionally avoid using Protocol
 // Buffers' oneof feature because it generates really ugly code and an unwieldy
@@ -667,17 +782,19 @@ type EndpointRequest struct {
 	// Supply represents a supply request.
 	Supply *SupplyRequest `protobuf:"bytes,4,opt,name=supply,proto3" json:"supply,omitempty"`
 	// Transition represents a transition request.
-	Transition           *TransitionRequest `protobuf:"bytes,5,opt,name=transition,proto3" json:"transition,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	Transition *TransitionRequest `protobuf:"bytes,5,opt,name=transition,proto3" json:"transition,omitempty"`
+	// RunHook represents a hook execution request.
+	RunHook              *RunHookRequest `protobuf:"bytes,6,opt,name=runHook,proto3" json:"runHook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *EndpointRequest) Reset()         { *m = EndpointRequest{} }
 func (m *EndpointRequest) String() string { return proto.CompactTextString(m) }
 func (*EndpointRequest) ProtoMessage()    {}
 func (*EndpointRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ed323a11ce40f0df, []int{12}
+	return fileDescriptor_ed323a11ce40f0df, []int{15}
 }
 
 func (m *EndpointRequest) XXX_Unmarshal(b []byte) error {
@@ -733,6 +850,13 @@ func (m *EndpointRequest) GetTransition() *TransitionRequest {
 	return nil
 }
 
+func (m *EndpointRequest) GetRunHook() *RunHookRequest {
+	if m != nil {
+		return m.RunHook
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*InitializeSynchronizationRequest)(nil), "remote.InitializeSynchronizationRequest")
 	proto.RegisterType((*InitializeSynchronizationResponse)(nil), "remote.InitializeSynchronizationResponse")
@@ -746,6 +870,9 @@ func init() {
 	proto.RegisterType((*SupplyRequest)(nil), "remote.SupplyRequest")
 	proto.RegisterType((*TransitionRequest)(nil), "remote.TransitionRequest")
 	proto.RegisterType((*TransitionResponse)(nil), "remote.TransitionResponse")
+	proto.RegisterType((*RunHookRequest)(nil), "remote.RunHookRequest")
+	proto.RegisterType((*RunHookCompletionRequest)(nil), "remote.RunHookCompletionRequest")
+	proto.RegisterType((*RunHookResponse)(nil), "remote.RunHookResponse")
 	proto.RegisterType((*EndpointRequest)(nil), "remote.EndpointRequest")
 }
 
@@ -754,51 +881,55 @@ func init() {
 }
 
 var fileDescriptor_ed323a11ce40f0df = []byte{
-	// 728 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x95, 0xdf, 0x6b, 0x1b, 0x39,
-	0x10, 0xc7, 0xd9, 0xd8, 0x4e, 0x9c, 0xb1, 0x7d, 0x3f, 0x74, 0xc9, 0xdd, 0x5e, 0xe0, 0x0e, 0x67,
-	0xef, 0x20, 0xbe, 0x83, 0xec, 0x1e, 0x3e, 0x08, 0xe4, 0xa5, 0x90, 0x3a, 0x09, 0xf4, 0xa1, 0x34,
-	0xc8, 0xa5, 0x85, 0xbe, 0xc9, 0x1b, 0x65, 0x2d, 0x2a, 0x4b, 0x5b, 0x49, 0x1b, 0xea, 0xfc, 0x51,
-	0xa5, 0x7f, 0x52, 0xff, 0x8d, 0xbe, 0x95, 0x95, 0xb4, 0xf6, 0xda, 0x71, 0x5d, 0xfa, 0xa6, 0x91,
-	0x3e, 0x33, 0x1a, 0x7d, 0x67, 0x67, 0x16, 0x62, 0x3d, 0x17, 0xe9, 0x54, 0x49, 0xc1, 0x1e, 0x88,
-	0x61, 0x52, 0x24, 0x54, 0xdc, 0xe6, 0x92, 0x09, 0x93, 0x28, 0x3a, 0x93, 0x86, 0x26, 0xb9, 0x92,
-	0x46, 0xa6, 0x92, 0xc7, 0x76, 0x81, 0x76, 0xdd, 0xf6, 0x51, 0xb4, 0xee, 0xa7, 0xca, 0x8d, 0x84,
-	0x8a, 0x8c, 0x09, 0xea, 0xd8, 0xa3, 0xbf, 0xd6, 0x99, 0x54, 0x8a, 0x3b, 0x96, 0x15, 0xca, 0x5a,
-	0x1e, 0xfa, 0x63, 0x1d, 0xba, 0xa7, 0x4a, 0x2f, 0x8f, 0xa3, 0xc7, 0x31, 0x14, 0x4d, 0x88, 0x4a,
-	0xa7, 0xec, 0xbe, 0xba, 0xe7, 0x78, 0x23, 0x93, 0x4e, 0x89, 0xc8, 0xe8, 0xd6, 0x30, 0xb9, 0x92,
-	0x13, 0x4e, 0x67, 0x8e, 0x89, 0x3e, 0x05, 0xd0, 0x7f, 0x26, 0x98, 0x61, 0x84, 0xb3, 0x07, 0x3a,
-	0x5e, 0x75, 0xc0, 0xf4, 0x5d, 0x41, 0xb5, 0x41, 0x21, 0xec, 0x69, 0xaa, 0xcb, 0x04, 0xc3, 0xa0,
-	0x1f, 0x0c, 0xf6, 0x71, 0x65, 0xa2, 0x21, 0xec, 0xf9, 0xd4, 0xc3, 0x9d, 0x7e, 0x30, 0xf8, 0x61,
-	0x18, 0xae, 0x6b, 0x1b, 0xbf, 0x72, 0xe7, 0xb8, 0x02, 0xd1, 0x25, 0xf4, 0x56, 0x34, 0x09, 0x1b,
-	0xfd, 0x60, 0xd0, 0x19, 0xfe, 0xf9, 0xc8, 0x73, 0x54, 0xa7, 0xf0, 0xaa, 0x13, 0x42, 0xd0, 0x54,
-	0x52, 0x9a, 0xb0, 0x69, 0x13, 0xb2, 0x6b, 0x74, 0x00, 0x2d, 0xc2, 0xf3, 0x29, 0x09, 0x5b, 0xfd,
-	0x60, 0xd0, 0xc6, 0xce, 0x88, 0xce, 0xe1, 0x78, 0xcb, 0x0b, 0x75, 0x2e, 0x85, 0xa6, 0xa5, 0x2b,
-	0x55, 0x4a, 0x2a, 0xff, 0x40, 0x67, 0x44, 0x3d, 0xe8, 0xdc, 0x48, 0xce, 0xbd, 0x0e, 0xd1, 0x6f,
-	0x70, 0x58, 0x9a, 0x23, 0x39, 0xcb, 0x39, 0xad, 0x09, 0x14, 0xfd, 0x0d, 0x5d, 0xc7, 0x6d, 0x8d,
-	0xc6, 0xa0, 0x33, 0x4e, 0xc9, 0x42, 0xd5, 0x6b, 0x38, 0x9c, 0x10, 0x4d, 0xc7, 0x82, 0xe4, 0x7a,
-	0x2a, 0xcd, 0x98, 0x65, 0x82, 0x98, 0x42, 0x51, 0xeb, 0xd4, 0x19, 0xfe, 0x14, 0xdb, 0xaf, 0x2b,
-	0x5e, 0xec, 0xe3, 0xcd, 0x78, 0xa9, 0xc4, 0x5d, 0xc1, 0xb9, 0x2d, 0x40, 0x1b, 0xdb, 0x75, 0xf4,
-	0x31, 0x80, 0xae, 0xbb, 0xcb, 0x67, 0x74, 0x06, 0x3d, 0xed, 0x3d, 0x2f, 0x29, 0x37, 0x24, 0x0c,
-	0xfa, 0x8d, 0xda, 0x25, 0x2f, 0x72, 0x5a, 0xc9, 0xbc, 0x82, 0xa1, 0x33, 0xf8, 0x35, 0x57, 0x54,
-	0x53, 0x75, 0x4f, 0xf5, 0xd5, 0x7b, 0x9a, 0x16, 0x86, 0x4c, 0x18, 0x67, 0x66, 0xee, 0xaf, 0xfb,
-	0xca, 0xe9, 0x52, 0x81, 0x46, 0x4d, 0x01, 0x74, 0x04, 0x6d, 0xa3, 0xe6, 0x17, 0x19, 0x61, 0xc2,
-	0x16, 0xae, 0x8d, 0x17, 0x76, 0xf4, 0x04, 0xba, 0x63, 0x43, 0x32, 0x5a, 0xc9, 0x73, 0x00, 0xad,
-	0x9c, 0x98, 0xa9, 0xb6, 0x99, 0xee, 0x63, 0x67, 0x94, 0x9f, 0xe2, 0x2d, 0xcb, 0xa8, 0x36, 0x3a,
-	0xdc, 0xe9, 0x37, 0x06, 0x5d, 0x5c, 0x99, 0xd1, 0x0c, 0x7a, 0xde, 0x7f, 0x59, 0x84, 0x0d, 0x01,
-	0xfe, 0x03, 0xd0, 0x95, 0x74, 0x2e, 0xc6, 0x26, 0xa9, 0x6b, 0xcc, 0xe6, 0xa7, 0x44, 0xaf, 0xa1,
-	0x37, 0x2e, 0xf2, 0x9c, 0xcf, 0xb7, 0xe7, 0xfb, 0xdd, 0xd7, 0x45, 0x23, 0xf8, 0xf9, 0xa5, 0x22,
-	0x42, 0xb3, 0x7a, 0x07, 0xc6, 0xd0, 0x31, 0x8b, 0x4d, 0xed, 0x8b, 0xd7, 0x8d, 0xcb, 0x86, 0x8e,
-	0x47, 0xb6, 0xe7, 0x71, 0x1d, 0x88, 0x3e, 0x04, 0x80, 0xea, 0x51, 0xbc, 0x24, 0x27, 0xb0, 0xa7,
-	0xa8, 0x2e, 0xb8, 0xa9, 0x42, 0xf4, 0x5c, 0x88, 0x0b, 0x37, 0x5a, 0x70, 0x75, 0x8a, 0xfe, 0x81,
-	0xb6, 0x9f, 0x13, 0x55, 0xd2, 0x9e, 0xbc, 0x71, 0xbb, 0x78, 0x71, 0x8c, 0x62, 0x40, 0xba, 0xd4,
-	0x5d, 0x3d, 0x67, 0x5a, 0x33, 0x91, 0x5d, 0x33, 0x4e, 0xb5, 0xd5, 0xaa, 0x8d, 0x37, 0x9c, 0x2c,
-	0xe5, 0x6c, 0xd6, 0xe5, 0xfc, 0x1c, 0xc0, 0x8f, 0x57, 0x7e, 0x0a, 0x57, 0x8f, 0x3e, 0x81, 0x66,
-	0x2e, 0x39, 0xf7, 0xfd, 0xf0, 0x4b, 0xec, 0xa6, 0x70, 0x5c, 0xeb, 0x48, 0x6c, 0x81, 0x12, 0xd4,
-	0x29, 0x71, 0x23, 0xa8, 0x06, 0xd6, 0x9a, 0x0d, 0x5b, 0x00, 0xfd, 0x0b, 0x2d, 0x9b, 0x91, 0x1f,
-	0x39, 0x07, 0x0b, 0xb2, 0xf6, 0xe1, 0x61, 0x87, 0xa0, 0x53, 0xd8, 0xd5, 0xb6, 0xc0, 0x36, 0xd1,
-	0xce, 0xf0, 0x70, 0x01, 0xd7, 0xcb, 0x8e, 0x3d, 0x84, 0xce, 0x01, 0x96, 0x05, 0xb0, 0x03, 0xa8,
-	0x33, 0xfc, 0xbd, 0x72, 0x79, 0x54, 0x50, 0x5c, 0x83, 0x9f, 0x8e, 0xde, 0x5c, 0x64, 0xcc, 0x4c,
-	0x8b, 0x49, 0x9c, 0xca, 0x59, 0x32, 0x2b, 0xca, 0xeb, 0xc5, 0x29, 0x93, 0xd5, 0x32, 0xc9, 0xdf,
-	0x66, 0xc9, 0x37, 0x7e, 0x59, 0x93, 0x5d, 0x3b, 0xcf, 0xff, 0xff, 0x12, 0x00, 0x00, 0xff, 0xff,
-	0x92, 0xe2, 0xc8, 0x69, 0xdc, 0x06, 0x00, 0x00,
+	// 800 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x95, 0x6d, 0x8f, 0x1b, 0x35,
+	0x10, 0xc7, 0xb5, 0x77, 0xb9, 0x24, 0x9d, 0x24, 0x2d, 0x98, 0xbb, 0xb2, 0x04, 0x81, 0xd2, 0x05,
+	0xa9, 0x29, 0x52, 0x77, 0xab, 0x54, 0xaa, 0x54, 0x21, 0x21, 0x1d, 0x69, 0x4f, 0xf0, 0x02, 0x51,
+	0x39, 0x08, 0x24, 0xde, 0x39, 0x5b, 0x77, 0xd7, 0x3a, 0xc7, 0x5e, 0x6c, 0xef, 0x89, 0xf4, 0x2b,
+	0x21, 0x21, 0x3e, 0x12, 0x1f, 0x05, 0xad, 0x1f, 0x92, 0xcd, 0x03, 0x41, 0xbc, 0xf3, 0x78, 0x7e,
+	0x33, 0x1e, 0xff, 0x6d, 0x8f, 0x21, 0xd5, 0x6b, 0x91, 0x97, 0x4a, 0x0a, 0xf6, 0x9e, 0x18, 0x26,
+	0x45, 0x46, 0xc5, 0xdb, 0x4a, 0x32, 0x61, 0x32, 0x45, 0x57, 0xd2, 0xd0, 0xac, 0x52, 0xd2, 0xc8,
+	0x5c, 0xf2, 0xd4, 0x0e, 0x50, 0xd7, 0x4d, 0x8f, 0x93, 0xfd, 0x38, 0xd5, 0x4c, 0x64, 0x54, 0x14,
+	0x4c, 0x50, 0xc7, 0x8e, 0xbf, 0xd8, 0x67, 0x72, 0x29, 0xde, 0xb1, 0xa2, 0x56, 0xd6, 0xf2, 0xd0,
+	0x78, 0x1f, 0x2a, 0xa5, 0xbc, 0xf5, 0xbe, 0xcf, 0xf6, 0x7d, 0x77, 0x54, 0xe9, 0x6d, 0x68, 0x72,
+	0x98, 0x5f, 0xd1, 0x8c, 0xa8, 0xbc, 0x64, 0x77, 0xa1, 0x86, 0x47, 0x47, 0x99, 0xbc, 0x24, 0xa2,
+	0xa0, 0x27, 0xd3, 0x54, 0x4a, 0x2e, 0x39, 0x5d, 0x39, 0x26, 0xf9, 0x3b, 0x82, 0xc9, 0xf7, 0x82,
+	0x19, 0x46, 0x38, 0x7b, 0x4f, 0x17, 0xbb, 0x01, 0x98, 0xfe, 0x56, 0x53, 0x6d, 0x50, 0x0c, 0x3d,
+	0x4d, 0x75, 0x53, 0x60, 0x1c, 0x4d, 0xa2, 0xe9, 0x3d, 0x1c, 0x4c, 0x34, 0x83, 0x9e, 0x2f, 0x3d,
+	0x3e, 0x9b, 0x44, 0xd3, 0xfb, 0xb3, 0x78, 0x5f, 0xf7, 0xf4, 0x67, 0xe7, 0xc7, 0x01, 0x44, 0xaf,
+	0x60, 0xb4, 0xa3, 0x57, 0x7c, 0x3e, 0x89, 0xa6, 0x83, 0xd9, 0xe7, 0x07, 0x91, 0xf3, 0x36, 0x85,
+	0x77, 0x83, 0x10, 0x82, 0x8e, 0x92, 0xd2, 0xc4, 0x1d, 0x5b, 0x90, 0x1d, 0xa3, 0x4b, 0xb8, 0x20,
+	0xbc, 0x2a, 0x49, 0x7c, 0x31, 0x89, 0xa6, 0x7d, 0xec, 0x8c, 0xe4, 0x25, 0x3c, 0x3a, 0xb1, 0x43,
+	0x5d, 0x49, 0xa1, 0x69, 0x13, 0x4a, 0x95, 0x92, 0xca, 0x6f, 0xd0, 0x19, 0xc9, 0x08, 0x06, 0x6f,
+	0x24, 0xe7, 0x5e, 0x87, 0xe4, 0x63, 0xb8, 0x6a, 0xcc, 0xb9, 0x5c, 0x55, 0x9c, 0xb6, 0x04, 0x4a,
+	0xbe, 0x84, 0xa1, 0xe3, 0x4e, 0x66, 0x63, 0x30, 0x58, 0xe4, 0x64, 0xa3, 0xea, 0x0d, 0x5c, 0x2d,
+	0x89, 0xa6, 0x0b, 0x41, 0x2a, 0x5d, 0x4a, 0xb3, 0x60, 0x85, 0x20, 0xa6, 0x56, 0xd4, 0x06, 0x0d,
+	0x66, 0x1f, 0xa4, 0xf6, 0xe6, 0xa5, 0x9b, 0x79, 0x7c, 0x1c, 0x6f, 0x94, 0x78, 0x57, 0x73, 0x6e,
+	0x0f, 0xa0, 0x8f, 0xed, 0x38, 0xf9, 0x2b, 0x82, 0xa1, 0x5b, 0xcb, 0x57, 0xf4, 0x02, 0x46, 0xda,
+	0x47, 0xbe, 0xa2, 0xdc, 0x90, 0x38, 0x9a, 0x9c, 0xb7, 0x16, 0xf9, 0xb1, 0xa2, 0x41, 0xe6, 0x1d,
+	0x0c, 0xbd, 0x80, 0x87, 0x95, 0xa2, 0x9a, 0xaa, 0x3b, 0xaa, 0x5f, 0xff, 0x4e, 0xf3, 0xda, 0x90,
+	0x25, 0xe3, 0xcc, 0xac, 0xfd, 0x72, 0xff, 0xe2, 0xdd, 0x2a, 0x70, 0xde, 0x52, 0x00, 0x8d, 0xa1,
+	0x6f, 0xd4, 0xfa, 0xba, 0x20, 0x4c, 0xd8, 0x83, 0xeb, 0xe3, 0x8d, 0x9d, 0x7c, 0x03, 0xc3, 0x85,
+	0x21, 0x05, 0x0d, 0xf2, 0x5c, 0xc2, 0x45, 0x45, 0x4c, 0xa9, 0x6d, 0xa5, 0xf7, 0xb0, 0x33, 0x9a,
+	0xab, 0xf8, 0x96, 0x15, 0x54, 0x1b, 0x1d, 0x9f, 0x4d, 0xce, 0xa7, 0x43, 0x1c, 0xcc, 0x64, 0x05,
+	0x23, 0x1f, 0xbf, 0x3d, 0x84, 0x23, 0x09, 0x9e, 0x01, 0xe8, 0x20, 0x9d, 0xcb, 0x71, 0x4c, 0xea,
+	0x16, 0x73, 0x7c, 0x2b, 0xc9, 0x2f, 0x30, 0x5a, 0xd4, 0x55, 0xc5, 0xd7, 0xa7, 0xeb, 0xfd, 0xdf,
+	0xcb, 0x25, 0x73, 0xf8, 0xf0, 0x27, 0x45, 0x84, 0x66, 0xed, 0x17, 0x98, 0xc2, 0xc0, 0x6c, 0x26,
+	0xb5, 0x3f, 0xbc, 0x61, 0xda, 0x3c, 0xe8, 0x74, 0x6e, 0xdf, 0x3c, 0x6e, 0x03, 0xc9, 0x9f, 0x11,
+	0xa0, 0x76, 0x16, 0x2f, 0xc9, 0x63, 0xe8, 0x29, 0xaa, 0x6b, 0x6e, 0x42, 0x8a, 0x91, 0x4b, 0x71,
+	0xed, 0x5a, 0x0b, 0x0e, 0x5e, 0xf4, 0x04, 0xfa, 0xbe, 0x4f, 0x84, 0xa2, 0x3d, 0xf9, 0xc6, 0xcd,
+	0xe2, 0x8d, 0x1b, 0xa5, 0x80, 0x74, 0xa3, 0xbb, 0xfa, 0x81, 0x69, 0xcd, 0x44, 0x71, 0xc3, 0x38,
+	0xd5, 0x56, 0xab, 0x3e, 0x3e, 0xe2, 0xd9, 0xca, 0xd9, 0x69, 0xcb, 0xf9, 0x35, 0xdc, 0xc7, 0xb5,
+	0xf8, 0x4e, 0xca, 0xdb, 0xb0, 0xe5, 0x27, 0xd0, 0x69, 0x3a, 0xa6, 0x7f, 0x0d, 0x57, 0x07, 0xdd,
+	0xc1, 0xb2, 0x16, 0x49, 0xc6, 0x10, 0xfb, 0xe0, 0xc3, 0xa7, 0x79, 0x03, 0x0f, 0x36, 0x89, 0xbd,
+	0x0a, 0xcf, 0xa1, 0xeb, 0xf6, 0xe9, 0x73, 0x7f, 0x7a, 0x3c, 0xb7, 0x45, 0xb0, 0x47, 0x93, 0x3f,
+	0xce, 0xe0, 0xc1, 0x6b, 0xff, 0x85, 0x84, 0x12, 0x1f, 0x43, 0xa7, 0x92, 0x9c, 0xfb, 0x34, 0x1f,
+	0xa5, 0xee, 0x0b, 0x49, 0x5b, 0x2d, 0x03, 0x5b, 0xa0, 0x01, 0x75, 0x4e, 0x5c, 0x8f, 0x6c, 0x81,
+	0xad, 0x6e, 0x80, 0x2d, 0x80, 0xbe, 0x82, 0x0b, 0x2b, 0x99, 0xef, 0x89, 0x97, 0x1b, 0xb2, 0xf5,
+	0x32, 0xb0, 0x43, 0xd0, 0x53, 0xe8, 0x6a, 0x7b, 0x03, 0xe3, 0x8e, 0x97, 0x28, 0xc0, 0xed, 0x7b,
+	0x89, 0x3d, 0x84, 0x5e, 0x02, 0x6c, 0x6f, 0x88, 0xed, 0x90, 0x83, 0xd9, 0x27, 0x21, 0xe4, 0xe0,
+	0xc6, 0xe1, 0x16, 0x8c, 0x9e, 0x41, 0x4f, 0x39, 0x0d, 0xe3, 0xae, 0x8d, 0x7b, 0x18, 0xe2, 0x76,
+	0xcf, 0x0c, 0x07, 0xec, 0xdb, 0xf9, 0xaf, 0xd7, 0x05, 0x33, 0x65, 0xbd, 0x4c, 0x73, 0xb9, 0xca,
+	0x56, 0x75, 0x53, 0xb0, 0x78, 0xca, 0x64, 0x18, 0x66, 0xd5, 0x6d, 0x91, 0xfd, 0xc7, 0x0f, 0xbd,
+	0xec, 0xda, 0x2f, 0xea, 0xf9, 0x3f, 0x03, 0x00, 0xf7, 0x45, 0x13, 0x3c, 0xcb, 0x07, 0x00, 0x00,
 }
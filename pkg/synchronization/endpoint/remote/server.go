@@ -2,12 +2,14 @@ package remote
 
 import (
 	contextpkg "context"
+	"io"
 	"net"
 
 	"github.com/pkg/errors"
 
 	"github.com/golang/protobuf/proto"
 
+	"github.com/mutagen-io/mutagen/pkg/agent"
 	"github.com/mutagen-io/mutagen/pkg/compression"
 	"github.com/mutagen-io/mutagen/pkg/encoding"
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
@@ -36,9 +38,13 @@ func ServeEndpoint(logger *logging.Logger, connection net.Conn, options ...Endpo
 	// Defer closure of the connection.
 	defer connection.Close()
 
-	// Enable read/write compression on the connection.
-	reader := compression.NewDecompressingReader(connection)
-	writer := compression.NewCompressingWriter(connection)
+	// Enable read/write compression on the connection if it was negotiated.
+	var reader io.Reader = connection
+	var writer io.Writer = connection
+	if agent.UseCompression(connection) {
+		reader = compression.NewDecompressingReader(connection)
+		writer = compression.NewCompressingWriter(connection)
+	}
 
 	// Create an encoder and decoder.
 	encoder := encoding.NewProtobufEncoder(writer)
@@ -181,6 +187,10 @@ func (s *endpointServer) serve() error {
 			if err := s.serveTransition(request.Transition); err != nil {
 				return errors.Wrap(err, "unable to serve transition request")
 			}
+		} else if request.RunHook != nil {
+			if err := s.serveRunHook(request.RunHook); err != nil {
+				return errors.Wrap(err, "unable to serve hook request")
+			}
 		} else {
 			// TODO: Should we panic here? The request validation already
 			// ensures that one and only one message component is set, so we
@@ -399,3 +409,68 @@ func (s *endpointServer) serveTransition(request *TransitionRequest) error {
 	// Success.
 	return nil
 }
+
+// serveRunHook serves a hook execution request.
+func (s *endpointServer) serveRunHook(request *RunHookRequest) error {
+	// Ensure the request is valid.
+	if err := request.ensureValid(); err != nil {
+		return errors.Wrap(err, "invalid hook request")
+	}
+
+	// Create a cancellable context for executing the hook. The context may be
+	// cancelled to terminate the hook early, but in case the hook completes
+	// naturally, ensure the context is cancelled before we're done.
+	hookContext, forceResponse := contextpkg.WithCancel(contextpkg.Background())
+	defer forceResponse()
+
+	// Start a Goroutine to run the hook and send a response when done. Local
+	// endpoints never return errors from hook execution, but we handle them
+	// for completeness.
+	responseSendResults := make(chan error, 1)
+	go func() {
+		result, err := s.endpoint.RunHook(hookContext, request.Hook)
+		if err != nil {
+			responseSendResults <- errors.Wrap(err, "unable to run hook")
+			return
+		}
+		responseSendResults <- errors.Wrap(
+			s.encoder.Encode(&RunHookResponse{Result: result}),
+			"unable to send hook response",
+		)
+	}()
+
+	// Start a Goroutine to watch for the completion request.
+	completionReceiveResults := make(chan error, 1)
+	go func() {
+		request := &RunHookCompletionRequest{}
+		completionReceiveResults <- errors.Wrap(
+			s.decoder.Decode(request),
+			"unable to receive hook completion request",
+		)
+	}()
+
+	// Wait for both a completion request to be received and a response to be
+	// sent. If the completion receive comes first, then terminate the hook and
+	// wait for the response to be sent.
+	var responseSendErr, completionReceiveErr error
+	select {
+	case responseSendErr = <-responseSendResults:
+		if responseSendErr != nil {
+			return responseSendErr
+		}
+		completionReceiveErr = <-completionReceiveResults
+	case completionReceiveErr = <-completionReceiveResults:
+		forceResponse()
+		responseSendErr = <-responseSendResults
+	}
+
+	// Check for errors.
+	if responseSendErr != nil {
+		return responseSendErr
+	} else if completionReceiveErr != nil {
+		return completionReceiveErr
+	}
+
+	// Success.
+	return nil
+}
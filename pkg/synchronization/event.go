@@ -0,0 +1,267 @@
+package synchronization
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// inCycle returns whether or not the status indicates that a synchronization
+// cycle is in progress.
+func (s Status) inCycle() bool {
+	return s == Status_Scanning || (s >= Status_Reconciling && s <= Status_Saving)
+}
+
+// EnsureValid ensures that Event's invariants are respected.
+func (e *Event) EnsureValid() error {
+	// A nil event is not valid.
+	if e == nil {
+		return errors.New("nil event")
+	}
+
+	// Ensure that the session identifier is non-empty.
+	if e.Session == "" {
+		return errors.New("empty session identifier")
+	}
+
+	// We intentionally don't validate the event type or statuses for the same
+	// reasons that we don't validate statuses in State.
+
+	// Ensure that all conflicts are valid.
+	for _, c := range e.Conflicts {
+		if err := c.EnsureValid(); err != nil {
+			return errors.Wrap(err, "invalid conflict")
+		}
+	}
+
+	// Ensure that all of alpha's problems are valid.
+	for _, p := range e.AlphaProblems {
+		if err := p.EnsureValid(); err != nil {
+			return errors.Wrap(err, "invalid alpha problem")
+		}
+	}
+
+	// Ensure that all of beta's problems are valid.
+	for _, p := range e.BetaProblems {
+		if err := p.EnsureValid(); err != nil {
+			return errors.Wrap(err, "invalid beta problem")
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// problemsEqual determines whether or not two problem lists are equal.
+func problemsEqual(first, second []*core.Problem) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	for i, problem := range first {
+		if problem.Path != second[i].Path || problem.Error != second[i].Error {
+			return false
+		}
+	}
+	return true
+}
+
+// conflictDifference returns the conflicts in first whose roots don't appear in
+// second.
+func conflictDifference(first, second []*core.Conflict) []*core.Conflict {
+	roots := make(map[string]bool, len(second))
+	for _, conflict := range second {
+		roots[conflict.Root()] = true
+	}
+	var result []*core.Conflict
+	for _, conflict := range first {
+		if !roots[conflict.Root()] {
+			result = append(result, conflict)
+		}
+	}
+	return result
+}
+
+// diffState computes the events that describe the transition between two
+// snapshots of a session's state. Since snapshots are taken at state index
+// changes, rapid intermediate transitions may be coalesced, but cycle
+// completions are always reported because they're based on a counter.
+func diffState(previous, current *State, stateIndex uint64) []*Event {
+	// Extract the session identifier.
+	session := current.Session.Identifier
+
+	// Track events.
+	var events []*Event
+
+	// Check for pausing and resuming.
+	if !previous.Session.Paused && current.Session.Paused {
+		events = append(events, &Event{Type: EventType_SessionPaused, Session: session, StateIndex: stateIndex})
+	} else if previous.Session.Paused && !current.Session.Paused {
+		events = append(events, &Event{Type: EventType_SessionResumed, Session: session, StateIndex: stateIndex})
+	}
+
+	// Check for connection changes.
+	if previous.AlphaConnected != current.AlphaConnected {
+		events = append(events, &Event{
+			Type:       EventType_AlphaConnectionChanged,
+			Session:    session,
+			StateIndex: stateIndex,
+			Connected:  current.AlphaConnected,
+		})
+	}
+	if previous.BetaConnected != current.BetaConnected {
+		events = append(events, &Event{
+			Type:       EventType_BetaConnectionChanged,
+			Session:    session,
+			StateIndex: stateIndex,
+			Connected:  current.BetaConnected,
+		})
+	}
+
+	// Check for status changes.
+	if previous.Status != current.Status {
+		events = append(events, &Event{
+			Type:           EventType_StatusChanged,
+			Session:        session,
+			StateIndex:     stateIndex,
+			PreviousStatus: previous.Status,
+			Status:         current.Status,
+		})
+	}
+
+	// Check for cycle starts and completions. If a cycle completed without us
+	// observing its start, then we report the start as well so that each
+	// completion has a corresponding start. If a cycle is in progress after a
+	// completion, then a new cycle has started.
+	completed := current.SuccessfulSynchronizationCycles > previous.SuccessfulSynchronizationCycles
+	if completed && !previous.Status.inCycle() {
+		events = append(events, &Event{Type: EventType_CycleStarted, Session: session, StateIndex: stateIndex})
+	}
+	if completed {
+		events = append(events, &Event{
+			Type:                            EventType_CycleCompleted,
+			Session:                         session,
+			StateIndex:                      stateIndex,
+			SuccessfulSynchronizationCycles: current.SuccessfulSynchronizationCycles,
+		})
+	}
+	if current.Status.inCycle() && (completed || !previous.Status.inCycle()) {
+		events = append(events, &Event{Type: EventType_CycleStarted, Session: session, StateIndex: stateIndex})
+	}
+
+	// Check for conflict changes.
+	if removed := conflictDifference(previous.Conflicts, current.Conflicts); len(removed) > 0 {
+		events = append(events, &Event{
+			Type:       EventType_ConflictsRemoved,
+			Session:    session,
+			StateIndex: stateIndex,
+			Conflicts:  removed,
+		})
+	}
+	if added := conflictDifference(current.Conflicts, previous.Conflicts); len(added) > 0 {
+		events = append(events, &Event{
+			Type:       EventType_ConflictsAdded,
+			Session:    session,
+			StateIndex: stateIndex,
+			Conflicts:  added,
+		})
+	}
+
+	// Check for problem changes.
+	if !problemsEqual(previous.AlphaProblems, current.AlphaProblems) ||
+		!problemsEqual(previous.BetaProblems, current.BetaProblems) {
+		events = append(events, &Event{
+			Type:          EventType_ProblemsChanged,
+			Session:       session,
+			StateIndex:    stateIndex,
+			AlphaProblems: current.AlphaProblems,
+			BetaProblems:  current.BetaProblems,
+		})
+	}
+
+	// Check for error changes.
+	if previous.LastError != current.LastError {
+		events = append(events, &Event{
+			Type:       EventType_ErrorChanged,
+			Session:    session,
+			StateIndex: stateIndex,
+			LastError:  current.LastError,
+		})
+	}
+
+	// Done.
+	return events
+}
+
+// diffStates computes the events that describe the transition between two sets
+// of session state snapshots, each keyed by session identifier. Sessions that
+// only appear in the current set are reported as added (along with any of
+// their conflicts and problems) and sessions that only appear in the previous
+// set are reported as removed. Events are ordered by session creation time,
+// with removals reported last.
+func diffStates(previous, current map[string]*State, stateIndex uint64) []*Event {
+	// Sort the current states by session creation time.
+	states := make([]*State, 0, len(current))
+	for _, state := range current {
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		iTime := states[i].Session.CreationTime
+		jTime := states[j].Session.CreationTime
+		return iTime.Seconds < jTime.Seconds ||
+			(iTime.Seconds == jTime.Seconds && iTime.Nanos < jTime.Nanos)
+	})
+
+	// Compute events for current sessions.
+	var events []*Event
+	for _, state := range states {
+		identifier := state.Session.Identifier
+		if p, ok := previous[identifier]; ok {
+			events = append(events, diffState(p, state, stateIndex)...)
+			continue
+		}
+		events = append(events, &Event{
+			Type:       EventType_SessionAdded,
+			Session:    identifier,
+			StateIndex: stateIndex,
+			Status:     state.Status,
+		})
+		if len(state.Conflicts) > 0 {
+			events = append(events, &Event{
+				Type:       EventType_ConflictsAdded,
+				Session:    identifier,
+				StateIndex: stateIndex,
+				Conflicts:  state.Conflicts,
+			})
+		}
+		if len(state.AlphaProblems) > 0 || len(state.BetaProblems) > 0 {
+			events = append(events, &Event{
+				Type:          EventType_ProblemsChanged,
+				Session:       identifier,
+				StateIndex:    stateIndex,
+				AlphaProblems: state.AlphaProblems,
+				BetaProblems:  state.BetaProblems,
+			})
+		}
+	}
+
+	// Compute events for removed sessions.
+	var removed []string
+	for identifier := range previous {
+		if _, ok := current[identifier]; !ok {
+			removed = append(removed, identifier)
+		}
+	}
+	sort.Strings(removed)
+	for _, identifier := range removed {
+		events = append(events, &Event{
+			Type:       EventType_SessionRemoved,
+			Session:    identifier,
+			StateIndex: stateIndex,
+		})
+	}
+
+	// Done.
+	return events
+}
@@ -0,0 +1,153 @@
+package synchronization
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// eventTypes extracts the types from a list of events.
+func eventTypes(events []*Event) []EventType {
+	types := make([]EventType, len(events))
+	for i, event := range events {
+		types[i] = event.Type
+	}
+	return types
+}
+
+// checkEventTypes verifies that a list of events has the expected types.
+func checkEventTypes(t *testing.T, events []*Event, expected ...EventType) {
+	// Mark this as a helper function to remove it from error traces.
+	t.Helper()
+
+	// Compare types.
+	types := eventTypes(events)
+	if len(types) != len(expected) {
+		t.Fatal("event count mismatch:", types, "!=", expected)
+	}
+	for i, eventType := range types {
+		if eventType != expected[i] {
+			t.Fatal("event type mismatch:", types, "!=", expected)
+		}
+	}
+}
+
+// testConflict creates a conflict rooted at the specified path.
+func testConflict(path string) *core.Conflict {
+	return &core.Conflict{
+		AlphaChanges: []*core.Change{{Path: path}},
+		BetaChanges:  []*core.Change{{Path: path}},
+	}
+}
+
+func TestDiffStatesAddedAndRemoved(t *testing.T) {
+	// Create states.
+	first := &State{
+		Session:   &Session{Identifier: "first", CreationTime: &timestamp.Timestamp{Seconds: 1}},
+		Status:    Status_Watching,
+		Conflicts: []*core.Conflict{testConflict("a")},
+	}
+	second := &State{
+		Session: &Session{Identifier: "second", CreationTime: &timestamp.Timestamp{Seconds: 2}},
+		Status:  Status_Disconnected,
+	}
+
+	// Verify initial events, which should be ordered by creation time.
+	events := diffStates(nil, map[string]*State{"second": second, "first": first}, 1)
+	checkEventTypes(t, events, EventType_SessionAdded, EventType_ConflictsAdded, EventType_SessionAdded)
+	if events[0].Session != "first" || events[2].Session != "second" {
+		t.Error("session addition events incorrectly ordered")
+	}
+	if events[0].Status != Status_Watching {
+		t.Error("session addition event has incorrect status")
+	}
+
+	// Verify removal events.
+	events = diffStates(map[string]*State{"first": first, "second": second}, map[string]*State{"second": second}, 2)
+	checkEventTypes(t, events, EventType_SessionRemoved)
+	if events[0].Session != "first" || events[0].StateIndex != 2 {
+		t.Error("session removal event has incorrect metadata")
+	}
+}
+
+func TestDiffStateCycle(t *testing.T) {
+	// Create a session.
+	session := &Session{Identifier: "session"}
+
+	// Verify events for a cycle starting.
+	watching := &State{Session: session, Status: Status_Watching, AlphaConnected: true, BetaConnected: true}
+	scanning := &State{Session: session, Status: Status_Scanning, AlphaConnected: true, BetaConnected: true}
+	checkEventTypes(t, diffState(watching, scanning, 1), EventType_StatusChanged, EventType_CycleStarted)
+
+	// Verify events for a cycle completing.
+	completed := &State{
+		Session:                         session,
+		Status:                          Status_Watching,
+		AlphaConnected:                  true,
+		BetaConnected:                   true,
+		SuccessfulSynchronizationCycles: 1,
+	}
+	events := diffState(scanning, completed, 2)
+	checkEventTypes(t, events, EventType_StatusChanged, EventType_CycleCompleted)
+	if events[1].SuccessfulSynchronizationCycles != 1 {
+		t.Error("cycle completion event has incorrect cycle count")
+	}
+
+	// Verify that a coalesced cycle still reports a start and a completion.
+	coalesced := &State{
+		Session:                         session,
+		Status:                          Status_Watching,
+		AlphaConnected:                  true,
+		BetaConnected:                   true,
+		SuccessfulSynchronizationCycles: 2,
+	}
+	checkEventTypes(t, diffState(completed, coalesced, 3), EventType_CycleStarted, EventType_CycleCompleted)
+}
+
+func TestDiffStateConnectionsConflictsProblemsAndErrors(t *testing.T) {
+	// Create a session.
+	session := &Session{Identifier: "session"}
+
+	// Create states.
+	previous := &State{
+		Session:        session,
+		AlphaConnected: true,
+		Conflicts:      []*core.Conflict{testConflict("a"), testConflict("b")},
+	}
+	current := &State{
+		Session:       &Session{Identifier: "session", Paused: true},
+		LastError:     "error",
+		Conflicts:     []*core.Conflict{testConflict("b"), testConflict("c")},
+		AlphaProblems: []*core.Problem{{Path: "path", Error: "error"}},
+	}
+
+	// Verify events.
+	events := diffState(previous, current, 1)
+	checkEventTypes(t, events,
+		EventType_SessionPaused,
+		EventType_AlphaConnectionChanged,
+		EventType_ConflictsRemoved,
+		EventType_ConflictsAdded,
+		EventType_ProblemsChanged,
+		EventType_ErrorChanged,
+	)
+	if events[1].Connected {
+		t.Error("connection change event indicates connection")
+	}
+	if len(events[2].Conflicts) != 1 || events[2].Conflicts[0].Root() != "a" {
+		t.Error("incorrect conflicts removed")
+	}
+	if len(events[3].Conflicts) != 1 || events[3].Conflicts[0].Root() != "c" {
+		t.Error("incorrect conflicts added")
+	}
+	if events[5].LastError != "error" {
+		t.Error("error change event has incorrect error")
+	}
+
+	// Verify that identical states don't generate events.
+	if events := diffState(current, current, 2); len(events) != 0 {
+		t.Error("identical states generated events:", eventTypes(events))
+	}
+}
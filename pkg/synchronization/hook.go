@@ -0,0 +1,318 @@
+package synchronization
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultHookTimeout is the default maximum execution time for hooks.
+	defaultHookTimeout = time.Minute
+	// maximumHookOutputSize is the maximum number of bytes of hook output that
+	// will be captured. Output beyond this size is discarded.
+	maximumHookOutputSize = 64 * 1024
+	// hookOutputGracePeriod is the maximum amount of time to wait for hook
+	// output to be closed after the hook exits.
+	hookOutputGracePeriod = time.Second
+)
+
+// IsDefault indicates whether or not the hook trigger is
+// HookTrigger_HookTriggerDefault.
+func (t HookTrigger) IsDefault() bool {
+	return t == HookTrigger_HookTriggerDefault
+}
+
+// UnmarshalText implements the text unmarshalling interface used when loading
+// from TOML files.
+func (t *HookTrigger) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a hook trigger.
+	switch text {
+	case "cycle-completed":
+		*t = HookTrigger_HookTriggerCycleCompleted
+	case "conflict-detected":
+		*t = HookTrigger_HookTriggerConflictDetected
+	case "halted":
+		*t = HookTrigger_HookTriggerHalted
+	case "connected":
+		*t = HookTrigger_HookTriggerConnected
+	case "disconnected":
+		*t = HookTrigger_HookTriggerDisconnected
+	default:
+		return errors.Errorf("unknown hook trigger specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular hook trigger is a valid,
+// non-default value.
+func (t HookTrigger) Supported() bool {
+	switch t {
+	case HookTrigger_HookTriggerCycleCompleted:
+		return true
+	case HookTrigger_HookTriggerConflictDetected:
+		return true
+	case HookTrigger_HookTriggerHalted:
+		return true
+	case HookTrigger_HookTriggerConnected:
+		return true
+	case HookTrigger_HookTriggerDisconnected:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a hook trigger.
+func (t HookTrigger) Description() string {
+	switch t {
+	case HookTrigger_HookTriggerDefault:
+		return "Default"
+	case HookTrigger_HookTriggerCycleCompleted:
+		return "Cycle Completed"
+	case HookTrigger_HookTriggerConflictDetected:
+		return "Conflict Detected"
+	case HookTrigger_HookTriggerHalted:
+		return "Halted"
+	case HookTrigger_HookTriggerConnected:
+		return "Connected"
+	case HookTrigger_HookTriggerDisconnected:
+		return "Disconnected"
+	default:
+		return "Unknown"
+	}
+}
+
+// IsDefault indicates whether or not the hook location is
+// HookLocation_HookLocationDefault.
+func (l HookLocation) IsDefault() bool {
+	return l == HookLocation_HookLocationDefault
+}
+
+// UnmarshalText implements the text unmarshalling interface used when loading
+// from TOML files.
+func (l *HookLocation) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a hook location.
+	switch text {
+	case "local":
+		*l = HookLocation_HookLocationLocal
+	case "alpha":
+		*l = HookLocation_HookLocationAlpha
+	case "beta":
+		*l = HookLocation_HookLocationBeta
+	default:
+		return errors.Errorf("unknown hook location specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular hook location is a valid,
+// non-default value.
+func (l HookLocation) Supported() bool {
+	switch l {
+	case HookLocation_HookLocationLocal:
+		return true
+	case HookLocation_HookLocationAlpha:
+		return true
+	case HookLocation_HookLocationBeta:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a hook location.
+func (l HookLocation) Description() string {
+	switch l {
+	case HookLocation_HookLocationDefault:
+		return "Default"
+	case HookLocation_HookLocationLocal:
+		return "Local"
+	case HookLocation_HookLocationAlpha:
+		return "Alpha"
+	case HookLocation_HookLocationBeta:
+		return "Beta"
+	default:
+		return "Unknown"
+	}
+}
+
+// EnsureValid ensures that Hook's invariants are respected.
+func (h *Hook) EnsureValid() error {
+	// A nil hook is not valid.
+	if h == nil {
+		return errors.New("nil hook")
+	}
+
+	// Verify that the trigger is supported. Unlike most enumerations, there's
+	// no sensible default.
+	if !h.Trigger.Supported() {
+		return errors.New("unknown or unsupported hook trigger")
+	}
+
+	// Verify that the location is unspecified or supported.
+	if !(h.Location.IsDefault() || h.Location.Supported()) {
+		return errors.New("unknown or unsupported hook location")
+	}
+
+	// Endpoints are disconnected by the time that disconnection hooks run, so
+	// those hooks can only be executed locally.
+	if h.Trigger == HookTrigger_HookTriggerDisconnected &&
+		!(h.Location.IsDefault() || h.Location == HookLocation_HookLocationLocal) {
+		return errors.New("disconnection hooks must be executed locally")
+	}
+
+	// Verify that the command is non-empty.
+	if h.Command == "" {
+		return errors.New("empty hook command")
+	}
+
+	// The working directory and timeout don't need to be validated - any of
+	// their values are technically valid.
+
+	// Success.
+	return nil
+}
+
+// limitedBuffer is an io.Writer that captures output up to a fixed size and
+// silently discards any output beyond that.
+type limitedBuffer struct {
+	// buffer is the underlying buffer.
+	buffer bytes.Buffer
+	// limit is the maximum number of bytes to capture.
+	limit int
+	// truncated indicates whether or not output has been discarded.
+	truncated bool
+}
+
+// Write implements io.Writer.Write.
+func (b *limitedBuffer) Write(data []byte) (int, error) {
+	if remaining := b.limit - b.buffer.Len(); len(data) > remaining {
+		b.buffer.Write(data[:remaining])
+		b.truncated = true
+	} else {
+		b.buffer.Write(data)
+	}
+	return len(data), nil
+}
+
+// String returns the captured output.
+func (b *limitedBuffer) String() string {
+	if b.truncated {
+		return b.buffer.String() + "\n[output truncated]"
+	}
+	return b.buffer.String()
+}
+
+// RunHook executes a hook command using the system shell and returns its
+// result. The hook's working directory is resolved against the specified base
+// directory, which is also used if the hook doesn't specify a working
+// directory. If the hook exceeds its timeout or the provided context is
+// cancelled, then it's terminated along with any processes that it has spawned.
+// Failures to run the command are reported in the result rather than returned
+// as an error.
+func RunHook(context context.Context, hook *Hook, baseDirectory string) *HookResult {
+	// Compute the working directory.
+	workingDirectory := hook.WorkingDirectory
+	if workingDirectory == "" {
+		workingDirectory = baseDirectory
+	} else if !filepath.IsAbs(workingDirectory) {
+		workingDirectory = filepath.Join(baseDirectory, workingDirectory)
+	}
+
+	// Compute the timeout.
+	timeout := defaultHookTimeout
+	if hook.Timeout != 0 {
+		timeout = time.Duration(hook.Timeout) * time.Second
+	}
+
+	// Create a pipe to capture output. We manage this pipe ourselves (rather
+	// than letting os/exec copy output) because processes spawned by the hook
+	// may inherit the pipe and hold it open after the hook exits, in which case
+	// os/exec would block until they exit.
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return &HookResult{Error: errors.Wrap(err, "unable to create output pipe").Error()}
+	}
+	defer reader.Close()
+
+	// Create and start the command.
+	command := hookCommand(hook.Command)
+	command.Dir = workingDirectory
+	command.Stdout = writer
+	command.Stderr = writer
+	err = command.Start()
+	writer.Close()
+	if err != nil {
+		return &HookResult{Error: errors.Wrap(err, "unable to start hook").Error()}
+	}
+
+	// Capture output in a background Goroutine.
+	output := &limitedBuffer{limit: maximumHookOutputSize}
+	outputDone := make(chan struct{})
+	go func() {
+		io.Copy(output, reader)
+		close(outputDone)
+	}()
+
+	// Wait for the command to exit, terminating it if it exceeds its timeout
+	// or if execution is cancelled.
+	waitResults := make(chan error, 1)
+	go func() {
+		waitResults <- command.Wait()
+	}()
+	timer := time.NewTimer(timeout)
+	var timedOut, cancelled bool
+	select {
+	case err = <-waitResults:
+		timer.Stop()
+	case <-timer.C:
+		timedOut = true
+		terminateHook(command)
+		err = <-waitResults
+	case <-context.Done():
+		timer.Stop()
+		cancelled = true
+		terminateHook(command)
+		err = <-waitResults
+	}
+
+	// Wait for output capture to complete, but only for a limited time since
+	// the output pipe may be held open by processes spawned by the hook.
+	select {
+	case <-outputDone:
+	case <-time.After(hookOutputGracePeriod):
+		reader.Close()
+		<-outputDone
+	}
+
+	// Record the result.
+	result := &HookResult{Output: output.String()}
+	if err != nil {
+		if timedOut {
+			result.Error = "hook timed out"
+		} else if cancelled {
+			result.Error = "hook cancelled"
+		} else {
+			result.Error = errors.Wrap(err, "hook failed").Error()
+		}
+	}
+
+	// Done.
+	return result
+}
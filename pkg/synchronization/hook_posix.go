@@ -0,0 +1,22 @@
+// +build !windows
+
+package synchronization
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// hookCommand creates a command that runs the specified hook command string
+// using the system shell. The command is run in its own process group so that
+// it can be terminated along with any processes that it spawns.
+func hookCommand(command string) *exec.Cmd {
+	result := exec.Command("/bin/sh", "-c", command)
+	result.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	return result
+}
+
+// terminateHook terminates a hook command's process group.
+func terminateHook(command *exec.Cmd) {
+	syscall.Kill(-command.Process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,137 @@
+// +build !windows
+
+package synchronization
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRunHookSuccess tests that RunHook captures output and runs in the
+// expected working directory.
+func TestRunHookSuccess(t *testing.T) {
+	// Create a temporary directory to act as the base directory and remove it
+	// when we're done.
+	directory, err := ioutil.TempDir("", "mutagen_hook")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Create a subdirectory to use as a relative working directory.
+	if err := os.Mkdir(filepath.Join(directory, "sub"), 0700); err != nil {
+		t.Fatal("unable to create subdirectory:", err)
+	}
+
+	// Run a hook that prints its working directory.
+	result := RunHook(context.Background(), &Hook{
+		Trigger:          HookTrigger_HookTriggerCycleCompleted,
+		Command:          "echo hello && basename \"$(pwd)\"",
+		WorkingDirectory: "sub",
+	}, directory)
+
+	// Verify the result.
+	if result.Error != "" {
+		t.Fatal("hook failed unexpectedly:", result.Error)
+	}
+	if result.Output != "hello\nsub\n" {
+		t.Errorf("unexpected hook output: %q", result.Output)
+	}
+}
+
+// TestRunHookFailure tests that RunHook reports command failures.
+func TestRunHookFailure(t *testing.T) {
+	result := RunHook(context.Background(), &Hook{
+		Trigger: HookTrigger_HookTriggerHalted,
+		Command: "echo failing && exit 3",
+	}, os.TempDir())
+	if result.Error == "" {
+		t.Error("hook failure not reported")
+	}
+	if result.Output != "failing\n" {
+		t.Errorf("unexpected hook output: %q", result.Output)
+	}
+}
+
+// TestRunHookTimeout tests that RunHook enforces hook timeouts.
+func TestRunHookTimeout(t *testing.T) {
+	result := RunHook(context.Background(), &Hook{
+		Trigger: HookTrigger_HookTriggerHalted,
+		Command: "sleep 10",
+		Timeout: 1,
+	}, os.TempDir())
+	if result.Error != "hook timed out" {
+		t.Errorf("unexpected hook error: %q", result.Error)
+	}
+}
+
+// TestRunHookTimeoutWithBackgroundedChild tests that RunHook terminates
+// processes spawned by a hook when the hook times out, even if they hold the
+// hook's output open.
+func TestRunHookTimeoutWithBackgroundedChild(t *testing.T) {
+	start := time.Now()
+	result := RunHook(context.Background(), &Hook{
+		Trigger: HookTrigger_HookTriggerHalted,
+		Command: "sleep 30 & sleep 30",
+		Timeout: 1,
+	}, os.TempDir())
+	if result.Error != "hook timed out" {
+		t.Errorf("unexpected hook error: %q", result.Error)
+	}
+	if duration := time.Since(start); duration > 10*time.Second {
+		t.Error("hook execution not terminated in a timely fashion:", duration)
+	}
+}
+
+// TestRunHookCancellation tests that RunHook terminates hooks (and processes
+// that they've spawned) when its context is cancelled.
+func TestRunHookCancellation(t *testing.T) {
+	context, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	result := RunHook(context, &Hook{
+		Trigger: HookTrigger_HookTriggerHalted,
+		Command: "sleep 30 & sleep 30",
+	}, os.TempDir())
+	if result.Error != "hook cancelled" {
+		t.Errorf("unexpected hook error: %q", result.Error)
+	}
+	if duration := time.Since(start); duration > 10*time.Second {
+		t.Error("hook execution not terminated in a timely fashion:", duration)
+	}
+}
+
+// TestRunHookBackgroundedChild tests that RunHook doesn't wait for processes
+// spawned by a successful hook that hold the hook's output open.
+func TestRunHookBackgroundedChild(t *testing.T) {
+	start := time.Now()
+	result := RunHook(context.Background(), &Hook{
+		Trigger: HookTrigger_HookTriggerHalted,
+		Command: "sleep 5 & echo started",
+	}, os.TempDir())
+	if result.Error != "" {
+		t.Errorf("unexpected hook error: %q", result.Error)
+	}
+	if result.Output != "started\n" {
+		t.Errorf("unexpected hook output: %q", result.Output)
+	}
+	if duration := time.Since(start); duration > 10*time.Second {
+		t.Error("hook execution blocked by backgrounded process:", duration)
+	}
+}
+
+// TestLimitedBuffer tests that limitedBuffer truncates output.
+func TestLimitedBuffer(t *testing.T) {
+	buffer := &limitedBuffer{limit: 4}
+	if n, err := buffer.Write([]byte("abcdef")); err != nil || n != 6 {
+		t.Fatal("unexpected write result:", n, err)
+	}
+	if output := buffer.String(); !strings.HasPrefix(output, "abcd\n") || !buffer.truncated {
+		t.Errorf("unexpected buffer output: %q", output)
+	}
+}
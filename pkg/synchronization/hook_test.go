@@ -0,0 +1,160 @@
+package synchronization
+
+import (
+	"testing"
+)
+
+// TestHookTriggerUnmarshal tests that unmarshaling from a string specification
+// succeeds for HookTrigger.
+func TestHookTriggerUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text            string
+		expectedTrigger HookTrigger
+		expectFailure   bool
+	}{
+		{"", HookTrigger_HookTriggerDefault, true},
+		{"asdf", HookTrigger_HookTriggerDefault, true},
+		{"cycle-completed", HookTrigger_HookTriggerCycleCompleted, false},
+		{"conflict-detected", HookTrigger_HookTriggerConflictDetected, false},
+		{"halted", HookTrigger_HookTriggerHalted, false},
+		{"connected", HookTrigger_HookTriggerConnected, false},
+		{"disconnected", HookTrigger_HookTriggerDisconnected, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var trigger HookTrigger
+		if err := trigger.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if trigger != testCase.expectedTrigger {
+			t.Errorf(
+				"unmarshaled trigger (%s) does not match expected (%s)",
+				trigger,
+				testCase.expectedTrigger,
+			)
+		}
+	}
+}
+
+// TestHookTriggerSupported tests that HookTrigger support detection works as
+// expected.
+func TestHookTriggerSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		trigger         HookTrigger
+		expectSupported bool
+	}{
+		{HookTrigger_HookTriggerDefault, false},
+		{HookTrigger_HookTriggerCycleCompleted, true},
+		{HookTrigger_HookTriggerConflictDetected, true},
+		{HookTrigger_HookTriggerHalted, true},
+		{HookTrigger_HookTriggerConnected, true},
+		{HookTrigger_HookTriggerDisconnected, true},
+		{(HookTrigger_HookTriggerDisconnected + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.trigger.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"trigger support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestHookLocationUnmarshal tests that unmarshaling from a string
+// specification succeeds for HookLocation.
+func TestHookLocationUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text             string
+		expectedLocation HookLocation
+		expectFailure    bool
+	}{
+		{"", HookLocation_HookLocationDefault, true},
+		{"asdf", HookLocation_HookLocationDefault, true},
+		{"local", HookLocation_HookLocationLocal, false},
+		{"alpha", HookLocation_HookLocationAlpha, false},
+		{"beta", HookLocation_HookLocationBeta, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var location HookLocation
+		if err := location.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if location != testCase.expectedLocation {
+			t.Errorf(
+				"unmarshaled location (%s) does not match expected (%s)",
+				location,
+				testCase.expectedLocation,
+			)
+		}
+	}
+}
+
+// TestHookLocationSupported tests that HookLocation support detection works as
+// expected.
+func TestHookLocationSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		location        HookLocation
+		expectSupported bool
+	}{
+		{HookLocation_HookLocationDefault, false},
+		{HookLocation_HookLocationLocal, true},
+		{HookLocation_HookLocationAlpha, true},
+		{HookLocation_HookLocationBeta, true},
+		{(HookLocation_HookLocationBeta + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.location.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"location support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestHookEnsureValid tests Hook.EnsureValid.
+func TestHookEnsureValid(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		hook          *Hook
+		expectFailure bool
+	}{
+		{nil, true},
+		{&Hook{Command: "true"}, true},
+		{&Hook{Trigger: HookTrigger_HookTriggerHalted}, true},
+		{&Hook{Trigger: HookTrigger_HookTriggerHalted, Location: HookLocation_HookLocationBeta + 1, Command: "true"}, true},
+		{&Hook{Trigger: HookTrigger_HookTriggerDisconnected, Location: HookLocation_HookLocationAlpha, Command: "true"}, true},
+		{&Hook{Trigger: HookTrigger_HookTriggerDisconnected, Command: "true"}, false},
+		{&Hook{Trigger: HookTrigger_HookTriggerCycleCompleted, Location: HookLocation_HookLocationBeta, Command: "make restart"}, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		err := testCase.hook.EnsureValid()
+		if err == nil && testCase.expectFailure {
+			t.Errorf("test case %d: hook validation succeeded unexpectedly", i)
+		} else if err != nil && !testCase.expectFailure {
+			t.Errorf("test case %d: hook validation failed unexpectedly: %v", i, err)
+		}
+	}
+}
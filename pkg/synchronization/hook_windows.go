@@ -0,0 +1,37 @@
+package synchronization
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// hookCommand creates a command that runs the specified hook command string
+// using the system shell.
+func hookCommand(command string) *exec.Cmd {
+	// Determine the shell to use.
+	shell := os.Getenv("COMSPEC")
+	if shell == "" {
+		shell = "cmd.exe"
+	}
+
+	// Create the command. We pass the command line verbatim since cmd.exe
+	// doesn't follow the standard argument quoting rules.
+	result := exec.Command(shell)
+	result.SysProcAttr = &syscall.SysProcAttr{
+		CmdLine: shell + " /C " + command,
+	}
+	return result
+}
+
+// terminateHook terminates a hook command along with any processes that it has
+// spawned. Windows doesn't have process groups in the POSIX sense, so we rely
+// on taskkill to terminate the process tree, falling back to terminating only
+// the hook process if that fails.
+func terminateHook(command *exec.Cmd) {
+	pid := strconv.Itoa(command.Process.Pid)
+	if exec.Command("taskkill", "/T", "/F", "/PID", pid).Run() != nil {
+		command.Process.Kill()
+	}
+}
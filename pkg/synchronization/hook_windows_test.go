@@ -0,0 +1,84 @@
+package synchronization
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRunHookSuccess tests that RunHook captures output and runs in the
+// expected working directory.
+func TestRunHookSuccess(t *testing.T) {
+	// Create a temporary directory to act as the base directory and remove it
+	// when we're done.
+	directory, err := ioutil.TempDir("", "mutagen_hook")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Create a subdirectory to use as a relative working directory.
+	if err := os.Mkdir(filepath.Join(directory, "sub"), 0700); err != nil {
+		t.Fatal("unable to create subdirectory:", err)
+	}
+
+	// Run a hook that prints its working directory.
+	result := RunHook(context.Background(), &Hook{
+		Trigger:          HookTrigger_HookTriggerCycleCompleted,
+		Command:          "echo hello && cd",
+		WorkingDirectory: "sub",
+	}, directory)
+
+	// Verify the result.
+	if result.Error != "" {
+		t.Fatal("hook failed unexpectedly:", result.Error)
+	}
+	lines := strings.Split(strings.TrimSpace(result.Output), "\r\n")
+	if len(lines) != 2 || lines[0] != "hello" || !strings.HasSuffix(lines[1], "sub") {
+		t.Errorf("unexpected hook output: %q", result.Output)
+	}
+}
+
+// TestRunHookFailure tests that RunHook reports command failures.
+func TestRunHookFailure(t *testing.T) {
+	result := RunHook(context.Background(), &Hook{
+		Trigger: HookTrigger_HookTriggerHalted,
+		Command: "echo failing && exit /b 3",
+	}, os.TempDir())
+	if result.Error == "" {
+		t.Error("hook failure not reported")
+	}
+	if strings.TrimSpace(result.Output) != "failing" {
+		t.Errorf("unexpected hook output: %q", result.Output)
+	}
+}
+
+// TestRunHookTimeout tests that RunHook enforces hook timeouts.
+func TestRunHookTimeout(t *testing.T) {
+	result := RunHook(context.Background(), &Hook{
+		Trigger: HookTrigger_HookTriggerHalted,
+		Command: "ping -n 30 127.0.0.1 >NUL",
+		Timeout: 1,
+	}, os.TempDir())
+	if result.Error != "hook timed out" {
+		t.Errorf("unexpected hook error: %q", result.Error)
+	}
+}
+
+// TestRunHookCancellation tests that RunHook terminates hooks when its context
+// is cancelled.
+func TestRunHookCancellation(t *testing.T) {
+	context, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	result := RunHook(context, &Hook{
+		Trigger: HookTrigger_HookTriggerHalted,
+		Command: "ping -n 30 127.0.0.1 >NUL",
+	}, os.TempDir())
+	if result.Error != "hook cancelled" {
+		t.Errorf("unexpected hook error: %q", result.Error)
+	}
+}
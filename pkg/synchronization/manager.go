@@ -12,6 +12,7 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/logging"
 	"github.com/mutagen-io/mutagen/pkg/selection"
 	"github.com/mutagen-io/mutagen/pkg/state"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
 	"github.com/mutagen-io/mutagen/pkg/url"
 )
 
@@ -100,13 +101,16 @@ func (m *Manager) findControllersBySpecification(specifications []string) ([]*co
 	for _, specification := range specifications {
 		var matched bool
 		for _, controller := range m.sessions {
-			if controller.session.Identifier == specification || controller.session.Name == specification {
+			controller.stateLock.Lock()
+			name := controller.session.Name
+			controller.stateLock.UnlockWithoutNotify()
+			if controller.session.Identifier == specification || name == specification {
 				controllerSet[controller] = true
 				matched = true
 			}
 		}
 		if !matched {
-			return nil, errors.Errorf("specification \"%s\" did not match any sessions", specification)
+			return nil, &selection.NoMatchError{Specification: specification}
 		}
 	}
 
@@ -136,7 +140,10 @@ func (m *Manager) findControllersByLabelSelector(labelSelector string) ([]*contr
 	// Loop over controllers and look for matches.
 	var controllers []*controller
 	for _, controller := range m.sessions {
-		if selector.Matches(controller.session.Labels) {
+		controller.stateLock.Lock()
+		labels := controller.session.Labels
+		controller.stateLock.UnlockWithoutNotify()
+		if selector.Matches(labels) {
 			controllers = append(controllers, controller)
 		}
 	}
@@ -183,12 +190,20 @@ func (m *Manager) Shutdown() {
 	}
 }
 
-// Create tells the manager to create a new session.
+// Create tells the manager to create a new session. If archive is non-nil, then
+// it's used as the session's initial archive (e.g. when importing a session),
+// otherwise the session starts with an empty archive. Sessions with non-empty
+// initial archives are always created paused, since an archive that doesn't
+// reflect the current endpoint contents (e.g. because an endpoint is empty or
+// different) would cause missing content to be treated as deleted and those
+// deletions to be propagated. The user should verify endpoint contents before
+// resuming such sessions.
 func (m *Manager) Create(
 	alpha, beta *url.URL,
 	configuration, configurationAlpha, configurationBeta *Configuration,
 	name string,
 	labels map[string]string,
+	archive *core.Archive,
 	paused bool,
 	prompter string,
 ) (string, error) {
@@ -199,6 +214,12 @@ func (m *Manager) Create(
 	}
 	identifier := randomUUID.String()
 
+	// If a non-empty initial archive has been provided, then force the session
+	// to be created paused.
+	if archive != nil && archive.Root != nil {
+		paused = true
+	}
+
 	// Attempt to create a session.
 	controller, err := newSession(
 		m.logger.Sublogger(identifier),
@@ -208,6 +229,7 @@ func (m *Manager) Create(
 		configuration, configurationAlpha, configurationBeta,
 		name,
 		labels,
+		archive,
 		paused,
 		prompter,
 	)
@@ -256,6 +278,96 @@ func (m *Manager) List(selection *selection.Selection, previousStateIndex uint64
 	return stateIndex, states, nil
 }
 
+// Watch monitors sessions matching the given selection and invokes the provided
+// callback with events describing changes to their states. Sessions that exist
+// when watching begins are reported via initial session addition events. If
+// the selection uses specifications, then they're resolved once when watching
+// begins, otherwise the selection is re-evaluated at each state change. Watch
+// runs until the context is cancelled, state tracking is terminated, or the
+// callback returns an error.
+func (m *Manager) Watch(context contextpkg.Context, selection *selection.Selection, callback func([]*Event) error) error {
+	return m.WatchStates(context, selection, func(events []*Event, _ map[string]*State) error {
+		return callback(events)
+	})
+}
+
+// WatchStates is like Watch, but it also provides the callback with the session
+// state snapshot from which events were computed, keyed by session identifier.
+// Since the snapshot is captured atomically with the events, callbacks can use
+// it to look up session metadata without racing against subsequent changes.
+// The snapshot should be treated as read-only.
+func (m *Manager) WatchStates(context contextpkg.Context, selection *selection.Selection, callback func([]*Event, map[string]*State) error) error {
+	// Validate the selection. If it uses specifications, then resolve them to
+	// a fixed set of session identifiers.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return errors.Wrap(err, "unable to locate requested sessions")
+	}
+	var identifiers map[string]bool
+	if len(selection.Specifications) > 0 {
+		identifiers = make(map[string]bool, len(controllers))
+		for _, controller := range controllers {
+			identifiers[controller.session.Identifier] = true
+		}
+	}
+
+	// Loop and report changes. Since the tracker's state index starts at 1, our
+	// initial wait will return immediately.
+	var stateIndex uint64
+	var previous map[string]*State
+	for {
+		// Wait for a state change.
+		var poisoned bool
+		stateIndex, poisoned, err = m.tracker.WaitForChangeWithContext(context, stateIndex)
+		if err != nil {
+			return err
+		} else if poisoned {
+			return errors.New("state tracking terminated")
+		}
+
+		// Extract the controllers for the sessions of interest.
+		if identifiers != nil {
+			controllers = controllers[:0]
+			for _, controller := range m.allControllers() {
+				if identifiers[controller.session.Identifier] {
+					controllers = append(controllers, controller)
+				}
+			}
+		} else if controllers, err = m.selectControllers(selection); err != nil {
+			return errors.Wrap(err, "unable to locate requested sessions")
+		}
+
+		// Extract the state from each controller.
+		current := make(map[string]*State, len(controllers))
+		for _, controller := range controllers {
+			current[controller.session.Identifier] = controller.currentState()
+		}
+
+		// Compute and report events.
+		if events := diffStates(previous, current, stateIndex); len(events) > 0 {
+			if err := callback(events, current); err != nil {
+				return err
+			}
+		}
+		previous = current
+	}
+}
+
+// Archive loads the current archive for the session matching the given
+// specification, which must match exactly one session.
+func (m *Manager) Archive(specification string) (*core.Archive, error) {
+	// Extract the controller for the session of interest.
+	controllers, err := m.findControllersBySpecification([]string{specification})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to locate requested session")
+	} else if len(controllers) != 1 {
+		return nil, errors.Errorf("specification \"%s\" matched multiple sessions", specification)
+	}
+
+	// Load the archive.
+	return controllers[0].loadArchive()
+}
+
 // Flush tells the manager to flush sessions matching the given specifications.
 func (m *Manager) Flush(selection *selection.Selection, prompter string, skipWait bool, context contextpkg.Context) error {
 	// Extract the controllers for the sessions of interest.
@@ -314,6 +426,33 @@ func (m *Manager) Resume(selection *selection.Selection, prompter string) error
 	return nil
 }
 
+// Update tells the manager to replace the configuration, name, and labels of
+// the session matching the given specification, which must match exactly one
+// session.
+func (m *Manager) Update(
+	specification string,
+	configuration, configurationAlpha, configurationBeta *Configuration,
+	name string,
+	labels map[string]string,
+	prompter string,
+) error {
+	// Extract the controller for the session of interest.
+	controllers, err := m.findControllersBySpecification([]string{specification})
+	if err != nil {
+		return errors.Wrap(err, "unable to locate requested session")
+	} else if len(controllers) != 1 {
+		return errors.Errorf("specification \"%s\" matched multiple sessions", specification)
+	}
+
+	// Attempt to update.
+	if err := controllers[0].update(configuration, configurationAlpha, configurationBeta, name, labels, prompter); err != nil {
+		return errors.Wrap(err, "unable to update session")
+	}
+
+	// Success.
+	return nil
+}
+
 // Terminate tells the manager to terminate sessions matching the given
 // specifications.
 func (m *Manager) Terminate(selection *selection.Selection, prompter string) error {
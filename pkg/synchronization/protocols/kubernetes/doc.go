@@ -0,0 +1,3 @@
+// Package kubernetes provides the Kubernetes synchronization session protocol
+// implementation.
+package kubernetes
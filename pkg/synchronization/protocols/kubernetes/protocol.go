@@ -0,0 +1,55 @@
+package kubernetes
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/agent/transports/kubernetes"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/endpoint/remote"
+	urlpkg "github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// protocolHandler implements the session.ProtocolHandler interface for
+// connecting to remote endpoints inside Kubernetes pods. It uses the agent
+// infrastructure over a Kubernetes transport.
+type protocolHandler struct{}
+
+// Connect connects to a Kubernetes endpoint.
+func (h *protocolHandler) Connect(
+	logger *logging.Logger,
+	url *urlpkg.URL,
+	prompter string,
+	session string,
+	version synchronization.Version,
+	configuration *synchronization.Configuration,
+	alpha bool,
+) (synchronization.Endpoint, error) {
+	// Verify that the URL is of the correct kind and protocol.
+	if url.Kind != urlpkg.Kind_Synchronization {
+		panic("non-synchronization URL dispatched to synchronization protocol handler")
+	} else if url.Protocol != urlpkg.Protocol_Kubernetes {
+		panic("non-Kubernetes URL dispatched to Kubernetes protocol handler")
+	}
+
+	// Create a Kubernetes agent transport.
+	transport, err := kubernetes.NewTransport(url.Host, url.Environment)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create Kubernetes transport")
+	}
+
+	// Dial an agent in endpoint mode.
+	connection, err := agent.Dial(logger, transport, agent.ModeEndpoint, prompter)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to dial agent endpoint")
+	}
+
+	// Create the endpoint client.
+	return remote.NewEndpointClient(connection, url.Path, session, version, configuration, alpha)
+}
+
+func init() {
+	// Register the Kubernetes protocol handler with the synchronization package.
+	synchronization.ProtocolHandlers[urlpkg.Protocol_Kubernetes] = &protocolHandler{}
+}
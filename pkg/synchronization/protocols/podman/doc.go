@@ -0,0 +1,3 @@
+// Package podman provides the Podman synchronization session protocol
+// implementation.
+package podman
@@ -0,0 +1,3 @@
+package podman
+
+// TODO: Implement.
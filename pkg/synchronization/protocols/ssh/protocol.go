@@ -34,13 +34,23 @@ func (h *protocolHandler) Connect(
 	}
 
 	// Create an SSH agent transport.
-	transport, err := ssh.NewTransport(url.User, url.Host, uint16(url.Port), prompter)
+	transport, err := ssh.NewTransport(
+		url.User, url.Host, uint16(url.Port),
+		url.Parameters[urlpkg.SSHJumpParameter], url.Parameters[urlpkg.SSHProxyCommandParameter],
+		prompter,
+	)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create SSH transport")
 	}
 
-	// Dial an agent in endpoint mode.
-	connection, err := agent.Dial(logger, transport, agent.ModeEndpoint, prompter)
+	// Dial an agent in endpoint mode. Agent connections to the same remote are
+	// shared, so this will only create a new SSH connection if one doesn't
+	// already exist.
+	key := ssh.MultiplexingKey(
+		url.User, url.Host, uint16(url.Port),
+		url.Parameters[urlpkg.SSHJumpParameter], url.Parameters[urlpkg.SSHProxyCommandParameter],
+	)
+	connection, err := agent.DialMultiplexed(logger, key, transport, agent.ModeEndpoint, prompter)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to dial agent endpoint")
 	}
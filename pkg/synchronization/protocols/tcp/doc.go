@@ -0,0 +1,3 @@
+// Package tcp provides the direct TCP synchronization session protocol
+// implementation.
+package tcp
@@ -0,0 +1,49 @@
+package tcp
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/agent/direct"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/endpoint/remote"
+	urlpkg "github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// protocolHandler implements the session.ProtocolHandler interface for
+// connecting to remote endpoints served by long-lived agents listening for
+// direct TCP connections.
+type protocolHandler struct{}
+
+// Connect connects to a TCP endpoint.
+func (h *protocolHandler) Connect(
+	logger *logging.Logger,
+	url *urlpkg.URL,
+	prompter string,
+	session string,
+	version synchronization.Version,
+	configuration *synchronization.Configuration,
+	alpha bool,
+) (synchronization.Endpoint, error) {
+	// Verify that the URL is of the correct kind and protocol.
+	if url.Kind != urlpkg.Kind_Synchronization {
+		panic("non-synchronization URL dispatched to synchronization protocol handler")
+	} else if url.Protocol != urlpkg.Protocol_TCP {
+		panic("non-TCP URL dispatched to TCP protocol handler")
+	}
+
+	// Dial the agent in endpoint mode.
+	connection, err := direct.Dial(url.Host, uint16(url.Port), url.Environment, agent.ModeEndpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to dial agent endpoint")
+	}
+
+	// Create the endpoint client.
+	return remote.NewEndpointClient(connection, url.Path, session, version, configuration, alpha)
+}
+
+func init() {
+	// Register the TCP protocol handler with the synchronization package.
+	synchronization.ProtocolHandlers[urlpkg.Protocol_TCP] = &protocolHandler{}
+}
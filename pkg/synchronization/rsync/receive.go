@@ -404,6 +404,47 @@ func (r *preemptableReceiver) finalize() error {
 	return r.receiver.finalize()
 }
 
+// countingReceiver is a Receiver implementation that reports the amount of
+// file data received.
+type countingReceiver struct {
+	// receiver is the underlying receiver.
+	receiver Receiver
+	// counter is the counting callback.
+	counter func(uint64)
+}
+
+// NewCountingReceiver wraps a receiver and reports the number of bytes of
+// operation data that it successfully receives via a callback. Block
+// operations don't carry data and thus aren't counted.
+func NewCountingReceiver(receiver Receiver, counter func(uint64)) Receiver {
+	return &countingReceiver{
+		receiver: receiver,
+		counter:  counter,
+	}
+}
+
+// Receive forwards messages to its underlying receiver and reports the size of
+// any operation data.
+func (r *countingReceiver) Receive(transmission *Transmission) error {
+	// Forward the transmission to the underlying receiver.
+	if err := r.receiver.Receive(transmission); err != nil {
+		return err
+	}
+
+	// Report any operation data.
+	if transmission.Operation != nil && len(transmission.Operation.Data) > 0 {
+		r.counter(uint64(len(transmission.Operation.Data)))
+	}
+
+	// Success.
+	return nil
+}
+
+// finalize invokes finalize on the underlying receiver.
+func (r *countingReceiver) finalize() error {
+	return r.receiver.finalize()
+}
+
 // Encoder is the interface used by an encoding receiver to forward
 // transmissions, usually across a network.
 type Encoder interface {
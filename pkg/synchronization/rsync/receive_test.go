@@ -1,3 +1,66 @@
 package rsync
 
-// TODO: Implement.
+import (
+	"testing"
+)
+
+// recordingReceiver is a Receiver implementation that counts the
+// transmissions that it receives.
+type recordingReceiver struct {
+	// received is the number of transmissions received.
+	received int
+	// finalized indicates whether or not finalize has been called.
+	finalized bool
+}
+
+// Receive implements Receiver.Receive.
+func (r *recordingReceiver) Receive(_ *Transmission) error {
+	r.received++
+	return nil
+}
+
+// finalize implements Receiver.finalize.
+func (r *recordingReceiver) finalize() error {
+	r.finalized = true
+	return nil
+}
+
+// TestCountingReceiver tests that countingReceiver reports data sizes and
+// forwards transmissions.
+func TestCountingReceiver(t *testing.T) {
+	// Create a counting receiver.
+	underlying := &recordingReceiver{}
+	var count uint64
+	receiver := NewCountingReceiver(underlying, func(n uint64) {
+		count += n
+	})
+
+	// Send a sequence of transmissions.
+	transmissions := []*Transmission{
+		{Operation: &Operation{Data: []byte("hello")}},
+		{Operation: &Operation{Start: 1, Count: 2}},
+		{Operation: &Operation{Data: []byte("world!")}},
+		{Done: true},
+	}
+	for _, transmission := range transmissions {
+		if err := receiver.Receive(transmission); err != nil {
+			t.Fatal("unable to receive transmission:", err)
+		}
+	}
+
+	// Finalize the receiver.
+	if err := receiver.finalize(); err != nil {
+		t.Fatal("unable to finalize receiver:", err)
+	}
+
+	// Verify results.
+	if count != 11 {
+		t.Error("counted byte count incorrect:", count, "!=", 11)
+	}
+	if underlying.received != len(transmissions) {
+		t.Error("underlying receiver transmission count incorrect:", underlying.received)
+	}
+	if !underlying.finalized {
+		t.Error("underlying receiver not finalized")
+	}
+}
@@ -6,6 +6,7 @@ package synchronization
 import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	core "github.com/mutagen-io/mutagen/pkg/synchronization/core"
 	rsync "github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
 	math "math"
@@ -91,9 +92,22 @@ type State struct {
 	Conflicts                       []*core.Conflict      `protobuf:"bytes,8,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
 	AlphaProblems                   []*core.Problem       `protobuf:"bytes,9,rep,name=alphaProblems,proto3" json:"alphaProblems,omitempty"`
 	BetaProblems                    []*core.Problem       `protobuf:"bytes,10,rep,name=betaProblems,proto3" json:"betaProblems,omitempty"`
-	XXX_NoUnkeyedLiteral            struct{}              `json:"-"`
-	XXX_unrecognized                []byte                `json:"-"`
-	XXX_sizecache                   int32                 `json:"-"`
+	LastHookError                   string                `protobuf:"bytes,11,opt,name=lastHookError,proto3" json:"lastHookError,omitempty"`
+	// StagedBytes is the number of bytes of file data received while staging
+	// since the session last connected.
+	StagedBytes uint64 `protobuf:"varint,12,opt,name=stagedBytes,proto3" json:"stagedBytes,omitempty"`
+	// Reconnections is the number of times that the session has reconnected
+	// to its endpoints after a connection failure.
+	Reconnections uint64 `protobuf:"varint,13,opt,name=reconnections,proto3" json:"reconnections,omitempty"`
+	// LastSynchronizationCycleDuration is the duration of the most recent
+	// successful synchronization cycle.
+	LastSynchronizationCycleDuration *duration.Duration `protobuf:"bytes,14,opt,name=lastSynchronizationCycleDuration,proto3" json:"lastSynchronizationCycleDuration,omitempty"`
+	// TotalSynchronizationCycleDuration is the cumulative duration of all
+	// successful synchronization cycles since the session last connected.
+	TotalSynchronizationCycleDuration *duration.Duration `protobuf:"bytes,15,opt,name=totalSynchronizationCycleDuration,proto3" json:"totalSynchronizationCycleDuration,omitempty"`
+	XXX_NoUnkeyedLiteral              struct{}           `json:"-"`
+	XXX_unrecognized                  []byte             `json:"-"`
+	XXX_sizecache                     int32              `json:"-"`
 }
 
 func (m *State) Reset()         { *m = State{} }
@@ -191,6 +205,41 @@ func (m *State) GetBetaProblems() []*core.Problem {
 	return nil
 }
 
+func (m *State) GetLastHookError() string {
+	if m != nil {
+		return m.LastHookError
+	}
+	return ""
+}
+
+func (m *State) GetStagedBytes() uint64 {
+	if m != nil {
+		return m.StagedBytes
+	}
+	return 0
+}
+
+func (m *State) GetReconnections() uint64 {
+	if m != nil {
+		return m.Reconnections
+	}
+	return 0
+}
+
+func (m *State) GetLastSynchronizationCycleDuration() *duration.Duration {
+	if m != nil {
+		return m.LastSynchronizationCycleDuration
+	}
+	return nil
+}
+
+func (m *State) GetTotalSynchronizationCycleDuration() *duration.Duration {
+	if m != nil {
+		return m.TotalSynchronizationCycleDuration
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("synchronization.Status", Status_name, Status_value)
 	proto.RegisterType((*State)(nil), "synchronization.State")
@@ -199,38 +248,44 @@ func init() {
 func init() { proto.RegisterFile("synchronization/state.proto", fileDescriptor_8699c6f4e92f6557) }
 
 var fileDescriptor_8699c6f4e92f6557 = []byte{
-	// 522 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0x5f, 0x6f, 0xd3, 0x30,
-	0x14, 0xc5, 0xc9, 0xba, 0xa6, 0xed, 0xed, 0xbf, 0x70, 0x19, 0x10, 0x15, 0x10, 0xd1, 0x40, 0x28,
-	0x42, 0x90, 0x68, 0xed, 0x23, 0x4f, 0xac, 0x03, 0xed, 0x0d, 0x94, 0x4c, 0x9a, 0xc4, 0x9b, 0xeb,
-	0x79, 0xa9, 0x45, 0x6a, 0x57, 0xb6, 0x3b, 0xa9, 0x7c, 0x6f, 0x5e, 0x11, 0x72, 0xe2, 0xd2, 0xb5,
-	0x9d, 0xc4, 0x9b, 0x7d, 0xee, 0xef, 0xd8, 0xd7, 0xf7, 0x24, 0xf0, 0x42, 0xaf, 0x05, 0x9d, 0x2b,
-	0x29, 0xf8, 0x2f, 0x62, 0xb8, 0x14, 0xa9, 0x36, 0xc4, 0xb0, 0x64, 0xa9, 0xa4, 0x91, 0x38, 0xdc,
-	0x2b, 0x8e, 0xde, 0xec, 0xd3, 0xca, 0x0a, 0xa9, 0x62, 0x94, 0xf1, 0x3b, 0xe7, 0x1a, 0xbd, 0x3a,
-	0x38, 0x92, 0x69, 0xcd, 0xa5, 0x70, 0xe5, 0x83, 0x33, 0xa8, 0x54, 0x2c, 0xa5, 0x52, 0xdc, 0x96,
-	0x9c, 0x1a, 0x07, 0x9d, 0x3e, 0x08, 0x2d, 0x95, 0x9c, 0x95, 0x6c, 0x51, 0x33, 0xa7, 0xbf, 0x1b,
-	0xd0, 0xcc, 0x6d, 0xb7, 0x38, 0x86, 0x96, 0xbb, 0x23, 0xf4, 0x22, 0x2f, 0xee, 0x8e, 0xc3, 0x64,
-	0xcf, 0x9f, 0xe4, 0x75, 0x3d, 0xdb, 0x80, 0x98, 0x82, 0x6f, 0x9f, 0xba, 0xd2, 0xe1, 0x51, 0xe4,
-	0xc5, 0x83, 0xf1, 0xf3, 0x43, 0x4b, 0x55, 0xce, 0x1c, 0x86, 0xef, 0x60, 0x40, 0xca, 0xe5, 0x9c,
-	0x4c, 0xa5, 0x10, 0x8c, 0x1a, 0x76, 0x13, 0x36, 0x22, 0x2f, 0x6e, 0x67, 0x7b, 0x2a, 0xbe, 0x85,
-	0xfe, 0x8c, 0x99, 0x7b, 0xd8, 0x71, 0x85, 0xed, 0x8a, 0xf8, 0x12, 0x3a, 0x25, 0xd1, 0xe6, 0x8b,
-	0x52, 0x52, 0x85, 0xcd, 0xc8, 0x8b, 0x3b, 0xd9, 0x56, 0xc0, 0x4b, 0x78, 0xad, 0x57, 0x94, 0x32,
-	0xad, 0x6f, 0x57, 0x65, 0xbe, 0xdb, 0xd7, 0x74, 0x4d, 0x4b, 0xa6, 0x43, 0x3f, 0xf2, 0xe2, 0xe3,
-	0xec, 0x7f, 0x18, 0x7e, 0x82, 0xbe, 0x36, 0xa4, 0xe0, 0xa2, 0xa8, 0x9f, 0x13, 0xb6, 0xaa, 0x01,
-	0x3d, 0x4d, 0xaa, 0xe4, 0x92, 0xac, 0x4e, 0x4e, 0xb9, 0xb7, 0xee, 0xb2, 0xf8, 0x01, 0x3a, 0x9b,
-	0x5c, 0x74, 0xd8, 0x8e, 0x1a, 0x71, 0x77, 0x3c, 0x48, 0x6c, 0x12, 0xc9, 0xd4, 0xc9, 0xd9, 0x16,
-	0xc0, 0x09, 0xf4, 0xab, 0x51, 0x7c, 0xaf, 0x53, 0xd2, 0x61, 0xa7, 0x72, 0xf4, 0x6b, 0x87, 0x53,
-	0xb3, 0x5d, 0x06, 0xcf, 0xa0, 0x67, 0x07, 0xf3, 0xcf, 0x03, 0x0f, 0x79, 0x76, 0x90, 0xf7, 0x7f,
-	0x3c, 0xf0, 0x5d, 0x83, 0x01, 0xf4, 0x2e, 0xb8, 0xa6, 0x9b, 0xa9, 0x06, 0x8f, 0x30, 0x84, 0x93,
-	0x4b, 0x52, 0x1a, 0x76, 0xf3, 0x4d, 0x64, 0x52, 0x9a, 0x0b, 0x56, 0x32, 0x3b, 0x8d, 0xc0, 0xc3,
-	0x11, 0x3c, 0xbb, 0x5f, 0xb9, 0x5a, 0x2f, 0xd9, 0x74, 0x4e, 0x44, 0xc1, 0x82, 0x23, 0x7c, 0x02,
-	0x43, 0x17, 0x0d, 0x17, 0xc5, 0x67, 0xdb, 0x60, 0xd0, 0x40, 0x84, 0xc1, 0x56, 0x3c, 0x67, 0x86,
-	0x04, 0xc7, 0xd8, 0x83, 0xf6, 0x35, 0x31, 0x74, 0xce, 0x45, 0x11, 0x34, 0xed, 0x2e, 0xa7, 0x44,
-	0x08, 0xbb, 0xf3, 0xf1, 0x04, 0x82, 0x6b, 0xc2, 0x2d, 0xfc, 0x55, 0xaa, 0x8c, 0x69, 0x4a, 0x44,
-	0xd0, 0xc2, 0x21, 0x74, 0x33, 0x46, 0xa5, 0xa0, 0xbc, 0xb4, 0x58, 0xdb, 0xf6, 0x9c, 0xd7, 0x53,
-	0xae, 0x2f, 0xea, 0x58, 0xc4, 0x29, 0xd5, 0x2d, 0x80, 0x8f, 0xa1, 0x7f, 0xa5, 0x88, 0xd0, 0xdc,
-	0xb6, 0x6e, 0x5d, 0x5d, 0x04, 0xf0, 0x73, 0x72, 0x67, 0xd7, 0xbd, 0xf3, 0xc9, 0x8f, 0xb3, 0x82,
-	0x9b, 0xf9, 0x6a, 0x96, 0x50, 0xb9, 0x48, 0x17, 0x2b, 0x43, 0x0a, 0x26, 0x3e, 0x72, 0xb9, 0x59,
-	0xa6, 0xcb, 0x9f, 0x45, 0xba, 0xf7, 0x35, 0xcf, 0xfc, 0xea, 0xa7, 0x99, 0xfc, 0x0d, 0x00, 0x00,
-	0xff, 0xff, 0x18, 0x15, 0x33, 0x78, 0xf1, 0x03, 0x00, 0x00,
+	// 619 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xdd, 0x6f, 0xd3, 0x3e,
+	0x14, 0xfd, 0x65, 0x1f, 0x59, 0x7b, 0xfb, 0x95, 0x9f, 0x19, 0x60, 0xca, 0x57, 0x18, 0x08, 0x55,
+	0x08, 0x12, 0xad, 0x7b, 0xe4, 0x89, 0x75, 0xa0, 0xbd, 0x81, 0xd2, 0x49, 0x93, 0x78, 0x73, 0x5d,
+	0x2f, 0xb5, 0x96, 0xd9, 0x95, 0xed, 0x4c, 0x2a, 0xff, 0x16, 0xff, 0x1f, 0x42, 0xb6, 0x13, 0xb6,
+	0x74, 0xd3, 0xfa, 0x16, 0x9f, 0x7b, 0xce, 0xbd, 0xf6, 0x39, 0x76, 0xe0, 0xb9, 0x5e, 0x09, 0xba,
+	0x50, 0x52, 0xf0, 0x5f, 0xc4, 0x70, 0x29, 0x52, 0x6d, 0x88, 0x61, 0xc9, 0x52, 0x49, 0x23, 0xd1,
+	0x60, 0xad, 0x38, 0x7c, 0x95, 0x4b, 0x99, 0x17, 0x2c, 0x75, 0xe5, 0x59, 0x79, 0x91, 0xce, 0x4b,
+	0xe5, 0x2a, 0x5e, 0x30, 0x7c, 0xbb, 0xde, 0x4d, 0x59, 0x20, 0x55, 0x8c, 0x32, 0x7e, 0x5d, 0x75,
+	0x1d, 0xbe, 0xbc, 0x33, 0x92, 0x69, 0xfd, 0x40, 0x0f, 0x2a, 0x15, 0x4b, 0xa9, 0x14, 0x17, 0x05,
+	0xa7, 0xa6, 0x22, 0x1d, 0xdc, 0x4b, 0x5a, 0x2a, 0x39, 0x2b, 0xd8, 0x95, 0xe7, 0x1c, 0xfc, 0x0e,
+	0x61, 0x77, 0x6a, 0x4f, 0x83, 0xc6, 0xb0, 0x57, 0xcd, 0xc0, 0x41, 0x1c, 0x8c, 0x3a, 0x63, 0x9c,
+	0xac, 0xe9, 0x93, 0xa9, 0xaf, 0x67, 0x35, 0x11, 0xa5, 0x10, 0x5a, 0x2b, 0x4a, 0x8d, 0xb7, 0xe2,
+	0x60, 0xd4, 0x1f, 0x3f, 0xbd, 0x2b, 0x71, 0xe5, 0xac, 0xa2, 0xa1, 0xf7, 0xd0, 0x27, 0xc5, 0x72,
+	0x41, 0x26, 0x52, 0x08, 0x46, 0x0d, 0x9b, 0xe3, 0xed, 0x38, 0x18, 0xb5, 0xb2, 0x35, 0x14, 0xbd,
+	0x83, 0xde, 0x8c, 0x99, 0x5b, 0xb4, 0x1d, 0x47, 0x6b, 0x82, 0xe8, 0x05, 0xb4, 0x0b, 0xa2, 0xcd,
+	0x57, 0xa5, 0xa4, 0xc2, 0xbb, 0x71, 0x30, 0x6a, 0x67, 0x37, 0x00, 0x3a, 0x85, 0xd7, 0xba, 0xa4,
+	0x94, 0x69, 0x7d, 0x51, 0x16, 0xd3, 0xe6, 0xbe, 0x26, 0x2b, 0x5a, 0x30, 0x8d, 0xc3, 0x38, 0x18,
+	0xed, 0x64, 0x9b, 0x68, 0xe8, 0x33, 0xf4, 0xb4, 0x21, 0x39, 0x17, 0xb9, 0x3f, 0x0e, 0xde, 0x73,
+	0x06, 0x3d, 0x4e, 0x5c, 0x72, 0x49, 0xe6, 0x93, 0x53, 0xd5, 0x59, 0x9b, 0x5c, 0xf4, 0x11, 0xda,
+	0x75, 0x2e, 0x1a, 0xb7, 0xe2, 0xed, 0x51, 0x67, 0xdc, 0x4f, 0x6c, 0x12, 0xc9, 0xa4, 0x82, 0xb3,
+	0x1b, 0x02, 0x3a, 0x82, 0x9e, 0xb3, 0xe2, 0x87, 0x4f, 0x49, 0xe3, 0xb6, 0x53, 0xf4, 0xbc, 0xa2,
+	0x42, 0xb3, 0x26, 0x07, 0x1d, 0x42, 0xd7, 0x1a, 0xf3, 0x4f, 0x03, 0xf7, 0x69, 0x1a, 0x14, 0x6b,
+	0xb0, 0x75, 0xea, 0x54, 0xca, 0x4b, 0x6f, 0x5f, 0xc7, 0xd9, 0xd7, 0x04, 0x51, 0x0c, 0x1d, 0x7b,
+	0x18, 0x36, 0x3f, 0x5e, 0x19, 0xa6, 0x71, 0xd7, 0xd9, 0x75, 0x1b, 0xb2, 0x7d, 0x14, 0xa3, 0x3e,
+	0x11, 0x2e, 0x85, 0xc6, 0x3d, 0xc7, 0x69, 0x82, 0x88, 0x41, 0x6c, 0x1b, 0xdf, 0xe7, 0xee, 0x49,
+	0xf5, 0x38, 0x70, 0xdf, 0x79, 0xfa, 0x2c, 0xf1, 0xaf, 0x27, 0xa9, 0x5f, 0x4f, 0x52, 0x13, 0xb2,
+	0x8d, 0x2d, 0x50, 0x0e, 0x6f, 0x8c, 0x34, 0xa4, 0x78, 0x70, 0xce, 0x60, 0xd3, 0x9c, 0xcd, 0x3d,
+	0x3e, 0xfc, 0x09, 0x20, 0xac, 0xe2, 0x8d, 0xa0, 0x7b, 0xc2, 0x35, 0xad, 0xef, 0x64, 0xf4, 0x1f,
+	0xc2, 0xb0, 0x7f, 0x4a, 0x0a, 0xc3, 0xe6, 0xdf, 0x45, 0x26, 0xa5, 0x39, 0x61, 0x05, 0xb3, 0xa2,
+	0x28, 0x40, 0x43, 0x78, 0x72, 0xbb, 0x72, 0xb6, 0x5a, 0xb2, 0xc9, 0x82, 0x88, 0x9c, 0x45, 0x5b,
+	0xe8, 0x11, 0x0c, 0xaa, 0x8b, 0xcd, 0x45, 0xfe, 0xc5, 0xc6, 0x1b, 0x6d, 0x23, 0x04, 0xfd, 0x1b,
+	0xf0, 0x98, 0x19, 0x12, 0xed, 0xa0, 0x2e, 0xb4, 0xce, 0x89, 0xa1, 0x0b, 0x2e, 0xf2, 0x68, 0xd7,
+	0xae, 0xa6, 0x94, 0x08, 0x61, 0x57, 0x21, 0xda, 0x87, 0xe8, 0x9c, 0x70, 0x4b, 0xfe, 0x26, 0x55,
+	0xc6, 0x34, 0x25, 0x22, 0xda, 0x43, 0x03, 0xe8, 0x64, 0x36, 0x0e, 0xca, 0x0b, 0x4b, 0x6b, 0xd9,
+	0x3d, 0x4f, 0xfd, 0x1d, 0xf5, 0x83, 0xda, 0x96, 0x52, 0x21, 0x6e, 0x0a, 0xa0, 0xff, 0xa1, 0x77,
+	0xa6, 0x88, 0xd0, 0xdc, 0x6e, 0xdd, 0xaa, 0x3a, 0x08, 0x20, 0x9c, 0x92, 0x6b, 0xfb, 0xdd, 0x3d,
+	0x3e, 0xfa, 0x79, 0x98, 0x73, 0xb3, 0x28, 0x67, 0x09, 0x95, 0x57, 0xe9, 0x55, 0x69, 0x6f, 0x84,
+	0xf8, 0xc4, 0x65, 0xfd, 0x99, 0x2e, 0x2f, 0xf3, 0x74, 0xed, 0x5f, 0x30, 0x0b, 0x9d, 0xd7, 0x47,
+	0x7f, 0x07, 0x00, 0xc0, 0xf7, 0x1c, 0x80, 0x4f, 0x05, 0x00, 0x00,
 }
@@ -10,26 +10,45 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/process"
 )
 
-// CommandPath returns the absolute path specification to use for invoking
-// Docker. It will use the MUTAGEN_DOCKER_PATH environment variable if provided,
-// otherwise falling back to a platform-specific implementation.
-func CommandPath() (string, error) {
+// defaultCommandName is the default name of the command used to invoke Docker.
+const defaultCommandName = "docker"
+
+// CommandName returns the name of the command to use for invoking Docker. If
+// name is non-empty (e.g. "nerdctl"), then it's used in place of Docker, which
+// allows Docker-compatible command line interfaces to be used. Otherwise it
+// defaults to "docker".
+func CommandName(name string) string {
+	if name != "" {
+		return name
+	}
+	return defaultCommandName
+}
+
+// CommandPath returns the absolute path specification to use for invoking the
+// named Docker command (see CommandName for name semantics). It will use the
+// MUTAGEN_DOCKER_PATH environment variable if provided, otherwise falling back
+// to a platform-specific implementation.
+func CommandPath(name string) (string, error) {
+	// Determine the command name.
+	name = CommandName(name)
+
 	// If MUTAGEN_DOCKER_PATH is specified, then use it to perform the lookup.
 	if searchPath := os.Getenv("MUTAGEN_DOCKER_PATH"); searchPath != "" {
-		return process.FindCommand("docker", []string{searchPath})
+		return process.FindCommand(name, []string{searchPath})
 	}
 
 	// Otherwise fall back to the platform-specific implementation.
-	return commandPathForPlatform()
+	return commandPathForPlatform(name)
 }
 
-// Command prepares (but does not start) a Docker command with the specified
-// arguments and scoped to lifetime of the provided context.
-func Command(context context.Context, args ...string) (*exec.Cmd, error) {
+// Command prepares (but does not start) a command using the named Docker
+// command (see CommandName for name semantics) with the specified arguments and
+// scoped to lifetime of the provided context.
+func Command(context context.Context, name string, args ...string) (*exec.Cmd, error) {
 	// Identify the command path.
-	commandPath, err := CommandPath()
+	commandPath, err := CommandPath(name)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to identify 'docker' command")
+		return nil, errors.Wrapf(err, "unable to identify '%s' command", CommandName(name))
 	}
 
 	// Create the command.
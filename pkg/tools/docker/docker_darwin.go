@@ -12,12 +12,12 @@ var commandSearchPaths = []string{
 	"/usr/local/bin",
 }
 
-// commandPathForPlatform will search for a suitable docker command
-// implementation on macOS.
-func commandPathForPlatform() (string, error) {
-	// First, attempt to find the docker executable using the PATH environment
+// commandPathForPlatform will search for a suitable implementation of the named
+// Docker command on macOS.
+func commandPathForPlatform(name string) (string, error) {
+	// First, attempt to find the executable using the PATH environment
 	// variable. If that works, use that result.
-	if path, err := exec.LookPath("docker"); err == nil {
+	if path, err := exec.LookPath(name); err == nil {
 		return path, nil
 	}
 
@@ -26,5 +26,5 @@ func commandPathForPlatform() (string, error) {
 	// unfortunately necessary due to launchd stripping almost everything out of
 	// the PATH environment variable, including /usr/local/bin, the default
 	// installation path for Docker for Mac.
-	return process.FindCommand("docker", commandSearchPaths)
+	return process.FindCommand(name, commandSearchPaths)
 }
@@ -6,7 +6,8 @@ import (
 	"os/exec"
 )
 
-// commandPathForPlatform searches for the docker command in the user's path.
-func commandPathForPlatform() (string, error) {
-	return exec.LookPath("docker")
+// commandPathForPlatform searches for the named Docker command in the user's
+// path.
+func commandPathForPlatform(name string) (string, error) {
+	return exec.LookPath(name)
 }
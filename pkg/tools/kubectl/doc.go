@@ -0,0 +1,2 @@
+// Package kubectl provides utility functions for interfacing with kubectl.
+package kubectl
@@ -0,0 +1,37 @@
+package kubectl
+
+import (
+	"context"
+	"os"
+	"os/exec"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/process"
+)
+
+// CommandPath returns the absolute path specification to use for invoking
+// kubectl. It will use the MUTAGEN_KUBECTL_PATH environment variable if
+// provided, otherwise falling back to a platform-specific implementation.
+func CommandPath() (string, error) {
+	// If MUTAGEN_KUBECTL_PATH is specified, then use it to perform the lookup.
+	if searchPath := os.Getenv("MUTAGEN_KUBECTL_PATH"); searchPath != "" {
+		return process.FindCommand("kubectl", []string{searchPath})
+	}
+
+	// Otherwise fall back to the platform-specific implementation.
+	return commandPathForPlatform()
+}
+
+// Command prepares (but does not start) a kubectl command with the specified
+// arguments and scoped to lifetime of the provided context.
+func Command(context context.Context, args ...string) (*exec.Cmd, error) {
+	// Identify the command path.
+	commandPath, err := CommandPath()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to identify 'kubectl' command")
+	}
+
+	// Create the command.
+	return exec.CommandContext(context, commandPath, args...), nil
+}
@@ -0,0 +1,29 @@
+package kubectl
+
+import (
+	"os/exec"
+
+	"github.com/mutagen-io/mutagen/pkg/process"
+)
+
+// commandSearchPaths specifies locations on macOS where we might find the
+// kubectl binary.
+var commandSearchPaths = []string{
+	"/usr/local/bin",
+}
+
+// commandPathForPlatform will search for a suitable kubectl command
+// implementation on macOS.
+func commandPathForPlatform() (string, error) {
+	// First, attempt to find the kubectl executable using the PATH environment
+	// variable. If that works, use that result.
+	if path, err := exec.LookPath("kubectl"); err == nil {
+		return path, nil
+	}
+
+	// If the PATH-based lookup fails, attempt to search a set of common
+	// locations where kubectl installations reside on macOS (e.g. the
+	// Homebrew and Docker for Mac installation paths). This is necessary due
+	// to launchd stripping /usr/local/bin out of the PATH environment variable.
+	return process.FindCommand("kubectl", commandSearchPaths)
+}
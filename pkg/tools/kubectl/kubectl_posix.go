@@ -0,0 +1,12 @@
+// +build !windows,!darwin
+
+package kubectl
+
+import (
+	"os/exec"
+)
+
+// commandPathForPlatform searches for the kubectl command in the user's path.
+func commandPathForPlatform() (string, error) {
+	return exec.LookPath("kubectl")
+}
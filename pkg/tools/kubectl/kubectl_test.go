@@ -0,0 +1,3 @@
+package kubectl
+
+// TODO: Implement.
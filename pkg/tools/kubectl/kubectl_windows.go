@@ -0,0 +1,10 @@
+package kubectl
+
+import (
+	"os/exec"
+)
+
+// commandPathForPlatform searches for the kubectl command in the user's path.
+func commandPathForPlatform() (string, error) {
+	return exec.LookPath("kubectl")
+}
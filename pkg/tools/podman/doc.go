@@ -0,0 +1,2 @@
+// Package podman provides utility functions for interfacing with Podman.
+package podman
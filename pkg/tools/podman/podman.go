@@ -0,0 +1,37 @@
+package podman
+
+import (
+	"context"
+	"os"
+	"os/exec"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/process"
+)
+
+// CommandPath returns the absolute path specification to use for invoking
+// Podman. It will use the MUTAGEN_PODMAN_PATH environment variable if
+// provided, otherwise falling back to a platform-specific implementation.
+func CommandPath() (string, error) {
+	// If MUTAGEN_PODMAN_PATH is specified, then use it to perform the lookup.
+	if searchPath := os.Getenv("MUTAGEN_PODMAN_PATH"); searchPath != "" {
+		return process.FindCommand("podman", []string{searchPath})
+	}
+
+	// Otherwise fall back to the platform-specific implementation.
+	return commandPathForPlatform()
+}
+
+// Command prepares (but does not start) a Podman command with the specified
+// arguments and scoped to lifetime of the provided context.
+func Command(context context.Context, args ...string) (*exec.Cmd, error) {
+	// Identify the command path.
+	commandPath, err := CommandPath()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to identify 'podman' command")
+	}
+
+	// Create the command.
+	return exec.CommandContext(context, commandPath, args...), nil
+}
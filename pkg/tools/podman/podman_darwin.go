@@ -0,0 +1,31 @@
+package podman
+
+import (
+	"os/exec"
+
+	"github.com/mutagen-io/mutagen/pkg/process"
+)
+
+// commandSearchPaths specifies locations on macOS where we might find the
+// podman binary.
+var commandSearchPaths = []string{
+	"/opt/podman/bin",
+	"/opt/homebrew/bin",
+	"/usr/local/bin",
+}
+
+// commandPathForPlatform will search for a suitable podman command
+// implementation on macOS.
+func commandPathForPlatform() (string, error) {
+	// First, attempt to find the podman executable using the PATH environment
+	// variable. If that works, use that result.
+	if path, err := exec.LookPath("podman"); err == nil {
+		return path, nil
+	}
+
+	// If the PATH-based lookup fails, attempt to search a set of common
+	// locations where Podman installations reside on macOS (e.g. the official
+	// installer and Homebrew installation paths). This is necessary due to
+	// launchd stripping almost everything out of the PATH environment variable.
+	return process.FindCommand("podman", commandSearchPaths)
+}
@@ -0,0 +1,10 @@
+package podman
+
+import (
+	"os/exec"
+)
+
+// commandPathForPlatform searches for the podman command in the user's path.
+func commandPathForPlatform() (string, error) {
+	return exec.LookPath("podman")
+}
@@ -2,6 +2,8 @@ package url
 
 import (
 	"os"
+
+	"github.com/golang/protobuf/proto"
 )
 
 const (
@@ -56,3 +58,49 @@ func getEnvironmentVariable(name string, kind Kind, first bool) (string, bool) {
 	// Check for the general variant.
 	return lookupEnv(name)
 }
+
+// environmentVariablesForProtocol returns the environment variables that are
+// locked in to URLs with the specified protocol at parse time.
+func environmentVariablesForProtocol(protocol Protocol) []string {
+	switch protocol {
+	case Protocol_Docker:
+		return DockerEnvironmentVariables
+	case Protocol_Podman:
+		return PodmanEnvironmentVariables
+	case Protocol_Kubernetes:
+		return KubernetesEnvironmentVariables
+	case Protocol_TCP:
+		return TCPEnvironmentVariables
+	default:
+		return nil
+	}
+}
+
+// WithoutEnvironment returns a copy of the URL with its locked-in environment
+// variables removed. It's used when URLs leave the local system (e.g. when
+// exporting sessions), since environment variable values are specific to the
+// local system and may reference credentials.
+func (u *URL) WithoutEnvironment() *URL {
+	result := proto.Clone(u).(*URL)
+	result.Environment = nil
+	return result
+}
+
+// WithLocalEnvironment returns a copy of the URL with its locked-in environment
+// variables replaced by values from the current environment, as would be done
+// at parse time. It's used when URLs arrive from another system (e.g. when
+// importing sessions). The first parameter indicates whether the URL is for the
+// first endpoint of a session (alpha or source) and controls which endpoint-
+// specific variables are consulted.
+func (u *URL) WithLocalEnvironment(first bool) *URL {
+	result := proto.Clone(u).(*URL)
+	result.Environment = nil
+	if variables := environmentVariablesForProtocol(u.Protocol); len(variables) > 0 {
+		result.Environment = make(map[string]string, len(variables))
+		for _, variable := range variables {
+			value, _ := getEnvironmentVariable(variable, u.Kind, first)
+			result.Environment[variable] = value
+		}
+	}
+	return result
+}
@@ -35,6 +35,12 @@ const (
 	// defaultDockerTLSVerify is the non-endpoint-specific value for the
 	// DOCKER_TLS_VERIFY environment variable.
 	defaultDockerTLSVerify = "sure!"
+	// betaSpecificKubernetesConfigEnvironmentVariable is the name of the
+	// beta-specific KUBECONFIG environment variable.
+	betaSpecificKubernetesConfigEnvironmentVariable = "MUTAGEN_BETA_KUBECONFIG"
+	// betaSpecificKubernetesConfig is the beta-specific value for the
+	// KUBECONFIG environment variable.
+	betaSpecificKubernetesConfig = "/beta/kubeconfig"
 )
 
 // mockEnvironment is a mock environment setup for use in testing.
@@ -45,6 +51,7 @@ var mockEnvironment = map[string]string{
 	betaSpecificDockerTLSVerifyEnvironmentVariable:        betaSpecificDockerTLSVerify,
 	sourceSpecificDockerHostEnvironmentVariable:           sourceSpecificDockerHost,
 	destinationSpecificDockerTLSVerifyEnvironmentVariable: destinationSpecificDockerTLSVerify,
+	betaSpecificKubernetesConfigEnvironmentVariable:       betaSpecificKubernetesConfig,
 }
 
 // mockLookupEnv is a mock implementation of the os.LookupEnv function.
@@ -145,3 +152,49 @@ func TestDestinationLookupNeitherExists(t *testing.T) {
 		t.Fatal("able to find unset environment variable")
 	}
 }
+
+func TestURLWithoutEnvironment(t *testing.T) {
+	original := &URL{
+		Protocol:    Protocol_Docker,
+		Host:        "container",
+		Path:        "/data",
+		Environment: map[string]string{DockerHostEnvironmentVariable: "ssh://user@host"},
+	}
+	if stripped := original.WithoutEnvironment(); len(stripped.Environment) != 0 {
+		t.Error("environment variables not removed")
+	} else if stripped.Host != original.Host || stripped.Path != original.Path {
+		t.Error("non-environment components not preserved")
+	} else if len(original.Environment) != 1 {
+		t.Error("original URL modified")
+	}
+}
+
+func TestURLWithLocalEnvironment(t *testing.T) {
+	// Verify that container URLs receive the local values for all of their
+	// environment variables, replacing any existing values.
+	original := &URL{
+		Protocol:    Protocol_Docker,
+		Host:        "container",
+		Path:        "/data",
+		Environment: map[string]string{DockerHostEnvironmentVariable: "tcp://attacker:2375"},
+	}
+	populated := original.WithLocalEnvironment(true)
+	if len(populated.Environment) != len(DockerEnvironmentVariables) {
+		t.Error("unexpected number of environment variables:", len(populated.Environment))
+	}
+	if value := populated.Environment[DockerHostEnvironmentVariable]; value != alphaSpecificDockerHost {
+		t.Error("unexpected Docker host:", value)
+	}
+	if value := populated.Environment[DockerTLSVerifyEnvironmentVariable]; value != defaultDockerTLSVerify {
+		t.Error("unexpected Docker TLS verification setting:", value)
+	}
+	if original.Environment[DockerHostEnvironmentVariable] != "tcp://attacker:2375" {
+		t.Error("original URL modified")
+	}
+
+	// Verify that URLs for protocols without environment variables don't
+	// receive any.
+	if populated := (&URL{Protocol: Protocol_SSH, Host: "host", Path: "/data"}).WithLocalEnvironment(false); len(populated.Environment) != 0 {
+		t.Error("environment variables set for SSH URL")
+	}
+}
@@ -2,6 +2,10 @@ package url
 
 import (
 	"fmt"
+	"net"
+	neturl "net/url"
+	"strconv"
+	"strings"
 )
 
 // Format formats a URL into a human-readable (and reparsable) format.
@@ -12,6 +16,12 @@ func (u *URL) Format(environmentPrefix string) string {
 		return u.formatSSH()
 	} else if u.Protocol == Protocol_Docker {
 		return u.formatDocker(environmentPrefix)
+	} else if u.Protocol == Protocol_Podman {
+		return u.formatPodman(environmentPrefix)
+	} else if u.Protocol == Protocol_Kubernetes {
+		return u.formatKubernetes(environmentPrefix)
+	} else if u.Protocol == Protocol_TCP {
+		return u.formatTCP(environmentPrefix)
 	}
 	panic("unknown URL protocol")
 }
@@ -21,8 +31,19 @@ func (u *URL) formatLocal() string {
 	return u.Path
 }
 
-// formatSSH formats an SSH URL into an SCP-style URL.
+// invalidSSHURLFormat is the value returned by formatSSH when a URL is provided
+// that breaks invariants.
+const invalidSSHURLFormat = "<invalid-ssh-url>"
+
+// formatSSH formats an SSH URL. URLs without parameters are formatted as SCP-
+// style URLs, while URLs with parameters are formatted as full SSH URLs since
+// SCP-style URLs can't represent parameters.
 func (u *URL) formatSSH() string {
+	// If parameters are present, then use the full URL format.
+	if len(u.Parameters) != 0 {
+		return u.formatSSHWithParameters()
+	}
+
 	// Create the base result.
 	result := u.Host
 
@@ -43,12 +64,73 @@ func (u *URL) formatSSH() string {
 	return result
 }
 
+// formatSSHWithParameters formats an SSH URL into a full SSH URL.
+func (u *URL) formatSSHWithParameters() string {
+	// Start with the host, which needs bracketing if it's an IPv6 address.
+	result := u.Host
+	if strings.IndexByte(result, ':') >= 0 {
+		result = fmt.Sprintf("[%s]", result)
+	}
+
+	// Add username if present.
+	if u.User != "" {
+		result = fmt.Sprintf("%s@%s", u.User, result)
+	}
+
+	// Add port if present.
+	if u.Port != 0 {
+		result = fmt.Sprintf("%s:%d", result, u.Port)
+	}
+
+	// Append the path in a manner that depends on the URL kind. Paths that
+	// don't start with a slash (i.e. home-directory-relative paths or Windows
+	// paths) need a slash prepended.
+	if u.Kind == Kind_Synchronization {
+		if u.Path == "" {
+			return invalidSSHURLFormat
+		} else if u.Path[0] == '/' {
+			result += u.Path
+		} else {
+			result += fmt.Sprintf("/%s", u.Path)
+		}
+	} else if u.Kind == Kind_Forwarding {
+		result += fmt.Sprintf(":%s", u.Path)
+	} else {
+		panic("unhandled URL kind")
+	}
+
+	// Add parameters.
+	parameters := make(neturl.Values, len(u.Parameters))
+	for key, value := range u.Parameters {
+		parameters.Set(key, value)
+	}
+	result += "?" + parameters.Encode()
+
+	// Add the scheme.
+	return sshURLPrefix + result
+}
+
 // invalidDockerURLFormat is the value returned by formatDocker when a URL is
 // provided that breaks invariants.
 const invalidDockerURLFormat = "<invalid-docker-url>"
 
 // formatDocker formats a Docker URL.
 func (u *URL) formatDocker(environmentPrefix string) string {
+	return u.formatContainer(dockerURLPrefix, DockerEnvironmentVariables, invalidDockerURLFormat, environmentPrefix)
+}
+
+// invalidPodmanURLFormat is the value returned by formatPodman when a URL is
+// provided that breaks invariants.
+const invalidPodmanURLFormat = "<invalid-podman-url>"
+
+// formatPodman formats a Podman URL.
+func (u *URL) formatPodman(environmentPrefix string) string {
+	return u.formatContainer(podmanURLPrefix, PodmanEnvironmentVariables, invalidPodmanURLFormat, environmentPrefix)
+}
+
+// formatContainer formats a URL for a Docker-compatible container protocol
+// using the specified prefix, environment variables, and invalid URL format.
+func (u *URL) formatContainer(prefix string, environmentVariables []string, invalid, environmentPrefix string) string {
 	// Start with the container name.
 	result := u.Host
 
@@ -57,13 +139,13 @@ func (u *URL) formatDocker(environmentPrefix string) string {
 		// If this is a home-directory-relative path or a Windows path, then we
 		// need to prepend a slash.
 		if u.Path == "" {
-			return invalidDockerURLFormat
+			return invalid
 		} else if u.Path[0] == '/' {
 			result += u.Path
 		} else if u.Path[0] == '~' || isWindowsPath(u.Path) {
 			result += fmt.Sprintf("/%s", u.Path)
 		} else {
-			return invalidDockerURLFormat
+			return invalid
 		}
 	} else if u.Kind == Kind_Forwarding {
 		result += fmt.Sprintf(":%s", u.Path)
@@ -77,11 +159,47 @@ func (u *URL) formatDocker(environmentPrefix string) string {
 	}
 
 	// Add the scheme.
-	result = dockerURLPrefix + result
+	result = prefix + result
+
+	// Add environment variable information if requested.
+	if environmentPrefix != "" {
+		for _, variable := range environmentVariables {
+			result += fmt.Sprintf("%s%s=%s", environmentPrefix, variable, u.Environment[variable])
+		}
+	}
+
+	// Done.
+	return result
+}
+
+// invalidKubernetesURLFormat is the value returned by formatKubernetes when a
+// URL is provided that breaks invariants.
+const invalidKubernetesURLFormat = "<invalid-kubernetes-url>"
+
+// formatKubernetes formats a Kubernetes URL.
+func (u *URL) formatKubernetes(environmentPrefix string) string {
+	// Ensure that the path is non-empty.
+	if u.Path == "" {
+		return invalidKubernetesURLFormat
+	}
+
+	// Create the base result. Absolute synchronization paths following a
+	// multi-component pod specification are separated by an empty component
+	// (see parseKubernetes) and, like single-pod absolute paths, already carry
+	// their leading slash. All other paths need a separating slash.
+	result := kubernetesURLPrefix + u.Host
+	if u.Kind == Kind_Synchronization && u.Path[0] == '/' {
+		if strings.IndexByte(u.Host, '/') >= 0 {
+			result += "/"
+		}
+		result += u.Path
+	} else {
+		result += "/" + u.Path
+	}
 
 	// Add environment variable information if requested.
 	if environmentPrefix != "" {
-		for _, variable := range DockerEnvironmentVariables {
+		for _, variable := range KubernetesEnvironmentVariables {
 			result += fmt.Sprintf("%s%s=%s", environmentPrefix, variable, u.Environment[variable])
 		}
 	}
@@ -89,3 +207,44 @@ func (u *URL) formatDocker(environmentPrefix string) string {
 	// Done.
 	return result
 }
+
+// invalidTCPURLFormat is the value returned by formatTCP when a URL is provided
+// that breaks invariants.
+const invalidTCPURLFormat = "<invalid-tcp-url>"
+
+// formatTCP formats a TCP URL.
+func (u *URL) formatTCP(environmentPrefix string) string {
+	// Start with the host and port, which handles IPv6 bracketing.
+	result := tcpURLPrefix + net.JoinHostPort(u.Host, strconv.Itoa(int(u.Port)))
+
+	// Append the path in a manner that depends on the URL kind.
+	if u.Kind == Kind_Synchronization {
+		// If this is a home-directory-relative path or a Windows path, then we
+		// need to prepend a slash.
+		if u.Path == "" {
+			return invalidTCPURLFormat
+		} else if u.Path[0] == '/' {
+			result += u.Path
+		} else if u.Path[0] == '~' || isWindowsPath(u.Path) {
+			result += fmt.Sprintf("/%s", u.Path)
+		} else {
+			return invalidTCPURLFormat
+		}
+	} else if u.Kind == Kind_Forwarding {
+		result += fmt.Sprintf(":%s", u.Path)
+	} else {
+		panic("unhandled URL kind")
+	}
+
+	// Add environment variable information if requested. None of these values
+	// are sensitive since the authentication token is referenced by path.
+	if environmentPrefix != "" {
+		for _, variable := range TCPEnvironmentVariables {
+			value := u.Environment[variable]
+			result += fmt.Sprintf("%s%s=%s", environmentPrefix, variable, value)
+		}
+	}
+
+	// Done.
+	return result
+}
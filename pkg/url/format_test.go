@@ -120,6 +120,54 @@ func TestFormatSSHUsernameHostnamePortPath(t *testing.T) {
 	test.run(t)
 }
 
+func TestFormatSSHWithJump(t *testing.T) {
+	test := &formatTestCase{
+		url: &URL{
+			Protocol: Protocol_SSH,
+			User:     "user",
+			Host:     "host",
+			Port:     2222,
+			Path:     "~/path",
+			Parameters: map[string]string{
+				SSHJumpParameter: "admin@bastion",
+			},
+		},
+		expected: "ssh://user@host:2222/~/path?jump=admin%40bastion",
+	}
+	test.run(t)
+}
+
+func TestFormatSSHIPv6WithProxy(t *testing.T) {
+	test := &formatTestCase{
+		url: &URL{
+			Protocol: Protocol_SSH,
+			Host:     "fe80::1",
+			Path:     "/test/path",
+			Parameters: map[string]string{
+				SSHProxyCommandParameter: "nc %h %p",
+			},
+		},
+		expected: "ssh://[fe80::1]/test/path?proxy=nc+%25h+%25p",
+	}
+	test.run(t)
+}
+
+func TestFormatForwardingSSHWithJump(t *testing.T) {
+	test := &formatTestCase{
+		url: &URL{
+			Kind:     Kind_Forwarding,
+			Protocol: Protocol_SSH,
+			Host:     "host",
+			Path:     "tcp:localhost:8080",
+			Parameters: map[string]string{
+				SSHJumpParameter: "bastion",
+			},
+		},
+		expected: "ssh://host:tcp:localhost:8080?jump=bastion",
+	}
+	test.run(t)
+}
+
 func TestFormatDockerInvalidEmptyPath(t *testing.T) {
 	test := &formatTestCase{
 		url: &URL{
@@ -155,7 +203,7 @@ func TestFormatDocker(t *testing.T) {
 			},
 		},
 		environmentPrefix: "|",
-		expected:          "docker://container/test/path/to/the file|DOCKER_HOST=unix:///path/to/docker.sock|DOCKER_TLS_VERIFY=|DOCKER_CERT_PATH=",
+		expected:          "docker://container/test/path/to/the file|DOCKER_HOST=unix:///path/to/docker.sock|DOCKER_TLS_VERIFY=|DOCKER_CERT_PATH=|MUTAGEN_DOCKER_COMMAND=",
 	}
 	test.run(t)
 }
@@ -172,7 +220,7 @@ func TestFormatForwardingDocker(t *testing.T) {
 			},
 		},
 		environmentPrefix: "|",
-		expected:          "docker://container:tcp4:localhost:8080|DOCKER_HOST=unix:///path/to/docker.sock|DOCKER_TLS_VERIFY=|DOCKER_CERT_PATH=",
+		expected:          "docker://container:tcp4:localhost:8080|DOCKER_HOST=unix:///path/to/docker.sock|DOCKER_TLS_VERIFY=|DOCKER_CERT_PATH=|MUTAGEN_DOCKER_COMMAND=",
 	}
 	test.run(t)
 }
@@ -190,7 +238,7 @@ func TestFormatDockerWithUsernameAndHomeRelativePath(t *testing.T) {
 			},
 		},
 		environmentPrefix: "|",
-		expected:          "docker://user@container/~/test/path/to/the file|DOCKER_HOST=unix:///path/to/docker.sock|DOCKER_TLS_VERIFY=true|DOCKER_CERT_PATH=",
+		expected:          "docker://user@container/~/test/path/to/the file|DOCKER_HOST=unix:///path/to/docker.sock|DOCKER_TLS_VERIFY=true|DOCKER_CERT_PATH=|MUTAGEN_DOCKER_COMMAND=",
 	}
 	test.run(t)
 }
@@ -208,7 +256,7 @@ func TestFormatDockerWithUsernameAndUserRelativePath(t *testing.T) {
 			},
 		},
 		environmentPrefix: "|",
-		expected:          "docker://user@container/~otheruser/test/path/to/the file|DOCKER_HOST=unix:///path/to/docker.sock|DOCKER_TLS_VERIFY=true|DOCKER_CERT_PATH=",
+		expected:          "docker://user@container/~otheruser/test/path/to/the file|DOCKER_HOST=unix:///path/to/docker.sock|DOCKER_TLS_VERIFY=true|DOCKER_CERT_PATH=|MUTAGEN_DOCKER_COMMAND=",
 	}
 	test.run(t)
 }
@@ -225,7 +273,169 @@ func TestFormatDockerWithWindowsPathPath(t *testing.T) {
 			},
 		},
 		environmentPrefix: "|",
-		expected:          `docker://container/C:\A\Windows\File Path |DOCKER_HOST=unix:///path/to/docker.sock|DOCKER_TLS_VERIFY=true|DOCKER_CERT_PATH=`,
+		expected:          `docker://container/C:\A\Windows\File Path |DOCKER_HOST=unix:///path/to/docker.sock|DOCKER_TLS_VERIFY=true|DOCKER_CERT_PATH=|MUTAGEN_DOCKER_COMMAND=`,
+	}
+	test.run(t)
+}
+
+func TestFormatKubernetesInvalidEmptyPath(t *testing.T) {
+	test := &formatTestCase{
+		url: &URL{
+			Protocol: Protocol_Kubernetes,
+			Host:     "pod",
+		},
+		expected: invalidKubernetesURLFormat,
+	}
+	test.run(t)
+}
+
+func TestFormatKubernetes(t *testing.T) {
+	test := &formatTestCase{
+		url: &URL{
+			Protocol: Protocol_Kubernetes,
+			Host:     "namespace/pod/container",
+			Path:     "~/test/path/to/the file",
+			Environment: map[string]string{
+				KubernetesConfigEnvironmentVariable: "/path/to/kubeconfig",
+			},
+		},
+		environmentPrefix: "|",
+		expected:          "kubernetes://namespace/pod/container/~/test/path/to/the file|KUBECONFIG=/path/to/kubeconfig",
+	}
+	test.run(t)
+}
+
+func TestFormatKubernetesAbsolutePath(t *testing.T) {
+	test := &formatTestCase{
+		url: &URL{
+			Protocol: Protocol_Kubernetes,
+			Host:     "pod",
+			Path:     "/test/path",
+		},
+		expected: "kubernetes://pod/test/path",
+	}
+	test.run(t)
+}
+
+func TestFormatKubernetesNamespaceAbsolutePath(t *testing.T) {
+	test := &formatTestCase{
+		url: &URL{
+			Protocol: Protocol_Kubernetes,
+			Host:     "namespace/pod",
+			Path:     "/test/path",
+		},
+		expected: "kubernetes://namespace/pod//test/path",
+	}
+	test.run(t)
+}
+
+func TestFormatForwardingKubernetes(t *testing.T) {
+	test := &formatTestCase{
+		url: &URL{
+			Kind:     Kind_Forwarding,
+			Protocol: Protocol_Kubernetes,
+			Host:     "pod",
+			Path:     "tcp4:localhost:8080",
+		},
+		expected: "kubernetes://pod/tcp4:localhost:8080",
+	}
+	test.run(t)
+}
+
+func TestFormatTCPInvalidEmptyPath(t *testing.T) {
+	test := &formatTestCase{
+		url: &URL{
+			Protocol: Protocol_TCP,
+			Host:     "host",
+			Port:     9000,
+		},
+		expected: invalidTCPURLFormat,
+	}
+	test.run(t)
+}
+
+func TestFormatTCPHomeRelativePath(t *testing.T) {
+	test := &formatTestCase{
+		url: &URL{
+			Protocol: Protocol_TCP,
+			Host:     "fe80::1",
+			Port:     9000,
+			Path:     "~/path",
+		},
+		expected: "tcp://[fe80::1]:9000/~/path",
+	}
+	test.run(t)
+}
+
+func TestFormatTCPWithEnvironment(t *testing.T) {
+	test := &formatTestCase{
+		url: &URL{
+			Protocol: Protocol_TCP,
+			Host:     "host",
+			Port:     9000,
+			Path:     "/path",
+			Environment: map[string]string{
+				TCPTokenFileEnvironmentVariable: "/path/to/token",
+				TCPTLSCAEnvironmentVariable:     "/path/to/ca.pem",
+			},
+		},
+		environmentPrefix: "|",
+		expected:          "tcp://host:9000/path|MUTAGEN_TCP_TOKEN_FILE=/path/to/token|MUTAGEN_TCP_TLS_CA=/path/to/ca.pem|MUTAGEN_TCP_TLS_CERTIFICATE=|MUTAGEN_TCP_TLS_KEY=",
+	}
+	test.run(t)
+}
+
+func TestFormatForwardingTCP(t *testing.T) {
+	test := &formatTestCase{
+		url: &URL{
+			Kind:     Kind_Forwarding,
+			Protocol: Protocol_TCP,
+			Host:     "host",
+			Port:     9000,
+			Path:     "tcp4:localhost:8080",
+		},
+		expected: "tcp://host:9000:tcp4:localhost:8080",
+	}
+	test.run(t)
+}
+
+func TestFormatPodmanInvalidEmptyPath(t *testing.T) {
+	test := &formatTestCase{
+		url: &URL{
+			Protocol: Protocol_Podman,
+			Host:     "container",
+		},
+		expected: invalidPodmanURLFormat,
+	}
+	test.run(t)
+}
+
+func TestFormatPodman(t *testing.T) {
+	test := &formatTestCase{
+		url: &URL{
+			Protocol: Protocol_Podman,
+			User:     "user",
+			Host:     "container",
+			Path:     "~/path",
+			Environment: map[string]string{
+				PodmanConnectionEnvironmentVariable: "remote",
+			},
+		},
+		environmentPrefix: "|",
+		expected:          "podman://user@container/~/path|CONTAINER_HOST=|CONTAINER_CONNECTION=remote|CONTAINER_SSHKEY=",
+	}
+	test.run(t)
+}
+
+func TestFormatForwardingPodman(t *testing.T) {
+	test := &formatTestCase{
+		url: &URL{
+			Kind:     Kind_Forwarding,
+			Protocol: Protocol_Podman,
+			Host:     "container",
+			Path:     "tcp4:localhost:8080",
+		},
+		expected: "podman://container:tcp4:localhost:8080",
 	}
 	test.run(t)
 }
@@ -22,6 +22,9 @@ func TestParse(t *testing.T) {
 		{"tcp4:localhost:3992", "tcp4", "localhost:3992", false},
 		{"tcp6:[::1]:3992", "tcp6", "[::1]:3992", false},
 		{"unix:/some/socket.sock", "unix", "/some/socket.sock", false},
+		{"http:localhost:8080", "http", "localhost:8080", false},
+		{"exec:docker exec -i container sh", "exec", "docker exec -i container sh", false},
+		{"stdio:docker exec -i container sh", "stdio", "docker exec -i container sh", false},
 	}
 
 	// Process test cases.
@@ -12,7 +12,39 @@ func IsValidProtocol(protocol string) bool {
 		return true
 	case "unix":
 		return true
+	case "http":
+		return true
+	case "exec":
+		return true
+	case "stdio":
+		return true
 	default:
 		return false
 	}
 }
+
+// IsCommandProtocol returns whether or not the specified protocol is one whose
+// address is a command to spawn for each connection, with the connection piped
+// to the command's standard input and output. The exec and stdio protocols are
+// equivalent in this regard.
+func IsCommandProtocol(protocol string) bool {
+	return protocol == "exec" || protocol == "stdio"
+}
+
+// SupportsListening returns whether or not the specified protocol can be used
+// for listening (i.e. as a forwarding source). Command endpoints can only be
+// used as destinations.
+func SupportsListening(protocol string) bool {
+	return !IsCommandProtocol(protocol)
+}
+
+// Network returns the network name (as used by the net package) corresponding
+// to the specified protocol. The protocol must be valid and must not be a
+// command protocol. HTTP endpoints operate over TCP, with HTTP awareness
+// implemented by the forwarding controller.
+func Network(protocol string) string {
+	if protocol == "http" {
+		return "tcp"
+	}
+	return protocol
+}
@@ -19,6 +19,9 @@ func TestIsValidProtocol(t *testing.T) {
 		{"tcp4", true},
 		{"tcp6", true},
 		{"unix", true},
+		{"http", true},
+		{"exec", true},
+		{"stdio", true},
 	}
 
 	// Process test cases.
@@ -28,3 +31,72 @@ func TestIsValidProtocol(t *testing.T) {
 		}
 	}
 }
+
+// TestSupportsListening tests that the SupportsListening function behaves as
+// expected for a variety of test cases.
+func TestSupportsListening(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		protocol string
+		expected bool
+	}{
+		{"tcp", true},
+		{"unix", true},
+		{"http", true},
+		{"exec", false},
+		{"stdio", false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := SupportsListening(testCase.protocol); supported != testCase.expected {
+			t.Error("listening support does not match expected:", supported, "!=", testCase.expected)
+		}
+	}
+}
+
+// TestNetwork tests that the Network function behaves as expected for a variety
+// of test cases.
+func TestNetwork(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		protocol string
+		expected string
+	}{
+		{"tcp", "tcp"},
+		{"tcp4", "tcp4"},
+		{"tcp6", "tcp6"},
+		{"unix", "unix"},
+		{"http", "tcp"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if network := Network(testCase.protocol); network != testCase.expected {
+			t.Error("network does not match expected:", network, "!=", testCase.expected)
+		}
+	}
+}
+
+// TestIsCommandProtocol tests that the IsCommandProtocol function behaves as
+// expected for a variety of test cases.
+func TestIsCommandProtocol(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		protocol string
+		expected bool
+	}{
+		{"tcp", false},
+		{"unix", false},
+		{"http", false},
+		{"exec", true},
+		{"stdio", true},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if command := IsCommandProtocol(testCase.protocol); command != testCase.expected {
+			t.Error("command protocol status does not match expected:", command, "!=", testCase.expected)
+		}
+	}
+}
@@ -26,6 +26,14 @@ func Parse(raw string, kind Kind, first bool) (*URL, error) {
 	// If we don't match anything, we assume the URL is a local path.
 	if isDockerURL(raw) {
 		return parseDocker(raw, kind, first)
+	} else if isPodmanURL(raw) {
+		return parsePodman(raw, kind, first)
+	} else if isKubernetesURL(raw) {
+		return parseKubernetes(raw, kind, first)
+	} else if isTCPURL(raw) {
+		return parseTCP(raw, kind, first)
+	} else if isSSHURL(raw) {
+		return parseSSH(raw, kind)
 	} else if isSCPSSHURL(raw, kind) {
 		return parseSCPSSH(raw, kind)
 	} else {
@@ -21,6 +21,10 @@ const (
 	// DockerCertPathEnvironmentVariable is the name of the DOCKER_CERT_PATH
 	// environment variable.
 	DockerCertPathEnvironmentVariable = "DOCKER_CERT_PATH"
+	// DockerCommandEnvironmentVariable is the name of the environment variable
+	// specifying the name of a Docker-compatible command (e.g. nerdctl) to use
+	// in place of Docker.
+	DockerCommandEnvironmentVariable = "MUTAGEN_DOCKER_COMMAND"
 )
 
 // DockerEnvironmentVariables is a list of Docker environment variables that
@@ -29,6 +33,7 @@ var DockerEnvironmentVariables = []string{
 	DockerHostEnvironmentVariable,
 	DockerTLSVerifyEnvironmentVariable,
 	DockerCertPathEnvironmentVariable,
+	DockerCommandEnvironmentVariable,
 }
 
 // isDockerURL checks whether or not a URL is a Docker URL. It requires the
@@ -39,9 +44,13 @@ func isDockerURL(raw string) bool {
 
 // parseDocker parses a Docker URL.
 func parseDocker(raw string, kind Kind, first bool) (*URL, error) {
-	// Strip off the prefix.
-	raw = raw[len(dockerURLPrefix):]
+	return parseContainer(raw[len(dockerURLPrefix):], kind, first, Protocol_Docker, DockerEnvironmentVariables)
+}
 
+// parseContainer parses a container URL (with its prefix already removed) for
+// a Docker-compatible container protocol, locking in the specified environment
+// variables.
+func parseContainer(raw string, kind Kind, first bool, protocol Protocol, environmentVariables []string) (*URL, error) {
 	// Determine the character that splits the container name from the path or
 	// forwarding endpoint component.
 	var splitCharacter rune
@@ -130,8 +139,8 @@ func parseDocker(raw string, kind Kind, first bool) (*URL, error) {
 		panic("unhandled URL kind")
 	}
 
-	// Loop over and record the values for the Docker environment variables that
-	// we need to preserve. For the variables in question, Docker treats an
+	// Loop over and record the values for the container environment variables
+	// that we need to preserve. For the variables in question, Docker treats an
 	// empty value the same as an unspecified value, so we always store
 	// something for each variable, even if it's just an empty string to
 	// indicate that its value was empty or unspecified.
@@ -139,8 +148,8 @@ func parseDocker(raw string, kind Kind, first bool) (*URL, error) {
 	// TODO: I'm a little concerned that Docker may eventually add environment
 	// variables where an empty value is not the same as an unspecified value,
 	// but we'll cross that bridge when we come to it.
-	environment := make(map[string]string, len(DockerEnvironmentVariables))
-	for _, variable := range DockerEnvironmentVariables {
+	environment := make(map[string]string, len(environmentVariables))
+	for _, variable := range environmentVariables {
 		value, _ := getEnvironmentVariable(variable, kind, first)
 		environment[variable] = value
 	}
@@ -148,7 +157,7 @@ func parseDocker(raw string, kind Kind, first bool) (*URL, error) {
 	// Success.
 	return &URL{
 		Kind:        kind,
-		Protocol:    Protocol_Docker,
+		Protocol:    protocol,
 		User:        username,
 		Host:        container,
 		Path:        path,
@@ -0,0 +1,167 @@
+package url
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/url/forwarding"
+)
+
+const (
+	// kubernetesURLPrefix is the lowercase version of the Kubernetes URL
+	// prefix.
+	kubernetesURLPrefix = "kubernetes://"
+
+	// KubernetesConfigEnvironmentVariable is the name of the KUBECONFIG
+	// environment variable.
+	KubernetesConfigEnvironmentVariable = "KUBECONFIG"
+)
+
+// KubernetesEnvironmentVariables is a list of Kubernetes environment variables
+// that should be locked in to the URL at parse time.
+var KubernetesEnvironmentVariables = []string{
+	KubernetesConfigEnvironmentVariable,
+}
+
+// isKubernetesURL checks whether or not a URL is a Kubernetes URL. It requires
+// the presence of a Kubernetes protocol prefix.
+func isKubernetesURL(raw string) bool {
+	return strings.HasPrefix(strings.ToLower(raw), kubernetesURLPrefix)
+}
+
+// ParseKubernetesPodSpecification parses a Kubernetes pod specification of the
+// form [<namespace>/]<pod>[/<container>], as stored in the host component of
+// Kubernetes URLs. Empty namespace and container values indicate that the
+// defaults should be used.
+func ParseKubernetesPodSpecification(specification string) (namespace, pod, container string, err error) {
+	// Split the specification into its components and assign them based on
+	// their count.
+	components := strings.Split(specification, "/")
+	switch len(components) {
+	case 1:
+		pod = components[0]
+	case 2:
+		namespace, pod = components[0], components[1]
+	case 3:
+		namespace, pod, container = components[0], components[1], components[2]
+	default:
+		err = errors.New("too many pod specification components")
+		return
+	}
+
+	// Ensure that none of the specified components are empty.
+	for _, component := range components {
+		if component == "" {
+			err = errors.New("empty pod specification component")
+			return
+		}
+	}
+
+	// Success.
+	return
+}
+
+// parseKubernetes parses a Kubernetes URL. Kubernetes URLs take the form
+// kubernetes://[<namespace>/]<pod>[/<container>]/<path> for synchronization
+// URLs and kubernetes://[<namespace>/]<pod>[/<container>]/<endpoint> for
+// forwarding URLs. Since '/' separates both the pod specification components
+// and the path, the boundary between them is resolved based on the URL kind.
+//
+// For forwarding URLs, the endpoint starts at the first component containing a
+// ':' (which can't appear in Kubernetes names). For synchronization URLs, the
+// path starts at the first component after the pod component that is empty
+// (i.e. a leading '/' follows the specification, indicating an absolute path),
+// starts with '~', or starts a Windows path. If no such component is present,
+// then the specification consists solely of the pod name and the remainder is
+// treated as an absolute path, as with Docker URLs.
+//
+// Thus kubernetes://pod/var/www and kubernetes://namespace/pod//var/www both
+// refer to /var/www, while kubernetes://namespace/pod/container/~/project
+// refers to a home-directory-relative path in a specific container.
+func parseKubernetes(raw string, kind Kind, first bool) (*URL, error) {
+	// Strip off the prefix and split what remains into components.
+	components := strings.Split(raw[len(kubernetesURLPrefix):], "/")
+
+	// Split the components into the pod specification and the path (or
+	// forwarding endpoint, depending on the URL kind).
+	var specification, path string
+	if kind == Kind_Synchronization {
+		// Look for a component that marks the start of the path. If the
+		// marker leaves too many specification components, then the pod
+		// specification validation below will reject the URL.
+		boundary := 1
+		for i := 1; i < len(components); i++ {
+			remaining := strings.Join(components[i:], "/")
+			if remaining == "" || remaining[0] == '/' || remaining[0] == '~' || isWindowsPath(remaining) {
+				boundary = i
+				break
+			}
+		}
+		specification = strings.Join(components[:boundary], "/")
+
+		// Extract the path. If it's not home-directory-relative or a Windows
+		// path, then it's absolute, and we need to restore the leading slash
+		// that was consumed by the split.
+		if boundary < len(components) {
+			path = strings.Join(components[boundary:], "/")
+			if path == "" || (path[0] != '/' && path[0] != '~' && !isWindowsPath(path)) {
+				path = "/" + path
+			}
+		}
+	} else if kind == Kind_Forwarding {
+		// Look for the first component containing a ':', which marks the
+		// start of the forwarding endpoint. Endpoints (e.g. Unix domain socket
+		// paths) may themselves contain '/', so we rejoin what remains.
+		boundary := len(components)
+		for i, c := range components {
+			if strings.IndexByte(c, ':') >= 0 {
+				boundary = i
+				break
+			}
+		}
+		specification = strings.Join(components[:boundary], "/")
+		path = strings.Join(components[boundary:], "/")
+	} else {
+		panic("unhandled URL kind")
+	}
+	if specification == "" {
+		return nil, errors.New("empty pod specification")
+	} else if _, _, _, err := ParseKubernetesPodSpecification(specification); err != nil {
+		return nil, errors.Wrap(err, "invalid pod specification")
+	} else if path == "" {
+		if kind == Kind_Synchronization {
+			return nil, errors.New("missing path")
+		} else if kind == Kind_Forwarding {
+			return nil, errors.New("missing forwarding endpoint")
+		} else {
+			panic("unhandled URL kind")
+		}
+	}
+
+	// Validate forwarding endpoints.
+	if kind == Kind_Forwarding {
+		if _, _, err := forwarding.Parse(path); err != nil {
+			return nil, errors.Wrap(err, "invalid forwarding endpoint URL")
+		}
+	}
+
+	// Loop over and record the values for the Kubernetes environment variables
+	// that we need to preserve. As with Docker, kubectl treats an empty value
+	// the same as an unspecified value, so we always store something for each
+	// variable.
+	environment := make(map[string]string, len(KubernetesEnvironmentVariables))
+	for _, variable := range KubernetesEnvironmentVariables {
+		value, _ := getEnvironmentVariable(variable, kind, first)
+		environment[variable] = value
+	}
+
+	// Success.
+	return &URL{
+		Kind:        kind,
+		Protocol:    Protocol_Kubernetes,
+		Host:        specification,
+		Path:        path,
+		Environment: environment,
+	}, nil
+}
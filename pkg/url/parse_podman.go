@@ -0,0 +1,42 @@
+package url
+
+import (
+	"strings"
+)
+
+const (
+	// podmanURLPrefix is the lowercase version of the Podman URL prefix.
+	podmanURLPrefix = "podman://"
+
+	// PodmanHostEnvironmentVariable is the name of the CONTAINER_HOST
+	// environment variable, which specifies a remote Podman service.
+	PodmanHostEnvironmentVariable = "CONTAINER_HOST"
+	// PodmanConnectionEnvironmentVariable is the name of the
+	// CONTAINER_CONNECTION environment variable, which specifies a named
+	// Podman system connection.
+	PodmanConnectionEnvironmentVariable = "CONTAINER_CONNECTION"
+	// PodmanSSHKeyEnvironmentVariable is the name of the CONTAINER_SSHKEY
+	// environment variable, which specifies the SSH key used to access a
+	// remote Podman service.
+	PodmanSSHKeyEnvironmentVariable = "CONTAINER_SSHKEY"
+)
+
+// PodmanEnvironmentVariables is a list of Podman environment variables that
+// should be locked in to the URL at parse time.
+var PodmanEnvironmentVariables = []string{
+	PodmanHostEnvironmentVariable,
+	PodmanConnectionEnvironmentVariable,
+	PodmanSSHKeyEnvironmentVariable,
+}
+
+// isPodmanURL checks whether or not a URL is a Podman URL. It requires the
+// presence of a Podman protocol prefix.
+func isPodmanURL(raw string) bool {
+	return strings.HasPrefix(strings.ToLower(raw), podmanURLPrefix)
+}
+
+// parsePodman parses a Podman URL. Podman URLs use the same format as Docker
+// URLs.
+func parsePodman(raw string, kind Kind, first bool) (*URL, error) {
+	return parseContainer(raw[len(podmanURLPrefix):], kind, first, Protocol_Podman, PodmanEnvironmentVariables)
+}
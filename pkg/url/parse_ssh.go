@@ -1,6 +1,7 @@
 package url
 
 import (
+	neturl "net/url"
 	"runtime"
 	"strconv"
 	"strings"
@@ -182,3 +183,174 @@ func parseSCPSSH(raw string, kind Kind) (*URL, error) {
 		Path:     path,
 	}, nil
 }
+
+const (
+	// sshURLPrefix is the lowercase version of the SSH URL prefix.
+	sshURLPrefix = "ssh://"
+
+	// SSHJumpParameter is the URL parameter specifying a jump host (or a
+	// comma-separated list of jump hosts) through which to connect to an SSH
+	// host, in the format accepted by OpenSSH's ProxyJump option.
+	SSHJumpParameter = "jump"
+	// SSHProxyCommandParameter is the URL parameter specifying a command to
+	// use to connect to an SSH host, in the format accepted by OpenSSH's
+	// ProxyCommand option.
+	SSHProxyCommandParameter = "proxy"
+)
+
+// ProxyCommand returns the SSH proxy command specified by a URL, if any. Since
+// proxy commands are run on the local system, callers should ensure that URLs
+// from untrusted sources are confirmed before use.
+func (u *URL) ProxyCommand() string {
+	if u == nil || u.Protocol != Protocol_SSH {
+		return ""
+	}
+	return u.Parameters[SSHProxyCommandParameter]
+}
+
+// isSSHURL checks whether or not a URL is a full (non-SCP-style) SSH URL. It
+// requires the presence of an SSH protocol prefix.
+func isSSHURL(raw string) bool {
+	return strings.HasPrefix(strings.ToLower(raw), sshURLPrefix)
+}
+
+// validateSSHParameters ensures that SSH URL parameters are valid.
+func validateSSHParameters(parameters map[string]string) error {
+	for key, value := range parameters {
+		if key != SSHJumpParameter && key != SSHProxyCommandParameter {
+			return errors.Errorf("unknown SSH URL parameter: %s", key)
+		} else if value == "" {
+			return errors.Errorf("empty SSH URL parameter: %s", key)
+		}
+	}
+	if parameters[SSHJumpParameter] != "" && parameters[SSHProxyCommandParameter] != "" {
+		return errors.New("SSH jump host and proxy command are mutually exclusive")
+	}
+	return nil
+}
+
+// parseSSH parses a full SSH URL. SSH URLs take the form
+// ssh://[<user>@]<host>[:<port>]/<path>[?<parameters>] for synchronization URLs
+// and ssh://[<user>@]<host>[:<port>]:<endpoint>[?<parameters>] for forwarding
+// URLs. IPv6 hosts must be enclosed in square brackets. Parameters use standard
+// URL query encoding. Since parameters are split off at the last question mark,
+// paths containing a question mark must either be followed by parameters or be
+// specified using SCP-style syntax.
+func parseSSH(raw string, kind Kind) (*URL, error) {
+	// Strip off the prefix.
+	raw = raw[len(sshURLPrefix):]
+
+	// Parse off parameters, if present.
+	var parameters map[string]string
+	if index := strings.LastIndexByte(raw, '?'); index >= 0 {
+		values, err := neturl.ParseQuery(raw[index+1:])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid parameters")
+		}
+		parameters = make(map[string]string, len(values))
+		for key, value := range values {
+			if len(value) != 1 {
+				return nil, errors.Errorf("parameter specified multiple times: %s", key)
+			}
+			parameters[key] = value[0]
+		}
+		if err := validateSSHParameters(parameters); err != nil {
+			return nil, err
+		}
+		raw = raw[:index]
+	}
+
+	// Parse off the username, if present. It must occur before any path or
+	// port separator.
+	var username string
+	for i, r := range raw {
+		if r == '@' {
+			if i == 0 {
+				return nil, errors.New("empty username specified")
+			}
+			username, raw = raw[:i], raw[i+1:]
+			break
+		} else if r == '/' || r == ':' {
+			break
+		}
+	}
+
+	// Parse off the host. If it's enclosed in brackets, then it's an IPv6
+	// address and we parse until the closing bracket.
+	var hostname string
+	if strings.HasPrefix(raw, "[") {
+		closing := strings.IndexByte(raw, ']')
+		if closing < 0 {
+			return nil, errors.New("unterminated IPv6 address")
+		}
+		hostname, raw = raw[1:closing], raw[closing+1:]
+	} else if index := strings.IndexAny(raw, ":/"); index >= 0 {
+		hostname, raw = raw[:index], raw[index:]
+	} else {
+		hostname, raw = raw, ""
+	}
+	if hostname == "" {
+		return nil, errors.New("empty hostname")
+	}
+
+	// Parse off the port, if present. A port is a (non-empty) sequence of
+	// digits following a colon and terminated by the end of the string, a path
+	// separator, or a forwarding endpoint separator.
+	var port uint32
+	if strings.HasPrefix(raw, ":") {
+		end := strings.IndexFunc(raw[1:], func(r rune) bool { return r < '0' || r > '9' }) + 1
+		if end == 0 {
+			end = len(raw)
+		}
+		if end > 1 && (end == len(raw) || raw[end] == '/' || raw[end] == ':') {
+			if port64, err := strconv.ParseUint(raw[1:end], 10, 16); err != nil || port64 == 0 {
+				return nil, errors.New("invalid port value specified")
+			} else {
+				port, raw = uint32(port64), raw[end:]
+			}
+		}
+	}
+	path := raw
+
+	// Perform path processing based on URL kind.
+	if kind == Kind_Synchronization {
+		// Ensure that the path was separated with a slash.
+		if path == "" {
+			return nil, errors.New("missing path")
+		} else if path[0] != '/' {
+			return nil, errors.New("invalid path separator")
+		}
+
+		// As with Docker URLs, treat "/~" as the start of a home-directory-
+		// relative path and "/" + Windows path as a Windows path.
+		if len(path) > 1 && (path[1] == '~' || isWindowsPath(path[1:])) {
+			path = path[1:]
+		}
+	} else if kind == Kind_Forwarding {
+		// Ensure that the forwarding endpoint was separated with a colon.
+		if path == "" || path == ":" {
+			return nil, errors.New("missing forwarding endpoint")
+		} else if path[0] != ':' {
+			return nil, errors.New("invalid forwarding endpoint separator")
+		}
+		path = path[1:]
+
+		// Parse the forwarding endpoint URL to ensure that it's valid.
+		if _, _, err := forwarding.Parse(path); err != nil {
+			return nil, errors.Wrap(err, "invalid forwarding endpoint URL")
+		}
+	} else {
+		panic("unhandled URL kind")
+	}
+
+	// Success.
+	return &URL{
+		Kind:       kind,
+		Protocol:   Protocol_SSH,
+		User:       username,
+		Host:       hostname,
+		Port:       port,
+		Path:       path,
+		Parameters: parameters,
+	}, nil
+}
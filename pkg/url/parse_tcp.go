@@ -0,0 +1,144 @@
+package url
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mutagen-io/mutagen/pkg/url/forwarding"
+)
+
+const (
+	// tcpURLPrefix is the lowercase version of the TCP URL prefix.
+	tcpURLPrefix = "tcp://"
+
+	// TCPTokenFileEnvironmentVariable is the name of the environment variable
+	// specifying the path to a file containing the authentication token to use
+	// when connecting to an agent. We record the path rather than the token
+	// itself so that the token never becomes part of session state.
+	TCPTokenFileEnvironmentVariable = "MUTAGEN_TCP_TOKEN_FILE"
+	// TCPTLSCAEnvironmentVariable is the name of the environment variable
+	// specifying the path to a PEM-encoded certificate authority bundle used to
+	// verify an agent's certificate. If unset, the system roots are used.
+	TCPTLSCAEnvironmentVariable = "MUTAGEN_TCP_TLS_CA"
+	// TCPTLSCertificateEnvironmentVariable is the name of the environment
+	// variable specifying the path to a PEM-encoded client certificate to
+	// present to an agent for mutual TLS authentication.
+	TCPTLSCertificateEnvironmentVariable = "MUTAGEN_TCP_TLS_CERTIFICATE"
+	// TCPTLSKeyEnvironmentVariable is the name of the environment variable
+	// specifying the path to the PEM-encoded private key corresponding to the
+	// client certificate.
+	TCPTLSKeyEnvironmentVariable = "MUTAGEN_TCP_TLS_KEY"
+)
+
+// TCPEnvironmentVariables is a list of TCP environment variables that should be
+// locked in to the URL at parse time.
+var TCPEnvironmentVariables = []string{
+	TCPTokenFileEnvironmentVariable,
+	TCPTLSCAEnvironmentVariable,
+	TCPTLSCertificateEnvironmentVariable,
+	TCPTLSKeyEnvironmentVariable,
+}
+
+// isTCPURL checks whether or not a URL is a TCP URL. It requires the presence
+// of a TCP protocol prefix.
+func isTCPURL(raw string) bool {
+	return strings.HasPrefix(strings.ToLower(raw), tcpURLPrefix)
+}
+
+// parseTCP parses a TCP URL. TCP URLs take the form tcp://<host>:<port>/<path>
+// for synchronization URLs and tcp://<host>:<port>:<endpoint> for forwarding
+// URLs. IPv6 hosts must be enclosed in square brackets. A port is always
+// required since there's no standard port for agent listeners.
+func parseTCP(raw string, kind Kind, first bool) (*URL, error) {
+	// Strip off the prefix.
+	raw = raw[len(tcpURLPrefix):]
+
+	// Parse off the host. If it's enclosed in brackets, then it's an IPv6
+	// address and we parse until the closing bracket.
+	var host string
+	if strings.HasPrefix(raw, "[") {
+		closing := strings.IndexByte(raw, ']')
+		if closing < 0 {
+			return nil, errors.New("unterminated IPv6 address")
+		}
+		host, raw = raw[1:closing], raw[closing+1:]
+	} else if index := strings.IndexByte(raw, ':'); index >= 0 {
+		host, raw = raw[:index], raw[index:]
+	} else {
+		return nil, errors.New("missing port")
+	}
+	if host == "" {
+		return nil, errors.New("empty host")
+	}
+
+	// Parse off the port. At this point, the remaining string should start
+	// with the colon separating the host and port.
+	if !strings.HasPrefix(raw, ":") {
+		return nil, errors.New("missing port")
+	}
+	raw = raw[1:]
+	end := strings.IndexFunc(raw, func(r rune) bool { return r < '0' || r > '9' })
+	if end < 0 {
+		end = len(raw)
+	}
+	if end == 0 {
+		return nil, errors.New("missing port")
+	}
+	port, err := strconv.ParseUint(raw[:end], 10, 16)
+	if err != nil || port == 0 || port > math.MaxUint16 {
+		return nil, errors.New("invalid port")
+	}
+	path := raw[end:]
+
+	// Perform path processing based on URL kind.
+	if kind == Kind_Synchronization {
+		// Ensure that the path was separated with a slash.
+		if path == "" || path == "/" {
+			return nil, errors.New("missing path")
+		} else if path[0] != '/' {
+			return nil, errors.New("invalid path separator")
+		}
+
+		// As with Docker URLs, treat "/~" as the start of a home-directory-
+		// relative path and "/" + Windows path as a Windows path.
+		if path[1] == '~' || isWindowsPath(path[1:]) {
+			path = path[1:]
+		}
+	} else if kind == Kind_Forwarding {
+		// Ensure that the forwarding endpoint was separated with a colon.
+		if path == "" || path == ":" {
+			return nil, errors.New("missing forwarding endpoint")
+		} else if path[0] != ':' {
+			return nil, errors.New("invalid forwarding endpoint separator")
+		}
+		path = path[1:]
+
+		// Parse the forwarding endpoint URL to ensure that it's valid.
+		if _, _, err := forwarding.Parse(path); err != nil {
+			return nil, errors.Wrap(err, "invalid forwarding endpoint URL")
+		}
+	} else {
+		panic("unhandled URL kind")
+	}
+
+	// Loop over and record the values for the TCP environment variables that we
+	// need to preserve. Empty values are treated as unspecified.
+	environment := make(map[string]string, len(TCPEnvironmentVariables))
+	for _, variable := range TCPEnvironmentVariables {
+		value, _ := getEnvironmentVariable(variable, kind, first)
+		environment[variable] = value
+	}
+
+	// Success.
+	return &URL{
+		Kind:        kind,
+		Protocol:    Protocol_TCP,
+		Host:        host,
+		Port:        uint32(port),
+		Path:        path,
+		Environment: environment,
+	}, nil
+}
@@ -72,6 +72,19 @@ func (c *parseTestCase) run(t *testing.T) {
 			}
 		}
 	}
+
+	// Verify parameters.
+	if len(url.Parameters) != len(c.expected.Parameters) {
+		t.Error("parameters length mismatch:", len(url.Parameters), "!=", len(c.expected.Parameters))
+	} else {
+		for ek, ev := range c.expected.Parameters {
+			if v, ok := url.Parameters[ek]; !ok {
+				t.Error("expected parameter", ek, "not in URL parameters")
+			} else if v != ev {
+				t.Error("parameter", ek, "value does not match expected:", v, "!=", ev)
+			}
+		}
+	}
 }
 
 func TestParseEmptyInvalid(t *testing.T) {
@@ -142,6 +155,22 @@ func TestParseForwardingLocalTCP(t *testing.T) {
 	test.run(t)
 }
 
+func TestParseForwardingLocalExec(t *testing.T) {
+	test := parseTestCase{
+		raw:  "exec:nc localhost 5050",
+		kind: Kind_Forwarding,
+		expected: &URL{
+			Kind:     Kind_Forwarding,
+			Protocol: Protocol_Local,
+			User:     "",
+			Host:     "",
+			Port:     0,
+			Path:     "exec:nc localhost 5050",
+		},
+	}
+	test.run(t)
+}
+
 func TestParseForwardingLocalUnixRelativeSocket(t *testing.T) {
 	// Compute the normalized form of a relative socket path.
 	path := "relative/path/to/socket.sock"
@@ -512,6 +541,150 @@ func TestParseSCPSSHUnicodeUsernameHostnamePortPath(t *testing.T) {
 	test.run(t)
 }
 
+func TestParseSSHMissingPathInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "ssh://user@host",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseSSHEmptyHostnameInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "ssh://user@/path",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseSSHInvalidPortInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "ssh://host:65536/path",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseSSHUnknownParameterInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "ssh://host/path?unknown=value",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseSSHEmptyParameterInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "ssh://host/path?jump=",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseSSHDuplicateParameterInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "ssh://host/path?jump=a&jump=b",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseSSHJumpAndProxyInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "ssh://host/path?jump=bastion&proxy=nc%20%25h%20%25p",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseSSHHostnamePath(t *testing.T) {
+	test := parseTestCase{
+		raw: "ssh://host/path",
+		expected: &URL{
+			Protocol: Protocol_SSH,
+			Host:     "host",
+			Path:     "/path",
+		},
+	}
+	test.run(t)
+}
+
+func TestParseSSHUsernameHostnamePortHomeRelativePathWithJump(t *testing.T) {
+	test := parseTestCase{
+		raw: "ssh://user@host:2222/~/path?jump=admin@bastion:22",
+		expected: &URL{
+			Protocol: Protocol_SSH,
+			User:     "user",
+			Host:     "host",
+			Port:     2222,
+			Path:     "~/path",
+			Parameters: map[string]string{
+				SSHJumpParameter: "admin@bastion:22",
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParseSSHIPv6WithWindowsPathAndProxy(t *testing.T) {
+	test := parseTestCase{
+		raw: "ssh://user@[fe80::1]:22/C:/path?proxy=nc%20%25h%20%25p",
+		expected: &URL{
+			Protocol: Protocol_SSH,
+			User:     "user",
+			Host:     "fe80::1",
+			Port:     22,
+			Path:     "C:/path",
+			Parameters: map[string]string{
+				SSHProxyCommandParameter: "nc %h %p",
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParseForwardingSSHInvalidEndpoint(t *testing.T) {
+	test := parseTestCase{
+		raw:  "ssh://host:bad:endpoint",
+		kind: Kind_Forwarding,
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseForwardingSSHWithJump(t *testing.T) {
+	test := parseTestCase{
+		raw:  "ssh://user@host:2222:tcp:localhost:8080?jump=bastion",
+		kind: Kind_Forwarding,
+		expected: &URL{
+			Kind:     Kind_Forwarding,
+			Protocol: Protocol_SSH,
+			User:     "user",
+			Host:     "host",
+			Port:     2222,
+			Path:     "tcp:localhost:8080",
+			Parameters: map[string]string{
+				SSHJumpParameter: "bastion",
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParseForwardingSSHNoPort(t *testing.T) {
+	test := parseTestCase{
+		raw:  "ssh://host:tcp:localhost:8080",
+		kind: Kind_Forwarding,
+		expected: &URL{
+			Kind:     Kind_Forwarding,
+			Protocol: Protocol_SSH,
+			Host:     "host",
+			Path:     "tcp:localhost:8080",
+		},
+	}
+	test.run(t)
+}
+
 func TestParseForwardingDockerWithSourceSpecificVariables(t *testing.T) {
 	test := parseTestCase{
 		raw:   "docker://cøntainer:unix:/some/socket.sock",
@@ -526,6 +699,7 @@ func TestParseForwardingDockerWithSourceSpecificVariables(t *testing.T) {
 				DockerHostEnvironmentVariable:      sourceSpecificDockerHost,
 				DockerTLSVerifyEnvironmentVariable: defaultDockerTLSVerify,
 				DockerCertPathEnvironmentVariable:  "",
+				DockerCommandEnvironmentVariable:   "",
 			},
 		},
 	}
@@ -545,6 +719,7 @@ func TestParseForwardingDockerWithDestinationSpecificVariables(t *testing.T) {
 				DockerHostEnvironmentVariable:      defaultDockerHost,
 				DockerTLSVerifyEnvironmentVariable: destinationSpecificDockerTLSVerify,
 				DockerCertPathEnvironmentVariable:  "",
+				DockerCommandEnvironmentVariable:   "",
 			},
 		},
 	}
@@ -563,6 +738,7 @@ func TestParseDockerWithBetaSpecificVariables(t *testing.T) {
 				DockerHostEnvironmentVariable:      defaultDockerHost,
 				DockerTLSVerifyEnvironmentVariable: betaSpecificDockerTLSVerify,
 				DockerCertPathEnvironmentVariable:  "",
+				DockerCommandEnvironmentVariable:   "",
 			},
 		},
 	}
@@ -582,6 +758,7 @@ func TestParseDockerWithWindowsPathAndAlphaSpecificVariables(t *testing.T) {
 				DockerHostEnvironmentVariable:      alphaSpecificDockerHost,
 				DockerTLSVerifyEnvironmentVariable: defaultDockerTLSVerify,
 				DockerCertPathEnvironmentVariable:  "",
+				DockerCommandEnvironmentVariable:   "",
 			},
 		},
 	}
@@ -602,6 +779,7 @@ func TestParseDockerWithUsernameHomeRelativePathAndAlphaSpecificVariables(t *tes
 				DockerHostEnvironmentVariable:      alphaSpecificDockerHost,
 				DockerTLSVerifyEnvironmentVariable: defaultDockerTLSVerify,
 				DockerCertPathEnvironmentVariable:  "",
+				DockerCommandEnvironmentVariable:   "",
 			},
 		},
 	}
@@ -622,6 +800,399 @@ func TestParseDockerWithUsernameUserRelativePathAndAlphaSpecificVariables(t *tes
 				DockerHostEnvironmentVariable:      alphaSpecificDockerHost,
 				DockerTLSVerifyEnvironmentVariable: defaultDockerTLSVerify,
 				DockerCertPathEnvironmentVariable:  "",
+				DockerCommandEnvironmentVariable:   "",
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParseKubernetesEmptyPodSpecificationInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "kubernetes:///path",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseKubernetesMissingPathInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "kubernetes://pod",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseKubernetesTooManyComponentsBeforeAbsolutePathInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "kubernetes://namespace/pod/container/extra//path",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseKubernetesTooManyComponentsInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "kubernetes://namespace/pod/container/extra/~/path",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseKubernetesPod(t *testing.T) {
+	test := parseTestCase{
+		raw:   "kubernetes://pød/пат/to/the file",
+		first: true,
+		expected: &URL{
+			Protocol: Protocol_Kubernetes,
+			Host:     "pød",
+			Path:     "/пат/to/the file",
+			Environment: map[string]string{
+				KubernetesConfigEnvironmentVariable: "",
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParseKubernetesNamespacePodWithAbsolutePath(t *testing.T) {
+	test := parseTestCase{
+		raw:   "kubernetes://namespace/pød//пат/to/the file",
+		first: true,
+		expected: &URL{
+			Protocol: Protocol_Kubernetes,
+			Host:     "namespace/pød",
+			Path:     "/пат/to/the file",
+			Environment: map[string]string{
+				KubernetesConfigEnvironmentVariable: "",
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParseKubernetesPodWithRootPath(t *testing.T) {
+	test := parseTestCase{
+		raw:   "kubernetes://pød/",
+		first: true,
+		expected: &URL{
+			Protocol: Protocol_Kubernetes,
+			Host:     "pød",
+			Path:     "/",
+			Environment: map[string]string{
+				KubernetesConfigEnvironmentVariable: "",
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParseKubernetesNamespacePodContainerWithBetaSpecificVariables(t *testing.T) {
+	test := parseTestCase{
+		raw: "KUBERNETES://namespace/pød/container/~/пат",
+		expected: &URL{
+			Protocol: Protocol_Kubernetes,
+			Host:     "namespace/pød/container",
+			Path:     "~/пат",
+			Environment: map[string]string{
+				KubernetesConfigEnvironmentVariable: betaSpecificKubernetesConfig,
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParseKubernetesWithWindowsPath(t *testing.T) {
+	test := parseTestCase{
+		raw:   `kubernetes://namespace/pød/C:\пат/to\the file`,
+		first: true,
+		expected: &URL{
+			Protocol: Protocol_Kubernetes,
+			Host:     "namespace/pød",
+			Path:     `C:\пат/to\the file`,
+			Environment: map[string]string{
+				KubernetesConfigEnvironmentVariable: "",
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParseForwardingKubernetesUnixDomainSocket(t *testing.T) {
+	test := parseTestCase{
+		raw:   "kubernetes://namespace/pød/container/unix:/path/to/socket",
+		kind:  Kind_Forwarding,
+		first: true,
+		expected: &URL{
+			Kind:     Kind_Forwarding,
+			Protocol: Protocol_Kubernetes,
+			Host:     "namespace/pød/container",
+			Path:     "unix:/path/to/socket",
+			Environment: map[string]string{
+				KubernetesConfigEnvironmentVariable: "",
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParseForwardingKubernetesInvalidEndpoint(t *testing.T) {
+	test := parseTestCase{
+		raw:  "kubernetes://pod/tcp4",
+		kind: Kind_Forwarding,
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseForwardingKubernetes(t *testing.T) {
+	test := parseTestCase{
+		raw:   "kubernetes://namespace/pød/tcp:localhost:8080",
+		kind:  Kind_Forwarding,
+		first: true,
+		expected: &URL{
+			Kind:     Kind_Forwarding,
+			Protocol: Protocol_Kubernetes,
+			Host:     "namespace/pød",
+			Path:     "tcp:localhost:8080",
+			Environment: map[string]string{
+				KubernetesConfigEnvironmentVariable: "",
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParseKubernetesPodSpecification(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		specification string
+		fail          bool
+		namespace     string
+		pod           string
+		container     string
+	}{
+		{"", true, "", "", ""},
+		{"pod", false, "", "pod", ""},
+		{"namespace/pod", false, "namespace", "pod", ""},
+		{"namespace/pod/container", false, "namespace", "pod", "container"},
+		{"namespace/", true, "", "", ""},
+		{"/pod", true, "", "", ""},
+		{"a/b/c/d", true, "", "", ""},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		namespace, pod, container, err := ParseKubernetesPodSpecification(testCase.specification)
+		if err != nil {
+			if !testCase.fail {
+				t.Errorf("parsing of \"%s\" failed unexpectedly: %v", testCase.specification, err)
+			}
+			continue
+		} else if testCase.fail {
+			t.Errorf("parsing of \"%s\" succeeded unexpectedly", testCase.specification)
+			continue
+		}
+		if namespace != testCase.namespace {
+			t.Error("namespace mismatch:", namespace, "!=", testCase.namespace)
+		}
+		if pod != testCase.pod {
+			t.Error("pod mismatch:", pod, "!=", testCase.pod)
+		}
+		if container != testCase.container {
+			t.Error("container mismatch:", container, "!=", testCase.container)
+		}
+	}
+}
+
+func TestParseTCPMissingPortInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "tcp://host/path",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseTCPZeroPortInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "tcp://host:0/path",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseTCPPortOutOfRangeInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "tcp://host:65536/path",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseTCPEmptyHostInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "tcp://:9000/path",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseTCPMissingPathInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "tcp://host:9000/",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseTCPUnterminatedIPv6Invalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "tcp://[::1:9000/path",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseTCP(t *testing.T) {
+	test := parseTestCase{
+		raw:   "tcp://høst:9000/пат/to/the file",
+		first: true,
+		expected: &URL{
+			Protocol: Protocol_TCP,
+			Host:     "høst",
+			Port:     9000,
+			Path:     "/пат/to/the file",
+			Environment: map[string]string{
+				TCPTokenFileEnvironmentVariable:      "",
+				TCPTLSCAEnvironmentVariable:          "",
+				TCPTLSCertificateEnvironmentVariable: "",
+				TCPTLSKeyEnvironmentVariable:         "",
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParseTCPIPv6WithHomeRelativePath(t *testing.T) {
+	test := parseTestCase{
+		raw:   "TCP://[fe80::1]:9000/~/path",
+		first: true,
+		expected: &URL{
+			Protocol: Protocol_TCP,
+			Host:     "fe80::1",
+			Port:     9000,
+			Path:     "~/path",
+			Environment: map[string]string{
+				TCPTokenFileEnvironmentVariable:      "",
+				TCPTLSCAEnvironmentVariable:          "",
+				TCPTLSCertificateEnvironmentVariable: "",
+				TCPTLSKeyEnvironmentVariable:         "",
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParseTCPWithWindowsPath(t *testing.T) {
+	test := parseTestCase{
+		raw:   `tcp://host:9000/C:\path`,
+		first: true,
+		expected: &URL{
+			Protocol: Protocol_TCP,
+			Host:     "host",
+			Port:     9000,
+			Path:     `C:\path`,
+			Environment: map[string]string{
+				TCPTokenFileEnvironmentVariable:      "",
+				TCPTLSCAEnvironmentVariable:          "",
+				TCPTLSCertificateEnvironmentVariable: "",
+				TCPTLSKeyEnvironmentVariable:         "",
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParseForwardingTCPInvalidEndpoint(t *testing.T) {
+	test := parseTestCase{
+		raw:  "tcp://host:9000:tcp4",
+		kind: Kind_Forwarding,
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParseForwardingTCP(t *testing.T) {
+	test := parseTestCase{
+		raw:   "tcp://host:9000:tcp:localhost:8080",
+		kind:  Kind_Forwarding,
+		first: true,
+		expected: &URL{
+			Kind:     Kind_Forwarding,
+			Protocol: Protocol_TCP,
+			Host:     "host",
+			Port:     9000,
+			Path:     "tcp:localhost:8080",
+			Environment: map[string]string{
+				TCPTokenFileEnvironmentVariable:      "",
+				TCPTLSCAEnvironmentVariable:          "",
+				TCPTLSCertificateEnvironmentVariable: "",
+				TCPTLSKeyEnvironmentVariable:         "",
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParsePodmanEmptyContainerInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "podman:///path",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParsePodmanMissingPathInvalid(t *testing.T) {
+	test := parseTestCase{
+		raw:  "podman://container",
+		fail: true,
+	}
+	test.run(t)
+}
+
+func TestParsePodmanWithUsernameAndHomeRelativePath(t *testing.T) {
+	test := parseTestCase{
+		raw:   "PODMAN://üsér@cøntainer/~/пат",
+		first: true,
+		expected: &URL{
+			Protocol: Protocol_Podman,
+			User:     "üsér",
+			Host:     "cøntainer",
+			Path:     "~/пат",
+			Environment: map[string]string{
+				PodmanHostEnvironmentVariable:       "",
+				PodmanConnectionEnvironmentVariable: "",
+				PodmanSSHKeyEnvironmentVariable:     "",
+			},
+		},
+	}
+	test.run(t)
+}
+
+func TestParseForwardingPodman(t *testing.T) {
+	test := parseTestCase{
+		raw:   "podman://container:tcp:localhost:8080",
+		kind:  Kind_Forwarding,
+		first: true,
+		expected: &URL{
+			Kind:     Kind_Forwarding,
+			Protocol: Protocol_Podman,
+			Host:     "container",
+			Path:     "tcp:localhost:8080",
+			Environment: map[string]string{
+				PodmanHostEnvironmentVariable:       "",
+				PodmanConnectionEnvironmentVariable: "",
+				PodmanSSHKeyEnvironmentVariable:     "",
 			},
 		},
 	}
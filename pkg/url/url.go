@@ -33,6 +33,11 @@ func (u *URL) EnsureValid() error {
 		return errors.New("unsupported URL kind")
 	}
 
+	// Only SSH URLs currently support parameters.
+	if u.Protocol != Protocol_SSH && len(u.Parameters) != 0 {
+		return errors.New("non-SSH URL with parameters")
+	}
+
 	// Validate the User, Host, Port, and Environment components based on
 	// protocol.
 	if u.Protocol == Protocol_Local {
@@ -52,6 +57,8 @@ func (u *URL) EnsureValid() error {
 			return errors.New("SSH URL with invalid port")
 		} else if len(u.Environment) != 0 {
 			return errors.New("SSH URL with environment variables")
+		} else if err := validateSSHParameters(u.Parameters); err != nil {
+			return errors.Wrap(err, "SSH URL with invalid parameters")
 		}
 	} else if u.Protocol == Protocol_Docker {
 		// In the case of Docker, we intentionally avoid validating environment
@@ -64,6 +71,31 @@ func (u *URL) EnsureValid() error {
 		} else if u.Port != 0 {
 			return errors.New("Docker URL with non-zero port")
 		}
+	} else if u.Protocol == Protocol_Podman {
+		// As with Docker, we avoid validating environment variables.
+		if u.Host == "" {
+			return errors.New("Podman URL with empty container identifier")
+		} else if u.Port != 0 {
+			return errors.New("Podman URL with non-zero port")
+		}
+	} else if u.Protocol == Protocol_Kubernetes {
+		// As with Docker, we avoid validating environment variables.
+		if _, _, _, err := ParseKubernetesPodSpecification(u.Host); err != nil {
+			return errors.Wrap(err, "Kubernetes URL with invalid pod specification")
+		} else if u.User != "" {
+			return errors.New("Kubernetes URL with non-empty username")
+		} else if u.Port != 0 {
+			return errors.New("Kubernetes URL with non-zero port")
+		}
+	} else if u.Protocol == Protocol_TCP {
+		// As with Docker, we avoid validating environment variables.
+		if u.Host == "" {
+			return errors.New("TCP URL with empty hostname")
+		} else if u.User != "" {
+			return errors.New("TCP URL with non-empty username")
+		} else if u.Port == 0 || u.Port > math.MaxUint16 {
+			return errors.New("TCP URL with invalid port")
+		}
 	} else {
 		return errors.New("unknown or unsupported protocol")
 	}
@@ -87,6 +119,27 @@ func (u *URL) EnsureValid() error {
 				return errors.New("Docker URL with incorrect first path character")
 			}
 		}
+
+		// The same validation applies to Podman URLs.
+		if u.Protocol == Protocol_Podman {
+			if !(u.Path[0] == '/' || u.Path[0] == '~' || isWindowsPath(u.Path)) {
+				return errors.New("Podman URL with incorrect first path character")
+			}
+		}
+
+		// The same validation applies to Kubernetes URLs.
+		if u.Protocol == Protocol_Kubernetes {
+			if !(u.Path[0] == '/' || u.Path[0] == '~' || isWindowsPath(u.Path)) {
+				return errors.New("Kubernetes URL with incorrect first path character")
+			}
+		}
+
+		// The same validation applies to TCP URLs.
+		if u.Protocol == Protocol_TCP {
+			if !(u.Path[0] == '/' || u.Path[0] == '~' || isWindowsPath(u.Path)) {
+				return errors.New("TCP URL with incorrect first path character")
+			}
+		}
 	} else if u.Kind == Kind_Forwarding {
 		// Parse the forwarding endpoint URL to ensure that it's valid.
 		protocol, address, err := forwarding.Parse(u.Path)
@@ -104,3 +157,18 @@ func (u *URL) EnsureValid() error {
 	// Success.
 	return nil
 }
+
+// EndpointCommand returns the command specified by a forwarding URL whose
+// endpoint uses a command protocol (e.g. exec:), if any. Since such commands are
+// run with the user's credentials for each forwarded connection, callers should
+// ensure that URLs from untrusted sources are confirmed before use.
+func (u *URL) EndpointCommand() string {
+	if u == nil || u.Kind != Kind_Forwarding {
+		return ""
+	}
+	protocol, address, err := forwarding.Parse(u.Path)
+	if err != nil || !forwarding.IsCommandProtocol(protocol) {
+		return ""
+	}
+	return address
+}
@@ -58,18 +58,31 @@ const (
 	Protocol_SSH Protocol = 1
 	// Docker indicates that the resource is inside a Docker container.
 	Protocol_Docker Protocol = 11
+	// Kubernetes indicates that the resource is inside a Kubernetes pod.
+	Protocol_Kubernetes Protocol = 12
+	// TCP indicates that the resource is accessible via a long-lived agent
+	// listening for direct TCP connections.
+	Protocol_TCP Protocol = 13
+	// Podman indicates that the resource is inside a Podman container.
+	Protocol_Podman Protocol = 14
 )
 
 var Protocol_name = map[int32]string{
 	0:  "Local",
 	1:  "SSH",
 	11: "Docker",
+	12: "Kubernetes",
+	13: "TCP",
+	14: "Podman",
 }
 
 var Protocol_value = map[string]int32{
-	"Local":  0,
-	"SSH":    1,
-	"Docker": 11,
+	"Local":      0,
+	"SSH":        1,
+	"Docker":     11,
+	"Kubernetes": 12,
+	"TCP":        13,
+	"Podman":     14,
 }
 
 func (x Protocol) String() string {
@@ -99,7 +112,10 @@ type URL struct {
 	Path string `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
 	// Environment is used to capture environment variable information (if
 	// necessary) for transports which operate by executing a command.
-	Environment          map[string]string `protobuf:"bytes,6,rep,name=environment,proto3" json:"environment,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Environment map[string]string `protobuf:"bytes,6,rep,name=environment,proto3" json:"environment,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Parameters are protocol-specific connection parameters, e.g. SSH jump
+	// hosts or proxy commands.
+	Parameters           map[string]string `protobuf:"bytes,8,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -179,36 +195,48 @@ func (m *URL) GetEnvironment() map[string]string {
 	return nil
 }
 
+func (m *URL) GetParameters() map[string]string {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("url.Kind", Kind_name, Kind_value)
 	proto.RegisterEnum("url.Protocol", Protocol_name, Protocol_value)
 	proto.RegisterType((*URL)(nil), "url.URL")
 	proto.RegisterMapType((map[string]string)(nil), "url.URL.EnvironmentEntry")
+	proto.RegisterMapType((map[string]string)(nil), "url.URL.ParametersEntry")
 }
 
 func init() { proto.RegisterFile("url/url.proto", fileDescriptor_ce31eacd751d7393) }
 
 var fileDescriptor_ce31eacd751d7393 = []byte{
-	// 328 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x91, 0x4f, 0x4b, 0xc3, 0x30,
-	0x18, 0xc6, 0xd7, 0xb5, 0xfb, 0xd3, 0x77, 0x6c, 0x86, 0xe8, 0x21, 0x0a, 0x42, 0x11, 0xc4, 0x3a,
-	0xb1, 0x83, 0x79, 0x11, 0x05, 0x0f, 0xe2, 0x44, 0x70, 0x07, 0xc9, 0xd8, 0xc5, 0x5b, 0xd7, 0x85,
-	0x36, 0xb4, 0x4b, 0x46, 0x96, 0x4c, 0xe6, 0x67, 0xf1, 0xc3, 0x4a, 0xb2, 0x4d, 0xc5, 0xdb, 0x93,
-	0xdf, 0xfb, 0x0b, 0x49, 0x9e, 0x40, 0xd7, 0xa8, 0x6a, 0x60, 0x54, 0x95, 0x2c, 0x95, 0xd4, 0x12,
-	0xfb, 0x46, 0x55, 0x67, 0x5f, 0x75, 0xf0, 0xa7, 0x74, 0x8c, 0x4f, 0x21, 0x28, 0xb9, 0x98, 0x93,
-	0x56, 0xe4, 0xc5, 0xbd, 0x61, 0x98, 0x58, 0xed, 0x95, 0x8b, 0x39, 0x75, 0x18, 0x5f, 0x42, 0xdb,
-	0x6d, 0xca, 0x64, 0x45, 0x3c, 0xa7, 0x74, 0x9d, 0xf2, 0xb6, 0x83, 0xf4, 0x67, 0x8c, 0x31, 0x04,
-	0x66, 0xc5, 0x14, 0xa9, 0x47, 0x5e, 0x1c, 0x52, 0x97, 0x2d, 0x2b, 0xe4, 0x4a, 0x13, 0x7f, 0xcb,
-	0x6c, 0xb6, 0x6c, 0x29, 0x95, 0x26, 0x41, 0xe4, 0xc5, 0x5d, 0xea, 0xb2, 0x63, 0xa9, 0x2e, 0x48,
-	0x63, 0xeb, 0xd9, 0x8c, 0xef, 0xa1, 0xc3, 0xc4, 0x9a, 0x2b, 0x29, 0x16, 0x4c, 0x68, 0xd2, 0x8c,
-	0xfc, 0xb8, 0x33, 0x3c, 0x76, 0xa7, 0x4f, 0xe9, 0x38, 0x19, 0xfd, 0xce, 0x46, 0x42, 0xab, 0x0d,
-	0xfd, 0x6b, 0x9f, 0x3c, 0x00, 0xfa, 0x2f, 0x60, 0x04, 0x7e, 0xc9, 0x36, 0xee, 0x19, 0x21, 0xb5,
-	0x11, 0x1f, 0x41, 0x63, 0x9d, 0x56, 0x86, 0xed, 0xee, 0xbc, 0x5d, 0xdc, 0xd5, 0x6f, 0xbd, 0xfe,
-	0x15, 0x04, 0xb6, 0x05, 0x7c, 0x08, 0x07, 0x93, 0x8d, 0xc8, 0x0a, 0x25, 0x05, 0xff, 0x4c, 0x35,
-	0x97, 0x02, 0xd5, 0x70, 0x0f, 0xe0, 0x59, 0xaa, 0x8f, 0x54, 0xcd, 0xb9, 0xc8, 0x91, 0xd7, 0xef,
-	0x43, 0x7b, 0xdf, 0x07, 0x0e, 0xa1, 0x31, 0x96, 0x59, 0x5a, 0xa1, 0x1a, 0x6e, 0x81, 0x3f, 0x99,
-	0xbc, 0x20, 0x0f, 0x03, 0x34, 0x9f, 0x64, 0x56, 0x32, 0x85, 0x3a, 0x8f, 0x17, 0xef, 0xe7, 0x39,
-	0xd7, 0x85, 0x99, 0x25, 0x99, 0x5c, 0x0c, 0x16, 0x46, 0xa7, 0x39, 0x13, 0xd7, 0x5c, 0xee, 0xe3,
-	0x60, 0x59, 0xe6, 0xf6, 0xaf, 0x66, 0x4d, 0x57, 0xec, 0xcd, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x4b, 0xd0, 0xde, 0x19, 0xbd, 0x01, 0x00, 0x00,
+	// 387 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xc1, 0x6f, 0xd3, 0x30,
+	0x14, 0xc6, 0x97, 0xa5, 0xed, 0x9a, 0x57, 0xd2, 0x59, 0x86, 0x83, 0x99, 0x84, 0x14, 0x21, 0x21,
+	0xc2, 0x10, 0xa9, 0x34, 0x2e, 0x13, 0x08, 0x0e, 0xc0, 0x10, 0xd2, 0x2a, 0x11, 0xb9, 0xec, 0xc2,
+	0xcd, 0x4d, 0xac, 0xc4, 0x4a, 0x62, 0x47, 0x8e, 0x3d, 0x54, 0xfe, 0x0f, 0xfe, 0x5f, 0x64, 0x67,
+	0x2b, 0x53, 0x6f, 0xdc, 0x3e, 0x7f, 0xef, 0xf7, 0x3d, 0xcb, 0xef, 0x19, 0x62, 0xab, 0xdb, 0x95,
+	0xd5, 0x6d, 0xd6, 0x6b, 0x65, 0x14, 0x0e, 0xad, 0x6e, 0x9f, 0xff, 0x09, 0x21, 0xbc, 0xa1, 0x6b,
+	0xfc, 0x0c, 0x26, 0x8d, 0x90, 0x25, 0x39, 0x49, 0x82, 0x74, 0x79, 0x11, 0x65, 0x0e, 0xbb, 0x16,
+	0xb2, 0xa4, 0xde, 0xc6, 0xaf, 0x60, 0xee, 0x43, 0x85, 0x6a, 0x49, 0xe0, 0x91, 0xd8, 0x23, 0xf9,
+	0x9d, 0x49, 0xf7, 0x65, 0x8c, 0x61, 0x62, 0x07, 0xae, 0xc9, 0x71, 0x12, 0xa4, 0x11, 0xf5, 0xda,
+	0x79, 0xb5, 0x1a, 0x0c, 0x09, 0x47, 0xcf, 0x69, 0xe7, 0xf5, 0x4a, 0x1b, 0x32, 0x49, 0x82, 0x34,
+	0xa6, 0x5e, 0x7b, 0x8f, 0x99, 0x9a, 0x4c, 0x47, 0xce, 0x69, 0xfc, 0x1e, 0x16, 0x5c, 0xde, 0x0a,
+	0xad, 0x64, 0xc7, 0xa5, 0x21, 0xb3, 0x24, 0x4c, 0x17, 0x17, 0x4f, 0xfd, 0xed, 0x37, 0x74, 0x9d,
+	0x5d, 0xfd, 0xab, 0x5d, 0x49, 0xa3, 0x77, 0xf4, 0x21, 0x8d, 0x2f, 0x01, 0x7a, 0xa6, 0x59, 0xc7,
+	0x0d, 0xd7, 0x03, 0x99, 0xfb, 0x2c, 0xd9, 0x67, 0xf3, 0x7d, 0x69, 0x8c, 0x3e, 0x60, 0xcf, 0x3e,
+	0x02, 0x3a, 0x6c, 0x8d, 0x11, 0x84, 0x0d, 0xdf, 0xf9, 0x01, 0x44, 0xd4, 0x49, 0xfc, 0x04, 0xa6,
+	0xb7, 0xac, 0xb5, 0xfc, 0xee, 0xb5, 0xe3, 0xe1, 0xdd, 0xf1, 0x65, 0x70, 0xf6, 0x01, 0x4e, 0x0f,
+	0xda, 0xff, 0x4f, 0xfc, 0xfc, 0x35, 0x4c, 0xdc, 0xf8, 0xf1, 0x63, 0x38, 0xdd, 0xec, 0x64, 0x51,
+	0x6b, 0x25, 0xc5, 0x6f, 0x66, 0x84, 0x92, 0xe8, 0x08, 0x2f, 0x01, 0xbe, 0x2a, 0xfd, 0x8b, 0xe9,
+	0x52, 0xc8, 0x0a, 0x05, 0xe7, 0xdf, 0x61, 0x7e, 0xbf, 0x08, 0x1c, 0xc1, 0x74, 0xad, 0x0a, 0xd6,
+	0xa2, 0x23, 0x7c, 0x02, 0xe1, 0x66, 0xf3, 0x0d, 0x05, 0x18, 0x60, 0xf6, 0x45, 0x15, 0x0d, 0xd7,
+	0x68, 0xe1, 0xb2, 0xd7, 0x76, 0xcb, 0xb5, 0xe4, 0x86, 0x0f, 0xe8, 0x91, 0x83, 0x7e, 0x7c, 0xce,
+	0x51, 0xec, 0xa0, 0x5c, 0x95, 0x1d, 0x93, 0x68, 0xf9, 0xe9, 0xe5, 0xcf, 0x17, 0x95, 0x30, 0xb5,
+	0xdd, 0x66, 0x85, 0xea, 0x56, 0x9d, 0x35, 0xac, 0xe2, 0xf2, 0x8d, 0x50, 0xf7, 0x72, 0xd5, 0x37,
+	0x95, 0xfb, 0x49, 0xdb, 0x99, 0x5f, 0xfb, 0xdb, 0xbf, 0x03, 0x00, 0x15, 0x43, 0x29, 0x67, 0x5b,
+	0x02, 0x00, 0x00,
 }
@@ -154,6 +154,49 @@ func TestURLEnsureValidSSHLargePortInvalid(t *testing.T) {
 	}
 }
 
+func TestURLEnsureValidSSHInvalidParametersInvalid(t *testing.T) {
+	invalid := &URL{
+		Protocol: Protocol_SSH,
+		Host:     "washington",
+		Path:     "some/path",
+		Parameters: map[string]string{
+			SSHJumpParameter:         "bastion",
+			SSHProxyCommandParameter: "nc %h %p",
+		},
+	}
+	if invalid.EnsureValid() == nil {
+		t.Error("invalid URL classified as valid")
+	}
+}
+
+func TestURLEnsureValidSSHWithJump(t *testing.T) {
+	valid := &URL{
+		Protocol: Protocol_SSH,
+		Host:     "washington",
+		Path:     "some/path",
+		Parameters: map[string]string{
+			SSHJumpParameter: "bastion",
+		},
+	}
+	if err := valid.EnsureValid(); err != nil {
+		t.Error("valid URL classified as invalid:", err)
+	}
+}
+
+func TestURLEnsureValidDockerParametersInvalid(t *testing.T) {
+	invalid := &URL{
+		Protocol: Protocol_Docker,
+		Host:     "container",
+		Path:     "/some/path",
+		Parameters: map[string]string{
+			SSHJumpParameter: "bastion",
+		},
+	}
+	if invalid.EnsureValid() == nil {
+		t.Error("invalid URL classified as valid")
+	}
+}
+
 func TestURLEnsureValidSSHEmptyPathInvalid(t *testing.T) {
 	invalid := &URL{
 		Protocol: Protocol_SSH,
@@ -276,3 +319,185 @@ func TestURLEnsureValidDocker(t *testing.T) {
 		t.Error("valid URL classified as invalid")
 	}
 }
+
+func TestURLEnsureValidKubernetesUsernameInvalid(t *testing.T) {
+	invalid := &URL{
+		Protocol: Protocol_Kubernetes,
+		User:     "george",
+		Host:     "washington",
+		Path:     "/path",
+	}
+	if invalid.EnsureValid() == nil {
+		t.Error("invalid URL classified as valid")
+	}
+}
+
+func TestURLEnsureValidKubernetesPortInvalid(t *testing.T) {
+	invalid := &URL{
+		Protocol: Protocol_Kubernetes,
+		Host:     "washington",
+		Port:     50,
+		Path:     "/path",
+	}
+	if invalid.EnsureValid() == nil {
+		t.Error("invalid URL classified as valid")
+	}
+}
+
+func TestURLEnsureValidKubernetesPodSpecificationInvalid(t *testing.T) {
+	invalid := &URL{
+		Protocol: Protocol_Kubernetes,
+		Host:     "a/b/c/d",
+		Path:     "/path",
+	}
+	if invalid.EnsureValid() == nil {
+		t.Error("invalid URL classified as valid")
+	}
+}
+
+func TestURLEnsureValidKubernetesBadPathInvalid(t *testing.T) {
+	invalid := &URL{
+		Protocol: Protocol_Kubernetes,
+		Host:     "washington",
+		Path:     "$path",
+	}
+	if invalid.EnsureValid() == nil {
+		t.Error("invalid URL classified as valid")
+	}
+}
+
+func TestURLEnsureValidKubernetes(t *testing.T) {
+	valid := &URL{
+		Protocol: Protocol_Kubernetes,
+		Host:     "namespace/washington/container",
+		Path:     "~/path",
+	}
+	if err := valid.EnsureValid(); err != nil {
+		t.Error("valid URL classified as invalid")
+	}
+}
+
+func TestURLEnsureValidTCPUsernameInvalid(t *testing.T) {
+	invalid := &URL{
+		Protocol: Protocol_TCP,
+		User:     "george",
+		Host:     "washington",
+		Port:     9000,
+		Path:     "/path",
+	}
+	if invalid.EnsureValid() == nil {
+		t.Error("invalid URL classified as valid")
+	}
+}
+
+func TestURLEnsureValidTCPPortInvalid(t *testing.T) {
+	invalid := &URL{
+		Protocol: Protocol_TCP,
+		Host:     "washington",
+		Path:     "/path",
+	}
+	if invalid.EnsureValid() == nil {
+		t.Error("invalid URL classified as valid")
+	}
+}
+
+func TestURLEnsureValidTCPBadPathInvalid(t *testing.T) {
+	invalid := &URL{
+		Protocol: Protocol_TCP,
+		Host:     "washington",
+		Port:     9000,
+		Path:     "$path",
+	}
+	if invalid.EnsureValid() == nil {
+		t.Error("invalid URL classified as valid")
+	}
+}
+
+func TestURLEnsureValidTCP(t *testing.T) {
+	valid := &URL{
+		Protocol: Protocol_TCP,
+		Host:     "washington",
+		Port:     9000,
+		Path:     "/path",
+	}
+	if err := valid.EnsureValid(); err != nil {
+		t.Error("valid URL classified as invalid")
+	}
+}
+
+func TestURLEnsureValidPodmanEmptyContainerInvalid(t *testing.T) {
+	invalid := &URL{
+		Protocol: Protocol_Podman,
+		Path:     "/path",
+	}
+	if invalid.EnsureValid() == nil {
+		t.Error("invalid URL classified as valid")
+	}
+}
+
+func TestURLEnsureValidPodmanBadPathInvalid(t *testing.T) {
+	invalid := &URL{
+		Protocol: Protocol_Podman,
+		Host:     "washington",
+		Path:     "$path",
+	}
+	if invalid.EnsureValid() == nil {
+		t.Error("invalid URL classified as valid")
+	}
+}
+
+func TestURLEnsureValidPodman(t *testing.T) {
+	valid := &URL{
+		Protocol: Protocol_Podman,
+		User:     "george",
+		Host:     "washington",
+		Path:     "/path",
+	}
+	if err := valid.EnsureValid(); err != nil {
+		t.Error("valid URL classified as invalid")
+	}
+}
+
+func TestURLProxyCommand(t *testing.T) {
+	ssh := &URL{
+		Protocol:   Protocol_SSH,
+		Host:       "host",
+		Path:       "/path",
+		Parameters: map[string]string{SSHProxyCommandParameter: "nc %h %p"},
+	}
+	if command := ssh.ProxyCommand(); command != "nc %h %p" {
+		t.Error("SSH URL proxy command does not match expected:", command)
+	}
+	local := &URL{
+		Path:       "/path",
+		Parameters: map[string]string{SSHProxyCommandParameter: "nc %h %p"},
+	}
+	if command := local.ProxyCommand(); command != "" {
+		t.Error("non-SSH URL has proxy command:", command)
+	}
+	var missing *URL
+	if command := missing.ProxyCommand(); command != "" {
+		t.Error("nil URL has proxy command:", command)
+	}
+}
+
+func TestURLEndpointCommand(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		url      *URL
+		expected string
+	}{
+		{nil, ""},
+		{&URL{Kind: Kind_Forwarding, Path: "exec:nc localhost 22"}, "nc localhost 22"},
+		{&URL{Kind: Kind_Forwarding, Protocol: Protocol_Docker, Host: "container", Path: "stdio:cat"}, "cat"},
+		{&URL{Kind: Kind_Forwarding, Path: "tcp:localhost:8080"}, ""},
+		{&URL{Kind: Kind_Synchronization, Path: "exec:command"}, ""},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if command := testCase.url.EndpointCommand(); command != testCase.expected {
+			t.Errorf("test index %d: endpoint command does not match expected: %q != %q", i, command, testCase.expected)
+		}
+	}
+}
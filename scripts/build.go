@@ -435,13 +435,9 @@ func main() {
 		}
 	}
 
-	// Build agent binaries and the combined agent bundle.
-	log.Println("Building agent bundle...")
-	agentBundlePath := filepath.Join(buildPath, agent.BundleName)
-	agentBundle, err := NewArchiveBuilder(agentBundlePath)
-	if err != nil {
-		cmd.Fatal(errors.Wrap(err, "unable to create agent archive builder"))
-	}
+	// Build agent binaries and compute their digests for the bundle manifest.
+	var agentNames []string
+	agentManifest := make(agent.BundleManifest)
 	for _, target := range targets {
 		// Skip agent targets that aren't appropriate for this build mode.
 		if mode == "slim" && target.Cross() {
@@ -458,12 +454,48 @@ func main() {
 
 		// Build the agent.
 		if err := target.Build(agentPackage, agentBuildPath); err != nil {
-			agentBundle.Close()
 			cmd.Fatal(errors.Wrap(err, "unable to build agent"))
 		}
 
-		// Add it to the bundle.
-		if err := agentBundle.Add(target.Name(), agentBuildPath, 0700); err != nil {
+		// Compute the agent's digest.
+		digest, err := agent.DigestFile(agentBuildPath)
+		if err != nil {
+			cmd.Fatal(errors.Wrap(err, "unable to compute agent digest"))
+		}
+
+		// Record the agent.
+		agentNames = append(agentNames, target.Name())
+		agentManifest[target.Name()] = digest
+	}
+
+	// Write the agent bundle manifest.
+	agentManifestPath := filepath.Join(agentBuildSubdirectoryPath, agent.BundleManifestName)
+	agentManifestFile, err := os.Create(agentManifestPath)
+	if err != nil {
+		cmd.Fatal(errors.Wrap(err, "unable to create agent bundle manifest"))
+	}
+	if err := agentManifest.Write(agentManifestFile); err != nil {
+		agentManifestFile.Close()
+		cmd.Fatal(errors.Wrap(err, "unable to write agent bundle manifest"))
+	}
+	if err := agentManifestFile.Close(); err != nil {
+		cmd.Fatal(errors.Wrap(err, "unable to close agent bundle manifest"))
+	}
+
+	// Build the combined agent bundle. The manifest must be the first entry.
+	log.Println("Building agent bundle...")
+	agentBundlePath := filepath.Join(buildPath, agent.BundleName)
+	agentBundle, err := NewArchiveBuilder(agentBundlePath)
+	if err != nil {
+		cmd.Fatal(errors.Wrap(err, "unable to create agent archive builder"))
+	}
+	if err := agentBundle.Add(agent.BundleManifestName, agentManifestPath, 0600); err != nil {
+		agentBundle.Close()
+		cmd.Fatal(errors.Wrap(err, "unable to add manifest to bundle"))
+	}
+	for _, name := range agentNames {
+		agentBuildPath := filepath.Join(agentBuildSubdirectoryPath, name)
+		if err := agentBundle.Add(name, agentBuildPath, 0700); err != nil {
 			agentBundle.Close()
 			cmd.Fatal(errors.Wrap(err, "unable to add agent to bundle"))
 		}